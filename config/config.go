@@ -1,9 +1,15 @@
 package config
 
 import (
+	"e-complaint-api/constants"
+	"e-complaint-api/drivers/file_storage"
 	"e-complaint-api/drivers/mysql"
+	"e-complaint-api/drivers/search_engine"
+	"e-complaint-api/llm"
 	"log"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -24,3 +30,88 @@ func InitConfigMySQL() mysql.Config {
 		DB_PORT:     os.Getenv("DB_PORT"),
 	}
 }
+
+// InitConfigFileStorage defaults to the Google Cloud Storage bucket the API has always
+// used, set STORAGE_DRIVER to "local" to run without any cloud credentials.
+func InitConfigFileStorage() file_storage.Config {
+	config := file_storage.Config{
+		DRIVER:          os.Getenv("STORAGE_DRIVER"),
+		LOCAL_DIR:       os.Getenv("STORAGE_LOCAL_DIR"),
+		GCS_CREDENTIALS: os.Getenv("GCS_CREDENTIALS"),
+		GCS_BUCKET:      os.Getenv("GCS_BUCKET"),
+		S3_ENDPOINT:     os.Getenv("S3_ENDPOINT"),
+		S3_REGION:       os.Getenv("S3_REGION"),
+		S3_BUCKET:       os.Getenv("S3_BUCKET"),
+		S3_ACCESS_KEY:   os.Getenv("S3_ACCESS_KEY"),
+		S3_SECRET_KEY:   os.Getenv("S3_SECRET_KEY"),
+	}
+
+	if config.DRIVER == "" {
+		config.DRIVER = "gcs"
+	}
+	if config.GCS_BUCKET == "" {
+		config.GCS_BUCKET = "e-complaint-assets"
+	}
+	if config.LOCAL_DIR == "" {
+		config.LOCAL_DIR = "./uploads"
+	}
+
+	return config
+}
+
+// InitConfigSearchEngine defaults to the FULLTEXT indexes of the database.
+func InitConfigSearchEngine() search_engine.Config {
+	config := search_engine.Config{
+		DRIVER: os.Getenv("SEARCH_DRIVER"),
+	}
+
+	if config.DRIVER == "" {
+		config.DRIVER = "mysql"
+	}
+
+	return config
+}
+
+// InitConfigLLM defaults to the chat completions API of OpenAI. LLM_BASE_URL points it
+// at any server with the same API, like a local model server, and LLM_DRIVER=fake
+// answers without a model.
+func InitConfigLLM() llm.Config {
+	config := llm.Config{
+		DRIVER:      os.Getenv("LLM_DRIVER"),
+		BASE_URL:    os.Getenv("LLM_BASE_URL"),
+		API_KEY:     os.Getenv("LLM_API_KEY"),
+		MODEL:       os.Getenv("LLM_MODEL"),
+		TEMPERATURE: constants.LLMDefaultTemperature,
+		MAX_TOKENS:  constants.LLMDefaultMaxTokens,
+		TIMEOUT:     constants.LLMDefaultTimeout,
+		MAX_RETRIES: constants.LLMDefaultMaxRetries,
+		RETRY_DELAY: constants.LLMDefaultRetryDelay,
+	}
+
+	if config.DRIVER == "" {
+		config.DRIVER = "openai"
+	}
+	if config.API_KEY == "" {
+		config.API_KEY = os.Getenv("OPENAI_API_KEY")
+	}
+	if config.MODEL == "" {
+		config.MODEL = constants.LLMDefaultModel
+	}
+	if temperature, err := strconv.ParseFloat(os.Getenv("LLM_TEMPERATURE"), 32); err == nil {
+		config.TEMPERATURE = float32(temperature)
+	}
+	if maxTokens, err := strconv.Atoi(os.Getenv("LLM_MAX_TOKENS")); err == nil {
+		config.MAX_TOKENS = maxTokens
+	}
+	if timeout, err := time.ParseDuration(os.Getenv("LLM_TIMEOUT")); err == nil {
+		config.TIMEOUT = timeout
+	}
+	if maxRetries, err := strconv.Atoi(os.Getenv("LLM_MAX_RETRIES")); err == nil {
+		config.MAX_RETRIES = maxRetries
+	}
+	if retryDelay, err := time.ParseDuration(os.Getenv("LLM_RETRY_DELAY")); err == nil {
+		config.RETRY_DELAY = retryDelay
+	}
+
+	return config
+}
@@ -0,0 +1,30 @@
+package constants
+
+// Types of the members of a chat room, which are also the sender types of messages.
+const (
+	ChatMemberUser  = "user"
+	ChatMemberAdmin = "admin"
+)
+
+// Types of the events pushed to the members of a chat room.
+const (
+	ChatEventMessage        = "message"
+	ChatEventMessageUpdated = "message_updated"
+	ChatEventMessageDeleted = "message_deleted"
+	ChatEventRoomStatus     = "room_status"
+	ChatEventTyping         = "typing"
+	ChatEventRead           = "read"
+	ChatEventError          = "error"
+)
+
+// ChatEventBuffer is the number of events a chat connection may fall behind before
+// events are dropped for it.
+const ChatEventBuffer = 64
+
+// Statuses of a chat room. Closed rooms take no new messages, archived rooms are
+// also left out of room lists unless asked for.
+const (
+	RoomStatusOpen     = "open"
+	RoomStatusClosed   = "closed"
+	RoomStatusArchived = "archived"
+)
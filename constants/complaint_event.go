@@ -0,0 +1,20 @@
+package constants
+
+import "time"
+
+// Types of the events pushed on the timeline stream of a complaint.
+const (
+	ComplaintEventProcess        = "process"
+	ComplaintEventProcessDeleted = "process_deleted"
+	ComplaintEventDiscussion     = "discussion"
+	ComplaintEventLikes          = "likes"
+	ComplaintEventEvidence       = "evidence"
+)
+
+// ComplaintEventBacklogLimit is the number of missed events loaded per query when a
+// stream resumes from a Last-Event-ID.
+const ComplaintEventBacklogLimit = 100
+
+// ComplaintEventKeepAlive is how often a comment is written to idle complaint streams
+// so proxies do not close them.
+const ComplaintEventKeepAlive = 30 * time.Second
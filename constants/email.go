@@ -0,0 +1,21 @@
+package constants
+
+// Events users get an email for. Users can opt out of each of them.
+const (
+	EmailEventComplaintReceived   = "complaint_received"
+	EmailEventComplaintVerified   = "complaint_verified"
+	EmailEventComplaintOnProgress = "complaint_on_progress"
+	EmailEventComplaintFinished   = "complaint_finished"
+	EmailEventComplaintRejected   = "complaint_rejected"
+	EmailEventDiscussionReply     = "discussion_reply"
+)
+
+// EmailEvents lists every event users get an email for.
+var EmailEvents = []string{
+	EmailEventComplaintReceived,
+	EmailEventComplaintVerified,
+	EmailEventComplaintOnProgress,
+	EmailEventComplaintFinished,
+	EmailEventComplaintRejected,
+	EmailEventDiscussionReply,
+}
@@ -16,6 +16,7 @@ var (
 	ErrFailedToCreateClientGCS          = errors.New("failed to create client gcs")
 	ErrFailedToUploadObject             = errors.New("failed to upload object")
 	ErrFailedToDeleteObject             = errors.New("failed to delete object")
+	ErrInvalidStorageDriver             = errors.New("invalid storage driver")
 	ErrRegencyNotFound                  = errors.New("regency not found")
 	ErrCategoryNotFound                 = errors.New("category not found")
 	ErrMaxFileSizeExceeded              = errors.New("max file size exceeded")
@@ -36,6 +37,7 @@ var (
 	ErrIDMustBeFilled                   = errors.New("id must be filled")
 	ErrComplaintProcessNotFound         = errors.New("complaint process not found")
 	ErrComplaintProcessCannotBeDeleted  = errors.New("complaint process cannot be deleted")
+	ErrComplaintProcessCannotBeUpdated  = errors.New("complaint process cannot be updated")
 	ErrCommentCannotBeEmpty             = errors.New("comment cannot be empty")
 	ErrNewsNotFound                     = errors.New("news not found")
 	ErrUserNotFound                     = errors.New("user not found")
@@ -53,4 +55,76 @@ var (
 	ErrDiscussionNotFound               = errors.New("discussion not found")
 	ErrPasswordMustBeAtLeast8Characters = errors.New("password must be at least 8 characters")
 	ErrCategoryHasBeenUsed              = errors.New("category has been used")
+	ErrNotificationNotFound             = errors.New("notification not found")
+	ErrInvalidStatusTransition          = errors.New("invalid status transition")
+	ErrComplaintSLANotFound             = errors.New("complaint sla not found")
+	ErrComplaintSLAAlreadyExists        = errors.New("complaint sla for this category and status already exists")
+	ErrTargetDaysMustBePositive         = errors.New("target days must be greater than 0")
+	ErrComplaintAlreadyAssigned         = errors.New("complaint already assigned to this admin")
+	ErrComplaintNotAssignedToYou        = errors.New("complaint is not assigned to you")
+	ErrAssignmentPoolNotFound           = errors.New("assignment pool not found")
+	ErrRoleNotFound                     = errors.New("role not found")
+	ErrRoleAlreadyExists                = errors.New("role already exists")
+	ErrInvalidPermission                = errors.New("invalid permission")
+	ErrForbidden                        = errors.New("you don't have permission to access this resource")
+	ErrComplaintOutOfScope              = errors.New("complaint is outside of your scope")
+	ErrInvalidRefreshToken              = errors.New("invalid refresh token")
+	ErrRefreshTokenExpired              = errors.New("refresh token expired")
+	ErrSessionRevoked                   = errors.New("session has been revoked or expired")
+	ErrFileNotFound                     = errors.New("file not found")
+	ErrInvalidDownloadURL               = errors.New("invalid download url")
+	ErrDownloadURLExpired               = errors.New("download url expired")
+	ErrImageDimensionsTooLarge          = errors.New("image dimensions too large")
+	ErrInvalidCoordinates               = errors.New("invalid coordinates")
+	ErrInvalidGeometry                  = errors.New("invalid geometry")
+	ErrInvalidRadius                    = errors.New("radius must be greater than 0 and at most 50 km")
+	ErrLocationOutsideRegency           = errors.New("location is outside of the selected regency")
+	ErrRegencyHasNoBoundary             = errors.New("location cannot be checked, the selected regency has no boundary")
+	ErrComplaintAlreadyMerged           = errors.New("complaint already merged into another complaint")
+	ErrCannotMergeComplaintIntoItself   = errors.New("complaint cannot be merged into itself")
+	ErrCannotMergePrivateIntoPublic     = errors.New("private complaint cannot be merged into a public complaint")
+	ErrMergedStatusNotAllowed           = errors.New("status Digabung can only be set by merging complaints")
+	ErrInvalidSearchDriver              = errors.New("invalid search driver")
+	ErrInvalidLLMDriver                 = errors.New("invalid llm driver")
+	ErrEmptyLLMResponse                 = errors.New("language model returned no answer")
+	ErrInvalidSearchCollection          = errors.New("invalid search type")
+	ErrSearchQueryMustBeFilled          = errors.New("search query must be filled")
+	ErrInvalidCursor                    = errors.New("invalid cursor")
+	ErrInvalidFilterValue               = errors.New("invalid filter value")
+	ErrFilterNotAllowed                 = errors.New("filter not allowed")
+	ErrInvalidRange                     = errors.New("range start must not be after range end")
+	ErrInvalidSortBy                    = errors.New("invalid sort by")
+	ErrInvalidSortType                  = errors.New("sort type must be asc or desc")
+	ErrInvalidExportFormat              = errors.New("export format must be xlsx or csv")
+	ErrImportFileMustBeFilled           = errors.New("import file must be filled")
+	ErrInvalidImportMode                = errors.New("import mode must be dry_run, partial or all_or_nothing")
+	ErrImportHasInvalidRows             = errors.New("import has invalid rows, nothing was imported")
+	ErrInvalidComplaintType             = errors.New("type must be public or private")
+	ErrInvalidDateFormat                = errors.New("date must be in DD-MM-YYYY format")
+	ErrImportKeyTooLong                 = errors.New("import key must be at most 64 characters")
+	ErrJobNotFound                      = errors.New("job not found")
+	ErrJobNotRetryable                  = errors.New("only dead jobs can be retried")
+	ErrInvalidJobStatus                 = errors.New("job status must be pending, running, done or dead")
+	ErrJobTypeNotRegistered             = errors.New("job type has no handler")
+	ErrExportNotReady                   = errors.New("export is not ready yet")
+	ErrInvalidEmailEvent                = errors.New("invalid email event")
+	ErrRoomNotFound                     = errors.New("room not found")
+	ErrNotRoomMember                    = errors.New("you are not a member of this room")
+	ErrRoomNameRequired                 = errors.New("room name is required")
+	ErrRoomMemberRequired               = errors.New("complaint_id, or admin_id for users and user_id for admins, is required")
+	ErrRoomNotJoinable                  = errors.New("only complaint rooms can be joined")
+	ErrMessageRequired                  = errors.New("message is required")
+	ErrInvalidChatEvent                 = errors.New("chat event type must be message, typing or read")
+	ErrMessageNotFound                  = errors.New("message not found")
+	ErrRoomClosed                       = errors.New("room is closed")
+	ErrInvalidRoomStatus                = errors.New("room status must be open, closed or archived")
+	ErrInvalidLastEventID               = errors.New("last event id must be a number")
+	ErrWebhookNotFound                  = errors.New("webhook not found")
+	ErrWebhookDeliveryNotFound          = errors.New("webhook delivery not found")
+	ErrInvalidWebhookURL                = errors.New("webhook url must be an absolute http or https url")
+	ErrWebhookAddressNotAllowed         = errors.New("webhook url must point at a public address")
+	ErrInvalidWebhookEvent              = errors.New("invalid webhook event")
+	ErrWebhookEventsRequired            = errors.New("webhook must be subscribed to at least one event")
+	ErrAdminCannotProcessComplaints     = errors.New("admin doesn't have permission to process complaints")
+	ErrOutsideAdminScope                = errors.New("category or regency is outside of the admin's scope")
 )
@@ -0,0 +1,4 @@
+package constants
+
+// ExportBatchSize is the number of rows an export loads from the database at a time.
+const ExportBatchSize = 500
@@ -0,0 +1,11 @@
+package constants
+
+// Radius in kilometers of the near=lat,lng complaint filter.
+const (
+	DefaultNearRadius = 5.0
+	MaxNearRadius     = 50.0
+)
+
+// MaxGeoJSONFeatures is the number of complaints a GeoJSON export holds at most, the
+// newest ones are kept.
+const MaxGeoJSONFeatures = 5000
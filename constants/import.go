@@ -0,0 +1,13 @@
+package constants
+
+// Modes of a complaint import. A dry run only validates the file, a partial import
+// imports the valid rows and an all or nothing import imports the file only when every
+// row is valid.
+const (
+	ImportModeDryRun       = "dry_run"
+	ImportModePartial      = "partial"
+	ImportModeAllOrNothing = "all_or_nothing"
+)
+
+// MaxImportKeyLength is the length of the longest idempotency key a row can carry.
+const MaxImportKeyLength = 64
@@ -0,0 +1,36 @@
+package constants
+
+import "time"
+
+// Statuses of a background job. A job that failed and still has attempts left goes
+// back to pending until its next attempt, a job without attempts left is dead.
+const (
+	JobStatusPending = "pending"
+	JobStatusRunning = "running"
+	JobStatusDone    = "done"
+	JobStatusDead    = "dead"
+)
+
+// Types of the background jobs the application runs.
+const (
+	JobTypeEmailOTP               = "email.otp"
+	JobTypeEmailComplaintProcess  = "email.complaint_process"
+	JobTypeEmailDiscussionReply   = "email.discussion_reply"
+	JobTypeNotifyComplaintProcess = "notification.complaint_process"
+	JobTypeNotifyDiscussion       = "notification.discussion"
+	JobTypeNotifyLike             = "notification.like"
+	JobTypeNotifyComplaintMerged  = "notification.complaint_merged"
+	JobTypeComplaintExport        = "complaint.export"
+	JobTypeComplaintImport        = "complaint.import"
+	JobTypeWebhookDelivery        = "webhook.delivery"
+)
+
+// JobMaxAttempts is the number of times a job is run before it is dead.
+const JobMaxAttempts = 5
+
+// A failed job is retried after JobRetryBaseDelay, doubling with every attempt up to
+// JobRetryMaxDelay.
+const (
+	JobRetryBaseDelay = 30 * time.Second
+	JobRetryMaxDelay  = time.Hour
+)
@@ -0,0 +1,24 @@
+package constants
+
+import "time"
+
+// Roles of the messages sent to a language model.
+const (
+	LLMRoleSystem = "system"
+	LLMRoleUser   = "user"
+)
+
+// LLMPersona is the first message of every conversation with the language model.
+const LLMPersona = "Anda adalah seorang customer service AI dari sebuah aplikasi bernama KeluhProv Banten, yang merupakan aplikasi pengaduan masyarakat di Provinsi Banten"
+
+// Defaults of the language model settings that are not set in the environment. A
+// request waits for the model for about 20 seconds at most, two attempts and the
+// delay between them.
+const (
+	LLMDefaultModel       = "gpt-3.5-turbo"
+	LLMDefaultTemperature = 0.7
+	LLMDefaultMaxTokens   = 1024
+	LLMDefaultTimeout     = 10 * time.Second
+	LLMDefaultMaxRetries  = 1
+	LLMDefaultRetryDelay  = 500 * time.Millisecond
+)
@@ -0,0 +1,7 @@
+package constants
+
+// Number of rows on a page of cursor pagination.
+const (
+	DefaultCursorLimit = 10
+	MaxCursorLimit     = 100
+)
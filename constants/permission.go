@@ -0,0 +1,32 @@
+package constants
+
+const (
+	PermissionAdminRead        = "admin:read"
+	PermissionUserRead         = "user:read"
+	PermissionComplaintProcess = "complaint:process"
+	PermissionComplaintAssign  = "complaint:assign"
+	PermissionComplaintImport  = "complaint:import"
+	PermissionComplaintExport  = "complaint:export"
+	PermissionCategoryManage   = "category:manage"
+	PermissionNewsManage       = "news:manage"
+	PermissionScheduleManage   = "schedule:manage"
+	PermissionDashboardRead    = "dashboard:read"
+	PermissionJobManage        = "job:manage"
+	PermissionWebhookManage    = "webhook:manage"
+)
+
+// Permissions lists every permission that can be granted to a role.
+var Permissions = []string{
+	PermissionAdminRead,
+	PermissionUserRead,
+	PermissionComplaintProcess,
+	PermissionComplaintAssign,
+	PermissionComplaintImport,
+	PermissionComplaintExport,
+	PermissionCategoryManage,
+	PermissionNewsManage,
+	PermissionScheduleManage,
+	PermissionDashboardRead,
+	PermissionJobManage,
+	PermissionWebhookManage,
+}
@@ -0,0 +1,16 @@
+package constants
+
+// Collections of the full-text search.
+const (
+	SearchComplaints  = "complaints"
+	SearchNews        = "news"
+	SearchDiscussions = "discussions"
+)
+
+// Number of search hits returned by the search endpoint and the most hits a list of
+// complaints or news is narrowed down to.
+const (
+	DefaultSearchLimit = 20
+	MaxSearchLimit     = 100
+	MaxSearchHits      = 1000
+)
@@ -0,0 +1,19 @@
+package constants
+
+// Folders of the file storage that uploads are stored in. Evidence keeps the folder it
+// was written to before the file storage existed, so old uploads are still found.
+const (
+	FolderProfilePhotos  = "profile-photos/"
+	FolderComplaintFiles = "complaint-files/"
+	FolderNewsFiles      = "news-files/"
+	FolderEvidenceFiles  = "bukti_unggah/"
+	FolderExports        = "exports/"
+	FolderImports        = "imports/"
+)
+
+// PublicFolders can be downloaded by anyone, files in other folders are only handed
+// out through signed download URLs.
+var PublicFolders = []string{
+	FolderProfilePhotos,
+	FolderNewsFiles,
+}
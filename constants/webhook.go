@@ -0,0 +1,35 @@
+package constants
+
+import "time"
+
+// Events admins can subscribe webhooks to.
+const (
+	WebhookEventComplaintCreated       = "complaint.created"
+	WebhookEventComplaintStatusChanged = "complaint.status_changed"
+	WebhookEventDiscussionCreated      = "discussion.created"
+	WebhookEventEvidenceUploaded       = "evidence.uploaded"
+)
+
+// WebhookEvents lists every event a webhook can be subscribed to.
+var WebhookEvents = []string{
+	WebhookEventComplaintCreated,
+	WebhookEventComplaintStatusChanged,
+	WebhookEventDiscussionCreated,
+	WebhookEventEvidenceUploaded,
+}
+
+// Statuses of a webhook delivery. A delivery stays pending while its attempts are
+// retried and fails once it runs out of attempts.
+const (
+	WebhookDeliveryPending = "pending"
+	WebhookDeliverySuccess = "success"
+	WebhookDeliveryFailed  = "failed"
+)
+
+// WebhookTimeout is how long a webhook endpoint has to answer a delivery, unless the
+// WEBHOOK_TIMEOUT env var says otherwise.
+const WebhookTimeout = 10 * time.Second
+
+// WebhookResponseLimit is the number of bytes of the response of a webhook endpoint
+// kept in the delivery log.
+const WebhookResponseLimit = 1024
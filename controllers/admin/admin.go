@@ -73,17 +73,12 @@ func (ac *AdminController) GetAdminByID(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, base.NewErrorResponse(constants.ErrInvalidIDFormat.Error()))
 	}
 
-	userRole, err := utils.GetRoleFromJWT(c)
+	principal, err := utils.GetPrincipal(c)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, base.NewErrorResponse(err.Error()))
 	}
 
-	userID, err := utils.GetIDFromJWT(c)
-	if err != nil {
-		return c.JSON(http.StatusInternalServerError, base.NewErrorResponse(err.Error()))
-	}
-
-	if userRole != "super_admin" && userID != id {
+	if principal.Role != "super_admin" && principal.ID != id {
 		return c.JSON(http.StatusUnauthorized, base.NewErrorResponse(constants.ErrUnauthorized.Error()))
 	}
 
@@ -107,17 +102,12 @@ func (ac *AdminController) DeleteAdmin(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, base.NewErrorResponse("Invalid ID format"))
 	}
 
-	jwtID, err := utils.GetIDFromJWT(c)
-	if err != nil {
-		return c.JSON(http.StatusInternalServerError, base.NewErrorResponse(err.Error()))
-	}
-
-	userRole, err := utils.GetRoleFromJWT(c)
+	principal, err := utils.GetPrincipal(c)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, base.NewErrorResponse(err.Error()))
 	}
 
-	if userRole == "super_admin" && id == jwtID {
+	if principal.Role == "super_admin" && id == principal.ID {
 		return c.JSON(http.StatusUnauthorized, base.NewErrorResponse(constants.ErrSuperAdminCannotDeleteThemselves.Error()))
 	}
 
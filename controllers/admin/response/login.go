@@ -8,6 +8,7 @@ type Login struct {
 	Email        string `json:"email"`
 	IsSuperAdmin bool   `json:"is_super_admin"`
 	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
 }
 
 func LoginFromEntitiesToResponse(admin *entities.Admin) *Login {
@@ -17,5 +18,6 @@ func LoginFromEntitiesToResponse(admin *entities.Admin) *Login {
 		Email:        admin.Email,
 		IsSuperAdmin: admin.IsSuperAdmin,
 		Token:        admin.Token,
+		RefreshToken: admin.RefreshToken,
 	}
 }
@@ -0,0 +1,106 @@
+package attachment
+
+import (
+	"e-complaint-api/constants"
+	"e-complaint-api/controllers/attachment/response"
+	"e-complaint-api/controllers/base"
+	"e-complaint-api/entities"
+	"e-complaint-api/utils"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type AttachmentController struct {
+	attachmentUseCase entities.AttachmentUseCaseInterface
+}
+
+func NewAttachmentController(attachmentUseCase entities.AttachmentUseCaseInterface) *AttachmentController {
+	return &AttachmentController{
+		attachmentUseCase: attachmentUseCase,
+	}
+}
+
+func (ac *AttachmentController) GetComplaintFileURL(c echo.Context) error {
+	principal, err := utils.GetPrincipal(c)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	fileID, err := strconv.Atoi(c.Param("file-id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, base.NewErrorResponse(constants.ErrInvalidIDFormat.Error()))
+	}
+
+	attachmentURL, err := ac.attachmentUseCase.GetComplaintFileURL(c.Param("complaint-id"), fileID, c.QueryParam("thumbnail") == "true", principal.ID, principal.Role)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	return c.JSON(http.StatusOK, base.NewSuccessResponse("Success Get Download URL", response.URLFromEntitiesToResponse(&attachmentURL)))
+}
+
+func (ac *AttachmentController) GetEvidenceURL(c echo.Context) error {
+	principal, err := utils.GetPrincipal(c)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, base.NewErrorResponse(constants.ErrInvalidIDFormat.Error()))
+	}
+
+	attachmentURL, err := ac.attachmentUseCase.GetEvidenceURL(id, principal.ID, principal.Role)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	return c.JSON(http.StatusOK, base.NewSuccessResponse("Success Get Download URL", response.URLFromEntitiesToResponse(&attachmentURL)))
+}
+
+// Download streams a file behind a signed download URL. The signature is the only
+// credential, so the route does not require a token.
+func (ac *AttachmentController) Download(c echo.Context) error {
+	filePath := c.QueryParam("path")
+	expires, err := strconv.ParseInt(c.QueryParam("expires"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusForbidden, base.NewErrorResponse(constants.ErrInvalidDownloadURL.Error()))
+	}
+
+	file, err := ac.attachmentUseCase.Open(filePath, expires, c.QueryParam("signature"))
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	c.Response().Header().Set("Cache-Control", "private, no-store")
+	return stream(c, filePath, file)
+}
+
+// DownloadPublic streams a file from one of the public folders such as profile photos
+// and news images.
+func (ac *AttachmentController) DownloadPublic(c echo.Context) error {
+	filePath := c.Param("*")
+
+	file, err := ac.attachmentUseCase.OpenPublic(filePath)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	return stream(c, filePath, file)
+}
+
+func stream(c echo.Context, filePath string, file io.ReadCloser) error {
+	defer file.Close()
+
+	contentType := mime.TypeByExtension(path.Ext(filePath))
+	if contentType == "" {
+		contentType = echo.MIMEOctetStream
+	}
+
+	return c.Stream(http.StatusOK, contentType, file)
+}
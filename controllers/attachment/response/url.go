@@ -0,0 +1,15 @@
+package response
+
+import "e-complaint-api/entities"
+
+type URL struct {
+	URL       string `json:"url"`
+	ExpiresAt string `json:"expires_at"`
+}
+
+func URLFromEntitiesToResponse(data *entities.AttachmentURL) *URL {
+	return &URL{
+		URL:       data.URL,
+		ExpiresAt: data.ExpiresAt.Format("2 January 2006 15:04:05"),
+	}
+}
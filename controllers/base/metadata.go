@@ -9,15 +9,19 @@ type Pagination struct {
 	PrevPage         int `json:"prev_page"`
 }
 
+// Metadata describes either a page of page/limit pagination, with the total and the
+// pagination, or a page of cursor pagination, with the cursor of the next page that is
+// left out on the last page.
 type Metadata struct {
-	TotalData  int `json:"total_data"`
-	Pagination Pagination
+	TotalData  *int        `json:"total_data,omitempty"`
+	Pagination *Pagination `json:"Pagination,omitempty"`
+	NextCursor string      `json:"next_cursor,omitempty"`
 }
 
 func NewMetadata(totalData int, totalDataPerPage int, firstPage int, lastPage int, currentPage int, nextPage int, prevPage int) *Metadata {
 	return &Metadata{
-		TotalData: totalData,
-		Pagination: Pagination{
+		TotalData: &totalData,
+		Pagination: &Pagination{
 			TotalDataPerPage: totalDataPerPage,
 			FirstPage:        firstPage,
 			LastPage:         lastPage,
@@ -27,3 +31,9 @@ func NewMetadata(totalData int, totalDataPerPage int, firstPage int, lastPage in
 		},
 	}
 }
+
+func NewCursorMetadata(nextCursor string) *Metadata {
+	return &Metadata{
+		NextCursor: nextCursor,
+	}
+}
@@ -3,6 +3,7 @@ package base
 type BaseErrorResponse struct {
 	Status  bool   `json:"status"`
 	Message string `json:"message"`
+	Data    any    `json:"data,omitempty"`
 }
 
 func NewErrorResponse(message string) *BaseErrorResponse {
@@ -11,3 +12,11 @@ func NewErrorResponse(message string) *BaseErrorResponse {
 		Message: message,
 	}
 }
+
+func NewErrorResponseWithData(message string, data any) *BaseErrorResponse {
+	return &BaseErrorResponse{
+		Status:  false,
+		Message: message,
+		Data:    data,
+	}
+}
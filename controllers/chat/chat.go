@@ -1,7 +1,10 @@
 package chat
 
 import (
+	"e-complaint-api/constants"
+	"e-complaint-api/controllers/base"
 	"e-complaint-api/entities"
+	"e-complaint-api/utils"
 	"github.com/labstack/echo/v4"
 	"log"
 	"net/http"
@@ -9,17 +12,61 @@ import (
 )
 
 type ChatController struct {
-	chatUsecase entities.ChatUseCaseInterface
+	chatUsecase      entities.ChatUseCaseInterface
+	complaintUseCase entities.ComplaintUseCaseInterface
+	roleUseCase      entities.RoleUseCaseInterface
 }
 
-func NewChatController(chatUsecase entities.ChatUseCaseInterface) *ChatController {
-	return &ChatController{chatUsecase: chatUsecase}
+func NewChatController(chatUsecase entities.ChatUseCaseInterface, complaintUseCase entities.ComplaintUseCaseInterface, roleUseCase entities.RoleUseCaseInterface) *ChatController {
+	return &ChatController{chatUsecase: chatUsecase, complaintUseCase: complaintUseCase, roleUseCase: roleUseCase}
+}
+
+// errorResponse responds with the error, except for internal errors, which are only
+// logged and answered with message
+func errorResponse(ctx echo.Context, err error, message string) error {
+	code := utils.ConvertResponseCode(err)
+	if code == http.StatusInternalServerError {
+		log.Println(message+":", err)
+		return ctx.JSON(code, map[string]string{"error": message})
+	}
+
+	return ctx.JSON(code, map[string]string{"error": err.Error()})
+}
+
+// ensureCanHandle lets an admin chat about a complaint only when they are its assignee
+// or it is in their scope. Super admins can chat about every complaint, and whether a
+// user reported it is up to the use case.
+func (c *ChatController) ensureCanHandle(principal entities.Principal, complaintID string) error {
+	if principal.Role != "admin" {
+		return nil
+	}
+
+	complaint, err := c.complaintUseCase.GetByID(complaintID)
+	if err != nil {
+		return err
+	}
+	if complaint.AssigneeID != nil && *complaint.AssigneeID == principal.ID {
+		return nil
+	}
+
+	return c.roleUseCase.EnsureInScope(principal.ID, principal.Role, complaint)
+}
+
+// senderType is the sender type of the messages of the principal
+func senderType(principal entities.Principal) string {
+	if principal.Role == "user" {
+		return constants.ChatMemberUser
+	}
+	return constants.ChatMemberAdmin
 }
 
 func (c *ChatController) CreateRoom(ctx echo.Context) error {
 	// Data model untuk body input
 	var request struct {
-		Name string `json:"name" form:"name"`
+		Name        string  `json:"name" form:"name"`
+		ComplaintID *string `json:"complaint_id" form:"complaint_id"`
+		AdminID     *int    `json:"admin_id" form:"admin_id"`
+		UserID      *int    `json:"user_id" form:"user_id"`
 	}
 
 	// Parsing data dari body (mendukung JSON, form-data, x-www-form-urlencoded)
@@ -27,30 +74,104 @@ func (c *ChatController) CreateRoom(ctx echo.Context) error {
 		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid input"})
 	}
 
-	if request.Name == "" {
-		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "Room name is required"})
+	principal, err := utils.GetPrincipal(ctx)
+	if err != nil {
+		return errorResponse(ctx, err, "Failed to create room")
+	}
+
+	// Anggota lain room adalah admin bagi user dan user bagi admin
+	memberID := request.AdminID
+	if senderType(principal) == constants.ChatMemberAdmin {
+		memberID = request.UserID
+	}
+
+	if request.ComplaintID != nil {
+		if err := c.ensureCanHandle(principal, *request.ComplaintID); err != nil {
+			return errorResponse(ctx, err, "Failed to create room")
+		}
 	}
 
 	// Buat room menggunakan data dari body
-	room, err := c.chatUsecase.CreateRoom(request.Name)
+	room, err := c.chatUsecase.CreateRoom(request.Name, request.ComplaintID, memberID, principal)
 	if err != nil {
-		log.Println("Failed to create room:", err)
-		return ctx.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to create room"})
+		return errorResponse(ctx, err, "Failed to create room")
 	}
 
 	return ctx.JSON(http.StatusOK, room)
 }
 
-func (c *ChatController) GetAllRooms(ctx echo.Context) error {
-	rooms, err := c.chatUsecase.GetAllRooms()
+// JoinRoom adds the calling admin to the room of a complaint they can handle
+func (c *ChatController) JoinRoom(ctx echo.Context) error {
+	roomIDInt, err := strconv.Atoi(ctx.Param("room-id"))
 	if err != nil {
-		log.Println("Failed to fetch rooms:", err)
-		return ctx.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to fetch rooms"})
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid Room ID"})
+	}
+
+	principal, err := utils.GetPrincipal(ctx)
+	if err != nil {
+		return errorResponse(ctx, err, "Failed to join room")
+	}
+
+	room, err := c.chatUsecase.GetRoomByID(roomIDInt)
+	if err != nil {
+		return errorResponse(ctx, err, "Failed to join room")
+	}
+	if room.ComplaintID != nil {
+		if err := c.ensureCanHandle(principal, *room.ComplaintID); err != nil {
+			return errorResponse(ctx, err, "Failed to join room")
+		}
+	}
+
+	room, err = c.chatUsecase.JoinRoom(roomIDInt, principal)
+	if err != nil {
+		return errorResponse(ctx, err, "Failed to join room")
+	}
+
+	return ctx.JSON(http.StatusOK, room)
+}
+
+// GetRooms retrieves the rooms of the caller with their unread counts
+func (c *ChatController) GetRooms(ctx echo.Context) error {
+	principal, err := utils.GetPrincipal(ctx)
+	if err != nil {
+		return errorResponse(ctx, err, "Failed to fetch rooms")
+	}
+
+	rooms, err := c.chatUsecase.GetRooms(principal, ctx.QueryParam("status"))
+	if err != nil {
+		return errorResponse(ctx, err, "Failed to fetch rooms")
 	}
 
 	return ctx.JSON(http.StatusOK, rooms)
 }
 
+// UpdateRoomStatus lets an admin open, close or archive a room
+func (c *ChatController) UpdateRoomStatus(ctx echo.Context) error {
+	roomIDInt, err := strconv.Atoi(ctx.Param("room-id"))
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid Room ID"})
+	}
+
+	var request struct {
+		Status string `json:"status" form:"status"`
+	}
+	if err := ctx.Bind(&request); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid input"})
+	}
+
+	principal, err := utils.GetPrincipal(ctx)
+	if err != nil {
+		return errorResponse(ctx, err, "Failed to update room")
+	}
+
+	room, err := c.chatUsecase.UpdateRoomStatus(roomIDInt, request.Status, principal)
+	if err != nil {
+		return errorResponse(ctx, err, "Failed to update room")
+	}
+
+	return ctx.JSON(http.StatusOK, room)
+}
+
 func (c *ChatController) SendMessage(ctx echo.Context) error {
 	roomID := ctx.Param("room-id")
 	if roomID == "" {
@@ -65,9 +186,7 @@ func (c *ChatController) SendMessage(ctx echo.Context) error {
 
 	// Data model untuk body input
 	var request struct {
-		SenderID   int    `json:"senderID" form:"senderID"`
-		SenderType string `json:"senderType" form:"senderType"`
-		Message    string `json:"message" form:"message"`
+		Message string `json:"message" form:"message"`
 	}
 
 	// Parsing data dari body (mendukung JSON, form-data, x-www-form-urlencoded)
@@ -76,23 +195,24 @@ func (c *ChatController) SendMessage(ctx echo.Context) error {
 		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid input"})
 	}
 
-	if request.SenderID <= 0 || request.SenderType == "" || request.Message == "" {
-		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "All fields are required"})
+	// Pengirim diambil dari token, bukan dari body
+	principal, err := utils.GetPrincipal(ctx)
+	if err != nil {
+		return errorResponse(ctx, err, "Failed to send message")
 	}
 
 	// Buat pesan
 	msg := entities.Message{
 		RoomID:     roomIDInt,
-		SenderID:   request.SenderID,
-		SenderType: request.SenderType,
+		SenderID:   principal.ID,
+		SenderType: senderType(principal),
 		Message:    request.Message,
 	}
 
 	// Kirim pesan menggunakan usecase
 	err = c.chatUsecase.SendMessage(&msg)
 	if err != nil {
-		log.Println("Failed to send message:", err)
-		return ctx.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to send message"})
+		return errorResponse(ctx, err, "Failed to send message")
 	}
 
 	return ctx.JSON(http.StatusOK, msg)
@@ -111,11 +231,27 @@ func (c *ChatController) GetMessagesByRoomID(ctx echo.Context) error {
 		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid Room ID"})
 	}
 
+	// Hanya anggota room yang dapat membaca pesannya
+	principal, err := utils.GetPrincipal(ctx)
+	if err != nil {
+		return errorResponse(ctx, err, "Failed to fetch messages")
+	}
+
+	// Parameter cursor, kosong untuk halaman pertama, mengaktifkan pagination dengan cursor
+	if ctx.QueryParams().Has("cursor") {
+		limit, _ := strconv.Atoi(ctx.QueryParam("limit"))
+		messages, nextCursor, err := c.chatUsecase.GetMessagesByRoomIDCursor(roomIDInt, principal, limit, ctx.QueryParam("cursor"))
+		if err != nil {
+			return errorResponse(ctx, err, "Failed to fetch messages")
+		}
+
+		return ctx.JSON(http.StatusOK, base.NewSuccessResponseWithMetadata("Success Get Messages", messages, *base.NewCursorMetadata(nextCursor)))
+	}
+
 	// Ambil pesan-pesan berdasarkan Room ID
-	messages, err := c.chatUsecase.GetMessagesByRoomID(roomIDInt)
+	messages, err := c.chatUsecase.GetMessagesByRoomID(roomIDInt, principal)
 	if err != nil {
-		log.Println("Failed to fetch messages:", err)
-		return ctx.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to fetch messages"})
+		return errorResponse(ctx, err, "Failed to fetch messages")
 	}
 
 	// Kirimkan data pesan ke klien
@@ -124,7 +260,7 @@ func (c *ChatController) GetMessagesByRoomID(ctx echo.Context) error {
 
 func (c *ChatController) GetRoomByID(ctx echo.Context) error {
 	// Ambil parameter room ID dari URL
-	ID := ctx.Param("id")
+	ID := ctx.Param("room-id")
 	if ID == "" {
 		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "Room ID is required"})
 	}
@@ -135,12 +271,78 @@ func (c *ChatController) GetRoomByID(ctx echo.Context) error {
 		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid Room ID"})
 	}
 
-	// Ambil detail room dari usecase
-	room, err := c.chatUsecase.GetRoomByID(IDInt)
+	principal, err := utils.GetPrincipal(ctx)
 	if err != nil {
-		return ctx.JSON(http.StatusNotFound, map[string]string{"error": "Room not found"})
+		return errorResponse(ctx, err, "Failed to fetch room")
+	}
+
+	// Ambil detail room dari usecase, hanya untuk anggota room
+	room, err := c.chatUsecase.GetRoom(IDInt, principal)
+	if err != nil {
+		return errorResponse(ctx, err, "Failed to fetch room")
 	}
 
 	// Kirimkan data room ke klien
 	return ctx.JSON(http.StatusOK, room)
+}
+
+// messageParams reads the room ID and message ID of a message route
+func messageParams(ctx echo.Context) (int, int, error) {
+	roomIDInt, err := strconv.Atoi(ctx.Param("room-id"))
+	if err != nil {
+		return 0, 0, constants.ErrInvalidIDFormat
+	}
+
+	messageIDInt, err := strconv.Atoi(ctx.Param("message-id"))
+	if err != nil {
+		return 0, 0, constants.ErrInvalidIDFormat
+	}
+
+	return roomIDInt, messageIDInt, nil
+}
+
+// UpdateMessage edits a message the caller sent
+func (c *ChatController) UpdateMessage(ctx echo.Context) error {
+	roomIDInt, messageIDInt, err := messageParams(ctx)
+	if err != nil {
+		return errorResponse(ctx, err, "Failed to update message")
+	}
+
+	var request struct {
+		Message string `json:"message" form:"message"`
+	}
+	if err := ctx.Bind(&request); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid input"})
+	}
+
+	principal, err := utils.GetPrincipal(ctx)
+	if err != nil {
+		return errorResponse(ctx, err, "Failed to update message")
+	}
+
+	msg, err := c.chatUsecase.UpdateMessage(roomIDInt, messageIDInt, request.Message, principal)
+	if err != nil {
+		return errorResponse(ctx, err, "Failed to update message")
+	}
+
+	return ctx.JSON(http.StatusOK, msg)
+}
+
+// DeleteMessage deletes a message the caller sent
+func (c *ChatController) DeleteMessage(ctx echo.Context) error {
+	roomIDInt, messageIDInt, err := messageParams(ctx)
+	if err != nil {
+		return errorResponse(ctx, err, "Failed to delete message")
+	}
+
+	principal, err := utils.GetPrincipal(ctx)
+	if err != nil {
+		return errorResponse(ctx, err, "Failed to delete message")
+	}
+
+	if err := c.chatUsecase.DeleteMessage(roomIDInt, messageIDInt, principal); err != nil {
+		return errorResponse(ctx, err, "Failed to delete message")
+	}
+
+	return ctx.JSON(http.StatusOK, base.NewSuccessResponse("Success Delete Message", nil))
 }
\ No newline at end of file
@@ -0,0 +1,101 @@
+package chat
+
+import (
+	"e-complaint-api/constants"
+	"e-complaint-api/entities"
+	"e-complaint-api/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"golang.org/x/net/websocket"
+)
+
+// chatFrame is a frame a member sends over the WebSocket of a room
+type chatFrame struct {
+	Type      string `json:"type"`
+	Message   string `json:"message"`
+	MessageID int    `json:"message_id"`
+}
+
+// Connect upgrades the request to a WebSocket that pushes the messages, typing
+// indicators and read receipts of a room to one of its members. The member sends
+// frames of type message, typing and read over the same WebSocket. Browsers cannot
+// set headers on WebSockets, so the route accepts the access token as the token
+// query param as well.
+func (c *ChatController) Connect(ctx echo.Context) error {
+	roomIDInt, err := strconv.Atoi(ctx.Param("room-id"))
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid Room ID"})
+	}
+
+	principal, err := utils.GetPrincipal(ctx)
+	if err != nil {
+		return errorResponse(ctx, err, "Failed to connect to room")
+	}
+
+	events, unsubscribe, err := c.chatUsecase.Subscribe(roomIDInt, principal)
+	if err != nil {
+		return errorResponse(ctx, err, "Failed to connect to room")
+	}
+	defer unsubscribe()
+
+	// the origin is not checked because the token authenticates the WebSocket, not
+	// cookies a foreign page could send along
+	server := websocket.Server{Handler: func(ws *websocket.Conn) {
+		defer ws.Close()
+
+		// closing the events ends the loop below once the member disconnects
+		go func() {
+			defer unsubscribe()
+			c.receive(ws, roomIDInt, principal)
+		}()
+
+		for event := range events {
+			if err := websocket.JSON.Send(ws, event); err != nil {
+				return
+			}
+		}
+	}}
+	server.ServeHTTP(ctx.Response(), ctx.Request())
+
+	return nil
+}
+
+// receive handles the frames of a member until the WebSocket is closed. Frames that
+// fail are answered with an error event instead of closing the WebSocket.
+func (c *ChatController) receive(ws *websocket.Conn, roomID int, principal entities.Principal) {
+	for {
+		var frame chatFrame
+		if err := websocket.JSON.Receive(ws, &frame); err != nil {
+			return
+		}
+
+		var err error
+		switch frame.Type {
+		case constants.ChatEventMessage:
+			err = c.chatUsecase.SendMessage(&entities.Message{
+				RoomID:     roomID,
+				SenderID:   principal.ID,
+				SenderType: senderType(principal),
+				Message:    frame.Message,
+			})
+		case constants.ChatEventTyping:
+			err = c.chatUsecase.Typing(roomID, principal)
+		case constants.ChatEventRead:
+			err = c.chatUsecase.MarkAsRead(roomID, frame.MessageID, principal)
+		default:
+			err = constants.ErrInvalidChatEvent
+		}
+
+		if err != nil {
+			message := err.Error()
+			if utils.ConvertResponseCode(err) == http.StatusInternalServerError {
+				message = constants.ErrInternalServerError.Error()
+			}
+			if err := websocket.JSON.Send(ws, entities.Event{Type: constants.ChatEventError, Data: message}); err != nil {
+				return
+			}
+		}
+	}
+}
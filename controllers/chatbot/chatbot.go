@@ -23,9 +23,9 @@ func NewChatbotController(chatbotUseCase entities.ChatbotUseCaseInterface) *Chat
 }
 
 func (cc *ChatbotController) GetHistory(c echo.Context) error {
-	userID, _ := utils.GetIDFromJWT(c)
+	principal, _ := utils.GetPrincipal(c)
 
-	history, err := cc.chatbotUseCase.GetHistory(userID)
+	history, err := cc.chatbotUseCase.GetHistory(principal.ID)
 	if err != nil {
 		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
 	}
@@ -49,7 +49,7 @@ func (cc *ChatbotController) GetHistory(c echo.Context) error {
 }
 
 func (cc *ChatbotController) GetChatCompletion(c echo.Context) error {
-	userID, _ := utils.GetIDFromJWT(c)
+	principal, _ := utils.GetPrincipal(c)
 
 	var request chatbot_request.Chat
 	if err := c.Bind(&request); err != nil {
@@ -57,9 +57,9 @@ func (cc *ChatbotController) GetChatCompletion(c echo.Context) error {
 	}
 
 	chatbot := request.ToEntities()
-	chatbot.UserID = userID
+	chatbot.UserID = principal.ID
 
-	err := cc.chatbotUseCase.GetChatCompletion(chatbot)
+	err := cc.chatbotUseCase.GetChatCompletion(c.Request().Context(), chatbot)
 	if err != nil {
 		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
 	}
@@ -70,9 +70,9 @@ func (cc *ChatbotController) GetChatCompletion(c echo.Context) error {
 }
 
 func (cc *ChatbotController) ClearHistory(c echo.Context) error {
-	userID, _ := utils.GetIDFromJWT(c)
+	principal, _ := utils.GetPrincipal(c)
 
-	err := cc.chatbotUseCase.ClearHistory(userID)
+	err := cc.chatbotUseCase.ClearHistory(principal.ID)
 	if err != nil {
 		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
 	}
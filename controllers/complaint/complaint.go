@@ -5,11 +5,21 @@ import (
 	"e-complaint-api/controllers/base"
 	complaint_request "e-complaint-api/controllers/complaint/request"
 	complaint_response "e-complaint-api/controllers/complaint/response"
+	complaint_duplicate_response "e-complaint-api/controllers/complaint_duplicate/response"
 	complaint_file_response "e-complaint-api/controllers/complaint_file/response"
 	"e-complaint-api/entities"
+	"e-complaint-api/export"
+	"e-complaint-api/geo"
+	"e-complaint-api/queryspec"
+	"e-complaint-api/upload"
 	"e-complaint-api/utils"
+	"e-complaint-api/workflow"
+	"fmt"
+	"log"
 	"net/http"
+	"net/url"
 	"strconv"
+	"time"
 
 	"github.com/labstack/echo/v4"
 )
@@ -18,70 +28,261 @@ type ComplaintController struct {
 	complaintUseCase        entities.ComplaintUseCaseInterface
 	complaintFileUseCase    entities.ComplaintFileUseCaseInterface
 	complaintProcessUseCase entities.ComplaintProcessUseCaseInterface
+	notificationUseCase     entities.NotificationUseCaseInterface
+	assignmentUseCase       entities.ComplaintAssignmentUseCaseInterface
+	roleUseCase             entities.RoleUseCaseInterface
+	duplicateUseCase        entities.ComplaintDuplicateUseCaseInterface
+	jobUseCase              entities.JobUseCaseInterface
+	exportStorage           entities.FileStorageInterface
+	complaintEventUseCase   entities.ComplaintEventUseCaseInterface
+	webhookUseCase          entities.WebhookUseCaseInterface
 }
 
-func NewComplaintController(complaintUseCase entities.ComplaintUseCaseInterface, complaintFileUseCase entities.ComplaintFileUseCaseInterface, complaintProcessUseCase entities.ComplaintProcessUseCaseInterface) *ComplaintController {
-	return &ComplaintController{
+// NewComplaintController registers the handler of export jobs, which stores the
+// exported files in exportStorage.
+func NewComplaintController(complaintUseCase entities.ComplaintUseCaseInterface, complaintFileUseCase entities.ComplaintFileUseCaseInterface, complaintProcessUseCase entities.ComplaintProcessUseCaseInterface, notificationUseCase entities.NotificationUseCaseInterface, assignmentUseCase entities.ComplaintAssignmentUseCaseInterface, roleUseCase entities.RoleUseCaseInterface, duplicateUseCase entities.ComplaintDuplicateUseCaseInterface, jobUseCase entities.JobUseCaseInterface, exportStorage entities.FileStorageInterface, complaintEventUseCase entities.ComplaintEventUseCaseInterface, webhookUseCase entities.WebhookUseCaseInterface) *ComplaintController {
+	complaintController := &ComplaintController{
 		complaintUseCase:        complaintUseCase,
 		complaintFileUseCase:    complaintFileUseCase,
 		complaintProcessUseCase: complaintProcessUseCase,
+		notificationUseCase:     notificationUseCase,
+		assignmentUseCase:       assignmentUseCase,
+		roleUseCase:             roleUseCase,
+		duplicateUseCase:        duplicateUseCase,
+		jobUseCase:              jobUseCase,
+		exportStorage:           exportStorage,
+		complaintEventUseCase:   complaintEventUseCase,
+		webhookUseCase:          webhookUseCase,
 	}
+	jobUseCase.Register(constants.JobTypeComplaintExport, complaintController.runExportJob)
+
+	return complaintController
 }
 
 func (cc *ComplaintController) GetPaginated(c echo.Context) error {
 	limit, _ := strconv.Atoi(c.QueryParam("limit"))
 	page, _ := strconv.Atoi(c.QueryParam("page"))
 	search := c.QueryParam("search")
-	regency_filter := c.QueryParam("regency_id")
-	category_filter, _ := strconv.Atoi(c.QueryParam("category_id"))
-	status_filter := c.QueryParam("status")
-	filter := map[string]interface{}{}
-	if regency_filter == "" && category_filter == 0 && status_filter == "" {
-		filter = nil
-	} else {
-		if regency_filter != "" {
-			filter["regency_id"] = regency_filter
-		}
-		if category_filter != 0 {
-			filter["category_id"] = category_filter
-		}
-		if status_filter != "" {
-			filter["status"] = status_filter
-		}
+	principal, _ := utils.GetPrincipal(c)
+
+	filter, err := cc.listFilter(c.QueryParams(), principal)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
 	}
 
 	sort_by := c.QueryParam("sort_by")
 	sort_type := c.QueryParam("sort_type")
 
+	// a cursor query param, empty for the first page, switches to cursor pagination
+	if c.QueryParams().Has("cursor") {
+		complaints, nextCursor, err := cc.complaintUseCase.GetByCursor(limit, c.QueryParam("cursor"), search, filter, sort_by, sort_type)
+		if err != nil {
+			return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+		}
+
+		return c.JSON(200, base.NewSuccessResponseWithMetadata("Success Get Reports", complaintResponses(principal.Role, complaints), *base.NewCursorMetadata(nextCursor)))
+	}
+
 	complaints, err := cc.complaintUseCase.GetPaginated(limit, page, search, filter, sort_by, sort_type)
 	if err != nil {
 		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
 	}
 
-	var complaintResponses interface{}
-	role, _ := utils.GetRoleFromJWT(c)
+	metaData, err := cc.complaintUseCase.GetMetaData(limit, page, search, filter)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	metaDataResponse := base.NewMetadata(metaData.TotalData, metaData.Pagination.TotalDataPerPage, metaData.Pagination.FirstPage, metaData.Pagination.LastPage, metaData.Pagination.CurrentPage, metaData.Pagination.NextPage, metaData.Pagination.PrevPage)
+
+	return c.JSON(200, base.NewSuccessResponseWithMetadata("Success Get Reports", complaintResponses(principal.Role, complaints), *metaDataResponse))
+}
+
+// listFilter reads the filters of the complaint listing and limits them to the scope of
+// the principal. It returns nil when nothing is filtered.
+func (cc *ComplaintController) listFilter(query url.Values, principal entities.Principal) (map[string]interface{}, error) {
+	overdue_filter := principal.Role != "user" && query.Get("overdue") == "true"
+
+	filter, err := queryspec.Complaints.ParseFor(query, principal.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	near_filter, err := nearFilter(query.Get("near"), query.Get("radius"))
+	if err != nil {
+		return nil, err
+	}
+
+	scope, err := cc.roleUseCase.GetScope(principal.ID, principal.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	if overdue_filter {
+		filter["overdue"] = true
+	}
+	if near_filter != nil {
+		filter["near"] = *near_filter
+	}
+	if !scope.IsEmpty() {
+		filter["scope"] = scope
+	}
+	if len(filter) == 0 {
+		filter = nil
+	}
+
+	return filter, nil
+}
+
+// Export streams the complaints that match the filters of GetPaginated as a csv or xlsx
+// file. The status is sent with the first bytes of the file. CSV rows go out batch by
+// batch, so a failure after the first batch only cuts the file short. An xlsx workbook
+// is only sent once it is complete, so its failures still get an error response.
+func (cc *ComplaintController) Export(c echo.Context) error {
+	format := c.QueryParam("format")
+	if format == "" {
+		format = export.FormatXLSX
+	}
+
+	principal, _ := utils.GetPrincipal(c)
+	filter, err := cc.listFilter(c.QueryParams(), principal)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	writer, err := export.NewWriter(format, &attachmentWriter{
+		response:    c.Response(),
+		contentType: export.ContentType(format),
+		filename:    fmt.Sprintf("complaints-%s.%s", time.Now().Format("20060102150405"), format),
+	})
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	fileURL := func(file *entities.ComplaintFile) string {
+		return fmt.Sprintf("%s://%s/api/v1/complaints/%s/files/%d/url", c.Scheme(), c.Request().Host, file.ComplaintID, file.ID)
+	}
+
+	started := false
+	start := func() error {
+		started = true
+		return writer.Write(complaint_response.ExportHeader)
+	}
+
+	err = cc.complaintUseCase.Export(c.QueryParam("search"), filter, c.QueryParam("sort_by"), c.QueryParam("sort_type"), func(complaints []entities.Complaint) error {
+		if !started {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+
+		for i := range complaints {
+			if err := writer.Write(complaint_response.ExportRowFromEntities(&complaints[i], fileURL)); err != nil {
+				return err
+			}
+		}
+
+		if err := writer.Flush(); err != nil {
+			return err
+		}
+		if c.Response().Committed {
+			c.Response().Flush()
+		}
+
+		return nil
+	})
+
+	// an export without complaints still has its header row
+	if err == nil && !started {
+		err = start()
+	}
+	if err == nil {
+		err = writer.Close()
+	}
+
+	if err != nil && !c.Response().Committed {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	return err
+}
+
+// attachmentWriter sends the status and the attachment headers right before the first
+// bytes of the file, so an export that fails earlier can still answer with an error.
+type attachmentWriter struct {
+	response    *echo.Response
+	contentType string
+	filename    string
+}
+
+func (w *attachmentWriter) Write(p []byte) (int, error) {
+	if !w.response.Committed {
+		w.response.Header().Set(echo.HeaderContentType, w.contentType)
+		w.response.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=\"%s\"", w.filename))
+		w.response.WriteHeader(http.StatusOK)
+	}
+
+	return w.response.Write(p)
+}
+
+// complaintResponses hides the admin only fields of complaints from users.
+func complaintResponses(role string, complaints []entities.Complaint) interface{} {
 	if role == "user" {
 		userResponses := []*complaint_response.Get{}
 		for _, complaint := range complaints {
 			userResponses = append(userResponses, complaint_response.GetFromEntitiesToResponse(&complaint))
 		}
-		complaintResponses = userResponses
-	} else {
-		adminResponses := []*complaint_response.AdminGet{}
-		for _, complaint := range complaints {
-			adminResponses = append(adminResponses, complaint_response.AdminGetFromEntitiesToResponse(&complaint))
+		return userResponses
+	}
+
+	adminResponses := []*complaint_response.AdminGet{}
+	for _, complaint := range complaints {
+		adminResponses = append(adminResponses, complaint_response.AdminGetFromEntitiesToResponse(&complaint))
+	}
+	return adminResponses
+}
+
+// nearFilter parses the near=lat,lng and radius=km query parameters. It returns nil
+// when near is not given.
+func nearFilter(near string, radius string) (*geo.Circle, error) {
+	if near == "" {
+		return nil, nil
+	}
+
+	center, err := geo.ParsePoint(near)
+	if err != nil {
+		return nil, err
+	}
+
+	circle := geo.Circle{Center: center, Radius: constants.DefaultNearRadius}
+	if radius != "" {
+		circle.Radius, err = strconv.ParseFloat(radius, 64)
+		if err != nil || !(circle.Radius > 0 && circle.Radius <= constants.MaxNearRadius) {
+			return nil, constants.ErrInvalidRadius
 		}
-		complaintResponses = adminResponses
 	}
 
-	metaData, err := cc.complaintUseCase.GetMetaData(limit, page, search, filter)
+	return &circle, nil
+}
+
+// GetGeoJSON exports the complaints that match the filters of GetPaginated and have a
+// location, at most MaxGeoJSONFeatures of them.
+func (cc *ComplaintController) GetGeoJSON(c echo.Context) error {
+	search := c.QueryParam("search")
+	principal, _ := utils.GetPrincipal(c)
+
+	filter, err := cc.listFilter(c.QueryParams(), principal)
 	if err != nil {
 		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
 	}
 
-	metaDataResponse := base.NewMetadata(metaData.TotalData, metaData.Pagination.TotalDataPerPage, metaData.Pagination.FirstPage, metaData.Pagination.LastPage, metaData.Pagination.CurrentPage, metaData.Pagination.NextPage, metaData.Pagination.PrevPage)
+	complaints, err := cc.complaintUseCase.GetWithLocation(search, filter)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
 
-	return c.JSON(200, base.NewSuccessResponseWithMetadata("Success Get Reports", complaintResponses, *metaDataResponse))
+	c.Response().Header().Set(echo.HeaderContentType, "application/geo+json")
+	return c.JSON(http.StatusOK, complaint_response.FeatureCollectionFromEntitiesToResponse(complaints))
 }
 
 func (cc *ComplaintController) GetByID(c echo.Context) error {
@@ -92,9 +293,14 @@ func (cc *ComplaintController) GetByID(c echo.Context) error {
 		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
 	}
 
+	principal, _ := utils.GetPrincipal(c)
+	err = cc.roleUseCase.EnsureInScope(principal.ID, principal.Role, complaint)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
 	var complaintResponse interface{}
-	role, _ := utils.GetRoleFromJWT(c)
-	if role == "user" {
+	if principal.Role == "user" {
 		complaintResponse = complaint_response.GetFromEntitiesToResponse(&complaint)
 	} else {
 		complaintResponse = complaint_response.AdminGetFromEntitiesToResponse(&complaint)
@@ -103,13 +309,82 @@ func (cc *ComplaintController) GetByID(c echo.Context) error {
 	return c.JSON(200, base.NewSuccessResponse("Success Get Report", complaintResponse))
 }
 
+// Events streams new processes, discussions, likes and evidence of a complaint as
+// server-sent events. A reconnecting stream gets the events it missed after the
+// Last-Event-ID header, or the last_event_id query param. Browsers cannot set headers
+// on an EventSource, so the route accepts the access token as the token query param
+// as well.
+func (cc *ComplaintController) Events(c echo.Context) error {
+	id := c.Param("id")
+
+	complaint, err := cc.complaintUseCase.GetByID(id)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	principal, _ := utils.GetPrincipal(c)
+	err = cc.roleUseCase.EnsureInScope(principal.ID, principal.Role, complaint)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	lastEventID := c.Request().Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.QueryParam("last_event_id")
+	}
+	lastEventIDInt := 0
+	if lastEventID != "" {
+		lastEventIDInt, err = strconv.Atoi(lastEventID)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, base.NewErrorResponse(constants.ErrInvalidLastEventID.Error()))
+		}
+	}
+
+	events, stop, err := cc.complaintEventUseCase.Subscribe(id, lastEventIDInt)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+	defer stop()
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set(echo.HeaderCacheControl, "no-cache")
+	res.Header().Set(echo.HeaderConnection, "keep-alive")
+	// stops nginx from buffering the stream
+	res.Header().Set("X-Accel-Buffering", "no")
+	res.WriteHeader(http.StatusOK)
+	res.Flush()
+
+	keepAlive := time.NewTicker(constants.ComplaintEventKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-c.Request().Context().Done():
+			return nil
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(res, ": keep-alive\n\n"); err != nil {
+				return nil
+			}
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if _, err := fmt.Fprintf(res, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, event.Data); err != nil {
+				return nil
+			}
+		}
+		res.Flush()
+	}
+}
+
 func (cc *ComplaintController) GetByUserID(c echo.Context) error {
-	user_id, err := utils.GetIDFromJWT(c)
+	principal, err := utils.GetPrincipal(c)
 	if err != nil {
 		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
 	}
 
-	complaints, err := cc.complaintUseCase.GetByUserID(user_id)
+	complaints, err := cc.complaintUseCase.GetByUserID(principal.ID)
 	if err != nil {
 		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
 	}
@@ -123,14 +398,14 @@ func (cc *ComplaintController) GetByUserID(c echo.Context) error {
 }
 
 func (cc *ComplaintController) Create(c echo.Context) error {
-	user_id, err := utils.GetIDFromJWT(c)
+	principal, err := utils.GetPrincipal(c)
 	if err != nil {
 		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
 	}
 
 	var complaintRequest complaint_request.Create
 	c.Bind(&complaintRequest)
-	complaintRequest.UserID = user_id
+	complaintRequest.UserID = principal.ID
 
 	form, err := c.MultipartForm()
 	if err != nil {
@@ -138,18 +413,10 @@ func (cc *ComplaintController) Create(c echo.Context) error {
 	}
 	files := form.File["files"]
 
-	if len(files) > 5 {
-		return c.JSON(http.StatusBadRequest, base.NewErrorResponse(constants.ErrMaxFileCountExceeded.Error()))
-	}
-
-	// Count total file size
-	totalFileSize := 0
-	for _, file := range files {
-		totalFileSize += int(file.Size)
-	}
-
-	if totalFileSize > 10*1024*1024 {
-		return c.JSON(http.StatusBadRequest, base.NewErrorResponse(constants.ErrMaxFileSizeExceeded.Error()))
+	// Reject invalid files before the complaint is stored
+	err = upload.ComplaintFiles.Check(files)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
 	}
 
 	complaint, err1 := cc.complaintUseCase.Create(complaintRequest.ToEntities())
@@ -174,7 +441,7 @@ func (cc *ComplaintController) Create(c echo.Context) error {
 	complaintProcess := entities.ComplaintProcess{
 		ComplaintID: complaint.ID,
 		AdminID:     1,
-		Status:      "Pending",
+		Status:      workflow.Complaint.Initial(),
 		Message:     "Aduan anda akan segera kami periksa",
 	}
 
@@ -183,23 +450,59 @@ func (cc *ComplaintController) Create(c echo.Context) error {
 		return c.JSON(utils.ConvertResponseCode(err3), base.NewErrorResponse(err3.Error()))
 	}
 
+	// The complaint is stored by now, failing the request would only make the user
+	// report it again
+	err4 := cc.notificationUseCase.NotifyComplaintProcess(complaint, complaintProcess)
+	if err4 != nil {
+		log.Printf("complaint %s: notify complaint process failed: %v", complaint.ID, err4)
+	}
+
+	_, err5 := cc.assignmentUseCase.AutoAssign(complaint)
+	if err5 != nil {
+		log.Printf("complaint %s: auto assign failed: %v", complaint.ID, err5)
+	}
+
+	similarComplaints, err6 := cc.duplicateUseCase.GetSimilar(complaint, principal.ID, principal.Role)
+	if err6 != nil {
+		log.Printf("complaint %s: get similar complaints failed: %v", complaint.ID, err6)
+	} else {
+		complaintResponse.SimilarComplaints = complaint_duplicate_response.SimilarFromEntitiesToResponses(similarComplaints)
+	}
+
+	createdComplaint, err7 := cc.complaintUseCase.GetByID(complaint.ID)
+	if err7 != nil {
+		log.Printf("complaint %s: get created complaint failed: %v", complaint.ID, err7)
+	} else {
+		err8 := cc.webhookUseCase.Dispatch(constants.WebhookEventComplaintCreated, complaint_response.WebhookFromEntitiesToResponse(&createdComplaint))
+		if err8 != nil {
+			log.Printf("complaint %s: dispatch webhook failed: %v", complaint.ID, err8)
+		}
+	}
+
 	return c.JSON(http.StatusCreated, base.NewSuccessResponse("Success Create Report", complaintResponse))
 }
 
 func (cc *ComplaintController) Delete(c echo.Context) error {
 	id := c.Param("id")
 
-	user_id, err := utils.GetIDFromJWT(c)
+	principal, err := utils.GetPrincipal(c)
 	if err != nil {
 		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
 	}
 
-	role, err := utils.GetRoleFromJWT(c)
-	if err != nil {
-		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	if principal.Role != "user" {
+		complaint, err := cc.complaintUseCase.GetByID(id)
+		if err != nil {
+			return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+		}
+
+		err = cc.roleUseCase.EnsureInScope(principal.ID, principal.Role, complaint)
+		if err != nil {
+			return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+		}
 	}
 
-	err = cc.complaintUseCase.Delete(id, user_id, role)
+	err = cc.complaintUseCase.Delete(id, principal.ID, principal.Role)
 	if err != nil {
 		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
 	}
@@ -215,7 +518,7 @@ func (cc *ComplaintController) Delete(c echo.Context) error {
 func (cc *ComplaintController) Update(c echo.Context) error {
 	id := c.Param("id")
 
-	user_id, err := utils.GetIDFromJWT(c)
+	principal, err := utils.GetPrincipal(c)
 	if err != nil {
 		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
 	}
@@ -223,7 +526,7 @@ func (cc *ComplaintController) Update(c echo.Context) error {
 	var complaintRequest complaint_request.Update
 	c.Bind(&complaintRequest)
 	complaintRequest.ID = id
-	complaintRequest.UserID = user_id
+	complaintRequest.UserID = principal.ID
 
 	form, err := c.MultipartForm()
 	if err != nil {
@@ -231,20 +534,9 @@ func (cc *ComplaintController) Update(c echo.Context) error {
 	}
 
 	files := form.File["files"]
-	if len(files) != 0 {
-		if len(files) > 5 {
-			return c.JSON(http.StatusBadRequest, base.NewErrorResponse(constants.ErrMaxFileCountExceeded.Error()))
-		}
-
-		// Count total file size
-		totalFileSize := 0
-		for _, file := range files {
-			totalFileSize += int(file.Size)
-		}
-
-		if totalFileSize > 10*1024*1024 {
-			return c.JSON(http.StatusBadRequest, base.NewErrorResponse(constants.ErrMaxFileSizeExceeded.Error()))
-		}
+	err = upload.ComplaintFiles.Check(files)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
 	}
 
 	complaint, err1 := cc.complaintUseCase.Update(*complaintRequest.ToEntities())
@@ -276,18 +568,3 @@ func (cc *ComplaintController) Update(c echo.Context) error {
 	return c.JSON(http.StatusOK, base.NewSuccessResponse("Success Update Report", complaintResponse))
 
 }
-
-func (cc *ComplaintController) Import(c echo.Context) error {
-	form, err := c.MultipartForm()
-	if err != nil {
-		return c.JSON(http.StatusInternalServerError, base.NewErrorResponse(err.Error()))
-	}
-	file := form.File["file"][0]
-
-	err = cc.complaintUseCase.Import(file)
-	if err != nil {
-		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
-	}
-
-	return c.JSON(http.StatusOK, base.NewSuccessResponse("Success Import Report", nil))
-}
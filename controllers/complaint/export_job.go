@@ -0,0 +1,176 @@
+package complaint
+
+import (
+	"e-complaint-api/constants"
+	"e-complaint-api/controllers/base"
+	complaint_response "e-complaint-api/controllers/complaint/response"
+	job_response "e-complaint-api/controllers/job/response"
+	"e-complaint-api/entities"
+	"e-complaint-api/export"
+	"e-complaint-api/utils"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// exportPayload is the payload of an export job. It holds the query of the request
+// rather than the filter read from it, so the job applies the scope the admin has
+// when it runs.
+type exportPayload struct {
+	Query   url.Values
+	AdminID int
+	Role    string
+	Format  string
+	BaseURL string
+}
+
+// EnqueueExport leaves the export of GetExport to a background job, for exports too
+// large to wait for. The file is downloaded with DownloadExport once the job is done.
+func (cc *ComplaintController) EnqueueExport(c echo.Context) error {
+	format := c.QueryParam("format")
+	if format == "" {
+		format = export.FormatXLSX
+	}
+	if format != export.FormatXLSX && format != export.FormatCSV {
+		return c.JSON(http.StatusBadRequest, base.NewErrorResponse(constants.ErrInvalidExportFormat.Error()))
+	}
+
+	principal, _ := utils.GetPrincipal(c)
+	// reject invalid filters now instead of in the job
+	if _, err := cc.listFilter(c.QueryParams(), principal); err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	job, err := cc.jobUseCase.Enqueue(constants.JobTypeComplaintExport, &principal.ID, exportPayload{
+		Query:   c.QueryParams(),
+		AdminID: principal.ID,
+		Role:    principal.Role,
+		Format:  format,
+		BaseURL: fmt.Sprintf("%s://%s", c.Scheme(), c.Request().Host),
+	})
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	return c.JSON(http.StatusAccepted, base.NewSuccessResponse("Success Enqueue Export", job_response.GetFromEntitiesToResponse(&job)))
+}
+
+// GetExportJob returns an export job of the calling admin.
+func (cc *ComplaintController) GetExportJob(c echo.Context) error {
+	job, err := cc.exportJob(c)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	return c.JSON(http.StatusOK, base.NewSuccessResponse("Success Get Export", job_response.GetFromEntitiesToResponse(&job)))
+}
+
+// DownloadExport streams the file of a finished export job of the calling admin.
+func (cc *ComplaintController) DownloadExport(c echo.Context) error {
+	job, err := cc.exportJob(c)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	if job.Status != constants.JobStatusDone {
+		return c.JSON(http.StatusBadRequest, base.NewErrorResponse(constants.ErrExportNotReady.Error()))
+	}
+
+	file, err := cc.exportStorage.Open(job.Result)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+	defer file.Close()
+
+	format := strings.TrimPrefix(path.Ext(job.Result), ".")
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=\"complaints-%s.%s\"", job.CreatedAt.Format("20060102150405"), format))
+
+	return c.Stream(http.StatusOK, export.ContentType(format), file)
+}
+
+func (cc *ComplaintController) exportJob(c echo.Context) (entities.Job, error) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return entities.Job{}, constants.ErrInvalidIDFormat
+	}
+
+	principal, _ := utils.GetPrincipal(c)
+
+	return cc.jobUseCase.GetOwn(id, constants.JobTypeComplaintExport, principal.ID)
+}
+
+// runExportJob writes the export to a temporary file and stores it, its result is the
+// path of the stored file. Storage takes whole files, so only the upload holds the
+// file in memory, not the export.
+func (cc *ComplaintController) runExportJob(data []byte) (string, error) {
+	var payload exportPayload
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return "", err
+	}
+
+	filter, err := cc.listFilter(payload.Query, entities.Principal{ID: payload.AdminID, Role: payload.Role})
+	if err != nil {
+		return "", err
+	}
+
+	file, err := os.CreateTemp("", "complaints-export-*")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	writer, err := export.NewWriter(payload.Format, file)
+	if err != nil {
+		return "", err
+	}
+
+	if err := writer.Write(complaint_response.ExportHeader); err != nil {
+		return "", err
+	}
+
+	fileURL := func(file *entities.ComplaintFile) string {
+		return fmt.Sprintf("%s/api/v1/complaints/%s/files/%d/url", payload.BaseURL, file.ComplaintID, file.ID)
+	}
+
+	err = cc.complaintUseCase.Export(payload.Query.Get("search"), filter, payload.Query.Get("sort_by"), payload.Query.Get("sort_type"), func(complaints []entities.Complaint) error {
+		for i := range complaints {
+			if err := writer.Write(complaint_response.ExportRowFromEntities(&complaints[i], fileURL)); err != nil {
+				return err
+			}
+		}
+
+		return writer.Flush()
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if err := writer.Close(); err != nil {
+		return "", err
+	}
+
+	content, err := os.ReadFile(file.Name())
+	if err != nil {
+		return "", err
+	}
+
+	paths, err := cc.exportStorage.Upload([]entities.UploadFile{{
+		Name:        fmt.Sprintf("complaints-%s.%s", time.Now().Format("20060102150405"), payload.Format),
+		ContentType: export.ContentType(payload.Format),
+		Content:     content,
+	}})
+	if err != nil {
+		return "", err
+	}
+
+	return paths[0], nil
+}
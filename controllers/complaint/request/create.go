@@ -6,13 +6,15 @@ import (
 )
 
 type Create struct {
-	UserID      int    `json:"user_id" form:"user_id"`
-	CategoryID  int    `json:"category_id" form:"category_id" binding:"required"`
-	Description string `json:"description" form:"description" binding:"required"`
-	RegencyID   string `json:"regency_id" form:"regency_id" binding:"required"`
-	Address     string `json:"address" form:"address" binding:"required"`
-	Date        string `json:"date" form:"date"`
-	Type        string `json:"type" form:"type" binding:"required"`
+	UserID      int      `json:"user_id" form:"user_id"`
+	CategoryID  int      `json:"category_id" form:"category_id" binding:"required"`
+	Description string   `json:"description" form:"description" binding:"required"`
+	RegencyID   string   `json:"regency_id" form:"regency_id" binding:"required"`
+	Address     string   `json:"address" form:"address" binding:"required"`
+	Date        string   `json:"date" form:"date"`
+	Type        string   `json:"type" form:"type" binding:"required"`
+	Latitude    *float64 `json:"latitude" form:"latitude"`
+	Longitude   *float64 `json:"longitude" form:"longitude"`
 }
 
 func (r *Create) ToEntities() *entities.Complaint {
@@ -27,5 +29,7 @@ func (r *Create) ToEntities() *entities.Complaint {
 		Address:     r.Address,
 		Date:        date,
 		Type:        r.Type,
+		Latitude:    r.Latitude,
+		Longitude:   r.Longitude,
 	}
 }
@@ -1,11 +1,13 @@
 package response
 
 import (
+	admin_response "e-complaint-api/controllers/admin/response"
 	category_response "e-complaint-api/controllers/category/response"
 	file_response "e-complaint-api/controllers/complaint_file/response"
 	regency_response "e-complaint-api/controllers/regency/response"
 	user_response "e-complaint-api/controllers/user/response"
 	"e-complaint-api/entities"
+	"time"
 )
 
 type AdminGet struct {
@@ -14,12 +16,18 @@ type AdminGet struct {
 	Category    category_response.Get         `json:"category"`
 	Regency     regency_response.Regency      `json:"regency"`
 	Address     string                        `json:"address"`
+	Latitude    *float64                      `json:"latitude"`
+	Longitude   *float64                      `json:"longitude"`
 	Description string                        `json:"description"`
 	Status      string                        `json:"status"`
+	MergedInto  *string                       `json:"merged_into"`
 	Type        string                        `json:"type"`
 	Files       []file_response.ComplaintFile `json:"files"`
 	Date        string                        `json:"date"`
 	TotalLikes  int                           `json:"total_likes"`
+	DueAt       string                        `json:"due_at,omitempty"`
+	IsOverdue   bool                          `json:"is_overdue"`
+	Assignee    *admin_response.GetSimple     `json:"assignee"`
 	UpdatedAt   string                        `json:"updated_at"`
 }
 
@@ -27,24 +35,43 @@ func AdminGetFromEntitiesToResponse(data *entities.Complaint) *AdminGet {
 	var files []file_response.ComplaintFile
 	for _, file := range data.Files {
 		files = append(files, file_response.ComplaintFile{
-			ID:          file.ID,
-			ComplaintID: file.ComplaintID,
-			Path:        file.Path,
+			ID:            file.ID,
+			ComplaintID:   file.ComplaintID,
+			Path:          file.Path,
+			ThumbnailPath: file.ThumbnailPath,
 		})
 	}
 
+	var dueAt string
+	var isOverdue bool
+	if data.DueAt != nil {
+		dueAt = data.DueAt.Format("2 January 2006 15:04:05")
+		isOverdue = data.DueAt.Before(time.Now())
+	}
+
+	var assignee *admin_response.GetSimple
+	if data.Assignee != nil {
+		assignee = admin_response.GetSimpleFromEntitiesToResponse(data.Assignee)
+	}
+
 	return &AdminGet{
 		ID:          data.ID,
 		User:        *user_response.GetUsersFromEntitiesToResponse(&data.User),
 		Category:    *category_response.GetFromEntitiesToResponse(&data.Category),
 		Regency:     *regency_response.FromEntitiesToResponse(&data.Regency),
 		Address:     data.Address,
+		Latitude:    data.Latitude,
+		Longitude:   data.Longitude,
 		Description: data.Description,
 		Status:      data.Status,
+		MergedInto:  data.MergedIntoID,
 		Type:        data.Type,
 		Files:       files,
 		Date:        data.Date.Format("2 January 2006"),
 		TotalLikes:  data.TotalLikes,
+		DueAt:       dueAt,
+		IsOverdue:   isOverdue,
+		Assignee:    assignee,
 		UpdatedAt:   data.UpdatedAt.Format("2 January 2006 15:04:05"),
 	}
 }
@@ -2,6 +2,7 @@ package response
 
 import (
 	category_response "e-complaint-api/controllers/category/response"
+	duplicate_response "e-complaint-api/controllers/complaint_duplicate/response"
 	file_response "e-complaint-api/controllers/complaint_file/response"
 	regency_response "e-complaint-api/controllers/regency/response"
 	user_response "e-complaint-api/controllers/user/response"
@@ -19,7 +20,9 @@ type Create struct {
 	Type        string                         `json:"type"`
 	Date        string                         `json:"date"`
 	Files       []*file_response.ComplaintFile `json:"files"`
-	CreatedAt   string                         `json:"created_at"`
+	// SimilarComplaints are open complaints that may report the same problem
+	SimilarComplaints []*duplicate_response.Similar `json:"similar_complaints"`
+	CreatedAt         string                        `json:"created_at"`
 }
 
 func CreateFromEntitiesToResponse(data *entities.Complaint) *Create {
@@ -0,0 +1,66 @@
+package response
+
+import (
+	"e-complaint-api/entities"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ExportHeader is the header row of a complaint export.
+var ExportHeader = []string{
+	"id", "date", "type", "status", "category", "regency", "address", "latitude", "longitude", "description",
+	"total_likes", "user_id", "user_name", "user_email", "assignee", "created_at", "updated_at", "processes", "files",
+}
+
+// ExportRowFromEntities turns a complaint into a row of the export. Processes are listed
+// oldest first, one per line. fileURL links every file to the endpoint that hands out
+// its download URL.
+func ExportRowFromEntities(complaint *entities.Complaint, fileURL func(file *entities.ComplaintFile) string) []string {
+	latitude, longitude := "", ""
+	if complaint.Latitude != nil && complaint.Longitude != nil {
+		latitude = strconv.FormatFloat(*complaint.Latitude, 'f', -1, 64)
+		longitude = strconv.FormatFloat(*complaint.Longitude, 'f', -1, 64)
+	}
+
+	assignee := ""
+	if complaint.Assignee != nil {
+		assignee = complaint.Assignee.Name
+	}
+
+	processes := make([]string, 0, len(complaint.Process))
+	for _, process := range complaint.Process {
+		admin := process.Admin.Name
+		if process.Escalation {
+			admin = "Sistem"
+		}
+		processes = append(processes, fmt.Sprintf("%s %s (%s): %s", process.CreatedAt.Format("2006-01-02 15:04:05"), process.Status, admin, process.Message))
+	}
+
+	files := make([]string, 0, len(complaint.Files))
+	for i := range complaint.Files {
+		files = append(files, fileURL(&complaint.Files[i]))
+	}
+
+	return []string{
+		complaint.ID,
+		complaint.Date.Format("02-01-2006"),
+		complaint.Type,
+		complaint.Status,
+		complaint.Category.Name,
+		complaint.Regency.Name,
+		complaint.Address,
+		latitude,
+		longitude,
+		complaint.Description,
+		strconv.Itoa(complaint.TotalLikes),
+		strconv.Itoa(complaint.UserID),
+		complaint.User.Name,
+		complaint.User.Email,
+		assignee,
+		complaint.CreatedAt.Format("2006-01-02 15:04:05"),
+		complaint.UpdatedAt.Format("2006-01-02 15:04:05"),
+		strings.Join(processes, "\n"),
+		strings.Join(files, "\n"),
+	}
+}
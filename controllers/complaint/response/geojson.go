@@ -0,0 +1,66 @@
+package response
+
+import (
+	"e-complaint-api/entities"
+)
+
+// FeatureCollection is a GeoJSON (RFC 7946) export of complaints that have a location.
+type FeatureCollection struct {
+	Type     string    `json:"type"`
+	Features []Feature `json:"features"`
+}
+
+type Feature struct {
+	Type       string            `json:"type"`
+	ID         string            `json:"id"`
+	Geometry   PointGeometry     `json:"geometry"`
+	Properties FeatureProperties `json:"properties"`
+}
+
+type PointGeometry struct {
+	Type string `json:"type"`
+	// Coordinates are [longitude, latitude] as required by GeoJSON
+	Coordinates [2]float64 `json:"coordinates"`
+}
+
+type FeatureProperties struct {
+	Category  string `json:"category"`
+	Regency   string `json:"regency"`
+	Address   string `json:"address"`
+	Status    string `json:"status"`
+	Type      string `json:"type"`
+	Date      string `json:"date"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+func FeatureCollectionFromEntitiesToResponse(data []entities.Complaint) *FeatureCollection {
+	features := []Feature{}
+	for _, complaint := range data {
+		if complaint.Latitude == nil || complaint.Longitude == nil {
+			continue
+		}
+
+		features = append(features, Feature{
+			Type: "Feature",
+			ID:   complaint.ID,
+			Geometry: PointGeometry{
+				Type:        "Point",
+				Coordinates: [2]float64{*complaint.Longitude, *complaint.Latitude},
+			},
+			Properties: FeatureProperties{
+				Category:  complaint.Category.Name,
+				Regency:   complaint.Regency.Name,
+				Address:   complaint.Address,
+				Status:    complaint.Status,
+				Type:      complaint.Type,
+				Date:      complaint.Date.Format("2 January 2006"),
+				UpdatedAt: complaint.UpdatedAt.Format("2 January 2006 15:04:05"),
+			},
+		})
+	}
+
+	return &FeatureCollection{
+		Type:     "FeatureCollection",
+		Features: features,
+	}
+}
@@ -14,8 +14,11 @@ type Get struct {
 	Category    category_response.Get         `json:"category"`
 	Regency     regency_response.Regency      `json:"regency"`
 	Address     string                        `json:"address"`
+	Latitude    *float64                      `json:"latitude"`
+	Longitude   *float64                      `json:"longitude"`
 	Description string                        `json:"description"`
 	Status      string                        `json:"status"`
+	MergedInto  *string                       `json:"merged_into"`
 	Type        string                        `json:"type"`
 	Date        string                        `json:"date"`
 	Files       []file_response.ComplaintFile `json:"files"`
@@ -27,9 +30,10 @@ func GetFromEntitiesToResponse(data *entities.Complaint) *Get {
 	var files []file_response.ComplaintFile
 	for _, file := range data.Files {
 		files = append(files, file_response.ComplaintFile{
-			ID:          file.ID,
-			ComplaintID: file.ComplaintID,
-			Path:        file.Path,
+			ID:            file.ID,
+			ComplaintID:   file.ComplaintID,
+			Path:          file.Path,
+			ThumbnailPath: file.ThumbnailPath,
 		})
 	}
 
@@ -49,8 +53,11 @@ func GetFromEntitiesToResponse(data *entities.Complaint) *Get {
 		Category:    *category_response.GetFromEntitiesToResponse(&data.Category),
 		Regency:     *regency_response.FromEntitiesToResponse(&data.Regency),
 		Address:     data.Address,
+		Latitude:    data.Latitude,
+		Longitude:   data.Longitude,
 		Description: data.Description,
 		Status:      data.Status,
+		MergedInto:  data.MergedIntoID,
 		Type:        data.Type,
 		Date:        data.Date.Format("2 January 2006"),
 		Files:       files,
@@ -14,6 +14,8 @@ type Update struct {
 	Category    *category_response.Get         `json:"category"`
 	Regency     *regency_response.Regency      `json:"regency"`
 	Address     string                         `json:"address"`
+	Latitude    *float64                       `json:"latitude"`
+	Longitude   *float64                       `json:"longitude"`
 	Description string                         `json:"description"`
 	Status      string                         `json:"status"`
 	Type        string                         `json:"type"`
@@ -34,9 +36,10 @@ func UpdateFromEntitiesToResponse(data *entities.Complaint) *Update {
 	var files []*file_response.ComplaintFile
 	for _, file := range data.Files {
 		files = append(files, &file_response.ComplaintFile{
-			ID:          file.ID,
-			ComplaintID: file.ComplaintID,
-			Path:        file.Path,
+			ID:            file.ID,
+			ComplaintID:   file.ComplaintID,
+			Path:          file.Path,
+			ThumbnailPath: file.ThumbnailPath,
 		})
 	}
 
@@ -46,6 +49,8 @@ func UpdateFromEntitiesToResponse(data *entities.Complaint) *Update {
 		Category:    category_response.GetFromEntitiesToResponse(&data.Category),
 		Regency:     regency_response.FromEntitiesToResponse(&data.Regency),
 		Address:     data.Address,
+		Latitude:    data.Latitude,
+		Longitude:   data.Longitude,
 		Description: data.Description,
 		Status:      data.Status,
 		Type:        data.Type,
@@ -0,0 +1,33 @@
+package response
+
+import "e-complaint-api/entities"
+
+// Webhook is the data of the webhook events. Every event carries the complaint it is
+// about in the shape admins get it, along with what happened to it: the new process
+// and the status before it, the new discussion or the uploaded evidence.
+type Webhook struct {
+	Complaint      *AdminGet   `json:"complaint"`
+	PreviousStatus string      `json:"previous_status,omitempty"`
+	Process        interface{} `json:"process,omitempty"`
+	Discussion     interface{} `json:"discussion,omitempty"`
+	Evidence       interface{} `json:"evidence,omitempty"`
+}
+
+// WebhookFromEntitiesToResponse hides the reporter of private complaints the same way
+// Get does, webhooks go to third parties.
+func WebhookFromEntitiesToResponse(data *entities.Complaint) *Webhook {
+	complaint := *data
+	if complaint.Type == "private" {
+		complaint.User = entities.User{
+			ID:              0,
+			Name:            "Anonymous",
+			Email:           "anonymous@anonymous.com",
+			TelephoneNumber: "000000000000",
+			ProfilePhoto:    "profile_photos/default.jpg",
+		}
+	}
+
+	return &Webhook{
+		Complaint: AdminGetFromEntitiesToResponse(&complaint),
+	}
+}
@@ -24,7 +24,7 @@ func NewComplaintActivityController(complaintActivityUseCase entities.ComplaintA
 }
 
 func (ca *ComplaintActivityController) GetByComplaintID(c echo.Context) error {
-	userID, err := utils.GetIDFromJWT(c)
+	principal, err := utils.GetPrincipal(c)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, base.NewErrorResponse(err.Error()))
 	}
@@ -32,7 +32,7 @@ func (ca *ComplaintActivityController) GetByComplaintID(c echo.Context) error {
 	activityType := c.QueryParam("type")
 	fmt.Println(activityType)
 
-	complaintIDs, err := ca.complaintUseCase.GetComplaintIDsByUserID(userID)
+	complaintIDs, err := ca.complaintUseCase.GetComplaintIDsByUserID(principal.ID)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, base.NewErrorResponse(err.Error()))
 	}
@@ -49,7 +49,7 @@ func (ca *ComplaintActivityController) GetByComplaintID(c echo.Context) error {
 		likeUserID := complaintActivity.Like.UserID
 
 		// Pastikan untuk hanya menambahkan aktivitas yang tidak sesuai dengan userID dari JWT
-		if (discussionUserID != nil && *discussionUserID == userID) || likeUserID == userID {
+		if (discussionUserID != nil && *discussionUserID == principal.ID) || likeUserID == principal.ID {
 			continue
 		}
 
@@ -0,0 +1,145 @@
+package complaint_assignment
+
+import (
+	"e-complaint-api/constants"
+	"e-complaint-api/controllers/base"
+	complaint_response "e-complaint-api/controllers/complaint/response"
+	"e-complaint-api/controllers/complaint_assignment/request"
+	"e-complaint-api/controllers/complaint_assignment/response"
+	"e-complaint-api/entities"
+	"e-complaint-api/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ComplaintAssignmentController struct {
+	complaintAssignmentUseCase entities.ComplaintAssignmentUseCaseInterface
+	complaintUseCase           entities.ComplaintUseCaseInterface
+	roleUseCase                entities.RoleUseCaseInterface
+}
+
+func NewComplaintAssignmentController(complaintAssignmentUseCase entities.ComplaintAssignmentUseCaseInterface, complaintUseCase entities.ComplaintUseCaseInterface, roleUseCase entities.RoleUseCaseInterface) *ComplaintAssignmentController {
+	return &ComplaintAssignmentController{
+		complaintAssignmentUseCase: complaintAssignmentUseCase,
+		complaintUseCase:           complaintUseCase,
+		roleUseCase:                roleUseCase,
+	}
+}
+
+// ensureInScope checks that the complaint of the request is in the scope of the
+// logged in admin.
+func (ca *ComplaintAssignmentController) ensureInScope(complaintID string, principal entities.Principal) error {
+	complaint, err := ca.complaintUseCase.GetByID(complaintID)
+	if err != nil {
+		return err
+	}
+
+	return ca.roleUseCase.EnsureInScope(principal.ID, principal.Role, complaint)
+}
+
+func (ca *ComplaintAssignmentController) Assign(c echo.Context) error {
+	principal, err := utils.GetPrincipal(c)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	err = ca.ensureInScope(c.Param("complaint-id"), principal)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	var assignRequest request.Assign
+	c.Bind(&assignRequest)
+
+	complaintAssignment, err := ca.complaintAssignmentUseCase.Assign(c.Param("complaint-id"), assignRequest.AdminID, principal.ID, principal.Role, assignRequest.Note)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	return c.JSON(http.StatusOK, base.NewSuccessResponse("Success Assign Complaint", response.GetFromEntitiesToResponse(&complaintAssignment)))
+}
+
+func (ca *ComplaintAssignmentController) GetHistory(c echo.Context) error {
+	principal, err := utils.GetPrincipal(c)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	err = ca.ensureInScope(c.Param("complaint-id"), principal)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	complaintAssignments, err := ca.complaintAssignmentUseCase.GetHistory(c.Param("complaint-id"))
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	complaintAssignmentResponses := []*response.Get{}
+	for _, complaintAssignment := range complaintAssignments {
+		complaintAssignmentResponses = append(complaintAssignmentResponses, response.GetFromEntitiesToResponse(&complaintAssignment))
+	}
+
+	return c.JSON(http.StatusOK, base.NewSuccessResponse("Success Get Complaint Assignments", complaintAssignmentResponses))
+}
+
+func (ca *ComplaintAssignmentController) GetQueue(c echo.Context) error {
+	principal, err := utils.GetPrincipal(c)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	complaints, err := ca.complaintAssignmentUseCase.GetQueue(principal.ID)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	complaintResponses := []*complaint_response.AdminGet{}
+	for _, complaint := range complaints {
+		complaintResponses = append(complaintResponses, complaint_response.AdminGetFromEntitiesToResponse(&complaint))
+	}
+
+	return c.JSON(http.StatusOK, base.NewSuccessResponse("Success Get Queue", complaintResponses))
+}
+
+func (ca *ComplaintAssignmentController) GetPools(c echo.Context) error {
+	assignmentPools, err := ca.complaintAssignmentUseCase.GetPools()
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	assignmentPoolResponses := []*response.Pool{}
+	for _, assignmentPool := range assignmentPools {
+		assignmentPoolResponses = append(assignmentPoolResponses, response.PoolFromEntitiesToResponse(&assignmentPool))
+	}
+
+	return c.JSON(http.StatusOK, base.NewSuccessResponse("Success Get Assignment Pools", assignmentPoolResponses))
+}
+
+func (ca *ComplaintAssignmentController) CreatePool(c echo.Context) error {
+	var poolRequest request.CreatePool
+	c.Bind(&poolRequest)
+
+	assignmentPool, err := ca.complaintAssignmentUseCase.CreatePool(poolRequest.ToEntities())
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	return c.JSON(http.StatusCreated, base.NewSuccessResponse("Success Create Assignment Pool", response.PoolFromEntitiesToResponse(&assignmentPool)))
+}
+
+func (ca *ComplaintAssignmentController) DeletePool(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, base.NewErrorResponse(constants.ErrInvalidIDFormat.Error()))
+	}
+
+	err = ca.complaintAssignmentUseCase.DeletePool(id)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	return c.JSON(http.StatusOK, base.NewSuccessResponse("Success Delete Assignment Pool", nil))
+}
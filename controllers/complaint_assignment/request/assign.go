@@ -0,0 +1,6 @@
+package request
+
+type Assign struct {
+	AdminID int    `json:"admin_id" form:"admin_id"`
+	Note    string `json:"note" form:"note"`
+}
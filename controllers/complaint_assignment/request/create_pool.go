@@ -0,0 +1,17 @@
+package request
+
+import "e-complaint-api/entities"
+
+type CreatePool struct {
+	AdminID    int     `json:"admin_id" form:"admin_id"`
+	CategoryID *int    `json:"category_id" form:"category_id"`
+	RegencyID  *string `json:"regency_id" form:"regency_id"`
+}
+
+func (r *CreatePool) ToEntities() *entities.AssignmentPool {
+	return &entities.AssignmentPool{
+		AdminID:    r.AdminID,
+		CategoryID: r.CategoryID,
+		RegencyID:  r.RegencyID,
+	}
+}
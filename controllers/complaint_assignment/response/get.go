@@ -0,0 +1,31 @@
+package response
+
+import (
+	admin_response "e-complaint-api/controllers/admin/response"
+	"e-complaint-api/entities"
+)
+
+type Get struct {
+	ID          int                       `json:"id"`
+	ComplaintID string                    `json:"complaint_id"`
+	Admin       *admin_response.GetSimple `json:"admin"`
+	AssignedBy  *admin_response.GetSimple `json:"assigned_by"`
+	Note        string                    `json:"note"`
+	CreatedAt   string                    `json:"created_at"`
+}
+
+func GetFromEntitiesToResponse(data *entities.ComplaintAssignment) *Get {
+	var assignedBy *admin_response.GetSimple
+	if data.AssignedBy != nil {
+		assignedBy = admin_response.GetSimpleFromEntitiesToResponse(data.AssignedBy)
+	}
+
+	return &Get{
+		ID:          data.ID,
+		ComplaintID: data.ComplaintID,
+		Admin:       admin_response.GetSimpleFromEntitiesToResponse(&data.Admin),
+		AssignedBy:  assignedBy,
+		Note:        data.Note,
+		CreatedAt:   data.CreatedAt.Format("2 January 2006 15:04:05"),
+	}
+}
@@ -0,0 +1,41 @@
+package response
+
+import (
+	admin_response "e-complaint-api/controllers/admin/response"
+	category_response "e-complaint-api/controllers/category/response"
+	regency_response "e-complaint-api/controllers/regency/response"
+	"e-complaint-api/entities"
+)
+
+type Pool struct {
+	ID             int                       `json:"id"`
+	Admin          *admin_response.GetSimple `json:"admin"`
+	Category       *category_response.Get    `json:"category"`
+	Regency        *regency_response.Regency `json:"regency"`
+	LastAssignedAt string                    `json:"last_assigned_at,omitempty"`
+}
+
+func PoolFromEntitiesToResponse(data *entities.AssignmentPool) *Pool {
+	var category *category_response.Get
+	if data.Category != nil {
+		category = category_response.GetFromEntitiesToResponse(data.Category)
+	}
+
+	var regency *regency_response.Regency
+	if data.Regency != nil {
+		regency = regency_response.FromEntitiesToResponse(data.Regency)
+	}
+
+	var lastAssignedAt string
+	if data.LastAssignedAt != nil {
+		lastAssignedAt = data.LastAssignedAt.Format("2 January 2006 15:04:05")
+	}
+
+	return &Pool{
+		ID:             data.ID,
+		Admin:          admin_response.GetSimpleFromEntitiesToResponse(&data.Admin),
+		Category:       category,
+		Regency:        regency,
+		LastAssignedAt: lastAssignedAt,
+	}
+}
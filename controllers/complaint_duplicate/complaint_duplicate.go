@@ -0,0 +1,114 @@
+package complaint_duplicate
+
+import (
+	"e-complaint-api/constants"
+	"e-complaint-api/controllers/base"
+	complaint_response "e-complaint-api/controllers/complaint/response"
+	"e-complaint-api/controllers/complaint_duplicate/request"
+	"e-complaint-api/controllers/complaint_duplicate/response"
+	"e-complaint-api/entities"
+	"e-complaint-api/utils"
+	"log"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ComplaintDuplicateController struct {
+	complaintUseCase          entities.ComplaintUseCaseInterface
+	complaintDuplicateUseCase entities.ComplaintDuplicateUseCaseInterface
+	notificationUseCase       entities.NotificationUseCaseInterface
+	assignmentUseCase         entities.ComplaintAssignmentUseCaseInterface
+	roleUseCase               entities.RoleUseCaseInterface
+	webhookUseCase            entities.WebhookUseCaseInterface
+}
+
+func NewComplaintDuplicateController(complaintUseCase entities.ComplaintUseCaseInterface, complaintDuplicateUseCase entities.ComplaintDuplicateUseCaseInterface, notificationUseCase entities.NotificationUseCaseInterface, assignmentUseCase entities.ComplaintAssignmentUseCaseInterface, roleUseCase entities.RoleUseCaseInterface, webhookUseCase entities.WebhookUseCaseInterface) *ComplaintDuplicateController {
+	return &ComplaintDuplicateController{
+		complaintUseCase:          complaintUseCase,
+		complaintDuplicateUseCase: complaintDuplicateUseCase,
+		notificationUseCase:       notificationUseCase,
+		assignmentUseCase:         assignmentUseCase,
+		roleUseCase:               roleUseCase,
+		webhookUseCase:            webhookUseCase,
+	}
+}
+
+func (cd *ComplaintDuplicateController) GetSimilar(c echo.Context) error {
+	principal, err := utils.GetPrincipal(c)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	complaint, err := cd.complaintUseCase.GetByID(c.Param("complaint-id"))
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	err = cd.roleUseCase.EnsureInScope(principal.ID, principal.Role, complaint)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	similarComplaints, err := cd.complaintDuplicateUseCase.GetSimilarByID(complaint.ID)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	return c.JSON(http.StatusOK, base.NewSuccessResponse("Success Get Similar Complaints", response.SimilarFromEntitiesToResponses(similarComplaints)))
+}
+
+// Merge merges the complaints in the request body into the complaint of the path.
+func (cd *ComplaintDuplicateController) Merge(c echo.Context) error {
+	principal, err := utils.GetPrincipal(c)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	complaint_id := c.Param("complaint-id")
+
+	var mergeRequest request.Merge
+	c.Bind(&mergeRequest)
+
+	// Every complaint of the merge changes, so all of them must be in the scope of the
+	// admin and processable by them
+	previousStatuses := map[string]string{}
+	for _, id := range append([]string{complaint_id}, mergeRequest.ComplaintIDs...) {
+		complaint, err := cd.complaintUseCase.GetByID(id)
+		if err != nil {
+			return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+		}
+		previousStatuses[id] = complaint.Status
+
+		err = cd.roleUseCase.EnsureInScope(principal.ID, principal.Role, complaint)
+		if err != nil {
+			return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+		}
+
+		err = cd.assignmentUseCase.EnsureCanProcess(id, principal.ID, principal.Role)
+		if err != nil {
+			return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+		}
+	}
+
+	master, duplicates, err := cd.complaintDuplicateUseCase.Merge(complaint_id, mergeRequest.ComplaintIDs, principal.ID)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	err = cd.notificationUseCase.NotifyComplaintMerged(master, duplicates)
+	if err != nil {
+		log.Printf("complaint %s: notify complaint merged failed: %v", master.ID, err)
+	}
+
+	for _, duplicate := range duplicates {
+		webhookData := complaint_response.WebhookFromEntitiesToResponse(&duplicate)
+		webhookData.PreviousStatus = previousStatuses[duplicate.ID]
+		err = cd.webhookUseCase.Dispatch(constants.WebhookEventComplaintStatusChanged, webhookData)
+		if err != nil {
+			log.Printf("complaint %s: dispatch webhook failed: %v", duplicate.ID, err)
+		}
+	}
+
+	return c.JSON(http.StatusOK, base.NewSuccessResponse("Success Merge Complaints", complaint_response.AdminGetFromEntitiesToResponse(&master)))
+}
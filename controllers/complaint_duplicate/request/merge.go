@@ -0,0 +1,5 @@
+package request
+
+type Merge struct {
+	ComplaintIDs []string `json:"complaint_ids" form:"complaint_ids"`
+}
@@ -0,0 +1,53 @@
+package response
+
+import (
+	category_response "e-complaint-api/controllers/category/response"
+	regency_response "e-complaint-api/controllers/regency/response"
+	"e-complaint-api/entities"
+	"math"
+)
+
+type Similar struct {
+	ID          string                    `json:"id"`
+	Category    *category_response.Get    `json:"category"`
+	Regency     *regency_response.Regency `json:"regency"`
+	Address     string                    `json:"address"`
+	Description string                    `json:"description"`
+	Status      string                    `json:"status"`
+	Type        string                    `json:"type"`
+	Date        string                    `json:"date"`
+	TotalLikes  int                       `json:"total_likes"`
+	Score       float64                   `json:"score"`
+	Distance    *float64                  `json:"distance_km"`
+}
+
+func SimilarFromEntitiesToResponse(data *entities.SimilarComplaint) *Similar {
+	var distance *float64
+	if data.Distance != nil {
+		rounded := math.Round(*data.Distance*1000) / 1000
+		distance = &rounded
+	}
+
+	return &Similar{
+		ID:          data.Complaint.ID,
+		Category:    category_response.GetFromEntitiesToResponse(&data.Complaint.Category),
+		Regency:     regency_response.FromEntitiesToResponse(&data.Complaint.Regency),
+		Address:     data.Complaint.Address,
+		Description: data.Complaint.Description,
+		Status:      data.Complaint.Status,
+		Type:        data.Complaint.Type,
+		Date:        data.Complaint.Date.Format("2 January 2006"),
+		TotalLikes:  data.Complaint.TotalLikes,
+		Score:       math.Round(data.Score*100) / 100,
+		Distance:    distance,
+	}
+}
+
+func SimilarFromEntitiesToResponses(data []entities.SimilarComplaint) []*Similar {
+	similarResponses := []*Similar{}
+	for _, similarComplaint := range data {
+		similarResponses = append(similarResponses, SimilarFromEntitiesToResponse(&similarComplaint))
+	}
+
+	return similarResponses
+}
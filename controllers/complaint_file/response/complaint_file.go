@@ -3,15 +3,17 @@ package response
 import "e-complaint-api/entities"
 
 type ComplaintFile struct {
-	ID          int    `json:"id"`
-	ComplaintID string `json:"complaint_id"`
-	Path        string `json:"path"`
+	ID            int    `json:"id"`
+	ComplaintID   string `json:"complaint_id"`
+	Path          string `json:"path"`
+	ThumbnailPath string `json:"thumbnail_path"`
 }
 
 func FromEntitiesToResponse(file *entities.ComplaintFile) *ComplaintFile {
 	return &ComplaintFile{
-		ID:          file.ID,
-		ComplaintID: file.ComplaintID,
-		Path:        file.Path,
+		ID:            file.ID,
+		ComplaintID:   file.ComplaintID,
+		Path:          file.Path,
+		ThumbnailPath: file.ThumbnailPath,
 	}
 }
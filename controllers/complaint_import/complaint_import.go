@@ -0,0 +1,87 @@
+package complaint_import
+
+import (
+	"e-complaint-api/constants"
+	"e-complaint-api/controllers/base"
+	"e-complaint-api/controllers/complaint_import/response"
+	job_response "e-complaint-api/controllers/job/response"
+	"e-complaint-api/entities"
+	"e-complaint-api/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ComplaintImportController struct {
+	complaintImportUseCase entities.ComplaintImportUseCaseInterface
+	jobUseCase             entities.JobUseCaseInterface
+	roleUseCase            entities.RoleUseCaseInterface
+}
+
+func NewComplaintImportController(complaintImportUseCase entities.ComplaintImportUseCaseInterface, jobUseCase entities.JobUseCaseInterface, roleUseCase entities.RoleUseCaseInterface) *ComplaintImportController {
+	return &ComplaintImportController{
+		complaintImportUseCase: complaintImportUseCase,
+		jobUseCase:             jobUseCase,
+		roleUseCase:            roleUseCase,
+	}
+}
+
+// Import imports the xlsx or csv file of the file form field as the calling admin. The
+// mode form field picks between dry_run, partial and all_or_nothing, the default. A dry
+// run reports right away, other imports run as a background job whose result is the
+// report. Rows outside the scope of the admin are invalid.
+func (ci *ComplaintImportController) Import(c echo.Context) error {
+	principal, err := utils.GetPrincipal(c)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	scope, err := ci.roleUseCase.GetScope(principal.ID, principal.Role)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, base.NewErrorResponse(constants.ErrImportFileMustBeFilled.Error()))
+	}
+
+	mode := c.FormValue("mode")
+	if mode != constants.ImportModeDryRun {
+		job, err := ci.complaintImportUseCase.Enqueue(file, mode, principal.ID, scope)
+		if err != nil {
+			return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+		}
+
+		return c.JSON(http.StatusAccepted, base.NewSuccessResponse("Success Enqueue Import", job_response.GetFromEntitiesToResponse(&job)))
+	}
+
+	report, err := ci.complaintImportUseCase.Import(file, mode, principal.ID, scope)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	return c.JSON(http.StatusOK, base.NewSuccessResponse("Success Validate Import Report", response.ReportFromEntitiesToResponse(&report)))
+}
+
+// GetJob returns an import job of the calling admin, its result is the report of the
+// import.
+func (ci *ComplaintImportController) GetJob(c echo.Context) error {
+	principal, err := utils.GetPrincipal(c)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, base.NewErrorResponse(constants.ErrInvalidIDFormat.Error()))
+	}
+
+	job, err := ci.jobUseCase.GetOwn(id, constants.JobTypeComplaintImport, principal.ID)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	return c.JSON(http.StatusOK, base.NewSuccessResponse("Success Get Import", job_response.GetFromEntitiesToResponse(&job)))
+}
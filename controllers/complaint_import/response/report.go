@@ -0,0 +1,41 @@
+package response
+
+import "e-complaint-api/entities"
+
+type RowError struct {
+	Row    int      `json:"row"`
+	Errors []string `json:"errors"`
+}
+
+type Report struct {
+	Mode         string     `json:"mode"`
+	TotalRows    int        `json:"total_rows"`
+	ValidRows    int        `json:"valid_rows"`
+	ImportedRows int        `json:"imported_rows"`
+	SkippedRows  []int      `json:"skipped_rows"`
+	Errors       []RowError `json:"errors"`
+}
+
+func ReportFromEntitiesToResponse(report *entities.ComplaintImportReport) *Report {
+	errors := []RowError{}
+	for _, rowError := range report.Errors {
+		errors = append(errors, RowError{
+			Row:    rowError.Row,
+			Errors: rowError.Errors,
+		})
+	}
+
+	skippedRows := report.SkippedRows
+	if skippedRows == nil {
+		skippedRows = []int{}
+	}
+
+	return &Report{
+		Mode:         report.Mode,
+		TotalRows:    report.TotalRows,
+		ValidRows:    report.ValidRows,
+		ImportedRows: report.ImportedRows,
+		SkippedRows:  skippedRows,
+		Errors:       errors,
+	}
+}
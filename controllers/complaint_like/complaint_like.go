@@ -1,9 +1,11 @@
 package complaint_like
 
 import (
+	"e-complaint-api/constants"
 	"e-complaint-api/controllers/base"
 	"e-complaint-api/entities"
 	"e-complaint-api/utils"
+	"log"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
@@ -13,13 +15,17 @@ type ComplaintLikeController struct {
 	complaintLikeUseCase     entities.ComplaintLikeUseCaseInterface
 	complaintUseCase         entities.ComplaintUseCaseInterface
 	complaintActivityUseCase entities.ComplaintActivityUseCaseInterface
+	notificationUseCase      entities.NotificationUseCaseInterface
+	complaintEventUseCase    entities.ComplaintEventUseCaseInterface
 }
 
-func NewComplaintLikeController(complaintLikeUseCase entities.ComplaintLikeUseCaseInterface, complaintUseCase entities.ComplaintUseCaseInterface, complaintActivityUseCase entities.ComplaintActivityUseCaseInterface) *ComplaintLikeController {
+func NewComplaintLikeController(complaintLikeUseCase entities.ComplaintLikeUseCaseInterface, complaintUseCase entities.ComplaintUseCaseInterface, complaintActivityUseCase entities.ComplaintActivityUseCaseInterface, notificationUseCase entities.NotificationUseCaseInterface, complaintEventUseCase entities.ComplaintEventUseCaseInterface) *ComplaintLikeController {
 	return &ComplaintLikeController{
 		complaintLikeUseCase:     complaintLikeUseCase,
 		complaintUseCase:         complaintUseCase,
 		complaintActivityUseCase: complaintActivityUseCase,
+		notificationUseCase:      notificationUseCase,
+		complaintEventUseCase:    complaintEventUseCase,
 	}
 }
 
@@ -29,18 +35,18 @@ func (c *ComplaintLikeController) ToggleLike(ctx echo.Context) error {
 		return ctx.JSON(http.StatusBadRequest, map[string]string{"message": "Complaint ID is required"})
 	}
 
-	_, err := c.complaintUseCase.GetByID(complaintID)
+	complaint, err := c.complaintUseCase.GetByID(complaintID)
 	if err != nil {
 		return ctx.JSON(http.StatusNotFound, base.NewErrorResponse("Complaint not found"))
 	}
 
-	userID, err := utils.GetIDFromJWT(ctx)
+	principal, err := utils.GetPrincipal(ctx)
 	if err != nil {
 		return ctx.JSON(http.StatusInternalServerError, base.NewErrorResponse(err.Error()))
 	}
 
 	complaintLike := &entities.ComplaintLike{
-		UserID:      userID,
+		UserID:      principal.ID,
 		ComplaintID: complaintID,
 	}
 
@@ -63,6 +69,11 @@ func (c *ComplaintLikeController) ToggleLike(ctx echo.Context) error {
 			return ctx.JSON(http.StatusInternalServerError, base.NewErrorResponse(err.Error()))
 		}
 
+		err = c.notificationUseCase.NotifyLike(complaint, *complaintLike)
+		if err != nil {
+			log.Printf("complaint %s: notify like failed: %v", complaintID, err)
+		}
+
 	} else {
 		err := c.complaintUseCase.DecreaseTotalLikes(complaintID)
 		if err != nil {
@@ -77,6 +88,16 @@ func (c *ComplaintLikeController) ToggleLike(ctx echo.Context) error {
 		}
 	}
 
+	complaint, err = c.complaintUseCase.GetByID(complaintID)
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, base.NewErrorResponse(err.Error()))
+	}
+
+	err = c.complaintEventUseCase.Publish(complaintID, constants.ComplaintEventLikes, map[string]int{"total_likes": complaint.TotalLikes})
+	if err != nil {
+		log.Printf("complaint %s: publish event failed: %v", complaintID, err)
+	}
+
 	message := "Complaint " + likeStatus
 
 	successResponse := base.NewSuccessResponse(message, nil)
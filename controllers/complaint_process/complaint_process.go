@@ -1,11 +1,14 @@
 package complaint_process
 
 import (
+	"e-complaint-api/constants"
 	"e-complaint-api/controllers/base"
+	complaint_response "e-complaint-api/controllers/complaint/response"
 	"e-complaint-api/controllers/complaint_process/request"
 	"e-complaint-api/controllers/complaint_process/response"
 	"e-complaint-api/entities"
 	"e-complaint-api/utils"
+	"log"
 	"net/http"
 	"strconv"
 
@@ -15,17 +18,44 @@ import (
 type ComplaintProcessController struct {
 	complaintUseCase        entities.ComplaintUseCaseInterface
 	complaintProcessUseCase entities.ComplaintProcessUseCaseInterface
+	notificationUseCase     entities.NotificationUseCaseInterface
+	assignmentUseCase       entities.ComplaintAssignmentUseCaseInterface
+	roleUseCase             entities.RoleUseCaseInterface
+	complaintEventUseCase   entities.ComplaintEventUseCaseInterface
+	webhookUseCase          entities.WebhookUseCaseInterface
 }
 
-func NewComplaintProcessController(complaintUseCase entities.ComplaintUseCaseInterface, complaintProcessUseCase entities.ComplaintProcessUseCaseInterface) *ComplaintProcessController {
+func NewComplaintProcessController(complaintUseCase entities.ComplaintUseCaseInterface, complaintProcessUseCase entities.ComplaintProcessUseCaseInterface, notificationUseCase entities.NotificationUseCaseInterface, assignmentUseCase entities.ComplaintAssignmentUseCaseInterface, roleUseCase entities.RoleUseCaseInterface, complaintEventUseCase entities.ComplaintEventUseCaseInterface, webhookUseCase entities.WebhookUseCaseInterface) *ComplaintProcessController {
 	return &ComplaintProcessController{
 		complaintUseCase:        complaintUseCase,
 		complaintProcessUseCase: complaintProcessUseCase,
+		notificationUseCase:     notificationUseCase,
+		assignmentUseCase:       assignmentUseCase,
+		roleUseCase:             roleUseCase,
+		complaintEventUseCase:   complaintEventUseCase,
+		webhookUseCase:          webhookUseCase,
 	}
 }
 
 func (cp *ComplaintProcessController) Create(c echo.Context) error {
-	admin_id, err := utils.GetIDFromJWT(c)
+	principal, err := utils.GetPrincipal(c)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	complaint_id := c.Param("complaint-id")
+
+	complaint, err := cp.complaintUseCase.GetByID(complaint_id)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	err = cp.roleUseCase.EnsureInScope(principal.ID, principal.Role, complaint)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	err = cp.assignmentUseCase.EnsureCanProcess(complaint_id, principal.ID, principal.Role)
 	if err != nil {
 		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
 	}
@@ -33,20 +63,40 @@ func (cp *ComplaintProcessController) Create(c echo.Context) error {
 	var complaintProcessRequest request.Create
 	c.Bind(&complaintProcessRequest)
 
-	complaintProcessRequest.AdminID = admin_id
-	complaint_id := c.Param("complaint-id")
+	complaintProcessRequest.AdminID = principal.ID
 	complaintProcessRequest.ComplaintID = complaint_id
+	previousStatus := complaint.Status
 
 	complaintProcess, err := cp.complaintProcessUseCase.Create(complaintProcessRequest.ToEntities())
 	if err != nil {
 		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
 	}
 
-	err = cp.complaintUseCase.UpdateStatus(complaint_id, complaintProcessRequest.Status)
+	complaint, err = cp.complaintUseCase.GetByID(complaint_id)
 	if err != nil {
 		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
 	}
 
+	err = cp.notificationUseCase.NotifyComplaintProcess(complaint, complaintProcess)
+	if err != nil {
+		log.Printf("complaint %s: notify complaint process failed: %v", complaint_id, err)
+	}
+
+	err = cp.complaintEventUseCase.Publish(complaint_id, constants.ComplaintEventProcess, response.GetFromEntitiesToResponse(&complaintProcess))
+	if err != nil {
+		log.Printf("complaint %s: publish event failed: %v", complaint_id, err)
+	}
+
+	if complaintProcess.Status != previousStatus {
+		webhookData := complaint_response.WebhookFromEntitiesToResponse(&complaint)
+		webhookData.PreviousStatus = previousStatus
+		webhookData.Process = response.GetFromEntitiesToResponse(&complaintProcess)
+		err = cp.webhookUseCase.Dispatch(constants.WebhookEventComplaintStatusChanged, webhookData)
+		if err != nil {
+			log.Printf("complaint %s: dispatch webhook failed: %v", complaint_id, err)
+		}
+	}
+
 	complaintProcessResponse := response.CreateFromEntitiesToResponse(&complaintProcess)
 
 	return c.JSON(http.StatusCreated, base.NewSuccessResponse("Success Create Complaint Process", complaintProcessResponse))
@@ -69,7 +119,7 @@ func (cp *ComplaintProcessController) GetByComplaintID(c echo.Context) error {
 }
 
 func (cp *ComplaintProcessController) Update(c echo.Context) error {
-	admin_id, err := utils.GetIDFromJWT(c)
+	principal, err := utils.GetPrincipal(c)
 	if err != nil {
 		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
 	}
@@ -77,10 +127,25 @@ func (cp *ComplaintProcessController) Update(c echo.Context) error {
 	complaintID := c.Param("complaint-id")
 	complaintProcessID, _ := strconv.Atoi(c.Param("process-id"))
 
+	complaint, err := cp.complaintUseCase.GetByID(complaintID)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	err = cp.roleUseCase.EnsureInScope(principal.ID, principal.Role, complaint)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	err = cp.assignmentUseCase.EnsureCanProcess(complaintID, principal.ID, principal.Role)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
 	var complaintProcessRequest request.Update
 	c.Bind(&complaintProcessRequest)
 	complaintProcessRequest.ID = complaintProcessID
-	complaintProcessRequest.AdminID = admin_id
+	complaintProcessRequest.AdminID = principal.ID
 	complaintProcessRequest.ComplaintID = complaintID
 
 	complaintProcess, err := cp.complaintProcessUseCase.Update(complaintProcessRequest.ToEntities())
@@ -94,18 +159,54 @@ func (cp *ComplaintProcessController) Update(c echo.Context) error {
 }
 
 func (cp *ComplaintProcessController) Delete(c echo.Context) error {
+	principal, err := utils.GetPrincipal(c)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
 	complaintID := c.Param("complaint-id")
 	complaintProcessID, _ := strconv.Atoi(c.Param("process-id"))
 
-	status, err := cp.complaintProcessUseCase.Delete(complaintID, complaintProcessID)
+	complaint, err := cp.complaintUseCase.GetByID(complaintID)
 	if err != nil {
 		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
 	}
 
-	err = cp.complaintUseCase.UpdateStatus(complaintID, status)
+	err = cp.roleUseCase.EnsureInScope(principal.ID, principal.Role, complaint)
 	if err != nil {
 		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
 	}
 
+	err = cp.assignmentUseCase.EnsureCanProcess(complaintID, principal.ID, principal.Role)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	status, err := cp.complaintProcessUseCase.Delete(complaintID, complaintProcessID)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	err = cp.complaintEventUseCase.Publish(complaintID, constants.ComplaintEventProcessDeleted, map[string]interface{}{"id": complaintProcessID, "status": status})
+	if err != nil {
+		log.Printf("complaint %s: publish event failed: %v", complaintID, err)
+	}
+
+	// Deleting the latest process reverts the complaint to the status before it
+	if status != complaint.Status {
+		previousStatus := complaint.Status
+		complaint, err = cp.complaintUseCase.GetByID(complaintID)
+		if err != nil {
+			log.Printf("complaint %s: get complaint failed: %v", complaintID, err)
+		} else {
+			webhookData := complaint_response.WebhookFromEntitiesToResponse(&complaint)
+			webhookData.PreviousStatus = previousStatus
+			err = cp.webhookUseCase.Dispatch(constants.WebhookEventComplaintStatusChanged, webhookData)
+			if err != nil {
+				log.Printf("complaint %s: dispatch webhook failed: %v", complaintID, err)
+			}
+		}
+	}
+
 	return c.JSON(http.StatusOK, base.NewSuccessResponse("Success Delete Complaint Process", nil))
 }
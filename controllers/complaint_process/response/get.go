@@ -11,16 +11,24 @@ type Get struct {
 	Admin       *admin_response.GetSimple `json:"admin"`
 	Status      string                    `json:"status"`
 	Message     string                    `json:"message"`
+	Escalation  bool                      `json:"escalation"`
 	UpdatedAt   string                    `json:"updated_at"`
 }
 
 func GetFromEntitiesToResponse(data *entities.ComplaintProcess) *Get {
+	var admin *admin_response.GetSimple
+	// escalations are recorded by the system and have no admin
+	if !data.Escalation {
+		admin = admin_response.GetSimpleFromEntitiesToResponse(&data.Admin)
+	}
+
 	return &Get{
 		ID:          data.ID,
 		ComplaintID: data.ComplaintID,
-		Admin:       admin_response.GetSimpleFromEntitiesToResponse(&data.Admin),
+		Admin:       admin,
 		Status:      data.Status,
 		Message:     data.Message,
+		Escalation:  data.Escalation,
 		UpdatedAt:   data.UpdatedAt.Format("2 January 2006 15:04:05"),
 	}
 }
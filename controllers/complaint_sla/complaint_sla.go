@@ -0,0 +1,82 @@
+package complaint_sla
+
+import (
+	"e-complaint-api/constants"
+	"e-complaint-api/controllers/base"
+	"e-complaint-api/controllers/complaint_sla/request"
+	"e-complaint-api/controllers/complaint_sla/response"
+	"e-complaint-api/entities"
+	"e-complaint-api/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ComplaintSLAController struct {
+	complaintSLAUseCase entities.ComplaintSLAUseCaseInterface
+}
+
+func NewComplaintSLAController(complaintSLAUseCase entities.ComplaintSLAUseCaseInterface) *ComplaintSLAController {
+	return &ComplaintSLAController{
+		complaintSLAUseCase: complaintSLAUseCase,
+	}
+}
+
+func (cc *ComplaintSLAController) GetAll(c echo.Context) error {
+	complaintSLAs, err := cc.complaintSLAUseCase.GetAll()
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	complaintSLAResponses := []*response.Get{}
+	for _, complaintSLA := range complaintSLAs {
+		complaintSLAResponses = append(complaintSLAResponses, response.GetFromEntitiesToResponse(&complaintSLA))
+	}
+
+	return c.JSON(http.StatusOK, base.NewSuccessResponse("Success Get Complaint SLAs", complaintSLAResponses))
+}
+
+func (cc *ComplaintSLAController) Create(c echo.Context) error {
+	var complaintSLARequest request.Create
+	c.Bind(&complaintSLARequest)
+
+	complaintSLA, err := cc.complaintSLAUseCase.Create(complaintSLARequest.ToEntities())
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	return c.JSON(http.StatusCreated, base.NewSuccessResponse("Success Create Complaint SLA", response.GetFromEntitiesToResponse(&complaintSLA)))
+}
+
+func (cc *ComplaintSLAController) Update(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, base.NewErrorResponse(constants.ErrInvalidIDFormat.Error()))
+	}
+
+	var complaintSLARequest request.Update
+	c.Bind(&complaintSLARequest)
+	complaintSLARequest.ID = id
+
+	complaintSLA, err := cc.complaintSLAUseCase.Update(complaintSLARequest.ToEntities())
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	return c.JSON(http.StatusOK, base.NewSuccessResponse("Success Update Complaint SLA", response.GetFromEntitiesToResponse(&complaintSLA)))
+}
+
+func (cc *ComplaintSLAController) Delete(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, base.NewErrorResponse(constants.ErrInvalidIDFormat.Error()))
+	}
+
+	err = cc.complaintSLAUseCase.Delete(id)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	return c.JSON(http.StatusOK, base.NewSuccessResponse("Success Delete Complaint SLA", nil))
+}
@@ -0,0 +1,17 @@
+package request
+
+import "e-complaint-api/entities"
+
+type Create struct {
+	CategoryID int    `json:"category_id" form:"category_id"`
+	Status     string `json:"status" form:"status"`
+	TargetDays int    `json:"target_days" form:"target_days"`
+}
+
+func (r *Create) ToEntities() *entities.ComplaintSLA {
+	return &entities.ComplaintSLA{
+		CategoryID: r.CategoryID,
+		Status:     r.Status,
+		TargetDays: r.TargetDays,
+	}
+}
@@ -0,0 +1,15 @@
+package request
+
+import "e-complaint-api/entities"
+
+type Update struct {
+	ID         int `json:"id" form:"id"`
+	TargetDays int `json:"target_days" form:"target_days"`
+}
+
+func (r *Update) ToEntities() *entities.ComplaintSLA {
+	return &entities.ComplaintSLA{
+		ID:         r.ID,
+		TargetDays: r.TargetDays,
+	}
+}
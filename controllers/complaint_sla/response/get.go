@@ -0,0 +1,24 @@
+package response
+
+import (
+	category_response "e-complaint-api/controllers/category/response"
+	"e-complaint-api/entities"
+)
+
+type Get struct {
+	ID         int                   `json:"id"`
+	Category   category_response.Get `json:"category"`
+	Status     string                `json:"status"`
+	TargetDays int                   `json:"target_days"`
+	UpdatedAt  string                `json:"updated_at"`
+}
+
+func GetFromEntitiesToResponse(data *entities.ComplaintSLA) *Get {
+	return &Get{
+		ID:         data.ID,
+		Category:   *category_response.GetFromEntitiesToResponse(&data.Category),
+		Status:     data.Status,
+		TargetDays: data.TargetDays,
+		UpdatedAt:  data.UpdatedAt.Format("2 January 2006 15:04:05"),
+	}
+}
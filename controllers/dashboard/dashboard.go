@@ -21,6 +21,7 @@ func (ctrl *DashboardController) GetDashboardData(c echo.Context) error {
 	complaintsByStatus, _ := ctrl.DashboardUsecase.GetComplaintsByStatus()
 	usersByYearAndMonth, _ := ctrl.DashboardUsecase.GetUsersByYearAndMonth()
 	latestComplaints, _ := ctrl.DashboardUsecase.GetLatestComplaints(5)
+	slaByRegency, _ := ctrl.DashboardUsecase.GetSLAByRegency()
 
 	numberedLatestComplaints := make([]response.NumberedComplaintResponse, len(latestComplaints))
 	for i, complaint := range latestComplaints {
@@ -44,6 +45,7 @@ func (ctrl *DashboardController) GetDashboardData(c echo.Context) error {
 		ComplaintsByStatus:  complaintsByStatus,
 		UsersByYearAndMonth: usersByYearAndMonth,
 		LatestComplaints:    numberedLatestComplaints,
+		SLAByRegency:        slaByRegency,
 	}
 
 	return c.JSON(http.StatusOK, base.NewSuccessResponse("Dashboard data retrieved successfully", resp))
@@ -5,6 +5,7 @@ type DashboardResponse struct {
 	ComplaintsByStatus  map[string]int64            `json:"complaintsByStatus"`
 	UsersByYearAndMonth map[string][]MonthData      `json:"usersByYearAndMonth"`
 	LatestComplaints    []NumberedComplaintResponse `json:"latestComplaints"`
+	SLAByRegency        []SLAByRegency              `json:"slaByRegency"`
 }
 
 type MonthData struct {
@@ -12,6 +13,12 @@ type MonthData struct {
 	Count int64  `json:"count"`
 }
 
+type SLAByRegency struct {
+	Regency  string `json:"regency"`
+	OnTime   int64  `json:"onTime"`
+	Breached int64  `json:"breached"`
+}
+
 type User struct {
 	Name string `json:"name"`
 }
@@ -1,11 +1,14 @@
 package discussion
 
 import (
+	"e-complaint-api/constants"
 	"e-complaint-api/controllers/base"
+	complaint_response "e-complaint-api/controllers/complaint/response"
 	"e-complaint-api/controllers/discussion/request"
 	"e-complaint-api/controllers/discussion/response"
 	"e-complaint-api/entities"
 	"e-complaint-api/utils"
+	"log"
 	"net/http"
 	"strconv"
 
@@ -16,16 +19,45 @@ type DiscussionController struct {
 	discussionUseCase        entities.DiscussionUseCaseInterface
 	complaintUsecase         entities.ComplaintUseCaseInterface
 	complaintActivityUseCase entities.ComplaintActivityUseCaseInterface
+	notificationUseCase      entities.NotificationUseCaseInterface
+	complaintEventUseCase    entities.ComplaintEventUseCaseInterface
+	webhookUseCase           entities.WebhookUseCaseInterface
+	roleUseCase              entities.RoleUseCaseInterface
 }
 
-func NewDiscussionController(discussionUseCase entities.DiscussionUseCaseInterface, complaintUsecase entities.ComplaintUseCaseInterface, complaintActivityUseCase entities.ComplaintActivityUseCaseInterface) *DiscussionController {
+func NewDiscussionController(discussionUseCase entities.DiscussionUseCaseInterface, complaintUsecase entities.ComplaintUseCaseInterface, complaintActivityUseCase entities.ComplaintActivityUseCaseInterface, notificationUseCase entities.NotificationUseCaseInterface, complaintEventUseCase entities.ComplaintEventUseCaseInterface, webhookUseCase entities.WebhookUseCaseInterface, roleUseCase entities.RoleUseCaseInterface) *DiscussionController {
 	return &DiscussionController{
 		discussionUseCase:        discussionUseCase,
 		complaintUsecase:         complaintUsecase,
 		complaintActivityUseCase: complaintActivityUseCase,
+		notificationUseCase:      notificationUseCase,
+		complaintEventUseCase:    complaintEventUseCase,
+		webhookUseCase:           webhookUseCase,
+		roleUseCase:              roleUseCase,
 	}
 }
 
+// getComplaint returns the complaint of the request when it is in the scope of the
+// logged in account.
+func (dc *DiscussionController) getComplaint(c echo.Context, complaintID string) (entities.Complaint, error) {
+	complaint, err := dc.complaintUsecase.GetByID(complaintID)
+	if err != nil {
+		return entities.Complaint{}, err
+	}
+
+	principal, err := utils.GetPrincipal(c)
+	if err != nil {
+		return entities.Complaint{}, err
+	}
+
+	err = dc.roleUseCase.EnsureInScope(principal.ID, principal.Role, complaint)
+	if err != nil {
+		return entities.Complaint{}, err
+	}
+
+	return complaint, nil
+}
+
 func (dc *DiscussionController) CreateDiscussion(c echo.Context) error {
 	complaintID := c.Param("complaint-id")
 	if complaintID == "" {
@@ -34,37 +66,31 @@ func (dc *DiscussionController) CreateDiscussion(c echo.Context) error {
 		})
 	}
 
-	_, err := dc.complaintUsecase.GetByID(complaintID)
+	complaint, err := dc.getComplaint(c, complaintID)
 	if err != nil {
-		return c.JSON(http.StatusNotFound, base.NewErrorResponse("Complaint not found"))
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
 	}
 
-	userID, err := utils.GetIDFromJWT(c)
+	principal, err := utils.GetPrincipal(c)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, base.NewErrorResponse(err.Error()))
 	}
 
-	role, err := utils.GetRoleFromJWT(c)
-	if err != nil {
-		return c.JSON(http.StatusInternalServerError, base.NewErrorResponse(err.Error()))
-
-	}
-
 	var req request.CreateDiscussion
 	if err := c.Bind(&req); err != nil {
 		return c.JSON(http.StatusBadRequest, base.NewErrorResponse(err.Error()))
 
 	}
 
-	if role == "admin" || role == "super_admin" {
-		req.AdminID = &userID
+	if principal.Role == "admin" || principal.Role == "super_admin" {
+		req.AdminID = &principal.ID
 		req.UserID = nil
 	} else {
-		req.UserID = &userID
+		req.UserID = &principal.ID
 		req.AdminID = nil
 	}
 
-	discussionEntity := req.ToEntities(userID, complaintID, role)
+	discussionEntity := req.ToEntities(principal.ID, complaintID, principal.Role)
 	err = dc.discussionUseCase.Create(discussionEntity)
 	if err != nil {
 		return c.JSON(http.StatusBadRequest, base.NewErrorResponse(err.Error()))
@@ -85,7 +111,33 @@ func (dc *DiscussionController) CreateDiscussion(c echo.Context) error {
 		return c.JSON(http.StatusInternalServerError, base.NewErrorResponse(err.Error()))
 	}
 
+	err = dc.notificationUseCase.NotifyDiscussion(complaint, *createdDiscussion)
+	if err != nil {
+		log.Printf("complaint %s: notify discussion failed: %v", complaintID, err)
+	}
+
 	discussionResponse := response.FromEntitiesToResponse(createdDiscussion)
+	err = dc.complaintEventUseCase.Publish(complaintID, constants.ComplaintEventDiscussion, discussionResponse)
+	if err != nil {
+		log.Printf("complaint %s: publish event failed: %v", complaintID, err)
+	}
+
+	webhookData := complaint_response.WebhookFromEntitiesToResponse(&complaint)
+	webhookData.Discussion = discussionResponse
+	if complaint.Type == "private" && discussionResponse.User != nil {
+		// The reporter of a private complaint stays anonymous in its discussions too
+		anonymousDiscussion := *discussionResponse
+		anonymousDiscussion.User = &response.User{
+			ID:   webhookData.Complaint.User.ID,
+			Name: webhookData.Complaint.User.Name,
+		}
+		webhookData.Discussion = &anonymousDiscussion
+	}
+	err = dc.webhookUseCase.Dispatch(constants.WebhookEventDiscussionCreated, webhookData)
+	if err != nil {
+		log.Printf("complaint %s: dispatch webhook failed: %v", complaintID, err)
+	}
+
 	return c.JSON(http.StatusCreated, base.NewSuccessResponse("Discussion created successfully", discussionResponse))
 }
 
@@ -95,9 +147,25 @@ func (dc *DiscussionController) GetDiscussionByComplaintID(c echo.Context) error
 		return c.JSON(http.StatusBadRequest, base.NewErrorResponse("Complaint Is Required"))
 	}
 
-	_, err := dc.complaintUsecase.GetByID(complaintID)
+	_, err := dc.getComplaint(c, complaintID)
 	if err != nil {
-		return c.JSON(http.StatusNotFound, base.NewErrorResponse("Complaint not found"))
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	// a cursor query param, empty for the first page, switches to cursor pagination
+	if c.QueryParams().Has("cursor") {
+		limit, _ := strconv.Atoi(c.QueryParam("limit"))
+		discussions, nextCursor, err := dc.discussionUseCase.GetByComplaintIDCursor(complaintID, limit, c.QueryParam("cursor"))
+		if err != nil {
+			return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+		}
+
+		discussionsResponse := []*response.DiscussionGet{}
+		for _, discussion := range discussions {
+			discussionsResponse = append(discussionsResponse, response.FromEntitiesGetToResponse(&discussion))
+		}
+
+		return c.JSON(http.StatusOK, base.NewSuccessResponseWithMetadata("Discussion found", discussionsResponse, *base.NewCursorMetadata(nextCursor)))
 	}
 
 	discussions, err := dc.discussionUseCase.GetByComplaintID(complaintID)
@@ -125,9 +193,9 @@ func (dc *DiscussionController) UpdateDiscussion(c echo.Context) error {
 
 	}
 
-	_, err := dc.complaintUsecase.GetByID(complaintID)
+	_, err := dc.getComplaint(c, complaintID)
 	if err != nil {
-		return c.JSON(http.StatusNotFound, base.NewErrorResponse("Complaint not found"))
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
 	}
 
 	discussionIDStr := c.Param("discussion-id")
@@ -146,12 +214,12 @@ func (dc *DiscussionController) UpdateDiscussion(c echo.Context) error {
 		return c.JSON(http.StatusNotFound, base.NewErrorResponse("Discussion not found"))
 	}
 
-	userID, err := utils.GetIDFromJWT(c)
+	principal, err := utils.GetPrincipal(c)
 	if err != nil {
 		return c.JSON(http.StatusUnauthorized, base.NewErrorResponse(err.Error()))
 	}
 
-	if discussion.UserID != nil && *discussion.UserID != userID {
+	if discussion.UserID != nil && *discussion.UserID != principal.ID {
 		return c.JSON(http.StatusUnauthorized, base.NewErrorResponse("You are not authorized to update this discussion"))
 	}
 
@@ -190,9 +258,9 @@ func (dc *DiscussionController) DeleteDiscussion(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, base.NewErrorResponse("Complaint ID is required"))
 	}
 
-	_, err := dc.complaintUsecase.GetByID(complaintID)
+	_, err := dc.getComplaint(c, complaintID)
 	if err != nil {
-		return c.JSON(http.StatusNotFound, base.NewErrorResponse("Complaint not found"))
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
 	}
 
 	discussionIDStr := c.Param("discussion-id")
@@ -209,17 +277,12 @@ func (dc *DiscussionController) DeleteDiscussion(c echo.Context) error {
 		return c.JSON(http.StatusNotFound, base.NewErrorResponse("Discussion not found"))
 	}
 
-	userID, err := utils.GetIDFromJWT(c)
+	principal, err := utils.GetPrincipal(c)
 	if err != nil {
 		return c.JSON(http.StatusUnauthorized, base.NewErrorResponse(err.Error()))
 	}
 
-	role, err := utils.GetRoleFromJWT(c)
-	if err != nil {
-		return c.JSON(http.StatusUnauthorized, base.NewErrorResponse(err.Error()))
-	}
-
-	if role != "admin" && discussion.UserID != nil && *discussion.UserID != userID {
+	if principal.Role != "admin" && discussion.UserID != nil && *discussion.UserID != principal.ID {
 		return c.JSON(http.StatusUnauthorized, base.NewErrorResponse("You are not authorized to delete this discussion"))
 	}
 
@@ -245,7 +308,12 @@ func (dc *DiscussionController) GetAnswerRecommendation(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, base.NewErrorResponse("Complaint ID is required"))
 	}
 
-	answer, err := dc.discussionUseCase.GetAnswerRecommendation(complaintID)
+	_, err := dc.getComplaint(c, complaintID)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	answer, err := dc.discussionUseCase.GetAnswerRecommendation(c.Request().Context(), complaintID)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, base.NewErrorResponse(err.Error()))
 	}
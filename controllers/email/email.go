@@ -0,0 +1,57 @@
+package email
+
+import (
+	"e-complaint-api/controllers/base"
+	"e-complaint-api/controllers/email/request"
+	"e-complaint-api/controllers/email/response"
+	"e-complaint-api/entities"
+	"e-complaint-api/utils"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type EmailController struct {
+	emailUseCase entities.EmailUseCaseInterface
+}
+
+func NewEmailController(emailUseCase entities.EmailUseCaseInterface) *EmailController {
+	return &EmailController{
+		emailUseCase: emailUseCase,
+	}
+}
+
+func (ec *EmailController) GetPreferences(c echo.Context) error {
+	principal, err := utils.GetPrincipal(c)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	preferences, err := ec.emailUseCase.GetPreferences(principal.ID)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	return c.JSON(http.StatusOK, base.NewSuccessResponse("Success Get Email Preferences", response.PreferencesFromEntitiesToResponse(preferences)))
+}
+
+// UpdatePreferences changes the preferences of the events in the request, the events
+// left out keep theirs.
+func (ec *EmailController) UpdatePreferences(c echo.Context) error {
+	principal, err := utils.GetPrincipal(c)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	var req request.UpdatePreferences
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, base.NewErrorResponse(err.Error()))
+	}
+
+	preferences, err := ec.emailUseCase.UpdatePreferences(principal.ID, req.ToEntities())
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	return c.JSON(http.StatusOK, base.NewSuccessResponse("Success Update Email Preferences", response.PreferencesFromEntitiesToResponse(preferences)))
+}
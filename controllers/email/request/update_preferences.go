@@ -0,0 +1,24 @@
+package request
+
+import "e-complaint-api/entities"
+
+type Preference struct {
+	Event   string `json:"event"`
+	Enabled bool   `json:"enabled"`
+}
+
+type UpdatePreferences struct {
+	Preferences []Preference `json:"preferences"`
+}
+
+func (req *UpdatePreferences) ToEntities() []entities.EmailPreference {
+	preferences := []entities.EmailPreference{}
+	for _, preference := range req.Preferences {
+		preferences = append(preferences, entities.EmailPreference{
+			Event:   preference.Event,
+			Enabled: preference.Enabled,
+		})
+	}
+
+	return preferences
+}
@@ -0,0 +1,20 @@
+package response
+
+import "e-complaint-api/entities"
+
+type Preference struct {
+	Event   string `json:"event"`
+	Enabled bool   `json:"enabled"`
+}
+
+func PreferencesFromEntitiesToResponse(data []entities.EmailPreference) []*Preference {
+	responses := []*Preference{}
+	for _, preference := range data {
+		responses = append(responses, &Preference{
+			Event:   preference.Event,
+			Enabled: preference.Enabled,
+		})
+	}
+
+	return responses
+}
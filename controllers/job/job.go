@@ -0,0 +1,76 @@
+package job
+
+import (
+	"e-complaint-api/constants"
+	"e-complaint-api/controllers/base"
+	"e-complaint-api/controllers/job/response"
+	"e-complaint-api/entities"
+	"e-complaint-api/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type JobController struct {
+	jobUseCase entities.JobUseCaseInterface
+}
+
+func NewJobController(jobUseCase entities.JobUseCaseInterface) *JobController {
+	return &JobController{
+		jobUseCase: jobUseCase,
+	}
+}
+
+// GetAll lists the jobs newest first, one page per cursor. The status and type query
+// params filter the list.
+func (jc *JobController) GetAll(c echo.Context) error {
+	filter := map[string]interface{}{}
+	if status := c.QueryParam("status"); status != "" {
+		filter["status"] = status
+	}
+	if jobType := c.QueryParam("type"); jobType != "" {
+		filter["type"] = jobType
+	}
+
+	limit, _ := strconv.Atoi(c.QueryParam("limit"))
+	jobs, nextCursor, err := jc.jobUseCase.GetByCursor(filter, limit, c.QueryParam("cursor"))
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	jobResponses := []*response.Get{}
+	for _, job := range jobs {
+		jobResponses = append(jobResponses, response.GetFromEntitiesToResponse(&job))
+	}
+
+	return c.JSON(http.StatusOK, base.NewSuccessResponseWithMetadata("Success Get Jobs", jobResponses, *base.NewCursorMetadata(nextCursor)))
+}
+
+func (jc *JobController) GetByID(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, base.NewErrorResponse(constants.ErrInvalidIDFormat.Error()))
+	}
+
+	job, err := jc.jobUseCase.GetByID(id)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	return c.JSON(http.StatusOK, base.NewSuccessResponse("Success Get Job", response.GetFromEntitiesToResponse(&job)))
+}
+
+func (jc *JobController) Retry(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, base.NewErrorResponse(constants.ErrInvalidIDFormat.Error()))
+	}
+
+	job, err := jc.jobUseCase.Retry(id)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	return c.JSON(http.StatusOK, base.NewSuccessResponse("Success Retry Job", response.GetFromEntitiesToResponse(&job)))
+}
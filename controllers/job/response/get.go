@@ -0,0 +1,43 @@
+package response
+
+import (
+	"e-complaint-api/entities"
+	"encoding/json"
+)
+
+// Get leaves out the payload of the job, which may hold secrets like the OTP of an
+// email. A result that is JSON is embedded as is.
+type Get struct {
+	ID          int         `json:"id"`
+	Type        string      `json:"type"`
+	Status      string      `json:"status"`
+	Attempts    int         `json:"attempts"`
+	MaxAttempts int         `json:"max_attempts"`
+	RunAt       string      `json:"run_at"`
+	LastError   string      `json:"last_error"`
+	Result      interface{} `json:"result"`
+	CreatedBy   *int        `json:"created_by"`
+	CreatedAt   string      `json:"created_at"`
+	UpdatedAt   string      `json:"updated_at"`
+}
+
+func GetFromEntitiesToResponse(data *entities.Job) *Get {
+	var result interface{} = data.Result
+	if json.Valid([]byte(data.Result)) {
+		result = json.RawMessage(data.Result)
+	}
+
+	return &Get{
+		ID:          data.ID,
+		Type:        data.Type,
+		Status:      data.Status,
+		Attempts:    data.Attempts,
+		MaxAttempts: data.MaxAttempts,
+		RunAt:       data.RunAt.Format("2 January 2006 15:04:05"),
+		LastError:   data.LastError,
+		Result:      result,
+		CreatedBy:   data.CreatedBy,
+		CreatedAt:   data.CreatedAt.Format("2 January 2006 15:04:05"),
+		UpdatedAt:   data.UpdatedAt.Format("2 January 2006 15:04:05"),
+	}
+}
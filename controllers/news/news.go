@@ -4,6 +4,8 @@ import (
 	"e-complaint-api/constants"
 	"e-complaint-api/controllers/base"
 	"e-complaint-api/entities"
+	"e-complaint-api/queryspec"
+	"e-complaint-api/upload"
 	"e-complaint-api/utils"
 	"net/http"
 	"strconv"
@@ -31,27 +33,32 @@ func (nc *NewsController) GetPaginated(c echo.Context) error {
 	limit, _ := strconv.Atoi(c.QueryParam("limit"))
 	page, _ := strconv.Atoi(c.QueryParam("page"))
 	search := c.QueryParam("search")
-	category_filter, _ := strconv.Atoi(c.QueryParam("category_id"))
-	filter := map[string]interface{}{}
-	if category_filter == 0 {
+	filter, err := queryspec.News.Parse(c.QueryParams())
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+	if len(filter) == 0 {
 		filter = nil
-	} else {
-		filter["category_id"] = category_filter
 	}
 
 	sort_by := c.QueryParam("sort_by")
 	sort_type := c.QueryParam("sort_type")
 
+	// a cursor query param, empty for the first page, switches to cursor pagination
+	if c.QueryParams().Has("cursor") {
+		news, nextCursor, err := nc.newsUseCase.GetByCursor(limit, c.QueryParam("cursor"), search, filter, sort_by, sort_type)
+		if err != nil {
+			return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+		}
+
+		return c.JSON(http.StatusOK, base.NewSuccessResponseWithMetadata("Success Get News", newsResponses(news), *base.NewCursorMetadata(nextCursor)))
+	}
+
 	news, err := nc.newsUseCase.GetPaginated(limit, page, search, filter, sort_by, sort_type)
 	if err != nil {
 		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
 	}
 
-	newsResponses := []*news_response.Get{}
-	for _, news := range news {
-		newsResponses = append(newsResponses, news_response.GetFromEntitiesToResponse(&news))
-	}
-
 	metaData, err := nc.newsUseCase.GetMetaData(limit, page, search, filter)
 	if err != nil {
 		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
@@ -59,7 +66,16 @@ func (nc *NewsController) GetPaginated(c echo.Context) error {
 
 	metaDataResponse := base.NewMetadata(metaData.TotalData, metaData.Pagination.TotalDataPerPage, metaData.Pagination.FirstPage, metaData.Pagination.LastPage, metaData.Pagination.CurrentPage, metaData.Pagination.NextPage, metaData.Pagination.PrevPage)
 
-	return c.JSON(http.StatusOK, base.NewSuccessResponseWithMetadata("Success Get News", newsResponses, *metaDataResponse))
+	return c.JSON(http.StatusOK, base.NewSuccessResponseWithMetadata("Success Get News", newsResponses(news), *metaDataResponse))
+}
+
+func newsResponses(news []entities.News) []*news_response.Get {
+	responses := []*news_response.Get{}
+	for _, news := range news {
+		responses = append(responses, news_response.GetFromEntitiesToResponse(&news))
+	}
+
+	return responses
 }
 
 func (nc *NewsController) GetByID(c echo.Context) error {
@@ -76,7 +92,7 @@ func (nc *NewsController) GetByID(c echo.Context) error {
 }
 
 func (nc *NewsController) Create(c echo.Context) error {
-	admin_id, err := utils.GetIDFromJWT(c)
+	principal, err := utils.GetPrincipal(c)
 	if err != nil {
 		return c.JSON(http.StatusUnauthorized, base.NewErrorResponse(err.Error()))
 	}
@@ -85,7 +101,7 @@ func (nc *NewsController) Create(c echo.Context) error {
 	if err := c.Bind(&newsRequest); err != nil {
 		return c.JSON(http.StatusBadRequest, base.NewErrorResponse(err.Error()))
 	}
-	newsRequest.AdminID = admin_id
+	newsRequest.AdminID = principal.ID
 
 	form, err := c.MultipartForm()
 	if err != nil {
@@ -93,17 +109,9 @@ func (nc *NewsController) Create(c echo.Context) error {
 	}
 	files := form.File["files"]
 
-	if len(files) > 3 {
-		return c.JSON(http.StatusBadRequest, base.NewErrorResponse("Maximum 3 files allowed"))
-	}
-
-	totalFileSize := 0
-	for _, file := range files {
-		totalFileSize += int(file.Size)
-	}
-
-	if totalFileSize > 10*1024*1024 {
-		return c.JSON(http.StatusBadRequest, base.NewErrorResponse(constants.ErrMaxFileSizeExceeded.Error()))
+	err = upload.NewsFiles.Check(files)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
 	}
 
 	news, err := nc.newsUseCase.Create(newsRequest.ToEntities())
@@ -154,7 +162,7 @@ func (nc *NewsController) Update(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, base.NewErrorResponse(constants.ErrInvalidIDFormat.Error()))
 	}
 
-	admin_id, err := utils.GetIDFromJWT(c)
+	principal, err := utils.GetPrincipal(c)
 	if err != nil {
 		return c.JSON(http.StatusUnauthorized, base.NewErrorResponse(err.Error()))
 	}
@@ -164,7 +172,7 @@ func (nc *NewsController) Update(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, base.NewErrorResponse(err.Error()))
 	}
 	newsRequest.ID = id
-	newsRequest.AdminID = admin_id
+	newsRequest.AdminID = principal.ID
 
 	form, err := c.MultipartForm()
 	if err != nil {
@@ -172,19 +180,9 @@ func (nc *NewsController) Update(c echo.Context) error {
 	}
 
 	files := form.File["files"]
-	if len(files) != 0 {
-		if len(files) > 3 {
-			return c.JSON(http.StatusBadRequest, base.NewErrorResponse(constants.ErrMaxFileCountExceeded.Error()))
-		}
-
-		totalFileSize := 0
-		for _, file := range files {
-			totalFileSize += int(file.Size)
-		}
-
-		if totalFileSize > 10*1024*1024 {
-			return c.JSON(http.StatusBadRequest, base.NewErrorResponse(constants.ErrMaxFileSizeExceeded.Error()))
-		}
+	err = upload.NewsFiles.Check(files)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
 	}
 
 	news, err := nc.newsUseCase.Update(*newsRequest.ToEntities())
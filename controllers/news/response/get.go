@@ -22,9 +22,10 @@ func GetFromEntitiesToResponse(data *entities.News) *Get {
 	var files []file_response.NewsFile
 	for _, file := range data.Files {
 		files = append(files, file_response.NewsFile{
-			ID:     file.ID,
-			NewsID: file.NewsID,
-			Path:   file.Path,
+			ID:            file.ID,
+			NewsID:        file.NewsID,
+			Path:          file.Path,
+			ThumbnailPath: file.ThumbnailPath,
 		})
 	}
 
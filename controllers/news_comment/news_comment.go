@@ -39,31 +39,25 @@ func (n *NewsCommentController) CommentNews(ctx echo.Context) error {
 		return ctx.JSON(http.StatusBadRequest, base.NewErrorResponse("News not found"))
 	}
 
-	userID, err := utils.GetIDFromJWT(ctx)
+	principal, err := utils.GetPrincipal(ctx)
 	if err != nil {
 		return ctx.JSON(http.StatusInternalServerError, base.NewErrorResponse(err.Error()))
 	}
 
-	role, err := utils.GetRoleFromJWT(ctx)
-	if err != nil {
-		return ctx.JSON(http.StatusInternalServerError, base.NewErrorResponse(err.Error()))
-
-	}
-
 	var req request.CommentNews
 	if err := ctx.Bind(&req); err != nil {
 		return ctx.JSON(http.StatusBadRequest, base.NewErrorResponse(err.Error()))
 	}
 
-	if role == "admin" || role == "super_admin" {
-		req.AdminID = &userID
+	if principal.Role == "admin" || principal.Role == "super_admin" {
+		req.AdminID = &principal.ID
 		req.UserID = nil
 	} else {
-		req.UserID = &userID
+		req.UserID = &principal.ID
 		req.AdminID = nil
 	}
 
-	comment := req.ToEntities(userID, newsID, role)
+	comment := req.ToEntities(principal.ID, newsID, principal.Role)
 	if err := n.newsCommentRepo.CommentNews(comment); err != nil {
 		return ctx.JSON(http.StatusBadRequest, base.NewErrorResponse(err.Error()))
 	}
@@ -144,12 +138,12 @@ func (n *NewsCommentController) UpdateComment(ctx echo.Context) error {
 		return ctx.JSON(http.StatusBadRequest, base.NewErrorResponse("Comment does not belong to the specified news"))
 	}
 
-	userID, err := utils.GetIDFromJWT(ctx)
+	principal, err := utils.GetPrincipal(ctx)
 	if err != nil {
 		return ctx.JSON(http.StatusInternalServerError, base.NewErrorResponse(err.Error()))
 	}
 
-	if comment.UserID != nil && *comment.UserID != userID {
+	if comment.UserID != nil && *comment.UserID != principal.ID {
 		return ctx.JSON(http.StatusUnauthorized, base.NewErrorResponse("You are not authorized to update this comment"))
 	}
 
@@ -196,17 +190,12 @@ func (n *NewsCommentController) DeleteComment(ctx echo.Context) error {
 		return ctx.JSON(http.StatusBadRequest, base.NewErrorResponse("Comment does not belong to the specified news"))
 	}
 
-	userID, err := utils.GetIDFromJWT(ctx)
-	if err != nil {
-		return ctx.JSON(http.StatusInternalServerError, base.NewErrorResponse(err.Error()))
-	}
-
-	role, err := utils.GetRoleFromJWT(ctx)
+	principal, err := utils.GetPrincipal(ctx)
 	if err != nil {
 		return ctx.JSON(http.StatusInternalServerError, base.NewErrorResponse(err.Error()))
 	}
 
-	if role != "admin" && comment.UserID != nil && *comment.UserID != userID {
+	if principal.Role != "admin" && comment.UserID != nil && *comment.UserID != principal.ID {
 		return ctx.JSON(http.StatusUnauthorized, base.NewErrorResponse("You are not authorized to delete this comment"))
 	}
 
@@ -37,13 +37,13 @@ func (n *NewsLikeController) ToggleLike(ctx echo.Context) error {
 		return ctx.JSON(http.StatusNotFound, base.NewErrorResponse("News not found"))
 	}
 
-	userID, err := utils.GetIDFromJWT(ctx)
+	principal, err := utils.GetPrincipal(ctx)
 	if err != nil {
 		return ctx.JSON(http.StatusInternalServerError, base.NewErrorResponse(err.Error()))
 	}
 
 	newsLike := &entities.NewsLike{
-		UserID: userID,
+		UserID: principal.ID,
 		NewsID: newsID,
 	}
 
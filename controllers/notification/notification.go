@@ -0,0 +1,100 @@
+package notification
+
+import (
+	"e-complaint-api/controllers/base"
+	"e-complaint-api/controllers/notification/response"
+	"e-complaint-api/entities"
+	"e-complaint-api/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type NotificationController struct {
+	notificationUseCase entities.NotificationUseCaseInterface
+}
+
+func NewNotificationController(notificationUseCase entities.NotificationUseCaseInterface) *NotificationController {
+	return &NotificationController{
+		notificationUseCase: notificationUseCase,
+	}
+}
+
+func (nc *NotificationController) GetAll(c echo.Context) error {
+	principal, err := utils.GetPrincipal(c)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	// a cursor query param, empty for the first page, switches to cursor pagination
+	if c.QueryParams().Has("cursor") {
+		limit, _ := strconv.Atoi(c.QueryParam("limit"))
+		notifications, nextCursor, err := nc.notificationUseCase.GetByRecipientCursor(principal.ID, principal.Role, limit, c.QueryParam("cursor"))
+		if err != nil {
+			return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+		}
+
+		return c.JSON(http.StatusOK, base.NewSuccessResponseWithMetadata("Success Get Notifications", notificationResponses(notifications), *base.NewCursorMetadata(nextCursor)))
+	}
+
+	notifications, err := nc.notificationUseCase.GetByRecipient(principal.ID, principal.Role)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	return c.JSON(http.StatusOK, base.NewSuccessResponse("Success Get Notifications", notificationResponses(notifications)))
+}
+
+func notificationResponses(notifications []entities.Notification) []*response.Get {
+	responses := []*response.Get{}
+	for _, notification := range notifications {
+		responses = append(responses, response.GetFromEntitiesToResponse(&notification))
+	}
+
+	return responses
+}
+
+func (nc *NotificationController) MarkAsRead(c echo.Context) error {
+	principal, err := utils.GetPrincipal(c)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	id, _ := strconv.Atoi(c.Param("id"))
+
+	err = nc.notificationUseCase.MarkAsRead(id, principal.ID, principal.Role)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	return c.JSON(http.StatusOK, base.NewSuccessResponse("Success Mark Notification As Read", nil))
+}
+
+func (nc *NotificationController) MarkAllAsRead(c echo.Context) error {
+	principal, err := utils.GetPrincipal(c)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	err = nc.notificationUseCase.MarkAllAsRead(principal.ID, principal.Role)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	return c.JSON(http.StatusOK, base.NewSuccessResponse("Success Mark All Notifications As Read", nil))
+}
+
+func (nc *NotificationController) GetUnreadCount(c echo.Context) error {
+	principal, err := utils.GetPrincipal(c)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	count, err := nc.notificationUseCase.GetUnreadCount(principal.ID, principal.Role)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	return c.JSON(http.StatusOK, base.NewSuccessResponse("Success Get Unread Notification Count", response.UnreadCount{UnreadCount: count}))
+}
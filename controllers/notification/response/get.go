@@ -0,0 +1,23 @@
+package response
+
+import "e-complaint-api/entities"
+
+type Get struct {
+	ID          int    `json:"id"`
+	ComplaintID string `json:"complaint_id"`
+	Type        string `json:"type"`
+	Message     string `json:"message"`
+	IsRead      bool   `json:"is_read"`
+	CreatedAt   string `json:"created_at"`
+}
+
+func GetFromEntitiesToResponse(data *entities.Notification) *Get {
+	return &Get{
+		ID:          data.ID,
+		ComplaintID: data.ComplaintID,
+		Type:        data.Type,
+		Message:     data.Message,
+		IsRead:      data.IsRead,
+		CreatedAt:   data.CreatedAt.Format("2 January 2006 15:04:05"),
+	}
+}
@@ -0,0 +1,5 @@
+package response
+
+type UnreadCount struct {
+	UnreadCount int64 `json:"unread_count"`
+}
@@ -0,0 +1,16 @@
+package request
+
+import "e-complaint-api/entities"
+
+type Create struct {
+	Name        string   `json:"name" form:"name"`
+	Description string   `json:"description" form:"description"`
+	Permissions []string `json:"permissions" form:"permissions"`
+}
+
+func (r *Create) ToEntities() *entities.Role {
+	return &entities.Role{
+		Name:        r.Name,
+		Description: r.Description,
+	}
+}
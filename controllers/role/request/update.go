@@ -0,0 +1,18 @@
+package request
+
+import "e-complaint-api/entities"
+
+type Update struct {
+	ID          int      `json:"id" form:"id"`
+	Name        string   `json:"name" form:"name"`
+	Description string   `json:"description" form:"description"`
+	Permissions []string `json:"permissions" form:"permissions"`
+}
+
+func (r *Update) ToEntities() *entities.Role {
+	return &entities.Role{
+		ID:          r.ID,
+		Name:        r.Name,
+		Description: r.Description,
+	}
+}
@@ -0,0 +1,7 @@
+package request
+
+type UpdateAdminAccess struct {
+	RoleID      *int     `json:"role_id" form:"role_id"`
+	RegencyIDs  []string `json:"regency_ids" form:"regency_ids"`
+	CategoryIDs []int    `json:"category_ids" form:"category_ids"`
+}
@@ -0,0 +1,38 @@
+package response
+
+import (
+	category_response "e-complaint-api/controllers/category/response"
+	regency_response "e-complaint-api/controllers/regency/response"
+	"e-complaint-api/entities"
+)
+
+type AdminAccess struct {
+	AdminID    int                         `json:"admin_id"`
+	Role       *Get                        `json:"role"`
+	Regencies  []*regency_response.Regency `json:"regencies"`
+	Categories []*category_response.Get    `json:"categories"`
+}
+
+func AdminAccessFromEntitiesToResponse(data *entities.Admin) *AdminAccess {
+	var role *Get
+	if data.Role != nil {
+		role = GetFromEntitiesToResponse(data.Role)
+	}
+
+	regencies := []*regency_response.Regency{}
+	for _, regency := range data.Regencies {
+		regencies = append(regencies, regency_response.FromEntitiesToResponse(&regency))
+	}
+
+	categories := []*category_response.Get{}
+	for _, category := range data.Categories {
+		categories = append(categories, category_response.GetFromEntitiesToResponse(&category))
+	}
+
+	return &AdminAccess{
+		AdminID:    data.ID,
+		Role:       role,
+		Regencies:  regencies,
+		Categories: categories,
+	}
+}
@@ -0,0 +1,40 @@
+package response
+
+import "e-complaint-api/entities"
+
+type Permission struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type Get struct {
+	ID          int      `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"`
+	UpdatedAt   string   `json:"updated_at"`
+}
+
+func PermissionFromEntitiesToResponse(data *entities.Permission) *Permission {
+	return &Permission{
+		ID:          data.ID,
+		Name:        data.Name,
+		Description: data.Description,
+	}
+}
+
+func GetFromEntitiesToResponse(data *entities.Role) *Get {
+	permissions := []string{}
+	for _, permission := range data.Permissions {
+		permissions = append(permissions, permission.Name)
+	}
+
+	return &Get{
+		ID:          data.ID,
+		Name:        data.Name,
+		Description: data.Description,
+		Permissions: permissions,
+		UpdatedAt:   data.UpdatedAt.Format("2 January 2006 15:04:05"),
+	}
+}
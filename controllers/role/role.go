@@ -0,0 +1,141 @@
+package role
+
+import (
+	"e-complaint-api/constants"
+	"e-complaint-api/controllers/base"
+	"e-complaint-api/controllers/role/request"
+	"e-complaint-api/controllers/role/response"
+	"e-complaint-api/entities"
+	"e-complaint-api/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type RoleController struct {
+	roleUseCase entities.RoleUseCaseInterface
+}
+
+func NewRoleController(roleUseCase entities.RoleUseCaseInterface) *RoleController {
+	return &RoleController{
+		roleUseCase: roleUseCase,
+	}
+}
+
+func (rc *RoleController) GetAll(c echo.Context) error {
+	roles, err := rc.roleUseCase.GetAll()
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	roleResponses := []*response.Get{}
+	for _, role := range roles {
+		roleResponses = append(roleResponses, response.GetFromEntitiesToResponse(&role))
+	}
+
+	return c.JSON(http.StatusOK, base.NewSuccessResponse("Success Get Roles", roleResponses))
+}
+
+func (rc *RoleController) GetByID(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, base.NewErrorResponse(constants.ErrInvalidIDFormat.Error()))
+	}
+
+	role, err := rc.roleUseCase.GetByID(id)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	return c.JSON(http.StatusOK, base.NewSuccessResponse("Success Get Role", response.GetFromEntitiesToResponse(&role)))
+}
+
+func (rc *RoleController) Create(c echo.Context) error {
+	var roleRequest request.Create
+	c.Bind(&roleRequest)
+
+	role, err := rc.roleUseCase.Create(roleRequest.ToEntities(), roleRequest.Permissions)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	return c.JSON(http.StatusCreated, base.NewSuccessResponse("Success Create Role", response.GetFromEntitiesToResponse(&role)))
+}
+
+func (rc *RoleController) Update(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, base.NewErrorResponse(constants.ErrInvalidIDFormat.Error()))
+	}
+
+	var roleRequest request.Update
+	c.Bind(&roleRequest)
+	roleRequest.ID = id
+
+	role, err := rc.roleUseCase.Update(roleRequest.ToEntities(), roleRequest.Permissions)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	return c.JSON(http.StatusOK, base.NewSuccessResponse("Success Update Role", response.GetFromEntitiesToResponse(&role)))
+}
+
+func (rc *RoleController) Delete(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, base.NewErrorResponse(constants.ErrInvalidIDFormat.Error()))
+	}
+
+	err = rc.roleUseCase.Delete(id)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	return c.JSON(http.StatusOK, base.NewSuccessResponse("Success Delete Role", nil))
+}
+
+func (rc *RoleController) GetPermissions(c echo.Context) error {
+	permissions, err := rc.roleUseCase.GetPermissions()
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	permissionResponses := []*response.Permission{}
+	for _, permission := range permissions {
+		permissionResponses = append(permissionResponses, response.PermissionFromEntitiesToResponse(&permission))
+	}
+
+	return c.JSON(http.StatusOK, base.NewSuccessResponse("Success Get Permissions", permissionResponses))
+}
+
+func (rc *RoleController) GetAdminAccess(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, base.NewErrorResponse(constants.ErrInvalidIDFormat.Error()))
+	}
+
+	admin, err := rc.roleUseCase.GetAdminAccess(id)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	return c.JSON(http.StatusOK, base.NewSuccessResponse("Success Get Admin Access", response.AdminAccessFromEntitiesToResponse(&admin)))
+}
+
+func (rc *RoleController) UpdateAdminAccess(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, base.NewErrorResponse(constants.ErrInvalidIDFormat.Error()))
+	}
+
+	var accessRequest request.UpdateAdminAccess
+	c.Bind(&accessRequest)
+
+	admin, err := rc.roleUseCase.UpdateAdminAccess(id, accessRequest.RoleID, accessRequest.RegencyIDs, accessRequest.CategoryIDs)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	return c.JSON(http.StatusOK, base.NewSuccessResponse("Success Update Admin Access", response.AdminAccessFromEntitiesToResponse(&admin)))
+}
@@ -0,0 +1,33 @@
+package response
+
+import (
+	"e-complaint-api/entities"
+	"math"
+)
+
+type Hit struct {
+	ID         string            `json:"id"`
+	Type       string            `json:"type"`
+	Reference  string            `json:"reference,omitempty"`
+	Score      float64           `json:"score"`
+	Highlights map[string]string `json:"highlights"`
+}
+
+func HitFromEntitiesToResponse(collection string, data *entities.SearchHit) *Hit {
+	return &Hit{
+		ID:         data.ID,
+		Type:       collection,
+		Reference:  data.Reference,
+		Score:      math.Round(data.Score*1000) / 1000,
+		Highlights: data.Highlights,
+	}
+}
+
+func HitFromEntitiesToResponses(collection string, data []entities.SearchHit) []*Hit {
+	hitResponses := []*Hit{}
+	for _, hit := range data {
+		hitResponses = append(hitResponses, HitFromEntitiesToResponse(collection, &hit))
+	}
+
+	return hitResponses
+}
@@ -0,0 +1,53 @@
+package search
+
+import (
+	"e-complaint-api/constants"
+	"e-complaint-api/controllers/base"
+	"e-complaint-api/controllers/search/response"
+	"e-complaint-api/entities"
+	"e-complaint-api/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type SearchController struct {
+	searchUseCase entities.SearchUseCaseInterface
+	roleUseCase   entities.RoleUseCaseInterface
+}
+
+func NewSearchController(searchUseCase entities.SearchUseCaseInterface, roleUseCase entities.RoleUseCaseInterface) *SearchController {
+	return &SearchController{
+		searchUseCase: searchUseCase,
+		roleUseCase:   roleUseCase,
+	}
+}
+
+// Search ranks the complaints, news or discussions, chosen by the type query param,
+// that match q and highlights the matching words. Admins only find the complaints and
+// discussions in their scope.
+func (sc *SearchController) Search(c echo.Context) error {
+	principal, err := utils.GetPrincipal(c)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	scope, err := sc.roleUseCase.GetScope(principal.ID, principal.Role)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	collection := c.QueryParam("type")
+	if collection == "" {
+		collection = constants.SearchComplaints
+	}
+	limit, _ := strconv.Atoi(c.QueryParam("limit"))
+
+	hits, err := sc.searchUseCase.Search(collection, c.QueryParam("q"), limit, scope)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	return c.JSON(http.StatusOK, base.NewSuccessResponse("Success Search", response.HitFromEntitiesToResponses(collection, hits)))
+}
@@ -0,0 +1,17 @@
+package response
+
+import "e-complaint-api/entities"
+
+type Token struct {
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresAt    string `json:"expires_at"`
+}
+
+func TokenFromEntitiesToResponse(data *entities.AuthToken) *Token {
+	return &Token{
+		Token:        data.AccessToken,
+		RefreshToken: data.RefreshToken,
+		ExpiresAt:    data.ExpiresAt.Format("2 January 2006 15:04:05"),
+	}
+}
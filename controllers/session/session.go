@@ -0,0 +1,48 @@
+package session
+
+import (
+	"e-complaint-api/controllers/base"
+	"e-complaint-api/controllers/session/request"
+	"e-complaint-api/controllers/session/response"
+	"e-complaint-api/entities"
+	"e-complaint-api/utils"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type SessionController struct {
+	sessionUseCase entities.SessionUseCaseInterface
+}
+
+func NewSessionController(sessionUseCase entities.SessionUseCaseInterface) *SessionController {
+	return &SessionController{
+		sessionUseCase: sessionUseCase,
+	}
+}
+
+func (sc *SessionController) Refresh(c echo.Context) error {
+	var refreshRequest request.Refresh
+	c.Bind(&refreshRequest)
+
+	authToken, err := sc.sessionUseCase.Refresh(refreshRequest.RefreshToken)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	return c.JSON(http.StatusOK, base.NewSuccessResponse("Success Refresh Token", response.TokenFromEntitiesToResponse(&authToken)))
+}
+
+func (sc *SessionController) Logout(c echo.Context) error {
+	principal, err := utils.GetPrincipal(c)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	err = sc.sessionUseCase.Logout(principal.SessionID)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	return c.JSON(http.StatusOK, base.NewSuccessResponse("Success Logout", nil))
+}
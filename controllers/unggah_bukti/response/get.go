@@ -0,0 +1,21 @@
+package response
+
+import "e-complaint-api/entities"
+
+// Get leaves out the storage path of the evidence, the file is downloaded through a
+// signed URL instead.
+type Get struct {
+	ID              int64  `json:"id"`
+	ComplaintID     string `json:"complaint_id"`
+	PenanggungJawab string `json:"penanggung_jawab"`
+	FinishedOn      string `json:"finished_on"`
+}
+
+func GetFromEntitiesToResponse(data *entities.UnggahBukti) *Get {
+	return &Get{
+		ID:              data.ID,
+		ComplaintID:     data.ComplaintID,
+		PenanggungJawab: data.PenanggungJawab,
+		FinishedOn:      data.FinishedOn.Format("2006-01-02"),
+	}
+}
@@ -1,65 +1,79 @@
 package unggah_bukti
 
 import (
+	"e-complaint-api/constants"
+	complaint_response "e-complaint-api/controllers/complaint/response"
+	"e-complaint-api/controllers/unggah_bukti/response"
 	"e-complaint-api/entities"
+	"e-complaint-api/utils"
 	"github.com/labstack/echo/v4"
-	"io"
+	"log"
 	"net/http"
-	"os"
 	"strconv"
 	"time"
 )
 
 type UnggahBuktiController struct {
-	usecase entities.UnggahBuktiUseCaseInterface
+	usecase               entities.UnggahBuktiUseCaseInterface
+	complaintUseCase      entities.ComplaintUseCaseInterface
+	complaintEventUseCase entities.ComplaintEventUseCaseInterface
+	webhookUseCase        entities.WebhookUseCaseInterface
+	roleUseCase           entities.RoleUseCaseInterface
 }
 
-func NewUnggahBuktiController(usecase entities.UnggahBuktiUseCaseInterface) *UnggahBuktiController {
-	return &UnggahBuktiController{usecase: usecase}
+func NewUnggahBuktiController(usecase entities.UnggahBuktiUseCaseInterface, complaintUseCase entities.ComplaintUseCaseInterface, complaintEventUseCase entities.ComplaintEventUseCaseInterface, webhookUseCase entities.WebhookUseCaseInterface, roleUseCase entities.RoleUseCaseInterface) *UnggahBuktiController {
+	return &UnggahBuktiController{usecase: usecase, complaintUseCase: complaintUseCase, complaintEventUseCase: complaintEventUseCase, webhookUseCase: webhookUseCase, roleUseCase: roleUseCase}
 }
 
-func (c *UnggahBuktiController) Create(ctx echo.Context) error {
-	// Parsing form-data
-	complaintID := ctx.FormValue("complaint_id")
-	penanggungJawab := ctx.FormValue("penanggung_jawab")
-	finishedOn := ctx.FormValue("finished_on")
+// Pastikan aduan dari bukti berada dalam cakupan admin yang login
+func (c *UnggahBuktiController) ensureInScope(ctx echo.Context, complaintID string) error {
+	complaint, err := c.complaintUseCase.GetByID(complaintID)
+	if err != nil {
+		return err
+	}
 
-	// Ambil file dari form-data
-	file, err := ctx.FormFile("path")
+	principal, err := utils.GetPrincipal(ctx)
 	if err != nil {
-		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "File is required"})
+		return err
 	}
 
-	// Buka file untuk disimpan
-	src, err := file.Open()
+	return c.roleUseCase.EnsureInScope(principal.ID, principal.Role, complaint)
+}
+
+// Bukti aduan private hanya untuk pelapor, admin yang ditugaskan dan super admin,
+// admin juga harus memiliki aduan dalam cakupannya
+func (c *UnggahBuktiController) ensureCanAccess(ctx echo.Context, complaintID string) error {
+	complaint, err := c.complaintUseCase.GetByID(complaintID)
 	if err != nil {
-		return ctx.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to open file"})
+		return err
 	}
-	defer src.Close()
 
-	// Buat direktori jika belum ada
-	uploadDir := "./uploads/bukti_unggah"
-	if _, err := os.Stat(uploadDir); os.IsNotExist(err) {
-		if err := os.MkdirAll(uploadDir, os.ModePerm); err != nil {
-			return ctx.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to create upload directory"})
-		}
+	principal, err := utils.GetPrincipal(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !complaint.CanAccessFiles(principal.ID, principal.Role) {
+		return constants.ErrForbidden
 	}
 
-	// Generate nama file unik
-	fileName := strconv.FormatInt(time.Now().Unix(), 10) + "_" + file.Filename
-	// Simpan file dengan path tanpa "./"
-	filePath := "uploads/bukti_unggah/" + fileName
+	return c.roleUseCase.EnsureInScope(principal.ID, principal.Role, complaint)
+}
+
+func (c *UnggahBuktiController) Create(ctx echo.Context) error {
+	// Parsing form-data
+	complaintID := ctx.FormValue("complaint_id")
+	penanggungJawab := ctx.FormValue("penanggung_jawab")
+	finishedOn := ctx.FormValue("finished_on")
 
-	// Simpan file ke direktori lokal
-	dst, err := os.Create(uploadDir + "/" + fileName)
+	// Ambil file dari form-data
+	file, err := ctx.FormFile("path")
 	if err != nil {
-		return ctx.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to save file"})
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "File is required"})
 	}
-	defer dst.Close()
 
-	// Copy isi file dari form-data ke file tujuan
-	if _, err = io.Copy(dst, src); err != nil {
-		return ctx.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to copy file"})
+	if err := c.ensureInScope(ctx, complaintID); err != nil {
+		return ctx.JSON(utils.ConvertResponseCode(err), map[string]string{"error": err.Error()})
 	}
 
 	// Konversi finishedOn ke time.Time
@@ -71,16 +85,36 @@ func (c *UnggahBuktiController) Create(ctx echo.Context) error {
 	// Buat objek untuk disimpan ke database
 	unggahBukti := &entities.UnggahBukti{
 		ComplaintID:     complaintID,
-		Path:            filePath, // Path yang disimpan di database
 		PenanggungJawab: penanggungJawab,
 		FinishedOn:      finishedOnTime,
 	}
 
-	// Simpan ke database menggunakan UseCase
-	if err := c.usecase.Create(unggahBukti); err != nil {
+	// Simpan file ke storage dan data ke database menggunakan UseCase
+	if err := c.usecase.Create(file, unggahBukti); err != nil {
+		// File yang ditolak oleh validasi upload dikembalikan apa adanya
+		if code := utils.ConvertResponseCode(err); code != http.StatusInternalServerError {
+			return ctx.JSON(code, map[string]string{"error": err.Error()})
+		}
 		return ctx.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to create record"})
 	}
 
+	// Kabarkan bukti baru ke stream aduan
+	if err := c.complaintEventUseCase.Publish(complaintID, constants.ComplaintEventEvidence, response.GetFromEntitiesToResponse(unggahBukti)); err != nil {
+		log.Printf("complaint %s: publish event failed: %v", complaintID, err)
+	}
+
+	// Kirim bukti baru ke webhook yang berlangganan
+	complaint, err := c.complaintUseCase.GetByID(complaintID)
+	if err != nil {
+		log.Printf("complaint %s: dispatch webhook failed: %v", complaintID, err)
+	} else {
+		webhookData := complaint_response.WebhookFromEntitiesToResponse(&complaint)
+		webhookData.Evidence = response.GetFromEntitiesToResponse(unggahBukti)
+		if err := c.webhookUseCase.Dispatch(constants.WebhookEventEvidenceUploaded, webhookData); err != nil {
+			log.Printf("complaint %s: dispatch webhook failed: %v", complaintID, err)
+		}
+	}
+
 	// Berikan respon sukses
 	return ctx.JSON(http.StatusCreated, map[string]interface{}{
 		"message":        "Data uploaded successfully",
@@ -94,11 +128,42 @@ func (c *UnggahBuktiController) GetAll(ctx echo.Context) error {
 	if err != nil {
 		return ctx.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to fetch"})
 	}
+
+	// Admin dengan cakupan hanya melihat bukti dari aduan dalam cakupannya
+	principal, err := utils.GetPrincipal(ctx)
+	if err != nil {
+		return ctx.JSON(utils.ConvertResponseCode(err), map[string]string{"error": err.Error()})
+	}
+	scope, err := c.roleUseCase.GetScope(principal.ID, principal.Role)
+	if err != nil {
+		return ctx.JSON(utils.ConvertResponseCode(err), map[string]string{"error": err.Error()})
+	}
+	if !scope.IsEmpty() {
+		inScope := map[string]bool{}
+		filtered := []entities.UnggahBukti{}
+		for _, unggahBukti := range data {
+			allowed, checked := inScope[unggahBukti.ComplaintID]
+			if !checked {
+				complaint, err := c.complaintUseCase.GetByID(unggahBukti.ComplaintID)
+				allowed = err == nil && scope.Contains(complaint.RegencyID, complaint.CategoryID)
+				inScope[unggahBukti.ComplaintID] = allowed
+			}
+			if allowed {
+				filtered = append(filtered, unggahBukti)
+			}
+		}
+		data = filtered
+	}
+
 	return ctx.JSON(http.StatusOK, data)
 }
 
 func (c *UnggahBuktiController) GetByComplaintID(ctx echo.Context) error {
 	complaintID := ctx.Param("complaint-id")
+	if err := c.ensureCanAccess(ctx, complaintID); err != nil {
+		return ctx.JSON(utils.ConvertResponseCode(err), map[string]string{"error": err.Error()})
+	}
+
 	data, err := c.usecase.GetByComplaintID(complaintID)
 	if err != nil {
 		return ctx.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to fetch"})
@@ -108,10 +173,21 @@ func (c *UnggahBuktiController) GetByComplaintID(ctx echo.Context) error {
 
 func (c *UnggahBuktiController) Update(ctx echo.Context) error {
 	id, _ := strconv.ParseInt(ctx.Param("id"), 10, 64)
+
+	existing, err := c.usecase.GetByID(id)
+	if err != nil || existing == nil {
+		return ctx.JSON(http.StatusNotFound, map[string]string{"error": "Record not found"})
+	}
+	if err := c.ensureInScope(ctx, existing.ComplaintID); err != nil {
+		return ctx.JSON(utils.ConvertResponseCode(err), map[string]string{"error": err.Error()})
+	}
+
 	var data entities.UnggahBukti
 	if err := ctx.Bind(&data); err != nil {
 		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid input"})
 	}
+	// Bukti tidak bisa dipindahkan ke aduan lain
+	data.ComplaintID = existing.ComplaintID
 	if err := c.usecase.Update(id, &data); err != nil {
 		return ctx.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to update"})
 	}
@@ -127,14 +203,12 @@ func (c *UnggahBuktiController) Delete(ctx echo.Context) error {
 	if err != nil || data == nil {
 		return ctx.JSON(http.StatusNotFound, map[string]string{"error": "Record not found"})
 	}
-
-	// Hapus file terkait
-	if err := os.Remove(data.Path); err != nil {
-		return ctx.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to delete file"})
+	if err := c.ensureInScope(ctx, data.ComplaintID); err != nil {
+		return ctx.JSON(utils.ConvertResponseCode(err), map[string]string{"error": err.Error()})
 	}
 
-	// Hapus data dari database
-	if err := c.usecase.Delete(id); err != nil {
+	// Hapus file terkait beserta datanya
+	if err := c.usecase.Delete(data.ID); err != nil {
 		return ctx.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to delete record"})
 	}
 
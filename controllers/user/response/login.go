@@ -3,17 +3,19 @@ package response
 import "e-complaint-api/entities"
 
 type Login struct {
-	ID    int    `json:"id"`
-	Name  string `json:"name"`
-	Email string `json:"email"`
-	Token string `json:"token"`
+	ID           int    `json:"id"`
+	Name         string `json:"name"`
+	Email        string `json:"email"`
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
 }
 
 func LoginFromEntitiesToResponse(user *entities.User) *Login {
 	return &Login{
-		ID:    user.ID,
-		Name:  user.Name,
-		Email: user.Email,
-		Token: user.Token,
+		ID:           user.ID,
+		Name:         user.Name,
+		Email:        user.Email,
+		Token:        user.Token,
+		RefreshToken: user.RefreshToken,
 	}
 }
@@ -76,7 +76,7 @@ func (uc *UserController) GetUserByID(c echo.Context) error {
 }
 
 func (uc *UserController) UpdateUser(c echo.Context) error {
-	jwtID, err := utils.GetIDFromJWT(c)
+	principal, err := utils.GetPrincipal(c)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, base.NewErrorResponse(err.Error()))
 	}
@@ -86,7 +86,7 @@ func (uc *UserController) UpdateUser(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, base.NewErrorResponse(err.Error()))
 	}
 
-	user, err := uc.userUseCase.UpdateUser(jwtID, userRequest.ToEntities())
+	user, err := uc.userUseCase.UpdateUser(principal.ID, userRequest.ToEntities())
 	if err != nil {
 		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
 	}
@@ -96,7 +96,7 @@ func (uc *UserController) UpdateUser(c echo.Context) error {
 }
 
 func (uc *UserController) UpdateProfilePhoto(c echo.Context) error {
-	userID, err := utils.GetIDFromJWT(c)
+	principal, err := utils.GetPrincipal(c)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, base.NewErrorResponse(err.Error()))
 	}
@@ -106,16 +106,7 @@ func (uc *UserController) UpdateProfilePhoto(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, base.NewErrorResponse(constants.ErrAllFieldsMustBeFilled.Error()))
 	}
 
-	// Check file format
-	if profilePhoto.Header.Get("Content-Type") != "image/jpeg" && profilePhoto.Header.Get("Content-Type") != "image/png" && profilePhoto.Header.Get("Content-Type") != "image/jpg" {
-		return c.JSON(http.StatusBadRequest, base.NewErrorResponse(constants.ErrInvalidFileFormat.Error()))
-	}
-
-	if profilePhoto.Size > 5*1024*1024 {
-		return c.JSON(http.StatusBadRequest, base.NewErrorResponse(constants.ErrMaxFileSizeExceeded.Error()))
-	}
-
-	err = uc.userUseCase.UpdateProfilePhoto(userID, profilePhoto)
+	err = uc.userUseCase.UpdateProfilePhoto(principal.ID, profilePhoto)
 	if err != nil {
 		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
 	}
@@ -131,19 +122,13 @@ func (uc *UserController) DeleteUser(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, base.NewErrorResponse(constants.ErrInvalidIDFormat.Error()))
 	}
 
-	userRole, err := utils.GetRoleFromJWT(c)
+	principal, err := utils.GetPrincipal(c)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, base.NewErrorResponse(err.Error()))
 	}
 
-	if userRole == "user" {
-		jwtID, err := utils.GetIDFromJWT(c)
-
-		if err != nil {
-			return c.JSON(http.StatusInternalServerError, base.NewErrorResponse(err.Error()))
-		}
-
-		if id != jwtID {
+	if principal.Role == "user" {
+		if id != principal.ID {
 			return c.JSON(http.StatusUnauthorized, base.NewErrorResponse(constants.ErrUnauthorized.Error()))
 		}
 	}
@@ -157,7 +142,7 @@ func (uc *UserController) DeleteUser(c echo.Context) error {
 }
 
 func (uc *UserController) UpdatePassword(c echo.Context) error {
-	jwtID, err := utils.GetIDFromJWT(c)
+	principal, err := utils.GetPrincipal(c)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, base.NewErrorResponse(err.Error()))
 	}
@@ -167,7 +152,7 @@ func (uc *UserController) UpdatePassword(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, base.NewErrorResponse(err.Error()))
 	}
 
-	err = uc.userUseCase.UpdatePassword(jwtID, passwordRequest.NewPassword)
+	err = uc.userUseCase.UpdatePassword(principal.ID, passwordRequest.NewPassword)
 	if err != nil {
 		return c.JSON(http.StatusBadRequest, base.NewErrorResponse(err.Error()))
 	}
@@ -0,0 +1,14 @@
+package request
+
+type Create struct {
+	URL    string   `json:"url"`
+	Events []string `json:"events"`
+}
+
+// Update replaces the URL, events and state of a webhook, so every field has to be
+// sent.
+type Update struct {
+	URL    string   `json:"url"`
+	Events []string `json:"events"`
+	Active bool     `json:"active"`
+}
@@ -0,0 +1,44 @@
+package response
+
+import (
+	"e-complaint-api/entities"
+	"encoding/json"
+)
+
+// Delivery embeds the payload as the JSON it was sent as.
+type Delivery struct {
+	ID             int             `json:"id"`
+	WebhookID      int             `json:"webhook_id"`
+	Event          string          `json:"event"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	ResponseStatus int             `json:"response_status"`
+	ResponseBody   string          `json:"response_body"`
+	LastError      string          `json:"last_error"`
+	ReplayOf       *int            `json:"replay_of"`
+	DeliveredAt    string          `json:"delivered_at,omitempty"`
+	CreatedAt      string          `json:"created_at"`
+}
+
+func DeliveryFromEntitiesToResponse(data *entities.WebhookDelivery) *Delivery {
+	var deliveredAt string
+	if data.DeliveredAt != nil {
+		deliveredAt = data.DeliveredAt.Format("2 January 2006 15:04:05")
+	}
+
+	return &Delivery{
+		ID:             data.ID,
+		WebhookID:      data.WebhookID,
+		Event:          data.Event,
+		Payload:        json.RawMessage(data.Payload),
+		Status:         data.Status,
+		Attempts:       data.Attempts,
+		ResponseStatus: data.ResponseStatus,
+		ResponseBody:   data.ResponseBody,
+		LastError:      data.LastError,
+		ReplayOf:       data.ReplayOf,
+		DeliveredAt:    deliveredAt,
+		CreatedAt:      data.CreatedAt.Format("2 January 2006 15:04:05"),
+	}
+}
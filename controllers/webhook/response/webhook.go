@@ -0,0 +1,41 @@
+package response
+
+import (
+	"e-complaint-api/entities"
+	"strings"
+)
+
+// Get leaves out the secret of the webhook, which is only shown when it is created.
+type Get struct {
+	ID        int      `json:"id"`
+	URL       string   `json:"url"`
+	Events    []string `json:"events"`
+	Active    bool     `json:"active"`
+	CreatedBy int      `json:"created_by"`
+	CreatedAt string   `json:"created_at"`
+	UpdatedAt string   `json:"updated_at"`
+}
+
+type Create struct {
+	Get
+	Secret string `json:"secret"`
+}
+
+func GetFromEntitiesToResponse(data *entities.Webhook) *Get {
+	return &Get{
+		ID:        data.ID,
+		URL:       data.URL,
+		Events:    strings.Split(data.Events, ","),
+		Active:    data.Active,
+		CreatedBy: data.CreatedBy,
+		CreatedAt: data.CreatedAt.Format("2 January 2006 15:04:05"),
+		UpdatedAt: data.UpdatedAt.Format("2 January 2006 15:04:05"),
+	}
+}
+
+func CreateFromEntitiesToResponse(data *entities.Webhook) *Create {
+	return &Create{
+		Get:    *GetFromEntitiesToResponse(data),
+		Secret: data.Secret,
+	}
+}
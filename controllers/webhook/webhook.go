@@ -0,0 +1,146 @@
+package webhook
+
+import (
+	"e-complaint-api/constants"
+	"e-complaint-api/controllers/base"
+	"e-complaint-api/controllers/webhook/request"
+	"e-complaint-api/controllers/webhook/response"
+	"e-complaint-api/entities"
+	"e-complaint-api/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type WebhookController struct {
+	webhookUseCase entities.WebhookUseCaseInterface
+}
+
+func NewWebhookController(webhookUseCase entities.WebhookUseCaseInterface) *WebhookController {
+	return &WebhookController{
+		webhookUseCase: webhookUseCase,
+	}
+}
+
+// Create registers a webhook. The secret the deliveries are signed with is only part
+// of this response.
+func (wc *WebhookController) Create(c echo.Context) error {
+	principal, err := utils.GetPrincipal(c)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	var req request.Create
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, base.NewErrorResponse(err.Error()))
+	}
+
+	webhook, err := wc.webhookUseCase.Create(req.URL, req.Events, principal.ID)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	return c.JSON(http.StatusCreated, base.NewSuccessResponse("Success Create Webhook", response.CreateFromEntitiesToResponse(&webhook)))
+}
+
+func (wc *WebhookController) GetAll(c echo.Context) error {
+	webhooks, err := wc.webhookUseCase.GetAll()
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	webhookResponses := []*response.Get{}
+	for _, webhook := range webhooks {
+		webhookResponses = append(webhookResponses, response.GetFromEntitiesToResponse(&webhook))
+	}
+
+	return c.JSON(http.StatusOK, base.NewSuccessResponse("Success Get Webhooks", webhookResponses))
+}
+
+func (wc *WebhookController) GetByID(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, base.NewErrorResponse(constants.ErrInvalidIDFormat.Error()))
+	}
+
+	webhook, err := wc.webhookUseCase.GetByID(id)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	return c.JSON(http.StatusOK, base.NewSuccessResponse("Success Get Webhook", response.GetFromEntitiesToResponse(&webhook)))
+}
+
+func (wc *WebhookController) Update(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, base.NewErrorResponse(constants.ErrInvalidIDFormat.Error()))
+	}
+
+	var req request.Update
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, base.NewErrorResponse(err.Error()))
+	}
+
+	webhook, err := wc.webhookUseCase.Update(id, req.URL, req.Events, req.Active)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	return c.JSON(http.StatusOK, base.NewSuccessResponse("Success Update Webhook", response.GetFromEntitiesToResponse(&webhook)))
+}
+
+func (wc *WebhookController) Delete(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, base.NewErrorResponse(constants.ErrInvalidIDFormat.Error()))
+	}
+
+	if err := wc.webhookUseCase.Delete(id); err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	return c.JSON(http.StatusOK, base.NewSuccessResponse("Success Delete Webhook", nil))
+}
+
+// GetDeliveries lists the delivery log of a webhook newest first, one page per cursor.
+func (wc *WebhookController) GetDeliveries(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, base.NewErrorResponse(constants.ErrInvalidIDFormat.Error()))
+	}
+
+	limit, _ := strconv.Atoi(c.QueryParam("limit"))
+	deliveries, nextCursor, err := wc.webhookUseCase.GetDeliveries(id, limit, c.QueryParam("cursor"))
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	deliveryResponses := []*response.Delivery{}
+	for _, delivery := range deliveries {
+		deliveryResponses = append(deliveryResponses, response.DeliveryFromEntitiesToResponse(&delivery))
+	}
+
+	return c.JSON(http.StatusOK, base.NewSuccessResponseWithMetadata("Success Get Webhook Deliveries", deliveryResponses, *base.NewCursorMetadata(nextCursor)))
+}
+
+// Replay sends a delivery again as a new delivery, which is returned.
+func (wc *WebhookController) Replay(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, base.NewErrorResponse(constants.ErrInvalidIDFormat.Error()))
+	}
+
+	deliveryID, err := strconv.Atoi(c.Param("delivery-id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, base.NewErrorResponse(constants.ErrInvalidIDFormat.Error()))
+	}
+
+	delivery, err := wc.webhookUseCase.Replay(id, deliveryID)
+	if err != nil {
+		return c.JSON(utils.ConvertResponseCode(err), base.NewErrorResponse(err.Error()))
+	}
+
+	return c.JSON(http.StatusAccepted, base.NewSuccessResponse("Success Replay Webhook Delivery", response.DeliveryFromEntitiesToResponse(&delivery)))
+}
@@ -0,0 +1,117 @@
+package cursor
+
+import (
+	"e-complaint-api/constants"
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+// timeLayout is the layout of time sort values, which MySQL compares with DATETIME and
+// DATE columns.
+const timeLayout = "2006-01-02 15:04:05.999999"
+
+// token is the content of a cursor. Sort is the order of the list the cursor was
+// handed out for, so a cursor cannot be used to continue a list in another order.
+// Value is the value the row has in the sorted column.
+type token struct {
+	ID    string `json:"id"`
+	Value string `json:"value,omitempty"`
+	Sort  string `json:"sort"`
+}
+
+// Encode returns an opaque cursor that points at the row with the ID in a list sorted
+// by sort.
+func Encode(id string, sort string) string {
+	return EncodeAfter(id, "", sort)
+}
+
+// EncodeAfter is Encode for lists sorted by another column than the ID. The cursor
+// keeps the value of the row in that column, so the next page is found without the
+// row, which may have been deleted in the meantime.
+func EncodeAfter(id string, value string, sort string) string {
+	data, _ := json.Marshal(token{ID: id, Value: value, Sort: sort})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// Decode returns the ID of the row a cursor points at. An empty cursor asks for the
+// first page, it decodes to an empty ID.
+func Decode(cursor string, sort string) (string, error) {
+	id, _, err := DecodeAfter(cursor, sort)
+	return id, err
+}
+
+// DecodeAfter returns the ID and the sort value of the row a cursor made by EncodeAfter
+// points at.
+func DecodeAfter(cursor string, sort string) (string, string, error) {
+	if cursor == "" {
+		return "", "", nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return "", "", constants.ErrInvalidCursor
+	}
+
+	var t token
+	if err := json.Unmarshal(data, &t); err != nil || t.ID == "" || t.Sort != sort {
+		return "", "", constants.ErrInvalidCursor
+	}
+
+	return t.ID, t.Value, nil
+}
+
+// Time formats a time as a sort value.
+func Time(t time.Time) string {
+	return t.Format(timeLayout)
+}
+
+// DecodeInt is Decode for tables with an auto increment ID. An empty cursor decodes
+// to 0.
+func DecodeInt(cursor string, sort string) (int, error) {
+	id, err := Decode(cursor, sort)
+	if err != nil || id == "" {
+		return 0, err
+	}
+
+	value, err := strconv.Atoi(id)
+	if err != nil {
+		return 0, constants.ErrInvalidCursor
+	}
+
+	return value, nil
+}
+
+// Limit returns the number of rows on a page, limit falls back to the default and is
+// capped at the maximum.
+func Limit(limit int) int {
+	if limit <= 0 {
+		return constants.DefaultCursorLimit
+	}
+
+	return min(limit, constants.MaxCursorLimit)
+}
+
+// Page cuts rows, which were queried with one row more than limit, down to limit. The
+// extra row tells that there is a next page, its cursor points at the last row kept.
+func Page[T any](rows []T, limit int, sort string, id func(T) string) ([]T, string) {
+	if len(rows) <= limit {
+		return rows, ""
+	}
+
+	rows = rows[:limit]
+	return rows, Encode(id(rows[limit-1]), sort)
+}
+
+// PageAfter is Page for cursors made by EncodeAfter, position returns the ID and the
+// sort value of a row.
+func PageAfter[T any](rows []T, limit int, sort string, position func(T) (string, string)) ([]T, string) {
+	if len(rows) <= limit {
+		return rows, ""
+	}
+
+	rows = rows[:limit]
+	id, value := position(rows[limit-1])
+	return rows, EncodeAfter(id, value, sort)
+}
@@ -0,0 +1,142 @@
+package cursor
+
+import (
+	"e-complaint-api/constants"
+	"encoding/base64"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		cursor := Encode("C-81j9aK9280", "created_at DESC")
+
+		id, err := Decode(cursor, "created_at DESC")
+		assert.NoError(t, err)
+		assert.Equal(t, "C-81j9aK9280", id)
+	})
+
+	t.Run("success first page", func(t *testing.T) {
+		id, err := Decode("", "created_at DESC")
+		assert.NoError(t, err)
+		assert.Equal(t, "", id)
+	})
+
+	t.Run("failed other sort", func(t *testing.T) {
+		_, err := Decode(Encode("C-1", "created_at DESC"), "total_likes DESC")
+		assert.Equal(t, constants.ErrInvalidCursor, err)
+	})
+
+	t.Run("failed not base64", func(t *testing.T) {
+		_, err := Decode("not a cursor!", "created_at DESC")
+		assert.Equal(t, constants.ErrInvalidCursor, err)
+	})
+
+	t.Run("failed not json", func(t *testing.T) {
+		_, err := Decode(base64.RawURLEncoding.EncodeToString([]byte("C-1")), "created_at DESC")
+		assert.Equal(t, constants.ErrInvalidCursor, err)
+	})
+}
+
+func TestEncodeDecodeAfter(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		cursor := EncodeAfter("C-81j9aK9280", "2024-05-05 10:00:00.5", "created_at DESC")
+
+		id, value, err := DecodeAfter(cursor, "created_at DESC")
+		assert.NoError(t, err)
+		assert.Equal(t, "C-81j9aK9280", id)
+		assert.Equal(t, "2024-05-05 10:00:00.5", value)
+	})
+
+	t.Run("success first page", func(t *testing.T) {
+		id, value, err := DecodeAfter("", "created_at DESC")
+		assert.NoError(t, err)
+		assert.Equal(t, "", id)
+		assert.Equal(t, "", value)
+	})
+
+	t.Run("failed other sort", func(t *testing.T) {
+		_, _, err := DecodeAfter(EncodeAfter("C-1", "3", "total_likes ASC"), "total_likes DESC")
+		assert.Equal(t, constants.ErrInvalidCursor, err)
+	})
+}
+
+func TestTime(t *testing.T) {
+	assert.Equal(t, "2024-05-05 10:00:00.123456", Time(time.Date(2024, 5, 5, 10, 0, 0, 123456789, time.UTC)))
+	assert.Equal(t, "2024-05-05 00:00:00", Time(time.Date(2024, 5, 5, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestDecodeInt(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		id, err := DecodeInt(Encode("42", "id DESC"), "id DESC")
+		assert.NoError(t, err)
+		assert.Equal(t, 42, id)
+	})
+
+	t.Run("success first page", func(t *testing.T) {
+		id, err := DecodeInt("", "id DESC")
+		assert.NoError(t, err)
+		assert.Equal(t, 0, id)
+	})
+
+	t.Run("failed not a number", func(t *testing.T) {
+		_, err := DecodeInt(Encode("C-1", "id DESC"), "id DESC")
+		assert.Equal(t, constants.ErrInvalidCursor, err)
+	})
+
+	t.Run("failed invalid cursor", func(t *testing.T) {
+		_, err := DecodeInt("???", "id DESC")
+		assert.Equal(t, constants.ErrInvalidCursor, err)
+	})
+}
+
+func TestLimit(t *testing.T) {
+	assert.Equal(t, constants.DefaultCursorLimit, Limit(0))
+	assert.Equal(t, constants.DefaultCursorLimit, Limit(-5))
+	assert.Equal(t, 25, Limit(25))
+	assert.Equal(t, constants.MaxCursorLimit, Limit(1000))
+}
+
+func TestPage(t *testing.T) {
+	id := func(row int) string { return strconv.Itoa(row) }
+
+	t.Run("next page", func(t *testing.T) {
+		rows, next := Page([]int{5, 4, 3}, 2, "id DESC", id)
+
+		assert.Equal(t, []int{5, 4}, rows)
+		nextID, err := Decode(next, "id DESC")
+		assert.NoError(t, err)
+		assert.Equal(t, "4", nextID)
+	})
+
+	t.Run("last page", func(t *testing.T) {
+		rows, next := Page([]int{2, 1}, 2, "id DESC", id)
+
+		assert.Equal(t, []int{2, 1}, rows)
+		assert.Equal(t, "", next)
+	})
+}
+
+func TestPageAfter(t *testing.T) {
+	position := func(row int) (string, string) { return strconv.Itoa(row), strconv.Itoa(row * 10) }
+
+	t.Run("next page", func(t *testing.T) {
+		rows, next := PageAfter([]int{5, 4, 3}, 2, "total_likes DESC", position)
+
+		assert.Equal(t, []int{5, 4}, rows)
+		nextID, nextValue, err := DecodeAfter(next, "total_likes DESC")
+		assert.NoError(t, err)
+		assert.Equal(t, "4", nextID)
+		assert.Equal(t, "40", nextValue)
+	})
+
+	t.Run("last page", func(t *testing.T) {
+		rows, next := PageAfter([]int{2, 1}, 2, "total_likes DESC", position)
+
+		assert.Equal(t, []int{2, 1}, rows)
+		assert.Equal(t, "", next)
+	})
+}
@@ -0,0 +1,59 @@
+package file_storage
+
+import (
+	"e-complaint-api/constants"
+	gcs_api "e-complaint-api/drivers/google_cloud_storage"
+	"e-complaint-api/drivers/local_storage"
+	"e-complaint-api/drivers/s3_storage"
+	"e-complaint-api/entities"
+
+	"cloud.google.com/go/storage"
+)
+
+type Config struct {
+	DRIVER          string
+	LOCAL_DIR       string
+	GCS_CREDENTIALS string
+	GCS_BUCKET      string
+	S3_ENDPOINT     string
+	S3_REGION       string
+	S3_BUCKET       string
+	S3_ACCESS_KEY   string
+	S3_SECRET_KEY   string
+}
+
+// FileStorage hands out a storage per folder on the backend chosen by DRIVER, which
+// is one of "local", "gcs" or "s3".
+type FileStorage struct {
+	config    Config
+	gcsClient *storage.Client
+}
+
+func NewFileStorage(config Config) *FileStorage {
+	fileStorage := &FileStorage{config: config}
+
+	switch config.DRIVER {
+	case "local", "s3":
+	case "gcs":
+		client, err := gcs_api.NewClient(config.GCS_CREDENTIALS)
+		if err != nil {
+			panic(err)
+		}
+		fileStorage.gcsClient = client
+	default:
+		panic(constants.ErrInvalidStorageDriver)
+	}
+
+	return fileStorage
+}
+
+func (f *FileStorage) Folder(folderPath string) entities.FileStorageInterface {
+	switch f.config.DRIVER {
+	case "gcs":
+		return gcs_api.NewFileHandlingAPI(f.gcsClient, f.config.GCS_BUCKET, folderPath)
+	case "s3":
+		return s3_storage.NewS3Storage(f.config.S3_ENDPOINT, f.config.S3_REGION, f.config.S3_BUCKET, f.config.S3_ACCESS_KEY, f.config.S3_SECRET_KEY, folderPath)
+	default:
+		return local_storage.NewLocalStorage(f.config.LOCAL_DIR, folderPath)
+	}
+}
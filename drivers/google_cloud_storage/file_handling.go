@@ -3,85 +3,95 @@ package google_cloud_storage
 import (
 	"context"
 	"e-complaint-api/constants"
+	"e-complaint-api/entities"
 	"e-complaint-api/utils"
+	"errors"
 	"io"
-	"mime/multipart"
-	"os"
 
 	"cloud.google.com/go/storage"
 	"google.golang.org/api/option"
 )
 
-const (
-	projectID  = "alterra-academy-420809"
-	bucketName = "e-complaint-assets"
-)
-
 type FileHandlingAPI struct {
-	APIKey     string
+	Client     *storage.Client
+	BucketName string
 	FolderPath string
 }
 
-func NewFileHandlingAPI(APIKey string, folderPath string) *FileHandlingAPI {
+// NewClient opens the client shared by every folder of the bucket.
+func NewClient(credentials string) (*storage.Client, error) {
+	client, err := storage.NewClient(context.Background(), option.WithCredentialsJSON([]byte(credentials)))
+	if err != nil {
+		return nil, constants.ErrFailedToCreateClientGCS
+	}
+
+	return client, nil
+}
+
+func NewFileHandlingAPI(client *storage.Client, bucketName string, folderPath string) *FileHandlingAPI {
 	return &FileHandlingAPI{
-		APIKey:     APIKey,
+		Client:     client,
+		BucketName: bucketName,
 		FolderPath: folderPath,
 	}
 }
 
-func (f *FileHandlingAPI) Upload(files []*multipart.FileHeader) ([]string, error) {
-	var credentials = f.APIKey
+func (f *FileHandlingAPI) Upload(files []entities.UploadFile) ([]string, error) {
+	ctx := context.Background()
 
 	var filePaths []string
-	for _, fileHeader := range files {
-		// Open file
-		file, err := fileHeader.Open()
-		if err != nil {
-			return nil, err
-		}
-		defer file.Close()
+	for _, file := range files {
+		// Hashing nama file menggunakan SHA256
+		dstPath := f.FolderPath + utils.HashFileName(file.Name)
 
-		// Load GCP credentials securely (consider using KMS or secrets manager)
-		ctx := context.Background()
-		client, err := storage.NewClient(ctx, option.WithCredentialsJSON([]byte(credentials)))
+		err := f.upload(ctx, file, dstPath)
 		if err != nil {
 			return nil, err
 		}
-		defer client.Close()
-
-		// Hashing nama file menggunakan SHA256
-		hashedFilename := utils.HashFileName(fileHeader.Filename)
 
-		dstPath := f.FolderPath + hashedFilename // Menggunakan nama file yang dihash
-		dst := client.Bucket(bucketName).Object(dstPath).NewWriter(ctx)
 		filePaths = append(filePaths, dstPath)
-		defer dst.Close()
-
-		// Salin isi file dari source ke destination di GCS
-		if _, err = io.Copy(dst, file); err != nil {
-			return nil, err
-		}
 	}
 
 	return filePaths, nil
 }
 
-func (f *FileHandlingAPI) Delete(filePaths []string) error {
-	var credentials = os.Getenv("GCS_CREDENTIALS")
+func (f *FileHandlingAPI) upload(ctx context.Context, file entities.UploadFile, dstPath string) error {
+	dst := f.Client.Bucket(f.BucketName).Object(dstPath).NewWriter(ctx)
+	dst.ContentType = file.ContentType
+	if _, err := dst.Write(file.Content); err != nil {
+		dst.Close()
+		return constants.ErrFailedToUploadObject
+	}
 
-	ctx := context.Background()
-	client, err := storage.NewClient(ctx, option.WithCredentialsJSON([]byte(credentials)))
-	if err != nil {
-		return constants.ErrFailedToCreateClientGCS
+	// The object is only written once the writer is closed
+	if err := dst.Close(); err != nil {
+		return constants.ErrFailedToUploadObject
 	}
-	defer client.Close()
+
+	return nil
+}
+
+func (f *FileHandlingAPI) Delete(filePaths []string) error {
+	ctx := context.Background()
 
 	for _, path := range filePaths {
-		obj := client.Bucket(bucketName).Object(path)
-		if err := obj.Delete(ctx); err != nil {
+		err := f.Client.Bucket(f.BucketName).Object(path).Delete(ctx)
+		if err != nil && !errors.Is(err, storage.ErrObjectNotExist) {
 			return constants.ErrFailedToDeleteObject
 		}
 	}
 
 	return nil
 }
+
+func (f *FileHandlingAPI) Open(filePath string) (io.ReadCloser, error) {
+	reader, err := f.Client.Bucket(f.BucketName).Object(filePath).NewReader(context.Background())
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotExist) {
+			return nil, constants.ErrFileNotFound
+		}
+		return nil, err
+	}
+
+	return reader, nil
+}
@@ -0,0 +1,73 @@
+package local_storage
+
+import (
+	"e-complaint-api/constants"
+	"e-complaint-api/entities"
+	"e-complaint-api/utils"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+)
+
+type LocalStorage struct {
+	BaseDir    string
+	FolderPath string
+}
+
+func NewLocalStorage(baseDir string, folderPath string) *LocalStorage {
+	return &LocalStorage{
+		BaseDir:    baseDir,
+		FolderPath: folderPath,
+	}
+}
+
+func (l *LocalStorage) Upload(files []entities.UploadFile) ([]string, error) {
+	err := os.MkdirAll(filepath.Join(l.BaseDir, l.FolderPath), os.ModePerm)
+	if err != nil {
+		return nil, constants.ErrFailedToUploadObject
+	}
+
+	var filePaths []string
+	for _, file := range files {
+		dstPath := l.FolderPath + utils.HashFileName(file.Name)
+
+		err := os.WriteFile(l.fullPath(dstPath), file.Content, 0644)
+		if err != nil {
+			return nil, constants.ErrFailedToUploadObject
+		}
+
+		filePaths = append(filePaths, dstPath)
+	}
+
+	return filePaths, nil
+}
+
+func (l *LocalStorage) Delete(filePaths []string) error {
+	for _, filePath := range filePaths {
+		err := os.Remove(l.fullPath(filePath))
+		if err != nil && !os.IsNotExist(err) {
+			return constants.ErrFailedToDeleteObject
+		}
+	}
+
+	return nil
+}
+
+func (l *LocalStorage) Open(filePath string) (io.ReadCloser, error) {
+	file, err := os.Open(l.fullPath(filePath))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, constants.ErrFileNotFound
+		}
+		return nil, err
+	}
+
+	return file, nil
+}
+
+// fullPath resolves a stored path inside BaseDir. Paths are cleaned as if they were
+// absolute first, so they cannot point outside of BaseDir.
+func (l *LocalStorage) fullPath(filePath string) string {
+	return filepath.Join(l.BaseDir, filepath.FromSlash(path.Clean("/"+filePath)))
+}
@@ -0,0 +1,52 @@
+package local_storage
+
+import (
+	"e-complaint-api/constants"
+	"e-complaint-api/entities"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoundTrip(t *testing.T) {
+	baseDir := t.TempDir()
+	storage := NewLocalStorage(baseDir, "bukti_unggah/")
+
+	paths, err := storage.Upload([]entities.UploadFile{{Name: "banjir.jpeg", Content: []byte("image")}})
+	assert.NoError(t, err)
+	assert.Len(t, paths, 1)
+	assert.True(t, strings.HasPrefix(paths[0], "bukti_unggah/"))
+	assert.FileExists(t, filepath.Join(baseDir, paths[0]))
+
+	file, err := storage.Open(paths[0])
+	assert.NoError(t, err)
+	content, _ := io.ReadAll(file)
+	file.Close()
+	assert.Equal(t, "image", string(content))
+
+	assert.NoError(t, storage.Delete(paths))
+	assert.NoFileExists(t, filepath.Join(baseDir, paths[0]))
+	_, err = storage.Open(paths[0])
+	assert.ErrorIs(t, err, constants.ErrFileNotFound)
+
+	// Deleting a missing file succeeds
+	assert.NoError(t, storage.Delete(paths))
+}
+
+func TestOpenOutsideBaseDir(t *testing.T) {
+	root := t.TempDir()
+	baseDir := filepath.Join(root, "uploads")
+	assert.NoError(t, os.MkdirAll(baseDir, os.ModePerm))
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "secret.txt"), []byte("secret"), 0644))
+	storage := NewLocalStorage(baseDir, "bukti_unggah/")
+
+	_, err := storage.Open("../secret.txt")
+	assert.ErrorIs(t, err, constants.ErrFileNotFound)
+
+	assert.NoError(t, storage.Delete([]string{"../secret.txt"}))
+	assert.FileExists(t, filepath.Join(root, "secret.txt"))
+}
@@ -2,8 +2,11 @@ package mailtrap
 
 import (
 	"bytes"
+	"e-complaint-api/entities"
+	"html/template"
+	"io"
+	"path/filepath"
 	"strconv"
-	"text/template"
 
 	"gopkg.in/gomail.v2"
 )
@@ -14,6 +17,7 @@ type MailTrapApi struct {
 	SMTP_USERNAME string
 	SMTP_PASSWORD string
 	EMAIL_FROM    string
+	TEMPLATE_DIR  string
 }
 
 func NewMailTrapApi(smtpHost, smtpPort, smtpUsername, smtpPassword, emailFrom string) *MailTrapApi {
@@ -23,40 +27,53 @@ func NewMailTrapApi(smtpHost, smtpPort, smtpUsername, smtpPassword, emailFrom st
 		SMTP_USERNAME: smtpUsername,
 		SMTP_PASSWORD: smtpPassword,
 		EMAIL_FROM:    emailFrom,
+		TEMPLATE_DIR:  "./templates",
 	}
 }
 
 func (u *MailTrapApi) SendOTP(email, otp, otp_type string) error {
-	m := gomail.NewMessage()
-	m.SetHeader("From", u.EMAIL_FROM)
-	m.SetHeader("To", email)
-	m.SetHeader("Subject", "Email Verification")
-
-	path := ""
+	templateName := "register"
 	if otp_type == "forgot_password" {
-		path = "./templates/forgot_password.html"
-	} else {
-		path = "./templates/register.html"
-	}
-
-	template, err := template.ParseFiles(path)
-	if err != nil {
-		return err
+		templateName = "forgot_password"
 	}
 
-	var body bytes.Buffer
 	data := struct {
 		OTP string
 	}{
 		OTP: otp,
 	}
 
+	return u.Send(email, "Email Verification", templateName, data, nil)
+}
+
+// Send renders TEMPLATE_DIR/<templateName>.html with data as the body of the email.
+// The template escapes data, so it may hold text written by users.
+func (u *MailTrapApi) Send(to string, subject string, templateName string, data interface{}, attachments []entities.EmailAttachment) error {
+	m := gomail.NewMessage()
+	m.SetHeader("From", u.EMAIL_FROM)
+	m.SetHeader("To", to)
+	m.SetHeader("Subject", subject)
+
+	template, err := template.ParseFiles(filepath.Join(u.TEMPLATE_DIR, templateName+".html"))
+	if err != nil {
+		return err
+	}
+
+	var body bytes.Buffer
 	err = template.Execute(&body, data)
 	if err != nil {
 		return err
 	}
 	m.SetBody("text/html", body.String())
 
+	for _, attachment := range attachments {
+		content := attachment.Content
+		m.Attach(attachment.Name, gomail.SetCopyFunc(func(w io.Writer) error {
+			_, err := w.Write(content)
+			return err
+		}))
+	}
+
 	port, err := strconv.Atoi(u.SMTP_PORT)
 	if err != nil {
 		return err
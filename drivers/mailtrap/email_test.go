@@ -0,0 +1,212 @@
+package mailtrap
+
+import (
+	"bytes"
+	"e-complaint-api/entities"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// smtpStandIn is a local SMTP server that keeps the messages sent to it, so emails
+// are tested without a real mail server.
+type smtpStandIn struct {
+	listener net.Listener
+	messages chan *mail.Message
+}
+
+func newSMTPStandIn(t *testing.T) *smtpStandIn {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	server := &smtpStandIn{listener: listener, messages: make(chan *mail.Message, 10)}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go server.serve(conn)
+		}
+	}()
+
+	return server
+}
+
+func (s *smtpStandIn) serve(conn net.Conn) {
+	defer conn.Close()
+
+	text := textproto.NewConn(conn)
+	text.PrintfLine("220 localhost ESMTP")
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+
+		switch command := strings.ToUpper(strings.SplitN(line, " ", 2)[0]); command {
+		case "DATA":
+			text.PrintfLine("354 end data with <CR><LF>.<CR><LF>")
+			data, err := text.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			message, err := mail.ReadMessage(bytes.NewReader(data))
+			if err != nil {
+				text.PrintfLine("554 invalid message")
+				continue
+			}
+			s.messages <- message
+			text.PrintfLine("250 OK")
+		case "QUIT":
+			text.PrintfLine("221 bye")
+			return
+		default:
+			text.PrintfLine("250 OK")
+		}
+	}
+}
+
+func (s *smtpStandIn) api() *MailTrapApi {
+	host, port, _ := net.SplitHostPort(s.listener.Addr().String())
+	api := NewMailTrapApi(host, port, "", "", "admin@keluhprov.id")
+	api.TEMPLATE_DIR = "../../templates"
+
+	return api
+}
+
+func (s *smtpStandIn) receive(t *testing.T) *mail.Message {
+	select {
+	case message := <-s.messages:
+		return message
+	case <-time.After(5 * time.Second):
+		t.Fatal("no email received")
+		return nil
+	}
+}
+
+// parts returns the decoded parts of a multipart message by their content type, or
+// the file name for attachments.
+func parts(t *testing.T, message *mail.Message) map[string]string {
+	_, params, err := mime.ParseMediaType(message.Header.Get("Content-Type"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parts := map[string]string{}
+	reader := multipart.NewReader(message.Body, params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return parts
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var content []byte
+		if part.Header.Get("Content-Transfer-Encoding") == "base64" {
+			content, err = io.ReadAll(base64.NewDecoder(base64.StdEncoding, part))
+		} else {
+			content, err = io.ReadAll(part)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		name := part.FileName()
+		if name == "" {
+			name, _, _ = mime.ParseMediaType(part.Header.Get("Content-Type"))
+		}
+		parts[name] = string(content)
+	}
+}
+
+func TestSend(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		server := newSMTPStandIn(t)
+		data := map[string]interface{}{
+			"Name":        "Budi",
+			"ComplaintID": "C-123",
+			"Description": "<script>alert(1)</script>",
+			"Status":      "Verifikasi",
+			"Message":     "Aduan anda sedang diperiksa",
+		}
+
+		err := server.api().Send("budi@example.com", "Aduan C-123 Telah Diverifikasi", "complaint_verified", data, nil)
+		assert.NoError(t, err)
+
+		message := server.receive(t)
+		assert.Equal(t, "budi@example.com", message.Header.Get("To"))
+		assert.Equal(t, "admin@keluhprov.id", message.Header.Get("From"))
+		assert.Equal(t, "Aduan C-123 Telah Diverifikasi", message.Header.Get("Subject"))
+
+		body, err := io.ReadAll(quotedprintable.NewReader(message.Body))
+		assert.NoError(t, err)
+		assert.Contains(t, string(body), "Halo, Budi")
+		assert.Contains(t, string(body), "C-123")
+		assert.Contains(t, string(body), "Aduan anda sedang diperiksa")
+		assert.Contains(t, string(body), "&lt;script&gt;")
+		assert.NotContains(t, string(body), "<script>")
+	})
+
+	t.Run("success with attachments", func(t *testing.T) {
+		server := newSMTPStandIn(t)
+		data := map[string]interface{}{
+			"Name":        "Budi",
+			"ComplaintID": "C-123",
+			"Status":      "Selesai",
+			"Evidence":    []map[string]string{{"PenanggungJawab": "Dinas PU", "FinishedOn": "3 June 2024"}},
+		}
+		attachments := []entities.EmailAttachment{{Name: "bukti.jpg", Content: []byte("image")}}
+
+		err := server.api().Send("budi@example.com", "Aduan C-123 Telah Selesai", "complaint_finished", data, attachments)
+		assert.NoError(t, err)
+
+		parts := parts(t, server.receive(t))
+		assert.Equal(t, "image", parts["bukti.jpg"])
+		body, err := io.ReadAll(quotedprintable.NewReader(strings.NewReader(parts["text/html"])))
+		assert.NoError(t, err)
+		assert.Contains(t, string(body), "Dinas PU")
+		assert.Contains(t, string(body), "3 June 2024")
+	})
+
+	t.Run("success otp", func(t *testing.T) {
+		server := newSMTPStandIn(t)
+
+		err := server.api().SendOTP("budi@example.com", "123456", "register")
+		assert.NoError(t, err)
+
+		body, err := io.ReadAll(quotedprintable.NewReader(server.receive(t).Body))
+		assert.NoError(t, err)
+		assert.Contains(t, string(body), "123456")
+	})
+
+	t.Run("failed template not found", func(t *testing.T) {
+		server := newSMTPStandIn(t)
+
+		err := server.api().Send("budi@example.com", "Subject", "missing", nil, nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("failed invalid port", func(t *testing.T) {
+		api := NewMailTrapApi("127.0.0.1", "smtp", "", "", "admin@keluhprov.id")
+		api.TEMPLATE_DIR = "../../templates"
+
+		err := api.Send("budi@example.com", "Subject", "complaint_received", nil, nil)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,44 @@
+package mailtrap
+
+import (
+	"e-complaint-api/constants"
+	"e-complaint-api/entities"
+	"encoding/json"
+)
+
+// QueuedMailTrapApi sends emails on the job queue, so a slow or unreachable SMTP
+// server neither slows down nor fails the request that sends them.
+type QueuedMailTrapApi struct {
+	jobUseCase entities.JobUseCaseInterface
+}
+
+type otpPayload struct {
+	Email   string
+	OTP     string
+	OTPType string
+}
+
+// NewQueuedMailTrapApi registers the handler of the email jobs, which sends them with
+// mailTrapApi.
+func NewQueuedMailTrapApi(mailTrapApi entities.MailTrapAPIInterface, jobUseCase entities.JobUseCaseInterface) *QueuedMailTrapApi {
+	jobUseCase.Register(constants.JobTypeEmailOTP, func(data []byte) (string, error) {
+		var payload otpPayload
+		if err := json.Unmarshal(data, &payload); err != nil {
+			return "", err
+		}
+
+		return "", mailTrapApi.SendOTP(payload.Email, payload.OTP, payload.OTPType)
+	})
+
+	return &QueuedMailTrapApi{jobUseCase: jobUseCase}
+}
+
+func (q *QueuedMailTrapApi) SendOTP(email, otp, otp_type string) error {
+	_, err := q.jobUseCase.Enqueue(constants.JobTypeEmailOTP, nil, otpPayload{
+		Email:   email,
+		OTP:     otp,
+		OTPType: otp_type,
+	})
+
+	return err
+}
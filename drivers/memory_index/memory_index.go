@@ -0,0 +1,320 @@
+package memory_index
+
+import (
+	"e-complaint-api/constants"
+	"e-complaint-api/entities"
+	"e-complaint-api/search"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+// idsKey is the statement instance key the IDs of the rows an update or delete is about
+// to write are kept under.
+const idsKey = "memory_index:ids"
+
+// MemoryIndex keeps a search.Index per collection. An index is built from its table on
+// the first search, after that gorm callbacks read the rows a statement wrote into it
+// once its transaction is committed. Only when the written rows cannot be told is the
+// index rebuilt on the next search.
+type MemoryIndex struct {
+	DB          *gorm.DB
+	collections map[string]search.Collection
+	indexes     map[string]*search.Index
+	stale       map[string]bool
+	mutex       sync.Mutex
+}
+
+func NewMemoryIndex(db *gorm.DB, collections []search.Collection) *MemoryIndex {
+	memoryIndex := &MemoryIndex{
+		DB:          db,
+		collections: make(map[string]search.Collection),
+		indexes:     make(map[string]*search.Index),
+		stale:       make(map[string]bool),
+	}
+	for _, collection := range collections {
+		memoryIndex.collections[collection.Name] = collection
+		memoryIndex.stale[collection.Name] = true
+	}
+
+	if db.ConnPool != nil {
+		db.ConnPool = &connPool{ConnPool: db.ConnPool}
+		db.Statement.ConnPool = db.ConnPool
+	}
+
+	db.Callback().Create().After("gorm:create").Register("memory_index:create", memoryIndex.refresh)
+	db.Callback().Update().Before("gorm:update").Register("memory_index:before_update", memoryIndex.collect)
+	db.Callback().Update().After("gorm:update").Register("memory_index:update", memoryIndex.refresh)
+	db.Callback().Delete().Before("gorm:delete").Register("memory_index:before_delete", memoryIndex.collect)
+	db.Callback().Delete().After("gorm:delete").Register("memory_index:delete", memoryIndex.refresh)
+
+	return memoryIndex
+}
+
+func (m *MemoryIndex) Search(collectionName string, query string, limit int) ([]entities.SearchHit, error) {
+	collection, ok := m.collections[collectionName]
+	if !ok {
+		return nil, constants.ErrInvalidSearchCollection
+	}
+
+	index, err := m.index(collection)
+	if err != nil {
+		return nil, err
+	}
+
+	results := index.Search(query, limit)
+	hits := make([]entities.SearchHit, 0, len(results))
+	for _, result := range results {
+		document, _ := index.Get(result.ID)
+		hit := entities.SearchHit{
+			ID:         result.ID,
+			Reference:  document.Reference,
+			Score:      result.Score,
+			Highlights: map[string]string{},
+		}
+		for _, field := range collection.Fields {
+			if highlight := search.Highlight(document.Fields[field], query); highlight != "" {
+				hit.Highlights[field] = highlight
+			}
+		}
+		hits = append(hits, hit)
+	}
+
+	return hits, nil
+}
+
+func (m *MemoryIndex) index(collection search.Collection) (*search.Index, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if !m.stale[collection.Name] {
+		return m.indexes[collection.Name], nil
+	}
+
+	documents, err := load(m.DB, collection, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	index := search.NewIndex()
+	for _, document := range documents {
+		index.Add(document)
+	}
+
+	m.indexes[collection.Name] = index
+	m.stale[collection.Name] = false
+
+	return index, nil
+}
+
+// load reads the documents of the rows with ids, of every row when ids is nil. Deleted
+// rows are left out.
+func load(db *gorm.DB, collection search.Collection, ids []string) ([]search.Document, error) {
+	columns := append([]string{"id"}, collection.Fields...)
+	if collection.Reference != "" {
+		columns = append(columns, collection.Reference)
+	}
+
+	query := db.Table(collection.Table).Select(columns).Where("deleted_at IS NULL")
+	if ids != nil {
+		query = query.Where("id IN ?", ids)
+	}
+
+	var rows []map[string]interface{}
+	if err := query.Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	documents := make([]search.Document, 0, len(rows))
+	for _, row := range rows {
+		document := search.Document{ID: toString(row["id"]), Fields: map[string]string{}}
+		if collection.Reference != "" {
+			document.Reference = toString(row[collection.Reference])
+		}
+		for _, field := range collection.Fields {
+			document.Fields[field] = toString(row[field])
+		}
+		documents = append(documents, document)
+	}
+
+	return documents, nil
+}
+
+// collection returns the collection a statement writes to and whether its index is
+// built. Statements that leave the indexed columns alone, such as counting the likes of
+// a complaint, write to no collection.
+func (m *MemoryIndex) collection(tx *gorm.DB) (search.Collection, bool) {
+	if tx.Error != nil || tx.Statement.Table == "" {
+		return search.Collection{}, false
+	}
+
+	for _, collection := range m.collections {
+		if collection.Table == tx.Statement.Table && changesIndexedColumn(tx.Statement, collection) {
+			m.mutex.Lock()
+			built := !m.stale[collection.Name]
+			m.mutex.Unlock()
+			return collection, built
+		}
+	}
+
+	return search.Collection{}, false
+}
+
+// collect keeps the IDs of the rows an update or delete is about to write. Rows that
+// the statement was not given are looked up with its conditions, before the write can
+// change or remove what they match.
+func (m *MemoryIndex) collect(tx *gorm.DB) {
+	if _, ok := m.collection(tx); !ok {
+		return
+	}
+
+	ids, ok := givenIDs(tx.Statement)
+	if !ok {
+		where, hasWhere := tx.Statement.Clauses["WHERE"]
+		if !hasWhere || tx.Statement.Schema == nil {
+			return
+		}
+
+		var values []interface{}
+		model := reflect.New(tx.Statement.Schema.ModelType).Interface()
+		err := tx.Session(&gorm.Session{NewDB: true}).Unscoped().Model(model).Clauses(where.Expression).Pluck("id", &values).Error
+		if err != nil {
+			return
+		}
+
+		ids = make([]string, 0, len(values))
+		for _, value := range values {
+			ids = append(ids, toString(value))
+		}
+	}
+
+	tx.InstanceSet(idsKey, ids)
+}
+
+// refresh reads the rows a statement wrote into the index of their collection. The rows
+// are read after the transaction of the statement is committed and not at all when it
+// is rolled back. When the rows cannot be told the index is rebuilt on the next search.
+func (m *MemoryIndex) refresh(tx *gorm.DB) {
+	collection, ok := m.collection(tx)
+	if !ok {
+		return
+	}
+
+	// ids stays nil when the written rows cannot be told
+	ids, _ := givenIDs(tx.Statement)
+	if collected, found := tx.InstanceGet(idsKey); found {
+		ids = collected.([]string)
+	}
+
+	update := func() {
+		if ids == nil {
+			m.invalidate(collection)
+		} else {
+			m.apply(collection, ids)
+		}
+	}
+
+	if transaction, ok := tx.Statement.ConnPool.(*transaction); ok {
+		transaction.AfterCommit(update)
+		return
+	}
+
+	update()
+}
+
+// apply reads the rows with ids into the index of the collection and removes the ones
+// that are gone.
+func (m *MemoryIndex) apply(collection search.Collection, ids []string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	index := m.indexes[collection.Name]
+	if m.stale[collection.Name] || index == nil || len(ids) == 0 {
+		return
+	}
+
+	documents, err := load(m.DB, collection, ids)
+	if err != nil {
+		m.stale[collection.Name] = true
+		return
+	}
+
+	written := make(map[string]bool, len(documents))
+	for _, document := range documents {
+		index.Add(document)
+		written[document.ID] = true
+	}
+	for _, id := range ids {
+		if !written[id] {
+			index.Remove(id)
+		}
+	}
+}
+
+func (m *MemoryIndex) invalidate(collection search.Collection) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.stale[collection.Name] = true
+}
+
+// givenIDs returns the primary keys of the rows a statement was given, which is every
+// row it writes unless it writes the rows that match its conditions.
+func givenIDs(statement *gorm.Statement) ([]string, bool) {
+	if statement.Schema == nil || statement.Schema.PrioritizedPrimaryField == nil {
+		return nil, false
+	}
+	field := statement.Schema.PrioritizedPrimaryField
+
+	values := []reflect.Value{}
+	switch value := reflect.Indirect(statement.ReflectValue); value.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			values = append(values, reflect.Indirect(value.Index(i)))
+		}
+	case reflect.Struct:
+		values = append(values, value)
+	default:
+		return nil, false
+	}
+
+	ids := make([]string, 0, len(values))
+	for _, value := range values {
+		id, zero := field.ValueOf(statement.Context, value)
+		if zero {
+			return nil, false
+		}
+		ids = append(ids, toString(id))
+	}
+
+	return ids, true
+}
+
+func changesIndexedColumn(statement *gorm.Statement, collection search.Collection) bool {
+	updates, ok := statement.Dest.(map[string]interface{})
+	if !ok {
+		return true
+	}
+
+	columns := append([]string{"deleted_at", collection.Reference}, collection.Fields...)
+	for _, column := range columns {
+		if _, ok := updates[column]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+func toString(value interface{}) string {
+	switch value := value.(type) {
+	case nil:
+		return ""
+	case []byte:
+		return string(value)
+	default:
+		return fmt.Sprint(value)
+	}
+}
@@ -0,0 +1,150 @@
+package memory_index
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"e-complaint-api/search"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"gorm.io/gorm/utils/tests"
+)
+
+type note struct {
+	ID        int
+	Body      string
+	Likes     int
+	DeletedAt gorm.DeletedAt
+}
+
+// fakeDriver opens connections that can only begin, commit and roll back transactions.
+type fakeDriver struct{}
+
+func (fakeDriver) Open(name string) (driver.Conn, error) {
+	return fakeConn{}, nil
+}
+
+type fakeConn struct{}
+
+func (fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("statements are not supported")
+}
+
+func (fakeConn) Close() error {
+	return nil
+}
+
+func (fakeConn) Begin() (driver.Tx, error) {
+	return fakeConn{}, nil
+}
+
+func (fakeConn) Commit() error {
+	return nil
+}
+
+func (fakeConn) Rollback() error {
+	return nil
+}
+
+func init() {
+	sql.Register("memory_index_fake", fakeDriver{})
+}
+
+// newBuiltIndex returns a memory index of notes whose index already holds notes 1 and
+// 2. The database runs dry, so rows read back from it are never found. Statements only
+// run in transactions when connPool is given.
+func newBuiltIndex(t *testing.T, connPool gorm.ConnPool) (*MemoryIndex, *gorm.DB) {
+	db, err := gorm.Open(tests.DummyDialector{}, &gorm.Config{DryRun: true, ConnPool: connPool})
+	assert.NoError(t, err)
+
+	memoryIndex := NewMemoryIndex(db, []search.Collection{{Name: "notes", Table: "notes", Fields: []string{"body"}}})
+	index := search.NewIndex()
+	index.Add(search.Document{ID: "1", Fields: map[string]string{"body": "jalan berlubang"}})
+	index.Add(search.Document{ID: "2", Fields: map[string]string{"body": "sampah menumpuk"}})
+	memoryIndex.indexes["notes"] = index
+	memoryIndex.stale["notes"] = false
+
+	return memoryIndex, db
+}
+
+func TestRefresh(t *testing.T) {
+	t.Run("success written row is read again", func(t *testing.T) {
+		memoryIndex, db := newBuiltIndex(t, nil)
+
+		db.Save(&note{ID: 1, Body: "jalan rusak"})
+
+		_, found := memoryIndex.indexes["notes"].Get("1")
+		assert.False(t, found)
+		assert.Equal(t, 1, memoryIndex.indexes["notes"].Len())
+		assert.False(t, memoryIndex.stale["notes"])
+	})
+
+	t.Run("success rows matched by conditions keep the index built", func(t *testing.T) {
+		memoryIndex, db := newBuiltIndex(t, nil)
+
+		db.Delete(&note{}, 2)
+
+		assert.Equal(t, 2, memoryIndex.indexes["notes"].Len())
+		assert.False(t, memoryIndex.stale["notes"])
+	})
+
+	t.Run("success other columns are ignored", func(t *testing.T) {
+		memoryIndex, db := newBuiltIndex(t, nil)
+
+		db.Model(&note{ID: 1}).Updates(map[string]interface{}{"likes": 3})
+
+		assert.Equal(t, 2, memoryIndex.indexes["notes"].Len())
+		assert.False(t, memoryIndex.stale["notes"])
+	})
+
+	t.Run("success unknown rows rebuild the index", func(t *testing.T) {
+		memoryIndex, db := newBuiltIndex(t, nil)
+
+		db.Create(&note{Body: "lampu mati"})
+
+		assert.True(t, memoryIndex.stale["notes"])
+	})
+}
+
+func TestRefreshAfterCommit(t *testing.T) {
+	sqlDB, err := sql.Open("memory_index_fake", "")
+	assert.NoError(t, err)
+	defer sqlDB.Close()
+
+	t.Run("success written row is read once committed", func(t *testing.T) {
+		memoryIndex, db := newBuiltIndex(t, sqlDB)
+
+		db.Save(&note{ID: 1, Body: "jalan rusak"})
+
+		_, found := memoryIndex.indexes["notes"].Get("1")
+		assert.False(t, found)
+	})
+
+	t.Run("success rows are read after the outer transaction", func(t *testing.T) {
+		memoryIndex, db := newBuiltIndex(t, sqlDB)
+
+		err := db.Transaction(func(tx *gorm.DB) error {
+			tx.Save(&note{ID: 1, Body: "jalan rusak"})
+			assert.Equal(t, 2, memoryIndex.indexes["notes"].Len())
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, memoryIndex.indexes["notes"].Len())
+	})
+
+	t.Run("success rolled back rows are never read", func(t *testing.T) {
+		memoryIndex, db := newBuiltIndex(t, sqlDB)
+
+		err := db.Transaction(func(tx *gorm.DB) error {
+			tx.Save(&note{ID: 1, Body: "jalan rusak"})
+			return errors.New("rollback")
+		})
+
+		assert.Error(t, err)
+		assert.Equal(t, 2, memoryIndex.indexes["notes"].Len())
+		assert.False(t, memoryIndex.stale["notes"])
+	})
+}
@@ -0,0 +1,76 @@
+package memory_index
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+// connPool begins transactions that run the functions queued on them once they are
+// committed, so the index never takes in rows that are rolled back.
+type connPool struct {
+	gorm.ConnPool
+}
+
+func (p *connPool) BeginTx(ctx context.Context, opts *sql.TxOptions) (gorm.ConnPool, error) {
+	db, err := p.GetDBConn()
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := db.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &transaction{Tx: tx, db: db}, nil
+}
+
+func (p *connPool) GetDBConn() (*sql.DB, error) {
+	if db, ok := p.ConnPool.(*sql.DB); ok {
+		return db, nil
+	} else if connector, ok := p.ConnPool.(gorm.GetDBConnector); ok {
+		return connector.GetDBConn()
+	}
+
+	return nil, gorm.ErrInvalidDB
+}
+
+type transaction struct {
+	*sql.Tx
+	db          *sql.DB
+	afterCommit []func()
+	mutex       sync.Mutex
+}
+
+// AfterCommit queues fn to run once the transaction is committed. Nothing runs when it
+// is rolled back.
+func (t *transaction) AfterCommit(fn func()) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.afterCommit = append(t.afterCommit, fn)
+}
+
+func (t *transaction) Commit() error {
+	if err := t.Tx.Commit(); err != nil {
+		return err
+	}
+
+	t.mutex.Lock()
+	afterCommit := t.afterCommit
+	t.afterCommit = nil
+	t.mutex.Unlock()
+
+	for _, fn := range afterCommit {
+		fn()
+	}
+
+	return nil
+}
+
+func (t *transaction) GetDBConn() (*sql.DB, error) {
+	return t.db, nil
+}
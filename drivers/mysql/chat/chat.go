@@ -1,7 +1,9 @@
 package chat
 
 import (
+	"e-complaint-api/constants"
 	"e-complaint-api/entities"
+	"errors"
 	"gorm.io/gorm"
 )
 
@@ -36,13 +38,96 @@ func (r *chatRepository) GetChatsBetweenUserAndAdmin(userID, adminID int) ([]ent
 	return messages, err
 }
 
-// CreateRoom creates a new chat room
-func (r *chatRepository) CreateRoom(name string) (*entities.Room, error) {
-	room := &entities.Room{Name: name}
-	if err := r.db.Create(room).Error; err != nil {
+// CreateRoom creates a new chat room together with its members
+func (r *chatRepository) CreateRoom(room *entities.Room, members []entities.RoomMember) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(room).Error; err != nil {
+			return err
+		}
+
+		for i := range members {
+			members[i].RoomID = room.ID
+		}
+		if err := tx.Create(&members).Error; err != nil {
+			return err
+		}
+
+		room.Members = members
+		return nil
+	})
+}
+
+// GetMessageByID retrieves a message of a room
+func (r *chatRepository) GetMessageByID(roomID int, messageID int) (*entities.Message, error) {
+	var message entities.Message
+	err := r.db.Where("room_id = ? AND id = ?", roomID, messageID).First(&message).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, constants.ErrMessageNotFound
+		}
 		return nil, err
 	}
-	return room, nil
+	return &message, nil
+}
+
+// UpdateMessage saves the edited text of a message
+func (r *chatRepository) UpdateMessage(message *entities.Message) error {
+	return r.db.Model(message).Select("message", "edited_at").Updates(message).Error
+}
+
+// DeleteMessage soft deletes a message
+func (r *chatRepository) DeleteMessage(message *entities.Message) error {
+	return r.db.Delete(message).Error
+}
+
+// GetMember retrieves the membership of a user or an admin in a room
+func (r *chatRepository) GetMember(roomID int, memberID int, memberType string) (*entities.RoomMember, error) {
+	var member entities.RoomMember
+	err := r.db.Where("room_id = ? AND member_id = ? AND member_type = ?", roomID, memberID, memberType).First(&member).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, constants.ErrNotRoomMember
+		}
+		return nil, err
+	}
+	return &member, nil
+}
+
+// CreateMember adds a member to a room
+func (r *chatRepository) CreateMember(member *entities.RoomMember) error {
+	return r.db.Create(member).Error
+}
+
+// UpdateLastRead moves the last message a member read forward, never back
+func (r *chatRepository) UpdateLastRead(member *entities.RoomMember, messageID int) error {
+	return r.db.Model(&entities.RoomMember{}).
+		Where("id = ? AND last_read_message_id < ?", member.ID, messageID).
+		Update("last_read_message_id", messageID).Error
+}
+
+// MemberExists checks whether the user or admin account of a member exists
+func (r *chatRepository) MemberExists(memberID int, memberType string) (bool, error) {
+	var model interface{} = &entities.User{}
+	if memberType == constants.ChatMemberAdmin {
+		model = &entities.Admin{}
+	}
+
+	var count int64
+	err := r.db.Model(model).Where("id = ?", memberID).Count(&count).Error
+	return count > 0, err
+}
+
+// GetComplaintUserID retrieves the ID of the user who reported a complaint
+func (r *chatRepository) GetComplaintUserID(complaintID string) (int, error) {
+	var complaint entities.Complaint
+	err := r.db.Select("user_id").Where("id = ?", complaintID).Take(&complaint).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, constants.ErrComplaintNotFound
+		}
+		return 0, err
+	}
+	return complaint.UserID, nil
 }
 
 // CreateMessage saves a new message in a specific room
@@ -50,13 +135,56 @@ func (r *chatRepository) CreateMessage(message *entities.Message) error {
 	return r.db.Create(message).Error
 }
 
-// GetAllRooms retrieves all chat rooms with their associated messages
-func (r *chatRepository) GetAllRooms() ([]entities.Room, error) {
+// GetRoomsByMember retrieves the rooms with the given statuses of which a user or an admin is a member
+func (r *chatRepository) GetRoomsByMember(memberID int, memberType string, statuses []string) ([]entities.Room, error) {
 	var rooms []entities.Room
-	err := r.db.Preload("Messages").Find(&rooms).Error
+	err := r.db.Joins("JOIN room_members ON room_members.room_id = rooms.id").
+		Where("room_members.member_id = ? AND room_members.member_type = ?", memberID, memberType).
+		Where("rooms.status IN ?", statuses).
+		Order("rooms.id DESC").Find(&rooms).Error
 	return rooms, err
 }
 
+// CountUnreadByRoom counts the messages of each room that a member has not read, leaving out their own messages
+func (r *chatRepository) CountUnreadByRoom(memberID int, memberType string, roomIDs []int) (map[int]int64, error) {
+	var rows []struct {
+		RoomID int
+		Count  int64
+	}
+	err := r.db.Model(&entities.Message{}).
+		Select("messages.room_id, COUNT(*) AS count").
+		Joins("JOIN room_members ON room_members.room_id = messages.room_id AND room_members.member_id = ? AND room_members.member_type = ?", memberID, memberType).
+		Where("messages.room_id IN ? AND messages.id > room_members.last_read_message_id", roomIDs).
+		Where("NOT (messages.sender_id = ? AND messages.sender_type = ?)", memberID, memberType).
+		Group("messages.room_id").Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := map[int]int64{}
+	for _, row := range rows {
+		counts[row.RoomID] = row.Count
+	}
+	return counts, nil
+}
+
+// GetLastMessages retrieves the last message of each room
+func (r *chatRepository) GetLastMessages(roomIDs []int) ([]entities.Message, error) {
+	var messages []entities.Message
+	lastIDs := r.db.Model(&entities.Message{}).Select("MAX(id)").Where("room_id IN ?", roomIDs).Group("room_id")
+	err := r.db.Where("id IN (?)", lastIDs).Find(&messages).Error
+	return messages, err
+}
+
+// UpdateRoomStatus opens, closes or archives a room
+func (r *chatRepository) UpdateRoomStatus(room *entities.Room, status string) error {
+	if err := r.db.Model(room).Update("status", status).Error; err != nil {
+		return err
+	}
+	room.Status = status
+	return nil
+}
+
 // GetMessagesByRoomID retrieves all messages for a specific room
 func (r *chatRepository) GetMessagesByRoomID(roomID int) ([]entities.Message, error) {
 	var messages []entities.Message
@@ -64,16 +192,26 @@ func (r *chatRepository) GetMessagesByRoomID(roomID int) ([]entities.Message, er
 	return messages, err
 }
 
+// GetMessagesByRoomIDAfter retrieves the messages of a room sent after the message with afterID
+func (r *chatRepository) GetMessagesByRoomIDAfter(roomID int, limit int, afterID int) ([]entities.Message, error) {
+	var messages []entities.Message
+	err := r.db.Where("room_id = ? AND id > ?", roomID, afterID).Order("id ASC").Limit(limit).Find(&messages).Error
+	return messages, err
+}
+
 // NewChatRepository initializes a new chat repository
 func NewChatRepository(db *gorm.DB) entities.ChatRepositoryInterface {
 	return &chatRepository{db: db}
 }
 
-// GetRoomByID retrieves a room by its ID
+// GetRoomByID retrieves a room with its members by its ID
 func (r *chatRepository) GetRoomByID(ID int) (*entities.Room, error) {
 	var room entities.Room
-	err := r.db.Preload("Messages").First(&room, ID).Error
+	err := r.db.Preload("Members").First(&room, ID).Error
 	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, constants.ErrRoomNotFound
+		}
 		return nil, err
 	}
 	return &room, nil
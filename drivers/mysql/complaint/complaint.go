@@ -3,9 +3,13 @@ package complaint
 import (
 	"e-complaint-api/constants"
 	"e-complaint-api/entities"
+	"e-complaint-api/geo"
+	"e-complaint-api/queryspec"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ComplaintRepo struct {
@@ -20,21 +24,19 @@ func (r *ComplaintRepo) GetPaginated(limit int, page int, search string, filter
 	var complaints []entities.Complaint
 	query := r.DB
 
-	if filter != nil {
-		query = query.Where(filter)
-	}
+	query = applyFilter(query, filter)
 
 	if search != "" {
 		query = query.Where("description LIKE ? OR address LIKE ? OR id LIKE ?", "%"+search+"%", "%"+search+"%", "%"+search+"%")
 	}
 
-	query = query.Order(sortBy + " " + sortType)
+	query = applySort(query, filter, sortBy, sortType)
 
 	if limit != 0 && page != 0 {
 		query = query.Limit(limit).Offset((page - 1) * limit)
 	}
 
-	if err := query.Preload("User").Preload("Regency").Preload("Category").Preload("Files").Find(&complaints).Error; err != nil {
+	if err := query.Preload("User").Preload("Regency").Preload("Category").Preload("Files").Preload("Assignee").Find(&complaints).Error; err != nil {
 		return nil, err
 	}
 
@@ -46,9 +48,7 @@ func (r *ComplaintRepo) GetMetaData(limit int, page int, search string, filter m
 
 	query := r.DB.Model(&entities.Complaint{})
 
-	if filter != nil {
-		query = query.Where(filter)
-	}
+	query = applyFilter(query, filter)
 
 	if search != "" {
 		query = query.Where("description LIKE ? OR address LIKE ? OR id LIKE ?", "%"+search+"%", "%"+search+"%", "%"+search+"%")
@@ -65,10 +65,80 @@ func (r *ComplaintRepo) GetMetaData(limit int, page int, search string, filter m
 	return metadata, nil
 }
 
+// GetAfter returns the complaints that sort after the complaint with afterID and
+// afterValue in sortBy, all of them when afterID is empty. Ties on sortBy are broken by
+// ID, so no complaint is skipped or repeated while new complaints arrive.
+func (r *ComplaintRepo) GetAfter(limit int, afterID string, afterValue string, search string, filter map[string]interface{}, sortBy string, sortType string) ([]entities.Complaint, error) {
+	var complaints []entities.Complaint
+
+	query := r.afterQuery(limit, afterID, afterValue, search, filter, sortBy, sortType)
+	if err := query.Preload("User").Preload("Regency").Preload("Category").Preload("Files").Preload("Assignee").Find(&complaints).Error; err != nil {
+		return nil, err
+	}
+
+	return complaints, nil
+}
+
+// GetAfterWithProcesses works like GetAfter and also loads the process timeline of every
+// complaint, oldest process first.
+func (r *ComplaintRepo) GetAfterWithProcesses(limit int, afterID string, afterValue string, search string, filter map[string]interface{}, sortBy string, sortType string) ([]entities.Complaint, error) {
+	var complaints []entities.Complaint
+
+	query := r.afterQuery(limit, afterID, afterValue, search, filter, sortBy, sortType)
+	query = query.Preload("Process", func(db *gorm.DB) *gorm.DB {
+		return db.Order("created_at ASC, id ASC")
+	}).Preload("Process.Admin")
+	if err := query.Preload("User").Preload("Regency").Preload("Category").Preload("Files").Preload("Assignee").Find(&complaints).Error; err != nil {
+		return nil, err
+	}
+
+	return complaints, nil
+}
+
+func (r *ComplaintRepo) afterQuery(limit int, afterID string, afterValue string, search string, filter map[string]interface{}, sortBy string, sortType string) *gorm.DB {
+	query := r.DB
+
+	query = applyFilter(query, filter)
+
+	if search != "" {
+		query = query.Where("description LIKE ? OR address LIKE ? OR id LIKE ?", "%"+search+"%", "%"+search+"%", "%"+search+"%")
+	}
+
+	if afterID != "" {
+		query = query.Where(queryspec.After(sortBy, afterValue, afterID, sortType))
+	}
+
+	query = applySort(query, filter, sortBy, sortType)
+	if sortBy != queryspec.Relevance {
+		query = query.Order("id " + sortType)
+	}
+
+	return query.Limit(limit)
+}
+
+// GetWithLocation returns at most limit complaints that have a location, newest first.
+func (r *ComplaintRepo) GetWithLocation(limit int, search string, filter map[string]interface{}) ([]entities.Complaint, error) {
+	var complaints []entities.Complaint
+
+	query := r.DB.Where("latitude IS NOT NULL AND longitude IS NOT NULL")
+
+	query = applyFilter(query, filter)
+
+	if search != "" {
+		query = query.Where("description LIKE ? OR address LIKE ? OR id LIKE ?", "%"+search+"%", "%"+search+"%", "%"+search+"%")
+	}
+
+	if err := query.Preload("Regency").Preload("Category").Order("created_at DESC").Limit(limit).Find(&complaints).Error; err != nil {
+		return nil, err
+	}
+
+	return complaints, nil
+}
+
 func (r *ComplaintRepo) GetByID(id string) (entities.Complaint, error) {
 	var complaint entities.Complaint
 
-	if err := r.DB.Preload("User").Preload("Regency").Preload("Category").Preload("Files").Where("id = ?", id).First(&complaint).Error; err != nil {
+	if err := r.DB.Preload("User").Preload("Regency").Preload("Category").Preload("Files").Preload("Assignee").Where("id = ?", id).First(&complaint).Error; err != nil {
 		return entities.Complaint{}, constants.ErrComplaintNotFound
 	}
 
@@ -147,6 +217,8 @@ func (r *ComplaintRepo) Update(complaint entities.Complaint) (entities.Complaint
 	oldComplaint.CategoryID = complaint.CategoryID
 	oldComplaint.RegencyID = complaint.RegencyID
 	oldComplaint.Address = complaint.Address
+	oldComplaint.Latitude = complaint.Latitude
+	oldComplaint.Longitude = complaint.Longitude
 	oldComplaint.Date = complaint.Date
 
 	if err := r.DB.Save(&oldComplaint).Error; err != nil {
@@ -218,3 +290,73 @@ func (r *ComplaintRepo) GetComplaintIDsByUserID(userID int) ([]string, error) {
 
 	return complaintIDs, nil
 }
+
+// applyFilter adds the column filters to query. The "overdue" key is not a column, it
+// selects complaints that passed the SLA deadline of their current status. The "scope"
+// key limits the complaints to the regencies and categories of an admin and the "ids"
+// key to the hits of a full-text search.
+func applyFilter(query *gorm.DB, filter map[string]interface{}) *gorm.DB {
+	columns := map[string]interface{}{}
+	for key, value := range filter {
+		switch key {
+		case "overdue":
+			if value == true {
+				query = query.Where("due_at < ?", time.Now())
+			}
+		case "near":
+			if circle, ok := value.(geo.Circle); ok {
+				query = applyNear(query, circle)
+			}
+		case "ids":
+			query = query.Where("id IN ?", value)
+		case "scope":
+			if scope, ok := value.(entities.AdminScope); ok {
+				if len(scope.RegencyIDs) > 0 {
+					query = query.Where("regency_id IN ?", scope.RegencyIDs)
+				}
+				if len(scope.CategoryIDs) > 0 {
+					query = query.Where("category_id IN ?", scope.CategoryIDs)
+				}
+			}
+		default:
+			if r, ok := value.(queryspec.Range); ok {
+				condition, vars := r.Where(key)
+				query = query.Where(condition, vars...)
+			} else {
+				columns[key] = value
+			}
+		}
+	}
+
+	if len(columns) > 0 {
+		query = query.Where(columns)
+	}
+
+	return query
+}
+
+// applyNear keeps complaints within the circle. The bounding box lets the location
+// index narrow down the rows before the haversine distance is computed.
+func applyNear(query *gorm.DB, circle geo.Circle) *gorm.DB {
+	southWest, northEast := geo.BoundingBox(circle.Center, circle.Radius)
+
+	return query.
+		Where("latitude BETWEEN ? AND ? AND longitude BETWEEN ? AND ?", southWest.Lat, northEast.Lat, southWest.Lng, northEast.Lng).
+		Where("6371 * ACOS(LEAST(1, COS(RADIANS(?)) * COS(RADIANS(latitude)) * COS(RADIANS(longitude) - RADIANS(?)) + SIN(RADIANS(?)) * SIN(RADIANS(latitude)))) <= ?",
+			circle.Center.Lat, circle.Center.Lng, circle.Center.Lat, circle.Radius)
+}
+
+// applySort orders complaints by sortBy. Sorting by "relevance" keeps the order of the
+// "ids" filter, which lists the hits of a full-text search best match first.
+func applySort(query *gorm.DB, filter map[string]interface{}, sortBy string, sortType string) *gorm.DB {
+	if sortBy != queryspec.Relevance {
+		return query.Order(clause.OrderByColumn{Column: clause.Column{Name: sortBy}, Desc: strings.EqualFold(sortType, "DESC")})
+	}
+
+	ids, ok := filter["ids"].([]string)
+	if !ok || len(ids) == 0 {
+		return query.Order("created_at DESC")
+	}
+
+	return query.Order(clause.OrderBy{Expression: clause.Expr{SQL: "FIELD(id, ?)", Vars: []interface{}{ids}, WithoutParentheses: true}})
+}
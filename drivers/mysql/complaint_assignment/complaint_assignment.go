@@ -0,0 +1,141 @@
+package complaint_assignment
+
+import (
+	"e-complaint-api/constants"
+	"e-complaint-api/entities"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ComplaintAssignmentRepo struct {
+	DB *gorm.DB
+}
+
+func NewComplaintAssignmentRepo(db *gorm.DB) *ComplaintAssignmentRepo {
+	return &ComplaintAssignmentRepo{DB: db}
+}
+
+func (r *ComplaintAssignmentRepo) Assign(complaintAssignment *entities.ComplaintAssignment) error {
+	if err := r.DB.Create(complaintAssignment).Error; err != nil {
+		return err
+	}
+
+	result := r.DB.Model(&entities.Complaint{}).Where("id = ?", complaintAssignment.ComplaintID).Update("assignee_id", complaintAssignment.AdminID)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return constants.ErrComplaintNotFound
+	}
+
+	if err := r.DB.Preload("Admin").Preload("AssignedBy").First(complaintAssignment).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *ComplaintAssignmentRepo) GetAssigneeID(complaintID string) (int, error) {
+	var complaint entities.Complaint
+	if err := r.DB.Select("id", "assignee_id").Where("id = ?", complaintID).First(&complaint).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, constants.ErrComplaintNotFound
+		}
+		return 0, err
+	}
+
+	if complaint.AssigneeID == nil {
+		return 0, nil
+	}
+
+	return *complaint.AssigneeID, nil
+}
+
+func (r *ComplaintAssignmentRepo) GetHistory(complaintID string) ([]entities.ComplaintAssignment, error) {
+	var complaintAssignments []entities.ComplaintAssignment
+	if err := r.DB.Where("complaint_id = ?", complaintID).Preload("Admin").Preload("AssignedBy").Order("created_at asc").Find(&complaintAssignments).Error; err != nil {
+		return nil, err
+	}
+
+	return complaintAssignments, nil
+}
+
+func (r *ComplaintAssignmentRepo) GetQueue(adminID int, excludedStatuses []string) ([]entities.Complaint, error) {
+	var complaints []entities.Complaint
+	query := r.DB.Where("assignee_id = ?", adminID)
+
+	if len(excludedStatuses) > 0 {
+		query = query.Where("status NOT IN ?", excludedStatuses)
+	}
+
+	// Complaints closest to their SLA deadline come first
+	query = query.Order("due_at IS NULL, due_at asc, created_at asc")
+
+	if err := query.Preload("User").Preload("Regency").Preload("Category").Preload("Files").Find(&complaints).Error; err != nil {
+		return nil, err
+	}
+
+	return complaints, nil
+}
+
+func (r *ComplaintAssignmentRepo) GetNextPoolMember(categoryID int, regencyID string) (entities.AssignmentPool, error) {
+	var assignmentPool entities.AssignmentPool
+	err := r.DB.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("(category_id = ? OR category_id IS NULL) AND (regency_id = ? OR regency_id IS NULL)", categoryID, regencyID).
+		Order("last_assigned_at IS NOT NULL, last_assigned_at asc, id asc").
+		First(&assignmentPool).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entities.AssignmentPool{}, constants.ErrAssignmentPoolNotFound
+		}
+		return entities.AssignmentPool{}, err
+	}
+
+	return assignmentPool, nil
+}
+
+func (r *ComplaintAssignmentRepo) UpdatePoolLastAssignedAt(id int, lastAssignedAt time.Time) error {
+	if err := r.DB.Model(&entities.AssignmentPool{}).Where("id = ?", id).Update("last_assigned_at", lastAssignedAt).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *ComplaintAssignmentRepo) GetPools() ([]entities.AssignmentPool, error) {
+	var assignmentPools []entities.AssignmentPool
+	if err := r.DB.Preload("Admin").Preload("Category").Preload("Regency").Order("id asc").Find(&assignmentPools).Error; err != nil {
+		return nil, err
+	}
+
+	return assignmentPools, nil
+}
+
+func (r *ComplaintAssignmentRepo) CreatePool(assignmentPool *entities.AssignmentPool) error {
+	if err := r.DB.Create(assignmentPool).Error; err != nil {
+		return err
+	}
+
+	if err := r.DB.Preload("Admin").Preload("Category").Preload("Regency").First(assignmentPool).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *ComplaintAssignmentRepo) DeletePool(id int) error {
+	result := r.DB.Delete(&entities.AssignmentPool{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return constants.ErrAssignmentPoolNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package complaint_duplicate
+
+import (
+	"e-complaint-api/entities"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// maxCandidates bounds the number of complaints that are compared with a new one.
+const maxCandidates = 200
+
+type ComplaintDuplicateRepo struct {
+	DB *gorm.DB
+}
+
+func NewComplaintDuplicateRepo(db *gorm.DB) *ComplaintDuplicateRepo {
+	return &ComplaintDuplicateRepo{DB: db}
+}
+
+// GetCandidates returns the recent complaints in the same category and regency that
+// are not in one of the excluded statuses.
+func (r *ComplaintDuplicateRepo) GetCandidates(complaint entities.Complaint, excludedStatuses []string, since time.Time) ([]entities.Complaint, error) {
+	var complaints []entities.Complaint
+	query := r.DB.Where("category_id = ? AND regency_id = ? AND id <> ? AND created_at >= ?", complaint.CategoryID, complaint.RegencyID, complaint.ID, since)
+
+	if len(excludedStatuses) > 0 {
+		query = query.Where("status NOT IN ?", excludedStatuses)
+	}
+
+	if err := query.Preload("Regency").Preload("Category").Order("created_at DESC").Limit(maxCandidates).Find(&complaints).Error; err != nil {
+		return nil, err
+	}
+
+	return complaints, nil
+}
+
+// Merge moves the likes, discussions and files of the duplicates to the master
+// complaint and links the duplicates to it.
+func (r *ComplaintDuplicateRepo) Merge(masterID string, duplicateIDs []string) error {
+	var masterLikes []entities.ComplaintLike
+	if err := r.DB.Select("id", "user_id").Where("complaint_id = ?", masterID).Find(&masterLikes).Error; err != nil {
+		return err
+	}
+
+	var duplicateLikes []entities.ComplaintLike
+	if err := r.DB.Select("id", "user_id").Where("complaint_id IN ?", duplicateIDs).Order("created_at ASC").Find(&duplicateLikes).Error; err != nil {
+		return err
+	}
+
+	// A user that liked several of the complaints keeps a single like on the master
+	liked := map[int]bool{}
+	for _, like := range masterLikes {
+		liked[like.UserID] = true
+	}
+
+	var movedLikeIDs, removedLikeIDs []int
+	for _, like := range duplicateLikes {
+		if liked[like.UserID] {
+			removedLikeIDs = append(removedLikeIDs, like.ID)
+			continue
+		}
+		liked[like.UserID] = true
+		movedLikeIDs = append(movedLikeIDs, like.ID)
+	}
+
+	if len(removedLikeIDs) > 0 {
+		if err := r.DB.Delete(&entities.ComplaintLike{}, removedLikeIDs).Error; err != nil {
+			return err
+		}
+	}
+
+	if len(movedLikeIDs) > 0 {
+		if err := r.DB.Model(&entities.ComplaintLike{}).Where("id IN ?", movedLikeIDs).Update("complaint_id", masterID).Error; err != nil {
+			return err
+		}
+	}
+
+	if err := r.DB.Model(&entities.Complaint{}).Where("id = ?", masterID).Update("total_likes", len(liked)).Error; err != nil {
+		return err
+	}
+
+	if err := r.DB.Unscoped().Model(&entities.Discussion{}).Where("complaint_id IN ?", duplicateIDs).Update("complaint_id", masterID).Error; err != nil {
+		return err
+	}
+
+	if err := r.DB.Model(&entities.ComplaintFile{}).Where("complaint_id IN ?", duplicateIDs).Update("complaint_id", masterID).Error; err != nil {
+		return err
+	}
+
+	if err := r.DB.Model(&entities.Complaint{}).Where("id IN ?", duplicateIDs).Updates(map[string]interface{}{"merged_into_id": masterID, "total_likes": 0}).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
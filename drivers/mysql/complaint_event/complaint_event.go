@@ -0,0 +1,35 @@
+package complaint_event
+
+import (
+	"e-complaint-api/entities"
+
+	"gorm.io/gorm"
+)
+
+type ComplaintEventRepo struct {
+	DB *gorm.DB
+}
+
+func NewComplaintEventRepo(db *gorm.DB) *ComplaintEventRepo {
+	return &ComplaintEventRepo{DB: db}
+}
+
+func (r *ComplaintEventRepo) Create(event *entities.ComplaintEvent) error {
+	if err := r.DB.Create(event).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetAfter returns the events of a complaint newer than the one with afterID, oldest
+// first.
+func (r *ComplaintEventRepo) GetAfter(complaintID string, afterID int, limit int) ([]entities.ComplaintEvent, error) {
+	var events []entities.ComplaintEvent
+	err := r.DB.Where("complaint_id = ? AND id > ?", complaintID, afterID).Order("id asc").Limit(limit).Find(&events).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
@@ -0,0 +1,97 @@
+package complaint_import
+
+import (
+	"e-complaint-api/entities"
+
+	"gorm.io/gorm"
+)
+
+// batchSize is the number of complaints inserted by a single statement.
+const batchSize = 100
+
+type ComplaintImportRepo struct {
+	DB *gorm.DB
+}
+
+func NewComplaintImportRepo(db *gorm.DB) *ComplaintImportRepo {
+	return &ComplaintImportRepo{DB: db}
+}
+
+func (r *ComplaintImportRepo) GetExistingUserIDs(ids []int) ([]int, error) {
+	existing := []int{}
+	if len(ids) == 0 {
+		return existing, nil
+	}
+
+	if err := r.DB.Model(&entities.User{}).Where("id IN ?", ids).Pluck("id", &existing).Error; err != nil {
+		return nil, err
+	}
+
+	return existing, nil
+}
+
+func (r *ComplaintImportRepo) GetExistingCategoryIDs(ids []int) ([]int, error) {
+	existing := []int{}
+	if len(ids) == 0 {
+		return existing, nil
+	}
+
+	if err := r.DB.Model(&entities.Category{}).Where("id IN ?", ids).Pluck("id", &existing).Error; err != nil {
+		return nil, err
+	}
+
+	return existing, nil
+}
+
+func (r *ComplaintImportRepo) GetExistingRegencyIDs(ids []string) ([]string, error) {
+	existing := []string{}
+	if len(ids) == 0 {
+		return existing, nil
+	}
+
+	if err := r.DB.Model(&entities.Regency{}).Where("id IN ?", ids).Pluck("id", &existing).Error; err != nil {
+		return nil, err
+	}
+
+	return existing, nil
+}
+
+// GetImportedKeys returns the keys of keys that belong to a complaint, deleted
+// complaints included, so a deleted complaint is not brought back by importing it again.
+func (r *ComplaintImportRepo) GetImportedKeys(keys []string) ([]string, error) {
+	imported := []string{}
+	if len(keys) == 0 {
+		return imported, nil
+	}
+
+	if err := r.DB.Unscoped().Model(&entities.Complaint{}).Where("import_key IN ?", keys).Pluck("import_key", &imported).Error; err != nil {
+		return nil, err
+	}
+
+	return imported, nil
+}
+
+func (r *ComplaintImportRepo) GetComplaintSLAs(categoryIDs []int) ([]entities.ComplaintSLA, error) {
+	slas := []entities.ComplaintSLA{}
+	if len(categoryIDs) == 0 {
+		return slas, nil
+	}
+
+	if err := r.DB.Where("category_id IN ?", categoryIDs).Find(&slas).Error; err != nil {
+		return nil, err
+	}
+
+	return slas, nil
+}
+
+// Import creates the complaints with their files and processes in one transaction, so
+// either every complaint is imported or none is.
+func (r *ComplaintImportRepo) Import(complaints []entities.Complaint) error {
+	if len(complaints) == 0 {
+		return nil
+	}
+
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		return tx.CreateInBatches(complaints, batchSize).Error
+	})
+}
@@ -3,6 +3,7 @@ package complaint_process
 import (
 	"e-complaint-api/constants"
 	"e-complaint-api/entities"
+	"errors"
 
 	"gorm.io/gorm"
 )
@@ -46,6 +47,10 @@ func (repo *ComplaintProcessRepo) Update(complaintProcesses *entities.ComplaintP
 		return constants.ErrComplaintProcessNotFound
 	}
 
+	if oldComplaintProcess.Escalation {
+		return constants.ErrComplaintProcessCannotBeUpdated
+	}
+
 	oldComplaintProcess.Message = complaintProcesses.Message
 	oldComplaintProcess.AdminID = complaintProcesses.AdminID
 	if err := repo.DB.Save(&oldComplaintProcess).Error; err != nil {
@@ -59,20 +64,32 @@ func (repo *ComplaintProcessRepo) Update(complaintProcesses *entities.ComplaintP
 	return nil
 }
 
-func (repo *ComplaintProcessRepo) Delete(complaintID string, complaintProcessID int) (string, error) {
+func (repo *ComplaintProcessRepo) GetLatestByComplaintID(complaintID string) (entities.ComplaintProcess, error) {
 	var complaintProcess entities.ComplaintProcess
-	if err := repo.DB.Where("complaint_id = ?", complaintID).Order("created_at desc").First(&complaintProcess).Error; err != nil {
-		return "", constants.ErrComplaintProcessNotFound
+	if err := repo.DB.Where("complaint_id = ? AND escalation = ?", complaintID, false).Order("created_at desc, id desc").First(&complaintProcess).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entities.ComplaintProcess{}, constants.ErrComplaintProcessNotFound
+		}
+		return entities.ComplaintProcess{}, constants.ErrInternalServerError
+	}
+
+	return complaintProcess, nil
+}
+
+func (repo *ComplaintProcessRepo) Delete(complaintID string, complaintProcessID int) error {
+	complaintProcess, err := repo.GetLatestByComplaintID(complaintID)
+	if err != nil {
+		return err
 	}
 
 	if complaintProcess.ID != complaintProcessID {
-		return "", constants.ErrComplaintProcessCannotBeDeleted
+		return constants.ErrComplaintProcessCannotBeDeleted
 	}
 
 	complaintProcess.DeletedAt = gorm.DeletedAt{Time: complaintProcess.CreatedAt, Valid: true}
 	if err := repo.DB.Save(&complaintProcess).Error; err != nil {
-		return "", constants.ErrInternalServerError
+		return constants.ErrInternalServerError
 	}
 
-	return complaintProcess.Status, nil
+	return nil
 }
@@ -0,0 +1,120 @@
+package complaint_sla
+
+import (
+	"e-complaint-api/constants"
+	"e-complaint-api/entities"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ComplaintSLARepo struct {
+	DB *gorm.DB
+}
+
+func NewComplaintSLARepo(db *gorm.DB) *ComplaintSLARepo {
+	return &ComplaintSLARepo{DB: db}
+}
+
+func (r *ComplaintSLARepo) GetAll() ([]entities.ComplaintSLA, error) {
+	var complaintSLAs []entities.ComplaintSLA
+	if err := r.DB.Preload("Category").Order("category_id asc").Find(&complaintSLAs).Error; err != nil {
+		return nil, err
+	}
+
+	return complaintSLAs, nil
+}
+
+func (r *ComplaintSLARepo) Create(complaintSLA *entities.ComplaintSLA) error {
+	if err := r.DB.Create(complaintSLA).Error; err != nil {
+		return err
+	}
+
+	if err := r.DB.Preload("Category").First(complaintSLA).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *ComplaintSLARepo) Update(complaintSLA *entities.ComplaintSLA) error {
+	var oldComplaintSLA entities.ComplaintSLA
+	if err := r.DB.First(&oldComplaintSLA, complaintSLA.ID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return constants.ErrComplaintSLANotFound
+		}
+		return err
+	}
+
+	oldComplaintSLA.TargetDays = complaintSLA.TargetDays
+	if err := r.DB.Save(&oldComplaintSLA).Error; err != nil {
+		return err
+	}
+
+	if err := r.DB.Preload("Category").First(complaintSLA, complaintSLA.ID).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *ComplaintSLARepo) Delete(id int) error {
+	result := r.DB.Delete(&entities.ComplaintSLA{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return constants.ErrComplaintSLANotFound
+	}
+
+	return nil
+}
+
+func (r *ComplaintSLARepo) GetTargetDays(complaintID string, status string) (int, error) {
+	var targetDays int
+	err := r.DB.Model(&entities.ComplaintSLA{}).
+		Select("complaint_slas.target_days").
+		Joins("JOIN complaints ON complaints.category_id = complaint_slas.category_id").
+		Where("complaints.id = ? AND complaint_slas.status = ?", complaintID, status).
+		Scan(&targetDays).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return targetDays, nil
+}
+
+func (r *ComplaintSLARepo) UpdateDueDate(complaintID string, dueAt *time.Time) error {
+	err := r.DB.Model(&entities.Complaint{}).Where("id = ?", complaintID).Updates(map[string]interface{}{
+		"due_at":       dueAt,
+		"escalated_at": nil,
+	}).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *ComplaintSLARepo) GetOverdue(now time.Time) ([]entities.Complaint, error) {
+	var complaints []entities.Complaint
+	if err := r.DB.Where("due_at < ? AND escalated_at IS NULL", now).Find(&complaints).Error; err != nil {
+		return nil, err
+	}
+
+	return complaints, nil
+}
+
+func (r *ComplaintSLARepo) MarkEscalated(complaintID string, escalatedAt time.Time) error {
+	err := r.DB.Model(&entities.Complaint{}).Where("id = ?", complaintID).Updates(map[string]interface{}{
+		"escalated_at": escalatedAt,
+		"sla_breached": true,
+	}).Error
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"e-complaint-api/entities"
 	"gorm.io/gorm"
 	"strconv"
+	"time"
 )
 
 type DashboardRepo struct {
@@ -90,3 +91,20 @@ func (repo *DashboardRepo) GetLatestComplaints(limit int) ([]entities.Complaint,
 
 	return complaints, nil
 }
+
+func (repo *DashboardRepo) GetSLAByRegency() ([]response.SLAByRegency, error) {
+	var results []response.SLAByRegency
+	breached := "complaints.sla_breached = 1 OR complaints.due_at < ?"
+	now := time.Now()
+
+	if err := repo.DB.Model(&entities.Complaint{}).
+		Select("regencies.name as regency, SUM(CASE WHEN "+breached+" THEN 0 ELSE 1 END) as on_time, SUM(CASE WHEN "+breached+" THEN 1 ELSE 0 END) as breached", now, now).
+		Joins("JOIN regencies ON regencies.id = complaints.regency_id").
+		Group("regencies.id, regencies.name").
+		Order("regencies.name asc").
+		Scan(&results).Error; err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
@@ -39,6 +39,16 @@ func (r *DiscussionRepo) GetByComplaintID(complaintID string) (*[]entities.Discu
 	return &discussions, nil
 }
 
+// GetByComplaintIDAfter returns the discussions of a complaint posted after the one with
+// afterID, oldest first.
+func (r *DiscussionRepo) GetByComplaintIDAfter(complaintID string, limit int, afterID int) ([]entities.Discussion, error) {
+	var discussions []entities.Discussion
+	if err := r.DB.Preload("User").Preload("Admin").Preload("Complaint").Where("complaint_id = ? AND id > ?", complaintID, afterID).Order("id ASC").Limit(limit).Find(&discussions).Error; err != nil {
+		return nil, err
+	}
+	return discussions, nil
+}
+
 func (r *DiscussionRepo) Update(discussion *entities.Discussion) error {
 	if err := r.DB.Save(discussion).Error; err != nil {
 		return err
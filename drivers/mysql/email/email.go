@@ -0,0 +1,67 @@
+package email
+
+import (
+	"e-complaint-api/constants"
+	"e-complaint-api/entities"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+type EmailRepo struct {
+	DB *gorm.DB
+}
+
+func NewEmailRepo(db *gorm.DB) *EmailRepo {
+	return &EmailRepo{DB: db}
+}
+
+func (r *EmailRepo) GetOptOuts(userID int) ([]string, error) {
+	events := []string{}
+	if err := r.DB.Model(&entities.EmailOptOut{}).Where("user_id = ?", userID).Pluck("event", &events).Error; err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// UpdateOptOuts replaces the events a user opted out of.
+func (r *EmailRepo) UpdateOptOuts(userID int, events []string) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&entities.EmailOptOut{}).Error; err != nil {
+			return err
+		}
+
+		if len(events) == 0 {
+			return nil
+		}
+
+		optOuts := []entities.EmailOptOut{}
+		for _, event := range events {
+			optOuts = append(optOuts, entities.EmailOptOut{UserID: userID, Event: event})
+		}
+
+		return tx.Create(&optOuts).Error
+	})
+}
+
+func (r *EmailRepo) GetComplaint(complaintID string) (entities.Complaint, error) {
+	var complaint entities.Complaint
+	if err := r.DB.Preload("User").Where("id = ?", complaintID).First(&complaint).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entities.Complaint{}, constants.ErrComplaintNotFound
+		}
+		return entities.Complaint{}, err
+	}
+
+	return complaint, nil
+}
+
+func (r *EmailRepo) GetEvidence(complaintID string) ([]entities.UnggahBukti, error) {
+	var evidence []entities.UnggahBukti
+	if err := r.DB.Where("complaint_id = ?", complaintID).Order("id asc").Find(&evidence).Error; err != nil {
+		return nil, err
+	}
+
+	return evidence, nil
+}
@@ -0,0 +1,98 @@
+package job
+
+import (
+	"e-complaint-api/constants"
+	"e-complaint-api/entities"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type JobRepo struct {
+	DB *gorm.DB
+}
+
+func NewJobRepo(db *gorm.DB) *JobRepo {
+	return &JobRepo{DB: db}
+}
+
+func (r *JobRepo) Create(job *entities.Job) error {
+	if err := r.DB.Create(job).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Claim locks the next job that is due, or that has been running since before
+// staleBefore because its worker stopped, and marks it as running. Rows locked by
+// other workers are skipped, so every job is claimed by one worker at a time. It
+// returns nil when no job is due.
+func (r *JobRepo) Claim(now time.Time, staleBefore time.Time) (*entities.Job, error) {
+	var job entities.Job
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("(status = ? AND run_at <= ?) OR (status = ? AND locked_at < ?)", constants.JobStatusPending, now, constants.JobStatusRunning, staleBefore).
+			Order("run_at asc, id asc").
+			Take(&job).Error
+		if err != nil {
+			return err
+		}
+
+		job.Status = constants.JobStatusRunning
+		job.Attempts++
+		job.LockedAt = &now
+
+		return tx.Save(&job).Error
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+func (r *JobRepo) Update(job *entities.Job) error {
+	if err := r.DB.Save(job).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetAfter returns the jobs older than the one with afterID, newest first. An afterID
+// of 0 starts at the newest job.
+func (r *JobRepo) GetAfter(filter map[string]interface{}, limit int, afterID int) ([]entities.Job, error) {
+	var jobs []entities.Job
+
+	query := r.DB.Model(&entities.Job{})
+	if len(filter) > 0 {
+		query = query.Where(filter)
+	}
+	if afterID != 0 {
+		query = query.Where("id < ?", afterID)
+	}
+
+	if err := query.Order("id desc").Limit(limit).Find(&jobs).Error; err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+func (r *JobRepo) GetByID(id int) (entities.Job, error) {
+	var job entities.Job
+	if err := r.DB.First(&job, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entities.Job{}, constants.ErrJobNotFound
+		}
+		return entities.Job{}, err
+	}
+
+	return job, nil
+}
@@ -48,10 +48,13 @@ func ConnectDB(config Config) *gorm.DB {
 }
 
 func Migration(db *gorm.DB) {
+	db.AutoMigrate(entities.Permission{})
+	db.AutoMigrate(entities.Role{})
 	db.AutoMigrate(entities.Admin{})
 	db.AutoMigrate(entities.User{})
 	db.AutoMigrate(entities.Category{})
 	db.AutoMigrate(entities.Regency{})
+	db.AutoMigrate(entities.RegencyBoundary{})
 	db.AutoMigrate(entities.Complaint{})
 	db.AutoMigrate(entities.ComplaintFile{})
 	db.AutoMigrate(entities.ComplaintProcess{})
@@ -66,9 +69,34 @@ func Migration(db *gorm.DB) {
 	db.AutoMigrate(entities.Chatbot{})
 	db.AutoMigrate(entities.Message{})
 	db.AutoMigrate(entities.Room{})
+	db.AutoMigrate(entities.RoomMember{})
 	db.AutoMigrate(entities.UnggahBukti{})
 	db.AutoMigrate(entities.Schedule{})
 	db.AutoMigrate(&entities.Notification{})
+	db.AutoMigrate(entities.ComplaintSLA{})
+	db.AutoMigrate(entities.ComplaintAssignment{})
+	db.AutoMigrate(entities.AssignmentPool{})
+	db.AutoMigrate(entities.Session{})
+	db.AutoMigrate(entities.Job{})
+	db.AutoMigrate(entities.EmailOptOut{})
+	db.AutoMigrate(entities.ComplaintEvent{})
+	db.AutoMigrate(entities.Webhook{})
+	db.AutoMigrate(entities.WebhookDelivery{})
+
+	// Evidence used to be saved as "uploads/bukti_unggah/<file>" relative to the working
+	// directory, the file storage expects the path inside its own base directory
+	db.Model(&entities.UnggahBukti{}).
+		Where("path LIKE ?", "uploads/bukti_unggah/%").
+		Update("path", gorm.Expr("SUBSTRING(path, ?)", len("uploads/")+1))
+
+	// Escalations are processes without an admin, AutoMigrate does not drop the NOT NULL
+	// the column used to have
+	columnTypes, _ := db.Migrator().ColumnTypes(&entities.ComplaintProcess{})
+	for _, columnType := range columnTypes {
+		if nullable, ok := columnType.Nullable(); columnType.Name() == "admin_id" && ok && !nullable {
+			db.Migrator().AlterColumn(&entities.ComplaintProcess{}, "AdminID")
+		}
+	}
 }
 
 func Seeder(db *gorm.DB, regencyAPI entities.RegencyIndonesiaAreaAPIInterface) {
@@ -76,6 +104,7 @@ func Seeder(db *gorm.DB, regencyAPI entities.RegencyIndonesiaAreaAPIInterface) {
 	seeder.SeedUser(db)
 	seeder.SeedCategory(db)
 	seeder.SeedRegencyFromAPI(db, regencyAPI)
+	seeder.SeedRegencyBoundary(db)
 	seeder.SeedComplaint(db)
 	seeder.SeedComplaintFile(db)
 	seeder.SeedComplaintProcess(db)
@@ -87,4 +116,8 @@ func Seeder(db *gorm.DB, regencyAPI entities.RegencyIndonesiaAreaAPIInterface) {
 	seeder.SeedFaq(db)
 	seeder.SeedNewsComment(db)
 	seeder.SeedNewsLike(db)
+	seeder.SeedPermission(db)
+	seeder.SeedRole(db)
+	seeder.SeedAdminScope(db)
+	seeder.SeedLegacyAdminRole(db)
 }
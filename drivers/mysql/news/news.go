@@ -3,9 +3,12 @@ package news
 import (
 	"e-complaint-api/constants"
 	"e-complaint-api/entities"
+	"e-complaint-api/queryspec"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type NewsRepo struct {
@@ -20,15 +23,13 @@ func (r *NewsRepo) GetPaginated(limit int, page int, search string, filter map[s
 	var news []entities.News
 	query := r.DB
 
-	if filter != nil {
-		query = query.Where(filter)
-	}
+	query = applyFilter(query, filter)
 
 	if search != "" {
 		query = query.Where("title LIKE ? OR content LIKE ?", "%"+search+"%", "%"+search+"%")
 	}
 
-	query = query.Order(sortBy + " " + sortType)
+	query = applySort(query, filter, sortBy, sortType)
 
 	if limit != 0 && page != 0 {
 		query = query.Limit(limit).Offset((page - 1) * limit)
@@ -46,9 +47,7 @@ func (r *NewsRepo) GetMetaData(limit int, page int, search string, filter map[st
 
 	query := r.DB.Model(&entities.News{})
 
-	if filter != nil {
-		query = query.Where(filter)
-	}
+	query = applyFilter(query, filter)
 
 	if search != "" {
 		query = query.Where("title LIKE ?", "%"+search+"%")
@@ -65,6 +64,34 @@ func (r *NewsRepo) GetMetaData(limit int, page int, search string, filter map[st
 	return metadata, nil
 }
 
+// GetAfter returns the news that sort after the news with afterID and afterValue in
+// sortBy, all of them when afterID is 0. Ties on sortBy are broken by ID.
+func (r *NewsRepo) GetAfter(limit int, afterID int, afterValue string, search string, filter map[string]interface{}, sortBy string, sortType string) ([]entities.News, error) {
+	var news []entities.News
+	query := r.DB
+
+	query = applyFilter(query, filter)
+
+	if search != "" {
+		query = query.Where("title LIKE ? OR content LIKE ?", "%"+search+"%", "%"+search+"%")
+	}
+
+	if afterID != 0 {
+		query = query.Where(queryspec.After(sortBy, afterValue, afterID, sortType))
+	}
+
+	query = applySort(query, filter, sortBy, sortType)
+	if sortBy != queryspec.Relevance {
+		query = query.Order("id " + sortType)
+	}
+
+	if err := query.Limit(limit).Preload("Admin").Preload("Category").Preload("Files").Find(&news).Error; err != nil {
+		return nil, err
+	}
+
+	return news, nil
+}
+
 func (r *NewsRepo) GetByID(id int) (entities.News, error) {
 	var news entities.News
 
@@ -122,3 +149,43 @@ func (r *NewsRepo) Update(news entities.News) (entities.News, error) {
 
 	return news, nil
 }
+
+// applyFilter adds the column filters to query. The "ids" key is not a column, it limits
+// the news to the hits of a full-text search.
+func applyFilter(query *gorm.DB, filter map[string]interface{}) *gorm.DB {
+	columns := map[string]interface{}{}
+	for key, value := range filter {
+		switch key {
+		case "ids":
+			query = query.Where("id IN ?", value)
+		default:
+			if r, ok := value.(queryspec.Range); ok {
+				condition, vars := r.Where(key)
+				query = query.Where(condition, vars...)
+			} else {
+				columns[key] = value
+			}
+		}
+	}
+
+	if len(columns) > 0 {
+		query = query.Where(columns)
+	}
+
+	return query
+}
+
+// applySort orders news by sortBy. Sorting by "relevance" keeps the order of the "ids"
+// filter, which lists the hits of a full-text search best match first.
+func applySort(query *gorm.DB, filter map[string]interface{}, sortBy string, sortType string) *gorm.DB {
+	if sortBy != queryspec.Relevance {
+		return query.Order(clause.OrderByColumn{Column: clause.Column{Name: sortBy}, Desc: strings.EqualFold(sortType, "DESC")})
+	}
+
+	ids, ok := filter["ids"].([]int)
+	if !ok || len(ids) == 0 {
+		return query.Order("created_at DESC")
+	}
+
+	return query.Order(clause.OrderBy{Expression: clause.Expr{SQL: "FIELD(id, ?)", Vars: []interface{}{ids}, WithoutParentheses: true}})
+}
@@ -0,0 +1,91 @@
+package notification
+
+import (
+	"e-complaint-api/constants"
+	"e-complaint-api/entities"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type NotificationRepo struct {
+	DB *gorm.DB
+}
+
+func NewNotificationRepo(db *gorm.DB) *NotificationRepo {
+	return &NotificationRepo{DB: db}
+}
+
+func (r *NotificationRepo) Create(notifications []*entities.Notification) error {
+	if err := r.DB.Create(notifications).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *NotificationRepo) GetByRecipient(recipientID int, recipientType string) ([]entities.Notification, error) {
+	var notifications []entities.Notification
+
+	if err := r.DB.Where("recipient_id = ? AND recipient_type = ?", recipientID, recipientType).Order("created_at desc").Find(&notifications).Error; err != nil {
+		return nil, err
+	}
+
+	return notifications, nil
+}
+
+// GetByRecipientAfter returns the notifications of a recipient older than the one with
+// afterID, newest first. An afterID of 0 starts at the newest notification.
+func (r *NotificationRepo) GetByRecipientAfter(recipientID int, recipientType string, limit int, afterID int) ([]entities.Notification, error) {
+	var notifications []entities.Notification
+
+	query := r.DB.Where("recipient_id = ? AND recipient_type = ?", recipientID, recipientType)
+	if afterID != 0 {
+		query = query.Where("id < ?", afterID)
+	}
+
+	if err := query.Order("id desc").Limit(limit).Find(&notifications).Error; err != nil {
+		return nil, err
+	}
+
+	return notifications, nil
+}
+
+func (r *NotificationRepo) MarkAsRead(id int, recipientID int, recipientType string) error {
+	var notification entities.Notification
+
+	if err := r.DB.Where("id = ? AND recipient_id = ? AND recipient_type = ?", id, recipientID, recipientType).First(&notification).Error; err != nil {
+		return constants.ErrNotificationNotFound
+	}
+
+	if notification.IsRead {
+		return nil
+	}
+
+	now := time.Now()
+	notification.IsRead = true
+	notification.ReadAt = &now
+	if err := r.DB.Save(&notification).Error; err != nil {
+		return constants.ErrInternalServerError
+	}
+
+	return nil
+}
+
+func (r *NotificationRepo) MarkAllAsRead(recipientID int, recipientType string) error {
+	if err := r.DB.Model(&entities.Notification{}).Where("recipient_id = ? AND recipient_type = ? AND is_read = ?", recipientID, recipientType, false).Updates(map[string]interface{}{"is_read": true, "read_at": time.Now()}).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *NotificationRepo) CountUnread(recipientID int, recipientType string) (int64, error) {
+	var count int64
+
+	if err := r.DB.Model(&entities.Notification{}).Where("recipient_id = ? AND recipient_type = ? AND is_read = ?", recipientID, recipientType, false).Count(&count).Error; err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
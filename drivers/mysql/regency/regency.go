@@ -1,7 +1,9 @@
 package regency
 
 import (
+	"e-complaint-api/constants"
 	"e-complaint-api/entities"
+	"errors"
 
 	"gorm.io/gorm"
 )
@@ -22,3 +24,15 @@ func (r *RegencyRepo) GetAll() ([]entities.Regency, error) {
 
 	return regencies, nil
 }
+
+func (r *RegencyRepo) GetBoundary(regencyID string) (entities.RegencyBoundary, error) {
+	var boundary entities.RegencyBoundary
+	if err := r.DB.Where("regency_id = ?", regencyID).First(&boundary).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entities.RegencyBoundary{}, constants.ErrNotFound
+		}
+		return entities.RegencyBoundary{}, err
+	}
+
+	return boundary, nil
+}
@@ -0,0 +1,166 @@
+package role
+
+import (
+	"e-complaint-api/constants"
+	"e-complaint-api/entities"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+type RoleRepo struct {
+	DB *gorm.DB
+}
+
+func NewRoleRepo(db *gorm.DB) *RoleRepo {
+	return &RoleRepo{DB: db}
+}
+
+func (r *RoleRepo) GetAll() ([]entities.Role, error) {
+	var roles []entities.Role
+	if err := r.DB.Preload("Permissions").Order("id asc").Find(&roles).Error; err != nil {
+		return nil, err
+	}
+
+	return roles, nil
+}
+
+func (r *RoleRepo) GetByID(id int) (entities.Role, error) {
+	var role entities.Role
+	if err := r.DB.Preload("Permissions").First(&role, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entities.Role{}, constants.ErrRoleNotFound
+		}
+		return entities.Role{}, err
+	}
+
+	return role, nil
+}
+
+func (r *RoleRepo) Create(role *entities.Role, permissions []string) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		var rolePermissions []entities.Permission
+		if err := tx.Where("name IN ?", permissions).Find(&rolePermissions).Error; err != nil {
+			return err
+		}
+		role.Permissions = rolePermissions
+
+		return tx.Create(role).Error
+	})
+}
+
+func (r *RoleRepo) Update(role *entities.Role, permissions []string) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		var oldRole entities.Role
+		if err := tx.First(&oldRole, role.ID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return constants.ErrRoleNotFound
+			}
+			return err
+		}
+
+		oldRole.Name = role.Name
+		oldRole.Description = role.Description
+		if err := tx.Omit("Permissions").Save(&oldRole).Error; err != nil {
+			return err
+		}
+
+		var rolePermissions []entities.Permission
+		if err := tx.Where("name IN ?", permissions).Find(&rolePermissions).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&oldRole).Association("Permissions").Replace(rolePermissions); err != nil {
+			return err
+		}
+
+		return tx.Preload("Permissions").First(role, role.ID).Error
+	})
+}
+
+func (r *RoleRepo) Delete(id int) error {
+	result := r.DB.Select("Permissions").Delete(&entities.Role{ID: id})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return constants.ErrRoleNotFound
+	}
+
+	return nil
+}
+
+func (r *RoleRepo) GetPermissions() ([]entities.Permission, error) {
+	var permissions []entities.Permission
+	if err := r.DB.Order("id asc").Find(&permissions).Error; err != nil {
+		return nil, err
+	}
+
+	return permissions, nil
+}
+
+func (r *RoleRepo) GetAdminAccess(adminID int) (entities.Admin, error) {
+	var admin entities.Admin
+	if err := r.DB.Preload("Role.Permissions").Preload("Regencies").Preload("Categories").First(&admin, adminID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entities.Admin{}, constants.ErrAdminNotFound
+		}
+		return entities.Admin{}, err
+	}
+
+	return admin, nil
+}
+
+func (r *RoleRepo) UpdateAdminAccess(adminID int, roleID *int, regencyIDs []string, categoryIDs []int) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		var admin entities.Admin
+		if err := tx.First(&admin, adminID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return constants.ErrAdminNotFound
+			}
+			return err
+		}
+
+		if roleID != nil {
+			if err := tx.First(&entities.Role{}, *roleID).Error; err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return constants.ErrRoleNotFound
+				}
+				return err
+			}
+		}
+
+		var regencies []entities.Regency
+		if len(regencyIDs) > 0 {
+			if err := tx.Where("id IN ?", regencyIDs).Find(&regencies).Error; err != nil {
+				return err
+			}
+
+			if len(regencies) != len(regencyIDs) {
+				return constants.ErrRegencyNotFound
+			}
+		}
+
+		var categories []entities.Category
+		if len(categoryIDs) > 0 {
+			if err := tx.Where("id IN ?", categoryIDs).Find(&categories).Error; err != nil {
+				return err
+			}
+
+			if len(categories) != len(categoryIDs) {
+				return constants.ErrCategoryNotFound
+			}
+		}
+
+		if err := tx.Model(&admin).Update("role_id", roleID).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&admin).Association("Regencies").Replace(regencies); err != nil {
+			return err
+		}
+
+		return tx.Model(&admin).Association("Categories").Replace(categories)
+	})
+}
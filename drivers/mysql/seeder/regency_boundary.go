@@ -0,0 +1,59 @@
+package seeder
+
+import (
+	"e-complaint-api/entities"
+	"e-complaint-api/geo"
+	_ "embed"
+	"encoding/json"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// regencyBoundaries holds simplified outlines of the regencies of Banten. They are
+// drawn generously so locations near a border are not rejected; replace the file
+// with official boundaries when exact checks are needed.
+//
+//go:embed data/regency_boundaries.geojson
+var regencyBoundaries []byte
+
+func SeedRegencyBoundary(db *gorm.DB) {
+	if err := db.First(&entities.RegencyBoundary{}).Error; errors.Is(err, gorm.ErrRecordNotFound) {
+		var collection struct {
+			Features []struct {
+				Properties struct {
+					Code string `json:"code"`
+				} `json:"properties"`
+				Geometry json.RawMessage `json:"geometry"`
+			} `json:"features"`
+		}
+		if err := json.Unmarshal(regencyBoundaries, &collection); err != nil {
+			panic(err)
+		}
+
+		var boundaries []entities.RegencyBoundary
+		for _, feature := range collection.Features {
+			if _, err := geo.ParseGeometry(string(feature.Geometry)); err != nil {
+				panic(err)
+			}
+
+			// Only regencies that were seeded can have a boundary
+			if err := db.First(&entities.Regency{}, "id = ?", feature.Properties.Code).Error; err != nil {
+				continue
+			}
+
+			boundaries = append(boundaries, entities.RegencyBoundary{
+				RegencyID: feature.Properties.Code,
+				Geometry:  string(feature.Geometry),
+			})
+		}
+
+		if len(boundaries) == 0 {
+			return
+		}
+
+		if err := db.Omit("Regency").Create(&boundaries).Error; err != nil {
+			panic(err)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package seeder
+
+import (
+	"e-complaint-api/constants"
+	"e-complaint-api/entities"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+var permissionDescriptions = map[string]string{
+	constants.PermissionAdminRead:        "Melihat daftar admin",
+	constants.PermissionUserRead:         "Melihat daftar pengguna",
+	constants.PermissionComplaintProcess: "Memproses aduan",
+	constants.PermissionComplaintAssign:  "Menugaskan aduan ke admin lain",
+	constants.PermissionComplaintImport:  "Mengimpor aduan",
+	constants.PermissionComplaintExport:  "Mengekspor aduan",
+	constants.PermissionCategoryManage:   "Mengelola kategori",
+	constants.PermissionNewsManage:       "Mengelola berita",
+	constants.PermissionScheduleManage:   "Mengelola jadwal",
+	constants.PermissionDashboardRead:    "Melihat dashboard",
+	constants.PermissionJobManage:        "Mengelola antrean pekerjaan latar belakang",
+	constants.PermissionWebhookManage:    "Mengelola webhook untuk sistem lain",
+}
+
+// SeedPermission makes sure every permission known by the application exists, so new
+// permissions become available without a manual migration.
+func SeedPermission(db *gorm.DB) {
+	for _, name := range constants.Permissions {
+		permission := entities.Permission{Name: name, Description: permissionDescriptions[name]}
+		if err := db.Where(entities.Permission{Name: name}).FirstOrCreate(&permission).Error; err != nil {
+			panic(err)
+		}
+	}
+}
+
+func SeedRole(db *gorm.DB) {
+	if err := db.First(&entities.Role{}).Error; errors.Is(err, gorm.ErrRecordNotFound) {
+		var permissions []entities.Permission
+		if err := db.Where("name IN ?", []string{
+			constants.PermissionAdminRead,
+			constants.PermissionUserRead,
+			constants.PermissionComplaintProcess,
+			constants.PermissionComplaintAssign,
+			constants.PermissionDashboardRead,
+		}).Find(&permissions).Error; err != nil {
+			panic(err)
+		}
+
+		role := entities.Role{
+			Name:        "Petugas Wilayah",
+			Description: "Petugas yang memproses aduan di wilayahnya",
+			Permissions: permissions,
+		}
+
+		if err := db.Create(&role).Error; err != nil {
+			panic(err)
+		}
+	}
+}
+
+// SeedAdminScope limits the regional admins to the complaints of their own regency.
+func SeedAdminScope(db *gorm.DB) {
+	if err := db.Table("admin_regencies").Take(&map[string]interface{}{}).Error; errors.Is(err, gorm.ErrRecordNotFound) {
+		var role entities.Role
+		if err := db.Where("name = ?", "Petugas Wilayah").First(&role).Error; err != nil {
+			return
+		}
+
+		scopes := map[string]string{
+			"admin_pandeglang@gmail.com": "3601",
+			"admin_lebak@gmail.com":      "3602",
+			"admin_serang@gmail.com":     "3604",
+		}
+
+		for email, regencyID := range scopes {
+			var admin entities.Admin
+			if err := db.Where("email = ?", email).First(&admin).Error; err != nil {
+				continue
+			}
+
+			var regency entities.Regency
+			if err := db.Where("id = ?", regencyID).First(&regency).Error; err != nil {
+				continue
+			}
+
+			if err := db.Model(&admin).Update("role_id", role.ID).Error; err != nil {
+				panic(err)
+			}
+
+			if err := db.Model(&admin).Association("Regencies").Append(&regency); err != nil {
+				panic(err)
+			}
+		}
+	}
+}
+
+// SeedLegacyAdminRole gives the admins that existed before roles were introduced a role
+// with the permissions every admin had back then and no scope. It only runs once, later
+// admins without a role have no permissions until a role is assigned.
+func SeedLegacyAdminRole(db *gorm.DB) {
+	if err := db.Where("name = ?", "Admin").First(&entities.Role{}).Error; errors.Is(err, gorm.ErrRecordNotFound) {
+		var permissions []entities.Permission
+		if err := db.Where("name IN ?", []string{
+			constants.PermissionAdminRead,
+			constants.PermissionUserRead,
+			constants.PermissionComplaintProcess,
+			constants.PermissionComplaintAssign,
+			constants.PermissionComplaintImport,
+			constants.PermissionComplaintExport,
+			constants.PermissionCategoryManage,
+			constants.PermissionNewsManage,
+			constants.PermissionScheduleManage,
+			constants.PermissionDashboardRead,
+		}).Find(&permissions).Error; err != nil {
+			panic(err)
+		}
+
+		role := entities.Role{
+			Name:        "Admin",
+			Description: "Hak akses admin sebelum peran diperkenalkan",
+			Permissions: permissions,
+		}
+
+		if err := db.Create(&role).Error; err != nil {
+			panic(err)
+		}
+
+		if err := db.Model(&entities.Admin{}).Where("role_id IS NULL AND is_super_admin = ?", false).Update("role_id", role.ID).Error; err != nil {
+			panic(err)
+		}
+	}
+}
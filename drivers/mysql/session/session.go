@@ -0,0 +1,114 @@
+package session
+
+import (
+	"e-complaint-api/constants"
+	"e-complaint-api/entities"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type SessionRepo struct {
+	DB *gorm.DB
+}
+
+func NewSessionRepo(db *gorm.DB) *SessionRepo {
+	return &SessionRepo{DB: db}
+}
+
+func (r *SessionRepo) Create(session *entities.Session) error {
+	if err := r.DB.Create(session).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *SessionRepo) GetByTokenHash(tokenHash string) (entities.Session, error) {
+	var session entities.Session
+	if err := r.DB.Where("token_hash = ?", tokenHash).First(&session).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entities.Session{}, constants.ErrInvalidRefreshToken
+		}
+		return entities.Session{}, err
+	}
+
+	return session, nil
+}
+
+func (r *SessionRepo) GetByID(id string) (entities.Session, error) {
+	var session entities.Session
+	if err := r.DB.Where("id = ?", id).First(&session).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entities.Session{}, constants.ErrSessionRevoked
+		}
+		return entities.Session{}, err
+	}
+
+	return session, nil
+}
+
+// Rotate revokes the old session and creates its replacement in one transaction. The
+// revoke only succeeds once, so two concurrent refreshes with the same token cannot
+// both get a new session.
+func (r *SessionRepo) Rotate(oldSessionID string, newSession *entities.Session) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entities.Session{}).Where("id = ? AND revoked_at IS NULL", oldSessionID).Update("revoked_at", time.Now())
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return constants.ErrInvalidRefreshToken
+		}
+
+		return tx.Create(newSession).Error
+	})
+}
+
+func (r *SessionRepo) Revoke(id string) error {
+	if err := r.DB.Model(&entities.Session{}).Where("id = ? AND revoked_at IS NULL", id).Update("revoked_at", time.Now()).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetSubject loads the admin or user a session belongs to. Deleted accounts are not
+// found.
+func (r *SessionRepo) GetSubject(subjectID int, subjectType string) (entities.SessionSubject, error) {
+	if subjectType == "admin" {
+		var admin entities.Admin
+		if err := r.DB.Where("id = ?", subjectID).First(&admin).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return entities.SessionSubject{}, constants.ErrInvalidRefreshToken
+			}
+			return entities.SessionSubject{}, err
+		}
+
+		role := "admin"
+		if admin.IsSuperAdmin {
+			role = "super_admin"
+		}
+		return entities.SessionSubject{Role: role, Name: admin.Name, Email: admin.Email}, nil
+	}
+
+	var user entities.User
+	if err := r.DB.Where("id = ?", subjectID).First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entities.SessionSubject{}, constants.ErrInvalidRefreshToken
+		}
+		return entities.SessionSubject{}, err
+	}
+
+	return entities.SessionSubject{Role: "user", Name: user.Name, Email: user.Email}, nil
+}
+
+func (r *SessionRepo) RevokeAll(subjectID int, subjectType string) error {
+	if err := r.DB.Model(&entities.Session{}).Where("subject_id = ? AND subject_type = ? AND revoked_at IS NULL", subjectID, subjectType).Update("revoked_at", time.Now()).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
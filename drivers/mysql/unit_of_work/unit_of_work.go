@@ -0,0 +1,32 @@
+package unit_of_work
+
+import (
+	"e-complaint-api/drivers/mysql/complaint"
+	"e-complaint-api/drivers/mysql/complaint_assignment"
+	"e-complaint-api/drivers/mysql/complaint_duplicate"
+	"e-complaint-api/drivers/mysql/complaint_process"
+	"e-complaint-api/drivers/mysql/complaint_sla"
+	"e-complaint-api/entities"
+
+	"gorm.io/gorm"
+)
+
+type UnitOfWork struct {
+	DB *gorm.DB
+}
+
+func NewUnitOfWork(db *gorm.DB) *UnitOfWork {
+	return &UnitOfWork{DB: db}
+}
+
+func (uow *UnitOfWork) Do(fn func(repositories entities.UnitOfWorkRepositories) error) error {
+	return uow.DB.Transaction(func(tx *gorm.DB) error {
+		return fn(entities.UnitOfWorkRepositories{
+			Complaint:        complaint.NewComplaintRepo(tx),
+			ComplaintProcess: complaint_process.NewComplaintProcessRepo(tx),
+			ComplaintSLA:     complaint_sla.NewComplaintSLARepo(tx),
+			Assignment:       complaint_assignment.NewComplaintAssignmentRepo(tx),
+			Duplicate:        complaint_duplicate.NewComplaintDuplicateRepo(tx),
+		})
+	})
+}
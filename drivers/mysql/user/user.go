@@ -74,6 +74,19 @@ func (r *UserRepo) GetUserByID(id int) (*entities.User, error) {
 	return &user, nil
 }
 
+func (r *UserRepo) GetUserByEmail(email string) (*entities.User, error) {
+	var user entities.User
+
+	if err := r.DB.Where("email = ?", email).First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, constants.ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	return &user, nil
+}
+
 func (r *UserRepo) UpdateUser(id int, user *entities.User) error {
 	if err := r.DB.Model(&entities.User{}).Where("id = ?", id).Updates(&user).Error; err != nil {
 		return err
@@ -0,0 +1,122 @@
+package webhook
+
+import (
+	"e-complaint-api/constants"
+	"e-complaint-api/entities"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+type WebhookRepo struct {
+	DB *gorm.DB
+}
+
+func NewWebhookRepo(db *gorm.DB) *WebhookRepo {
+	return &WebhookRepo{DB: db}
+}
+
+func (r *WebhookRepo) Create(webhook *entities.Webhook) error {
+	if err := r.DB.Create(webhook).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *WebhookRepo) GetAll() ([]entities.Webhook, error) {
+	var webhooks []entities.Webhook
+	if err := r.DB.Order("id asc").Find(&webhooks).Error; err != nil {
+		return nil, err
+	}
+
+	return webhooks, nil
+}
+
+func (r *WebhookRepo) GetByID(id int) (entities.Webhook, error) {
+	var webhook entities.Webhook
+	if err := r.DB.First(&webhook, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entities.Webhook{}, constants.ErrWebhookNotFound
+		}
+		return entities.Webhook{}, err
+	}
+
+	return webhook, nil
+}
+
+// GetByEvent returns the active webhooks subscribed to event.
+func (r *WebhookRepo) GetByEvent(event string) ([]entities.Webhook, error) {
+	var webhooks []entities.Webhook
+	if err := r.DB.Where("active = ? AND FIND_IN_SET(?, events) > 0", true, event).Order("id asc").Find(&webhooks).Error; err != nil {
+		return nil, err
+	}
+
+	return webhooks, nil
+}
+
+func (r *WebhookRepo) Update(webhook *entities.Webhook) error {
+	if err := r.DB.Save(webhook).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *WebhookRepo) Delete(id int) error {
+	result := r.DB.Delete(&entities.Webhook{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return constants.ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+func (r *WebhookRepo) CreateDelivery(delivery *entities.WebhookDelivery) error {
+	if err := r.DB.Create(delivery).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetDeliveryByID returns a delivery of a webhook with the webhook preloaded.
+func (r *WebhookRepo) GetDeliveryByID(webhookID int, id int) (entities.WebhookDelivery, error) {
+	var delivery entities.WebhookDelivery
+	if err := r.DB.Preload("Webhook").Where("webhook_id = ?", webhookID).First(&delivery, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entities.WebhookDelivery{}, constants.ErrWebhookDeliveryNotFound
+		}
+		return entities.WebhookDelivery{}, err
+	}
+
+	return delivery, nil
+}
+
+// GetDeliveriesAfter returns the deliveries of a webhook older than the one with
+// afterID, newest first. An afterID of 0 starts at the newest delivery.
+func (r *WebhookRepo) GetDeliveriesAfter(webhookID int, limit int, afterID int) ([]entities.WebhookDelivery, error) {
+	var deliveries []entities.WebhookDelivery
+
+	query := r.DB.Where("webhook_id = ?", webhookID)
+	if afterID != 0 {
+		query = query.Where("id < ?", afterID)
+	}
+
+	if err := query.Order("id desc").Limit(limit).Find(&deliveries).Error; err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func (r *WebhookRepo) UpdateDelivery(delivery *entities.WebhookDelivery) error {
+	if err := r.DB.Omit("Webhook").Save(delivery).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
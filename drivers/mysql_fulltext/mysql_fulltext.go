@@ -0,0 +1,123 @@
+package mysql_fulltext
+
+import (
+	"e-complaint-api/constants"
+	"e-complaint-api/entities"
+	"e-complaint-api/search"
+	"e-complaint-api/similarity"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+type MySQLFulltext struct {
+	DB          *gorm.DB
+	collections map[string]search.Collection
+}
+
+func NewMySQLFulltext(db *gorm.DB, collections []search.Collection) *MySQLFulltext {
+	fulltext := &MySQLFulltext{DB: db, collections: make(map[string]search.Collection)}
+	for _, collection := range collections {
+		fulltext.collections[collection.Name] = collection
+	}
+
+	return fulltext
+}
+
+// Search ranks the rows with MATCH ... AGAINST in natural language mode. MySQL neither
+// stems nor knows Indonesian stopwords, so the query is rewritten to its words without
+// stopwords together with their roots, e.g. "jalan berlubang" to "jalan berlubang lubang".
+func (m *MySQLFulltext) Search(collectionName string, query string, limit int) ([]entities.SearchHit, error) {
+	collection, ok := m.collections[collectionName]
+	if !ok {
+		return nil, constants.ErrInvalidSearchCollection
+	}
+
+	against := expand(query)
+	if against == "" {
+		return []entities.SearchHit{}, nil
+	}
+
+	match := "MATCH(" + strings.Join(collection.Fields, ", ") + ") AGAINST(? IN NATURAL LANGUAGE MODE)"
+	columns := append([]string{"id"}, collection.Fields...)
+	if collection.Reference != "" {
+		columns = append(columns, collection.Reference)
+	}
+
+	var rows []map[string]interface{}
+	err := m.DB.Table(collection.Table).
+		Select(strings.Join(columns, ", ")+", "+match+" AS score", against).
+		Where("deleted_at IS NULL").
+		Where(match+" > 0", against).
+		Order("score DESC").
+		Limit(limit).
+		Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	hits := make([]entities.SearchHit, 0, len(rows))
+	for _, row := range rows {
+		hit := entities.SearchHit{
+			ID:         toString(row["id"]),
+			Score:      toFloat(row["score"]),
+			Highlights: map[string]string{},
+		}
+		if collection.Reference != "" {
+			hit.Reference = toString(row[collection.Reference])
+		}
+		for _, field := range collection.Fields {
+			if highlight := search.Highlight(toString(row[field]), query); highlight != "" {
+				hit.Highlights[field] = highlight
+			}
+		}
+		hits = append(hits, hit)
+	}
+
+	return hits, nil
+}
+
+func expand(query string) string {
+	seen := map[string]bool{}
+	var words []string
+	for _, word := range similarity.Words(query) {
+		if search.IsStopword(word) {
+			continue
+		}
+		for _, term := range []string{word, search.Stem(word)} {
+			if !seen[term] {
+				seen[term] = true
+				words = append(words, term)
+			}
+		}
+	}
+
+	return strings.Join(words, " ")
+}
+
+// toString and toFloat convert the values the MySQL driver scans into a map, which
+// are []byte for text columns and decimals.
+func toString(value interface{}) string {
+	switch value := value.(type) {
+	case nil:
+		return ""
+	case []byte:
+		return string(value)
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+func toFloat(value interface{}) float64 {
+	switch value := value.(type) {
+	case float64:
+		return value
+	case float32:
+		return float64(value)
+	default:
+		score, _ := strconv.ParseFloat(toString(value), 64)
+		return score
+	}
+}
@@ -0,0 +1,187 @@
+package s3_storage
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"e-complaint-api/constants"
+	"e-complaint-api/entities"
+	"e-complaint-api/utils"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// unsignedPayload lets files be streamed to the bucket without hashing them first.
+const unsignedPayload = "UNSIGNED-PAYLOAD"
+
+// S3Storage talks to any S3 compatible object storage (AWS S3, MinIO, R2, ...) with
+// path-style URLs and signature version 4.
+type S3Storage struct {
+	Endpoint   string
+	Region     string
+	BucketName string
+	AccessKey  string
+	SecretKey  string
+	FolderPath string
+	Client     *http.Client
+}
+
+func NewS3Storage(endpoint string, region string, bucketName string, accessKey string, secretKey string, folderPath string) *S3Storage {
+	return &S3Storage{
+		Endpoint:   strings.TrimSuffix(endpoint, "/"),
+		Region:     region,
+		BucketName: bucketName,
+		AccessKey:  accessKey,
+		SecretKey:  secretKey,
+		FolderPath: folderPath,
+		Client:     &http.Client{Timeout: time.Minute},
+	}
+}
+
+func (s *S3Storage) Upload(files []entities.UploadFile) ([]string, error) {
+	var filePaths []string
+	for _, file := range files {
+		dstPath := s.FolderPath + utils.HashFileName(file.Name)
+
+		err := s.upload(file, dstPath)
+		if err != nil {
+			return nil, err
+		}
+
+		filePaths = append(filePaths, dstPath)
+	}
+
+	return filePaths, nil
+}
+
+func (s *S3Storage) upload(file entities.UploadFile, dstPath string) error {
+	req, err := http.NewRequest(http.MethodPut, s.objectURL(dstPath), bytes.NewReader(file.Content))
+	if err != nil {
+		return constants.ErrFailedToUploadObject
+	}
+	if file.ContentType != "" {
+		req.Header.Set("Content-Type", file.ContentType)
+	}
+
+	err = s.do(req)
+	if err != nil {
+		return constants.ErrFailedToUploadObject
+	}
+
+	return nil
+}
+
+func (s *S3Storage) Delete(filePaths []string) error {
+	for _, path := range filePaths {
+		req, err := http.NewRequest(http.MethodDelete, s.objectURL(path), nil)
+		if err != nil {
+			return constants.ErrFailedToDeleteObject
+		}
+
+		// Deleting an object that does not exist also succeeds
+		err = s.do(req)
+		if err != nil {
+			return constants.ErrFailedToDeleteObject
+		}
+	}
+
+	return nil
+}
+
+func (s *S3Storage) Open(filePath string) (io.ReadCloser, error) {
+	req, err := http.NewRequest(http.MethodGet, s.objectURL(filePath), nil)
+	if err != nil {
+		return nil, err
+	}
+	s.sign(req, time.Now())
+
+	res, err := s.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode == http.StatusNotFound {
+		res.Body.Close()
+		return nil, constants.ErrFileNotFound
+	}
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		res.Body.Close()
+		return nil, fmt.Errorf("s3 responded with status %d", res.StatusCode)
+	}
+
+	// The caller closes the body once the file has been read
+	return res.Body, nil
+}
+
+func (s *S3Storage) objectURL(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+
+	return s.Endpoint + "/" + url.PathEscape(s.BucketName) + "/" + strings.Join(segments, "/")
+}
+
+func (s *S3Storage) do(req *http.Request) error {
+	s.sign(req, time.Now())
+
+	res, err := s.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("s3 responded with status %d", res.StatusCode)
+	}
+
+	return nil
+}
+
+// sign adds the headers of an AWS signature version 4 to the request.
+func (s *S3Storage) sign(req *http.Request, now time.Time) {
+	amzDate := now.UTC().Format("20060102T150405Z")
+	date := amzDate[:8]
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", unsignedPayload)
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalHeaders := "host:" + req.URL.Host + "\n" +
+		"x-amz-content-sha256:" + unsignedPayload + "\n" +
+		"x-amz-date:" + amzDate + "\n"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders,
+		signedHeaders,
+		unsignedPayload,
+	}, "\n")
+
+	scope := date + "/" + s.Region + "/s3/aws4_request"
+	hashedRequest := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(hashedRequest[:])
+
+	key := hmacSHA256([]byte("AWS4"+s.SecretKey), date)
+	key = hmacSHA256(key, s.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.AccessKey, scope, signedHeaders, signature,
+	))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,98 @@
+package s3_storage
+
+import (
+	"e-complaint-api/constants"
+	"e-complaint-api/entities"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeS3 keeps objects in memory and rejects requests that are not signed.
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=access/") || r.Header.Get("X-Amz-Date") == "" {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodPut:
+		body, _ := io.ReadAll(r.Body)
+		f.objects[r.URL.Path] = body
+	case http.MethodGet:
+		object, ok := f.objects[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write(object)
+	case http.MethodDelete:
+		delete(f.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func TestSign(t *testing.T) {
+	storage := NewS3Storage("http://localhost:9000", "us-east-1", "evidence", "AKIDEXAMPLE", "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY", "bukti_unggah/")
+	req, _ := http.NewRequest(http.MethodGet, storage.objectURL("bukti_unggah/a b.jpg"), nil)
+
+	storage.sign(req, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+
+	assert.Equal(t, "/evidence/bukti_unggah/a%20b.jpg", req.URL.EscapedPath())
+	assert.Equal(t, "20240102T030405Z", req.Header.Get("X-Amz-Date"))
+	assert.Equal(t, unsignedPayload, req.Header.Get("X-Amz-Content-Sha256"))
+	assert.Equal(t, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20240102/us-east-1/s3/aws4_request, "+
+		"SignedHeaders=host;x-amz-content-sha256;x-amz-date, "+
+		"Signature=70d8eaec303719a0ad4d6d82aa3730b86fcd289d9a55c422b60b125394c38f1a", req.Header.Get("Authorization"))
+}
+
+func TestRoundTrip(t *testing.T) {
+	server := httptest.NewServer(&fakeS3{objects: map[string][]byte{}})
+	defer server.Close()
+	storage := NewS3Storage(server.URL+"/", "us-east-1", "evidence", "access", "secret", "bukti_unggah/")
+
+	paths, err := storage.Upload([]entities.UploadFile{{Name: "banjir.jpeg", Content: []byte("image"), ContentType: "image/jpeg"}})
+	assert.NoError(t, err)
+	assert.Len(t, paths, 1)
+	assert.True(t, strings.HasPrefix(paths[0], "bukti_unggah/"))
+	assert.True(t, strings.HasSuffix(paths[0], ".jpeg"))
+
+	file, err := storage.Open(paths[0])
+	assert.NoError(t, err)
+	content, _ := io.ReadAll(file)
+	file.Close()
+	assert.Equal(t, "image", string(content))
+
+	assert.NoError(t, storage.Delete(paths))
+	_, err = storage.Open(paths[0])
+	assert.ErrorIs(t, err, constants.ErrFileNotFound)
+
+	// Deleting a missing object succeeds like it does on S3
+	assert.NoError(t, storage.Delete(paths))
+}
+
+func TestUnsignedRequest(t *testing.T) {
+	server := httptest.NewServer(&fakeS3{objects: map[string][]byte{}})
+	defer server.Close()
+	storage := NewS3Storage(server.URL, "us-east-1", "evidence", "other", "secret", "bukti_unggah/")
+
+	_, err := storage.Upload([]entities.UploadFile{{Name: "banjir.jpeg", Content: []byte("image")}})
+	assert.ErrorIs(t, err, constants.ErrFailedToUploadObject)
+
+	_, err = storage.Open("bukti_unggah/banjir.jpeg")
+	assert.Error(t, err)
+}
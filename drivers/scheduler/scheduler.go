@@ -0,0 +1,20 @@
+package scheduler
+
+import (
+	"log"
+	"time"
+)
+
+// Every runs job in the background once per interval for as long as the process lives.
+func Every(interval time.Duration, name string, job func() error) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			if err := job(); err != nil {
+				log.Printf("scheduler: %s failed: %v", name, err)
+			}
+		}
+	}()
+}
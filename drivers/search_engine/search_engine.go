@@ -0,0 +1,36 @@
+package search_engine
+
+import (
+	"e-complaint-api/constants"
+	"e-complaint-api/drivers/memory_index"
+	"e-complaint-api/drivers/mysql_fulltext"
+	"e-complaint-api/entities"
+	"e-complaint-api/search"
+
+	"gorm.io/gorm"
+)
+
+type Config struct {
+	DRIVER string
+}
+
+// Collections are the tables that can be searched.
+var Collections = []search.Collection{
+	{Name: constants.SearchComplaints, Table: "complaints", Fields: []string{"description", "address"}},
+	{Name: constants.SearchNews, Table: "news", Fields: []string{"title", "content"}},
+	{Name: constants.SearchDiscussions, Table: "discussions", Fields: []string{"comment"}, Reference: "complaint_id"},
+}
+
+// NewSearchEngine returns the search engine chosen by DRIVER, which is one of "mysql",
+// that uses the FULLTEXT indexes of the database, or "memory", that keeps its own index
+// in memory.
+func NewSearchEngine(config Config, db *gorm.DB) entities.SearchEngineInterface {
+	switch config.DRIVER {
+	case "mysql":
+		return mysql_fulltext.NewMySQLFulltext(db, Collections)
+	case "memory":
+		return memory_index.NewMemoryIndex(db, Collections)
+	default:
+		panic(constants.ErrInvalidSearchDriver)
+	}
+}
@@ -0,0 +1,84 @@
+package webhook
+
+import (
+	"bytes"
+	"e-complaint-api/constants"
+	"e-complaint-api/entities"
+	"e-complaint-api/utils"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+type HTTPSender struct {
+	client *http.Client
+}
+
+// NewHTTPSender returns a sender that gives up on endpoints that take longer than
+// timeout to answer. It only connects to public addresses and does not follow
+// redirects, so a webhook cannot be used to reach, and read, the internal network.
+func NewHTTPSender(timeout time.Duration) *HTTPSender {
+	return newHTTPSender(timeout, utils.IsPublicIP)
+}
+
+// newHTTPSender returns a sender that only connects to the addresses allowed by allow.
+// The check runs on the resolved address of every connection, so a host name that
+// resolves to an internal address is refused as well.
+func newHTTPSender(timeout time.Duration, allow func(net.IP) bool) *HTTPSender {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network string, address string, conn syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if !allow(net.ParseIP(host)) {
+				return constants.ErrWebhookAddressNotAllowed
+			}
+			return nil
+		},
+	}
+
+	return &HTTPSender{
+		client: &http.Client{
+			Timeout: timeout,
+			Transport: &http.Transport{
+				DialContext:         dialer.DialContext,
+				TLSHandshakeTimeout: timeout,
+				MaxIdleConns:        10,
+				IdleConnTimeout:     90 * time.Second,
+			},
+			CheckRedirect: func(request *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// Send posts body to url. Only the first bytes of the response are read, so a large
+// response does not end up in the delivery log. Redirects are returned as they are,
+// so they fail the delivery.
+func (s *HTTPSender) Send(url string, headers map[string]string, body []byte) (entities.WebhookResponse, error) {
+	request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return entities.WebhookResponse{}, err
+	}
+	for key, value := range headers {
+		request.Header.Set(key, value)
+	}
+
+	response, err := s.client.Do(request)
+	if err != nil {
+		return entities.WebhookResponse{}, err
+	}
+	defer response.Body.Close()
+
+	responseBody, err := io.ReadAll(io.LimitReader(response.Body, constants.WebhookResponseLimit))
+	if err != nil {
+		return entities.WebhookResponse{}, err
+	}
+
+	return entities.WebhookResponse{StatusCode: response.StatusCode, Body: string(responseBody)}, nil
+}
@@ -0,0 +1,85 @@
+package webhook
+
+import (
+	"e-complaint-api/constants"
+	"e-complaint-api/utils"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// allowAll lets the tests reach their local servers.
+func allowAll(net.IP) bool {
+	return true
+}
+
+func TestSend(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "complaint.created", r.Header.Get("X-Webhook-Event"))
+			assert.Equal(t, `{"id":1}`, string(body))
+			w.Write([]byte(strings.Repeat("a", constants.WebhookResponseLimit+10)))
+		}))
+		defer server.Close()
+
+		response, err := newHTTPSender(time.Second, allowAll).Send(server.URL, map[string]string{"X-Webhook-Event": "complaint.created"}, []byte(`{"id":1}`))
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Len(t, response.Body, constants.WebhookResponseLimit)
+	})
+
+	t.Run("success redirect not followed", func(t *testing.T) {
+		followed := false
+		target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			followed = true
+		}))
+		defer target.Close()
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, target.URL, http.StatusTemporaryRedirect)
+		}))
+		defer server.Close()
+
+		response, err := newHTTPSender(time.Second, allowAll).Send(server.URL, nil, []byte(`{}`))
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusTemporaryRedirect, response.StatusCode)
+		assert.False(t, followed)
+	})
+
+	t.Run("failed internal address", func(t *testing.T) {
+		reached := false
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			reached = true
+		}))
+		defer server.Close()
+
+		for _, url := range []string{server.URL, strings.Replace(server.URL, "127.0.0.1", "localhost", 1)} {
+			_, err := NewHTTPSender(time.Second).Send(url, nil, []byte(`{}`))
+			assert.ErrorIs(t, err, constants.ErrWebhookAddressNotAllowed, url)
+		}
+		assert.False(t, reached)
+	})
+
+	t.Run("failed invalid url", func(t *testing.T) {
+		_, err := NewHTTPSender(time.Second).Send("://", nil, nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestIsPublicIP(t *testing.T) {
+	for _, ip := range []string{"93.184.216.34", "2606:4700::1111"} {
+		assert.True(t, utils.IsPublicIP(net.ParseIP(ip)), ip)
+	}
+
+	for _, ip := range []string{"127.0.0.1", "10.1.2.3", "172.16.0.1", "192.168.0.1", "169.254.169.254", "100.64.0.1", "0.0.0.0", "224.0.0.1", "::1", "fe80::1", "fc00::1", "::ffff:127.0.0.1"} {
+		assert.False(t, utils.IsPublicIP(net.ParseIP(ip)), ip)
+	}
+	assert.False(t, utils.IsPublicIP(nil))
+}
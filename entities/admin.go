@@ -18,6 +18,11 @@ type Admin struct {
 	UpdatedAt       time.Time      `gorm:"autoUpdateTime"`
 	DeletedAt       gorm.DeletedAt `gorm:"index"`
 	Token           string         `gorm:"-"`
+	RefreshToken    string         `gorm:"-"`
+	RoleID          *int           `gorm:"default:null"`
+	Role            *Role          `gorm:"foreignKey:RoleID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	Regencies       []Regency      `gorm:"many2many:admin_regencies;"`
+	Categories      []Category     `gorm:"many2many:admin_categories;"`
 	Discussion      []Discussion   `gorm:"foreignKey:AdminID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
 	NewsComment     []NewsComment  `gorm:"foreignKey:AdminID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
 }
@@ -0,0 +1,20 @@
+package entities
+
+import (
+	"io"
+	"time"
+)
+
+// AttachmentURL is a short-lived link that downloads one stored file without any
+// further authentication.
+type AttachmentURL struct {
+	URL       string
+	ExpiresAt time.Time
+}
+
+type AttachmentUseCaseInterface interface {
+	GetComplaintFileURL(complaintID string, fileID int, thumbnail bool, accountID int, role string) (AttachmentURL, error)
+	GetEvidenceURL(evidenceID int64, accountID int, role string) (AttachmentURL, error)
+	Open(filePath string, expires int64, signature string) (io.ReadCloser, error)
+	OpenPublic(filePath string) (io.ReadCloser, error)
+}
@@ -2,14 +2,36 @@ package entities
 
 import (
 	"time"
+
+	"gorm.io/gorm"
 )
 
 // Room represents a chat room where users and admins can communicate
 type Room struct {
-	ID        int       `gorm:"primaryKey"`
-	Name      string    `gorm:"not null"`
-	CreatedAt time.Time `gorm:"autoCreateTime"`
-	Messages  []Message `gorm:"foreignKey:RoomID"`
+	ID          int          `gorm:"primaryKey"`
+	Name        string       `gorm:"not null"`
+	ComplaintID *string      `gorm:"size:15;index"`
+	Status      string       `gorm:"type:ENUM('open', 'closed', 'archived');not null;default:'open'"`
+	CreatedAt   time.Time    `gorm:"autoCreateTime"`
+	Messages    []Message    `gorm:"foreignKey:RoomID"`
+	Members     []RoomMember `gorm:"foreignKey:RoomID"`
+}
+
+// RoomMember is a user or an admin who can read and send the messages of a room
+type RoomMember struct {
+	ID                int       `gorm:"primaryKey"`
+	RoomID            int       `gorm:"not null;uniqueIndex:idx_room_member"`
+	MemberID          int       `gorm:"not null;uniqueIndex:idx_room_member"`
+	MemberType        string    `gorm:"type:ENUM('user', 'admin');not null;uniqueIndex:idx_room_member"`
+	LastReadMessageID int       `gorm:"not null;default:0"`
+	CreatedAt         time.Time `gorm:"autoCreateTime"`
+}
+
+// RoomSummary is a room in the room list of a member
+type RoomSummary struct {
+	Room
+	LastMessage *Message
+	UnreadCount int64
 }
 
 // Message represents a chat message within a room
@@ -20,6 +42,30 @@ type Message struct {
 	SenderType string    `gorm:"type:ENUM('user', 'admin');not null"`
 	Message    string    `gorm:"type:text;not null"`
 	CreatedAt  time.Time `gorm:"autoCreateTime"`
+	EditedAt   *time.Time
+	DeletedAt  gorm.DeletedAt `gorm:"index"`
+}
+
+// ChatTyping is pushed to the members of a room while a member is typing
+type ChatTyping struct {
+	RoomID     int
+	SenderID   int
+	SenderType string
+}
+
+// ChatMessageDeleted is pushed to the members of a room when a message is deleted
+type ChatMessageDeleted struct {
+	RoomID    int
+	MessageID int
+}
+
+// ChatReadReceipt is pushed to the members of a room when a member read its messages
+// up to MessageID
+type ChatReadReceipt struct {
+	RoomID     int
+	ReaderID   int
+	ReaderType string
+	MessageID  int
 }
 
 // ChatRepositoryInterface defines methods for interacting with chat data
@@ -28,21 +74,42 @@ type ChatRepositoryInterface interface {
 	GetChatsByUserID(userID int) ([]Message, error)
 	GetChatsByAdminID(adminID int) ([]Message, error)
 	GetChatsBetweenUserAndAdmin(userID, adminID int) ([]Message, error)
-	GetAllRooms() ([]Room, error)
-	CreateRoom(name string) (*Room, error)
+	CreateRoom(room *Room, members []RoomMember) error
+	GetRoomsByMember(memberID int, memberType string, statuses []string) ([]Room, error)
+	CountUnreadByRoom(memberID int, memberType string, roomIDs []int) (map[int]int64, error)
+	GetLastMessages(roomIDs []int) ([]Message, error)
+	UpdateRoomStatus(room *Room, status string) error
 	GetMessagesByRoomID(roomID int) ([]Message, error)
+	GetMessagesByRoomIDAfter(roomID int, limit int, afterID int) ([]Message, error)
 	GetRoomByID(ID int) (*Room, error)
+	GetMessageByID(roomID int, messageID int) (*Message, error)
+	UpdateMessage(message *Message) error
+	DeleteMessage(message *Message) error
+	GetMember(roomID int, memberID int, memberType string) (*RoomMember, error)
+	CreateMember(member *RoomMember) error
+	UpdateLastRead(member *RoomMember, messageID int) error
+	MemberExists(memberID int, memberType string) (bool, error)
+	GetComplaintUserID(complaintID string) (int, error)
 }
 
 // ChatUseCaseInterface defines the business logic methods for chat interactions
 type ChatUseCaseInterface interface {
 	SendMessage(chat *Message) error
+	Typing(roomID int, principal Principal) error
+	MarkAsRead(roomID int, messageID int, principal Principal) error
+	Subscribe(roomID int, principal Principal) (<-chan Event, func(), error)
 	GetUserChats(userID int) ([]Message, error)
 	GetAdminChats(adminID int) ([]Message, error)
 	GetConversation(userID, adminID int) ([]Message, error)
 	GetAllChatsByUser(userID int) ([]Message, error)
-	GetAllRooms() ([]Room, error)
-	CreateRoom(name string) (*Room, error)
-	GetMessagesByRoomID(roomID int) ([]Message, error)
+	GetRooms(principal Principal, status string) ([]RoomSummary, error)
+	CreateRoom(name string, complaintID *string, memberID *int, principal Principal) (*Room, error)
+	JoinRoom(roomID int, principal Principal) (*Room, error)
+	GetMessagesByRoomID(roomID int, principal Principal) ([]Message, error)
+	GetMessagesByRoomIDCursor(roomID int, principal Principal, limit int, cursor string) ([]Message, string, error)
 	GetRoomByID(ID int) (*Room, error)
+	GetRoom(roomID int, principal Principal) (*Room, error)
+	UpdateRoomStatus(roomID int, status string, principal Principal) (*Room, error)
+	UpdateMessage(roomID int, messageID int, text string, principal Principal) (*Message, error)
+	DeleteMessage(roomID int, messageID int, principal Principal) error
 }
\ No newline at end of file
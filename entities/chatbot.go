@@ -1,6 +1,7 @@
 package entities
 
 import (
+	"context"
 	"time"
 
 	"gorm.io/gorm"
@@ -23,12 +24,8 @@ type ChatbotRepositoryInterface interface {
 	ClearHistory(userID int) error
 }
 
-type ChatbotOpenAIAPIInterface interface {
-	GetChatCompletion(prompt []string, userPrompt string) (string, error)
-}
-
 type ChatbotUseCaseInterface interface {
-	GetChatCompletion(chatbot *Chatbot) error
+	GetChatCompletion(ctx context.Context, chatbot *Chatbot) error
 	GetHistory(userID int) ([]Chatbot, error)
 	ClearHistory(userID int) error
 }
@@ -1,7 +1,6 @@
 package entities
 
 import (
-	"mime/multipart"
 	"time"
 
 	"gorm.io/gorm"
@@ -12,12 +11,20 @@ type Complaint struct {
 	UserID        int                `gorm:"not null"`
 	CategoryID    int                `gorm:"not null"`
 	RegencyID     string             `gorm:"not null;type:varchar;size:4;"`
-	Address       string             `gorm:"not null"`
-	Description   string             `gorm:"not null"`
-	Status        string             `gorm:"type:enum('Pending', 'Verifikasi', 'On Progress', 'Selesai', 'Ditolak');default:'Pending'"`
+	Address       string             `gorm:"not null;index:idx_complaints_fulltext,class:FULLTEXT,priority:2"`
+	Latitude      *float64           `gorm:"type:decimal(10,7);index:idx_complaints_location"`
+	Longitude     *float64           `gorm:"type:decimal(10,7);index:idx_complaints_location"`
+	Description   string             `gorm:"not null;index:idx_complaints_fulltext,class:FULLTEXT,priority:1"`
+	Status        string             `gorm:"type:varchar(20);default:'Pending'"`
 	Type          string             `gorm:"type:enum('public', 'private')"`
 	Date          time.Time          `gorm:"type:date"`
 	TotalLikes    int                `gorm:"default:0"`
+	DueAt         *time.Time         `gorm:"index"`
+	EscalatedAt   *time.Time         `gorm:"default:null"`
+	SLABreached   bool               `gorm:"column:sla_breached;default:false"`
+	AssigneeID    *int               `gorm:"index;default:null"`
+	MergedIntoID  *string            `gorm:"type:varchar(15);index;default:null"`
+	ImportKey     *string            `gorm:"type:varchar(64);uniqueIndex;default:null"`
 	CreatedAt     time.Time          `gorm:"autoCreateTime"`
 	UpdatedAt     time.Time          `gorm:"autoUpdateTime"`
 	DeletedAt     gorm.DeletedAt     `gorm:"index"`
@@ -25,14 +32,33 @@ type Complaint struct {
 	Regency       Regency            `gorm:"foreignKey:RegencyID;references:ID"`
 	Files         []ComplaintFile    `gorm:"foreignKey:ComplaintID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
 	Category      Category           `gorm:"foreignKey:CategoryID;references:ID"`
+	Assignee      *Admin             `gorm:"foreignKey:AssigneeID;references:ID"`
 	Process       []ComplaintProcess `gorm:"foreignKey:ComplaintID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
 	Discussion    []Discussion       `gorm:"foreignKey:ComplaintID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
 	ComplaintLike []ComplaintLike    `gorm:"foreignKey:ComplaintID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
 }
 
+// CanAccessFiles reports whether an account may see the files and evidence of the
+// complaint. Those of private complaints are only available to the owner, the assigned
+// admin and super admins.
+func (c Complaint) CanAccessFiles(accountID int, role string) bool {
+	if role == "super_admin" || c.Type == "public" {
+		return true
+	}
+
+	if role == "user" {
+		return c.UserID == accountID
+	}
+
+	return c.AssigneeID != nil && *c.AssigneeID == accountID
+}
+
 type ComplaintRepositoryInterface interface {
 	GetPaginated(limit int, page int, search string, filter map[string]interface{}, sortBy string, sortType string) ([]Complaint, error)
 	GetMetaData(limit int, page int, search string, filter map[string]interface{}) (Metadata, error)
+	GetAfter(limit int, afterID string, afterValue string, search string, filter map[string]interface{}, sortBy string, sortType string) ([]Complaint, error)
+	GetAfterWithProcesses(limit int, afterID string, afterValue string, search string, filter map[string]interface{}, sortBy string, sortType string) ([]Complaint, error)
+	GetWithLocation(limit int, search string, filter map[string]interface{}) ([]Complaint, error)
 	GetByID(id string) (Complaint, error)
 	GetByUserID(userId int) ([]Complaint, error)
 	Create(complaint *Complaint) error
@@ -41,7 +67,6 @@ type ComplaintRepositoryInterface interface {
 	Update(complaint Complaint) (Complaint, error)
 	UpdateStatus(id string, status string) error
 	GetStatus(id string) (string, error)
-	Import(complaints []Complaint) error
 	IncreaseTotalLikes(id string) error
 	DecreaseTotalLikes(id string) error
 	GetComplaintIDsByUserID(userID int) ([]string, error)
@@ -50,13 +75,15 @@ type ComplaintRepositoryInterface interface {
 type ComplaintUseCaseInterface interface {
 	GetPaginated(limit int, page int, search string, filter map[string]interface{}, sortBy string, sortType string) ([]Complaint, error)
 	GetMetaData(limit int, page int, search string, filter map[string]interface{}) (Metadata, error)
+	GetByCursor(limit int, cursor string, search string, filter map[string]interface{}, sortBy string, sortType string) ([]Complaint, string, error)
+	Export(search string, filter map[string]interface{}, sortBy string, sortType string, write func([]Complaint) error) error
+	GetWithLocation(search string, filter map[string]interface{}) ([]Complaint, error)
 	GetByID(id string) (Complaint, error)
 	GetByUserID(userId int) ([]Complaint, error)
 	Create(complaint *Complaint) (Complaint, error)
 	Delete(id string, userId int, role string) error
 	Update(complaint Complaint) (Complaint, error)
 	UpdateStatus(id string, status string) error
-	Import(file *multipart.FileHeader) error
 	IncreaseTotalLikes(id string) error
 	DecreaseTotalLikes(id string) error
 	GetComplaintIDsByUserID(userID int) ([]string, error)
@@ -0,0 +1,52 @@
+package entities
+
+import "time"
+
+// ComplaintAssignment is one entry of the assignment history of a complaint.
+type ComplaintAssignment struct {
+	ID           int       `gorm:"primaryKey"`
+	ComplaintID  string    `gorm:"not null;type:varchar(15);index"`
+	AdminID      int       `gorm:"not null"`
+	AssignedByID *int      `gorm:"default:null"`
+	Note         string    `gorm:"type:text"`
+	CreatedAt    time.Time `gorm:"autoCreateTime"`
+	Admin        Admin     `gorm:"foreignKey:AdminID;references:ID"`
+	AssignedBy   *Admin    `gorm:"foreignKey:AssignedByID;references:ID"`
+}
+
+// AssignmentPool makes an admin eligible for automatic assignment of complaints in a
+// category and regency. An empty category or regency matches every complaint.
+type AssignmentPool struct {
+	ID             int        `gorm:"primaryKey"`
+	AdminID        int        `gorm:"not null"`
+	CategoryID     *int       `gorm:"default:null"`
+	RegencyID      *string    `gorm:"type:varchar(4);default:null"`
+	LastAssignedAt *time.Time `gorm:"default:null"`
+	CreatedAt      time.Time  `gorm:"autoCreateTime"`
+	Admin          Admin      `gorm:"foreignKey:AdminID;references:ID"`
+	Category       *Category  `gorm:"foreignKey:CategoryID;references:ID"`
+	Regency        *Regency   `gorm:"foreignKey:RegencyID;references:ID"`
+}
+
+type ComplaintAssignmentRepositoryInterface interface {
+	Assign(complaintAssignment *ComplaintAssignment) error
+	GetAssigneeID(complaintID string) (int, error)
+	GetHistory(complaintID string) ([]ComplaintAssignment, error)
+	GetQueue(adminID int, excludedStatuses []string) ([]Complaint, error)
+	GetNextPoolMember(categoryID int, regencyID string) (AssignmentPool, error)
+	UpdatePoolLastAssignedAt(id int, lastAssignedAt time.Time) error
+	GetPools() ([]AssignmentPool, error)
+	CreatePool(assignmentPool *AssignmentPool) error
+	DeletePool(id int) error
+}
+
+type ComplaintAssignmentUseCaseInterface interface {
+	Assign(complaintID string, adminID int, assignedByID int, role string, note string) (ComplaintAssignment, error)
+	AutoAssign(complaint Complaint) (ComplaintAssignment, error)
+	EnsureCanProcess(complaintID string, adminID int, role string) error
+	GetHistory(complaintID string) ([]ComplaintAssignment, error)
+	GetQueue(adminID int) ([]Complaint, error)
+	GetPools() ([]AssignmentPool, error)
+	CreatePool(assignmentPool *AssignmentPool) (AssignmentPool, error)
+	DeletePool(id int) error
+}
@@ -0,0 +1,24 @@
+package entities
+
+import "time"
+
+// SimilarComplaint is an open complaint that likely reports the same problem as
+// another complaint.
+type SimilarComplaint struct {
+	Complaint Complaint
+	// Score ranges from 0 to 1, a higher score is a more likely duplicate
+	Score float64
+	// Distance in kilometers, only set when both complaints have a location
+	Distance *float64
+}
+
+type ComplaintDuplicateRepositoryInterface interface {
+	GetCandidates(complaint Complaint, excludedStatuses []string, since time.Time) ([]Complaint, error)
+	Merge(masterID string, duplicateIDs []string) error
+}
+
+type ComplaintDuplicateUseCaseInterface interface {
+	GetSimilar(complaint Complaint, viewerID int, role string) ([]SimilarComplaint, error)
+	GetSimilarByID(complaintID string) ([]SimilarComplaint, error)
+	Merge(masterID string, duplicateIDs []string, adminID int) (Complaint, []Complaint, error)
+}
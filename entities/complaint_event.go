@@ -0,0 +1,29 @@
+package entities
+
+import "time"
+
+// ComplaintEvent is a change on the timeline of a complaint, such as a new process or
+// discussion. Events are stored so streams can resume from the ID of the last event
+// they got. Data is the JSON of the event.
+type ComplaintEvent struct {
+	ID          int       `gorm:"primaryKey"`
+	ComplaintID string    `gorm:"type:varchar;size:15;not null;index"`
+	Type        string    `gorm:"type:varchar(50);not null"`
+	Data        string    `gorm:"type:longtext;not null"`
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+	Complaint   Complaint `gorm:"foreignKey:ComplaintID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+type ComplaintEventRepositoryInterface interface {
+	Create(event *ComplaintEvent) error
+	GetAfter(complaintID string, afterID int, limit int) ([]ComplaintEvent, error)
+}
+
+type ComplaintEventUseCaseInterface interface {
+	Publish(complaintID string, eventType string, data interface{}) error
+	// Subscribe returns the events of a complaint after lastEventID followed by the
+	// events published from now on, until the returned func is called. A lastEventID
+	// of 0 skips the events published before. The channel is closed when the stream
+	// falls behind and the missed events cannot be loaded.
+	Subscribe(complaintID string, lastEventID int) (<-chan ComplaintEvent, func(), error)
+}
@@ -8,12 +8,13 @@ import (
 )
 
 type ComplaintFile struct {
-	ID          int            `gorm:"primaryKey"`
-	ComplaintID string         `gorm:"not null;type:varchar;size:15;"`
-	Path        string         `gorm:"not null;type:varchar(255)"`
-	CreatedAt   time.Time      `gorm:"autoCreateTime"`
-	UpdatedAt   time.Time      `gorm:"autoUpdateTime"`
-	DeletedAt   gorm.DeletedAt `gorm:"index"`
+	ID            int            `gorm:"primaryKey"`
+	ComplaintID   string         `gorm:"not null;type:varchar;size:15;"`
+	Path          string         `gorm:"not null;type:varchar(255)"`
+	ThumbnailPath string         `gorm:"type:varchar(255)"`
+	CreatedAt     time.Time      `gorm:"autoCreateTime"`
+	UpdatedAt     time.Time      `gorm:"autoUpdateTime"`
+	DeletedAt     gorm.DeletedAt `gorm:"index"`
 }
 
 type ComplaintFileRepositoryInterface interface {
@@ -22,11 +23,6 @@ type ComplaintFileRepositoryInterface interface {
 	FindByComplaintID(complaintID string) ([]ComplaintFile, error)
 }
 
-type ComplaintFileGCSAPIInterface interface {
-	Upload(files []*multipart.FileHeader) ([]string, error)
-	Delete(filePaths []string) error
-}
-
 type ComplaintFileUseCaseInterface interface {
 	Create(files []*multipart.FileHeader, complaintID string) ([]ComplaintFile, error)
 	DeleteByComplaintID(complaintID string) error
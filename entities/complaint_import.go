@@ -0,0 +1,36 @@
+package entities
+
+import "mime/multipart"
+
+// ComplaintImportRowError lists what is wrong with a row of an imported file. Row is the
+// row number as shown by spreadsheet applications, the header being row 1.
+type ComplaintImportRowError struct {
+	Row    int      `json:"row"`
+	Errors []string `json:"errors"`
+}
+
+// ComplaintImportReport describes the outcome of an import. SkippedRows are the rows
+// whose idempotency key was already imported, by an earlier import or earlier in the
+// same file. It is stored as the result of import jobs, hence the JSON tags.
+type ComplaintImportReport struct {
+	Mode         string                    `json:"mode"`
+	TotalRows    int                       `json:"total_rows"`
+	ValidRows    int                       `json:"valid_rows"`
+	ImportedRows int                       `json:"imported_rows"`
+	SkippedRows  []int                     `json:"skipped_rows"`
+	Errors       []ComplaintImportRowError `json:"errors"`
+}
+
+type ComplaintImportRepositoryInterface interface {
+	GetExistingUserIDs(ids []int) ([]int, error)
+	GetExistingCategoryIDs(ids []int) ([]int, error)
+	GetExistingRegencyIDs(ids []string) ([]string, error)
+	GetImportedKeys(keys []string) ([]string, error)
+	GetComplaintSLAs(categoryIDs []int) ([]ComplaintSLA, error)
+	Import(complaints []Complaint) error
+}
+
+type ComplaintImportUseCaseInterface interface {
+	Import(file *multipart.FileHeader, mode string, adminID int, scope AdminScope) (ComplaintImportReport, error)
+	Enqueue(file *multipart.FileHeader, mode string, adminID int, scope AdminScope) (Job, error)
+}
@@ -7,22 +7,26 @@ import (
 )
 
 type ComplaintProcess struct {
-	ID          int            `gorm:"primaryKey"`
-	ComplaintID string         `gorm:"not null;type:varchar;size:15;"`
-	AdminID     int            `gorm:"not null"`
-	Status      string         `gorm:"not null;type:enum('Pending', 'Verifikasi', 'On Progress', 'Selesai', 'Ditolak')"`
-	Message     string         `gorm:"type:text"`
-	CreatedAt   time.Time      `gorm:"autoCreateTime"`
-	UpdatedAt   time.Time      `gorm:"autoUpdateTime"`
-	DeletedAt   gorm.DeletedAt `gorm:"index"`
-	Admin       Admin          `gorm:"foreignKey:AdminID;references:ID"`
+	ID          int    `gorm:"primaryKey"`
+	ComplaintID string `gorm:"not null;type:varchar;size:15;"`
+	AdminID     int    `gorm:"default:null"`
+	Status      string `gorm:"not null;type:varchar(20)"`
+	Message     string `gorm:"type:text"`
+	// Escalation is set on the processes the system records when a complaint passed
+	// the deadline of its status. They have no admin and never change the status.
+	Escalation bool           `gorm:"default:false"`
+	CreatedAt  time.Time      `gorm:"autoCreateTime"`
+	UpdatedAt  time.Time      `gorm:"autoUpdateTime"`
+	DeletedAt  gorm.DeletedAt `gorm:"index"`
+	Admin      Admin          `gorm:"foreignKey:AdminID;references:ID"`
 }
 
 type ComplaintProcessRepositoryInterface interface {
 	Create(complaintProcesses *ComplaintProcess) error
 	GetByComplaintID(complaintID string) ([]ComplaintProcess, error)
+	GetLatestByComplaintID(complaintID string) (ComplaintProcess, error)
 	Update(complaintProcesses *ComplaintProcess) error
-	Delete(complaintID string, complaintProcessID int) (string, error)
+	Delete(complaintID string, complaintProcessID int) error
 }
 
 type ComplaintProcessUseCaseInterface interface {
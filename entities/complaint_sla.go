@@ -0,0 +1,33 @@
+package entities
+
+import "time"
+
+// ComplaintSLA is the maximum number of days a complaint of a category may stay in a status.
+type ComplaintSLA struct {
+	ID         int       `gorm:"primaryKey"`
+	CategoryID int       `gorm:"not null;uniqueIndex:idx_complaint_sla_category_status"`
+	Status     string    `gorm:"not null;type:varchar(20);uniqueIndex:idx_complaint_sla_category_status"`
+	TargetDays int       `gorm:"not null"`
+	CreatedAt  time.Time `gorm:"autoCreateTime"`
+	UpdatedAt  time.Time `gorm:"autoUpdateTime"`
+	Category   Category  `gorm:"foreignKey:CategoryID;references:ID"`
+}
+
+type ComplaintSLARepositoryInterface interface {
+	GetAll() ([]ComplaintSLA, error)
+	Create(complaintSLA *ComplaintSLA) error
+	Update(complaintSLA *ComplaintSLA) error
+	Delete(id int) error
+	GetTargetDays(complaintID string, status string) (int, error)
+	UpdateDueDate(complaintID string, dueAt *time.Time) error
+	GetOverdue(now time.Time) ([]Complaint, error)
+	MarkEscalated(complaintID string, escalatedAt time.Time) error
+}
+
+type ComplaintSLAUseCaseInterface interface {
+	GetAll() ([]ComplaintSLA, error)
+	Create(complaintSLA *ComplaintSLA) (ComplaintSLA, error)
+	Update(complaintSLA *ComplaintSLA) (ComplaintSLA, error)
+	Delete(id int) error
+	EscalateOverdue() error
+}
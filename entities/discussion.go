@@ -1,6 +1,7 @@
 package entities
 
 import (
+	"context"
 	"time"
 
 	"gorm.io/gorm"
@@ -11,7 +12,7 @@ type Discussion struct {
 	UserID      *int           `gorm:"index;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
 	AdminID     *int           `gorm:"index;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
 	ComplaintID string         `gorm:"type:varchar(15);index;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
-	Comment     string         `gorm:"not null;type:text"`
+	Comment     string         `gorm:"not null;type:text;index:idx_discussions_fulltext,class:FULLTEXT"`
 	User        User           `gorm:"foreignKey:UserID;references:ID"`
 	Admin       Admin          `gorm:"foreignKey:AdminID;references:ID"`
 	Complaint   Complaint      `gorm:"foreignKey:ComplaintID;references:ID"`
@@ -24,19 +25,17 @@ type DiscussionRepositoryInterface interface {
 	Create(discussion *Discussion) error
 	GetById(id int) (*Discussion, error)
 	GetByComplaintID(complaintID string) (*[]Discussion, error)
+	GetByComplaintIDAfter(complaintID string, limit int, afterID int) ([]Discussion, error)
 	Update(discussion *Discussion) error
 	Delete(id int) error
 }
 
-type DiscussionOpenAIAPIInterface interface {
-	GetChatCompletion(prompt []string, userPrompt string) (string, error)
-}
-
 type DiscussionUseCaseInterface interface {
 	Create(discussion *Discussion) error
 	GetById(id int) (*Discussion, error)
 	GetByComplaintID(complaintID string) (*[]Discussion, error)
+	GetByComplaintIDCursor(complaintID string, limit int, cursor string) ([]Discussion, string, error)
 	Update(discussion *Discussion) error
 	Delete(id int) error
-	GetAnswerRecommendation(complaintID string) (string, error)
+	GetAnswerRecommendation(ctx context.Context, complaintID string) (string, error)
 }
@@ -0,0 +1,44 @@
+package entities
+
+import "time"
+
+// EmailOptOut records that a user does not want the emails of an event. Users get the
+// emails of every event they did not opt out of.
+type EmailOptOut struct {
+	ID        int       `gorm:"primaryKey"`
+	UserID    int       `gorm:"not null;uniqueIndex:idx_email_opt_out_user_event"`
+	Event     string    `gorm:"type:varchar(50);not null;uniqueIndex:idx_email_opt_out_user_event"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	User      User      `gorm:"foreignKey:UserID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+// EmailPreference tells whether a user gets the emails of an event.
+type EmailPreference struct {
+	Event   string
+	Enabled bool
+}
+
+type EmailAttachment struct {
+	Name    string
+	Content []byte
+}
+
+// MailerInterface sends an email rendered from one of the html templates, named
+// without its extension.
+type MailerInterface interface {
+	Send(to string, subject string, templateName string, data interface{}, attachments []EmailAttachment) error
+}
+
+type EmailRepositoryInterface interface {
+	GetOptOuts(userID int) ([]string, error)
+	UpdateOptOuts(userID int, events []string) error
+	GetComplaint(complaintID string) (Complaint, error)
+	GetEvidence(complaintID string) ([]UnggahBukti, error)
+}
+
+type EmailUseCaseInterface interface {
+	NotifyComplaintProcess(complaint Complaint, complaintProcess ComplaintProcess) error
+	NotifyDiscussion(complaint Complaint, discussion Discussion) error
+	GetPreferences(userID int) ([]EmailPreference, error)
+	UpdatePreferences(userID int, preferences []EmailPreference) ([]EmailPreference, error)
+}
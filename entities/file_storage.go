@@ -0,0 +1,12 @@
+package entities
+
+import "io"
+
+// FileStorageInterface stores uploaded files inside one folder of the configured
+// storage backend. Upload returns the paths the files are stored at, which are the
+// paths Delete and Open expect.
+type FileStorageInterface interface {
+	Upload(files []UploadFile) ([]string, error)
+	Delete(filePaths []string) error
+	Open(filePath string) (io.ReadCloser, error)
+}
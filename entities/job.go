@@ -0,0 +1,45 @@
+package entities
+
+import "time"
+
+// Job is a unit of work run in the background by the job workers. Payload is the JSON
+// the handler of its type is called with, Result what the handler returned and
+// LastError the error of its latest failed attempt. CreatedBy is the admin who asked
+// for the job, if any.
+type Job struct {
+	ID          int        `gorm:"primaryKey"`
+	Type        string     `gorm:"type:varchar(50);not null;index"`
+	Payload     string     `gorm:"type:longtext;not null"`
+	Status      string     `gorm:"type:enum('pending', 'running', 'done', 'dead');not null;default:pending;index:idx_job_status_run_at"`
+	Attempts    int        `gorm:"not null;default:0"`
+	MaxAttempts int        `gorm:"not null"`
+	RunAt       time.Time  `gorm:"not null;index:idx_job_status_run_at"`
+	LockedAt    *time.Time `gorm:"default:null"`
+	LastError   string     `gorm:"type:text"`
+	Result      string     `gorm:"type:text"`
+	CreatedBy   *int       `gorm:"default:null;index"`
+	CreatedAt   time.Time  `gorm:"autoCreateTime"`
+	UpdatedAt   time.Time  `gorm:"autoUpdateTime"`
+}
+
+// JobHandler runs a job of one type. The string it returns is stored as the result of
+// the job.
+type JobHandler func(payload []byte) (string, error)
+
+type JobRepositoryInterface interface {
+	Create(job *Job) error
+	Claim(now time.Time, staleBefore time.Time) (*Job, error)
+	Update(job *Job) error
+	GetAfter(filter map[string]interface{}, limit int, afterID int) ([]Job, error)
+	GetByID(id int) (Job, error)
+}
+
+type JobUseCaseInterface interface {
+	Register(jobType string, handler JobHandler)
+	Enqueue(jobType string, createdBy *int, payload interface{}) (Job, error)
+	RunOnce() error
+	GetByCursor(filter map[string]interface{}, limit int, cursor string) ([]Job, string, error)
+	GetByID(id int) (Job, error)
+	GetOwn(id int, jobType string, adminID int) (Job, error)
+	Retry(id int) (Job, error)
+}
@@ -0,0 +1,16 @@
+package entities
+
+import "context"
+
+// LLMMessage is a message of a conversation with a language model. Role is one of
+// constants.LLMRoleSystem and constants.LLMRoleUser.
+type LLMMessage struct {
+	Role    string
+	Content string
+}
+
+// LLMProviderInterface answers a conversation with a language model. The model and
+// its settings are part of the provider, the answer is given up once ctx is done.
+type LLMProviderInterface interface {
+	ChatCompletion(ctx context.Context, messages []LLMMessage) (string, error)
+}
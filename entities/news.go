@@ -10,8 +10,8 @@ type News struct {
 	ID         int            `gorm:"primaryKey"`
 	AdminID    int            `gorm:"not null"`
 	CategoryID int            `gorm:"not null"`
-	Title      string         `gorm:"not null;type:varchar(255)"`
-	Content    string         `gorm:"not null"`
+	Title      string         `gorm:"not null;type:varchar(255);index:idx_news_fulltext,class:FULLTEXT,priority:1"`
+	Content    string         `gorm:"not null;index:idx_news_fulltext,class:FULLTEXT,priority:2"`
 	TotalLikes int            `gorm:"default:0"`
 	CreatedAt  time.Time      `gorm:"autoCreateTime"`
 	UpdatedAt  time.Time      `gorm:"autoUpdateTime"`
@@ -26,6 +26,7 @@ type News struct {
 type NewsRepositoryInterface interface {
 	GetPaginated(limit int, page int, search string, filter map[string]interface{}, sortBy string, sortType string) ([]News, error)
 	GetMetaData(limit int, page int, search string, filter map[string]interface{}) (Metadata, error)
+	GetAfter(limit int, afterID int, afterValue string, search string, filter map[string]interface{}, sortBy string, sortType string) ([]News, error)
 	GetByID(id int) (News, error)
 	Create(news *News) error
 	Delete(id int) error
@@ -35,6 +36,7 @@ type NewsRepositoryInterface interface {
 type NewsUseCaseInterface interface {
 	GetPaginated(limit int, page int, search string, filter map[string]interface{}, sortBy string, sortType string) ([]News, error)
 	GetMetaData(limit int, page int, search string, filter map[string]interface{}) (Metadata, error)
+	GetByCursor(limit int, cursor string, search string, filter map[string]interface{}, sortBy string, sortType string) ([]News, string, error)
 	GetByID(id int) (News, error)
 	Create(news *News) (News, error)
 	Delete(id int) error
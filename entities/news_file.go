@@ -8,12 +8,13 @@ import (
 )
 
 type NewsFile struct {
-	ID        int            `gorm:"primaryKey"`
-	NewsID    int            `gorm:"not null"`
-	Path      string         `gorm:"not null;type:varchar(255)"`
-	CreatedAt time.Time      `gorm:"autoCreateTime"`
-	UpdatedAt time.Time      `gorm:"autoUpdateTime"`
-	DeletedAt gorm.DeletedAt `gorm:"index"`
+	ID            int            `gorm:"primaryKey"`
+	NewsID        int            `gorm:"not null"`
+	Path          string         `gorm:"not null;type:varchar(255)"`
+	ThumbnailPath string         `gorm:"type:varchar(255)"`
+	CreatedAt     time.Time      `gorm:"autoCreateTime"`
+	UpdatedAt     time.Time      `gorm:"autoUpdateTime"`
+	DeletedAt     gorm.DeletedAt `gorm:"index"`
 }
 
 type NewsFileRepositoryInterface interface {
@@ -22,11 +23,6 @@ type NewsFileRepositoryInterface interface {
 	FindByNewsID(newsID int) ([]NewsFile, error)
 }
 
-type NewsFileGCSAPIInterface interface {
-	Upload(files []*multipart.FileHeader) ([]string, error)
-	Delete(filePaths []string) error
-}
-
 type NewsFileUseCaseInterface interface {
 	Create(files []*multipart.FileHeader, newsID int) ([]NewsFile, error)
 	DeleteByNewsID(newsID int) error
@@ -0,0 +1,43 @@
+package entities
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Notification struct {
+	ID            int            `gorm:"primaryKey"`
+	RecipientID   int            `gorm:"not null;index:idx_notification_recipient"`
+	RecipientType string         `gorm:"type:enum('user', 'admin');not null;index:idx_notification_recipient"`
+	ComplaintID   string         `gorm:"type:varchar(15);index"`
+	Type          string         `gorm:"type:enum('process', 'discussion', 'like', 'merge');not null"`
+	Message       string         `gorm:"not null;type:text"`
+	IsRead        bool           `gorm:"default:false"`
+	ReadAt        *time.Time     `gorm:"default:null"`
+	CreatedAt     time.Time      `gorm:"autoCreateTime"`
+	UpdatedAt     time.Time      `gorm:"autoUpdateTime"`
+	DeletedAt     gorm.DeletedAt `gorm:"index"`
+	Complaint     Complaint      `gorm:"foreignKey:ComplaintID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+type NotificationRepositoryInterface interface {
+	Create(notifications []*Notification) error
+	GetByRecipient(recipientID int, recipientType string) ([]Notification, error)
+	GetByRecipientAfter(recipientID int, recipientType string, limit int, afterID int) ([]Notification, error)
+	MarkAsRead(id int, recipientID int, recipientType string) error
+	MarkAllAsRead(recipientID int, recipientType string) error
+	CountUnread(recipientID int, recipientType string) (int64, error)
+}
+
+type NotificationUseCaseInterface interface {
+	GetByRecipient(recipientID int, role string) ([]Notification, error)
+	GetByRecipientCursor(recipientID int, role string, limit int, cursor string) ([]Notification, string, error)
+	MarkAsRead(id int, recipientID int, role string) error
+	MarkAllAsRead(recipientID int, role string) error
+	GetUnreadCount(recipientID int, role string) (int64, error)
+	NotifyComplaintProcess(complaint Complaint, complaintProcess ComplaintProcess) error
+	NotifyDiscussion(complaint Complaint, discussion Discussion) error
+	NotifyLike(complaint Complaint, complaintLike ComplaintLike) error
+	NotifyComplaintMerged(master Complaint, duplicates []Complaint) error
+}
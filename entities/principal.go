@@ -0,0 +1,11 @@
+package entities
+
+// Principal is the account behind an authenticated request, read from the claims of
+// an access token whose signature and expiry were verified.
+type Principal struct {
+	ID        int
+	Name      string
+	Email     string
+	Role      string
+	SessionID string
+}
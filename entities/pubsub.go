@@ -0,0 +1,17 @@
+package entities
+
+// Event is what is published on the topics of a PubSubInterface, for example a new
+// chat message.
+type Event struct {
+	Type string      `json:"type"`
+	Data interface{} `json:"data"`
+}
+
+// PubSubInterface delivers events to the subscribers of a topic. Events published
+// while nobody is subscribed are lost.
+type PubSubInterface interface {
+	Publish(topic string, event Event)
+	// Subscribe returns the events published on topic from now on, until the returned
+	// func is called, which closes the channel.
+	Subscribe(topic string) (<-chan Event, func())
+}
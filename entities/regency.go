@@ -5,8 +5,17 @@ type Regency struct {
 	Name string `gorm:"not null;type:varchar(255)"`
 }
 
+// RegencyBoundary is the outline of a regency as a GeoJSON Polygon or MultiPolygon.
+// It is kept apart from Regency so loading regencies stays cheap.
+type RegencyBoundary struct {
+	RegencyID string  `gorm:"primaryKey;type:varchar(4)"`
+	Geometry  string  `gorm:"not null;type:longtext"`
+	Regency   Regency `gorm:"foreignKey:RegencyID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
 type RegencyRepositoryInterface interface {
 	GetAll() ([]Regency, error)
+	GetBoundary(regencyID string) (RegencyBoundary, error)
 }
 
 type RegencyIndonesiaAreaAPIInterface interface {
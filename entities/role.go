@@ -0,0 +1,84 @@
+package entities
+
+import "time"
+
+type Permission struct {
+	ID          int    `gorm:"primaryKey"`
+	Name        string `gorm:"unique;not null;type:varchar(50)"`
+	Description string `gorm:"type:varchar(255)"`
+}
+
+type Role struct {
+	ID          int          `gorm:"primaryKey"`
+	Name        string       `gorm:"unique;not null;type:varchar(50)"`
+	Description string       `gorm:"type:varchar(255)"`
+	CreatedAt   time.Time    `gorm:"autoCreateTime"`
+	UpdatedAt   time.Time    `gorm:"autoUpdateTime"`
+	Permissions []Permission `gorm:"many2many:role_permissions;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+// AdminScope limits the complaints an admin can see and process to a set of
+// regencies and categories. An empty list does not limit anything.
+type AdminScope struct {
+	RegencyIDs  []string
+	CategoryIDs []int
+}
+
+func (s AdminScope) IsEmpty() bool {
+	return len(s.RegencyIDs) == 0 && len(s.CategoryIDs) == 0
+}
+
+func (s AdminScope) Contains(regencyID string, categoryID int) bool {
+	if len(s.RegencyIDs) > 0 && !containsString(s.RegencyIDs, regencyID) {
+		return false
+	}
+
+	if len(s.CategoryIDs) > 0 && !containsInt(s.CategoryIDs, categoryID) {
+		return false
+	}
+
+	return true
+}
+
+func containsString(slice []string, item string) bool {
+	for _, element := range slice {
+		if element == item {
+			return true
+		}
+	}
+	return false
+}
+
+func containsInt(slice []int, item int) bool {
+	for _, element := range slice {
+		if element == item {
+			return true
+		}
+	}
+	return false
+}
+
+type RoleRepositoryInterface interface {
+	GetAll() ([]Role, error)
+	GetByID(id int) (Role, error)
+	Create(role *Role, permissions []string) error
+	Update(role *Role, permissions []string) error
+	Delete(id int) error
+	GetPermissions() ([]Permission, error)
+	GetAdminAccess(adminID int) (Admin, error)
+	UpdateAdminAccess(adminID int, roleID *int, regencyIDs []string, categoryIDs []int) error
+}
+
+type RoleUseCaseInterface interface {
+	GetAll() ([]Role, error)
+	GetByID(id int) (Role, error)
+	Create(role *Role, permissions []string) (Role, error)
+	Update(role *Role, permissions []string) (Role, error)
+	Delete(id int) error
+	GetPermissions() ([]Permission, error)
+	GetAdminAccess(adminID int) (Admin, error)
+	UpdateAdminAccess(adminID int, roleID *int, regencyIDs []string, categoryIDs []int) (Admin, error)
+	HasPermission(adminID int, role string, permission string) error
+	GetScope(adminID int, role string) (AdminScope, error)
+	EnsureInScope(adminID int, role string, complaint Complaint) error
+}
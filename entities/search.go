@@ -0,0 +1,17 @@
+package entities
+
+type SearchHit struct {
+	ID string
+	// Reference is the record a hit belongs to, e.g. the complaint of a discussion
+	Reference  string
+	Score      float64
+	Highlights map[string]string
+}
+
+type SearchEngineInterface interface {
+	Search(collection string, query string, limit int) ([]SearchHit, error)
+}
+
+type SearchUseCaseInterface interface {
+	Search(collection string, query string, limit int, scope AdminScope) ([]SearchHit, error)
+}
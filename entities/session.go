@@ -0,0 +1,51 @@
+package entities
+
+import "time"
+
+// Session is created on login and lives as long as its refresh token. Only the hash of
+// the refresh token is stored. Access tokens carry the session ID, so revoking a
+// session also rejects the access tokens issued for it.
+type Session struct {
+	ID          string     `gorm:"primaryKey;type:varchar(25)"`
+	SubjectID   int        `gorm:"not null;index:idx_session_subject"`
+	SubjectType string     `gorm:"type:enum('user', 'admin');not null;index:idx_session_subject"`
+	Role        string     `gorm:"not null;type:varchar(20)"`
+	Name        string     `gorm:"type:varchar(255)"`
+	Email       string     `gorm:"type:varchar(255)"`
+	TokenHash   string     `gorm:"unique;not null;type:varchar(64)"`
+	ExpiresAt   time.Time  `gorm:"not null"`
+	RevokedAt   *time.Time `gorm:"default:null"`
+	CreatedAt   time.Time  `gorm:"autoCreateTime"`
+}
+
+// SessionSubject is the account a session belongs to as it is now, which may differ
+// from what was copied into the session at login.
+type SessionSubject struct {
+	Role  string
+	Name  string
+	Email string
+}
+
+type AuthToken struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresAt    time.Time
+}
+
+type SessionRepositoryInterface interface {
+	Create(session *Session) error
+	GetByTokenHash(tokenHash string) (Session, error)
+	GetByID(id string) (Session, error)
+	Rotate(oldSessionID string, newSession *Session) error
+	Revoke(id string) error
+	RevokeAll(subjectID int, subjectType string) error
+	GetSubject(subjectID int, subjectType string) (SessionSubject, error)
+}
+
+type SessionUseCaseInterface interface {
+	Create(subjectID int, role string, name string, email string) (AuthToken, error)
+	Refresh(refreshToken string) (AuthToken, error)
+	Logout(sessionID string) error
+	RevokeAll(subjectID int, role string) error
+	EnsureActive(sessionID string) error
+}
@@ -1,6 +1,9 @@
 package entities
 
-import "time"
+import (
+	"mime/multipart"
+	"time"
+)
 
 type UnggahBukti struct {
 	ID              int64     `gorm:"primaryKey" json:"id"`
@@ -20,7 +23,7 @@ type UnggahBuktiRepositoryInterface interface {
 }
 
 type UnggahBuktiUseCaseInterface interface {
-	Create(unggahBukti *UnggahBukti) error
+	Create(file *multipart.FileHeader, unggahBukti *UnggahBukti) error
 	GetAll() ([]UnggahBukti, error)
 	GetByComplaintID(complaintID string) ([]UnggahBukti, error)
 	GetByID(id int64) (*UnggahBukti, error) // Tambahkan GetByID
@@ -0,0 +1,16 @@
+package entities
+
+// UnitOfWorkRepositories holds the repositories bound to a single transaction.
+type UnitOfWorkRepositories struct {
+	Complaint        ComplaintRepositoryInterface
+	ComplaintProcess ComplaintProcessRepositoryInterface
+	ComplaintSLA     ComplaintSLARepositoryInterface
+	Assignment       ComplaintAssignmentRepositoryInterface
+	Duplicate        ComplaintDuplicateRepositoryInterface
+}
+
+type UnitOfWorkInterface interface {
+	// Do runs fn in a transaction. The transaction is committed when fn returns nil
+	// and rolled back otherwise.
+	Do(fn func(repositories UnitOfWorkRepositories) error) error
+}
@@ -0,0 +1,10 @@
+package entities
+
+// UploadFile is an uploaded file after it went through the upload pipeline, ready to
+// be written to storage. Thumbnail is only set for images of purposes that need one.
+type UploadFile struct {
+	Name        string
+	ContentType string
+	Content     []byte
+	Thumbnail   *UploadFile
+}
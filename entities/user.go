@@ -15,6 +15,7 @@ type User struct {
 	TelephoneNumber string         `gorm:"not null;type:varchar(20)"`
 	ProfilePhoto    string         `gorm:"default:profile-photos/default.jpg;type:varchar(255)"`
 	Token           string         `gorm:"-"`
+	RefreshToken    string         `gorm:"-"`
 	Otp             string         `gorm:"default:null;type:varchar(5)"`
 	OtpExpiredAt    time.Time      `gorm:"default:null"`
 	EmailVerified   bool           `gorm:"default:false"`
@@ -31,6 +32,7 @@ type UserRepositoryInterface interface {
 	Login(user *User) error
 	GetAllUsers() ([]*User, error)
 	GetUserByID(id int) (*User, error)
+	GetUserByEmail(email string) (*User, error)
 	UpdateUser(id int, user *User) error
 	UpdateProfilePhoto(id int, profilePhoto string) error
 	Delete(id int) error
@@ -45,10 +47,6 @@ type MailTrapAPIInterface interface {
 	SendOTP(email, otp, otp_type string) error
 }
 
-type UserGCSAPIInterface interface {
-	Upload(files []*multipart.FileHeader) ([]string, error)
-}
-
 type UserUseCaseInterface interface {
 	Register(user *User) (User, error)
 	Login(user *User) (User, error)
@@ -0,0 +1,74 @@
+package entities
+
+import "time"
+
+// Webhook is an endpoint of another system that gets the complaint events it is
+// subscribed to. Events holds the names of those events separated by commas, Secret
+// the key the deliveries are signed with.
+type Webhook struct {
+	ID        int       `gorm:"primaryKey"`
+	URL       string    `gorm:"type:varchar(255);not null"`
+	Secret    string    `gorm:"type:varchar(64);not null"`
+	Events    string    `gorm:"type:varchar(255);not null"`
+	Active    bool      `gorm:"not null;default:true"`
+	CreatedBy int       `gorm:"not null"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+	Admin     Admin     `gorm:"foreignKey:CreatedBy;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+// WebhookDelivery is an event sent, or still to be sent, to a webhook. Payload is the
+// JSON of the event data. ReplayOf is the delivery a replayed delivery was copied from.
+type WebhookDelivery struct {
+	ID             int        `gorm:"primaryKey"`
+	WebhookID      int        `gorm:"not null;index"`
+	Event          string     `gorm:"type:varchar(50);not null"`
+	Payload        string     `gorm:"type:longtext;not null"`
+	Status         string     `gorm:"type:enum('pending', 'success', 'failed');not null;default:pending"`
+	Attempts       int        `gorm:"not null;default:0"`
+	ResponseStatus int        `gorm:"not null;default:0"`
+	ResponseBody   string     `gorm:"type:text"`
+	LastError      string     `gorm:"type:text"`
+	ReplayOf       *int       `gorm:"default:null"`
+	DeliveredAt    *time.Time `gorm:"default:null"`
+	CreatedAt      time.Time  `gorm:"autoCreateTime"`
+	UpdatedAt      time.Time  `gorm:"autoUpdateTime"`
+	Webhook        Webhook    `gorm:"foreignKey:WebhookID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+// WebhookResponse is what a webhook endpoint answered a delivery with.
+type WebhookResponse struct {
+	StatusCode int
+	Body       string
+}
+
+// WebhookSenderInterface posts the body of a delivery to the URL of a webhook.
+type WebhookSenderInterface interface {
+	Send(url string, headers map[string]string, body []byte) (WebhookResponse, error)
+}
+
+type WebhookRepositoryInterface interface {
+	Create(webhook *Webhook) error
+	GetAll() ([]Webhook, error)
+	GetByID(id int) (Webhook, error)
+	GetByEvent(event string) ([]Webhook, error)
+	Update(webhook *Webhook) error
+	Delete(id int) error
+	CreateDelivery(delivery *WebhookDelivery) error
+	GetDeliveryByID(webhookID int, id int) (WebhookDelivery, error)
+	GetDeliveriesAfter(webhookID int, limit int, afterID int) ([]WebhookDelivery, error)
+	UpdateDelivery(delivery *WebhookDelivery) error
+}
+
+type WebhookUseCaseInterface interface {
+	Create(url string, events []string, createdBy int) (Webhook, error)
+	GetAll() ([]Webhook, error)
+	GetByID(id int) (Webhook, error)
+	Update(id int, url string, events []string, active bool) (Webhook, error)
+	Delete(id int) error
+	// Dispatch queues a delivery of event with data to every active webhook subscribed
+	// to it.
+	Dispatch(event string, data interface{}) error
+	GetDeliveries(webhookID int, limit int, cursor string) ([]WebhookDelivery, string, error)
+	Replay(webhookID int, deliveryID int) (WebhookDelivery, error)
+}
@@ -0,0 +1,156 @@
+package export
+
+import (
+	"e-complaint-api/constants"
+	"encoding/csv"
+	"io"
+	"os"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+)
+
+// maxCellLength is the number of characters a spreadsheet cell can hold.
+const maxCellLength = 32767
+
+// Writer writes rows of a table one at a time, so exports never hold the whole table in
+// memory.
+type Writer interface {
+	Write(row []string) error
+	// Flush sends the rows written so far to the underlying writer when the format
+	// allows it.
+	Flush() error
+	Close() error
+}
+
+// NewWriter returns the writer of format, which is either csv or xlsx.
+func NewWriter(format string, w io.Writer) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return &csvWriter{writer: csv.NewWriter(w)}, nil
+	case FormatXLSX:
+		file := excelize.NewFile()
+		stream, err := file.NewStreamWriter("Sheet1")
+		if err != nil {
+			file.Close()
+			return nil, constants.ErrInternalServerError
+		}
+		return &xlsxWriter{file: file, stream: stream, writer: w}, nil
+	default:
+		return nil, constants.ErrInvalidExportFormat
+	}
+}
+
+// ContentType returns the media type of format.
+func ContentType(format string) string {
+	if format == FormatXLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+
+	return "text/csv; charset=utf-8"
+}
+
+// escape keeps spreadsheet applications from evaluating user input as a formula.
+func escape(value string) string {
+	if value == "" {
+		return value
+	}
+
+	switch value[0] {
+	case '=', '+', '@', '\t', '\r':
+		return "'" + value
+	case '-':
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return "'" + value
+		}
+	}
+
+	return value
+}
+
+type csvWriter struct {
+	writer *csv.Writer
+}
+
+func (w *csvWriter) Write(row []string) error {
+	escaped := make([]string, len(row))
+	for i, value := range row {
+		escaped[i] = escape(value)
+	}
+
+	return w.writer.Write(escaped)
+}
+
+func (w *csvWriter) Flush() error {
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+func (w *csvWriter) Close() error {
+	return w.Flush()
+}
+
+// xlsxWriter streams the rows into the sheet, which excelize keeps in a temporary file
+// once it grows large. The workbook can only be sent once every row is written, it is
+// built in a temporary file first so nothing reaches the writer when building fails.
+type xlsxWriter struct {
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	writer io.Writer
+	rows   int
+}
+
+func (w *xlsxWriter) Write(row []string) error {
+	values := make([]interface{}, len(row))
+	for i, value := range row {
+		value = escape(value)
+		if utf8.RuneCountInString(value) > maxCellLength {
+			value = string([]rune(value)[:maxCellLength])
+		}
+		values[i] = value
+	}
+
+	w.rows++
+	cell, err := excelize.CoordinatesToCellName(1, w.rows)
+	if err != nil {
+		return err
+	}
+
+	return w.stream.SetRow(cell, values)
+}
+
+func (w *xlsxWriter) Flush() error {
+	return nil
+}
+
+func (w *xlsxWriter) Close() error {
+	defer w.file.Close()
+
+	if err := w.stream.Flush(); err != nil {
+		return err
+	}
+
+	workbook, err := os.CreateTemp("", "export-*.xlsx")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(workbook.Name())
+	defer workbook.Close()
+
+	if err := w.file.Write(workbook); err != nil {
+		return err
+	}
+
+	if _, err := workbook.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	_, err = io.Copy(w.writer, workbook)
+	return err
+}
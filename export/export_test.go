@@ -0,0 +1,92 @@
+package export
+
+import (
+	"bytes"
+	"e-complaint-api/constants"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xuri/excelize/v2"
+)
+
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("connection reset")
+}
+
+func TestNewWriter(t *testing.T) {
+	t.Run("failed unknown format", func(t *testing.T) {
+		_, err := NewWriter("pdf", &bytes.Buffer{})
+		assert.Equal(t, constants.ErrInvalidExportFormat, err)
+	})
+}
+
+func TestCSVWriter(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		var buffer bytes.Buffer
+		writer, err := NewWriter(FormatCSV, &buffer)
+		assert.NoError(t, err)
+
+		assert.NoError(t, writer.Write([]string{"id", "description"}))
+		assert.NoError(t, writer.Write([]string{"C-1", "jalan \"rusak\", berlubang\nparah"}))
+		assert.NoError(t, writer.Flush())
+		assert.Equal(t, "id,description\nC-1,\"jalan \"\"rusak\"\", berlubang\nparah\"\n", buffer.String())
+
+		assert.NoError(t, writer.Write([]string{"C-2", "=HYPERLINK(\"http://evil\")", "-6.12", "-cmd", "@SUM(A1)"}))
+		assert.NoError(t, writer.Close())
+		assert.True(t, strings.HasSuffix(buffer.String(), "C-2,\"'=HYPERLINK(\"\"http://evil\"\")\",-6.12,'-cmd,'@SUM(A1)\n"))
+	})
+
+	t.Run("failed write error", func(t *testing.T) {
+		writer, _ := NewWriter(FormatCSV, failingWriter{})
+		assert.NoError(t, writer.Write([]string{"id"}))
+		assert.Error(t, writer.Flush())
+	})
+}
+
+func TestXLSXWriter(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		var buffer bytes.Buffer
+		writer, err := NewWriter(FormatXLSX, &buffer)
+		assert.NoError(t, err)
+
+		long := strings.Repeat("é", maxCellLength+10)
+		assert.NoError(t, writer.Write([]string{"id", "description"}))
+		assert.NoError(t, writer.Write([]string{"C-1", "+62 812"}))
+		assert.NoError(t, writer.Write([]string{"C-2", long}))
+		assert.NoError(t, writer.Flush())
+		assert.Zero(t, buffer.Len())
+		assert.NoError(t, writer.Close())
+
+		file, err := excelize.OpenReader(&buffer)
+		assert.NoError(t, err)
+		defer file.Close()
+
+		rows, err := file.GetRows("Sheet1")
+		assert.NoError(t, err)
+		assert.Len(t, rows, 3)
+		assert.Equal(t, []string{"id", "description"}, rows[0])
+		assert.Equal(t, []string{"C-1", "'+62 812"}, rows[1])
+		assert.Equal(t, maxCellLength, len([]rune(rows[2][1])))
+	})
+
+	t.Run("failed write error", func(t *testing.T) {
+		writer, _ := NewWriter(FormatXLSX, failingWriter{})
+		assert.NoError(t, writer.Write([]string{"id"}))
+		assert.Error(t, writer.Close())
+	})
+}
+
+func TestContentType(t *testing.T) {
+	assert.Equal(t, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", ContentType(FormatXLSX))
+	assert.Equal(t, "text/csv; charset=utf-8", ContentType(FormatCSV))
+}
+
+func TestEscape(t *testing.T) {
+	assert.Equal(t, "", escape(""))
+	assert.Equal(t, "Pending", escape("Pending"))
+	assert.Equal(t, "'\tcmd", escape("\tcmd"))
+}
@@ -0,0 +1,171 @@
+package geo
+
+import (
+	"e-complaint-api/constants"
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// earthRadius is the mean radius of the earth in kilometers.
+const earthRadius = 6371.0
+
+type Point struct {
+	Lat float64
+	Lng float64
+}
+
+// NewPoint validates a latitude and longitude pair.
+func NewPoint(lat float64, lng float64) (Point, error) {
+	if math.IsNaN(lat) || math.IsNaN(lng) || lat < -90 || lat > 90 || lng < -180 || lng > 180 {
+		return Point{}, constants.ErrInvalidCoordinates
+	}
+
+	return Point{Lat: lat, Lng: lng}, nil
+}
+
+// ParsePoint parses a "lat,lng" pair as used in query parameters.
+func ParsePoint(value string) (Point, error) {
+	parts := strings.Split(value, ",")
+	if len(parts) != 2 {
+		return Point{}, constants.ErrInvalidCoordinates
+	}
+
+	lat, err := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+	if err != nil {
+		return Point{}, constants.ErrInvalidCoordinates
+	}
+
+	lng, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+	if err != nil {
+		return Point{}, constants.ErrInvalidCoordinates
+	}
+
+	return NewPoint(lat, lng)
+}
+
+// Circle is the area within Radius kilometers of Center.
+type Circle struct {
+	Center Point
+	Radius float64
+}
+
+// Distance returns the great-circle distance between two points in kilometers.
+func Distance(a Point, b Point) float64 {
+	lat1, lat2 := radians(a.Lat), radians(b.Lat)
+	dLat, dLng := lat2-lat1, radians(b.Lng-a.Lng)
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadius * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// BoundingBox returns the south-west and north-east corners of a box that contains
+// every point within radius kilometers of center. It is used to narrow down radius
+// queries before the exact distance is computed.
+func BoundingBox(center Point, radius float64) (Point, Point) {
+	dLat := radius / earthRadius * 180 / math.Pi
+
+	// Near the poles every longitude is within reach
+	dLng := 180.0
+	if cos := math.Cos(radians(center.Lat)); cos > 1e-9 {
+		dLng = math.Min(180, dLat/cos)
+	}
+
+	return Point{Lat: center.Lat - dLat, Lng: center.Lng - dLng}, Point{Lat: center.Lat + dLat, Lng: center.Lng + dLng}
+}
+
+func radians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
+
+// Geometry is an area read from a GeoJSON Polygon or MultiPolygon.
+type Geometry struct {
+	// Every polygon is a list of rings, every ring a list of [lng, lat] positions
+	// as defined by RFC 7946.
+	polygons [][][][2]float64
+}
+
+// ParseGeometry parses a GeoJSON Polygon or MultiPolygon geometry.
+func ParseGeometry(data string) (Geometry, error) {
+	var raw struct {
+		Type        string          `json:"type"`
+		Coordinates json.RawMessage `json:"coordinates"`
+	}
+	if err := json.Unmarshal([]byte(data), &raw); err != nil {
+		return Geometry{}, constants.ErrInvalidGeometry
+	}
+
+	var geometry Geometry
+	switch raw.Type {
+	case "Polygon":
+		var polygon [][][2]float64
+		if err := json.Unmarshal(raw.Coordinates, &polygon); err != nil {
+			return Geometry{}, constants.ErrInvalidGeometry
+		}
+		geometry.polygons = [][][][2]float64{polygon}
+	case "MultiPolygon":
+		if err := json.Unmarshal(raw.Coordinates, &geometry.polygons); err != nil {
+			return Geometry{}, constants.ErrInvalidGeometry
+		}
+	default:
+		return Geometry{}, constants.ErrInvalidGeometry
+	}
+
+	if len(geometry.polygons) == 0 {
+		return Geometry{}, constants.ErrInvalidGeometry
+	}
+
+	for _, polygon := range geometry.polygons {
+		if len(polygon) == 0 {
+			return Geometry{}, constants.ErrInvalidGeometry
+		}
+		for _, ring := range polygon {
+			if len(ring) < 4 {
+				return Geometry{}, constants.ErrInvalidGeometry
+			}
+		}
+	}
+
+	return geometry, nil
+}
+
+// Contains reports whether a point lies inside the geometry. The first ring of every
+// polygon is its outline, the other rings are holes.
+func (g Geometry) Contains(point Point) bool {
+	for _, polygon := range g.polygons {
+		if !inRing(polygon[0], point) {
+			continue
+		}
+
+		inHole := false
+		for _, hole := range polygon[1:] {
+			if inRing(hole, point) {
+				inHole = true
+				break
+			}
+		}
+
+		if !inHole {
+			return true
+		}
+	}
+
+	return false
+}
+
+// inRing uses ray casting: a point is inside a ring when a ray from it crosses the
+// ring an odd number of times.
+func inRing(ring [][2]float64, point Point) bool {
+	inside := false
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		xi, yi := ring[i][0], ring[i][1]
+		xj, yj := ring[j][0], ring[j][1]
+
+		if (yi > point.Lat) != (yj > point.Lat) && point.Lng < (xj-xi)*(point.Lat-yi)/(yj-yi)+xi {
+			inside = !inside
+		}
+	}
+
+	return inside
+}
@@ -0,0 +1,128 @@
+package geo
+
+import (
+	"e-complaint-api/constants"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPoint(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		point, err := NewPoint(-6.12, 106.15)
+		assert.NoError(t, err)
+		assert.Equal(t, Point{Lat: -6.12, Lng: 106.15}, point)
+	})
+
+	t.Run("failed latitude out of range", func(t *testing.T) {
+		_, err := NewPoint(-90.5, 106.15)
+		assert.Equal(t, constants.ErrInvalidCoordinates, err)
+	})
+
+	t.Run("failed longitude out of range", func(t *testing.T) {
+		_, err := NewPoint(-6.12, 180.5)
+		assert.Equal(t, constants.ErrInvalidCoordinates, err)
+	})
+
+	t.Run("failed not a number", func(t *testing.T) {
+		_, err := NewPoint(math.NaN(), 106.15)
+		assert.Equal(t, constants.ErrInvalidCoordinates, err)
+	})
+}
+
+func TestParsePoint(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		point, err := ParsePoint("-6.12, 106.15")
+		assert.NoError(t, err)
+		assert.Equal(t, Point{Lat: -6.12, Lng: 106.15}, point)
+	})
+
+	t.Run("failed missing longitude", func(t *testing.T) {
+		_, err := ParsePoint("-6.12")
+		assert.Equal(t, constants.ErrInvalidCoordinates, err)
+	})
+
+	t.Run("failed invalid latitude", func(t *testing.T) {
+		_, err := ParsePoint("south,106.15")
+		assert.Equal(t, constants.ErrInvalidCoordinates, err)
+	})
+
+	t.Run("failed invalid longitude", func(t *testing.T) {
+		_, err := ParsePoint("-6.12,east")
+		assert.Equal(t, constants.ErrInvalidCoordinates, err)
+	})
+}
+
+func TestDistance(t *testing.T) {
+	serang := Point{Lat: -6.1200, Lng: 106.1503}
+	cilegon := Point{Lat: -6.0025, Lng: 106.0111}
+
+	assert.InDelta(t, 20.2, Distance(serang, cilegon), 0.5)
+	assert.Equal(t, 0.0, Distance(serang, serang))
+}
+
+func TestBoundingBox(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		center := Point{Lat: -6.12, Lng: 106.15}
+		sw, ne := BoundingBox(center, 10)
+
+		assert.Less(t, sw.Lat, center.Lat)
+		assert.Less(t, sw.Lng, center.Lng)
+		assert.Greater(t, ne.Lat, center.Lat)
+		assert.Greater(t, ne.Lng, center.Lng)
+
+		// Every corner of the box lies at least radius away along each axis
+		assert.InDelta(t, 10, Distance(center, Point{Lat: ne.Lat, Lng: center.Lng}), 0.01)
+		assert.InDelta(t, 10, Distance(center, Point{Lat: center.Lat, Lng: ne.Lng}), 0.01)
+	})
+
+	t.Run("success at pole", func(t *testing.T) {
+		sw, ne := BoundingBox(Point{Lat: 90, Lng: 0}, 10)
+		assert.Equal(t, -180.0, sw.Lng)
+		assert.Equal(t, 180.0, ne.Lng)
+	})
+}
+
+func TestParseGeometry(t *testing.T) {
+	t.Run("success polygon", func(t *testing.T) {
+		geometry, err := ParseGeometry(`{"type":"Polygon","coordinates":[[[0,0],[10,0],[10,10],[0,10],[0,0]]]}`)
+		assert.NoError(t, err)
+		assert.Len(t, geometry.polygons, 1)
+	})
+
+	t.Run("success multipolygon", func(t *testing.T) {
+		geometry, err := ParseGeometry(`{"type":"MultiPolygon","coordinates":[[[[0,0],[1,0],[1,1],[0,0]]],[[[5,5],[6,5],[6,6],[5,5]]]]}`)
+		assert.NoError(t, err)
+		assert.Len(t, geometry.polygons, 2)
+	})
+
+	tests := map[string]string{
+		"failed invalid json":             `{`,
+		"failed unsupported type":         `{"type":"Point","coordinates":[0,0]}`,
+		"failed invalid polygon":          `{"type":"Polygon","coordinates":"x"}`,
+		"failed invalid multipolygon":     `{"type":"MultiPolygon","coordinates":"x"}`,
+		"failed empty multipolygon":       `{"type":"MultiPolygon","coordinates":[]}`,
+		"failed polygon without rings":    `{"type":"MultiPolygon","coordinates":[[]]}`,
+		"failed ring with too few points": `{"type":"Polygon","coordinates":[[[0,0],[1,0],[0,0]]]}`,
+	}
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseGeometry(data)
+			assert.Equal(t, constants.ErrInvalidGeometry, err)
+		})
+	}
+}
+
+func TestContains(t *testing.T) {
+	geometry, err := ParseGeometry(`{"type":"MultiPolygon","coordinates":[
+		[[[0,0],[10,0],[10,10],[0,10],[0,0]],[[4,4],[6,4],[6,6],[4,6],[4,4]]],
+		[[[20,20],[30,20],[30,30],[20,30],[20,20]]]
+	]}`)
+	assert.NoError(t, err)
+
+	assert.True(t, geometry.Contains(Point{Lat: 2, Lng: 2}))
+	assert.True(t, geometry.Contains(Point{Lat: 25, Lng: 25}))
+	assert.False(t, geometry.Contains(Point{Lat: 5, Lng: 5}))
+	assert.False(t, geometry.Contains(Point{Lat: 15, Lng: 15}))
+}
@@ -0,0 +1,47 @@
+package llm
+
+import (
+	"context"
+	"e-complaint-api/constants"
+	"e-complaint-api/entities"
+	"sync"
+)
+
+// Fake is a provider that answers without a language model. It is used to run the API
+// offline and in tests.
+type Fake struct {
+	mu       sync.Mutex
+	answer   string
+	requests [][]entities.LLMMessage
+}
+
+// NewFake returns a provider that always answers with answer. When answer is empty it
+// repeats the last message of the conversation, so the same conversation always gets
+// the same answer.
+func NewFake(answer string) *Fake {
+	return &Fake{answer: answer}
+}
+
+func (f *Fake) ChatCompletion(ctx context.Context, messages []entities.LLMMessage) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.requests = append(f.requests, messages)
+
+	if f.answer != "" {
+		return f.answer, nil
+	}
+	if len(messages) == 0 {
+		return "", constants.ErrEmptyLLMResponse
+	}
+
+	return "[fake] " + messages[len(messages)-1].Content, nil
+}
+
+// Requests returns the conversations the provider has answered, oldest first.
+func (f *Fake) Requests() [][]entities.LLMMessage {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([][]entities.LLMMessage{}, f.requests...)
+}
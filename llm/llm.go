@@ -0,0 +1,48 @@
+package llm
+
+import (
+	"e-complaint-api/constants"
+	"e-complaint-api/entities"
+	"time"
+)
+
+type Config struct {
+	DRIVER      string
+	BASE_URL    string
+	API_KEY     string
+	MODEL       string
+	TEMPERATURE float32
+	MAX_TOKENS  int
+	TIMEOUT     time.Duration
+	MAX_RETRIES int
+	RETRY_DELAY time.Duration
+}
+
+// NewLLMProvider returns the provider chosen by DRIVER, which is one of "openai", that
+// talks to the chat completions API at BASE_URL, or "fake", that answers without any
+// model so the API can run offline.
+func NewLLMProvider(config Config) entities.LLMProviderInterface {
+	switch config.DRIVER {
+	case "openai":
+		return NewOpenAI(config)
+	case "fake":
+		return NewFake("")
+	default:
+		panic(constants.ErrInvalidLLMDriver)
+	}
+}
+
+// Prompt returns a conversation that starts with the persona of the application,
+// followed by instructions as system messages and userMessage, unless it is empty.
+func Prompt(instructions []string, userMessage string) []entities.LLMMessage {
+	messages := []entities.LLMMessage{{Role: constants.LLMRoleSystem, Content: constants.LLMPersona}}
+	for _, instruction := range instructions {
+		messages = append(messages, entities.LLMMessage{Role: constants.LLMRoleSystem, Content: instruction})
+	}
+
+	if userMessage != "" {
+		messages = append(messages, entities.LLMMessage{Role: constants.LLMRoleUser, Content: userMessage})
+	}
+
+	return messages
+}
@@ -0,0 +1,252 @@
+package llm
+
+import (
+	"context"
+	"e-complaint-api/constants"
+	"e-complaint-api/entities"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	openai "github.com/sashabaranov/go-openai"
+	"github.com/stretchr/testify/assert"
+)
+
+var conversation = []entities.LLMMessage{
+	{Role: constants.LLMRoleSystem, Content: "Jawab dengan singkat"},
+	{Role: constants.LLMRoleUser, Content: "Halo"},
+}
+
+// server answers chat completions with handle and counts the requests it got.
+func server(t *testing.T, handle func(w http.ResponseWriter, request openai.ChatCompletionRequest)) (Config, *int32) {
+	requests := new(int32)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+		assert.Equal(t, "/chat/completions", r.URL.Path)
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+
+		var request openai.ChatCompletionRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		handle(w, request)
+	}))
+	t.Cleanup(ts.Close)
+
+	return Config{
+		DRIVER:      "openai",
+		BASE_URL:    ts.URL,
+		API_KEY:     "secret",
+		MODEL:       "llama3",
+		TEMPERATURE: 0.2,
+		MAX_TOKENS:  256,
+		TIMEOUT:     time.Second,
+		MAX_RETRIES: 2,
+	}, requests
+}
+
+func answer(w http.ResponseWriter, content string) {
+	response := openai.ChatCompletionResponse{}
+	if content != "" {
+		response.Choices = []openai.ChatCompletionChoice{{Message: openai.ChatCompletionMessage{Role: "assistant", Content: content}}}
+	}
+	json.NewEncoder(w).Encode(response)
+}
+
+func fail(w http.ResponseWriter, statusCode int) {
+	w.WriteHeader(statusCode)
+	w.Write([]byte(`{"error":{"message":"failed"}}`))
+}
+
+func TestOpenAI(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		config, requests := server(t, func(w http.ResponseWriter, request openai.ChatCompletionRequest) {
+			assert.Equal(t, "llama3", request.Model)
+			assert.Equal(t, float32(0.2), request.Temperature)
+			assert.Equal(t, 256, request.MaxTokens)
+			assert.Equal(t, []openai.ChatCompletionMessage{
+				{Role: constants.LLMRoleSystem, Content: "Jawab dengan singkat"},
+				{Role: constants.LLMRoleUser, Content: "Halo"},
+			}, request.Messages)
+			answer(w, "Halo juga")
+		})
+
+		result, err := NewLLMProvider(config).ChatCompletion(context.Background(), conversation)
+		assert.NoError(t, err)
+		assert.Equal(t, "Halo juga", result)
+		assert.Equal(t, int32(1), *requests)
+	})
+
+	t.Run("success temperature 0", func(t *testing.T) {
+		config, _ := server(t, func(w http.ResponseWriter, request openai.ChatCompletionRequest) {
+			assert.Equal(t, float32(math.SmallestNonzeroFloat32), request.Temperature)
+			answer(w, "Halo juga")
+		})
+		config.TEMPERATURE = 0
+
+		_, err := NewOpenAI(config).ChatCompletion(context.Background(), conversation)
+		assert.NoError(t, err)
+	})
+
+	t.Run("success after retries", func(t *testing.T) {
+		attempts := 0
+		config, requests := server(t, func(w http.ResponseWriter, request openai.ChatCompletionRequest) {
+			attempts++
+			switch attempts {
+			case 1:
+				fail(w, http.StatusTooManyRequests)
+			case 2:
+				fail(w, http.StatusServiceUnavailable)
+			default:
+				answer(w, "Halo juga")
+			}
+		})
+		config.RETRY_DELAY = time.Millisecond
+
+		result, err := NewOpenAI(config).ChatCompletion(context.Background(), conversation)
+		assert.NoError(t, err)
+		assert.Equal(t, "Halo juga", result)
+		assert.Equal(t, int32(3), *requests)
+	})
+
+	t.Run("failed out of retries", func(t *testing.T) {
+		config, requests := server(t, func(w http.ResponseWriter, request openai.ChatCompletionRequest) {
+			fail(w, http.StatusInternalServerError)
+		})
+		config.RETRY_DELAY = time.Millisecond
+
+		_, err := NewOpenAI(config).ChatCompletion(context.Background(), conversation)
+		assert.Error(t, err)
+		assert.Equal(t, int32(3), *requests)
+	})
+
+	t.Run("failed timeout", func(t *testing.T) {
+		config, requests := server(t, func(w http.ResponseWriter, request openai.ChatCompletionRequest) {
+			time.Sleep(50 * time.Millisecond)
+			answer(w, "Halo juga")
+		})
+		config.TIMEOUT = 10 * time.Millisecond
+		config.MAX_RETRIES = 1
+
+		_, err := NewOpenAI(config).ChatCompletion(context.Background(), conversation)
+		assert.ErrorContains(t, err, "deadline exceeded")
+		assert.Equal(t, int32(2), *requests)
+	})
+
+	t.Run("failed canceled while waiting to retry", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		config, requests := server(t, func(w http.ResponseWriter, request openai.ChatCompletionRequest) {
+			cancel()
+			fail(w, http.StatusServiceUnavailable)
+		})
+		config.RETRY_DELAY = time.Minute
+
+		started := time.Now()
+		_, err := NewOpenAI(config).ChatCompletion(ctx, conversation)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Less(t, time.Since(started), time.Second)
+		assert.Equal(t, int32(1), *requests)
+	})
+
+	t.Run("failed deadline of the caller", func(t *testing.T) {
+		config, requests := server(t, func(w http.ResponseWriter, request openai.ChatCompletionRequest) {
+			time.Sleep(50 * time.Millisecond)
+			answer(w, "Halo juga")
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		_, err := NewOpenAI(config).ChatCompletion(ctx, conversation)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, int32(1), *requests)
+	})
+
+	t.Run("failed bad request", func(t *testing.T) {
+		config, requests := server(t, func(w http.ResponseWriter, request openai.ChatCompletionRequest) {
+			fail(w, http.StatusBadRequest)
+		})
+
+		_, err := NewOpenAI(config).ChatCompletion(context.Background(), conversation)
+		assert.Error(t, err)
+		assert.Equal(t, int32(1), *requests)
+	})
+
+	t.Run("failed bad gateway without body", func(t *testing.T) {
+		config, requests := server(t, func(w http.ResponseWriter, request openai.ChatCompletionRequest) {
+			w.WriteHeader(http.StatusBadGateway)
+		})
+		config.MAX_RETRIES = 1
+
+		_, err := NewOpenAI(config).ChatCompletion(context.Background(), conversation)
+		assert.Error(t, err)
+		assert.Equal(t, int32(2), *requests)
+	})
+
+	t.Run("failed no choices", func(t *testing.T) {
+		config, requests := server(t, func(w http.ResponseWriter, request openai.ChatCompletionRequest) {
+			answer(w, "")
+		})
+
+		_, err := NewOpenAI(config).ChatCompletion(context.Background(), conversation)
+		assert.Equal(t, constants.ErrEmptyLLMResponse, err)
+		assert.Equal(t, int32(1), *requests)
+	})
+}
+
+func TestFake(t *testing.T) {
+	t.Run("success repeat", func(t *testing.T) {
+		fake := NewLLMProvider(Config{DRIVER: "fake"}).(*Fake)
+
+		result, err := fake.ChatCompletion(context.Background(), conversation)
+		assert.NoError(t, err)
+		assert.Equal(t, "[fake] Halo", result)
+
+		again, _ := fake.ChatCompletion(context.Background(), conversation)
+		assert.Equal(t, result, again)
+		assert.Equal(t, [][]entities.LLMMessage{conversation, conversation}, fake.Requests())
+	})
+
+	t.Run("success answer", func(t *testing.T) {
+		result, err := NewFake("Halo juga").ChatCompletion(context.Background(), conversation)
+		assert.NoError(t, err)
+		assert.Equal(t, "Halo juga", result)
+	})
+
+	t.Run("failed empty conversation", func(t *testing.T) {
+		_, err := NewFake("").ChatCompletion(context.Background(), nil)
+		assert.Equal(t, constants.ErrEmptyLLMResponse, err)
+	})
+}
+
+func TestNewLLMProvider(t *testing.T) {
+	t.Run("success openai", func(t *testing.T) {
+		provider := NewLLMProvider(Config{DRIVER: "openai"})
+		assert.IsType(t, &OpenAI{}, provider)
+	})
+
+	t.Run("failed invalid driver", func(t *testing.T) {
+		assert.PanicsWithValue(t, constants.ErrInvalidLLMDriver, func() {
+			NewLLMProvider(Config{DRIVER: "other"})
+		})
+	})
+}
+
+func TestPrompt(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		assert.Equal(t, []entities.LLMMessage{
+			{Role: constants.LLMRoleSystem, Content: constants.LLMPersona},
+			{Role: constants.LLMRoleSystem, Content: "FAQ"},
+			{Role: constants.LLMRoleUser, Content: "Halo"},
+		}, Prompt([]string{"FAQ"}, "Halo"))
+	})
+
+	t.Run("success without user message", func(t *testing.T) {
+		assert.Equal(t, []entities.LLMMessage{
+			{Role: constants.LLMRoleSystem, Content: constants.LLMPersona},
+			{Role: constants.LLMRoleSystem, Content: "FAQ"},
+		}, Prompt([]string{"FAQ"}, ""))
+	})
+}
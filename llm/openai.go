@@ -0,0 +1,118 @@
+package llm
+
+import (
+	"context"
+	"e-complaint-api/constants"
+	"e-complaint-api/entities"
+	"errors"
+	"math"
+	"net/http"
+	"time"
+
+	openai "github.com/sashabaranov/go-openai"
+)
+
+// OpenAI is a provider for the chat completions API of OpenAI, or of any server that
+// implements it, like a local model server.
+type OpenAI struct {
+	client      *openai.Client
+	model       string
+	temperature float32
+	maxTokens   int
+	timeout     time.Duration
+	maxRetries  int
+	retryDelay  time.Duration
+}
+
+// NewOpenAI returns a provider that sends its requests to BASE_URL, or to OpenAI when
+// it is empty.
+func NewOpenAI(config Config) *OpenAI {
+	clientConfig := openai.DefaultConfig(config.API_KEY)
+	if config.BASE_URL != "" {
+		clientConfig.BaseURL = config.BASE_URL
+	}
+
+	return &OpenAI{
+		client:      openai.NewClientWithConfig(clientConfig),
+		model:       config.MODEL,
+		temperature: config.TEMPERATURE,
+		maxTokens:   config.MAX_TOKENS,
+		timeout:     config.TIMEOUT,
+		maxRetries:  config.MAX_RETRIES,
+		retryDelay:  config.RETRY_DELAY,
+	}
+}
+
+// ChatCompletion sends messages with the settings of the provider. Every attempt
+// runs for at most the timeout of the provider. Attempts that fail on the network,
+// time out, get rate limited or hit a server error are retried up to the retries of
+// the provider, waiting twice as long before each retry. Once ctx is done, e.g.
+// because the client went away, no attempt or wait is started or finished.
+func (o *OpenAI) ChatCompletion(ctx context.Context, messages []entities.LLMMessage) (string, error) {
+	request := openai.ChatCompletionRequest{
+		Model:       o.model,
+		Temperature: o.temperature,
+		MaxTokens:   o.maxTokens,
+	}
+	// A temperature of 0 is left out of the request, so the smallest one is sent instead
+	if request.Temperature == 0 {
+		request.Temperature = math.SmallestNonzeroFloat32
+	}
+	for _, message := range messages {
+		request.Messages = append(request.Messages, openai.ChatCompletionMessage{
+			Role:    message.Role,
+			Content: message.Content,
+		})
+	}
+
+	delay := o.retryDelay
+	for attempt := 0; ; attempt++ {
+		answer, err := o.complete(ctx, request)
+		if err == nil || attempt >= o.maxRetries || !retryable(err) || ctx.Err() != nil {
+			return answer, err
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return "", ctx.Err()
+		case <-timer.C:
+		}
+		delay *= 2
+	}
+}
+
+func (o *OpenAI) complete(ctx context.Context, request openai.ChatCompletionRequest) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, o.timeout)
+	defer cancel()
+
+	response, err := o.client.CreateChatCompletion(ctx, request)
+	if err != nil {
+		return "", err
+	}
+	if len(response.Choices) == 0 || response.Choices[0].Message.Content == "" {
+		return "", constants.ErrEmptyLLMResponse
+	}
+
+	return response.Choices[0].Message.Content, nil
+}
+
+// retryable tells whether a failed attempt may succeed when it is sent again. Errors
+// without a status code are network errors and timeouts.
+func retryable(err error) bool {
+	if errors.Is(err, constants.ErrEmptyLLMResponse) {
+		return false
+	}
+
+	statusCode := 0
+	var apiError *openai.APIError
+	var requestError *openai.RequestError
+	if errors.As(err, &apiError) {
+		statusCode = apiError.HTTPStatusCode
+	} else if errors.As(err, &requestError) {
+		statusCode = requestError.HTTPStatusCode
+	}
+
+	return statusCode == 0 || statusCode == http.StatusTooManyRequests || statusCode >= http.StatusInternalServerError
+}
@@ -2,23 +2,32 @@ package main
 
 import (
 	"e-complaint-api/config"
+	"e-complaint-api/constants"
 	dashboard_cl "e-complaint-api/controllers/dashboard"
 	"e-complaint-api/controllers/news_comment"
 	"e-complaint-api/controllers/news_like"
 	"e-complaint-api/drivers/mailtrap"
 	"e-complaint-api/drivers/mysql"
 	dashboard_repo "e-complaint-api/drivers/mysql/dashboard"
-	"e-complaint-api/drivers/openai_api"
+	"e-complaint-api/drivers/scheduler"
+	"e-complaint-api/llm"
+	"e-complaint-api/middlewares"
+	"e-complaint-api/pubsub"
 	"e-complaint-api/routes"
 	dashboard_uc "e-complaint-api/usecases/dashboard"
 
 	"os"
+	"strconv"
+	"time"
 
-	gcs_api "e-complaint-api/drivers/google_cloud_storage"
+	"e-complaint-api/drivers/file_storage"
+	"e-complaint-api/drivers/search_engine"
 
 	admin_cl "e-complaint-api/controllers/admin"
+	attachment_cl "e-complaint-api/controllers/attachment"
 	admin_rp "e-complaint-api/drivers/mysql/admin"
 	admin_uc "e-complaint-api/usecases/admin"
+	attachment_uc "e-complaint-api/usecases/attachment"
 
 	complaint_cl "e-complaint-api/controllers/complaint"
 	complaint_rp "e-complaint-api/drivers/mysql/complaint"
@@ -31,6 +40,27 @@ import (
 	complaint_process_rp "e-complaint-api/drivers/mysql/complaint_process"
 	complaint_process_uc "e-complaint-api/usecases/complaint_process"
 
+	unit_of_work "e-complaint-api/drivers/mysql/unit_of_work"
+
+	complaint_assignment_cl "e-complaint-api/controllers/complaint_assignment"
+	complaint_duplicate_cl "e-complaint-api/controllers/complaint_duplicate"
+	complaint_import_cl "e-complaint-api/controllers/complaint_import"
+	complaint_sla_cl "e-complaint-api/controllers/complaint_sla"
+	role_cl "e-complaint-api/controllers/role"
+	session_cl "e-complaint-api/controllers/session"
+	complaint_assignment_rp "e-complaint-api/drivers/mysql/complaint_assignment"
+	complaint_duplicate_rp "e-complaint-api/drivers/mysql/complaint_duplicate"
+	complaint_import_rp "e-complaint-api/drivers/mysql/complaint_import"
+	complaint_sla_rp "e-complaint-api/drivers/mysql/complaint_sla"
+	role_rp "e-complaint-api/drivers/mysql/role"
+	session_rp "e-complaint-api/drivers/mysql/session"
+	complaint_assignment_uc "e-complaint-api/usecases/complaint_assignment"
+	complaint_duplicate_uc "e-complaint-api/usecases/complaint_duplicate"
+	complaint_import_uc "e-complaint-api/usecases/complaint_import"
+	complaint_sla_uc "e-complaint-api/usecases/complaint_sla"
+	role_uc "e-complaint-api/usecases/role"
+	session_uc "e-complaint-api/usecases/session"
+
 	user_cl "e-complaint-api/controllers/user"
 	user_rp "e-complaint-api/drivers/mysql/user"
 	user_uc "e-complaint-api/usecases/user"
@@ -75,6 +105,9 @@ import (
 	chat_rp "e-complaint-api/drivers/mysql/chat"
 	chat_uc "e-complaint-api/usecases/chat"
 
+	complaint_event_rp "e-complaint-api/drivers/mysql/complaint_event"
+	complaint_event_uc "e-complaint-api/usecases/complaint_event"
+
 	unggah_bukti_cl "e-complaint-api/controllers/unggah_bukti"
 	unggah_bukti_rp "e-complaint-api/drivers/mysql/unggah_bukti"
 	unggah_bukti_uc "e-complaint-api/usecases/unggah_bukti"
@@ -86,6 +119,26 @@ import (
 	news_comment_rp "e-complaint-api/drivers/mysql/news_comment"
 	news_comment_uc "e-complaint-api/usecases/news_comment"
 
+	notification_cl "e-complaint-api/controllers/notification"
+	notification_rp "e-complaint-api/drivers/mysql/notification"
+	notification_uc "e-complaint-api/usecases/notification"
+
+	email_cl "e-complaint-api/controllers/email"
+	email_rp "e-complaint-api/drivers/mysql/email"
+	email_uc "e-complaint-api/usecases/email"
+
+	search_cl "e-complaint-api/controllers/search"
+	search_uc "e-complaint-api/usecases/search"
+
+	job_cl "e-complaint-api/controllers/job"
+	job_rp "e-complaint-api/drivers/mysql/job"
+	job_uc "e-complaint-api/usecases/job"
+
+	webhook_cl "e-complaint-api/controllers/webhook"
+	webhook_rp "e-complaint-api/drivers/mysql/webhook"
+	webhook_sender "e-complaint-api/drivers/webhook"
+	webhook_uc "e-complaint-api/usecases/webhook"
+
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 )
@@ -100,58 +153,142 @@ func main() {
 	e := echo.New()
 	e.Use(middleware.CORS())
 
-	e.Static("/uploads", "./uploads")
+	fileStorage := file_storage.NewFileStorage(config.InitConfigFileStorage())
+	searchEngine := search_engine.NewSearchEngine(config.InitConfigSearchEngine(), DB)
+	eventHub := pubsub.NewHub(constants.ChatEventBuffer)
+
+	complaintEventRepo := complaint_event_rp.NewComplaintEventRepo(DB)
+	complaintEventUsecase := complaint_event_uc.NewComplaintEventUseCase(complaintEventRepo, eventHub)
+
+	jobLockTimeout, err := time.ParseDuration(os.Getenv("JOB_LOCK_TIMEOUT"))
+	if err != nil {
+		jobLockTimeout = 30 * time.Minute
+	}
+	jobRepo := job_rp.NewJobRepo(DB)
+	jobUsecase := job_uc.NewJobUseCase(jobRepo, jobLockTimeout)
+	JobController := job_cl.NewJobController(jobUsecase)
+
+	webhookTimeout, err := time.ParseDuration(os.Getenv("WEBHOOK_TIMEOUT"))
+	if err != nil {
+		webhookTimeout = constants.WebhookTimeout
+	}
+	webhookRepo := webhook_rp.NewWebhookRepo(DB)
+	webhookUsecase := webhook_uc.NewWebhookUseCase(webhookRepo, webhook_sender.NewHTTPSender(webhookTimeout), jobUsecase)
+	WebhookController := webhook_cl.NewWebhookController(webhookUsecase)
+
+	accessTokenTTL, err := time.ParseDuration(os.Getenv("JWT_ACCESS_TOKEN_TTL"))
+	if err != nil {
+		accessTokenTTL = 15 * time.Minute
+	}
+	refreshTokenTTL, err := time.ParseDuration(os.Getenv("JWT_REFRESH_TOKEN_TTL"))
+	if err != nil {
+		refreshTokenTTL = 30 * 24 * time.Hour
+	}
+	sessionRepo := session_rp.NewSessionRepo(DB)
+	sessionUsecase := session_uc.NewSessionUseCase(sessionRepo, accessTokenTTL, refreshTokenTTL)
+	SessionController := session_cl.NewSessionController(sessionUsecase)
+	SessionMiddleware := middlewares.NewSessionMiddleware(sessionUsecase)
 
 	adminRepo := admin_rp.NewAdminRepo(DB)
-	adminUsecase := admin_uc.NewAdminUseCase(adminRepo)
+	adminUsecase := admin_uc.NewAdminUseCase(adminRepo, sessionUsecase)
 	AdminController := admin_cl.NewAdminController(adminUsecase)
 
-	mailTrapApi := mailtrap.NewMailTrapApi(
+	mailer := mailtrap.NewMailTrapApi(
 		os.Getenv("SMTP_HOST"),
 		os.Getenv("SMTP_PORT"),
 		os.Getenv("SMTP_USERNAME"),
 		os.Getenv("SMTP_PASSWORD"),
 		os.Getenv("SMTP_FROM"),
 	)
-	userGCSAPI := gcs_api.NewFileHandlingAPI(os.Getenv("GCS_CREDENTIALS"), "profile-photos/")
+	mailTrapApi := mailtrap.NewQueuedMailTrapApi(mailer, jobUsecase)
+	userStorage := fileStorage.Folder(constants.FolderProfilePhotos)
 	userRepo := user_rp.NewUserRepo(DB)
-	userUsecase := user_uc.NewUserUseCase(userRepo, mailTrapApi, userGCSAPI)
+	userUsecase := user_uc.NewUserUseCase(userRepo, mailTrapApi, userStorage, sessionUsecase)
 	UserController := user_cl.NewUserController(userUsecase)
 
-	complaintFileGCSAPI := gcs_api.NewFileHandlingAPI(os.Getenv("GCS_CREDENTIALS"), "complaint-files/")
+	complaintFileStorage := fileStorage.Folder(constants.FolderComplaintFiles)
 	complaintFileRepo := complaint_file_rp.NewComplaintFileRepo(DB)
-	complaintFileUsecase := complaint_file_uc.NewComplaintFileUseCase(complaintFileRepo, complaintFileGCSAPI)
+	complaintFileUsecase := complaint_file_uc.NewComplaintFileUseCase(complaintFileRepo, complaintFileStorage)
+
+	roleRepo := role_rp.NewRoleRepo(DB)
+	roleUsecase := role_uc.NewRoleUseCase(roleRepo)
+	RoleController := role_cl.NewRoleController(roleUsecase)
+	PermissionMiddleware := middlewares.NewPermissionMiddleware(roleUsecase)
 
+	regencyRepo := regency_rp.NewRegencyRepo(DB)
 	complaintRepo := complaint_rp.NewComplaintRepo(DB)
 	complaintProcessRepo := complaint_process_rp.NewComplaintProcessRepo(DB)
-	complaintUsecase := complaint_uc.NewComplaintUseCase(complaintRepo, complaintFileRepo)
-	complaintProcessUsecase := complaint_process_uc.NewComplaintProcessUseCase(complaintProcessRepo, complaintRepo)
-	ComplaintController := complaint_cl.NewComplaintController(complaintUsecase, complaintFileUsecase, complaintProcessUsecase)
-	ComplaintProcessController := complaint_process_cl.NewComplaintProcessController(complaintUsecase, complaintProcessUsecase)
+	unitOfWork := unit_of_work.NewUnitOfWork(DB)
+	complaintUsecase := complaint_uc.NewComplaintUseCase(complaintRepo, complaintFileRepo, regencyRepo, searchEngine)
+	complaintProcessUsecase := complaint_process_uc.NewComplaintProcessUseCase(complaintProcessRepo, unitOfWork)
+
+	complaintSLARepo := complaint_sla_rp.NewComplaintSLARepo(DB)
+	complaintSLAUsecase := complaint_sla_uc.NewComplaintSLAUseCase(complaintSLARepo, unitOfWork)
+	ComplaintSLAController := complaint_sla_cl.NewComplaintSLAController(complaintSLAUsecase)
+
+	complaintAssignmentRepo := complaint_assignment_rp.NewComplaintAssignmentRepo(DB)
+	complaintAssignmentUsecase := complaint_assignment_uc.NewComplaintAssignmentUseCase(complaintAssignmentRepo, unitOfWork, roleUsecase)
+	ComplaintAssignmentController := complaint_assignment_cl.NewComplaintAssignmentController(complaintAssignmentUsecase, complaintUsecase, roleUsecase)
+
+	slaCheckInterval, err := time.ParseDuration(os.Getenv("SLA_CHECK_INTERVAL"))
+	if err != nil {
+		slaCheckInterval = time.Hour
+	}
+	scheduler.Every(slaCheckInterval, "complaint sla escalation", complaintSLAUsecase.EscalateOverdue)
+
+	notificationRepo := notification_rp.NewNotificationRepo(DB)
+	emailRepo := email_rp.NewEmailRepo(DB)
+	emailUsecase := email_uc.NewEmailUseCase(emailRepo, mailer, fileStorage.Folder(constants.FolderEvidenceFiles), jobUsecase)
+	EmailController := email_cl.NewEmailController(emailUsecase)
+
+	notificationUsecase := notification_uc.NewQueuedNotificationUseCase(notification_uc.NewNotificationUseCase(notificationRepo, complaintProcessRepo), jobUsecase, emailUsecase)
+	NotificationController := notification_cl.NewNotificationController(notificationUsecase)
+
+	complaintDuplicateRepo := complaint_duplicate_rp.NewComplaintDuplicateRepo(DB)
+	complaintDuplicateUsecase := complaint_duplicate_uc.NewComplaintDuplicateUseCase(complaintDuplicateRepo, complaintRepo, unitOfWork)
+	ComplaintDuplicateController := complaint_duplicate_cl.NewComplaintDuplicateController(complaintUsecase, complaintDuplicateUsecase, notificationUsecase, complaintAssignmentUsecase, roleUsecase, webhookUsecase)
+
+	complaintImportRepo := complaint_import_rp.NewComplaintImportRepo(DB)
+	complaintImportUsecase := complaint_import_uc.NewComplaintImportUseCase(complaintImportRepo, fileStorage.Folder(constants.FolderImports), jobUsecase)
+	ComplaintImportController := complaint_import_cl.NewComplaintImportController(complaintImportUsecase, jobUsecase, roleUsecase)
+
+	ComplaintController := complaint_cl.NewComplaintController(complaintUsecase, complaintFileUsecase, complaintProcessUsecase, notificationUsecase, complaintAssignmentUsecase, roleUsecase, complaintDuplicateUsecase, jobUsecase, fileStorage.Folder(constants.FolderExports), complaintEventUsecase, webhookUsecase)
+	ComplaintProcessController := complaint_process_cl.NewComplaintProcessController(complaintUsecase, complaintProcessUsecase, notificationUsecase, complaintAssignmentUsecase, roleUsecase, complaintEventUsecase, webhookUsecase)
 
 	categoryRepo := category_rp.NewCategoryRepo(DB)
 	categoryUsecase := category_uc.NewCategoryUseCase(categoryRepo)
 	CategoryController := category_cl.NewCategoryController(categoryUsecase)
 
-	regencyRepo := regency_rp.NewRegencyRepo(DB)
 	regencyUsecase := regency_uc.NewRegencyUseCase(regencyRepo)
 	RegencyController := regency_cl.NewRegencyController(regencyUsecase)
 
-	NewsFileGCSAPIInterface := gcs_api.NewFileHandlingAPI(os.Getenv("GCS_CREDENTIALS"), "news-files/")
+	NewsFileStorage := fileStorage.Folder(constants.FolderNewsFiles)
 	NewsFileRepo := news_file_rp.NewNewsFileRepo(DB)
-	NewsFileUsecase := news_file_uc.NewNewsFileUseCase(NewsFileRepo, NewsFileGCSAPIInterface)
+	NewsFileUsecase := news_file_uc.NewNewsFileUseCase(NewsFileRepo, NewsFileStorage)
 
 	newsRepo := news_rp.NewNewsRepo(DB)
-	newsUsecase := news_uc.NewNewsUseCase(newsRepo)
+	newsUsecase := news_uc.NewNewsUseCase(newsRepo, searchEngine)
 	NewsController := news_cl.NewNewsController(newsUsecase, NewsFileUsecase)
 
 	chatRepo := chat_rp.NewChatRepository(DB)
-	chatUsecase := chat_uc.NewChatUseCase(chatRepo)
-	ChatController := chat_cl.NewChatController(chatUsecase)
+	chatUsecase := chat_uc.NewChatUseCase(chatRepo, eventHub)
+	ChatController := chat_cl.NewChatController(chatUsecase, complaintUsecase, roleUsecase)
 
 	unggahBuktiRepo := unggah_bukti_rp.NewUnggahBuktiRepository(DB)
-	unggahBuktiUseCase := unggah_bukti_uc.NewUnggahBuktiUseCase(unggahBuktiRepo)
-	unggahBuktiController := unggah_bukti_cl.NewUnggahBuktiController(unggahBuktiUseCase)
+	unggahBuktiStorage := fileStorage.Folder(constants.FolderEvidenceFiles)
+	unggahBuktiUseCase := unggah_bukti_uc.NewUnggahBuktiUseCase(unggahBuktiRepo, unggahBuktiStorage)
+	unggahBuktiController := unggah_bukti_cl.NewUnggahBuktiController(unggahBuktiUseCase, complaintUsecase, complaintEventUsecase, webhookUsecase, roleUsecase)
+
+	fileURLSecret := os.Getenv("FILE_URL_SECRET")
+	if fileURLSecret == "" {
+		fileURLSecret = os.Getenv("JWT_SECRET")
+	}
+	fileURLTTL, err := time.ParseDuration(os.Getenv("FILE_URL_TTL"))
+	if err != nil {
+		fileURLTTL = 5 * time.Minute
+	}
+	attachmentUsecase := attachment_uc.NewAttachmentUseCase(complaintRepo, unggahBuktiRepo, fileStorage.Folder(""), fileURLSecret, fileURLTTL)
+	AttachmentController := attachment_cl.NewAttachmentController(attachmentUsecase)
 
 	schedule_rp := schedule_rp.NewScheduleRepository(DB)
 	scheduleUsecase := schedule_uc.NewScheduleUseCase(schedule_rp)
@@ -161,19 +298,19 @@ func main() {
 	complaintActivityUsecase := complaint_activity_uc.NewComplaintActivityUseCase(complaintActivityRepo)
 	ComplaintActivityController := complaint_activity.NewComplaintActivityController(complaintActivityUsecase, complaintUsecase)
 
-	openAIAPI := openai_api.NewOpenAIAPI(os.Getenv("OPENAI_API_KEY"))
+	llmProvider := llm.NewLLMProvider(config.InitConfigLLM())
 	faqRepo := faq_rp.NewFaqRepo(DB)
 
 	discussionRepo := discussion_rp.NewDiscussionRepo(DB)
-	discussionUsecase := discussion_uc.NewDiscussionUseCase(discussionRepo, faqRepo, openAIAPI)
-	DiscussionController := discussion_cl.NewDiscussionController(discussionUsecase, complaintUsecase, complaintActivityUsecase)
+	discussionUsecase := discussion_uc.NewDiscussionUseCase(discussionRepo, faqRepo, llmProvider)
+	DiscussionController := discussion_cl.NewDiscussionController(discussionUsecase, complaintUsecase, complaintActivityUsecase, notificationUsecase, complaintEventUsecase, webhookUsecase, roleUsecase)
 
 	complaintLikeRepo := complaint_like_rp.NewComplaintLikeRepository(DB)
 	complaintLikeUsecase := complaint_like_uc.NewComplaintLikeUseCase(complaintLikeRepo)
-	ComplaintLikeController := complaint_like.NewComplaintLikeController(complaintLikeUsecase, complaintUsecase, complaintActivityUsecase)
+	ComplaintLikeController := complaint_like.NewComplaintLikeController(complaintLikeUsecase, complaintUsecase, complaintActivityUsecase, notificationUsecase, complaintEventUsecase)
 
 	chatbotRepo := chatbot_rp.NewChatbotRepo(DB)
-	chatbotUsecase := chatbot_uc.NewChatbotUseCase(chatbotRepo, faqRepo, complaintRepo, openAIAPI)
+	chatbotUsecase := chatbot_uc.NewChatbotUseCase(chatbotRepo, faqRepo, complaintRepo, llmProvider)
 	ChatbotController := chatbot_cl.NewChatbotController(chatbotUsecase)
 
 	newsLikeRepo := news_like_rp.NewNewsLikeRepo(DB)
@@ -188,24 +325,54 @@ func main() {
 	dashboardUsecase := dashboard_uc.NewDashboardUseCase(dashboardRepo)
 	dashboardController := dashboard_cl.NewDashboardController(*dashboardUsecase)
 
+	searchUsecase := search_uc.NewSearchUseCase(searchEngine, complaintRepo)
+	SearchController := search_cl.NewSearchController(searchUsecase, roleUsecase)
+
 	routes := routes.RouteController{
-		AdminController:             AdminController,
-		UserController:              UserController,
-		ComplaintController:         ComplaintController,
-		CategoryController:          CategoryController,
-		ComplaintProcessController:  ComplaintProcessController,
-		DiscussionController:        DiscussionController,
-		NewsController:              NewsController,
-		RegencyController:           RegencyController,
-		ComplaintLikeController:     ComplaintLikeController,
-		NewsLikeController:          NewsLikeController,
-		NewsCommentController:       NewsCommentController,
-		ComplaintActivityController: ComplaintActivityController,
-		ChatbotController:           ChatbotController,
-		DashboardController:         dashboardController,
-		ChatController:              ChatController,
-		UnggahBuktiController:       unggahBuktiController,
-		ScheduleController:          ScheduleController,
+		AdminController:               AdminController,
+		UserController:                UserController,
+		ComplaintController:           ComplaintController,
+		CategoryController:            CategoryController,
+		ComplaintProcessController:    ComplaintProcessController,
+		DiscussionController:          DiscussionController,
+		NewsController:                NewsController,
+		RegencyController:             RegencyController,
+		ComplaintLikeController:       ComplaintLikeController,
+		NewsLikeController:            NewsLikeController,
+		NewsCommentController:         NewsCommentController,
+		ComplaintActivityController:   ComplaintActivityController,
+		ChatbotController:             ChatbotController,
+		DashboardController:           dashboardController,
+		ChatController:                ChatController,
+		UnggahBuktiController:         unggahBuktiController,
+		ScheduleController:            ScheduleController,
+		NotificationController:        NotificationController,
+		EmailController:               EmailController,
+		ComplaintSLAController:        ComplaintSLAController,
+		ComplaintAssignmentController: ComplaintAssignmentController,
+		ComplaintDuplicateController:  ComplaintDuplicateController,
+		ComplaintImportController:     ComplaintImportController,
+		RoleController:                RoleController,
+		PermissionMiddleware:          PermissionMiddleware,
+		SessionController:             SessionController,
+		SessionMiddleware:             SessionMiddleware,
+		AttachmentController:          AttachmentController,
+		SearchController:              SearchController,
+		JobController:                 JobController,
+		WebhookController:             WebhookController,
+	}
+
+	// every handler is registered by now, so the workers can start
+	jobWorkers, err := strconv.Atoi(os.Getenv("JOB_WORKERS"))
+	if err != nil || jobWorkers <= 0 {
+		jobWorkers = 2
+	}
+	jobPollInterval, err := time.ParseDuration(os.Getenv("JOB_POLL_INTERVAL"))
+	if err != nil {
+		jobPollInterval = 2 * time.Second
+	}
+	for i := 0; i < jobWorkers; i++ {
+		scheduler.Every(jobPollInterval, "job worker", jobUsecase.RunOnce)
 	}
 
 	routes.InitRoute(e)
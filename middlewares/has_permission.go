@@ -0,0 +1,42 @@
+package middlewares
+
+import (
+	"e-complaint-api/entities"
+	"e-complaint-api/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+type PermissionMiddleware struct {
+	roleUseCase entities.RoleUseCaseInterface
+}
+
+func NewPermissionMiddleware(roleUseCase entities.RoleUseCaseInterface) *PermissionMiddleware {
+	return &PermissionMiddleware{
+		roleUseCase: roleUseCase,
+	}
+}
+
+// HasPermission only lets the request through when the role of the logged in admin
+// grants the permission.
+func (m *PermissionMiddleware) HasPermission(permission string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			principal, err := utils.GetPrincipal(c)
+			if err != nil {
+				return c.JSON(utils.ConvertResponseCode(err), map[string]interface{}{
+					"message": err.Error(),
+				})
+			}
+
+			err = m.roleUseCase.HasPermission(principal.ID, principal.Role, permission)
+			if err != nil {
+				return c.JSON(utils.ConvertResponseCode(err), map[string]interface{}{
+					"message": err.Error(),
+				})
+			}
+
+			return next(c)
+		}
+	}
+}
@@ -10,8 +10,8 @@ import (
 
 func IsAdmin(next echo.HandlerFunc) echo.HandlerFunc {
 	return func(c echo.Context) error {
-		role, error := utils.GetRoleFromJWT(c)
-		if error != nil || (role != "admin" && role != "super_admin") {
+		principal, err := utils.GetPrincipal(c)
+		if err != nil || (principal.Role != "admin" && principal.Role != "super_admin") {
 			return c.JSON(http.StatusUnauthorized, map[string]interface{}{
 				"message": constants.ErrUnauthorized.Error(),
 			})
@@ -0,0 +1,49 @@
+package middlewares
+
+import (
+	"e-complaint-api/constants"
+	"e-complaint-api/entities"
+	"e-complaint-api/utils"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type SessionMiddleware struct {
+	sessionUseCase entities.SessionUseCaseInterface
+}
+
+func NewSessionMiddleware(sessionUseCase entities.SessionUseCaseInterface) *SessionMiddleware {
+	return &SessionMiddleware{
+		sessionUseCase: sessionUseCase,
+	}
+}
+
+// IsSessionActive rejects access tokens whose session was revoked by a logout or a
+// password change, even when the token itself has not expired yet.
+func (m *SessionMiddleware) IsSessionActive(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		principal, err := utils.GetPrincipal(c)
+		if err != nil {
+			return c.JSON(utils.ConvertResponseCode(err), map[string]interface{}{
+				"message": err.Error(),
+			})
+		}
+
+		// Tokens issued before sessions existed carry no session and are rejected
+		if principal.SessionID == "" {
+			return c.JSON(http.StatusUnauthorized, map[string]interface{}{
+				"message": constants.ErrUnauthorized.Error(),
+			})
+		}
+
+		err = m.sessionUseCase.EnsureActive(principal.SessionID)
+		if err != nil {
+			return c.JSON(utils.ConvertResponseCode(err), map[string]interface{}{
+				"message": err.Error(),
+			})
+		}
+
+		return next(c)
+	}
+}
@@ -10,8 +10,8 @@ import (
 
 func IsUser(next echo.HandlerFunc) echo.HandlerFunc {
 	return func(c echo.Context) error {
-		role, error := utils.GetRoleFromJWT(c)
-		if error != nil || role != "user" {
+		principal, err := utils.GetPrincipal(c)
+		if err != nil || principal.Role != "user" {
 			return c.JSON(http.StatusUnauthorized, map[string]interface{}{
 				"message": constants.ErrUnauthorized.Error(),
 			})
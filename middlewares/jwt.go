@@ -1,24 +1,31 @@
 package middlewares
 
 import (
+	"e-complaint-api/entities"
+	"e-complaint-api/utils"
 	"os"
+	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	echojwt "github.com/labstack/echo-jwt"
+	"github.com/labstack/echo/v4"
 )
 
 type jwtCustomClaims struct {
-	ID    int    `json:"id"`
-	Name  string `json:"name"`
-	Email string `json:"email"`
-	Role  string `json:"role"`
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	Email     string `json:"email"`
+	Role      string `json:"role"`
+	SessionID string `json:"sid"`
 	jwt.RegisteredClaims
 }
 
-func GenerateTokenJWT(userId int, name string, email string, userRole string) string {
+func GenerateTokenJWT(userId int, name string, email string, userRole string, sessionID string, expiresAt time.Time) string {
 	var userClaims = jwtCustomClaims{
-		userId, name, email, userRole,
+		userId, name, email, userRole, sessionID,
 		jwt.RegisteredClaims{
-			// ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour * 24)),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
 	}
 
@@ -27,3 +34,39 @@ func GenerateTokenJWT(userId int, name string, email string, userRole string) st
 	resultJWT, _ := token.SignedString([]byte(os.Getenv("JWT_SECRET")))
 	return resultJWT
 }
+
+// JWT verifies the signature and expiry of the access token and stores its claims as
+// the principal of the request. Tokens are parsed here because echo-jwt still uses
+// an older version of the jwt library than the one the tokens are issued with.
+func JWT() echo.MiddlewareFunc {
+	return jwtMiddleware("header:Authorization:Bearer ")
+}
+
+// JWTWithQuery is JWT for the streams browsers open without setting headers, such as
+// WebSockets. It also accepts the access token as the token query param.
+func JWTWithQuery() echo.MiddlewareFunc {
+	return jwtMiddleware("header:Authorization:Bearer ,query:token")
+}
+
+func jwtMiddleware(tokenLookup string) echo.MiddlewareFunc {
+	secret := []byte(os.Getenv("JWT_SECRET"))
+
+	return echojwt.WithConfig(echojwt.Config{
+		TokenLookup: tokenLookup,
+		ParseTokenFunc: func(c echo.Context, auth string) (interface{}, error) {
+			return jwt.ParseWithClaims(auth, new(jwtCustomClaims), func(token *jwt.Token) (interface{}, error) {
+				return secret, nil
+			}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+		},
+		SuccessHandler: func(c echo.Context) {
+			claims := c.Get("user").(*jwt.Token).Claims.(*jwtCustomClaims)
+			utils.SetPrincipal(c, entities.Principal{
+				ID:        claims.ID,
+				Name:      claims.Name,
+				Email:     claims.Email,
+				Role:      claims.Role,
+				SessionID: claims.SessionID,
+			})
+		},
+	})
+}
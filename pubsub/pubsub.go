@@ -0,0 +1,64 @@
+package pubsub
+
+import (
+	"e-complaint-api/entities"
+	"sync"
+)
+
+// Hub is an in-process entities.PubSubInterface. It only reaches subscribers of the
+// same process, so it has to be replaced by a broker once the API runs on more than
+// one instance.
+type Hub struct {
+	mu          sync.RWMutex
+	buffer      int
+	subscribers map[string]map[chan entities.Event]struct{}
+}
+
+// NewHub returns a hub whose subscribers may fall buffer events behind. Events that
+// do not fit in the buffer of a subscriber are dropped for that subscriber, so a slow
+// subscriber never blocks the publisher.
+func NewHub(buffer int) *Hub {
+	return &Hub{
+		buffer:      buffer,
+		subscribers: map[string]map[chan entities.Event]struct{}{},
+	}
+}
+
+func (h *Hub) Publish(topic string, event entities.Event) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for subscriber := range h.subscribers[topic] {
+		select {
+		case subscriber <- event:
+		default:
+		}
+	}
+}
+
+func (h *Hub) Subscribe(topic string) (<-chan entities.Event, func()) {
+	subscriber := make(chan entities.Event, h.buffer)
+
+	h.mu.Lock()
+	if h.subscribers[topic] == nil {
+		h.subscribers[topic] = map[chan entities.Event]struct{}{}
+	}
+	h.subscribers[topic][subscriber] = struct{}{}
+	h.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			h.mu.Lock()
+			defer h.mu.Unlock()
+
+			delete(h.subscribers[topic], subscriber)
+			if len(h.subscribers[topic]) == 0 {
+				delete(h.subscribers, topic)
+			}
+			close(subscriber)
+		})
+	}
+
+	return subscriber, unsubscribe
+}
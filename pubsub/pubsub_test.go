@@ -0,0 +1,62 @@
+package pubsub
+
+import (
+	"e-complaint-api/entities"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublish(t *testing.T) {
+	t.Run("delivered to the subscribers of the topic", func(t *testing.T) {
+		hub := NewHub(1)
+		first, unsubscribeFirst := hub.Subscribe("chat.room.1")
+		defer unsubscribeFirst()
+		second, unsubscribeSecond := hub.Subscribe("chat.room.1")
+		defer unsubscribeSecond()
+		other, unsubscribeOther := hub.Subscribe("chat.room.2")
+		defer unsubscribeOther()
+
+		hub.Publish("chat.room.1", entities.Event{Type: "message", Data: "halo"})
+
+		assert.Equal(t, entities.Event{Type: "message", Data: "halo"}, <-first)
+		assert.Equal(t, entities.Event{Type: "message", Data: "halo"}, <-second)
+		assert.Len(t, other, 0)
+	})
+
+	t.Run("dropped when the buffer is full", func(t *testing.T) {
+		hub := NewHub(1)
+		events, unsubscribe := hub.Subscribe("chat.room.1")
+		defer unsubscribe()
+
+		hub.Publish("chat.room.1", entities.Event{Type: "typing"})
+		hub.Publish("chat.room.1", entities.Event{Type: "message"})
+
+		assert.Equal(t, entities.Event{Type: "typing"}, <-events)
+		assert.Len(t, events, 0)
+	})
+
+	t.Run("without subscribers", func(t *testing.T) {
+		hub := NewHub(1)
+		hub.Publish("chat.room.1", entities.Event{Type: "message"})
+	})
+}
+
+func TestSubscribe(t *testing.T) {
+	t.Run("unsubscribe closes the channel once", func(t *testing.T) {
+		hub := NewHub(1)
+		events, unsubscribe := hub.Subscribe("chat.room.1")
+		_, unsubscribeOther := hub.Subscribe("chat.room.1")
+
+		unsubscribe()
+		unsubscribe()
+		_, open := <-events
+		assert.False(t, open)
+		assert.Len(t, hub.subscribers["chat.room.1"], 1)
+
+		unsubscribeOther()
+		assert.NotContains(t, hub.subscribers, "chat.room.1")
+
+		hub.Publish("chat.room.1", entities.Event{Type: "message"})
+	})
+}
@@ -0,0 +1,283 @@
+package queryspec
+
+import (
+	"e-complaint-api/constants"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm/clause"
+)
+
+// Relevance sorts the hits of a full-text search best match first.
+const Relevance = "relevance"
+
+// dateLayout is the layout of the date range query parameters.
+const dateLayout = "2006-01-02"
+
+type Kind int
+
+const (
+	// Text matches the column against the value as is.
+	Text Kind = iota
+	// Integer matches the column against the value parsed as an integer.
+	Integer
+	// List matches the column against any of the comma separated values.
+	List
+	// DateRange reads <param>_from and <param>_to, both inclusive dates.
+	DateRange
+	// IntegerRange reads <param>_min and <param>_max, both inclusive.
+	IntegerRange
+)
+
+// Filter maps a query parameter to the column it filters on.
+type Filter struct {
+	Param  string
+	Column string
+	Kind   Kind
+	// Values whitelists the values of Text and List filters, any value is allowed when
+	// it is empty.
+	Values []string
+	// AdminOnly filters may not be used by users, e.g. because they would tell who is
+	// behind a private complaint.
+	AdminOnly bool
+}
+
+// Range keeps the rows with From <= column < To. A nil bound is left open.
+type Range struct {
+	From interface{}
+	To   interface{}
+}
+
+// Where returns the condition of the range on column.
+func (r Range) Where(column string) (string, []interface{}) {
+	conditions := []string{}
+	vars := []interface{}{}
+	if r.From != nil {
+		conditions = append(conditions, column+" >= ?")
+		vars = append(vars, r.From)
+	}
+	if r.To != nil {
+		conditions = append(conditions, column+" < ?")
+		vars = append(vars, r.To)
+	}
+
+	return strings.Join(conditions, " AND "), vars
+}
+
+// After returns the condition that keeps the rows sorting after the row with id and
+// value in column, in the order of sortType. Ties on column are broken by ID. The column
+// is quoted as an identifier, the values are bound.
+func After(column string, value interface{}, id interface{}, sortType string) clause.Expression {
+	beyond := func(column string, value interface{}) clause.Expression {
+		if strings.EqualFold(sortType, "ASC") {
+			return clause.Gt{Column: clause.Column{Name: column}, Value: value}
+		}
+		return clause.Lt{Column: clause.Column{Name: column}, Value: value}
+	}
+
+	return clause.Or(
+		beyond(column, value),
+		clause.And(clause.Eq{Column: clause.Column{Name: column}, Value: value}, beyond("id", id)),
+	)
+}
+
+// Spec lists the filters and sort columns a listing accepts. Only the columns listed
+// here ever end up in a query, whatever the client sends.
+type Spec struct {
+	Filters     []Filter
+	Sorts       []string
+	DefaultSort string
+}
+
+// Parse turns the query parameters into a filter keyed by column. Parameters that are
+// not in the spec are ignored.
+func (s Spec) Parse(params url.Values) (map[string]interface{}, error) {
+	filter := map[string]interface{}{}
+	for _, f := range s.Filters {
+		value, ok, err := f.parse(params)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			filter[f.Column] = value
+		}
+	}
+
+	return filter, nil
+}
+
+// ParseFor is Parse for a principal with role. Users get constants.ErrFilterNotAllowed
+// when they send an AdminOnly filter.
+func (s Spec) ParseFor(params url.Values, role string) (map[string]interface{}, error) {
+	if role == "user" {
+		for _, f := range s.Filters {
+			if f.AdminOnly && f.given(params) {
+				return nil, constants.ErrFilterNotAllowed
+			}
+		}
+	}
+
+	return s.Parse(params)
+}
+
+// given tells whether any query parameter of the filter is set.
+func (f Filter) given(params url.Values) bool {
+	switch f.Kind {
+	case DateRange:
+		return params.Get(f.Param+"_from") != "" || params.Get(f.Param+"_to") != ""
+	case IntegerRange:
+		return params.Get(f.Param+"_min") != "" || params.Get(f.Param+"_max") != ""
+	default:
+		return strings.TrimSpace(params.Get(f.Param)) != ""
+	}
+}
+
+func (f Filter) parse(params url.Values) (interface{}, bool, error) {
+	switch f.Kind {
+	case Integer:
+		value := strings.TrimSpace(params.Get(f.Param))
+		if value == "" {
+			return nil, false, nil
+		}
+		number, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, false, constants.ErrInvalidFilterValue
+		}
+		return number, true, nil
+	case List:
+		values := []string{}
+		for _, value := range strings.Split(params.Get(f.Param), ",") {
+			if value = strings.TrimSpace(value); value == "" {
+				continue
+			}
+			if !f.allows(value) {
+				return nil, false, constants.ErrInvalidFilterValue
+			}
+			values = append(values, value)
+		}
+		return values, len(values) > 0, nil
+	case DateRange:
+		return parseRange(params.Get(f.Param+"_from"), params.Get(f.Param+"_to"), parseDate)
+	case IntegerRange:
+		return parseRange(params.Get(f.Param+"_min"), params.Get(f.Param+"_max"), parseInteger)
+	default:
+		value := strings.TrimSpace(params.Get(f.Param))
+		if value == "" {
+			return nil, false, nil
+		}
+		if !f.allows(value) {
+			return nil, false, constants.ErrInvalidFilterValue
+		}
+		return value, true, nil
+	}
+}
+
+func (f Filter) allows(value string) bool {
+	if len(f.Values) == 0 {
+		return true
+	}
+
+	for _, allowed := range f.Values {
+		if value == allowed {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseRange parses both inclusive bounds of a range. The upper bound is turned into the
+// exclusive bound that follows it.
+func parseRange(min string, max string, parse func(string, bool) (interface{}, error)) (interface{}, bool, error) {
+	min, max = strings.TrimSpace(min), strings.TrimSpace(max)
+	if min == "" && max == "" {
+		return nil, false, nil
+	}
+
+	var r Range
+	var err error
+	if min != "" {
+		if r.From, err = parse(min, false); err != nil {
+			return nil, false, err
+		}
+	}
+	if max != "" {
+		if r.To, err = parse(max, true); err != nil {
+			return nil, false, err
+		}
+	}
+
+	if min != "" && max != "" && !less(r.From, r.To) {
+		return nil, false, constants.ErrInvalidRange
+	}
+
+	return r, true, nil
+}
+
+func parseDate(value string, upper bool) (interface{}, error) {
+	date, err := time.ParseInLocation(dateLayout, value, time.Local)
+	if err != nil {
+		return nil, constants.ErrInvalidFilterValue
+	}
+	if upper {
+		date = date.AddDate(0, 0, 1)
+	}
+
+	return date, nil
+}
+
+func parseInteger(value string, upper bool) (interface{}, error) {
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, constants.ErrInvalidFilterValue
+	}
+	if upper {
+		number++
+	}
+
+	return number, nil
+}
+
+func less(from interface{}, to interface{}) bool {
+	switch from := from.(type) {
+	case time.Time:
+		return from.Before(to.(time.Time))
+	default:
+		return from.(int) < to.(int)
+	}
+}
+
+// Sort validates sortBy and sortType against the spec. An empty sortBy sorts by
+// relevance when there are search hits and by the default column otherwise, an empty
+// sortType sorts descending.
+func (s Spec) Sort(sortBy string, sortType string, hits bool) (string, string, error) {
+	switch {
+	case sortBy == "" && hits:
+		sortBy = Relevance
+	case sortBy == "" || sortBy == Relevance && !hits:
+		sortBy = s.DefaultSort
+	case sortBy != Relevance && !s.sortable(sortBy):
+		return "", "", constants.ErrInvalidSortBy
+	}
+
+	switch strings.ToUpper(sortType) {
+	case "", "DESC":
+		return sortBy, "DESC", nil
+	case "ASC":
+		return sortBy, "ASC", nil
+	default:
+		return "", "", constants.ErrInvalidSortType
+	}
+}
+
+func (s Spec) sortable(sortBy string) bool {
+	for _, column := range s.Sorts {
+		if sortBy == column {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,192 @@
+package queryspec
+
+import (
+	"e-complaint-api/constants"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/utils/tests"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.Local)
+}
+
+func TestParse(t *testing.T) {
+	t.Run("success all filters", func(t *testing.T) {
+		params, _ := url.ParseQuery("regency_id=3601&category_id=2&user_id=7&status=Pending,%20Verifikasi&type=public" +
+			"&date_from=2024-01-01&date_to=2024-01-31&created_at_to=2024-02-01&total_likes_min=10&total_likes_max=20&unknown=1")
+
+		filter, err := Complaints.Parse(params)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{
+			"regency_id":  "3601",
+			"category_id": 2,
+			"user_id":     7,
+			"status":      []string{"Pending", "Verifikasi"},
+			"type":        []string{"public"},
+			"date":        Range{From: date(2024, time.January, 1), To: date(2024, time.February, 1)},
+			"created_at":  Range{To: date(2024, time.February, 2)},
+			"total_likes": Range{From: 10, To: 21},
+		}, filter)
+	})
+
+	t.Run("success no filters", func(t *testing.T) {
+		filter, err := News.Parse(url.Values{"status": {"Pending"}, "category_id": {""}})
+		assert.NoError(t, err)
+		assert.Empty(t, filter)
+	})
+
+	t.Run("success single day range", func(t *testing.T) {
+		filter, err := News.Parse(url.Values{"created_at_from": {"2024-03-05"}, "created_at_to": {"2024-03-05"}})
+		assert.NoError(t, err)
+		assert.Equal(t, Range{From: date(2024, time.March, 5), To: date(2024, time.March, 6)}, filter["created_at"])
+	})
+
+	t.Run("success empty list", func(t *testing.T) {
+		filter, err := Complaints.Parse(url.Values{"status": {" , "}})
+		assert.NoError(t, err)
+		assert.Empty(t, filter)
+	})
+
+	t.Run("failed invalid integer", func(t *testing.T) {
+		_, err := Complaints.Parse(url.Values{"category_id": {"abc"}})
+		assert.Equal(t, constants.ErrInvalidFilterValue, err)
+	})
+
+	t.Run("failed status not in workflow", func(t *testing.T) {
+		_, err := Complaints.Parse(url.Values{"status": {"Pending,Hilang"}})
+		assert.Equal(t, constants.ErrInvalidFilterValue, err)
+	})
+
+	t.Run("failed text not whitelisted", func(t *testing.T) {
+		spec := Spec{Filters: []Filter{{Param: "level", Column: "level", Kind: Text, Values: []string{"high"}}}}
+		_, err := spec.Parse(url.Values{"level": {"low"}})
+		assert.Equal(t, constants.ErrInvalidFilterValue, err)
+	})
+
+	t.Run("failed invalid date", func(t *testing.T) {
+		_, err := Complaints.Parse(url.Values{"date_from": {"01-01-2024"}})
+		assert.Equal(t, constants.ErrInvalidFilterValue, err)
+
+		_, err = Complaints.Parse(url.Values{"date_to": {"2024-13-01"}})
+		assert.Equal(t, constants.ErrInvalidFilterValue, err)
+	})
+
+	t.Run("failed invalid integer range", func(t *testing.T) {
+		_, err := Complaints.Parse(url.Values{"total_likes_min": {"many"}})
+		assert.Equal(t, constants.ErrInvalidFilterValue, err)
+	})
+
+	t.Run("failed range start after end", func(t *testing.T) {
+		_, err := Complaints.Parse(url.Values{"date_from": {"2024-02-01"}, "date_to": {"2024-01-01"}})
+		assert.Equal(t, constants.ErrInvalidRange, err)
+
+		_, err = Complaints.Parse(url.Values{"total_likes_min": {"20"}, "total_likes_max": {"10"}})
+		assert.Equal(t, constants.ErrInvalidRange, err)
+	})
+}
+
+func TestParseFor(t *testing.T) {
+	t.Run("success admin", func(t *testing.T) {
+		filter, err := Complaints.ParseFor(url.Values{"user_id": {"7"}, "type": {"private"}}, "admin")
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"user_id": 7, "type": []string{"private"}}, filter)
+	})
+
+	t.Run("success user without admin only filters", func(t *testing.T) {
+		filter, err := Complaints.ParseFor(url.Values{"type": {"private"}, "user_id": {" "}}, "user")
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"type": []string{"private"}}, filter)
+	})
+
+	t.Run("failed user filters by user", func(t *testing.T) {
+		_, err := Complaints.ParseFor(url.Values{"user_id": {"7"}, "type": {"private"}}, "user")
+		assert.Equal(t, constants.ErrFilterNotAllowed, err)
+	})
+
+	t.Run("failed user filters on admin only range", func(t *testing.T) {
+		spec := Spec{Filters: []Filter{
+			{Param: "created_at", Column: "created_at", Kind: DateRange, AdminOnly: true},
+			{Param: "total_likes", Column: "total_likes", Kind: IntegerRange, AdminOnly: true},
+		}}
+
+		_, err := spec.ParseFor(url.Values{"created_at_to": {"2024-01-01"}}, "user")
+		assert.Equal(t, constants.ErrFilterNotAllowed, err)
+
+		_, err = spec.ParseFor(url.Values{"total_likes_min": {"1"}}, "user")
+		assert.Equal(t, constants.ErrFilterNotAllowed, err)
+	})
+}
+
+func TestRangeWhere(t *testing.T) {
+	condition, vars := Range{From: 10, To: 21}.Where("total_likes")
+	assert.Equal(t, "total_likes >= ? AND total_likes < ?", condition)
+	assert.Equal(t, []interface{}{10, 21}, vars)
+
+	condition, vars = Range{To: 21}.Where("total_likes")
+	assert.Equal(t, "total_likes < ?", condition)
+	assert.Equal(t, []interface{}{21}, vars)
+}
+
+func TestAfter(t *testing.T) {
+	db, _ := gorm.Open(tests.DummyDialector{}, &gorm.Config{DryRun: true})
+	sql := func(condition clause.Expression) (string, []interface{}) {
+		statement := db.Table("complaints").Where(condition).Find(&[]map[string]interface{}{}).Statement
+		return statement.SQL.String(), statement.Vars
+	}
+
+	query, vars := sql(After("total_likes", "3", "C-2", "DESC"))
+	assert.Equal(t, "SELECT * FROM `complaints` WHERE (`total_likes` < ? OR (`total_likes` = ? AND `id` < ?))", query)
+	assert.Equal(t, []interface{}{"3", "3", "C-2"}, vars)
+
+	query, _ = sql(After("created_at`; DROP TABLE complaints; --", "3", "C-2", "ASC"))
+	assert.Contains(t, query, "`created_at``; DROP TABLE complaints; --` > ?")
+	assert.Contains(t, query, "`id` > ?")
+}
+
+func TestSort(t *testing.T) {
+	t.Run("success default", func(t *testing.T) {
+		sortBy, sortType, err := Complaints.Sort("", "", false)
+		assert.NoError(t, err)
+		assert.Equal(t, "created_at", sortBy)
+		assert.Equal(t, "DESC", sortType)
+	})
+
+	t.Run("success relevance with hits", func(t *testing.T) {
+		sortBy, _, err := Complaints.Sort("", "", true)
+		assert.NoError(t, err)
+		assert.Equal(t, Relevance, sortBy)
+
+		sortBy, _, err = News.Sort(Relevance, "", true)
+		assert.NoError(t, err)
+		assert.Equal(t, Relevance, sortBy)
+	})
+
+	t.Run("success relevance without hits", func(t *testing.T) {
+		sortBy, _, err := Complaints.Sort(Relevance, "", false)
+		assert.NoError(t, err)
+		assert.Equal(t, "created_at", sortBy)
+	})
+
+	t.Run("success whitelisted column", func(t *testing.T) {
+		sortBy, sortType, err := Complaints.Sort("total_likes", "asc", false)
+		assert.NoError(t, err)
+		assert.Equal(t, "total_likes", sortBy)
+		assert.Equal(t, "ASC", sortType)
+	})
+
+	t.Run("failed column not whitelisted", func(t *testing.T) {
+		_, _, err := Complaints.Sort("created_at; DROP TABLE complaints", "", false)
+		assert.Equal(t, constants.ErrInvalidSortBy, err)
+	})
+
+	t.Run("failed invalid sort type", func(t *testing.T) {
+		_, _, err := News.Sort("title", "desc, id", false)
+		assert.Equal(t, constants.ErrInvalidSortType, err)
+	})
+}
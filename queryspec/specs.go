@@ -0,0 +1,32 @@
+package queryspec
+
+import "e-complaint-api/workflow"
+
+// Complaints is the spec of the complaint listing, e.g.
+// ?status=Pending,Verifikasi&date_from=2024-01-01&total_likes_min=10&sort_by=total_likes
+var Complaints = Spec{
+	Filters: []Filter{
+		{Param: "regency_id", Column: "regency_id", Kind: Text},
+		{Param: "category_id", Column: "category_id", Kind: Integer},
+		{Param: "user_id", Column: "user_id", Kind: Integer, AdminOnly: true},
+		{Param: "status", Column: "status", Kind: List, Values: workflow.Complaint.Statuses()},
+		{Param: "type", Column: "type", Kind: List, Values: []string{"public", "private"}},
+		{Param: "date", Column: "date", Kind: DateRange},
+		{Param: "created_at", Column: "created_at", Kind: DateRange},
+		{Param: "total_likes", Column: "total_likes", Kind: IntegerRange},
+	},
+	Sorts:       []string{"created_at", "updated_at", "date", "total_likes", "status"},
+	DefaultSort: "created_at",
+}
+
+// News is the spec of the news listing.
+var News = Spec{
+	Filters: []Filter{
+		{Param: "category_id", Column: "category_id", Kind: Integer},
+		{Param: "admin_id", Column: "admin_id", Kind: Integer},
+		{Param: "created_at", Column: "created_at", Kind: DateRange},
+		{Param: "total_likes", Column: "total_likes", Kind: IntegerRange},
+	},
+	Sorts:       []string{"created_at", "updated_at", "title", "total_likes"},
+	DefaultSort: "created_at",
+}
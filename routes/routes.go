@@ -1,86 +1,148 @@
 package routes
 
 import (
+	"e-complaint-api/constants"
 	"e-complaint-api/controllers/admin"
+	"e-complaint-api/controllers/attachment"
 	"e-complaint-api/controllers/category"
 	"e-complaint-api/controllers/chat"
 	"e-complaint-api/controllers/chatbot"
 	"e-complaint-api/controllers/complaint"
 	"e-complaint-api/controllers/complaint_activity"
+	"e-complaint-api/controllers/complaint_assignment"
+	"e-complaint-api/controllers/complaint_duplicate"
+	"e-complaint-api/controllers/complaint_import"
 	complaint_like "e-complaint-api/controllers/complaint_like"
 	"e-complaint-api/controllers/complaint_process"
+	"e-complaint-api/controllers/complaint_sla"
 	dashboard "e-complaint-api/controllers/dashboard"
 	"e-complaint-api/controllers/discussion"
+	"e-complaint-api/controllers/email"
+	"e-complaint-api/controllers/job"
 	"e-complaint-api/controllers/news"
 	"e-complaint-api/controllers/news_comment"
 	"e-complaint-api/controllers/news_like"
+	"e-complaint-api/controllers/notification"
 	"e-complaint-api/controllers/regency"
+	"e-complaint-api/controllers/role"
 	"e-complaint-api/controllers/schedule"
+	"e-complaint-api/controllers/search"
+	"e-complaint-api/controllers/session"
 	"e-complaint-api/controllers/unggah_bukti"
 	"e-complaint-api/controllers/user"
+	"e-complaint-api/controllers/webhook"
 	"e-complaint-api/middlewares"
-	"os"
-
-	echojwt "github.com/labstack/echo-jwt"
 
 	"github.com/labstack/echo/v4"
 )
 
 type RouteController struct {
-	AdminController             *admin.AdminController
-	UserController              *user.UserController
-	ComplaintController         *complaint.ComplaintController
-	CategoryController          *category.CategoryController
-	ComplaintProcessController  *complaint_process.ComplaintProcessController
-	DiscussionController        *discussion.DiscussionController
-	NewsController              *news.NewsController
-	RegencyController           *regency.RegencyController
-	ComplaintLikeController     *complaint_like.ComplaintLikeController
-	NewsLikeController          *news_like.NewsLikeController
-	NewsCommentController       *news_comment.NewsCommentController
-	ComplaintActivityController *complaint_activity.ComplaintActivityController
-	ChatbotController           *chatbot.ChatbotController
-	DashboardController         *dashboard.DashboardController
-	ChatController              *chat.ChatController
-	UnggahBuktiController       *unggah_bukti.UnggahBuktiController
-	ScheduleController          *schedule.ScheduleController
+	AdminController               *admin.AdminController
+	UserController                *user.UserController
+	ComplaintController           *complaint.ComplaintController
+	CategoryController            *category.CategoryController
+	ComplaintProcessController    *complaint_process.ComplaintProcessController
+	DiscussionController          *discussion.DiscussionController
+	NewsController                *news.NewsController
+	RegencyController             *regency.RegencyController
+	ComplaintLikeController       *complaint_like.ComplaintLikeController
+	NewsLikeController            *news_like.NewsLikeController
+	NewsCommentController         *news_comment.NewsCommentController
+	ComplaintActivityController   *complaint_activity.ComplaintActivityController
+	ChatbotController             *chatbot.ChatbotController
+	DashboardController           *dashboard.DashboardController
+	ChatController                *chat.ChatController
+	UnggahBuktiController         *unggah_bukti.UnggahBuktiController
+	ScheduleController            *schedule.ScheduleController
+	NotificationController        *notification.NotificationController
+	EmailController               *email.EmailController
+	ComplaintSLAController        *complaint_sla.ComplaintSLAController
+	ComplaintAssignmentController *complaint_assignment.ComplaintAssignmentController
+	ComplaintDuplicateController  *complaint_duplicate.ComplaintDuplicateController
+	ComplaintImportController     *complaint_import.ComplaintImportController
+	RoleController                *role.RoleController
+	PermissionMiddleware          *middlewares.PermissionMiddleware
+	SessionController             *session.SessionController
+	SessionMiddleware             *middlewares.SessionMiddleware
+	AttachmentController          *attachment.AttachmentController
+	SearchController              *search.SearchController
+	JobController                 *job.JobController
+	WebhookController             *webhook.WebhookController
 }
 
 func (r *RouteController) InitRoute(e *echo.Echo) {
-	var jwt = echojwt.JWT([]byte(os.Getenv("JWT_SECRET")))
+	var jwt = middlewares.JWT()
+	var isSessionActive = r.SessionMiddleware.IsSessionActive
 
 	// Route For Super Admin
 	superAdmin := e.Group("/api/v1")
-	superAdmin.Use(jwt, middlewares.IsSuperAdmin)
+	superAdmin.Use(jwt, isSessionActive, middlewares.IsSuperAdmin)
 	superAdmin.POST("/admins", r.AdminController.CreateAccount)
 	superAdmin.DELETE("/admins/:id", r.AdminController.DeleteAdmin)
 	superAdmin.PUT("/admins/:id", r.AdminController.UpdateAdmin)
+	superAdmin.POST("/complaint-slas", r.ComplaintSLAController.Create)
+	superAdmin.PUT("/complaint-slas/:id", r.ComplaintSLAController.Update)
+	superAdmin.DELETE("/complaint-slas/:id", r.ComplaintSLAController.Delete)
+	superAdmin.GET("/assignment-pools", r.ComplaintAssignmentController.GetPools)
+	superAdmin.POST("/assignment-pools", r.ComplaintAssignmentController.CreatePool)
+	superAdmin.DELETE("/assignment-pools/:id", r.ComplaintAssignmentController.DeletePool)
+	superAdmin.GET("/roles", r.RoleController.GetAll)
+	superAdmin.GET("/roles/:id", r.RoleController.GetByID)
+	superAdmin.POST("/roles", r.RoleController.Create)
+	superAdmin.PUT("/roles/:id", r.RoleController.Update)
+	superAdmin.DELETE("/roles/:id", r.RoleController.Delete)
+	superAdmin.GET("/permissions", r.RoleController.GetPermissions)
+	superAdmin.GET("/admins/:id/access", r.RoleController.GetAdminAccess)
+	superAdmin.PUT("/admins/:id/access", r.RoleController.UpdateAdminAccess)
 
 	// Route For Admin & Super Admin
+	can := r.PermissionMiddleware.HasPermission
 	admin := e.Group("/api/v1")
 	admin.POST("/admins/login", r.AdminController.Login)
-	admin.Use(jwt, middlewares.IsAdmin)
-	admin.GET("/admins", r.AdminController.GetAllAdmins)
-	admin.GET("/admins/:id", r.AdminController.GetAdminByID)
-	admin.GET("/users", r.UserController.GetAllUsers)
-	admin.POST("/complaints/:complaint-id/processes", r.ComplaintProcessController.Create)
-	admin.PUT("/complaints/:complaint-id/processes/:process-id", r.ComplaintProcessController.Update)
-	admin.POST("/categories", r.CategoryController.CreateCategory)
-	admin.PUT("/categories/:id", r.CategoryController.UpdateCategory)
-	admin.DELETE("/categories/:id", r.CategoryController.DeleteCategory)
-	admin.DELETE("/complaints/:complaint-id/processes/:process-id", r.ComplaintProcessController.Delete)
-	admin.POST("/news", r.NewsController.Create)
-	admin.DELETE("/news/:id", r.NewsController.Delete)
-	admin.PUT("/news/:id", r.NewsController.Update)
-	admin.POST("/complaints/import", r.ComplaintController.Import)
-	admin.GET("/complaints/:complaint-id/discussions/get-recommendation", r.DiscussionController.GetAnswerRecommendation)
-	admin.GET("/admins/dashboard", r.DashboardController.GetDashboardData)
-
-	admin.GET("/schedules", r.ScheduleController.GetAll)      // Menampilkan semua jadwal
-	admin.GET("/schedules/:id", r.ScheduleController.GetByID) // Menampilkan jadwal berdasarkan ID
-	admin.POST("/schedules", r.ScheduleController.Create)     // Menambahkan jadwal
-	admin.PUT("/schedules/:id", r.ScheduleController.Update)  // Memperbarui jadwal berdasarkan ID
-	admin.DELETE("/schedules/:id", r.ScheduleController.Delete)
+	admin.Use(jwt, isSessionActive, middlewares.IsAdmin)
+	admin.GET("/admins", r.AdminController.GetAllAdmins, can(constants.PermissionAdminRead))
+	admin.GET("/admins/:id", r.AdminController.GetAdminByID, can(constants.PermissionAdminRead))
+	admin.GET("/users", r.UserController.GetAllUsers, can(constants.PermissionUserRead))
+	admin.POST("/complaints/:complaint-id/processes", r.ComplaintProcessController.Create, can(constants.PermissionComplaintProcess))
+	admin.PUT("/complaints/:complaint-id/processes/:process-id", r.ComplaintProcessController.Update, can(constants.PermissionComplaintProcess))
+	admin.POST("/categories", r.CategoryController.CreateCategory, can(constants.PermissionCategoryManage))
+	admin.PUT("/categories/:id", r.CategoryController.UpdateCategory, can(constants.PermissionCategoryManage))
+	admin.DELETE("/categories/:id", r.CategoryController.DeleteCategory, can(constants.PermissionCategoryManage))
+	admin.DELETE("/complaints/:complaint-id/processes/:process-id", r.ComplaintProcessController.Delete, can(constants.PermissionComplaintProcess))
+	admin.POST("/news", r.NewsController.Create, can(constants.PermissionNewsManage))
+	admin.DELETE("/news/:id", r.NewsController.Delete, can(constants.PermissionNewsManage))
+	admin.PUT("/news/:id", r.NewsController.Update, can(constants.PermissionNewsManage))
+	admin.POST("/complaints/import", r.ComplaintImportController.Import, can(constants.PermissionComplaintImport))
+	admin.GET("/complaints/import/:id", r.ComplaintImportController.GetJob, can(constants.PermissionComplaintImport))
+	admin.GET("/complaints/export", r.ComplaintController.Export, can(constants.PermissionComplaintExport))
+	admin.POST("/complaints/exports", r.ComplaintController.EnqueueExport, can(constants.PermissionComplaintExport))
+	admin.GET("/complaints/exports/:id", r.ComplaintController.GetExportJob, can(constants.PermissionComplaintExport))
+	admin.GET("/complaints/exports/:id/file", r.ComplaintController.DownloadExport, can(constants.PermissionComplaintExport))
+	admin.GET("/jobs", r.JobController.GetAll, can(constants.PermissionJobManage))
+	admin.GET("/jobs/:id", r.JobController.GetByID, can(constants.PermissionJobManage))
+	admin.POST("/jobs/:id/retry", r.JobController.Retry, can(constants.PermissionJobManage))
+	admin.POST("/webhooks", r.WebhookController.Create, can(constants.PermissionWebhookManage))
+	admin.GET("/webhooks", r.WebhookController.GetAll, can(constants.PermissionWebhookManage))
+	admin.GET("/webhooks/:id", r.WebhookController.GetByID, can(constants.PermissionWebhookManage))
+	admin.PUT("/webhooks/:id", r.WebhookController.Update, can(constants.PermissionWebhookManage))
+	admin.DELETE("/webhooks/:id", r.WebhookController.Delete, can(constants.PermissionWebhookManage))
+	admin.GET("/webhooks/:id/deliveries", r.WebhookController.GetDeliveries, can(constants.PermissionWebhookManage))
+	admin.POST("/webhooks/:id/deliveries/:delivery-id/replay", r.WebhookController.Replay, can(constants.PermissionWebhookManage))
+	admin.GET("/complaints/:complaint-id/discussions/get-recommendation", r.DiscussionController.GetAnswerRecommendation, can(constants.PermissionComplaintProcess))
+	admin.GET("/admins/dashboard", r.DashboardController.GetDashboardData, can(constants.PermissionDashboardRead))
+	admin.GET("/complaints/geojson", r.ComplaintController.GetGeoJSON, can(constants.PermissionDashboardRead))
+	admin.GET("/complaint-slas", r.ComplaintSLAController.GetAll, can(constants.PermissionComplaintProcess))
+	admin.PUT("/complaints/:complaint-id/assignee", r.ComplaintAssignmentController.Assign, can(constants.PermissionComplaintAssign))
+	admin.GET("/complaints/:complaint-id/assignments", r.ComplaintAssignmentController.GetHistory, can(constants.PermissionComplaintAssign))
+	admin.GET("/complaints/:complaint-id/similar", r.ComplaintDuplicateController.GetSimilar, can(constants.PermissionComplaintProcess))
+	admin.POST("/complaints/:complaint-id/merge", r.ComplaintDuplicateController.Merge, can(constants.PermissionComplaintProcess))
+	admin.GET("/admins/queue", r.ComplaintAssignmentController.GetQueue, can(constants.PermissionComplaintProcess))
+
+	admin.GET("/schedules", r.ScheduleController.GetAll, can(constants.PermissionScheduleManage))      // Menampilkan semua jadwal
+	admin.GET("/schedules/:id", r.ScheduleController.GetByID, can(constants.PermissionScheduleManage)) // Menampilkan jadwal berdasarkan ID
+	admin.POST("/schedules", r.ScheduleController.Create, can(constants.PermissionScheduleManage))     // Menambahkan jadwal
+	admin.PUT("/schedules/:id", r.ScheduleController.Update, can(constants.PermissionScheduleManage))  // Memperbarui jadwal berdasarkan ID
+	admin.DELETE("/schedules/:id", r.ScheduleController.Delete, can(constants.PermissionScheduleManage))
 
 	// Route For User
 	user := e.Group("/api/v1")
@@ -91,7 +153,7 @@ func (r *RouteController) InitRoute(e *echo.Echo) {
 	user.POST("/users/forgot-password/send-otp", r.UserController.SendOTPForgotPassword)
 	user.POST("/users/forgot-password/verify-otp", r.UserController.VerifyOTPForgotPassword)
 	user.PUT("/users/forgot-password/change-password", r.UserController.UpdatePasswordForgot)
-	user.Use(jwt, middlewares.IsUser)
+	user.Use(jwt, isSessionActive, middlewares.IsUser)
 	user.POST("/complaints", r.ComplaintController.Create)
 	user.PUT("/complaints/:id", r.ComplaintController.Update)
 	user.PUT("/users/update-profile", r.UserController.UpdateUser)
@@ -103,16 +165,21 @@ func (r *RouteController) InitRoute(e *echo.Echo) {
 	user.POST("/chatbot/messages", r.ChatbotController.GetChatCompletion)
 	user.GET("/chatbot/messages", r.ChatbotController.GetHistory)
 	user.DELETE("/chatbot/messages", r.ChatbotController.ClearHistory)
+	user.GET("/users/email-preferences", r.EmailController.GetPreferences)
+	user.PUT("/users/email-preferences", r.EmailController.UpdatePreferences)
 
 	// Route For All Authenticated User
 	auth_user := e.Group("/api/v1")
-	auth_user.Use(jwt)
+	auth_user.POST("/auth/refresh", r.SessionController.Refresh)
+	auth_user.Use(jwt, isSessionActive)
+	auth_user.POST("/auth/logout", r.SessionController.Logout)
 	auth_user.GET("/users/:id", r.UserController.GetUserByID)
 	auth_user.DELETE("/users/:id", r.UserController.DeleteUser)
 	auth_user.GET("/complaints", r.ComplaintController.GetPaginated)
 	auth_user.GET("/complaints/:id", r.ComplaintController.GetByID)
 	auth_user.DELETE("/complaints/:id", r.ComplaintController.Delete)
 	auth_user.GET("/complaints/:complaint-id/processes", r.ComplaintProcessController.GetByComplaintID)
+	auth_user.GET("/complaints/:complaint-id/files/:file-id/url", r.AttachmentController.GetComplaintFileURL)
 	auth_user.GET("/categories", r.CategoryController.GetAll)
 	auth_user.GET("/categories/:id", r.CategoryController.GetByID)
 	auth_user.DELETE("/complaints/:complaint-id/discussions/:discussion-id", r.DiscussionController.DeleteDiscussion)
@@ -127,23 +194,56 @@ func (r *RouteController) InitRoute(e *echo.Echo) {
 	auth_user.GET("/news/:news-id/comments", r.NewsCommentController.GetCommentNews)
 	auth_user.PUT("/news/:news-id/comments/:comment-id", r.NewsCommentController.UpdateComment)
 	auth_user.DELETE("/news/:news-id/comments/:comment-id", r.NewsCommentController.DeleteComment)
+	auth_user.GET("/notifications", r.NotificationController.GetAll)
+	auth_user.GET("/notifications/unread-count", r.NotificationController.GetUnreadCount)
+	auth_user.PUT("/notifications/read-all", r.NotificationController.MarkAllAsRead)
+	auth_user.PUT("/notifications/:id/read", r.NotificationController.MarkAsRead)
+	auth_user.GET("/search", r.SearchController.Search)
 	// Route For Public
+	public := e.Group("/api/v1")
+	public.GET("/files", r.AttachmentController.Download)
+	public.GET("/files/public/*", r.AttachmentController.DownloadPublic)
 
 	// Route untuk Chat
 	chat := e.Group("/api/v1")
-	chat.Use(jwt) // Tambahkan middleware jika diperlukan untuk otentikasi
+	chat.Use(jwt, isSessionActive) // Tambahkan middleware jika diperlukan untuk otentikasi
 
 	chat.POST("/rooms", r.ChatController.CreateRoom)
 
 	chat.POST("/rooms/:room-id/messages", r.ChatController.SendMessage)
 
-	chat.GET("/rooms", r.ChatController.GetAllRooms)
+	chat.GET("/rooms", r.ChatController.GetRooms)
+
+	chat.GET("/rooms/:room-id", r.ChatController.GetRoomByID)
+
+	chat.PUT("/rooms/:room-id/status", r.ChatController.UpdateRoomStatus, middlewares.IsAdmin)
+
+	chat.GET("/rooms/:room-id/messages", r.ChatController.GetMessagesByRoomID)
+
+	chat.PUT("/rooms/:room-id/messages/:message-id", r.ChatController.UpdateMessage)
+
+	chat.DELETE("/rooms/:room-id/messages/:message-id", r.ChatController.DeleteMessage)
+
+	chat.POST("/rooms/:room-id/members", r.ChatController.JoinRoom, middlewares.IsAdmin)
+
+	// WebSocket untuk pesan, indikator mengetik dan tanda baca secara real-time
+	chatStream := e.Group("/api/v1")
+	chatStream.Use(middlewares.JWTWithQuery(), isSessionActive)
+	chatStream.GET("/rooms/:room-id/ws", r.ChatController.Connect)
+
+	// Server-sent events untuk perkembangan aduan secara real-time
+	complaintStream := e.Group("/api/v1")
+	complaintStream.Use(middlewares.JWTWithQuery(), isSessionActive)
+	complaintStream.GET("/complaints/:id/events", r.ComplaintController.Events)
 
+	// Route For Proof Of Completion
 	unggahBukti := e.Group("/api/v1/unggah-bukti")
-	unggahBukti.POST("", r.UnggahBuktiController.Create)
-	unggahBukti.GET("", r.UnggahBuktiController.GetAll)
+	unggahBukti.Use(jwt, isSessionActive)
+	unggahBukti.POST("", r.UnggahBuktiController.Create, middlewares.IsAdmin, can(constants.PermissionComplaintProcess))
+	unggahBukti.GET("", r.UnggahBuktiController.GetAll, middlewares.IsAdmin)
 	unggahBukti.GET("/:complaint-id", r.UnggahBuktiController.GetByComplaintID)
-	unggahBukti.PUT("/:id", r.UnggahBuktiController.Update)
-	unggahBukti.DELETE("/:id", r.UnggahBuktiController.Delete)
+	unggahBukti.GET("/:id/url", r.AttachmentController.GetEvidenceURL)
+	unggahBukti.PUT("/:id", r.UnggahBuktiController.Update, middlewares.IsAdmin, can(constants.PermissionComplaintProcess))
+	unggahBukti.DELETE("/:id", r.UnggahBuktiController.Delete, middlewares.IsAdmin, can(constants.PermissionComplaintProcess))
 
 }
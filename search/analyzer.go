@@ -0,0 +1,17 @@
+package search
+
+import "e-complaint-api/similarity"
+
+// Terms splits text into the terms that are indexed and searched for: lower case
+// words without stopwords, reduced to their root.
+func Terms(text string) []string {
+	var terms []string
+	for _, word := range similarity.Words(text) {
+		if IsStopword(word) {
+			continue
+		}
+		terms = append(terms, Stem(word))
+	}
+
+	return terms
+}
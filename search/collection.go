@@ -0,0 +1,11 @@
+package search
+
+// Collection describes the table a kind of document is read from.
+type Collection struct {
+	Name  string
+	Table string
+	// Fields are the searched columns
+	Fields []string
+	// Reference is an optional column that is returned with every hit
+	Reference string
+}
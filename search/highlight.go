@@ -0,0 +1,94 @@
+package search
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+const (
+	// snippetWords is the number of words a highlighted snippet is cut down to
+	snippetWords = 24
+	// snippetContext is the number of words shown before the first match
+	snippetContext = 4
+)
+
+type span struct {
+	start int
+	end   int
+	match bool
+}
+
+// Highlight cuts text down to a snippet around the first word that matches the query
+// and wraps every matching word in <mark> tags. The rest of the snippet is HTML
+// escaped. It returns an empty string when no word matches.
+func Highlight(text string, query string) string {
+	wanted := map[string]bool{}
+	for _, term := range Terms(query) {
+		wanted[term] = true
+	}
+
+	words := split(text)
+	first := -1
+	for i := range words {
+		word := strings.ToLower(text[words[i].start:words[i].end])
+		words[i].match = !IsStopword(word) && wanted[Stem(word)]
+		if words[i].match && first < 0 {
+			first = i
+		}
+	}
+
+	if first < 0 {
+		return ""
+	}
+
+	from := max(0, first-snippetContext)
+	to := min(len(words), from+snippetWords)
+
+	var snippet strings.Builder
+	if from > 0 {
+		snippet.WriteString("…")
+	}
+
+	position := words[from].start
+	for _, word := range words[from:to] {
+		snippet.WriteString(html.EscapeString(text[position:word.start]))
+		if word.match {
+			snippet.WriteString("<mark>" + html.EscapeString(text[word.start:word.end]) + "</mark>")
+		} else {
+			snippet.WriteString(html.EscapeString(text[word.start:word.end]))
+		}
+		position = word.end
+	}
+
+	if to < len(words) {
+		snippet.WriteString("…")
+	}
+
+	return snippet.String()
+}
+
+// split returns the byte offsets of the words of text.
+func split(text string) []span {
+	var words []span
+	start := -1
+	for i, r := range text {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+
+		if start >= 0 {
+			words = append(words, span{start: start, end: i})
+			start = -1
+		}
+	}
+
+	if start >= 0 {
+		words = append(words, span{start: start, end: len(text)})
+	}
+
+	return words
+}
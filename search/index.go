@@ -0,0 +1,162 @@
+package search
+
+import (
+	"math"
+	"sort"
+	"sync"
+)
+
+// BM25 parameters, see https://en.wikipedia.org/wiki/Okapi_BM25
+const (
+	k1 = 1.2
+	b  = 0.75
+)
+
+// Document is a record in an index. Fields are searched, Reference is stored as is,
+// e.g. the complaint a discussion belongs to.
+type Document struct {
+	ID        string
+	Reference string
+	Fields    map[string]string
+}
+
+type Hit struct {
+	ID    string
+	Score float64
+}
+
+type indexedDocument struct {
+	document Document
+	length   int
+	terms    map[string]int
+}
+
+// Index is an in memory inverted index that ranks documents with BM25. It is safe
+// for concurrent use.
+type Index struct {
+	mutex       sync.RWMutex
+	documents   map[string]indexedDocument
+	postings    map[string]map[string]int
+	totalLength int
+}
+
+func NewIndex() *Index {
+	return &Index{
+		documents: make(map[string]indexedDocument),
+		postings:  make(map[string]map[string]int),
+	}
+}
+
+// Add indexes a document, replacing the document with the same ID.
+func (i *Index) Add(document Document) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	i.remove(document.ID)
+
+	indexed := indexedDocument{document: document, terms: make(map[string]int)}
+	for _, text := range document.Fields {
+		for _, term := range Terms(text) {
+			indexed.terms[term]++
+			indexed.length++
+		}
+	}
+
+	for term, frequency := range indexed.terms {
+		if i.postings[term] == nil {
+			i.postings[term] = make(map[string]int)
+		}
+		i.postings[term][document.ID] = frequency
+	}
+
+	i.documents[document.ID] = indexed
+	i.totalLength += indexed.length
+}
+
+func (i *Index) Remove(id string) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	i.remove(id)
+}
+
+func (i *Index) remove(id string) {
+	indexed, ok := i.documents[id]
+	if !ok {
+		return
+	}
+
+	for term := range indexed.terms {
+		delete(i.postings[term], id)
+		if len(i.postings[term]) == 0 {
+			delete(i.postings, term)
+		}
+	}
+
+	delete(i.documents, id)
+	i.totalLength -= indexed.length
+}
+
+func (i *Index) Get(id string) (Document, bool) {
+	i.mutex.RLock()
+	defer i.mutex.RUnlock()
+
+	indexed, ok := i.documents[id]
+	return indexed.document, ok
+}
+
+func (i *Index) Len() int {
+	i.mutex.RLock()
+	defer i.mutex.RUnlock()
+
+	return len(i.documents)
+}
+
+// Search returns at most limit documents that contain a term of the query, the best
+// match first.
+func (i *Index) Search(query string, limit int) []Hit {
+	i.mutex.RLock()
+	defer i.mutex.RUnlock()
+
+	if len(i.documents) == 0 {
+		return []Hit{}
+	}
+
+	count := float64(len(i.documents))
+	averageLength := float64(i.totalLength) / count
+
+	scores := map[string]float64{}
+	searched := map[string]bool{}
+	for _, term := range Terms(query) {
+		if searched[term] {
+			continue
+		}
+		searched[term] = true
+
+		postings := i.postings[term]
+		idf := math.Log(1 + (count-float64(len(postings))+0.5)/(float64(len(postings))+0.5))
+		for id, frequency := range postings {
+			tf := float64(frequency)
+			length := float64(i.documents[id].length)
+			scores[id] += idf * tf * (k1 + 1) / (tf + k1*(1-b+b*length/averageLength))
+		}
+	}
+
+	hits := make([]Hit, 0, len(scores))
+	for id, score := range scores {
+		hits = append(hits, Hit{ID: id, Score: score})
+	}
+
+	sort.Slice(hits, func(x, y int) bool {
+		if hits[x].Score != hits[y].Score {
+			return hits[x].Score > hits[y].Score
+		}
+		return hits[x].ID < hits[y].ID
+	})
+
+	if limit > 0 && len(hits) > limit {
+		hits = hits[:limit]
+	}
+
+	return hits
+}
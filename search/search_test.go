@@ -0,0 +1,127 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStem(t *testing.T) {
+	tests := map[string]string{
+		"berlubang":   "lubang",
+		"menumpuknya": "tumpuk",
+		"menulis":     "tulis",
+		"memukul":     "pukul",
+		"membaca":     "baca",
+		"menyapu":     "sapu",
+		"mengambil":   "ambil",
+		"pengaduan":   "adu",
+		"penanganan":  "tangan",
+		"diperbaiki":  "baik",
+		"kerusakan":   "rusak",
+		"ditangani":   "tangan",
+		"bersepeda":   "sepeda",
+		"berjalan":    "jalan",
+		"terbakar":    "bakar",
+		"belajar":     "ajar",
+		"pelajaran":   "ajar",
+		"rumahlah":    "rumah",
+		"jalan":       "jalan",
+		"makan":       "makan",
+		"kursi":       "kursi",
+		"sampah":      "sampah",
+	}
+
+	for word, stem := range tests {
+		t.Run(word, func(t *testing.T) {
+			assert.Equal(t, stem, Stem(word))
+		})
+	}
+}
+
+func TestTerms(t *testing.T) {
+	assert.Equal(t, []string{"jalan", "lubang", "depan", "pasar"}, Terms("Jalan yang berlubang di depan pasar!"))
+	assert.Empty(t, Terms("yang di dan"))
+	assert.True(t, IsStopword("yang"))
+	assert.False(t, IsStopword("jalan"))
+}
+
+func TestHighlight(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		assert.Equal(t, "Jalan <mark>berlubang</mark> di depan <mark>pasar</mark> &amp; terminal", Highlight("Jalan berlubang di depan pasar & terminal", "lubang di pasar"))
+	})
+
+	t.Run("success cut down to snippet", func(t *testing.T) {
+		text := "satu dua tiga empat lima enam tujuh delapan sembilan sepuluh sebelas dua belas tiga belas empat belas lima belas enam belas tujuh belas delapan belas sembilan belas dua puluh sampah dua puluh satu dua puluh dua dua puluh tiga dua puluh empat"
+		snippet := Highlight(text, "sampah")
+
+		assert.Equal(t, "…sembilan belas dua puluh <mark>sampah</mark> dua puluh satu dua puluh dua dua puluh tiga dua puluh empat", snippet)
+	})
+
+	t.Run("success long text", func(t *testing.T) {
+		text := "sampah menumpuk satu dua tiga empat lima enam tujuh delapan sembilan sepuluh sebelas dua belas tiga belas empat belas lima belas enam belas tujuh belas"
+
+		assert.Equal(t, "<mark>sampah</mark> <mark>menumpuk</mark> satu dua tiga empat lima enam tujuh delapan sembilan sepuluh sebelas dua belas tiga belas empat belas lima belas enam belas tujuh…", Highlight(text, "tumpuk sampah"))
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		assert.Equal(t, "", Highlight("Lampu jalan mati", "sampah"))
+		assert.Equal(t, "", Highlight("Lampu jalan mati", "yang"))
+	})
+}
+
+func TestIndex(t *testing.T) {
+	index := NewIndex()
+	index.Add(Document{ID: "1", Fields: map[string]string{"description": "Jalan berlubang di depan pasar", "address": "Jl. Ahmad Yani"}})
+	index.Add(Document{ID: "2", Fields: map[string]string{"description": "Lubang besar, lubang dalam, lubang di mana-mana", "address": "Pasar Rau"}})
+	index.Add(Document{ID: "3", Reference: "C-1", Fields: map[string]string{"comment": "Sampah menumpuk di sungai"}})
+	index.Add(Document{ID: "4", Fields: map[string]string{"description": "Lampu jalan mati"}})
+
+	t.Run("success ranked", func(t *testing.T) {
+		hits := index.Search("lubang", 10)
+
+		assert.Len(t, hits, 2)
+		assert.Equal(t, "2", hits[0].ID)
+		assert.Equal(t, "1", hits[1].ID)
+		assert.Greater(t, hits[0].Score, hits[1].Score)
+	})
+
+	t.Run("success several terms", func(t *testing.T) {
+		hits := index.Search("jalan berlubang jalan", 10)
+
+		assert.Equal(t, "1", hits[0].ID)
+		assert.Len(t, hits, 3)
+	})
+
+	t.Run("success limited", func(t *testing.T) {
+		assert.Len(t, index.Search("lubang jalan", 1), 1)
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		assert.Empty(t, index.Search("banjir", 10))
+		assert.Empty(t, index.Search("yang", 10))
+	})
+
+	t.Run("get", func(t *testing.T) {
+		document, ok := index.Get("3")
+
+		assert.True(t, ok)
+		assert.Equal(t, "C-1", document.Reference)
+		assert.Equal(t, 4, index.Len())
+	})
+
+	t.Run("replace and remove", func(t *testing.T) {
+		index.Add(Document{ID: "4", Fields: map[string]string{"description": "Banjir di perumahan"}})
+		assert.Equal(t, "4", index.Search("banjir", 10)[0].ID)
+		assert.Len(t, index.Search("lampu", 10), 0)
+
+		index.Remove("4")
+		index.Remove("5")
+		assert.Empty(t, index.Search("banjir", 10))
+		assert.Equal(t, 3, index.Len())
+	})
+
+	t.Run("empty index", func(t *testing.T) {
+		assert.Empty(t, NewIndex().Search("lubang", 10))
+	})
+}
@@ -0,0 +1,155 @@
+package search
+
+import "strings"
+
+// Stem reduces a lower case Indonesian word to its root by stripping affixes, e.g.
+// "berlubang" to "lubang" and "menumpuknya" to "tumpuk". It follows the rule based
+// stemmer of Tala (2003), which needs no dictionary: affixes are only stripped while
+// at least two syllables remain, so short roots such as "jalan" stay intact.
+func Stem(word string) string {
+	word = stripSuffix(word, []string{"kah", "lah", "tah", "pun"})
+	word = stripSuffix(word, []string{"nya", "ku", "mu"})
+
+	if stem, prefix := stripFirstPrefix(word); prefix != "" {
+		word = stripDerivationalSuffix(stem, prefix)
+		if stem, ok := stripSecondPrefix(word); ok {
+			word = stem
+		}
+		return word
+	}
+
+	if stem, ok := stripSecondPrefix(word); ok {
+		return stripDerivationalSuffix(stem, "")
+	}
+
+	return stripDerivationalSuffix(word, "")
+}
+
+func stripSuffix(word string, suffixes []string) string {
+	for _, suffix := range suffixes {
+		if stem, ok := strings.CutSuffix(word, suffix); ok && syllables(stem) >= 2 {
+			return stem
+		}
+	}
+
+	return word
+}
+
+// disallowedSuffixes are the derivational suffixes that never follow a prefix.
+var disallowedSuffixes = map[string][]string{
+	"di": {"an"},
+	"ke": {"i", "kan"},
+	"me": {"an"},
+	"se": {"i", "kan"},
+	"te": {"an"},
+}
+
+func stripDerivationalSuffix(word string, prefix string) string {
+	for _, suffix := range []string{"kan", "an", "i"} {
+		stem, ok := strings.CutSuffix(word, suffix)
+		if !ok || syllables(stem) < 2 || disallowed(prefix, suffix) {
+			continue
+		}
+
+		// "-i" is part of the root after an "s", e.g. "kursi"
+		if suffix == "i" && strings.HasSuffix(stem, "s") {
+			continue
+		}
+
+		return stem
+	}
+
+	return word
+}
+
+func disallowed(prefix string, suffix string) bool {
+	for key, suffixes := range disallowedSuffixes {
+		if !strings.HasPrefix(prefix, key) {
+			continue
+		}
+		for _, disallowedSuffix := range suffixes {
+			if disallowedSuffix == suffix {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// firstPrefixes are tried in order. Before a vowel the first letter of the root melts
+// into some prefixes, e.g. "menulis" comes from "tulis", restore restores it.
+var firstPrefixes = []struct {
+	prefix    string
+	restore   string
+	vowelOnly bool
+}{
+	{"meng", "", false},
+	{"meny", "s", true},
+	{"men", "t", false},
+	{"mem", "p", false},
+	{"me", "", false},
+	{"peng", "", false},
+	{"peny", "s", true},
+	{"pen", "t", false},
+	{"pem", "p", false},
+	{"di", "", false},
+	{"ter", "", false},
+	{"ke", "", false},
+	{"se", "", false},
+}
+
+func stripFirstPrefix(word string) (string, string) {
+	for _, rule := range firstPrefixes {
+		stem, ok := strings.CutPrefix(word, rule.prefix)
+		if !ok || stem == "" {
+			continue
+		}
+
+		if isVowel(stem[0]) {
+			stem = rule.restore + stem
+		} else if rule.vowelOnly {
+			continue
+		}
+
+		if syllables(stem) >= 2 {
+			return stem, rule.prefix
+		}
+	}
+
+	return word, ""
+}
+
+func stripSecondPrefix(word string) (string, bool) {
+	for _, prefix := range []string{"ber", "bel", "be", "per", "pel", "pe"} {
+		rest, ok := strings.CutPrefix(word, prefix)
+		if !ok || syllables(rest) < 2 {
+			continue
+		}
+
+		// "bel" and "pel" only occur before "ajar", e.g. "belajar" and "pelajaran"
+		if (prefix == "bel" || prefix == "pel") && !strings.HasPrefix(rest, "ajar") {
+			continue
+		}
+
+		return rest, true
+	}
+
+	return word, false
+}
+
+func isVowel(c byte) bool {
+	return strings.IndexByte("aeiou", c) >= 0
+}
+
+// syllables approximates the number of syllables of a word by its number of vowels.
+func syllables(word string) int {
+	count := 0
+	for i := 0; i < len(word); i++ {
+		if isVowel(word[i]) {
+			count++
+		}
+	}
+
+	return count
+}
@@ -0,0 +1,27 @@
+package search
+
+// stopwords are common Indonesian words that say nothing about what a text is about.
+var stopwords = map[string]bool{}
+
+func init() {
+	for _, word := range []string{
+		"ada", "adalah", "agar", "akan", "aku", "anda", "antara", "apa", "apakah", "atas",
+		"atau", "bagaimana", "bagi", "bahwa", "banyak", "begitu", "belum", "beliau", "berapa", "bisa",
+		"bu", "bukan", "dalam", "dan", "dapat", "dari", "daripada", "dengan", "di", "dia",
+		"dong", "hal", "hanya", "harus", "hingga", "ia", "ialah", "ini", "itu", "jadi",
+		"jika", "juga", "kalau", "kami", "kamu", "kapan", "karena", "ke", "kenapa", "kepada",
+		"ketika", "kita", "lagi", "lah", "lalu", "mana", "masih", "maka", "mengapa", "mereka",
+		"mohon", "namun", "nya", "oleh", "pada", "pak", "para", "pernah", "pula", "pun",
+		"saat", "saja", "sampai", "sangat", "saya", "secara", "sedang", "segera", "sehingga", "sejak",
+		"sekali", "semua", "seperti", "serta", "setelah", "setiap", "siapa", "sini", "situ", "sudah",
+		"supaya", "tak", "tapi", "telah", "tentang", "tersebut", "tetapi", "tidak", "tolong", "untuk",
+		"ya", "yaitu", "yakni", "yang",
+	} {
+		stopwords[word] = true
+	}
+}
+
+// IsStopword reports whether a lower case word is left out of the index.
+func IsStopword(word string) bool {
+	return stopwords[word]
+}
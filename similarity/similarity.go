@@ -0,0 +1,54 @@
+package similarity
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Text returns how similar two texts are, from 0 (nothing in common) to 1 (the same
+// text). It compares the character trigrams of both texts with the Dice coefficient,
+// so typos, casing, punctuation and a different word order only lower the score a
+// little.
+func Text(a string, b string) float64 {
+	trigramsA, trigramsB := trigrams(a), trigrams(b)
+	if len(trigramsA) == 0 || len(trigramsB) == 0 {
+		return 0
+	}
+
+	shared := 0
+	for trigram, countA := range trigramsA {
+		shared += min(countA, trigramsB[trigram])
+	}
+
+	return 2 * float64(shared) / float64(total(trigramsA)+total(trigramsB))
+}
+
+// trigrams counts the trigrams of every word of the normalized text. Words are padded
+// with spaces so that short words and word boundaries count as well.
+func trigrams(text string) map[string]int {
+	result := map[string]int{}
+	for _, word := range Words(text) {
+		runes := []rune(" " + word + " ")
+		for i := 0; i+3 <= len(runes); i++ {
+			result[string(runes[i:i+3])]++
+		}
+	}
+
+	return result
+}
+
+func total(trigrams map[string]int) int {
+	count := 0
+	for _, n := range trigrams {
+		count += n
+	}
+
+	return count
+}
+
+// Words splits text into lower case words of letters and digits.
+func Words(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
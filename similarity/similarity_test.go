@@ -0,0 +1,37 @@
+package similarity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestText(t *testing.T) {
+	t.Run("same text", func(t *testing.T) {
+		assert.Equal(t, 1.0, Text("Jalan berlubang di depan pasar", "jalan berlubang, di depan PASAR!"))
+	})
+
+	t.Run("similar text", func(t *testing.T) {
+		score := Text("Jalan berlubang besar di depan pasar Rau", "Ada lubang besar di jalan depan pasar rau")
+		assert.Greater(t, score, 0.6)
+		assert.Less(t, score, 1.0)
+	})
+
+	t.Run("typo", func(t *testing.T) {
+		assert.Greater(t, Text("lampu jalan mati", "lampu jalam mati"), 0.7)
+	})
+
+	t.Run("different text", func(t *testing.T) {
+		assert.Less(t, Text("Jalan berlubang di depan pasar", "Sampah menumpuk di sungai"), 0.3)
+	})
+
+	t.Run("empty text", func(t *testing.T) {
+		assert.Equal(t, 0.0, Text("", "Jalan berlubang"))
+		assert.Equal(t, 0.0, Text("...", "..."))
+	})
+}
+
+func TestWords(t *testing.T) {
+	assert.Equal(t, []string{"jl", "sudirman", "no", "12", "serang"}, Words("Jl. Sudirman No.12, Serang"))
+	assert.Empty(t, Words(" - "))
+}
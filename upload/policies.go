@@ -0,0 +1,30 @@
+package upload
+
+// Policies of every purpose files are uploaded for. New purposes only need a policy
+// here.
+var (
+	ComplaintFiles = Policy{
+		MaxFileCount:  5,
+		MaxFileSize:   10 * 1024 * 1024,
+		MaxTotalSize:  10 * 1024 * 1024,
+		AllowedTypes:  []string{TypeJPEG, TypePNG},
+		ThumbnailSize: 320,
+	}
+	NewsFiles = Policy{
+		MaxFileCount:  3,
+		MaxFileSize:   10 * 1024 * 1024,
+		MaxTotalSize:  10 * 1024 * 1024,
+		AllowedTypes:  []string{TypeJPEG, TypePNG},
+		ThumbnailSize: 320,
+	}
+	ProfilePhoto = Policy{
+		MaxFileCount: 1,
+		MaxFileSize:  5 * 1024 * 1024,
+		AllowedTypes: []string{TypeJPEG, TypePNG},
+	}
+	Evidence = Policy{
+		MaxFileCount: 1,
+		MaxFileSize:  10 * 1024 * 1024,
+		AllowedTypes: []string{TypeJPEG, TypePNG, TypePDF},
+	}
+)
@@ -0,0 +1,41 @@
+package upload
+
+import "e-complaint-api/entities"
+
+// Store uploads processed files together with their thumbnails. The thumbnail path of
+// a file is at the same index as its path, and empty when it has no thumbnail.
+// Nothing is left in storage when an upload fails.
+func Store(storage entities.FileStorageInterface, files []entities.UploadFile) ([]string, []string, error) {
+	paths, err := storage.Upload(files)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var thumbnails []entities.UploadFile
+	for _, file := range files {
+		if file.Thumbnail != nil {
+			thumbnails = append(thumbnails, *file.Thumbnail)
+		}
+	}
+
+	thumbnailPaths := make([]string, len(files))
+	if len(thumbnails) == 0 {
+		return paths, thumbnailPaths, nil
+	}
+
+	uploadedThumbnails, err := storage.Upload(thumbnails)
+	if err != nil {
+		storage.Delete(paths)
+		return nil, nil, err
+	}
+
+	next := 0
+	for i, file := range files {
+		if file.Thumbnail != nil {
+			thumbnailPaths[i] = uploadedThumbnails[next]
+			next++
+		}
+	}
+
+	return paths, thumbnailPaths, nil
+}
@@ -0,0 +1,208 @@
+package upload
+
+import (
+	"bytes"
+	"e-complaint-api/constants"
+	"e-complaint-api/entities"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	TypeJPEG = "image/jpeg"
+	TypePNG  = "image/png"
+	TypePDF  = "application/pdf"
+)
+
+// maxPixels guards against images that are small on disk but decode into huge
+// bitmaps.
+const maxPixels = 50 * 1000 * 1000
+
+// extensions maps every content type the pipeline accepts to the extension files of
+// that type are stored with, so the stored name never depends on the client.
+var extensions = map[string]string{
+	TypeJPEG: ".jpg",
+	TypePNG:  ".png",
+	TypePDF:  ".pdf",
+}
+
+// Policy describes what may be uploaded for one purpose. Zero limits are not
+// enforced.
+type Policy struct {
+	MaxFileCount int
+	// MaxFileSize limits every single file, MaxTotalSize all files of one request.
+	MaxFileSize  int64
+	MaxTotalSize int64
+	// AllowedTypes are matched against the sniffed content type, never against the
+	// type the client claims.
+	AllowedTypes []string
+	// ThumbnailSize is the bounding box thumbnails of images are scaled into. No
+	// thumbnails are generated when it is zero.
+	ThumbnailSize int
+}
+
+// Check rejects files that break the limits of the policy without decoding them. It
+// is cheap enough to run before anything is written to the database.
+func (p Policy) Check(files []*multipart.FileHeader) error {
+	if p.MaxFileCount > 0 && len(files) > p.MaxFileCount {
+		return constants.ErrMaxFileCountExceeded
+	}
+
+	var totalSize int64
+	for _, file := range files {
+		if p.MaxFileSize > 0 && file.Size > p.MaxFileSize {
+			return constants.ErrMaxFileSizeExceeded
+		}
+		totalSize += file.Size
+	}
+
+	if p.MaxTotalSize > 0 && totalSize > p.MaxTotalSize {
+		return constants.ErrMaxFileSizeExceeded
+	}
+
+	for _, file := range files {
+		content, err := read(file, 512)
+		if err != nil {
+			return err
+		}
+
+		if !p.allows(http.DetectContentType(content)) {
+			return constants.ErrInvalidFileFormat
+		}
+	}
+
+	return nil
+}
+
+// Process checks the files and turns them into files ready for storage. Images are
+// decoded and encoded again, which drops EXIF data such as GPS coordinates, and get
+// a thumbnail when the policy asks for one.
+func (p Policy) Process(files []*multipart.FileHeader) ([]entities.UploadFile, error) {
+	if err := p.Check(files); err != nil {
+		return nil, err
+	}
+
+	var uploadFiles []entities.UploadFile
+	for _, file := range files {
+		content, err := read(file, file.Size)
+		if err != nil {
+			return nil, err
+		}
+
+		uploadFile, err := p.process(file.Filename, content)
+		if err != nil {
+			return nil, err
+		}
+
+		uploadFiles = append(uploadFiles, uploadFile)
+	}
+
+	return uploadFiles, nil
+}
+
+func (p Policy) process(filename string, content []byte) (entities.UploadFile, error) {
+	contentType := http.DetectContentType(content)
+	name := strings.TrimSuffix(filepath.Base(filename), filepath.Ext(filename)) + extensions[contentType]
+
+	if contentType != TypeJPEG && contentType != TypePNG {
+		return entities.UploadFile{Name: name, ContentType: contentType, Content: content}, nil
+	}
+
+	img, err := decode(content, contentType)
+	if err != nil {
+		return entities.UploadFile{}, err
+	}
+
+	encoded, err := encode(img, contentType)
+	if err != nil {
+		return entities.UploadFile{}, err
+	}
+
+	uploadFile := entities.UploadFile{Name: name, ContentType: contentType, Content: encoded}
+
+	if p.ThumbnailSize > 0 {
+		thumbnail, err := encode(thumbnail(img, p.ThumbnailSize), TypeJPEG)
+		if err != nil {
+			return entities.UploadFile{}, err
+		}
+
+		uploadFile.Thumbnail = &entities.UploadFile{
+			Name:        "thumb-" + strings.TrimSuffix(name, filepath.Ext(name)) + extensions[TypeJPEG],
+			ContentType: TypeJPEG,
+			Content:     thumbnail,
+		}
+	}
+
+	return uploadFile, nil
+}
+
+func (p Policy) allows(contentType string) bool {
+	for _, allowedType := range p.AllowedTypes {
+		if allowedType == contentType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// read returns at most limit bytes of an uploaded file.
+func read(file *multipart.FileHeader, limit int64) ([]byte, error) {
+	src, err := file.Open()
+	if err != nil {
+		return nil, constants.ErrInternalServerError
+	}
+	defer src.Close()
+
+	content, err := io.ReadAll(io.LimitReader(src, limit))
+	if err != nil {
+		return nil, constants.ErrInternalServerError
+	}
+
+	return content, nil
+}
+
+func decode(content []byte, contentType string) (image.Image, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(content))
+	if err != nil {
+		return nil, constants.ErrInvalidFileFormat
+	}
+
+	if config.Width*config.Height > maxPixels {
+		return nil, constants.ErrImageDimensionsTooLarge
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(content))
+	if err != nil {
+		return nil, constants.ErrInvalidFileFormat
+	}
+
+	// Re-encoding drops the EXIF orientation, so it has to be applied to the pixels
+	if contentType == TypeJPEG {
+		img = orient(img, orientation(content))
+	}
+
+	return img, nil
+}
+
+func encode(img image.Image, contentType string) ([]byte, error) {
+	var buf bytes.Buffer
+
+	var err error
+	if contentType == TypePNG {
+		err = png.Encode(&buf, img)
+	} else {
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: 85})
+	}
+	if err != nil {
+		return nil, constants.ErrInternalServerError
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,363 @@
+package upload
+
+import (
+	"bytes"
+	"e-complaint-api/constants"
+	"e-complaint-api/entities"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"mime/multipart"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockStorage struct {
+	mock.Mock
+}
+
+func (m *MockStorage) Upload(files []entities.UploadFile) ([]string, error) {
+	args := m.Called(files)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockStorage) Delete(filePaths []string) error {
+	args := m.Called(filePaths)
+	return args.Error(0)
+}
+
+func (m *MockStorage) Open(filePath string) (io.ReadCloser, error) {
+	args := m.Called(filePath)
+	return args.Get(0).(io.ReadCloser), args.Error(1)
+}
+
+func newFileHeader(t *testing.T, filename string, content []byte) *multipart.FileHeader {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, _ := writer.CreateFormFile("files", filename)
+	part.Write(content)
+	writer.Close()
+
+	form, err := multipart.NewReader(&body, writer.Boundary()).ReadForm(1024 * 1024)
+	assert.NoError(t, err)
+
+	return form.File["files"][0]
+}
+
+func newPNG(width int, height int) []byte {
+	var buf bytes.Buffer
+	png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height)))
+	return buf.Bytes()
+}
+
+// newJPEG returns a JPEG whose EXIF segment holds the given orientation and a GPS
+// marker.
+func newJPEG(width int, height int, orientation uint16) []byte {
+	var buf bytes.Buffer
+	jpeg.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height)), nil)
+
+	tiff := []byte("II*\x00\x08\x00\x00\x00")
+	tiff = binary.LittleEndian.AppendUint16(tiff, 1)
+	tiff = binary.LittleEndian.AppendUint16(tiff, 0x0112)
+	tiff = binary.LittleEndian.AppendUint16(tiff, 3)
+	tiff = binary.LittleEndian.AppendUint32(tiff, 1)
+	tiff = binary.LittleEndian.AppendUint16(tiff, orientation)
+	tiff = append(tiff, 0, 0, 0, 0, 0, 0)
+	tiff = append(tiff, []byte("GPSLatitude-6.914744")...)
+
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+	app1 := []byte{0xFF, 0xE1}
+	app1 = binary.BigEndian.AppendUint16(app1, uint16(len(segment)+2))
+	app1 = append(app1, segment...)
+
+	content := buf.Bytes()
+	return append(append([]byte{0xFF, 0xD8}, app1...), content[2:]...)
+}
+
+func TestCheck(t *testing.T) {
+	policy := Policy{MaxFileCount: 2, MaxFileSize: 1024, MaxTotalSize: 1536, AllowedTypes: []string{TypePNG}}
+
+	t.Run("success", func(t *testing.T) {
+		err := policy.Check([]*multipart.FileHeader{newFileHeader(t, "a.png", newPNG(2, 2))})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("failed max file count exceeded", func(t *testing.T) {
+		file := newFileHeader(t, "a.png", newPNG(2, 2))
+
+		err := policy.Check([]*multipart.FileHeader{file, file, file})
+
+		assert.Equal(t, constants.ErrMaxFileCountExceeded, err)
+	})
+
+	t.Run("failed max file size exceeded", func(t *testing.T) {
+		file := newFileHeader(t, "a.png", newPNG(2, 2))
+		file.Size = 2048
+
+		err := policy.Check([]*multipart.FileHeader{file})
+
+		assert.Equal(t, constants.ErrMaxFileSizeExceeded, err)
+	})
+
+	t.Run("failed max total size exceeded", func(t *testing.T) {
+		file := newFileHeader(t, "a.png", newPNG(2, 2))
+		file.Size = 1000
+
+		err := policy.Check([]*multipart.FileHeader{file, file})
+
+		assert.Equal(t, constants.ErrMaxFileSizeExceeded, err)
+	})
+
+	t.Run("failed content does not match allowed types", func(t *testing.T) {
+		file := newFileHeader(t, "a.png", []byte("<html><script></script></html>"))
+		file.Header.Set("Content-Type", TypePNG)
+
+		err := policy.Check([]*multipart.FileHeader{file})
+
+		assert.Equal(t, constants.ErrInvalidFileFormat, err)
+	})
+
+	t.Run("failed file cannot be read", func(t *testing.T) {
+		err := policy.Check([]*multipart.FileHeader{{Filename: "a.png"}})
+
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+}
+
+func TestProcess(t *testing.T) {
+	t.Run("success strips exif and applies orientation", func(t *testing.T) {
+		file := newFileHeader(t, "photo.jpeg", newJPEG(40, 20, 6))
+
+		result, err := ComplaintFiles.Process([]*multipart.FileHeader{file})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "photo.jpg", result[0].Name)
+		assert.Equal(t, TypeJPEG, result[0].ContentType)
+		assert.False(t, bytes.Contains(result[0].Content, []byte("Exif")))
+		assert.False(t, bytes.Contains(result[0].Content, []byte("GPSLatitude")))
+
+		config, err := jpeg.DecodeConfig(bytes.NewReader(result[0].Content))
+		assert.NoError(t, err)
+		assert.Equal(t, 20, config.Width)
+		assert.Equal(t, 40, config.Height)
+	})
+
+	t.Run("success generates thumbnail", func(t *testing.T) {
+		file := newFileHeader(t, "photo.png", newPNG(800, 400))
+
+		result, err := ComplaintFiles.Process([]*multipart.FileHeader{file})
+
+		assert.NoError(t, err)
+		assert.Equal(t, TypePNG, result[0].ContentType)
+		assert.Equal(t, "thumb-photo.jpg", result[0].Thumbnail.Name)
+
+		config, err := jpeg.DecodeConfig(bytes.NewReader(result[0].Thumbnail.Content))
+		assert.NoError(t, err)
+		assert.Equal(t, 320, config.Width)
+		assert.Equal(t, 160, config.Height)
+	})
+
+	t.Run("success without thumbnail", func(t *testing.T) {
+		file := newFileHeader(t, "photo.png", newPNG(8, 8))
+
+		result, err := ProfilePhoto.Process([]*multipart.FileHeader{file})
+
+		assert.NoError(t, err)
+		assert.Nil(t, result[0].Thumbnail)
+	})
+
+	t.Run("success stores name with sniffed extension", func(t *testing.T) {
+		file := newFileHeader(t, "report.html", []byte("%PDF-1.4\n%\xe2\xe3\xcf\xd3"))
+
+		result, err := Evidence.Process([]*multipart.FileHeader{file})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "report.pdf", result[0].Name)
+		assert.Equal(t, TypePDF, result[0].ContentType)
+	})
+
+	t.Run("failed check", func(t *testing.T) {
+		file := newFileHeader(t, "report.pdf", []byte("%PDF-1.4"))
+
+		_, err := ComplaintFiles.Process([]*multipart.FileHeader{file})
+
+		assert.Equal(t, constants.ErrInvalidFileFormat, err)
+	})
+
+	t.Run("failed corrupted image", func(t *testing.T) {
+		file := newFileHeader(t, "photo.jpg", []byte("\xFF\xD8\xFF\xE0 not a real jpeg"))
+
+		_, err := ComplaintFiles.Process([]*multipart.FileHeader{file})
+
+		assert.Equal(t, constants.ErrInvalidFileFormat, err)
+	})
+
+	t.Run("failed truncated image", func(t *testing.T) {
+		content := newPNG(8, 8)
+		file := newFileHeader(t, "photo.png", content[:len(content)-20])
+
+		_, err := ComplaintFiles.Process([]*multipart.FileHeader{file})
+
+		assert.Equal(t, constants.ErrInvalidFileFormat, err)
+	})
+
+	t.Run("failed image dimensions too large", func(t *testing.T) {
+		content := newPNG(1, 1)
+		// Claim a 10000 x 10000 image in the IHDR chunk and fix up its checksum
+		binary.BigEndian.PutUint32(content[16:], 10000)
+		binary.BigEndian.PutUint32(content[20:], 10000)
+		binary.BigEndian.PutUint32(content[29:], crc32.ChecksumIEEE(content[12:29]))
+		file := newFileHeader(t, "photo.png", content)
+
+		_, err := ComplaintFiles.Process([]*multipart.FileHeader{file})
+
+		assert.Equal(t, constants.ErrImageDimensionsTooLarge, err)
+	})
+}
+
+func TestOrientation(t *testing.T) {
+	t.Run("little endian", func(t *testing.T) {
+		assert.Equal(t, 8, orientation(newJPEG(2, 2, 8)))
+	})
+
+	t.Run("big endian", func(t *testing.T) {
+		tiff := []byte("MM\x00*\x00\x00\x00\x08\x00\x01\x01\x12\x00\x03\x00\x00\x00\x01\x00\x03\x00\x00")
+		assert.Equal(t, 3, tiffOrientation(tiff))
+	})
+
+	t.Run("invalid value", func(t *testing.T) {
+		assert.Equal(t, 1, orientation(newJPEG(2, 2, 9)))
+	})
+
+	t.Run("without exif", func(t *testing.T) {
+		var buf bytes.Buffer
+		jpeg.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 2, 2)), nil)
+
+		assert.Equal(t, 1, orientation(buf.Bytes()))
+	})
+
+	t.Run("not a jpeg", func(t *testing.T) {
+		assert.Equal(t, 1, orientation(newPNG(2, 2)))
+	})
+
+	t.Run("malformed segments", func(t *testing.T) {
+		assert.Equal(t, 1, orientation([]byte{0xFF, 0xD8, 0x00, 0x00, 0x00, 0x00}))
+		assert.Equal(t, 1, orientation([]byte{0xFF, 0xD8, 0xFF, 0xE1, 0xFF, 0xFF}))
+		assert.Equal(t, 1, tiffOrientation([]byte("II*")))
+		assert.Equal(t, 1, tiffOrientation([]byte("XX*\x00\x08\x00\x00\x00")))
+		assert.Equal(t, 1, tiffOrientation([]byte("II*\x00\xFF\x00\x00\x00")))
+		assert.Equal(t, 1, tiffOrientation([]byte("II*\x00\x08\x00\x00\x00\x02\x00")))
+		assert.Equal(t, 1, tiffOrientation([]byte("II*\x00\x08\x00\x00\x00\x00\x00")))
+	})
+}
+
+func TestOrient(t *testing.T) {
+	// A 2 x 1 image with a red pixel on the left and a blue pixel on the right
+	red := color.NRGBA{R: 255, A: 255}
+	blue := color.NRGBA{B: 255, A: 255}
+	src := image.NewNRGBA(image.Rect(0, 0, 2, 1))
+	src.Set(0, 0, red)
+	src.Set(1, 0, blue)
+
+	tests := []struct {
+		orientation int
+		bounds      image.Rectangle
+		redAt       image.Point
+	}{
+		{1, image.Rect(0, 0, 2, 1), image.Pt(0, 0)},
+		{2, image.Rect(0, 0, 2, 1), image.Pt(1, 0)},
+		{3, image.Rect(0, 0, 2, 1), image.Pt(1, 0)},
+		{4, image.Rect(0, 0, 2, 1), image.Pt(0, 0)},
+		{5, image.Rect(0, 0, 1, 2), image.Pt(0, 0)},
+		{6, image.Rect(0, 0, 1, 2), image.Pt(0, 0)},
+		{7, image.Rect(0, 0, 1, 2), image.Pt(0, 1)},
+		{8, image.Rect(0, 0, 1, 2), image.Pt(0, 1)},
+	}
+
+	for _, test := range tests {
+		dst := orient(src, test.orientation)
+
+		assert.Equal(t, test.bounds, dst.Bounds(), "orientation %d", test.orientation)
+		assert.Equal(t, red, color.NRGBAModel.Convert(dst.At(test.redAt.X, test.redAt.Y)), "orientation %d", test.orientation)
+	}
+}
+
+func TestThumbnail(t *testing.T) {
+	t.Run("portrait", func(t *testing.T) {
+		dst := thumbnail(image.NewRGBA(image.Rect(0, 0, 100, 400)), 50)
+
+		assert.Equal(t, image.Rect(0, 0, 12, 50), dst.Bounds())
+	})
+
+	t.Run("smaller than size", func(t *testing.T) {
+		dst := thumbnail(image.NewRGBA(image.Rect(0, 0, 10, 5)), 50)
+
+		assert.Equal(t, image.Rect(0, 0, 10, 5), dst.Bounds())
+	})
+
+	t.Run("transparent pixels become white", func(t *testing.T) {
+		dst := thumbnail(image.NewNRGBA(image.Rect(0, 0, 4, 4)), 2)
+
+		assert.Equal(t, color.RGBA{R: 255, G: 255, B: 255, A: 255}, dst.At(0, 0))
+	})
+}
+
+func TestStore(t *testing.T) {
+	files := []entities.UploadFile{
+		{Name: "a.jpg", Thumbnail: &entities.UploadFile{Name: "thumb-a.jpg"}},
+		{Name: "b.pdf"},
+		{Name: "c.png", Thumbnail: &entities.UploadFile{Name: "thumb-c.jpg"}},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		storage := new(MockStorage)
+		storage.On("Upload", files).Return([]string{"a", "b", "c"}, nil)
+		storage.On("Upload", []entities.UploadFile{*files[0].Thumbnail, *files[2].Thumbnail}).Return([]string{"thumb-a", "thumb-c"}, nil)
+
+		paths, thumbnailPaths, err := Store(storage, files)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a", "b", "c"}, paths)
+		assert.Equal(t, []string{"thumb-a", "", "thumb-c"}, thumbnailPaths)
+	})
+
+	t.Run("success without thumbnails", func(t *testing.T) {
+		storage := new(MockStorage)
+		storage.On("Upload", files[1:2]).Return([]string{"b"}, nil)
+
+		paths, thumbnailPaths, err := Store(storage, files[1:2])
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"b"}, paths)
+		assert.Equal(t, []string{""}, thumbnailPaths)
+	})
+
+	t.Run("failed to upload files", func(t *testing.T) {
+		storage := new(MockStorage)
+		storage.On("Upload", files).Return([]string{}, constants.ErrFailedToUploadObject)
+
+		_, _, err := Store(storage, files)
+
+		assert.Equal(t, constants.ErrFailedToUploadObject, err)
+	})
+
+	t.Run("failed to upload thumbnails", func(t *testing.T) {
+		storage := new(MockStorage)
+		storage.On("Upload", files).Return([]string{"a", "b", "c"}, nil)
+		storage.On("Upload", mock.Anything).Return([]string{}, errors.New("failed to upload"))
+		storage.On("Delete", []string{"a", "b", "c"}).Return(nil)
+
+		_, _, err := Store(storage, files)
+
+		assert.Error(t, err)
+		storage.AssertExpectations(t)
+	})
+}
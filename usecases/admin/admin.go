@@ -3,18 +3,19 @@ package admin
 import (
 	"e-complaint-api/constants"
 	"e-complaint-api/entities"
-	"e-complaint-api/middlewares"
 	"errors"
 	"strings"
 )
 
 type AdminUseCase struct {
-	repository entities.AdminRepositoryInterface
+	repository     entities.AdminRepositoryInterface
+	sessionUseCase entities.SessionUseCaseInterface
 }
 
-func NewAdminUseCase(repository entities.AdminRepositoryInterface) *AdminUseCase {
+func NewAdminUseCase(repository entities.AdminRepositoryInterface, sessionUseCase entities.SessionUseCaseInterface) *AdminUseCase {
 	return &AdminUseCase{
-		repository: repository,
+		repository:     repository,
+		sessionUseCase: sessionUseCase,
 	}
 }
 
@@ -52,12 +53,19 @@ func (u *AdminUseCase) Login(admin *entities.Admin) (entities.Admin, error) {
 		return entities.Admin{}, constants.ErrInvalidUsernameOrPassword
 	}
 
+	role := "admin"
 	if admin.IsSuperAdmin {
-		(*admin).Token = middlewares.GenerateTokenJWT(admin.ID, admin.Name, admin.Email, "super_admin")
-	} else {
-		(*admin).Token = middlewares.GenerateTokenJWT(admin.ID, admin.Name, admin.Email, "admin")
+		role = "super_admin"
 	}
 
+	authToken, err := u.sessionUseCase.Create(admin.ID, role, admin.Name, admin.Email)
+	if err != nil {
+		return entities.Admin{}, err
+	}
+
+	(*admin).Token = authToken.AccessToken
+	(*admin).RefreshToken = authToken.RefreshToken
+
 	return *admin, nil
 }
 
@@ -96,7 +104,8 @@ func (u *AdminUseCase) DeleteAdmin(id int) error {
 		return constants.ErrInternalServerError
 	}
 
-	return nil
+	// The deleted admin is signed out everywhere
+	return u.sessionUseCase.RevokeAll(id, "admin")
 }
 
 func (u *AdminUseCase) UpdateAdmin(id int, admin *entities.Admin) (entities.Admin, error) {
@@ -149,5 +158,13 @@ func (u *AdminUseCase) UpdateAdmin(id int, admin *entities.Admin) (entities.Admi
 		return entities.Admin{}, constants.ErrInternalServerError
 	}
 
+	// A new password signs the admin out everywhere
+	if admin.Password != "" {
+		err = u.sessionUseCase.RevokeAll(id, "admin")
+		if err != nil {
+			return entities.Admin{}, err
+		}
+	}
+
 	return *existingAdmin, nil
 }
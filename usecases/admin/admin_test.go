@@ -49,10 +49,40 @@ func (m *MockAdminRepository) GetAdminByEmail(email string) (*entities.Admin, er
 	return args.Get(0).(*entities.Admin), args.Error(1)
 }
 
+type MockSessionUseCase struct {
+	mock.Mock
+}
+
+func (m *MockSessionUseCase) Create(subjectID int, role string, name string, email string) (entities.AuthToken, error) {
+	args := m.Called(subjectID, role, name, email)
+	return args.Get(0).(entities.AuthToken), args.Error(1)
+}
+
+func (m *MockSessionUseCase) Refresh(refreshToken string) (entities.AuthToken, error) {
+	args := m.Called(refreshToken)
+	return args.Get(0).(entities.AuthToken), args.Error(1)
+}
+
+func (m *MockSessionUseCase) Logout(sessionID string) error {
+	args := m.Called(sessionID)
+	return args.Error(0)
+}
+
+func (m *MockSessionUseCase) RevokeAll(subjectID int, role string) error {
+	args := m.Called(subjectID, role)
+	return args.Error(0)
+}
+
+func (m *MockSessionUseCase) EnsureActive(sessionID string) error {
+	args := m.Called(sessionID)
+	return args.Error(0)
+}
+
 func TestCreateAccount(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		mockAdminRepo := new(MockAdminRepository)
-		AdminUseCase := NewAdminUseCase(mockAdminRepo)
+		mockSessionUseCase := new(MockSessionUseCase)
+		AdminUseCase := NewAdminUseCase(mockAdminRepo, mockSessionUseCase)
 
 		admin := entities.Admin{
 			Name:            "admin",
@@ -72,7 +102,8 @@ func TestCreateAccount(t *testing.T) {
 
 	t.Run("failed empty field", func(t *testing.T) {
 		mockAdminRepo := new(MockAdminRepository)
-		AdminUseCase := NewAdminUseCase(mockAdminRepo)
+		mockSessionUseCase := new(MockSessionUseCase)
+		AdminUseCase := NewAdminUseCase(mockAdminRepo, mockSessionUseCase)
 
 		admin := entities.Admin{
 			Name:            "",
@@ -90,7 +121,8 @@ func TestCreateAccount(t *testing.T) {
 
 	t.Run("failed email already exists", func(t *testing.T) {
 		mockAdminRepo := new(MockAdminRepository)
-		AdminUseCase := NewAdminUseCase(mockAdminRepo)
+		mockSessionUseCase := new(MockSessionUseCase)
+		AdminUseCase := NewAdminUseCase(mockAdminRepo, mockSessionUseCase)
 
 		admin := entities.Admin{
 			Name:            "admin",
@@ -110,7 +142,8 @@ func TestCreateAccount(t *testing.T) {
 
 	t.Run("failed internal server error", func(t *testing.T) {
 		mockAdminRepo := new(MockAdminRepository)
-		AdminUseCase := NewAdminUseCase(mockAdminRepo)
+		mockSessionUseCase := new(MockSessionUseCase)
+		AdminUseCase := NewAdminUseCase(mockAdminRepo, mockSessionUseCase)
 
 		admin := entities.Admin{
 			Name:            "admin",
@@ -130,7 +163,8 @@ func TestCreateAccount(t *testing.T) {
 
 	t.Run("failed username already exists", func(t *testing.T) {
 		mockAdminRepo := new(MockAdminRepository)
-		AdminUseCase := NewAdminUseCase(mockAdminRepo)
+		mockSessionUseCase := new(MockSessionUseCase)
+		AdminUseCase := NewAdminUseCase(mockAdminRepo, mockSessionUseCase)
 
 		admin := entities.Admin{
 			Name:            "admin",
@@ -150,7 +184,8 @@ func TestCreateAccount(t *testing.T) {
 
 	t.Run("failed password must be at least 8 characters", func(t *testing.T) {
 		mockAdminRepo := new(MockAdminRepository)
-		AdminUseCase := NewAdminUseCase(mockAdminRepo)
+		mockSessionUseCase := new(MockSessionUseCase)
+		AdminUseCase := NewAdminUseCase(mockAdminRepo, mockSessionUseCase)
 
 		admin := entities.Admin{
 			Name:            "admin",
@@ -170,7 +205,8 @@ func TestCreateAccount(t *testing.T) {
 func TestLogin(t *testing.T) {
 	t.Run("success admin", func(t *testing.T) {
 		mockAdminRepo := new(MockAdminRepository)
-		AdminUseCase := NewAdminUseCase(mockAdminRepo)
+		mockSessionUseCase := new(MockSessionUseCase)
+		AdminUseCase := NewAdminUseCase(mockAdminRepo, mockSessionUseCase)
 
 		admin := entities.Admin{
 			Email:    "admin@gmail.com",
@@ -178,17 +214,22 @@ func TestLogin(t *testing.T) {
 		}
 
 		mockAdminRepo.On("Login", &admin).Return(nil)
+		mockSessionUseCase.On("Create", 0, "admin", "", "admin@gmail.com").Return(entities.AuthToken{AccessToken: "access", RefreshToken: "refresh"}, nil)
 
 		result, err := AdminUseCase.Login(&admin)
 		assert.NoError(t, err)
 		assert.Equal(t, admin, result)
+		assert.Equal(t, "access", result.Token)
+		assert.Equal(t, "refresh", result.RefreshToken)
 
 		mockAdminRepo.AssertExpectations(t)
+		mockSessionUseCase.AssertExpectations(t)
 	})
 
 	t.Run("success super admin", func(t *testing.T) {
 		mockAdminRepo := new(MockAdminRepository)
-		AdminUseCase := NewAdminUseCase(mockAdminRepo)
+		mockSessionUseCase := new(MockSessionUseCase)
+		AdminUseCase := NewAdminUseCase(mockAdminRepo, mockSessionUseCase)
 
 		admin := entities.Admin{
 			Email:        "super_admin@gmail.com",
@@ -197,17 +238,41 @@ func TestLogin(t *testing.T) {
 		}
 
 		mockAdminRepo.On("Login", &admin).Return(nil)
+		mockSessionUseCase.On("Create", 0, "super_admin", "", "super_admin@gmail.com").Return(entities.AuthToken{AccessToken: "access", RefreshToken: "refresh"}, nil)
 
 		result, err := AdminUseCase.Login(&admin)
 		assert.NoError(t, err)
 		assert.Equal(t, admin, result)
 
 		mockAdminRepo.AssertExpectations(t)
+		mockSessionUseCase.AssertExpectations(t)
+	})
+
+	t.Run("failed create session", func(t *testing.T) {
+		mockAdminRepo := new(MockAdminRepository)
+		mockSessionUseCase := new(MockSessionUseCase)
+		AdminUseCase := NewAdminUseCase(mockAdminRepo, mockSessionUseCase)
+
+		admin := entities.Admin{
+			Email:    "admin@gmail.com",
+			Password: "admin",
+		}
+
+		mockAdminRepo.On("Login", &admin).Return(nil)
+		mockSessionUseCase.On("Create", 0, "admin", "", "admin@gmail.com").Return(entities.AuthToken{}, constants.ErrInternalServerError)
+
+		result, err := AdminUseCase.Login(&admin)
+		assert.Equal(t, constants.ErrInternalServerError, err)
+		assert.Equal(t, entities.Admin{}, result)
+
+		mockAdminRepo.AssertExpectations(t)
+		mockSessionUseCase.AssertExpectations(t)
 	})
 
 	t.Run("failed empty field", func(t *testing.T) {
 		mockAdminRepo := new(MockAdminRepository)
-		AdminUseCase := NewAdminUseCase(mockAdminRepo)
+		mockSessionUseCase := new(MockSessionUseCase)
+		AdminUseCase := NewAdminUseCase(mockAdminRepo, mockSessionUseCase)
 
 		admin := entities.Admin{
 			Email:    "",
@@ -223,7 +288,8 @@ func TestLogin(t *testing.T) {
 
 	t.Run("failed invalid username or password", func(t *testing.T) {
 		mockAdminRepo := new(MockAdminRepository)
-		AdminUseCase := NewAdminUseCase(mockAdminRepo)
+		mockSessionUseCase := new(MockSessionUseCase)
+		AdminUseCase := NewAdminUseCase(mockAdminRepo, mockSessionUseCase)
 
 		admin := entities.Admin{
 			Email:    "admin@gmail.com",
@@ -243,7 +309,8 @@ func TestLogin(t *testing.T) {
 func TestGetAllAdmins(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		mockAdminRepo := new(MockAdminRepository)
-		AdminUseCase := NewAdminUseCase(mockAdminRepo)
+		mockSessionUseCase := new(MockSessionUseCase)
+		AdminUseCase := NewAdminUseCase(mockAdminRepo, mockSessionUseCase)
 
 		admins := []*entities.Admin{
 			{
@@ -276,7 +343,8 @@ func TestGetAllAdmins(t *testing.T) {
 
 	t.Run("failed", func(t *testing.T) {
 		mockAdminRepo := new(MockAdminRepository)
-		AdminUseCase := NewAdminUseCase(mockAdminRepo)
+		mockSessionUseCase := new(MockSessionUseCase)
+		AdminUseCase := NewAdminUseCase(mockAdminRepo, mockSessionUseCase)
 
 		mockAdminRepo.On("GetAllAdmins").Return(([]*entities.Admin)(nil), constants.ErrInternalServerError)
 
@@ -291,7 +359,8 @@ func TestGetAllAdmins(t *testing.T) {
 func TestGetAdminByID(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		mockAdminRepo := new(MockAdminRepository)
-		AdminUseCase := NewAdminUseCase(mockAdminRepo)
+		mockSessionUseCase := new(MockSessionUseCase)
+		AdminUseCase := NewAdminUseCase(mockAdminRepo, mockSessionUseCase)
 
 		admin := entities.Admin{
 			ID:              1,
@@ -312,7 +381,8 @@ func TestGetAdminByID(t *testing.T) {
 
 	t.Run("failed admin not found", func(t *testing.T) {
 		mockAdminRepo := new(MockAdminRepository)
-		AdminUseCase := NewAdminUseCase(mockAdminRepo)
+		mockSessionUseCase := new(MockSessionUseCase)
+		AdminUseCase := NewAdminUseCase(mockAdminRepo, mockSessionUseCase)
 
 		mockAdminRepo.On("GetAdminByID", 1).Return((*entities.Admin)(nil), constants.ErrAdminNotFound)
 
@@ -325,7 +395,8 @@ func TestGetAdminByID(t *testing.T) {
 
 	t.Run("failed internal server error", func(t *testing.T) {
 		mockAdminRepo := new(MockAdminRepository)
-		AdminUseCase := NewAdminUseCase(mockAdminRepo)
+		mockSessionUseCase := new(MockSessionUseCase)
+		AdminUseCase := NewAdminUseCase(mockAdminRepo, mockSessionUseCase)
 
 		mockAdminRepo.On("GetAdminByID", 1).Return((*entities.Admin)(nil), constants.ErrInternalServerError)
 
@@ -340,20 +411,37 @@ func TestGetAdminByID(t *testing.T) {
 func TestDeleteAdmin(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		mockAdminRepo := new(MockAdminRepository)
-		AdminUseCase := NewAdminUseCase(mockAdminRepo)
+		mockSessionUseCase := new(MockSessionUseCase)
+		AdminUseCase := NewAdminUseCase(mockAdminRepo, mockSessionUseCase)
 
 		mockAdminRepo.On("GetAdminByID", 1).Return(&entities.Admin{}, nil)
 		mockAdminRepo.On("DeleteAdmin", 1).Return(nil)
+		mockSessionUseCase.On("RevokeAll", 1, "admin").Return(nil)
 
 		err := AdminUseCase.DeleteAdmin(1)
 		assert.NoError(t, err)
 
 		mockAdminRepo.AssertExpectations(t)
+		mockSessionUseCase.AssertExpectations(t)
+	})
+
+	t.Run("failed revoke sessions", func(t *testing.T) {
+		mockAdminRepo := new(MockAdminRepository)
+		mockSessionUseCase := new(MockSessionUseCase)
+		AdminUseCase := NewAdminUseCase(mockAdminRepo, mockSessionUseCase)
+
+		mockAdminRepo.On("GetAdminByID", 1).Return(&entities.Admin{}, nil)
+		mockAdminRepo.On("DeleteAdmin", 1).Return(nil)
+		mockSessionUseCase.On("RevokeAll", 1, "admin").Return(constants.ErrInternalServerError)
+
+		err := AdminUseCase.DeleteAdmin(1)
+		assert.Equal(t, constants.ErrInternalServerError, err)
 	})
 
 	t.Run("failed admin not found", func(t *testing.T) {
 		mockAdminRepo := new(MockAdminRepository)
-		AdminUseCase := NewAdminUseCase(mockAdminRepo)
+		mockSessionUseCase := new(MockSessionUseCase)
+		AdminUseCase := NewAdminUseCase(mockAdminRepo, mockSessionUseCase)
 
 		mockAdminRepo.On("GetAdminByID", 1).Return((*entities.Admin)(nil), constants.ErrAdminNotFound)
 
@@ -365,7 +453,8 @@ func TestDeleteAdmin(t *testing.T) {
 
 	t.Run("failed internal server error", func(t *testing.T) {
 		mockAdminRepo := new(MockAdminRepository)
-		AdminUseCase := NewAdminUseCase(mockAdminRepo)
+		mockSessionUseCase := new(MockSessionUseCase)
+		AdminUseCase := NewAdminUseCase(mockAdminRepo, mockSessionUseCase)
 
 		mockAdminRepo.On("GetAdminByID", 1).Return(&entities.Admin{}, nil)
 		mockAdminRepo.On("DeleteAdmin", 1).Return(constants.ErrInternalServerError)
@@ -380,7 +469,8 @@ func TestDeleteAdmin(t *testing.T) {
 func TestUpdateAdmin(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		mockAdminRepo := new(MockAdminRepository)
-		AdminUseCase := NewAdminUseCase(mockAdminRepo)
+		mockSessionUseCase := new(MockSessionUseCase)
+		AdminUseCase := NewAdminUseCase(mockAdminRepo, mockSessionUseCase)
 
 		admin := entities.Admin{
 			ID:              1,
@@ -401,17 +491,54 @@ func TestUpdateAdmin(t *testing.T) {
 		mockAdminRepo.On("GetAdminByID", 1).Return(&admin, nil)
 		mockAdminRepo.On("GetAdminByEmail", updatedAdmin.Email).Return((*entities.Admin)(nil), nil)
 		mockAdminRepo.On("UpdateAdmin", 1, &updatedAdmin).Return(nil)
+		mockSessionUseCase.On("RevokeAll", 1, "admin").Return(nil)
 
 		result, err := AdminUseCase.UpdateAdmin(1, &updatedAdmin)
 		assert.NoError(t, err)
 		assert.Equal(t, updatedAdmin, result)
 
 		mockAdminRepo.AssertExpectations(t)
+		mockSessionUseCase.AssertExpectations(t)
+	})
+
+	t.Run("failed revoke sessions", func(t *testing.T) {
+		mockAdminRepo := new(MockAdminRepository)
+		mockSessionUseCase := new(MockSessionUseCase)
+		AdminUseCase := NewAdminUseCase(mockAdminRepo, mockSessionUseCase)
+
+		admin := entities.Admin{
+			ID:              1,
+			Name:            "admin",
+			Email:           "admin@gmail.com",
+			Password:        "admin12345",
+			TelephoneNumber: "08123456789",
+		}
+
+		updatedAdmin := entities.Admin{
+			ID:              1,
+			Name:            "updated_admin",
+			Email:           "updated_admin@gmail.com",
+			Password:        "updated_admin",
+			TelephoneNumber: "08123456780",
+		}
+
+		mockAdminRepo.On("GetAdminByID", 1).Return(&admin, nil)
+		mockAdminRepo.On("GetAdminByEmail", updatedAdmin.Email).Return((*entities.Admin)(nil), nil)
+		mockAdminRepo.On("UpdateAdmin", 1, &updatedAdmin).Return(nil)
+		mockSessionUseCase.On("RevokeAll", 1, "admin").Return(constants.ErrInternalServerError)
+
+		result, err := AdminUseCase.UpdateAdmin(1, &updatedAdmin)
+		assert.Equal(t, constants.ErrInternalServerError, err)
+		assert.Equal(t, entities.Admin{}, result)
+
+		mockAdminRepo.AssertExpectations(t)
+		mockSessionUseCase.AssertExpectations(t)
 	})
 
 	t.Run("failed admin not found", func(t *testing.T) {
 		mockAdminRepo := new(MockAdminRepository)
-		AdminUseCase := NewAdminUseCase(mockAdminRepo)
+		mockSessionUseCase := new(MockSessionUseCase)
+		AdminUseCase := NewAdminUseCase(mockAdminRepo, mockSessionUseCase)
 
 		updatedAdmin := entities.Admin{
 			ID:              1,
@@ -432,7 +559,8 @@ func TestUpdateAdmin(t *testing.T) {
 
 	t.Run("failed internal server error when getting admin by email", func(t *testing.T) {
 		mockAdminRepo := new(MockAdminRepository)
-		AdminUseCase := NewAdminUseCase(mockAdminRepo)
+		mockSessionUseCase := new(MockSessionUseCase)
+		AdminUseCase := NewAdminUseCase(mockAdminRepo, mockSessionUseCase)
 
 		admin := entities.Admin{
 			ID:              1,
@@ -461,7 +589,8 @@ func TestUpdateAdmin(t *testing.T) {
 
 	t.Run("failed email already exists", func(t *testing.T) {
 		mockAdminRepo := new(MockAdminRepository)
-		AdminUseCase := NewAdminUseCase(mockAdminRepo)
+		mockSessionUseCase := new(MockSessionUseCase)
+		AdminUseCase := NewAdminUseCase(mockAdminRepo, mockSessionUseCase)
 
 		admin := entities.Admin{
 			ID:              1,
@@ -499,7 +628,8 @@ func TestUpdateAdmin(t *testing.T) {
 
 	t.Run("failed no new data provided", func(t *testing.T) {
 		mockAdminRepo := new(MockAdminRepository)
-		AdminUseCase := NewAdminUseCase(mockAdminRepo)
+		mockSessionUseCase := new(MockSessionUseCase)
+		AdminUseCase := NewAdminUseCase(mockAdminRepo, mockSessionUseCase)
 
 		admin := entities.Admin{
 			ID:              1,
@@ -528,7 +658,8 @@ func TestUpdateAdmin(t *testing.T) {
 
 	t.Run("failed internal server error when updating admin", func(t *testing.T) {
 		mockAdminRepo := new(MockAdminRepository)
-		AdminUseCase := NewAdminUseCase(mockAdminRepo)
+		mockSessionUseCase := new(MockSessionUseCase)
+		AdminUseCase := NewAdminUseCase(mockAdminRepo, mockSessionUseCase)
 
 		admin := entities.Admin{
 			ID:              1,
@@ -559,7 +690,8 @@ func TestUpdateAdmin(t *testing.T) {
 
 	t.Run("failed password must be at least 8 characters", func(t *testing.T) {
 		mockAdminRepo := new(MockAdminRepository)
-		AdminUseCase := NewAdminUseCase(mockAdminRepo)
+		mockSessionUseCase := new(MockSessionUseCase)
+		AdminUseCase := NewAdminUseCase(mockAdminRepo, mockSessionUseCase)
 
 		admin := entities.Admin{
 			ID:              1,
@@ -0,0 +1,143 @@
+package attachment
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"e-complaint-api/constants"
+	"e-complaint-api/entities"
+	"encoding/hex"
+	"io"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// downloadPath is the route that serves files behind a signed download URL.
+const downloadPath = "/api/v1/files"
+
+type AttachmentUseCase struct {
+	complaintRepository   entities.ComplaintRepositoryInterface
+	unggahBuktiRepository entities.UnggahBuktiRepositoryInterface
+	storage               entities.FileStorageInterface
+	secret                []byte
+	urlTTL                time.Duration
+}
+
+func NewAttachmentUseCase(complaintRepository entities.ComplaintRepositoryInterface, unggahBuktiRepository entities.UnggahBuktiRepositoryInterface, storage entities.FileStorageInterface, secret string, urlTTL time.Duration) *AttachmentUseCase {
+	return &AttachmentUseCase{
+		complaintRepository:   complaintRepository,
+		unggahBuktiRepository: unggahBuktiRepository,
+		storage:               storage,
+		secret:                []byte(secret),
+		urlTTL:                urlTTL,
+	}
+}
+
+// GetComplaintFileURL signs the URL of a complaint file, or of its thumbnail when
+// thumbnail is set.
+func (u *AttachmentUseCase) GetComplaintFileURL(complaintID string, fileID int, thumbnail bool, accountID int, role string) (entities.AttachmentURL, error) {
+	complaint, err := u.complaintRepository.GetByID(complaintID)
+	if err != nil {
+		return entities.AttachmentURL{}, err
+	}
+
+	if !complaint.CanAccessFiles(accountID, role) {
+		return entities.AttachmentURL{}, constants.ErrForbidden
+	}
+
+	for _, file := range complaint.Files {
+		if file.ID != fileID {
+			continue
+		}
+
+		if thumbnail {
+			if file.ThumbnailPath == "" {
+				return entities.AttachmentURL{}, constants.ErrFileNotFound
+			}
+			return u.sign(file.ThumbnailPath, time.Now()), nil
+		}
+
+		return u.sign(file.Path, time.Now()), nil
+	}
+
+	return entities.AttachmentURL{}, constants.ErrFileNotFound
+}
+
+func (u *AttachmentUseCase) GetEvidenceURL(evidenceID int64, accountID int, role string) (entities.AttachmentURL, error) {
+	evidence, err := u.unggahBuktiRepository.GetByID(evidenceID)
+	if err != nil || evidence == nil {
+		return entities.AttachmentURL{}, constants.ErrFileNotFound
+	}
+
+	complaint, err := u.complaintRepository.GetByID(evidence.ComplaintID)
+	if err != nil {
+		return entities.AttachmentURL{}, err
+	}
+
+	if !complaint.CanAccessFiles(accountID, role) {
+		return entities.AttachmentURL{}, constants.ErrForbidden
+	}
+
+	return u.sign(evidence.Path, time.Now()), nil
+}
+
+// Open returns the file behind a signed download URL.
+func (u *AttachmentUseCase) Open(filePath string, expires int64, signature string) (io.ReadCloser, error) {
+	if !hmac.Equal([]byte(signature), []byte(u.signature(filePath, expires))) {
+		return nil, constants.ErrInvalidDownloadURL
+	}
+
+	if time.Now().Unix() > expires {
+		return nil, constants.ErrDownloadURLExpired
+	}
+
+	return u.open(filePath)
+}
+
+// OpenPublic returns a file from one of the public folders, which need no signature.
+func (u *AttachmentUseCase) OpenPublic(filePath string) (io.ReadCloser, error) {
+	filePath = strings.TrimPrefix(path.Clean("/"+filePath), "/")
+
+	for _, folder := range constants.PublicFolders {
+		if strings.HasPrefix(filePath, folder) {
+			return u.open(filePath)
+		}
+	}
+
+	return nil, constants.ErrFileNotFound
+}
+
+func (u *AttachmentUseCase) open(filePath string) (io.ReadCloser, error) {
+	file, err := u.storage.Open(filePath)
+	if err != nil {
+		if err == constants.ErrFileNotFound {
+			return nil, err
+		}
+		return nil, constants.ErrInternalServerError
+	}
+
+	return file, nil
+}
+
+func (u *AttachmentUseCase) sign(filePath string, now time.Time) entities.AttachmentURL {
+	expiresAt := now.Add(u.urlTTL)
+	expires := expiresAt.Unix()
+
+	query := url.Values{}
+	query.Set("path", filePath)
+	query.Set("expires", strconv.FormatInt(expires, 10))
+	query.Set("signature", u.signature(filePath, expires))
+
+	return entities.AttachmentURL{
+		URL:       downloadPath + "?" + query.Encode(),
+		ExpiresAt: time.Unix(expires, 0),
+	}
+}
+
+func (u *AttachmentUseCase) signature(filePath string, expires int64) string {
+	mac := hmac.New(sha256.New, u.secret)
+	mac.Write([]byte(filePath + "\n" + strconv.FormatInt(expires, 10)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,409 @@
+package attachment
+
+import (
+	"e-complaint-api/constants"
+	"e-complaint-api/entities"
+	"errors"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockComplaintRepo struct {
+	mock.Mock
+}
+
+func (m *MockComplaintRepo) GetPaginated(limit int, page int, search string, filter map[string]interface{}, sortBy string, sortType string) ([]entities.Complaint, error) {
+	args := m.Called(limit, page, search, filter, sortBy, sortType)
+	return args.Get(0).([]entities.Complaint), args.Error(1)
+}
+
+func (m *MockComplaintRepo) GetMetaData(limit int, page int, search string, filter map[string]interface{}) (entities.Metadata, error) {
+	args := m.Called(limit, page, search, filter)
+	return args.Get(0).(entities.Metadata), args.Error(1)
+}
+
+func (m *MockComplaintRepo) GetAfter(limit int, afterID string, afterValue string, search string, filter map[string]interface{}, sortBy string, sortType string) ([]entities.Complaint, error) {
+	args := m.Called(limit, afterID, afterValue, search, filter, sortBy, sortType)
+	return args.Get(0).([]entities.Complaint), args.Error(1)
+}
+
+func (m *MockComplaintRepo) GetAfterWithProcesses(limit int, afterID string, afterValue string, search string, filter map[string]interface{}, sortBy string, sortType string) ([]entities.Complaint, error) {
+	args := m.Called(limit, afterID, afterValue, search, filter, sortBy, sortType)
+	return args.Get(0).([]entities.Complaint), args.Error(1)
+}
+
+func (m *MockComplaintRepo) GetWithLocation(limit int, search string, filter map[string]interface{}) ([]entities.Complaint, error) {
+	args := m.Called(limit, search, filter)
+	return args.Get(0).([]entities.Complaint), args.Error(1)
+}
+
+func (m *MockComplaintRepo) GetByID(id string) (entities.Complaint, error) {
+	args := m.Called(id)
+	return args.Get(0).(entities.Complaint), args.Error(1)
+}
+
+func (m *MockComplaintRepo) GetByUserID(userId int) ([]entities.Complaint, error) {
+	args := m.Called(userId)
+	return args.Get(0).([]entities.Complaint), args.Error(1)
+}
+
+func (m *MockComplaintRepo) Create(complaint *entities.Complaint) error {
+	args := m.Called(complaint)
+	return args.Error(0)
+}
+
+func (m *MockComplaintRepo) Delete(id string, userId int) error {
+	args := m.Called(id, userId)
+	return args.Error(0)
+}
+
+func (m *MockComplaintRepo) AdminDelete(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockComplaintRepo) Update(complaint entities.Complaint) (entities.Complaint, error) {
+	args := m.Called(complaint)
+	return args.Get(0).(entities.Complaint), args.Error(1)
+}
+
+func (m *MockComplaintRepo) UpdateStatus(id string, status string) error {
+	args := m.Called(id, status)
+	return args.Error(0)
+}
+
+func (m *MockComplaintRepo) GetStatus(id string) (string, error) {
+	args := m.Called(id)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockComplaintRepo) IncreaseTotalLikes(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockComplaintRepo) DecreaseTotalLikes(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockComplaintRepo) GetComplaintIDsByUserID(userID int) ([]string, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+type MockUnggahBuktiRepo struct {
+	mock.Mock
+}
+
+func (m *MockUnggahBuktiRepo) Create(unggahBukti *entities.UnggahBukti) error {
+	args := m.Called(unggahBukti)
+	return args.Error(0)
+}
+
+func (m *MockUnggahBuktiRepo) GetAll() ([]entities.UnggahBukti, error) {
+	args := m.Called()
+	return args.Get(0).([]entities.UnggahBukti), args.Error(1)
+}
+
+func (m *MockUnggahBuktiRepo) GetByComplaintID(complaintID string) ([]entities.UnggahBukti, error) {
+	args := m.Called(complaintID)
+	return args.Get(0).([]entities.UnggahBukti), args.Error(1)
+}
+
+func (m *MockUnggahBuktiRepo) GetByID(id int64) (*entities.UnggahBukti, error) {
+	args := m.Called(id)
+	return args.Get(0).(*entities.UnggahBukti), args.Error(1)
+}
+
+func (m *MockUnggahBuktiRepo) Update(id int64, unggahBukti *entities.UnggahBukti) error {
+	args := m.Called(id, unggahBukti)
+	return args.Error(0)
+}
+
+func (m *MockUnggahBuktiRepo) Delete(id int64) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+type MockStorage struct {
+	mock.Mock
+}
+
+func (m *MockStorage) Upload(files []entities.UploadFile) ([]string, error) {
+	args := m.Called(files)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockStorage) Delete(filePaths []string) error {
+	args := m.Called(filePaths)
+	return args.Error(0)
+}
+
+func (m *MockStorage) Open(filePath string) (io.ReadCloser, error) {
+	args := m.Called(filePath)
+	return args.Get(0).(io.ReadCloser), args.Error(1)
+}
+
+func newTestUseCase() (*AttachmentUseCase, *MockComplaintRepo, *MockUnggahBuktiRepo, *MockStorage) {
+	complaintRepo := new(MockComplaintRepo)
+	unggahBuktiRepo := new(MockUnggahBuktiRepo)
+	storage := new(MockStorage)
+	usecase := NewAttachmentUseCase(complaintRepo, unggahBuktiRepo, storage, "secret", 5*time.Minute)
+	return usecase, complaintRepo, unggahBuktiRepo, storage
+}
+
+func privateComplaint() entities.Complaint {
+	assigneeID := 2
+	return entities.Complaint{
+		ID:         "C-123",
+		UserID:     1,
+		Type:       "private",
+		AssigneeID: &assigneeID,
+		Files: []entities.ComplaintFile{
+			{ID: 1, ComplaintID: "C-123", Path: "complaint-files/photo.jpg", ThumbnailPath: "complaint-files/thumb-photo.jpg"},
+			{ID: 2, ComplaintID: "C-123", Path: "complaint-files/scan.png"},
+		},
+	}
+}
+
+func parseURL(t *testing.T, attachmentURL entities.AttachmentURL) (string, int64, string) {
+	parsed, err := url.Parse(attachmentURL.URL)
+	assert.NoError(t, err)
+	assert.Equal(t, "/api/v1/files", parsed.Path)
+
+	expires, err := strconv.ParseInt(parsed.Query().Get("expires"), 10, 64)
+	assert.NoError(t, err)
+	return parsed.Query().Get("path"), expires, parsed.Query().Get("signature")
+}
+
+func TestGetComplaintFileURL(t *testing.T) {
+	t.Run("success owner", func(t *testing.T) {
+		usecase, complaintRepo, _, _ := newTestUseCase()
+		complaintRepo.On("GetByID", "C-123").Return(privateComplaint(), nil)
+
+		result, err := usecase.GetComplaintFileURL("C-123", 1, false, 1, "user")
+
+		assert.NoError(t, err)
+		filePath, expires, signature := parseURL(t, result)
+		assert.Equal(t, "complaint-files/photo.jpg", filePath)
+		assert.Equal(t, result.ExpiresAt.Unix(), expires)
+		assert.Equal(t, usecase.signature(filePath, expires), signature)
+	})
+
+	t.Run("success thumbnail", func(t *testing.T) {
+		usecase, complaintRepo, _, _ := newTestUseCase()
+		complaintRepo.On("GetByID", "C-123").Return(privateComplaint(), nil)
+
+		result, err := usecase.GetComplaintFileURL("C-123", 1, true, 1, "user")
+
+		assert.NoError(t, err)
+		filePath, _, _ := parseURL(t, result)
+		assert.Equal(t, "complaint-files/thumb-photo.jpg", filePath)
+	})
+
+	t.Run("failed file without thumbnail", func(t *testing.T) {
+		usecase, complaintRepo, _, _ := newTestUseCase()
+		complaintRepo.On("GetByID", "C-123").Return(privateComplaint(), nil)
+
+		_, err := usecase.GetComplaintFileURL("C-123", 2, true, 1, "user")
+
+		assert.Equal(t, constants.ErrFileNotFound, err)
+	})
+
+	t.Run("success assigned admin", func(t *testing.T) {
+		usecase, complaintRepo, _, _ := newTestUseCase()
+		complaintRepo.On("GetByID", "C-123").Return(privateComplaint(), nil)
+
+		_, err := usecase.GetComplaintFileURL("C-123", 1, false, 2, "admin")
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("success super admin", func(t *testing.T) {
+		usecase, complaintRepo, _, _ := newTestUseCase()
+		complaintRepo.On("GetByID", "C-123").Return(privateComplaint(), nil)
+
+		_, err := usecase.GetComplaintFileURL("C-123", 1, false, 9, "super_admin")
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("success public complaint", func(t *testing.T) {
+		usecase, complaintRepo, _, _ := newTestUseCase()
+		complaint := privateComplaint()
+		complaint.Type = "public"
+		complaintRepo.On("GetByID", "C-123").Return(complaint, nil)
+
+		_, err := usecase.GetComplaintFileURL("C-123", 1, false, 5, "user")
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("failed other user", func(t *testing.T) {
+		usecase, complaintRepo, _, _ := newTestUseCase()
+		complaintRepo.On("GetByID", "C-123").Return(privateComplaint(), nil)
+
+		_, err := usecase.GetComplaintFileURL("C-123", 1, false, 5, "user")
+
+		assert.Equal(t, constants.ErrForbidden, err)
+	})
+
+	t.Run("failed admin not assigned", func(t *testing.T) {
+		usecase, complaintRepo, _, _ := newTestUseCase()
+		complaintRepo.On("GetByID", "C-123").Return(privateComplaint(), nil)
+
+		_, err := usecase.GetComplaintFileURL("C-123", 1, false, 3, "admin")
+
+		assert.Equal(t, constants.ErrForbidden, err)
+	})
+
+	t.Run("failed complaint not found", func(t *testing.T) {
+		usecase, complaintRepo, _, _ := newTestUseCase()
+		complaintRepo.On("GetByID", "C-123").Return(entities.Complaint{}, constants.ErrComplaintNotFound)
+
+		_, err := usecase.GetComplaintFileURL("C-123", 1, false, 1, "user")
+
+		assert.Equal(t, constants.ErrComplaintNotFound, err)
+	})
+
+	t.Run("failed file not found", func(t *testing.T) {
+		usecase, complaintRepo, _, _ := newTestUseCase()
+		complaintRepo.On("GetByID", "C-123").Return(privateComplaint(), nil)
+
+		_, err := usecase.GetComplaintFileURL("C-123", 7, false, 1, "user")
+
+		assert.Equal(t, constants.ErrFileNotFound, err)
+	})
+}
+
+func TestGetEvidenceURL(t *testing.T) {
+	evidence := &entities.UnggahBukti{ID: 1, ComplaintID: "C-123", Path: "bukti-unggah/bukti.jpg"}
+
+	t.Run("success", func(t *testing.T) {
+		usecase, complaintRepo, unggahBuktiRepo, _ := newTestUseCase()
+		unggahBuktiRepo.On("GetByID", int64(1)).Return(evidence, nil)
+		complaintRepo.On("GetByID", "C-123").Return(privateComplaint(), nil)
+
+		result, err := usecase.GetEvidenceURL(1, 1, "user")
+
+		assert.NoError(t, err)
+		filePath, _, _ := parseURL(t, result)
+		assert.Equal(t, "bukti-unggah/bukti.jpg", filePath)
+	})
+
+	t.Run("failed evidence not found", func(t *testing.T) {
+		usecase, _, unggahBuktiRepo, _ := newTestUseCase()
+		unggahBuktiRepo.On("GetByID", int64(1)).Return((*entities.UnggahBukti)(nil), errors.New("record not found"))
+
+		_, err := usecase.GetEvidenceURL(1, 1, "user")
+
+		assert.Equal(t, constants.ErrFileNotFound, err)
+	})
+
+	t.Run("failed complaint not found", func(t *testing.T) {
+		usecase, complaintRepo, unggahBuktiRepo, _ := newTestUseCase()
+		unggahBuktiRepo.On("GetByID", int64(1)).Return(evidence, nil)
+		complaintRepo.On("GetByID", "C-123").Return(entities.Complaint{}, constants.ErrComplaintNotFound)
+
+		_, err := usecase.GetEvidenceURL(1, 1, "user")
+
+		assert.Equal(t, constants.ErrComplaintNotFound, err)
+	})
+
+	t.Run("failed forbidden", func(t *testing.T) {
+		usecase, complaintRepo, unggahBuktiRepo, _ := newTestUseCase()
+		unggahBuktiRepo.On("GetByID", int64(1)).Return(evidence, nil)
+		complaintRepo.On("GetByID", "C-123").Return(privateComplaint(), nil)
+
+		_, err := usecase.GetEvidenceURL(1, 5, "user")
+
+		assert.Equal(t, constants.ErrForbidden, err)
+	})
+}
+
+func TestOpen(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		usecase, _, _, storage := newTestUseCase()
+		expires := time.Now().Add(time.Minute).Unix()
+		storage.On("Open", "complaint-files/photo.jpg").Return(io.NopCloser(strings.NewReader("data")), nil)
+
+		file, err := usecase.Open("complaint-files/photo.jpg", expires, usecase.signature("complaint-files/photo.jpg", expires))
+
+		assert.NoError(t, err)
+		assert.NotNil(t, file)
+	})
+
+	t.Run("failed invalid signature", func(t *testing.T) {
+		usecase, _, _, _ := newTestUseCase()
+		expires := time.Now().Add(time.Minute).Unix()
+
+		_, err := usecase.Open("complaint-files/other.jpg", expires, usecase.signature("complaint-files/photo.jpg", expires))
+
+		assert.Equal(t, constants.ErrInvalidDownloadURL, err)
+	})
+
+	t.Run("failed expired", func(t *testing.T) {
+		usecase, _, _, _ := newTestUseCase()
+		expires := time.Now().Add(-time.Minute).Unix()
+
+		_, err := usecase.Open("complaint-files/photo.jpg", expires, usecase.signature("complaint-files/photo.jpg", expires))
+
+		assert.Equal(t, constants.ErrDownloadURLExpired, err)
+	})
+
+	t.Run("failed file not found", func(t *testing.T) {
+		usecase, _, _, storage := newTestUseCase()
+		expires := time.Now().Add(time.Minute).Unix()
+		storage.On("Open", "complaint-files/photo.jpg").Return(io.NopCloser(nil), constants.ErrFileNotFound)
+
+		_, err := usecase.Open("complaint-files/photo.jpg", expires, usecase.signature("complaint-files/photo.jpg", expires))
+
+		assert.Equal(t, constants.ErrFileNotFound, err)
+	})
+
+	t.Run("failed storage error", func(t *testing.T) {
+		usecase, _, _, storage := newTestUseCase()
+		expires := time.Now().Add(time.Minute).Unix()
+		storage.On("Open", "complaint-files/photo.jpg").Return(io.NopCloser(nil), errors.New("connection reset"))
+
+		_, err := usecase.Open("complaint-files/photo.jpg", expires, usecase.signature("complaint-files/photo.jpg", expires))
+
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+}
+
+func TestOpenPublic(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		usecase, _, _, storage := newTestUseCase()
+		storage.On("Open", "news-files/cover.jpg").Return(io.NopCloser(strings.NewReader("data")), nil)
+
+		file, err := usecase.OpenPublic("news-files/cover.jpg")
+
+		assert.NoError(t, err)
+		assert.NotNil(t, file)
+	})
+
+	t.Run("failed private folder", func(t *testing.T) {
+		usecase, _, _, _ := newTestUseCase()
+
+		_, err := usecase.OpenPublic("complaint-files/photo.jpg")
+
+		assert.Equal(t, constants.ErrFileNotFound, err)
+	})
+
+	t.Run("failed path traversal", func(t *testing.T) {
+		usecase, _, _, _ := newTestUseCase()
+
+		_, err := usecase.OpenPublic("news-files/../complaint-files/photo.jpg")
+
+		assert.Equal(t, constants.ErrFileNotFound, err)
+	})
+}
@@ -1,38 +1,386 @@
 package chat
 
 import (
+	"e-complaint-api/constants"
+	"e-complaint-api/cursor"
 	"e-complaint-api/entities"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
 )
 
 type chatUseCase struct {
 	chatRepo entities.ChatRepositoryInterface
+	hub      entities.PubSubInterface
 }
 
-// SendMessage adds a new message to a room
+// roomTopic is the topic the events of a room are published on
+func roomTopic(roomID int) string {
+	return fmt.Sprintf("chat.room.%d", roomID)
+}
+
+// memberType is the member type of the user or admin a principal is
+func memberType(principal entities.Principal) string {
+	if principal.Role == "user" {
+		return constants.ChatMemberUser
+	}
+	return constants.ChatMemberAdmin
+}
+
+// openRoom retrieves a room that takes new messages
+func (uc *chatUseCase) openRoom(roomID int) (*entities.Room, error) {
+	room, err := uc.chatRepo.GetRoomByID(roomID)
+	if err != nil {
+		return nil, err
+	}
+	if room.Status != constants.RoomStatusOpen {
+		return nil, constants.ErrRoomClosed
+	}
+	return room, nil
+}
+
+// SendMessage adds a new message to a room and pushes it to the members of the room.
+// Only members of the room can send messages to it, and only while it is open.
 func (uc *chatUseCase) SendMessage(chat *entities.Message) error {
-	return uc.chatRepo.CreateChat(chat)
+	if strings.TrimSpace(chat.Message) == "" {
+		return constants.ErrMessageRequired
+	}
+
+	if _, err := uc.chatRepo.GetMember(chat.RoomID, chat.SenderID, chat.SenderType); err != nil {
+		return err
+	}
+
+	if _, err := uc.openRoom(chat.RoomID); err != nil {
+		return err
+	}
+
+	if err := uc.chatRepo.CreateChat(chat); err != nil {
+		return err
+	}
+
+	uc.hub.Publish(roomTopic(chat.RoomID), entities.Event{Type: constants.ChatEventMessage, Data: *chat})
+	return nil
 }
 
-// NewChatUseCase initializes a new chat use case
-func NewChatUseCase(chatRepo entities.ChatRepositoryInterface) entities.ChatUseCaseInterface {
-	return &chatUseCase{chatRepo: chatRepo}
+// Typing tells the members of a room that a member is typing
+func (uc *chatUseCase) Typing(roomID int, principal entities.Principal) error {
+	member, err := uc.chatRepo.GetMember(roomID, principal.ID, memberType(principal))
+	if err != nil {
+		return err
+	}
+
+	uc.hub.Publish(roomTopic(roomID), entities.Event{Type: constants.ChatEventTyping, Data: entities.ChatTyping{
+		RoomID:     roomID,
+		SenderID:   member.MemberID,
+		SenderType: member.MemberType,
+	}})
+	return nil
+}
+
+// MarkAsRead records that a member read the messages of a room up to messageID and
+// tells the other members
+func (uc *chatUseCase) MarkAsRead(roomID int, messageID int, principal entities.Principal) error {
+	if messageID <= 0 {
+		return constants.ErrInvalidIDFormat
+	}
+
+	member, err := uc.chatRepo.GetMember(roomID, principal.ID, memberType(principal))
+	if err != nil {
+		return err
+	}
+
+	if messageID <= member.LastReadMessageID {
+		return nil
+	}
+
+	if err := uc.chatRepo.UpdateLastRead(member, messageID); err != nil {
+		return err
+	}
+
+	uc.hub.Publish(roomTopic(roomID), entities.Event{Type: constants.ChatEventRead, Data: entities.ChatReadReceipt{
+		RoomID:     roomID,
+		ReaderID:   member.MemberID,
+		ReaderType: member.MemberType,
+		MessageID:  messageID,
+	}})
+	return nil
 }
 
-// CreateRoom creates a new chat room
-func (uc *chatUseCase) CreateRoom(name string) (*entities.Room, error) {
-	return uc.chatRepo.CreateRoom(name)
+// Subscribe returns the events of a room for one of its members, until the returned
+// func is called
+func (uc *chatUseCase) Subscribe(roomID int, principal entities.Principal) (<-chan entities.Event, func(), error) {
+	if _, err := uc.chatRepo.GetMember(roomID, principal.ID, memberType(principal)); err != nil {
+		return nil, nil, err
+	}
+
+	events, unsubscribe := uc.hub.Subscribe(roomTopic(roomID))
+	return events, unsubscribe, nil
 }
 
-// GetAllRooms retrieves all chat rooms with their associated messages
-func (uc *chatUseCase) GetAllRooms() ([]entities.Room, error) {
-	return uc.chatRepo.GetAllRooms()
+// NewChatUseCase initializes a new chat use case, which pushes the events of rooms
+// through hub
+func NewChatUseCase(chatRepo entities.ChatRepositoryInterface, hub entities.PubSubInterface) entities.ChatUseCaseInterface {
+	return &chatUseCase{chatRepo: chatRepo, hub: hub}
 }
 
-// GetMessagesByRoomID retrieves all messages for a specific room
-func (uc *chatUseCase) GetMessagesByRoomID(roomID int) ([]entities.Message, error) {
+// CreateRoom creates a new chat room for the principal and the user or admin with
+// memberID. Rooms for a complaint are between its reporter and an admin, so users can
+// only create them for their own complaints and admins get the reporter as member.
+func (uc *chatUseCase) CreateRoom(name string, complaintID *string, memberID *int, principal entities.Principal) (*entities.Room, error) {
+	if strings.TrimSpace(name) == "" {
+		return nil, constants.ErrRoomNameRequired
+	}
+	if complaintID == nil && memberID == nil {
+		return nil, constants.ErrRoomMemberRequired
+	}
+
+	members := []entities.RoomMember{{MemberID: principal.ID, MemberType: memberType(principal)}}
+	otherType := constants.ChatMemberAdmin
+	if memberType(principal) == constants.ChatMemberAdmin {
+		otherType = constants.ChatMemberUser
+	}
+
+	if complaintID != nil {
+		userID, err := uc.chatRepo.GetComplaintUserID(*complaintID)
+		if err != nil {
+			return nil, err
+		}
+
+		if otherType == constants.ChatMemberAdmin && userID != principal.ID {
+			return nil, constants.ErrForbidden
+		}
+		// the reporter is the user of the rooms admins create for a complaint
+		if otherType == constants.ChatMemberUser {
+			memberID = &userID
+		}
+	}
+
+	if memberID != nil {
+		exists, err := uc.chatRepo.MemberExists(*memberID, otherType)
+		if err != nil {
+			return nil, err
+		}
+		if !exists && otherType == constants.ChatMemberAdmin {
+			return nil, constants.ErrAdminNotFound
+		}
+		if !exists {
+			return nil, constants.ErrUserNotFound
+		}
+
+		members = append(members, entities.RoomMember{MemberID: *memberID, MemberType: otherType})
+	}
+
+	room := &entities.Room{Name: name, ComplaintID: complaintID}
+	if err := uc.chatRepo.CreateRoom(room, members); err != nil {
+		return nil, err
+	}
+	return room, nil
+}
+
+// JoinRoom adds an admin to the room of a complaint, so other admins can take over
+// the conversation with its reporter
+func (uc *chatUseCase) JoinRoom(roomID int, principal entities.Principal) (*entities.Room, error) {
+	if memberType(principal) != constants.ChatMemberAdmin {
+		return nil, constants.ErrForbidden
+	}
+
+	room, err := uc.chatRepo.GetRoomByID(roomID)
+	if err != nil {
+		return nil, err
+	}
+	if room.ComplaintID == nil {
+		return nil, constants.ErrRoomNotJoinable
+	}
+
+	_, err = uc.chatRepo.GetMember(roomID, principal.ID, constants.ChatMemberAdmin)
+	if err == nil {
+		return room, nil
+	}
+	if err != constants.ErrNotRoomMember {
+		return nil, err
+	}
+
+	if err := uc.chatRepo.CreateMember(&entities.RoomMember{RoomID: roomID, MemberID: principal.ID, MemberType: constants.ChatMemberAdmin}); err != nil {
+		return nil, err
+	}
+	return room, nil
+}
+
+// GetRooms retrieves the rooms of which the principal is a member, with their last
+// message and the number of messages the principal has not read. Archived rooms are
+// only retrieved when asked for with their status.
+func (uc *chatUseCase) GetRooms(principal entities.Principal, status string) ([]entities.RoomSummary, error) {
+	statuses := []string{constants.RoomStatusOpen, constants.RoomStatusClosed}
+	switch status {
+	case "":
+	case constants.RoomStatusOpen, constants.RoomStatusClosed, constants.RoomStatusArchived:
+		statuses = []string{status}
+	default:
+		return nil, constants.ErrInvalidRoomStatus
+	}
+
+	rooms, err := uc.chatRepo.GetRoomsByMember(principal.ID, memberType(principal), statuses)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := []entities.RoomSummary{}
+	if len(rooms) == 0 {
+		return summaries, nil
+	}
+
+	roomIDs := []int{}
+	for _, room := range rooms {
+		roomIDs = append(roomIDs, room.ID)
+	}
+
+	unread, err := uc.chatRepo.CountUnreadByRoom(principal.ID, memberType(principal), roomIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	lastMessages, err := uc.chatRepo.GetLastMessages(roomIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	lastMessageByRoom := map[int]*entities.Message{}
+	for i := range lastMessages {
+		lastMessageByRoom[lastMessages[i].RoomID] = &lastMessages[i]
+	}
+
+	for _, room := range rooms {
+		summaries = append(summaries, entities.RoomSummary{
+			Room:        room,
+			LastMessage: lastMessageByRoom[room.ID],
+			UnreadCount: unread[room.ID],
+		})
+	}
+	return summaries, nil
+}
+
+// GetRoom retrieves a room of which the principal is a member
+func (uc *chatUseCase) GetRoom(roomID int, principal entities.Principal) (*entities.Room, error) {
+	if _, err := uc.chatRepo.GetMember(roomID, principal.ID, memberType(principal)); err != nil {
+		return nil, err
+	}
+
+	return uc.chatRepo.GetRoomByID(roomID)
+}
+
+// UpdateRoomStatus lets an admin who is a member of a room open, close or archive it
+func (uc *chatUseCase) UpdateRoomStatus(roomID int, status string, principal entities.Principal) (*entities.Room, error) {
+	if memberType(principal) != constants.ChatMemberAdmin {
+		return nil, constants.ErrForbidden
+	}
+	if status != constants.RoomStatusOpen && status != constants.RoomStatusClosed && status != constants.RoomStatusArchived {
+		return nil, constants.ErrInvalidRoomStatus
+	}
+
+	room, err := uc.GetRoom(roomID, principal)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.chatRepo.UpdateRoomStatus(room, status); err != nil {
+		return nil, err
+	}
+
+	uc.hub.Publish(roomTopic(roomID), entities.Event{Type: constants.ChatEventRoomStatus, Data: *room})
+	return room, nil
+}
+
+// ownMessage retrieves a message the principal sent to an open room
+func (uc *chatUseCase) ownMessage(roomID int, messageID int, principal entities.Principal) (*entities.Message, error) {
+	if _, err := uc.openRoom(roomID); err != nil {
+		return nil, err
+	}
+
+	message, err := uc.chatRepo.GetMessageByID(roomID, messageID)
+	if err != nil {
+		return nil, err
+	}
+	if message.SenderID != principal.ID || message.SenderType != memberType(principal) {
+		return nil, constants.ErrForbidden
+	}
+	return message, nil
+}
+
+// UpdateMessage edits the text of a message the principal sent and pushes the edited
+// message to the members of the room
+func (uc *chatUseCase) UpdateMessage(roomID int, messageID int, text string, principal entities.Principal) (*entities.Message, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, constants.ErrMessageRequired
+	}
+
+	message, err := uc.ownMessage(roomID, messageID, principal)
+	if err != nil {
+		return nil, err
+	}
+
+	editedAt := time.Now()
+	message.Message = text
+	message.EditedAt = &editedAt
+	if err := uc.chatRepo.UpdateMessage(message); err != nil {
+		return nil, err
+	}
+
+	uc.hub.Publish(roomTopic(roomID), entities.Event{Type: constants.ChatEventMessageUpdated, Data: *message})
+	return message, nil
+}
+
+// DeleteMessage soft deletes a message the principal sent and tells the members of
+// the room
+func (uc *chatUseCase) DeleteMessage(roomID int, messageID int, principal entities.Principal) error {
+	message, err := uc.ownMessage(roomID, messageID, principal)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.chatRepo.DeleteMessage(message); err != nil {
+		return err
+	}
+
+	uc.hub.Publish(roomTopic(roomID), entities.Event{Type: constants.ChatEventMessageDeleted, Data: entities.ChatMessageDeleted{
+		RoomID:    roomID,
+		MessageID: messageID,
+	}})
+	return nil
+}
+
+// GetMessagesByRoomID retrieves all messages for a specific room of which the principal is a member
+func (uc *chatUseCase) GetMessagesByRoomID(roomID int, principal entities.Principal) ([]entities.Message, error) {
+	if _, err := uc.chatRepo.GetMember(roomID, principal.ID, memberType(principal)); err != nil {
+		return nil, err
+	}
+
 	return uc.chatRepo.GetMessagesByRoomID(roomID)
 }
 
+// GetMessagesByRoomIDCursor retrieves a page of messages of a room of which the principal is a member and the cursor of the next page
+func (uc *chatUseCase) GetMessagesByRoomIDCursor(roomID int, principal entities.Principal, limit int, token string) ([]entities.Message, string, error) {
+	afterID, err := cursor.DecodeInt(token, "id ASC")
+	if err != nil {
+		return nil, "", err
+	}
+
+	if _, err := uc.chatRepo.GetMember(roomID, principal.ID, memberType(principal)); err != nil {
+		return nil, "", err
+	}
+
+	limit = cursor.Limit(limit)
+	messages, err := uc.chatRepo.GetMessagesByRoomIDAfter(roomID, limit+1, afterID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	messages, next := cursor.Page(messages, limit, "id ASC", func(message entities.Message) string {
+		return strconv.Itoa(message.ID)
+	})
+	return messages, next, nil
+}
+
 // GetUserChats retrieves all messages sent by a specific user
 func (uc *chatUseCase) GetUserChats(userID int) ([]entities.Message, error) {
 	return uc.chatRepo.GetChatsByUserID(userID)
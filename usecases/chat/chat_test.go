@@ -0,0 +1,786 @@
+package chat
+
+import (
+	"e-complaint-api/constants"
+	"e-complaint-api/entities"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockChatRepo struct {
+	mock.Mock
+}
+
+func (m *MockChatRepo) CreateChat(chat *entities.Message) error {
+	args := m.Called(chat)
+	return args.Error(0)
+}
+
+func (m *MockChatRepo) GetChatsByUserID(userID int) ([]entities.Message, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]entities.Message), args.Error(1)
+}
+
+func (m *MockChatRepo) GetChatsByAdminID(adminID int) ([]entities.Message, error) {
+	args := m.Called(adminID)
+	return args.Get(0).([]entities.Message), args.Error(1)
+}
+
+func (m *MockChatRepo) GetChatsBetweenUserAndAdmin(userID, adminID int) ([]entities.Message, error) {
+	args := m.Called(userID, adminID)
+	return args.Get(0).([]entities.Message), args.Error(1)
+}
+
+func (m *MockChatRepo) GetRoomsByMember(memberID int, memberType string, statuses []string) ([]entities.Room, error) {
+	args := m.Called(memberID, memberType, statuses)
+	return args.Get(0).([]entities.Room), args.Error(1)
+}
+
+func (m *MockChatRepo) CountUnreadByRoom(memberID int, memberType string, roomIDs []int) (map[int]int64, error) {
+	args := m.Called(memberID, memberType, roomIDs)
+	return args.Get(0).(map[int]int64), args.Error(1)
+}
+
+func (m *MockChatRepo) GetLastMessages(roomIDs []int) ([]entities.Message, error) {
+	args := m.Called(roomIDs)
+	return args.Get(0).([]entities.Message), args.Error(1)
+}
+
+func (m *MockChatRepo) UpdateRoomStatus(room *entities.Room, status string) error {
+	args := m.Called(room, status)
+	return args.Error(0)
+}
+
+func (m *MockChatRepo) GetMessageByID(roomID int, messageID int) (*entities.Message, error) {
+	args := m.Called(roomID, messageID)
+	return args.Get(0).(*entities.Message), args.Error(1)
+}
+
+func (m *MockChatRepo) UpdateMessage(message *entities.Message) error {
+	args := m.Called(message)
+	return args.Error(0)
+}
+
+func (m *MockChatRepo) DeleteMessage(message *entities.Message) error {
+	args := m.Called(message)
+	return args.Error(0)
+}
+
+func (m *MockChatRepo) CreateRoom(room *entities.Room, members []entities.RoomMember) error {
+	args := m.Called(room, members)
+	return args.Error(0)
+}
+
+func (m *MockChatRepo) GetMessagesByRoomID(roomID int) ([]entities.Message, error) {
+	args := m.Called(roomID)
+	return args.Get(0).([]entities.Message), args.Error(1)
+}
+
+func (m *MockChatRepo) GetMessagesByRoomIDAfter(roomID int, limit int, afterID int) ([]entities.Message, error) {
+	args := m.Called(roomID, limit, afterID)
+	return args.Get(0).([]entities.Message), args.Error(1)
+}
+
+func (m *MockChatRepo) GetRoomByID(ID int) (*entities.Room, error) {
+	args := m.Called(ID)
+	return args.Get(0).(*entities.Room), args.Error(1)
+}
+
+func (m *MockChatRepo) GetMember(roomID int, memberID int, memberType string) (*entities.RoomMember, error) {
+	args := m.Called(roomID, memberID, memberType)
+	return args.Get(0).(*entities.RoomMember), args.Error(1)
+}
+
+func (m *MockChatRepo) CreateMember(member *entities.RoomMember) error {
+	args := m.Called(member)
+	return args.Error(0)
+}
+
+func (m *MockChatRepo) UpdateLastRead(member *entities.RoomMember, messageID int) error {
+	args := m.Called(member, messageID)
+	return args.Error(0)
+}
+
+func (m *MockChatRepo) MemberExists(memberID int, memberType string) (bool, error) {
+	args := m.Called(memberID, memberType)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockChatRepo) GetComplaintUserID(complaintID string) (int, error) {
+	args := m.Called(complaintID)
+	return args.Int(0), args.Error(1)
+}
+
+type MockPubSub struct {
+	mock.Mock
+}
+
+func (m *MockPubSub) Publish(topic string, event entities.Event) {
+	m.Called(topic, event)
+}
+
+func (m *MockPubSub) Subscribe(topic string) (<-chan entities.Event, func()) {
+	args := m.Called(topic)
+	return args.Get(0).(<-chan entities.Event), args.Get(1).(func())
+}
+
+var (
+	user        = entities.Principal{ID: 5, Role: "user"}
+	admin       = entities.Principal{ID: 2, Role: "admin"}
+	userMember  = &entities.RoomMember{ID: 1, RoomID: 1, MemberID: 5, MemberType: constants.ChatMemberUser, LastReadMessageID: 3}
+	openRoom    = &entities.Room{ID: 1, Status: constants.RoomStatusOpen}
+	errDatabase = errors.New("database error")
+)
+
+func TestSendMessage(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		message := &entities.Message{RoomID: 1, SenderID: 5, SenderType: constants.ChatMemberUser, Message: "Halo"}
+		repo := new(MockChatRepo)
+		repo.On("GetMember", 1, 5, constants.ChatMemberUser).Return(userMember, nil)
+		repo.On("GetRoomByID", 1).Return(openRoom, nil)
+		repo.On("CreateChat", message).Return(nil)
+		hub := new(MockPubSub)
+		hub.On("Publish", "chat.room.1", entities.Event{Type: constants.ChatEventMessage, Data: *message}).Return()
+
+		err := NewChatUseCase(repo, hub).SendMessage(message)
+		assert.NoError(t, err)
+		hub.AssertExpectations(t)
+	})
+
+	t.Run("failed empty message", func(t *testing.T) {
+		err := NewChatUseCase(new(MockChatRepo), new(MockPubSub)).SendMessage(&entities.Message{RoomID: 1, Message: " "})
+		assert.Equal(t, constants.ErrMessageRequired, err)
+	})
+
+	t.Run("failed not a member", func(t *testing.T) {
+		repo := new(MockChatRepo)
+		repo.On("GetMember", 1, 6, constants.ChatMemberUser).Return((*entities.RoomMember)(nil), constants.ErrNotRoomMember)
+
+		err := NewChatUseCase(repo, new(MockPubSub)).SendMessage(&entities.Message{RoomID: 1, SenderID: 6, SenderType: constants.ChatMemberUser, Message: "Halo"})
+		assert.Equal(t, constants.ErrNotRoomMember, err)
+		repo.AssertNotCalled(t, "CreateChat", mock.Anything)
+	})
+
+	t.Run("failed creating message", func(t *testing.T) {
+		repo := new(MockChatRepo)
+		repo.On("GetMember", 1, 5, constants.ChatMemberUser).Return(userMember, nil)
+		repo.On("GetRoomByID", 1).Return(openRoom, nil)
+		repo.On("CreateChat", mock.Anything).Return(errDatabase)
+		hub := new(MockPubSub)
+
+		err := NewChatUseCase(repo, hub).SendMessage(&entities.Message{RoomID: 1, SenderID: 5, SenderType: constants.ChatMemberUser, Message: "Halo"})
+		assert.Equal(t, errDatabase, err)
+		hub.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+	})
+
+	t.Run("failed room closed", func(t *testing.T) {
+		repo := new(MockChatRepo)
+		repo.On("GetMember", 1, 5, constants.ChatMemberUser).Return(userMember, nil)
+		repo.On("GetRoomByID", 1).Return(&entities.Room{ID: 1, Status: constants.RoomStatusClosed}, nil)
+
+		err := NewChatUseCase(repo, new(MockPubSub)).SendMessage(&entities.Message{RoomID: 1, SenderID: 5, SenderType: constants.ChatMemberUser, Message: "Halo"})
+		assert.Equal(t, constants.ErrRoomClosed, err)
+		repo.AssertNotCalled(t, "CreateChat", mock.Anything)
+	})
+
+	t.Run("failed room not found", func(t *testing.T) {
+		repo := new(MockChatRepo)
+		repo.On("GetMember", 1, 5, constants.ChatMemberUser).Return(userMember, nil)
+		repo.On("GetRoomByID", 1).Return((*entities.Room)(nil), constants.ErrRoomNotFound)
+
+		err := NewChatUseCase(repo, new(MockPubSub)).SendMessage(&entities.Message{RoomID: 1, SenderID: 5, SenderType: constants.ChatMemberUser, Message: "Halo"})
+		assert.Equal(t, constants.ErrRoomNotFound, err)
+	})
+}
+
+func TestTyping(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		repo := new(MockChatRepo)
+		repo.On("GetMember", 1, 5, constants.ChatMemberUser).Return(userMember, nil)
+		hub := new(MockPubSub)
+		hub.On("Publish", "chat.room.1", entities.Event{Type: constants.ChatEventTyping, Data: entities.ChatTyping{
+			RoomID: 1, SenderID: 5, SenderType: constants.ChatMemberUser,
+		}}).Return()
+
+		err := NewChatUseCase(repo, hub).Typing(1, user)
+		assert.NoError(t, err)
+		hub.AssertExpectations(t)
+	})
+
+	t.Run("failed not a member", func(t *testing.T) {
+		repo := new(MockChatRepo)
+		repo.On("GetMember", 1, 2, constants.ChatMemberAdmin).Return((*entities.RoomMember)(nil), constants.ErrNotRoomMember)
+
+		err := NewChatUseCase(repo, new(MockPubSub)).Typing(1, admin)
+		assert.Equal(t, constants.ErrNotRoomMember, err)
+	})
+}
+
+func TestMarkAsRead(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		repo := new(MockChatRepo)
+		repo.On("GetMember", 1, 5, constants.ChatMemberUser).Return(userMember, nil)
+		repo.On("UpdateLastRead", userMember, 7).Return(nil)
+		hub := new(MockPubSub)
+		hub.On("Publish", "chat.room.1", entities.Event{Type: constants.ChatEventRead, Data: entities.ChatReadReceipt{
+			RoomID: 1, ReaderID: 5, ReaderType: constants.ChatMemberUser, MessageID: 7,
+		}}).Return()
+
+		err := NewChatUseCase(repo, hub).MarkAsRead(1, 7, user)
+		assert.NoError(t, err)
+		hub.AssertExpectations(t)
+	})
+
+	t.Run("success already read", func(t *testing.T) {
+		repo := new(MockChatRepo)
+		repo.On("GetMember", 1, 5, constants.ChatMemberUser).Return(userMember, nil)
+		hub := new(MockPubSub)
+
+		err := NewChatUseCase(repo, hub).MarkAsRead(1, 3, user)
+		assert.NoError(t, err)
+		repo.AssertNotCalled(t, "UpdateLastRead", mock.Anything, mock.Anything)
+		hub.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+	})
+
+	t.Run("failed invalid message id", func(t *testing.T) {
+		err := NewChatUseCase(new(MockChatRepo), new(MockPubSub)).MarkAsRead(1, 0, user)
+		assert.Equal(t, constants.ErrInvalidIDFormat, err)
+	})
+
+	t.Run("failed not a member", func(t *testing.T) {
+		repo := new(MockChatRepo)
+		repo.On("GetMember", 1, 5, constants.ChatMemberUser).Return((*entities.RoomMember)(nil), constants.ErrNotRoomMember)
+
+		err := NewChatUseCase(repo, new(MockPubSub)).MarkAsRead(1, 7, user)
+		assert.Equal(t, constants.ErrNotRoomMember, err)
+	})
+
+	t.Run("failed updating", func(t *testing.T) {
+		repo := new(MockChatRepo)
+		repo.On("GetMember", 1, 5, constants.ChatMemberUser).Return(userMember, nil)
+		repo.On("UpdateLastRead", userMember, 7).Return(errDatabase)
+
+		err := NewChatUseCase(repo, new(MockPubSub)).MarkAsRead(1, 7, user)
+		assert.Equal(t, errDatabase, err)
+	})
+}
+
+func TestSubscribe(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		repo := new(MockChatRepo)
+		repo.On("GetMember", 1, 5, constants.ChatMemberUser).Return(userMember, nil)
+		hub := new(MockPubSub)
+		events := make(chan entities.Event)
+		hub.On("Subscribe", "chat.room.1").Return((<-chan entities.Event)(events), func() {})
+
+		received, unsubscribe, err := NewChatUseCase(repo, hub).Subscribe(1, user)
+		assert.NoError(t, err)
+		assert.Equal(t, (<-chan entities.Event)(events), received)
+		assert.NotNil(t, unsubscribe)
+	})
+
+	t.Run("failed not a member", func(t *testing.T) {
+		repo := new(MockChatRepo)
+		repo.On("GetMember", 1, 5, constants.ChatMemberUser).Return((*entities.RoomMember)(nil), constants.ErrNotRoomMember)
+		hub := new(MockPubSub)
+
+		_, _, err := NewChatUseCase(repo, hub).Subscribe(1, user)
+		assert.Equal(t, constants.ErrNotRoomMember, err)
+		hub.AssertNotCalled(t, "Subscribe", mock.Anything)
+	})
+}
+
+func TestCreateRoom(t *testing.T) {
+	complaintID := "C-123"
+	adminID := 2
+	userID := 5
+
+	t.Run("success user with admin", func(t *testing.T) {
+		repo := new(MockChatRepo)
+		repo.On("MemberExists", 2, constants.ChatMemberAdmin).Return(true, nil)
+		repo.On("CreateRoom", &entities.Room{Name: "Bantuan"}, []entities.RoomMember{
+			{MemberID: 5, MemberType: constants.ChatMemberUser},
+			{MemberID: 2, MemberType: constants.ChatMemberAdmin},
+		}).Return(nil)
+
+		room, err := NewChatUseCase(repo, nil).CreateRoom("Bantuan", nil, &adminID, user)
+		assert.NoError(t, err)
+		assert.Equal(t, "Bantuan", room.Name)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("success user for own complaint", func(t *testing.T) {
+		repo := new(MockChatRepo)
+		repo.On("GetComplaintUserID", "C-123").Return(5, nil)
+		repo.On("CreateRoom", &entities.Room{Name: "Aduan C-123", ComplaintID: &complaintID}, []entities.RoomMember{
+			{MemberID: 5, MemberType: constants.ChatMemberUser},
+		}).Return(nil)
+
+		room, err := NewChatUseCase(repo, nil).CreateRoom("Aduan C-123", &complaintID, nil, user)
+		assert.NoError(t, err)
+		assert.Equal(t, &complaintID, room.ComplaintID)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("success admin for complaint", func(t *testing.T) {
+		repo := new(MockChatRepo)
+		repo.On("GetComplaintUserID", "C-123").Return(5, nil)
+		repo.On("MemberExists", 5, constants.ChatMemberUser).Return(true, nil)
+		repo.On("CreateRoom", mock.Anything, []entities.RoomMember{
+			{MemberID: 2, MemberType: constants.ChatMemberAdmin},
+			{MemberID: 5, MemberType: constants.ChatMemberUser},
+		}).Return(nil)
+
+		_, err := NewChatUseCase(repo, nil).CreateRoom("Aduan C-123", &complaintID, nil, admin)
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("failed name required", func(t *testing.T) {
+		_, err := NewChatUseCase(new(MockChatRepo), nil).CreateRoom("", nil, &adminID, user)
+		assert.Equal(t, constants.ErrRoomNameRequired, err)
+	})
+
+	t.Run("failed member required", func(t *testing.T) {
+		_, err := NewChatUseCase(new(MockChatRepo), nil).CreateRoom("Bantuan", nil, nil, user)
+		assert.Equal(t, constants.ErrRoomMemberRequired, err)
+	})
+
+	t.Run("failed complaint not found", func(t *testing.T) {
+		repo := new(MockChatRepo)
+		repo.On("GetComplaintUserID", "C-123").Return(0, constants.ErrComplaintNotFound)
+
+		_, err := NewChatUseCase(repo, nil).CreateRoom("Aduan C-123", &complaintID, nil, user)
+		assert.Equal(t, constants.ErrComplaintNotFound, err)
+	})
+
+	t.Run("failed complaint of another user", func(t *testing.T) {
+		repo := new(MockChatRepo)
+		repo.On("GetComplaintUserID", "C-123").Return(6, nil)
+
+		_, err := NewChatUseCase(repo, nil).CreateRoom("Aduan C-123", &complaintID, nil, user)
+		assert.Equal(t, constants.ErrForbidden, err)
+	})
+
+	t.Run("failed admin not found", func(t *testing.T) {
+		repo := new(MockChatRepo)
+		repo.On("MemberExists", 2, constants.ChatMemberAdmin).Return(false, nil)
+
+		_, err := NewChatUseCase(repo, nil).CreateRoom("Bantuan", nil, &adminID, user)
+		assert.Equal(t, constants.ErrAdminNotFound, err)
+	})
+
+	t.Run("failed user not found", func(t *testing.T) {
+		repo := new(MockChatRepo)
+		repo.On("MemberExists", 5, constants.ChatMemberUser).Return(false, nil)
+
+		_, err := NewChatUseCase(repo, nil).CreateRoom("Bantuan", nil, &userID, admin)
+		assert.Equal(t, constants.ErrUserNotFound, err)
+	})
+
+	t.Run("failed checking member", func(t *testing.T) {
+		repo := new(MockChatRepo)
+		repo.On("MemberExists", 5, constants.ChatMemberUser).Return(false, errDatabase)
+
+		_, err := NewChatUseCase(repo, nil).CreateRoom("Bantuan", nil, &userID, admin)
+		assert.Equal(t, errDatabase, err)
+	})
+
+	t.Run("failed creating room", func(t *testing.T) {
+		repo := new(MockChatRepo)
+		repo.On("MemberExists", 5, constants.ChatMemberUser).Return(true, nil)
+		repo.On("CreateRoom", mock.Anything, mock.Anything).Return(errDatabase)
+
+		_, err := NewChatUseCase(repo, nil).CreateRoom("Bantuan", nil, &userID, admin)
+		assert.Equal(t, errDatabase, err)
+	})
+}
+
+func TestJoinRoom(t *testing.T) {
+	complaintID := "C-123"
+	complaintRoom := &entities.Room{ID: 1, Name: "Aduan C-123", ComplaintID: &complaintID}
+
+	t.Run("success", func(t *testing.T) {
+		repo := new(MockChatRepo)
+		repo.On("GetRoomByID", 1).Return(complaintRoom, nil)
+		repo.On("GetMember", 1, 2, constants.ChatMemberAdmin).Return((*entities.RoomMember)(nil), constants.ErrNotRoomMember)
+		repo.On("CreateMember", &entities.RoomMember{RoomID: 1, MemberID: 2, MemberType: constants.ChatMemberAdmin}).Return(nil)
+
+		room, err := NewChatUseCase(repo, nil).JoinRoom(1, admin)
+		assert.NoError(t, err)
+		assert.Equal(t, complaintRoom, room)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("success already a member", func(t *testing.T) {
+		repo := new(MockChatRepo)
+		repo.On("GetRoomByID", 1).Return(complaintRoom, nil)
+		repo.On("GetMember", 1, 2, constants.ChatMemberAdmin).Return(&entities.RoomMember{}, nil)
+
+		_, err := NewChatUseCase(repo, nil).JoinRoom(1, admin)
+		assert.NoError(t, err)
+		repo.AssertNotCalled(t, "CreateMember", mock.Anything)
+	})
+
+	t.Run("failed user", func(t *testing.T) {
+		_, err := NewChatUseCase(new(MockChatRepo), nil).JoinRoom(1, user)
+		assert.Equal(t, constants.ErrForbidden, err)
+	})
+
+	t.Run("failed room not found", func(t *testing.T) {
+		repo := new(MockChatRepo)
+		repo.On("GetRoomByID", 1).Return((*entities.Room)(nil), constants.ErrRoomNotFound)
+
+		_, err := NewChatUseCase(repo, nil).JoinRoom(1, admin)
+		assert.Equal(t, constants.ErrRoomNotFound, err)
+	})
+
+	t.Run("failed not a complaint room", func(t *testing.T) {
+		repo := new(MockChatRepo)
+		repo.On("GetRoomByID", 1).Return(&entities.Room{ID: 1}, nil)
+
+		_, err := NewChatUseCase(repo, nil).JoinRoom(1, admin)
+		assert.Equal(t, constants.ErrRoomNotJoinable, err)
+	})
+
+	t.Run("failed getting member", func(t *testing.T) {
+		repo := new(MockChatRepo)
+		repo.On("GetRoomByID", 1).Return(complaintRoom, nil)
+		repo.On("GetMember", 1, 2, constants.ChatMemberAdmin).Return((*entities.RoomMember)(nil), errDatabase)
+
+		_, err := NewChatUseCase(repo, nil).JoinRoom(1, admin)
+		assert.Equal(t, errDatabase, err)
+	})
+
+	t.Run("failed creating member", func(t *testing.T) {
+		repo := new(MockChatRepo)
+		repo.On("GetRoomByID", 1).Return(complaintRoom, nil)
+		repo.On("GetMember", 1, 2, constants.ChatMemberAdmin).Return((*entities.RoomMember)(nil), constants.ErrNotRoomMember)
+		repo.On("CreateMember", mock.Anything).Return(errDatabase)
+
+		_, err := NewChatUseCase(repo, nil).JoinRoom(1, admin)
+		assert.Equal(t, errDatabase, err)
+	})
+}
+
+func TestGetMessagesByRoomID(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		repo := new(MockChatRepo)
+		repo.On("GetMember", 1, 5, constants.ChatMemberUser).Return(userMember, nil)
+		repo.On("GetMessagesByRoomID", 1).Return([]entities.Message{{ID: 1}}, nil)
+
+		messages, err := NewChatUseCase(repo, nil).GetMessagesByRoomID(1, user)
+		assert.NoError(t, err)
+		assert.Len(t, messages, 1)
+	})
+
+	t.Run("failed not a member", func(t *testing.T) {
+		repo := new(MockChatRepo)
+		repo.On("GetMember", 1, 5, constants.ChatMemberUser).Return((*entities.RoomMember)(nil), constants.ErrNotRoomMember)
+
+		_, err := NewChatUseCase(repo, nil).GetMessagesByRoomID(1, user)
+		assert.Equal(t, constants.ErrNotRoomMember, err)
+	})
+}
+
+func TestGetMessagesByRoomIDCursor(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		repo := new(MockChatRepo)
+		repo.On("GetMember", 1, 5, constants.ChatMemberUser).Return(userMember, nil)
+		repo.On("GetMessagesByRoomIDAfter", 1, 2, 0).Return([]entities.Message{{ID: 1}, {ID: 2}}, nil)
+
+		messages, next, err := NewChatUseCase(repo, nil).GetMessagesByRoomIDCursor(1, user, 1, "")
+		assert.NoError(t, err)
+		assert.Len(t, messages, 1)
+		assert.NotEmpty(t, next)
+	})
+
+	t.Run("failed invalid cursor", func(t *testing.T) {
+		_, _, err := NewChatUseCase(new(MockChatRepo), nil).GetMessagesByRoomIDCursor(1, user, 1, "invalid")
+		assert.Equal(t, constants.ErrInvalidCursor, err)
+	})
+
+	t.Run("failed not a member", func(t *testing.T) {
+		repo := new(MockChatRepo)
+		repo.On("GetMember", 1, 5, constants.ChatMemberUser).Return((*entities.RoomMember)(nil), constants.ErrNotRoomMember)
+
+		_, _, err := NewChatUseCase(repo, nil).GetMessagesByRoomIDCursor(1, user, 1, "")
+		assert.Equal(t, constants.ErrNotRoomMember, err)
+	})
+
+	t.Run("failed getting messages", func(t *testing.T) {
+		repo := new(MockChatRepo)
+		repo.On("GetMember", 1, 5, constants.ChatMemberUser).Return(userMember, nil)
+		repo.On("GetMessagesByRoomIDAfter", 1, 2, 0).Return([]entities.Message{}, errDatabase)
+
+		_, _, err := NewChatUseCase(repo, nil).GetMessagesByRoomIDCursor(1, user, 1, "")
+		assert.Equal(t, errDatabase, err)
+	})
+}
+
+func TestGetChats(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		repo := new(MockChatRepo)
+		repo.On("GetChatsByUserID", 5).Return([]entities.Message{{ID: 1}}, nil)
+		repo.On("GetChatsByAdminID", 2).Return([]entities.Message{{ID: 2}}, nil)
+		repo.On("GetChatsBetweenUserAndAdmin", 5, 2).Return([]entities.Message{{ID: 1}, {ID: 2}}, nil)
+		repo.On("GetRoomByID", 1).Return(&entities.Room{ID: 1}, nil)
+		useCase := NewChatUseCase(repo, nil)
+
+		messages, err := useCase.GetUserChats(5)
+		assert.NoError(t, err)
+		assert.Len(t, messages, 1)
+
+		messages, err = useCase.GetAllChatsByUser(5)
+		assert.NoError(t, err)
+		assert.Len(t, messages, 1)
+
+		messages, err = useCase.GetAdminChats(2)
+		assert.NoError(t, err)
+		assert.Len(t, messages, 1)
+
+		messages, err = useCase.GetConversation(5, 2)
+		assert.NoError(t, err)
+		assert.Len(t, messages, 2)
+
+		room, err := useCase.GetRoomByID(1)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, room.ID)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		repo := new(MockChatRepo)
+		repo.On("GetChatsByUserID", 5).Return([]entities.Message{}, errDatabase)
+
+		_, err := NewChatUseCase(repo, nil).GetAllChatsByUser(5)
+		assert.Equal(t, errDatabase, err)
+	})
+}
+
+func TestGetRooms(t *testing.T) {
+	active := []string{constants.RoomStatusOpen, constants.RoomStatusClosed}
+
+	t.Run("success", func(t *testing.T) {
+		repo := new(MockChatRepo)
+		repo.On("GetRoomsByMember", 5, constants.ChatMemberUser, active).Return([]entities.Room{{ID: 2}, {ID: 1}}, nil)
+		repo.On("CountUnreadByRoom", 5, constants.ChatMemberUser, []int{2, 1}).Return(map[int]int64{2: 3}, nil)
+		repo.On("GetLastMessages", []int{2, 1}).Return([]entities.Message{{ID: 9, RoomID: 2}}, nil)
+
+		rooms, err := NewChatUseCase(repo, nil).GetRooms(user, "")
+		assert.NoError(t, err)
+		assert.Len(t, rooms, 2)
+		assert.Equal(t, int64(3), rooms[0].UnreadCount)
+		assert.Equal(t, 9, rooms[0].LastMessage.ID)
+		assert.Equal(t, int64(0), rooms[1].UnreadCount)
+		assert.Nil(t, rooms[1].LastMessage)
+	})
+
+	t.Run("success archived", func(t *testing.T) {
+		repo := new(MockChatRepo)
+		repo.On("GetRoomsByMember", 2, constants.ChatMemberAdmin, []string{constants.RoomStatusArchived}).Return([]entities.Room{}, nil)
+
+		rooms, err := NewChatUseCase(repo, nil).GetRooms(admin, constants.RoomStatusArchived)
+		assert.NoError(t, err)
+		assert.Empty(t, rooms)
+		repo.AssertNotCalled(t, "CountUnreadByRoom", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("failed invalid status", func(t *testing.T) {
+		_, err := NewChatUseCase(new(MockChatRepo), nil).GetRooms(user, "deleted")
+		assert.Equal(t, constants.ErrInvalidRoomStatus, err)
+	})
+
+	t.Run("failed getting rooms", func(t *testing.T) {
+		repo := new(MockChatRepo)
+		repo.On("GetRoomsByMember", 5, constants.ChatMemberUser, active).Return([]entities.Room{}, errDatabase)
+
+		_, err := NewChatUseCase(repo, nil).GetRooms(user, "")
+		assert.Equal(t, errDatabase, err)
+	})
+
+	t.Run("failed counting unread", func(t *testing.T) {
+		repo := new(MockChatRepo)
+		repo.On("GetRoomsByMember", 5, constants.ChatMemberUser, active).Return([]entities.Room{{ID: 1}}, nil)
+		repo.On("CountUnreadByRoom", 5, constants.ChatMemberUser, []int{1}).Return(map[int]int64{}, errDatabase)
+
+		_, err := NewChatUseCase(repo, nil).GetRooms(user, "")
+		assert.Equal(t, errDatabase, err)
+	})
+
+	t.Run("failed getting last messages", func(t *testing.T) {
+		repo := new(MockChatRepo)
+		repo.On("GetRoomsByMember", 5, constants.ChatMemberUser, active).Return([]entities.Room{{ID: 1}}, nil)
+		repo.On("CountUnreadByRoom", 5, constants.ChatMemberUser, []int{1}).Return(map[int]int64{}, nil)
+		repo.On("GetLastMessages", []int{1}).Return([]entities.Message{}, errDatabase)
+
+		_, err := NewChatUseCase(repo, nil).GetRooms(user, "")
+		assert.Equal(t, errDatabase, err)
+	})
+}
+
+func TestGetRoom(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		repo := new(MockChatRepo)
+		repo.On("GetMember", 1, 5, constants.ChatMemberUser).Return(userMember, nil)
+		repo.On("GetRoomByID", 1).Return(openRoom, nil)
+
+		room, err := NewChatUseCase(repo, nil).GetRoom(1, user)
+		assert.NoError(t, err)
+		assert.Equal(t, openRoom, room)
+	})
+
+	t.Run("failed not a member", func(t *testing.T) {
+		repo := new(MockChatRepo)
+		repo.On("GetMember", 1, 5, constants.ChatMemberUser).Return((*entities.RoomMember)(nil), constants.ErrNotRoomMember)
+
+		_, err := NewChatUseCase(repo, nil).GetRoom(1, user)
+		assert.Equal(t, constants.ErrNotRoomMember, err)
+	})
+}
+
+func TestUpdateRoomStatus(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		room := &entities.Room{ID: 1, Status: constants.RoomStatusOpen}
+		repo := new(MockChatRepo)
+		repo.On("GetMember", 1, 2, constants.ChatMemberAdmin).Return(&entities.RoomMember{}, nil)
+		repo.On("GetRoomByID", 1).Return(room, nil)
+		repo.On("UpdateRoomStatus", room, constants.RoomStatusClosed).Run(func(args mock.Arguments) {
+			args.Get(0).(*entities.Room).Status = constants.RoomStatusClosed
+		}).Return(nil)
+		hub := new(MockPubSub)
+		hub.On("Publish", "chat.room.1", entities.Event{Type: constants.ChatEventRoomStatus, Data: entities.Room{ID: 1, Status: constants.RoomStatusClosed}}).Return()
+
+		updated, err := NewChatUseCase(repo, hub).UpdateRoomStatus(1, constants.RoomStatusClosed, admin)
+		assert.NoError(t, err)
+		assert.Equal(t, constants.RoomStatusClosed, updated.Status)
+		hub.AssertExpectations(t)
+	})
+
+	t.Run("failed user", func(t *testing.T) {
+		_, err := NewChatUseCase(new(MockChatRepo), nil).UpdateRoomStatus(1, constants.RoomStatusClosed, user)
+		assert.Equal(t, constants.ErrForbidden, err)
+	})
+
+	t.Run("failed invalid status", func(t *testing.T) {
+		_, err := NewChatUseCase(new(MockChatRepo), nil).UpdateRoomStatus(1, "deleted", admin)
+		assert.Equal(t, constants.ErrInvalidRoomStatus, err)
+	})
+
+	t.Run("failed not a member", func(t *testing.T) {
+		repo := new(MockChatRepo)
+		repo.On("GetMember", 1, 2, constants.ChatMemberAdmin).Return((*entities.RoomMember)(nil), constants.ErrNotRoomMember)
+
+		_, err := NewChatUseCase(repo, nil).UpdateRoomStatus(1, constants.RoomStatusArchived, admin)
+		assert.Equal(t, constants.ErrNotRoomMember, err)
+	})
+
+	t.Run("failed updating", func(t *testing.T) {
+		repo := new(MockChatRepo)
+		repo.On("GetMember", 1, 2, constants.ChatMemberAdmin).Return(&entities.RoomMember{}, nil)
+		repo.On("GetRoomByID", 1).Return(&entities.Room{ID: 1}, nil)
+		repo.On("UpdateRoomStatus", mock.Anything, constants.RoomStatusArchived).Return(errDatabase)
+
+		_, err := NewChatUseCase(repo, nil).UpdateRoomStatus(1, constants.RoomStatusArchived, admin)
+		assert.Equal(t, errDatabase, err)
+	})
+}
+
+func TestUpdateMessage(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		message := &entities.Message{ID: 7, RoomID: 1, SenderID: 5, SenderType: constants.ChatMemberUser, Message: "Halo"}
+		repo := new(MockChatRepo)
+		repo.On("GetRoomByID", 1).Return(openRoom, nil)
+		repo.On("GetMessageByID", 1, 7).Return(message, nil)
+		repo.On("UpdateMessage", message).Return(nil)
+		hub := new(MockPubSub)
+		hub.On("Publish", "chat.room.1", mock.MatchedBy(func(event entities.Event) bool {
+			return event.Type == constants.ChatEventMessageUpdated && event.Data.(entities.Message).Message == "Halo, Admin"
+		})).Return()
+
+		updated, err := NewChatUseCase(repo, hub).UpdateMessage(1, 7, "Halo, Admin", user)
+		assert.NoError(t, err)
+		assert.Equal(t, "Halo, Admin", updated.Message)
+		assert.NotNil(t, updated.EditedAt)
+		hub.AssertExpectations(t)
+	})
+
+	t.Run("failed empty message", func(t *testing.T) {
+		_, err := NewChatUseCase(new(MockChatRepo), nil).UpdateMessage(1, 7, "", user)
+		assert.Equal(t, constants.ErrMessageRequired, err)
+	})
+
+	t.Run("failed room closed", func(t *testing.T) {
+		repo := new(MockChatRepo)
+		repo.On("GetRoomByID", 1).Return(&entities.Room{ID: 1, Status: constants.RoomStatusArchived}, nil)
+
+		_, err := NewChatUseCase(repo, nil).UpdateMessage(1, 7, "Halo", user)
+		assert.Equal(t, constants.ErrRoomClosed, err)
+	})
+
+	t.Run("failed message not found", func(t *testing.T) {
+		repo := new(MockChatRepo)
+		repo.On("GetRoomByID", 1).Return(openRoom, nil)
+		repo.On("GetMessageByID", 1, 7).Return((*entities.Message)(nil), constants.ErrMessageNotFound)
+
+		_, err := NewChatUseCase(repo, nil).UpdateMessage(1, 7, "Halo", user)
+		assert.Equal(t, constants.ErrMessageNotFound, err)
+	})
+
+	t.Run("failed message of another member", func(t *testing.T) {
+		repo := new(MockChatRepo)
+		repo.On("GetRoomByID", 1).Return(openRoom, nil)
+		repo.On("GetMessageByID", 1, 7).Return(&entities.Message{ID: 7, SenderID: 5, SenderType: constants.ChatMemberAdmin}, nil)
+
+		_, err := NewChatUseCase(repo, nil).UpdateMessage(1, 7, "Halo", user)
+		assert.Equal(t, constants.ErrForbidden, err)
+	})
+
+	t.Run("failed updating", func(t *testing.T) {
+		repo := new(MockChatRepo)
+		repo.On("GetRoomByID", 1).Return(openRoom, nil)
+		repo.On("GetMessageByID", 1, 7).Return(&entities.Message{ID: 7, SenderID: 5, SenderType: constants.ChatMemberUser}, nil)
+		repo.On("UpdateMessage", mock.Anything).Return(errDatabase)
+
+		_, err := NewChatUseCase(repo, nil).UpdateMessage(1, 7, "Halo", user)
+		assert.Equal(t, errDatabase, err)
+	})
+}
+
+func TestDeleteMessage(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		message := &entities.Message{ID: 7, RoomID: 1, SenderID: 2, SenderType: constants.ChatMemberAdmin}
+		repo := new(MockChatRepo)
+		repo.On("GetRoomByID", 1).Return(openRoom, nil)
+		repo.On("GetMessageByID", 1, 7).Return(message, nil)
+		repo.On("DeleteMessage", message).Return(nil)
+		hub := new(MockPubSub)
+		hub.On("Publish", "chat.room.1", entities.Event{Type: constants.ChatEventMessageDeleted, Data: entities.ChatMessageDeleted{RoomID: 1, MessageID: 7}}).Return()
+
+		err := NewChatUseCase(repo, hub).DeleteMessage(1, 7, admin)
+		assert.NoError(t, err)
+		hub.AssertExpectations(t)
+	})
+
+	t.Run("failed message of another member", func(t *testing.T) {
+		repo := new(MockChatRepo)
+		repo.On("GetRoomByID", 1).Return(openRoom, nil)
+		repo.On("GetMessageByID", 1, 7).Return(&entities.Message{ID: 7, SenderID: 5, SenderType: constants.ChatMemberUser}, nil)
+
+		err := NewChatUseCase(repo, nil).DeleteMessage(1, 7, admin)
+		assert.Equal(t, constants.ErrForbidden, err)
+		repo.AssertNotCalled(t, "DeleteMessage", mock.Anything)
+	})
+
+	t.Run("failed deleting", func(t *testing.T) {
+		repo := new(MockChatRepo)
+		repo.On("GetRoomByID", 1).Return(openRoom, nil)
+		repo.On("GetMessageByID", 1, 7).Return(&entities.Message{ID: 7, SenderID: 2, SenderType: constants.ChatMemberAdmin}, nil)
+		repo.On("DeleteMessage", mock.Anything).Return(errDatabase)
+
+		err := NewChatUseCase(repo, nil).DeleteMessage(1, 7, admin)
+		assert.Equal(t, errDatabase, err)
+	})
+}
@@ -1,7 +1,9 @@
 package chatbot
 
 import (
+	"context"
 	"e-complaint-api/entities"
+	"e-complaint-api/llm"
 	"strconv"
 )
 
@@ -9,19 +11,19 @@ type ChatbotUseCase struct {
 	chatbotRepo   entities.ChatbotRepositoryInterface
 	faqRepo       entities.FaqRepositoryInterface
 	complaintRepo entities.ComplaintRepositoryInterface
-	OpenAIAPI     entities.ChatbotOpenAIAPIInterface
+	llmProvider   entities.LLMProviderInterface
 }
 
-func NewChatbotUseCase(chatbotRepo entities.ChatbotRepositoryInterface, faqRepo entities.FaqRepositoryInterface, complaintRepo entities.ComplaintRepositoryInterface, OpenAIAPI entities.ChatbotOpenAIAPIInterface) *ChatbotUseCase {
+func NewChatbotUseCase(chatbotRepo entities.ChatbotRepositoryInterface, faqRepo entities.FaqRepositoryInterface, complaintRepo entities.ComplaintRepositoryInterface, llmProvider entities.LLMProviderInterface) *ChatbotUseCase {
 	return &ChatbotUseCase{
 		chatbotRepo:   chatbotRepo,
 		faqRepo:       faqRepo,
 		complaintRepo: complaintRepo,
-		OpenAIAPI:     OpenAIAPI,
+		llmProvider:   llmProvider,
 	}
 }
 
-func (u *ChatbotUseCase) GetChatCompletion(chatbot *entities.Chatbot) error {
+func (u *ChatbotUseCase) GetChatCompletion(ctx context.Context, chatbot *entities.Chatbot) error {
 	faq, err := u.faqRepo.GetAll()
 	if err != nil {
 		return err
@@ -53,7 +55,7 @@ func (u *ChatbotUseCase) GetChatCompletion(chatbot *entities.Chatbot) error {
 
 	prompt = append(prompt, "Tolong anda sebagai Customer Service untuk memberikan respon kepada user berdasarkan FAQ dan Riwayat Aduan User di atas")
 
-	botResponse, err := u.OpenAIAPI.GetChatCompletion(prompt, chatbot.UserMessage)
+	botResponse, err := u.llmProvider.ChatCompletion(ctx, llm.Prompt(prompt, chatbot.UserMessage))
 	if err != nil {
 		return err
 	}
@@ -1,8 +1,10 @@
 package chatbot
 
 import (
+	"context"
 	"e-complaint-api/constants"
 	"e-complaint-api/entities"
+	"e-complaint-api/llm"
 	"errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -63,6 +65,21 @@ func (m *Complaint) GetMetaData(limit int, page int, search string, filter map[s
 	return args.Get(0).(entities.Metadata), args.Error(1)
 }
 
+func (m *Complaint) GetAfter(limit int, afterID string, afterValue string, search string, filter map[string]interface{}, sortBy string, sortType string) ([]entities.Complaint, error) {
+	args := m.Called(limit, afterID, afterValue, search, filter, sortBy, sortType)
+	return args.Get(0).([]entities.Complaint), args.Error(1)
+}
+
+func (m *Complaint) GetAfterWithProcesses(limit int, afterID string, afterValue string, search string, filter map[string]interface{}, sortBy string, sortType string) ([]entities.Complaint, error) {
+	args := m.Called(limit, afterID, afterValue, search, filter, sortBy, sortType)
+	return args.Get(0).([]entities.Complaint), args.Error(1)
+}
+
+func (m *Complaint) GetWithLocation(limit int, search string, filter map[string]interface{}) ([]entities.Complaint, error) {
+	args := m.Called(limit, search, filter)
+	return args.Get(0).([]entities.Complaint), args.Error(1)
+}
+
 func (m *Complaint) GetByID(id string) (entities.Complaint, error) {
 	args := m.Called(id)
 	return args.Get(0).(entities.Complaint), args.Error(1)
@@ -103,11 +120,6 @@ func (m *Complaint) GetStatus(id string) (string, error) {
 	return args.String(0), args.Error(1)
 }
 
-func (m *Complaint) Import(complaints []entities.Complaint) error {
-	args := m.Called(complaints)
-	return args.Error(0)
-}
-
 func (m *Complaint) IncreaseTotalLikes(id string) error {
 	args := m.Called(id)
 	return args.Error(0)
@@ -123,15 +135,23 @@ func (m *Complaint) GetComplaintIDsByUserID(userId int) ([]string, error) {
 	return args.Get(0).([]string), args.Error(1)
 }
 
-type OpenAIAPI struct {
+type LLMProvider struct {
 	mock.Mock
 }
 
-func (m *OpenAIAPI) GetChatCompletion(prompt []string, userMessage string) (string, error) {
-	args := m.Called(prompt, userMessage)
+func (m *LLMProvider) ChatCompletion(ctx context.Context, messages []entities.LLMMessage) (string, error) {
+	args := m.Called(ctx, messages)
 	return args.String(0), args.Error(1)
 }
 
+// askedBy matches conversations that end with userMessage from the user.
+func askedBy(userMessage string) interface{} {
+	return mock.MatchedBy(func(messages []entities.LLMMessage) bool {
+		last := messages[len(messages)-1]
+		return last.Role == constants.LLMRoleUser && last.Content == userMessage
+	})
+}
+
 func TestChatbotUseCase_ClearHistory(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		chatbotRepo := new(Chatbot)
@@ -159,18 +179,18 @@ func TestChatbotUseCase_GetChatCompletion(t *testing.T) {
 		chatbotRepo := new(Chatbot)
 		faqRepo := new(Faq)
 		complaintRepo := new(Complaint)
-		openAIAPI := new(OpenAIAPI)
+		llmProvider := new(LLMProvider)
 
 		chatbot := &entities.Chatbot{UserID: 1, UserMessage: "Hello"}
 
 		faqRepo.On("GetAll").Return([]entities.Faq{}, nil)
 		complaintRepo.On("GetByUserID", chatbot.UserID).Return([]entities.Complaint{}, nil)
-		openAIAPI.On("GetChatCompletion", mock.Anything, chatbot.UserMessage).Return("Hello, how can I assist you?", nil)
+		llmProvider.On("ChatCompletion", mock.Anything, askedBy(chatbot.UserMessage)).Return("Hello, how can I assist you?", nil)
 		chatbotRepo.On("Create", chatbot).Return(nil)
 
-		uc := NewChatbotUseCase(chatbotRepo, faqRepo, complaintRepo, openAIAPI)
+		uc := NewChatbotUseCase(chatbotRepo, faqRepo, complaintRepo, llmProvider)
 
-		err := uc.GetChatCompletion(chatbot)
+		err := uc.GetChatCompletion(context.Background(), chatbot)
 		assert.Nil(t, err)
 		assert.Equal(t, "Hello, how can I assist you?", chatbot.BotResponse)
 	})
@@ -179,18 +199,18 @@ func TestChatbotUseCase_GetChatCompletion(t *testing.T) {
 		chatbotRepo := new(Chatbot)
 		faqRepo := new(Faq)
 		complaintRepo := new(Complaint)
-		openAIAPI := new(OpenAIAPI)
+		llmProvider := new(LLMProvider)
 
 		chatbot := &entities.Chatbot{UserID: 1, UserMessage: "Hello"}
 
 		faqRepo.On("GetAll").Return([]entities.Faq{}, nil)
 		complaintRepo.On("GetByUserID", chatbot.UserID).Return([]entities.Complaint{{ID: "1", Description: "Test", Status: "Open", CreatedAt: time.Now()}}, nil)
-		openAIAPI.On("GetChatCompletion", mock.Anything, chatbot.UserMessage).Return("Hello, how can I assist you?", nil)
+		llmProvider.On("ChatCompletion", mock.Anything, askedBy(chatbot.UserMessage)).Return("Hello, how can I assist you?", nil)
 		chatbotRepo.On("Create", chatbot).Return(nil)
 
-		uc := NewChatbotUseCase(chatbotRepo, faqRepo, complaintRepo, openAIAPI)
+		uc := NewChatbotUseCase(chatbotRepo, faqRepo, complaintRepo, llmProvider)
 
-		err := uc.GetChatCompletion(chatbot)
+		err := uc.GetChatCompletion(context.Background(), chatbot)
 		assert.Nil(t, err)
 		assert.Equal(t, "Hello, how can I assist you?", chatbot.BotResponse)
 	})
@@ -199,52 +219,73 @@ func TestChatbotUseCase_GetChatCompletion(t *testing.T) {
 		chatbotRepo := new(Chatbot)
 		faqRepo := new(Faq)
 		complaintRepo := new(Complaint)
-		openAIAPI := new(OpenAIAPI)
+		llmProvider := new(LLMProvider)
 
 		chatbot := &entities.Chatbot{UserID: 1, UserMessage: "Hello"}
 
 		faqRepo.On("GetAll").Return([]entities.Faq{{Question: "Test", Answer: "Test"}}, nil)
 		complaintRepo.On("GetByUserID", chatbot.UserID).Return([]entities.Complaint{}, nil)
-		openAIAPI.On("GetChatCompletion", mock.Anything, chatbot.UserMessage).Return("Hello, how can I assist you?", nil)
+		llmProvider.On("ChatCompletion", mock.Anything, askedBy(chatbot.UserMessage)).Return("Hello, how can I assist you?", nil)
 		chatbotRepo.On("Create", chatbot).Return(nil)
 
-		uc := NewChatbotUseCase(chatbotRepo, faqRepo, complaintRepo, openAIAPI)
+		uc := NewChatbotUseCase(chatbotRepo, faqRepo, complaintRepo, llmProvider)
 
-		err := uc.GetChatCompletion(chatbot)
+		err := uc.GetChatCompletion(context.Background(), chatbot)
 		assert.Nil(t, err)
 		assert.Equal(t, "Hello, how can I assist you?", chatbot.BotResponse)
 	})
 
+	t.Run("success with fake provider", func(t *testing.T) {
+		chatbotRepo := new(Chatbot)
+		faqRepo := new(Faq)
+		complaintRepo := new(Complaint)
+		fake := llm.NewFake("")
+
+		chatbot := &entities.Chatbot{UserID: 1, UserMessage: "Hello"}
+
+		faqRepo.On("GetAll").Return([]entities.Faq{{Question: "Test", Answer: "Test"}}, nil)
+		complaintRepo.On("GetByUserID", chatbot.UserID).Return([]entities.Complaint{}, nil)
+		chatbotRepo.On("Create", chatbot).Return(nil)
+
+		uc := NewChatbotUseCase(chatbotRepo, faqRepo, complaintRepo, fake)
+
+		err := uc.GetChatCompletion(context.Background(), chatbot)
+		assert.Nil(t, err)
+		assert.Equal(t, "[fake] Hello", chatbot.BotResponse)
+		assert.Len(t, fake.Requests(), 1)
+		assert.Equal(t, constants.LLMPersona, fake.Requests()[0][0].Content)
+	})
+
 	t.Run("error", func(t *testing.T) {
 		chatbotRepo := new(Chatbot)
 		faqRepo := new(Faq)
 		complaintRepo := new(Complaint)
-		openAIAPI := new(OpenAIAPI)
+		llmProvider := new(LLMProvider)
 
 		chatbot := &entities.Chatbot{UserID: 1, UserMessage: "Hello"}
 
 		faqRepo.On("GetAll").Return([]entities.Faq{}, errors.New("error"))
-		uc := NewChatbotUseCase(chatbotRepo, faqRepo, complaintRepo, openAIAPI)
+		uc := NewChatbotUseCase(chatbotRepo, faqRepo, complaintRepo, llmProvider)
 
-		err := uc.GetChatCompletion(chatbot)
+		err := uc.GetChatCompletion(context.Background(), chatbot)
 		assert.NotNil(t, err)
 	})
 
-	t.Run("error on GetChatCompletion", func(t *testing.T) {
+	t.Run("error on ChatCompletion", func(t *testing.T) {
 		chatbotRepo := new(Chatbot)
 		faqRepo := new(Faq)
 		complaintRepo := new(Complaint)
-		openAIAPI := new(OpenAIAPI)
+		llmProvider := new(LLMProvider)
 
 		chatbot := &entities.Chatbot{UserID: 1, UserMessage: "Hello"}
 
 		faqRepo.On("GetAll").Return([]entities.Faq{}, nil)
 		complaintRepo.On("GetByUserID", chatbot.UserID).Return([]entities.Complaint{}, nil)
-		openAIAPI.On("GetChatCompletion", mock.Anything, chatbot.UserMessage).Return("", errors.New("error"))
+		llmProvider.On("ChatCompletion", mock.Anything, askedBy(chatbot.UserMessage)).Return("", errors.New("error"))
 
-		uc := NewChatbotUseCase(chatbotRepo, faqRepo, complaintRepo, openAIAPI)
+		uc := NewChatbotUseCase(chatbotRepo, faqRepo, complaintRepo, llmProvider)
 
-		err := uc.GetChatCompletion(chatbot)
+		err := uc.GetChatCompletion(context.Background(), chatbot)
 		assert.NotNil(t, err)
 	})
 
@@ -252,18 +293,18 @@ func TestChatbotUseCase_GetChatCompletion(t *testing.T) {
 		chatbotRepo := new(Chatbot)
 		faqRepo := new(Faq)
 		complaintRepo := new(Complaint)
-		openAIAPI := new(OpenAIAPI)
+		llmProvider := new(LLMProvider)
 
 		chatbot := &entities.Chatbot{UserID: 1, UserMessage: "Hello"}
 
 		faqRepo.On("GetAll").Return([]entities.Faq{}, nil)
 		complaintRepo.On("GetByUserID", chatbot.UserID).Return([]entities.Complaint{}, nil)
-		openAIAPI.On("GetChatCompletion", mock.Anything, chatbot.UserMessage).Return("Hello, how can I assist you?", nil)
+		llmProvider.On("ChatCompletion", mock.Anything, askedBy(chatbot.UserMessage)).Return("Hello, how can I assist you?", nil)
 		chatbotRepo.On("Create", chatbot).Return(errors.New("error"))
 
-		uc := NewChatbotUseCase(chatbotRepo, faqRepo, complaintRepo, openAIAPI)
+		uc := NewChatbotUseCase(chatbotRepo, faqRepo, complaintRepo, llmProvider)
 
-		err := uc.GetChatCompletion(chatbot)
+		err := uc.GetChatCompletion(context.Background(), chatbot)
 		assert.NotNil(t, err)
 	})
 
@@ -271,16 +312,16 @@ func TestChatbotUseCase_GetChatCompletion(t *testing.T) {
 		chatbotRepo := new(Chatbot)
 		faqRepo := new(Faq)
 		complaintRepo := new(Complaint)
-		openAIAPI := new(OpenAIAPI)
+		llmProvider := new(LLMProvider)
 
 		chatbot := &entities.Chatbot{UserID: 1, UserMessage: "Hello"}
 
 		faqRepo.On("GetAll").Return([]entities.Faq{}, nil)
 		complaintRepo.On("GetByUserID", chatbot.UserID).Return([]entities.Complaint{}, errors.New("error"))
 
-		uc := NewChatbotUseCase(chatbotRepo, faqRepo, complaintRepo, openAIAPI)
+		uc := NewChatbotUseCase(chatbotRepo, faqRepo, complaintRepo, llmProvider)
 
-		err := uc.GetChatCompletion(chatbot)
+		err := uc.GetChatCompletion(context.Background(), chatbot)
 		assert.NotNil(t, err)
 	})
 
@@ -2,25 +2,30 @@ package complaint
 
 import (
 	"e-complaint-api/constants"
+	"e-complaint-api/cursor"
 	"e-complaint-api/entities"
+	"e-complaint-api/geo"
+	"e-complaint-api/queryspec"
 	"e-complaint-api/utils"
-	"mime/multipart"
+	"e-complaint-api/workflow"
+	"errors"
 	"strconv"
 	"strings"
-	"time"
 )
 
 type ComplaintUseCase struct {
 	complaintRepo     entities.ComplaintRepositoryInterface
 	complaintFileRepo entities.ComplaintFileRepositoryInterface
-	getRowsFromExcel  func(file *multipart.FileHeader) ([][]string, error)
+	regencyRepo       entities.RegencyRepositoryInterface
+	searchEngine      entities.SearchEngineInterface
 }
 
-func NewComplaintUseCase(complaintRepo entities.ComplaintRepositoryInterface, complaintFileRepo entities.ComplaintFileRepositoryInterface) *ComplaintUseCase {
+func NewComplaintUseCase(complaintRepo entities.ComplaintRepositoryInterface, complaintFileRepo entities.ComplaintFileRepositoryInterface, regencyRepo entities.RegencyRepositoryInterface, searchEngine entities.SearchEngineInterface) *ComplaintUseCase {
 	return &ComplaintUseCase{
 		complaintRepo:     complaintRepo,
 		complaintFileRepo: complaintFileRepo,
-		getRowsFromExcel:  utils.GetRowsFromExcel,
+		regencyRepo:       regencyRepo,
+		searchEngine:      searchEngine,
 	}
 }
 
@@ -31,12 +36,14 @@ func (u *ComplaintUseCase) GetPaginated(limit int, page int, search string, filt
 		return nil, constants.ErrLimitMustBeFilled
 	}
 
-	if sortBy == "" {
-		sortBy = "created_at"
+	search, filter, err := u.fullTextSearch(search, filter)
+	if err != nil {
+		return nil, err
 	}
 
-	if sortType == "" {
-		sortType = "DESC"
+	sortBy, sortType, err = queryspec.Complaints.Sort(sortBy, sortType, filter["ids"] != nil)
+	if err != nil {
+		return nil, err
 	}
 
 	complaints, err := u.complaintRepo.GetPaginated(limit, page, search, filter, sortBy, sortType)
@@ -49,6 +56,11 @@ func (u *ComplaintUseCase) GetPaginated(limit int, page int, search string, filt
 
 func (u *ComplaintUseCase) GetMetaData(limit int, page int, search string, filter map[string]interface{}) (entities.Metadata, error) {
 	var pagination entities.Pagination
+	search, filter, err := u.fullTextSearch(search, filter)
+	if err != nil {
+		return entities.Metadata{}, err
+	}
+
 	metaData, err := u.complaintRepo.GetMetaData(limit, page, search, filter)
 
 	if err != nil {
@@ -92,6 +104,116 @@ func (u *ComplaintUseCase) GetMetaData(limit int, page int, search string, filte
 	return metaData, nil
 }
 
+// GetByCursor returns the page of complaints after the one the cursor points at and the
+// cursor of the next page, which is empty on the last page. Unlike GetPaginated it does
+// not count the complaints and is not thrown off by complaints created in between.
+func (u *ComplaintUseCase) GetByCursor(limit int, token string, search string, filter map[string]interface{}, sortBy string, sortType string) ([]entities.Complaint, string, error) {
+	search, filter, err := u.fullTextSearch(search, filter)
+	if err != nil {
+		return nil, "", err
+	}
+
+	sortBy, sortType, err = queryspec.Complaints.Sort(sortBy, sortType, filter["ids"] != nil)
+	if err != nil {
+		return nil, "", err
+	}
+
+	sort := sortBy + " " + sortType
+	afterID, afterValue, err := cursor.DecodeAfter(token, sort)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// the hits of a full-text search are already in order, the page starts after the
+	// hit the cursor points at
+	if sortBy == queryspec.Relevance && afterID != "" {
+		filter["ids"] = idsAfter(filter["ids"].([]string), afterID)
+		afterID = ""
+	}
+
+	limit = cursor.Limit(limit)
+	complaints, err := u.complaintRepo.GetAfter(limit+1, afterID, afterValue, search, filter, sortBy, sortType)
+	if err != nil {
+		return nil, "", constants.ErrInternalServerError
+	}
+
+	complaints, next := cursor.PageAfter(complaints, limit, sort, func(complaint entities.Complaint) (string, string) {
+		return complaint.ID, sortValue(complaint, sortBy)
+	})
+
+	return complaints, next, nil
+}
+
+// Export passes the complaints that match the filters to write, ExportBatchSize at a
+// time and with their process timeline, so the complaints never all sit in memory.
+func (u *ComplaintUseCase) Export(search string, filter map[string]interface{}, sortBy string, sortType string, write func([]entities.Complaint) error) error {
+	search, filter, err := u.fullTextSearch(search, filter)
+	if err != nil {
+		return err
+	}
+
+	sortBy, sortType, err = queryspec.Complaints.Sort(sortBy, sortType, filter["ids"] != nil)
+	if err != nil {
+		return err
+	}
+
+	afterID, afterValue := "", ""
+	for {
+		complaints, err := u.complaintRepo.GetAfterWithProcesses(constants.ExportBatchSize, afterID, afterValue, search, filter, sortBy, sortType)
+		if err != nil {
+			return constants.ErrInternalServerError
+		}
+
+		if len(complaints) == 0 {
+			return nil
+		}
+
+		if err := write(complaints); err != nil {
+			return err
+		}
+
+		if len(complaints) < constants.ExportBatchSize {
+			return nil
+		}
+
+		afterID = complaints[len(complaints)-1].ID
+		afterValue = sortValue(complaints[len(complaints)-1], sortBy)
+		if sortBy == queryspec.Relevance {
+			filter["ids"] = idsAfter(filter["ids"].([]string), afterID)
+			afterID = ""
+		}
+	}
+}
+
+// sortValue returns the value of a complaint in the column sortBy, the relevance of a
+// hit is its place in the ids filter and has no value.
+func sortValue(complaint entities.Complaint, sortBy string) string {
+	switch sortBy {
+	case "created_at":
+		return cursor.Time(complaint.CreatedAt)
+	case "updated_at":
+		return cursor.Time(complaint.UpdatedAt)
+	case "date":
+		return cursor.Time(complaint.Date)
+	case "total_likes":
+		return strconv.Itoa(complaint.TotalLikes)
+	case "status":
+		return complaint.Status
+	default:
+		return ""
+	}
+}
+
+func idsAfter(ids []string, id string) []string {
+	for i := range ids {
+		if ids[i] == id {
+			return ids[i+1:]
+		}
+	}
+
+	return []string{}
+}
+
 func (u *ComplaintUseCase) GetByID(id string) (entities.Complaint, error) {
 	complaint, err := u.complaintRepo.GetByID(id)
 	if err != nil {
@@ -110,10 +232,93 @@ func (u *ComplaintUseCase) GetByUserID(userId int) ([]entities.Complaint, error)
 	return complaints, nil
 }
 
+func (u *ComplaintUseCase) GetWithLocation(search string, filter map[string]interface{}) ([]entities.Complaint, error) {
+	search, filter, err := u.fullTextSearch(search, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	complaints, err := u.complaintRepo.GetWithLocation(constants.MaxGeoJSONFeatures, search, filter)
+	if err != nil {
+		return nil, constants.ErrInternalServerError
+	}
+
+	return complaints, nil
+}
+
+// fullTextSearch narrows the filter down to the IDs of the complaints that match search,
+// best match first, and clears search. A complaint ID such as "C-81j9aK9280" is left to
+// the repository, which looks it up by its ID.
+func (u *ComplaintUseCase) fullTextSearch(search string, filter map[string]interface{}) (string, map[string]interface{}, error) {
+	search = strings.TrimSpace(search)
+	if search == "" || strings.HasPrefix(strings.ToUpper(search), "C-") {
+		return search, filter, nil
+	}
+
+	hits, err := u.searchEngine.Search(constants.SearchComplaints, search, constants.MaxSearchHits)
+	if err != nil {
+		return "", nil, constants.ErrInternalServerError
+	}
+
+	ids := make([]string, 0, len(hits))
+	for _, hit := range hits {
+		ids = append(ids, hit.ID)
+	}
+
+	narrowed := map[string]interface{}{"ids": ids}
+	for key, value := range filter {
+		narrowed[key] = value
+	}
+
+	return "", narrowed, nil
+}
+
+// validateLocation accepts complaints without a location and complaints located
+// inside the boundary of their regency. A location in a regency without a boundary
+// cannot be checked and is rejected.
+func (u *ComplaintUseCase) validateLocation(complaint entities.Complaint) error {
+	if complaint.Latitude == nil && complaint.Longitude == nil {
+		return nil
+	}
+
+	if complaint.Latitude == nil || complaint.Longitude == nil {
+		return constants.ErrInvalidCoordinates
+	}
+
+	point, err := geo.NewPoint(*complaint.Latitude, *complaint.Longitude)
+	if err != nil {
+		return err
+	}
+
+	boundary, err := u.regencyRepo.GetBoundary(complaint.RegencyID)
+	if err != nil {
+		if errors.Is(err, constants.ErrNotFound) {
+			return constants.ErrRegencyHasNoBoundary
+		}
+		return constants.ErrInternalServerError
+	}
+
+	geometry, err := geo.ParseGeometry(boundary.Geometry)
+	if err != nil {
+		return constants.ErrInternalServerError
+	}
+
+	if !geometry.Contains(point) {
+		return constants.ErrLocationOutsideRegency
+	}
+
+	return nil
+}
+
 func (u *ComplaintUseCase) Create(complaint *entities.Complaint) (entities.Complaint, error) {
 	if complaint.CategoryID == 0 || complaint.UserID == 0 || complaint.RegencyID == "" || complaint.Description == "" || complaint.Address == "" || complaint.Type == "" || complaint.Date.IsZero() {
 		return entities.Complaint{}, constants.ErrAllFieldsMustBeFilled
 	}
+
+	if err := u.validateLocation(*complaint); err != nil {
+		return entities.Complaint{}, err
+	}
+
 	(*complaint).ID = utils.GenerateID("C-", 10)
 
 	err := u.complaintRepo.Create(complaint)
@@ -151,6 +356,10 @@ func (u *ComplaintUseCase) Update(complaint entities.Complaint) (entities.Compla
 		return entities.Complaint{}, constants.ErrAllFieldsMustBeFilled
 	}
 
+	if err := u.validateLocation(complaint); err != nil {
+		return entities.Complaint{}, err
+	}
+
 	complaint, err := u.complaintRepo.Update(complaint)
 	if err != nil {
 		if strings.HasSuffix(err.Error(), "REFERENCES `regencies` (`id`))") {
@@ -166,7 +375,7 @@ func (u *ComplaintUseCase) Update(complaint entities.Complaint) (entities.Compla
 }
 
 func (u *ComplaintUseCase) UpdateStatus(id string, status string) error {
-	if status != "Pending" && status != "Verifikasi" && status != "On Progress" && status != "Selesai" && status != "Ditolak" {
+	if !workflow.Complaint.IsValid(status) {
 		return constants.ErrInvalidStatus
 	}
 
@@ -182,151 +391,6 @@ func (u *ComplaintUseCase) UpdateStatus(id string, status string) error {
 	return nil
 }
 
-func (u *ComplaintUseCase) Import(file *multipart.FileHeader) error {
-	rows, err := u.getRowsFromExcel(file)
-	if err != nil {
-		return err
-	}
-
-	var complaints []entities.Complaint
-	var complaintFiles []entities.ComplaintFile
-	var process []entities.ComplaintProcess
-
-	// Loop through each row in the sheet
-	for i, row := range rows {
-		if i == 0 {
-			// Skip the header row
-			continue
-		}
-
-		if len(row) < 9 {
-			// Ensure the row has enough columns
-			return constants.ErrColumnsDoesntMatch
-		}
-
-		userId, err := strconv.Atoi(row[0])
-		if err != nil {
-			return constants.ErrInvalidIDFormat
-		}
-
-		categoryId, err := strconv.Atoi(row[1])
-		if err != nil {
-			return constants.ErrInvalidCategoryIDFormat
-		}
-
-		regencyId := row[2]
-		address := row[3]
-		description := row[4]
-		status := row[5]
-		typeComplaint := row[6]
-		date, _ := time.Parse("02-01-2006", row[7])
-		pathFiles := row[8]
-
-		process = []entities.ComplaintProcess{}
-		if status == "Verifikasi" {
-			process = append(process, entities.ComplaintProcess{
-				AdminID: 1,
-				Status:  "Pending",
-				Message: "Aduan anda sedang dalam proses verifikasi oleh admin kami",
-			})
-			process = append(process, entities.ComplaintProcess{
-				AdminID: 1,
-				Status:  "Verifikasi",
-				Message: "Aduan anda telah diverifikasi oleh admin kami",
-			})
-		} else if status == "On Progress" {
-			process = append(process, entities.ComplaintProcess{
-				AdminID: 1,
-				Status:  "Pending",
-				Message: "Aduan anda sedang dalam proses verifikasi oleh admin kami",
-			})
-			process = append(process, entities.ComplaintProcess{
-				AdminID: 1,
-				Status:  "Verifikasi",
-				Message: "Aduan anda telah diverifikasi oleh admin kami",
-			})
-			process = append(process, entities.ComplaintProcess{
-				AdminID: 1,
-				Status:  "On Progress",
-				Message: "Aduan anda sedang dalam proses penanganan",
-			})
-		} else if status == "Selesai" {
-			process = append(process, entities.ComplaintProcess{
-				AdminID: 1,
-				Status:  "Pending",
-				Message: "Aduan anda sedang dalam proses verifikasi oleh admin kami",
-			})
-			process = append(process, entities.ComplaintProcess{
-				AdminID: 1,
-				Status:  "Verifikasi",
-				Message: "Aduan anda telah diverifikasi oleh admin kami",
-			})
-			process = append(process, entities.ComplaintProcess{
-				AdminID: 1,
-				Status:  "On Progress",
-				Message: "Aduan anda sedang dalam proses penanganan",
-			})
-			process = append(process, entities.ComplaintProcess{
-				AdminID: 1,
-				Status:  "Selesai",
-				Message: "Aduan anda telah selesai ditangani",
-			})
-		} else if status == "Ditolak" {
-			process = append(process, entities.ComplaintProcess{
-				AdminID: 1,
-				Status:  "Pending",
-				Message: "Aduan anda sedang dalam proses verifikasi oleh admin kami",
-			})
-			process = append(process, entities.ComplaintProcess{
-				AdminID: 1,
-				Status:  "Ditolak",
-				Message: "Aduan anda ditolak karena tidak sesuai dengan ketentuan yang berlaku",
-			})
-		} else if status == "Pending" {
-			process = append(process, entities.ComplaintProcess{
-				AdminID: 1,
-				Status:  "Pending",
-				Message: "Aduan anda sedang dalam proses verifikasi oleh admin kami",
-			})
-		}
-
-		// split pathFiles by comma
-		files := strings.Split(pathFiles, ",")
-		complaintFiles = []entities.ComplaintFile{}
-		for _, file := range files {
-			complaintFile := entities.ComplaintFile{
-				Path: file,
-			}
-
-			complaintFiles = append(complaintFiles, complaintFile)
-		}
-
-		complaint := entities.Complaint{
-			ID:          utils.GenerateID("C-", 10),
-			UserID:      userId,
-			CategoryID:  categoryId,
-			RegencyID:   regencyId,
-			Address:     address,
-			Description: description,
-			Status:      status,
-			Type:        typeComplaint,
-			Date:        date,
-			Files:       complaintFiles,
-			Process:     process,
-		}
-
-		complaints = append(complaints, complaint)
-	}
-
-	// Import the complaints
-	err = u.complaintRepo.Import(complaints)
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
-
 func (u *ComplaintUseCase) IncreaseTotalLikes(id string) error {
 	err := u.complaintRepo.IncreaseTotalLikes(id)
 	if err != nil {
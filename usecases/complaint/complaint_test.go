@@ -2,9 +2,11 @@ package complaint
 
 import (
 	"e-complaint-api/constants"
+	"e-complaint-api/cursor"
 	"e-complaint-api/entities"
 	"errors"
 	"mime/multipart"
+	"strconv"
 	"testing"
 	"time"
 
@@ -26,6 +28,21 @@ func (m *MockComplaintRepo) GetMetaData(limit int, page int, search string, filt
 	return args.Get(0).(entities.Metadata), args.Error(1)
 }
 
+func (m *MockComplaintRepo) GetAfter(limit int, afterID string, afterValue string, search string, filter map[string]interface{}, sortBy string, sortType string) ([]entities.Complaint, error) {
+	args := m.Called(limit, afterID, afterValue, search, filter, sortBy, sortType)
+	return args.Get(0).([]entities.Complaint), args.Error(1)
+}
+
+func (m *MockComplaintRepo) GetAfterWithProcesses(limit int, afterID string, afterValue string, search string, filter map[string]interface{}, sortBy string, sortType string) ([]entities.Complaint, error) {
+	args := m.Called(limit, afterID, afterValue, search, filter, sortBy, sortType)
+	return args.Get(0).([]entities.Complaint), args.Error(1)
+}
+
+func (m *MockComplaintRepo) GetWithLocation(limit int, search string, filter map[string]interface{}) ([]entities.Complaint, error) {
+	args := m.Called(limit, search, filter)
+	return args.Get(0).([]entities.Complaint), args.Error(1)
+}
+
 func (m *MockComplaintRepo) GetByID(id string) (entities.Complaint, error) {
 	args := m.Called(id)
 	return args.Get(0).(entities.Complaint), args.Error(1)
@@ -66,11 +83,6 @@ func (m *MockComplaintRepo) GetStatus(id string) (string, error) {
 	return args.String(0), args.Error(1)
 }
 
-func (m *MockComplaintRepo) Import(complaints []entities.Complaint) error {
-	args := m.Called(complaints)
-	return args.Error(0)
-}
-
 func (m *MockComplaintRepo) IncreaseTotalLikes(id string) error {
 	args := m.Called(id)
 	return args.Error(0)
@@ -100,6 +112,34 @@ func (m *MockComplaintFileRepo) DeleteByComplaintID(complaintID string) error {
 	return args.Error(0)
 }
 
+func (m *MockComplaintFileRepo) FindByComplaintID(complaintID string) ([]entities.ComplaintFile, error) {
+	args := m.Called(complaintID)
+	return args.Get(0).([]entities.ComplaintFile), args.Error(1)
+}
+
+type MockRegencyRepo struct {
+	mock.Mock
+}
+
+func (m *MockRegencyRepo) GetAll() ([]entities.Regency, error) {
+	args := m.Called()
+	return args.Get(0).([]entities.Regency), args.Error(1)
+}
+
+func (m *MockRegencyRepo) GetBoundary(regencyID string) (entities.RegencyBoundary, error) {
+	args := m.Called(regencyID)
+	return args.Get(0).(entities.RegencyBoundary), args.Error(1)
+}
+
+type MockSearchEngine struct {
+	mock.Mock
+}
+
+func (m *MockSearchEngine) Search(collection string, query string, limit int) ([]entities.SearchHit, error) {
+	args := m.Called(collection, query, limit)
+	return args.Get(0).([]entities.SearchHit), args.Error(1)
+}
+
 type MockUtils struct {
 	mock.Mock
 }
@@ -113,9 +153,9 @@ func TestGetPaginated(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		mockComplaintRepo := new(MockComplaintRepo)
 		mockComplaintFileRepo := new(MockComplaintFileRepo)
-		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo)
+		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo, new(MockRegencyRepo), new(MockSearchEngine))
 
-		mockComplaintRepo.On("GetPaginated", 10, 1, "", map[string]interface{}{}, "created_at", "desc").Return([]entities.Complaint{}, nil)
+		mockComplaintRepo.On("GetPaginated", 10, 1, "", map[string]interface{}{}, "created_at", "DESC").Return([]entities.Complaint{}, nil)
 
 		result, err := mockUsecase.GetPaginated(10, 1, "", map[string]interface{}{}, "created_at", "desc")
 		assert.NoError(t, err)
@@ -129,7 +169,7 @@ func TestGetPaginated(t *testing.T) {
 		mockComplaintRepo := new(MockComplaintRepo)
 		mockComplaintFileRepo := new(MockComplaintFileRepo)
 
-		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo)
+		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo, new(MockRegencyRepo), new(MockSearchEngine))
 
 		mockComplaintRepo.On("GetPaginated", 10, 1, "", map[string]interface{}{}, "created_at", "DESC").Return([]entities.Complaint{}, nil)
 
@@ -140,10 +180,76 @@ func TestGetPaginated(t *testing.T) {
 		mockComplaintRepo.AssertExpectations(t)
 	})
 
+	t.Run("success full-text search sorted by relevance", func(t *testing.T) {
+		mockComplaintRepo := new(MockComplaintRepo)
+		mockSearchEngine := new(MockSearchEngine)
+		mockUsecase := NewComplaintUseCase(mockComplaintRepo, new(MockComplaintFileRepo), new(MockRegencyRepo), mockSearchEngine)
+
+		hits := []entities.SearchHit{{ID: "C-2", Score: 2.5}, {ID: "C-1", Score: 1.2}}
+		mockSearchEngine.On("Search", constants.SearchComplaints, "jalan berlubang", constants.MaxSearchHits).Return(hits, nil)
+		mockComplaintRepo.On("GetPaginated", 10, 1, "", map[string]interface{}{"ids": []string{"C-2", "C-1"}, "status": "Pending"}, "relevance", "DESC").Return([]entities.Complaint{{ID: "C-2"}, {ID: "C-1"}}, nil)
+
+		filter := map[string]interface{}{"status": "Pending"}
+		result, err := mockUsecase.GetPaginated(10, 1, " jalan berlubang ", filter, "", "")
+		assert.NoError(t, err)
+		assert.Equal(t, []entities.Complaint{{ID: "C-2"}, {ID: "C-1"}}, result)
+		assert.Equal(t, map[string]interface{}{"status": "Pending"}, filter)
+
+		mockSearchEngine.AssertExpectations(t)
+		mockComplaintRepo.AssertExpectations(t)
+	})
+
+	t.Run("success search by complaint id", func(t *testing.T) {
+		mockComplaintRepo := new(MockComplaintRepo)
+		mockSearchEngine := new(MockSearchEngine)
+		mockUsecase := NewComplaintUseCase(mockComplaintRepo, new(MockComplaintFileRepo), new(MockRegencyRepo), mockSearchEngine)
+
+		mockComplaintRepo.On("GetPaginated", 10, 1, "c-81j9ak9280", map[string]interface{}(nil), "created_at", "DESC").Return([]entities.Complaint{{ID: "C-81j9aK9280"}}, nil)
+
+		result, err := mockUsecase.GetPaginated(10, 1, "c-81j9ak9280", nil, "", "")
+		assert.NoError(t, err)
+		assert.Equal(t, []entities.Complaint{{ID: "C-81j9aK9280"}}, result)
+
+		mockSearchEngine.AssertNotCalled(t, "Search")
+	})
+
+	t.Run("failed search engine error", func(t *testing.T) {
+		mockSearchEngine := new(MockSearchEngine)
+		mockUsecase := NewComplaintUseCase(new(MockComplaintRepo), new(MockComplaintFileRepo), new(MockRegencyRepo), mockSearchEngine)
+
+		mockSearchEngine.On("Search", constants.SearchComplaints, "sampah", constants.MaxSearchHits).Return([]entities.SearchHit(nil), errors.New("database error"))
+
+		result, err := mockUsecase.GetPaginated(10, 1, "sampah", nil, "", "")
+		assert.Equal(t, constants.ErrInternalServerError, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("failed invalid sort by", func(t *testing.T) {
+		mockComplaintRepo := new(MockComplaintRepo)
+		mockUsecase := NewComplaintUseCase(mockComplaintRepo, new(MockComplaintFileRepo), new(MockRegencyRepo), new(MockSearchEngine))
+
+		result, err := mockUsecase.GetPaginated(10, 1, "", nil, "description", "desc")
+		assert.Equal(t, constants.ErrInvalidSortBy, err)
+		assert.Nil(t, result)
+
+		mockComplaintRepo.AssertNotCalled(t, "GetPaginated")
+	})
+
+	t.Run("failed invalid sort type", func(t *testing.T) {
+		mockComplaintRepo := new(MockComplaintRepo)
+		mockUsecase := NewComplaintUseCase(mockComplaintRepo, new(MockComplaintFileRepo), new(MockRegencyRepo), new(MockSearchEngine))
+
+		result, err := mockUsecase.GetPaginated(10, 1, "", nil, "created_at", "desc, (SELECT 1)")
+		assert.Equal(t, constants.ErrInvalidSortType, err)
+		assert.Nil(t, result)
+
+		mockComplaintRepo.AssertNotCalled(t, "GetPaginated")
+	})
+
 	t.Run("failed limit must filled when page is filled", func(t *testing.T) {
 		mockComplaintRepo := new(MockComplaintRepo)
 		mockComplaintFileRepo := new(MockComplaintFileRepo)
-		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo)
+		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo, new(MockRegencyRepo), new(MockSearchEngine))
 
 		result, err := mockUsecase.GetPaginated(0, 1, "", map[string]interface{}{}, "created_at", "desc")
 		assert.Error(t, err)
@@ -157,7 +263,7 @@ func TestGetPaginated(t *testing.T) {
 	t.Run("failed page must filled when limit is filled", func(t *testing.T) {
 		mockComplaintRepo := new(MockComplaintRepo)
 		mockComplaintFileRepo := new(MockComplaintFileRepo)
-		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo)
+		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo, new(MockRegencyRepo), new(MockSearchEngine))
 
 		result, err := mockUsecase.GetPaginated(10, 0, "", map[string]interface{}{}, "created_at", "desc")
 		assert.Error(t, err)
@@ -171,9 +277,9 @@ func TestGetPaginated(t *testing.T) {
 	t.Run("failed internal server error", func(t *testing.T) {
 		mockComplaintRepo := new(MockComplaintRepo)
 		mockComplaintFileRepo := new(MockComplaintFileRepo)
-		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo)
+		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo, new(MockRegencyRepo), new(MockSearchEngine))
 
-		mockComplaintRepo.On("GetPaginated", 10, 1, "", map[string]interface{}{}, "created_at", "desc").Return(([]entities.Complaint)(nil), constants.ErrInternalServerError)
+		mockComplaintRepo.On("GetPaginated", 10, 1, "", map[string]interface{}{}, "created_at", "DESC").Return(([]entities.Complaint)(nil), constants.ErrInternalServerError)
 
 		result, err := mockUsecase.GetPaginated(10, 1, "", map[string]interface{}{}, "created_at", "desc")
 		assert.Error(t, err)
@@ -185,11 +291,111 @@ func TestGetPaginated(t *testing.T) {
 	})
 }
 
+func TestGetByCursor(t *testing.T) {
+	t.Run("success first page", func(t *testing.T) {
+		mockComplaintRepo := new(MockComplaintRepo)
+		mockUsecase := NewComplaintUseCase(mockComplaintRepo, new(MockComplaintFileRepo), new(MockRegencyRepo), new(MockSearchEngine))
+
+		createdAt := time.Date(2024, 5, 5, 10, 0, 0, 0, time.Local)
+		complaints := []entities.Complaint{{ID: "C-3", CreatedAt: createdAt}, {ID: "C-2", CreatedAt: createdAt}, {ID: "C-1", CreatedAt: createdAt}}
+		mockComplaintRepo.On("GetAfter", 3, "", "", "", map[string]interface{}{"status": "Pending"}, "created_at", "DESC").Return(complaints, nil)
+
+		result, nextCursor, err := mockUsecase.GetByCursor(2, "", "", map[string]interface{}{"status": "Pending"}, "", "")
+		assert.NoError(t, err)
+		assert.Equal(t, complaints[:2], result)
+		assert.Equal(t, cursor.EncodeAfter("C-2", "2024-05-05 10:00:00", "created_at DESC"), nextCursor)
+	})
+
+	t.Run("success last page", func(t *testing.T) {
+		mockComplaintRepo := new(MockComplaintRepo)
+		mockUsecase := NewComplaintUseCase(mockComplaintRepo, new(MockComplaintFileRepo), new(MockRegencyRepo), new(MockSearchEngine))
+
+		mockComplaintRepo.On("GetAfter", 3, "C-2", "12", "", map[string]interface{}(nil), "total_likes", "ASC").Return([]entities.Complaint{{ID: "C-1"}}, nil)
+
+		result, nextCursor, err := mockUsecase.GetByCursor(2, cursor.EncodeAfter("C-2", "12", "total_likes ASC"), "", nil, "total_likes", "asc")
+		assert.NoError(t, err)
+		assert.Equal(t, []entities.Complaint{{ID: "C-1"}}, result)
+		assert.Equal(t, "", nextCursor)
+	})
+
+	t.Run("success full-text search continues after the cursor", func(t *testing.T) {
+		mockComplaintRepo := new(MockComplaintRepo)
+		mockSearchEngine := new(MockSearchEngine)
+		mockUsecase := NewComplaintUseCase(mockComplaintRepo, new(MockComplaintFileRepo), new(MockRegencyRepo), mockSearchEngine)
+
+		mockSearchEngine.On("Search", constants.SearchComplaints, "sampah", constants.MaxSearchHits).Return([]entities.SearchHit{{ID: "C-5"}, {ID: "C-2"}, {ID: "C-9"}, {ID: "C-4"}}, nil)
+		mockComplaintRepo.On("GetAfter", 2, "", "", "", map[string]interface{}{"ids": []string{"C-9", "C-4"}}, "relevance", "DESC").Return([]entities.Complaint{{ID: "C-9"}, {ID: "C-4"}}, nil)
+
+		result, nextCursor, err := mockUsecase.GetByCursor(1, cursor.Encode("C-2", "relevance DESC"), "sampah", nil, "", "")
+		assert.NoError(t, err)
+		assert.Equal(t, []entities.Complaint{{ID: "C-9"}}, result)
+		assert.Equal(t, cursor.Encode("C-9", "relevance DESC"), nextCursor)
+	})
+
+	t.Run("success full-text search with unknown cursor", func(t *testing.T) {
+		mockComplaintRepo := new(MockComplaintRepo)
+		mockSearchEngine := new(MockSearchEngine)
+		mockUsecase := NewComplaintUseCase(mockComplaintRepo, new(MockComplaintFileRepo), new(MockRegencyRepo), mockSearchEngine)
+
+		mockSearchEngine.On("Search", constants.SearchComplaints, "sampah", constants.MaxSearchHits).Return([]entities.SearchHit{{ID: "C-5"}}, nil)
+		mockComplaintRepo.On("GetAfter", 2, "", "", "", map[string]interface{}{"ids": []string{}}, "relevance", "DESC").Return([]entities.Complaint{}, nil)
+
+		result, _, err := mockUsecase.GetByCursor(1, cursor.Encode("C-2", "relevance DESC"), "sampah", nil, "", "")
+		assert.NoError(t, err)
+		assert.Empty(t, result)
+	})
+
+	t.Run("success relevance without search sorts by creation", func(t *testing.T) {
+		mockComplaintRepo := new(MockComplaintRepo)
+		mockUsecase := NewComplaintUseCase(mockComplaintRepo, new(MockComplaintFileRepo), new(MockRegencyRepo), new(MockSearchEngine))
+
+		mockComplaintRepo.On("GetAfter", 3, "", "", "", map[string]interface{}(nil), "created_at", "DESC").Return([]entities.Complaint{}, nil)
+
+		_, _, err := mockUsecase.GetByCursor(2, "", "", nil, "relevance", "")
+		assert.NoError(t, err)
+	})
+
+	t.Run("failed invalid cursor", func(t *testing.T) {
+		mockUsecase := NewComplaintUseCase(new(MockComplaintRepo), new(MockComplaintFileRepo), new(MockRegencyRepo), new(MockSearchEngine))
+
+		_, _, err := mockUsecase.GetByCursor(2, cursor.Encode("C-2", "created_at DESC"), "", nil, "created_at", "asc")
+		assert.Equal(t, constants.ErrInvalidCursor, err)
+	})
+
+	t.Run("failed invalid sort by", func(t *testing.T) {
+		mockUsecase := NewComplaintUseCase(new(MockComplaintRepo), new(MockComplaintFileRepo), new(MockRegencyRepo), new(MockSearchEngine))
+
+		_, _, err := mockUsecase.GetByCursor(2, "", "", nil, "(SELECT password FROM users LIMIT 1)", "")
+		assert.Equal(t, constants.ErrInvalidSortBy, err)
+	})
+
+	t.Run("failed search engine error", func(t *testing.T) {
+		mockSearchEngine := new(MockSearchEngine)
+		mockUsecase := NewComplaintUseCase(new(MockComplaintRepo), new(MockComplaintFileRepo), new(MockRegencyRepo), mockSearchEngine)
+
+		mockSearchEngine.On("Search", constants.SearchComplaints, "sampah", constants.MaxSearchHits).Return([]entities.SearchHit(nil), errors.New("database error"))
+
+		_, _, err := mockUsecase.GetByCursor(2, "", "sampah", nil, "", "")
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+
+	t.Run("failed internal server error", func(t *testing.T) {
+		mockComplaintRepo := new(MockComplaintRepo)
+		mockUsecase := NewComplaintUseCase(mockComplaintRepo, new(MockComplaintFileRepo), new(MockRegencyRepo), new(MockSearchEngine))
+
+		mockComplaintRepo.On("GetAfter", constants.DefaultCursorLimit+1, "", "", "", map[string]interface{}(nil), "created_at", "DESC").Return([]entities.Complaint(nil), errors.New("database error"))
+
+		result, _, err := mockUsecase.GetByCursor(0, "", "", nil, "", "")
+		assert.Equal(t, constants.ErrInternalServerError, err)
+		assert.Nil(t, result)
+	})
+}
+
 func TestGetMetaData(t *testing.T) {
 	t.Run("success empty", func(t *testing.T) {
 		mockComplaintRepo := new(MockComplaintRepo)
 		mockComplaintFileRepo := new(MockComplaintFileRepo)
-		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo)
+		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo, new(MockRegencyRepo), new(MockSearchEngine))
 
 		mockComplaintRepo.On("GetMetaData", 10, 1, "", map[string]interface{}{}).Return(entities.Metadata{}, nil)
 
@@ -212,7 +418,7 @@ func TestGetMetaData(t *testing.T) {
 	t.Run("success not empty", func(t *testing.T) {
 		mockComplaintRepo := new(MockComplaintRepo)
 		mockComplaintFileRepo := new(MockComplaintFileRepo)
-		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo)
+		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo, new(MockRegencyRepo), new(MockSearchEngine))
 
 		mockComplaintRepo.On("GetMetaData", 10, 1, "", map[string]interface{}{}).Return(entities.Metadata{
 			TotalData: 10,
@@ -240,7 +446,7 @@ func TestGetMetaData(t *testing.T) {
 	t.Run("success not empty with page > 1", func(t *testing.T) {
 		mockComplaintRepo := new(MockComplaintRepo)
 		mockComplaintFileRepo := new(MockComplaintFileRepo)
-		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo)
+		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo, new(MockRegencyRepo), new(MockSearchEngine))
 
 		mockComplaintRepo.On("GetMetaData", 10, 2, "", map[string]interface{}{}).Return(entities.Metadata{
 			TotalData: 10,
@@ -269,7 +475,7 @@ func TestGetMetaData(t *testing.T) {
 	t.Run("success not empty with page > 1 and not in last page", func(t *testing.T) {
 		mockComplaintRepo := new(MockComplaintRepo)
 		mockComplaintFileRepo := new(MockComplaintFileRepo)
-		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo)
+		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo, new(MockRegencyRepo), new(MockSearchEngine))
 
 		mockComplaintRepo.On("GetMetaData", 10, 2, "", map[string]interface{}{}).Return(entities.Metadata{
 			TotalData: 30,
@@ -298,7 +504,7 @@ func TestGetMetaData(t *testing.T) {
 	t.Run("success without limit and page filled", func(t *testing.T) {
 		mockComplaintRepo := new(MockComplaintRepo)
 		mockComplaintFileRepo := new(MockComplaintFileRepo)
-		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo)
+		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo, new(MockRegencyRepo), new(MockSearchEngine))
 
 		mockComplaintRepo.On("GetMetaData", 0, 0, "", map[string]interface{}{}).Return(entities.Metadata{}, nil)
 
@@ -321,7 +527,7 @@ func TestGetMetaData(t *testing.T) {
 	t.Run("failed internal server error", func(t *testing.T) {
 		mockComplaintRepo := new(MockComplaintRepo)
 		mockComplaintFileRepo := new(MockComplaintFileRepo)
-		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo)
+		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo, new(MockRegencyRepo), new(MockSearchEngine))
 
 		mockComplaintRepo.On("GetMetaData", 10, 1, "", map[string]interface{}{}).Return(entities.Metadata{}, constants.ErrInternalServerError)
 
@@ -335,13 +541,314 @@ func TestGetMetaData(t *testing.T) {
 		mockComplaintRepo.AssertExpectations(t)
 		mockComplaintFileRepo.AssertExpectations(t)
 	})
+	t.Run("success full-text search", func(t *testing.T) {
+		mockComplaintRepo := new(MockComplaintRepo)
+		mockSearchEngine := new(MockSearchEngine)
+		mockUsecase := NewComplaintUseCase(mockComplaintRepo, new(MockComplaintFileRepo), new(MockRegencyRepo), mockSearchEngine)
+
+		mockSearchEngine.On("Search", constants.SearchComplaints, "sampah", constants.MaxSearchHits).Return([]entities.SearchHit{{ID: "C-1"}}, nil)
+		mockComplaintRepo.On("GetMetaData", 10, 1, "", map[string]interface{}{"ids": []string{"C-1"}}).Return(entities.Metadata{TotalData: 1}, nil)
+
+		result, err := mockUsecase.GetMetaData(10, 1, "sampah", nil)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, result.TotalData)
+	})
+
+	t.Run("failed search engine error", func(t *testing.T) {
+		mockSearchEngine := new(MockSearchEngine)
+		mockUsecase := NewComplaintUseCase(new(MockComplaintRepo), new(MockComplaintFileRepo), new(MockRegencyRepo), mockSearchEngine)
+
+		mockSearchEngine.On("Search", constants.SearchComplaints, "sampah", constants.MaxSearchHits).Return([]entities.SearchHit(nil), errors.New("database error"))
+
+		_, err := mockUsecase.GetMetaData(10, 1, "sampah", nil)
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+}
+
+func TestExport(t *testing.T) {
+	t.Run("success in batches", func(t *testing.T) {
+		mockComplaintRepo := new(MockComplaintRepo)
+		mockUsecase := NewComplaintUseCase(mockComplaintRepo, new(MockComplaintFileRepo), new(MockRegencyRepo), new(MockSearchEngine))
+
+		firstBatch := make([]entities.Complaint, constants.ExportBatchSize)
+		createdAt := time.Date(2024, 5, 5, 10, 0, 0, 0, time.Local)
+		for i := range firstBatch {
+			firstBatch[i] = entities.Complaint{ID: "C-" + strconv.Itoa(i), CreatedAt: createdAt.Add(time.Duration(i) * time.Millisecond)}
+		}
+		lastID := firstBatch[len(firstBatch)-1].ID
+		lastCreatedAt := cursor.Time(firstBatch[len(firstBatch)-1].CreatedAt)
+		filter := map[string]interface{}{"status": []string{"Pending"}}
+		mockComplaintRepo.On("GetAfterWithProcesses", constants.ExportBatchSize, "", "", "", filter, "created_at", "ASC").Return(firstBatch, nil)
+		mockComplaintRepo.On("GetAfterWithProcesses", constants.ExportBatchSize, lastID, lastCreatedAt, "", filter, "created_at", "ASC").Return([]entities.Complaint{{ID: "C-x"}}, nil)
+
+		written := 0
+		err := mockUsecase.Export("", filter, "created_at", "asc", func(complaints []entities.Complaint) error {
+			written += len(complaints)
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, constants.ExportBatchSize+1, written)
+		mockComplaintRepo.AssertExpectations(t)
+	})
+
+	t.Run("success no complaints", func(t *testing.T) {
+		mockComplaintRepo := new(MockComplaintRepo)
+		mockUsecase := NewComplaintUseCase(mockComplaintRepo, new(MockComplaintFileRepo), new(MockRegencyRepo), new(MockSearchEngine))
+
+		mockComplaintRepo.On("GetAfterWithProcesses", constants.ExportBatchSize, "", "", "", map[string]interface{}(nil), "created_at", "DESC").Return([]entities.Complaint{}, nil)
+
+		called := false
+		err := mockUsecase.Export("", nil, "", "", func(complaints []entities.Complaint) error {
+			called = true
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.False(t, called)
+	})
+
+	t.Run("success full-text search keeps relevance across batches", func(t *testing.T) {
+		mockComplaintRepo := new(MockComplaintRepo)
+		mockSearchEngine := new(MockSearchEngine)
+		mockUsecase := NewComplaintUseCase(mockComplaintRepo, new(MockComplaintFileRepo), new(MockRegencyRepo), mockSearchEngine)
+
+		hits := make([]entities.SearchHit, constants.ExportBatchSize+1)
+		firstBatch := make([]entities.Complaint, constants.ExportBatchSize)
+		ids := make([]string, len(hits))
+		for i := range hits {
+			ids[i] = "C-" + strconv.Itoa(i)
+			hits[i] = entities.SearchHit{ID: ids[i]}
+			if i < len(firstBatch) {
+				firstBatch[i] = entities.Complaint{ID: ids[i]}
+			}
+		}
+		mockSearchEngine.On("Search", constants.SearchComplaints, "sampah", constants.MaxSearchHits).Return(hits, nil)
+		mockComplaintRepo.On("GetAfterWithProcesses", constants.ExportBatchSize, "", "", "", map[string]interface{}{"ids": ids}, "relevance", "DESC").Return(firstBatch, nil).Once()
+		mockComplaintRepo.On("GetAfterWithProcesses", constants.ExportBatchSize, "", "", "", map[string]interface{}{"ids": ids[constants.ExportBatchSize:]}, "relevance", "DESC").Return([]entities.Complaint{{ID: ids[constants.ExportBatchSize]}}, nil).Once()
+
+		written := []string{}
+		err := mockUsecase.Export("sampah", nil, "", "", func(complaints []entities.Complaint) error {
+			for _, complaint := range complaints {
+				written = append(written, complaint.ID)
+			}
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, ids, written)
+	})
+
+	t.Run("failed search engine error", func(t *testing.T) {
+		mockSearchEngine := new(MockSearchEngine)
+		mockUsecase := NewComplaintUseCase(new(MockComplaintRepo), new(MockComplaintFileRepo), new(MockRegencyRepo), mockSearchEngine)
+
+		mockSearchEngine.On("Search", constants.SearchComplaints, "sampah", constants.MaxSearchHits).Return([]entities.SearchHit(nil), errors.New("database error"))
+
+		err := mockUsecase.Export("sampah", nil, "", "", func(complaints []entities.Complaint) error { return nil })
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+
+	t.Run("failed invalid sort by", func(t *testing.T) {
+		mockUsecase := NewComplaintUseCase(new(MockComplaintRepo), new(MockComplaintFileRepo), new(MockRegencyRepo), new(MockSearchEngine))
+
+		err := mockUsecase.Export("", nil, "password", "", func(complaints []entities.Complaint) error { return nil })
+		assert.Equal(t, constants.ErrInvalidSortBy, err)
+	})
+
+	t.Run("failed internal server error", func(t *testing.T) {
+		mockComplaintRepo := new(MockComplaintRepo)
+		mockUsecase := NewComplaintUseCase(mockComplaintRepo, new(MockComplaintFileRepo), new(MockRegencyRepo), new(MockSearchEngine))
+
+		mockComplaintRepo.On("GetAfterWithProcesses", constants.ExportBatchSize, "", "", "", map[string]interface{}(nil), "created_at", "DESC").Return([]entities.Complaint(nil), errors.New("database error"))
+
+		err := mockUsecase.Export("", nil, "", "", func(complaints []entities.Complaint) error { return nil })
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+
+	t.Run("failed write error", func(t *testing.T) {
+		mockComplaintRepo := new(MockComplaintRepo)
+		mockUsecase := NewComplaintUseCase(mockComplaintRepo, new(MockComplaintFileRepo), new(MockRegencyRepo), new(MockSearchEngine))
+
+		mockComplaintRepo.On("GetAfterWithProcesses", constants.ExportBatchSize, "", "", "", map[string]interface{}(nil), "created_at", "DESC").Return([]entities.Complaint{{ID: "C-1"}}, nil)
+
+		writeErr := errors.New("connection reset")
+		err := mockUsecase.Export("", nil, "", "", func(complaints []entities.Complaint) error { return writeErr })
+		assert.Equal(t, writeErr, err)
+	})
+}
+
+func TestGetWithLocation(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockComplaintRepo := new(MockComplaintRepo)
+		mockComplaintFileRepo := new(MockComplaintFileRepo)
+		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo, new(MockRegencyRepo), new(MockSearchEngine))
+
+		mockComplaintRepo.On("GetWithLocation", constants.MaxGeoJSONFeatures, "", map[string]interface{}{"status": "Pending"}).Return([]entities.Complaint{{ID: "C-1"}}, nil)
+
+		result, err := mockUsecase.GetWithLocation("", map[string]interface{}{"status": "Pending"})
+		assert.NoError(t, err)
+		assert.Equal(t, []entities.Complaint{{ID: "C-1"}}, result)
+	})
+
+	t.Run("success full-text search", func(t *testing.T) {
+		mockComplaintRepo := new(MockComplaintRepo)
+		mockSearchEngine := new(MockSearchEngine)
+		mockUsecase := NewComplaintUseCase(mockComplaintRepo, new(MockComplaintFileRepo), new(MockRegencyRepo), mockSearchEngine)
+
+		mockSearchEngine.On("Search", constants.SearchComplaints, "banjir", constants.MaxSearchHits).Return([]entities.SearchHit{}, nil)
+		mockComplaintRepo.On("GetWithLocation", constants.MaxGeoJSONFeatures, "", map[string]interface{}{"ids": []string{}}).Return([]entities.Complaint{}, nil)
+
+		result, err := mockUsecase.GetWithLocation("banjir", nil)
+		assert.NoError(t, err)
+		assert.Empty(t, result)
+	})
+
+	t.Run("failed search engine error", func(t *testing.T) {
+		mockSearchEngine := new(MockSearchEngine)
+		mockUsecase := NewComplaintUseCase(new(MockComplaintRepo), new(MockComplaintFileRepo), new(MockRegencyRepo), mockSearchEngine)
+
+		mockSearchEngine.On("Search", constants.SearchComplaints, "banjir", constants.MaxSearchHits).Return([]entities.SearchHit(nil), errors.New("database error"))
+
+		_, err := mockUsecase.GetWithLocation("banjir", nil)
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+
+	t.Run("failed internal server error", func(t *testing.T) {
+		mockComplaintRepo := new(MockComplaintRepo)
+		mockComplaintFileRepo := new(MockComplaintFileRepo)
+		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo, new(MockRegencyRepo), new(MockSearchEngine))
+
+		mockComplaintRepo.On("GetWithLocation", constants.MaxGeoJSONFeatures, "", map[string]interface{}(nil)).Return([]entities.Complaint(nil), errors.New("database error"))
+
+		_, err := mockUsecase.GetWithLocation("", nil)
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+}
+
+// serangBoundary is a square around the center of Kota Serang.
+var serangBoundary = entities.RegencyBoundary{
+	RegencyID: "3673",
+	Geometry:  `{"type":"Polygon","coordinates":[[[106.05,-6.22],[106.3,-6.22],[106.3,-5.95],[106.05,-5.95],[106.05,-6.22]]]}`,
+}
+
+func TestCreateWithLocation(t *testing.T) {
+	newComplaint := func(lat *float64, lng *float64) entities.Complaint {
+		date, _ := time.Parse("2006-01-02", "2021-01-01")
+		return entities.Complaint{
+			UserID:      1,
+			CategoryID:  1,
+			RegencyID:   "3673",
+			Description: "description",
+			Address:     "address",
+			Type:        "public",
+			Date:        date,
+			Latitude:    lat,
+			Longitude:   lng,
+		}
+	}
+	float := func(value float64) *float64 {
+		return &value
+	}
+
+	t.Run("success inside regency", func(t *testing.T) {
+		complaint := newComplaint(float(-6.12), float(106.15))
+
+		mockComplaintRepo := new(MockComplaintRepo)
+		mockRegencyRepo := new(MockRegencyRepo)
+		mockUsecase := NewComplaintUseCase(mockComplaintRepo, new(MockComplaintFileRepo), mockRegencyRepo, new(MockSearchEngine))
+
+		mockRegencyRepo.On("GetBoundary", "3673").Return(serangBoundary, nil)
+		mockComplaintRepo.On("Create", &complaint).Return(nil)
+
+		_, err := mockUsecase.Create(&complaint)
+		assert.NoError(t, err)
+	})
+
+	t.Run("failed regency without boundary", func(t *testing.T) {
+		complaint := newComplaint(float(-6.12), float(106.15))
+
+		mockComplaintRepo := new(MockComplaintRepo)
+		mockRegencyRepo := new(MockRegencyRepo)
+		mockUsecase := NewComplaintUseCase(mockComplaintRepo, new(MockComplaintFileRepo), mockRegencyRepo, new(MockSearchEngine))
+
+		mockRegencyRepo.On("GetBoundary", "3673").Return(entities.RegencyBoundary{}, constants.ErrNotFound)
+
+		_, err := mockUsecase.Create(&complaint)
+		assert.Equal(t, constants.ErrRegencyHasNoBoundary, err)
+		mockComplaintRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("failed outside regency", func(t *testing.T) {
+		complaint := newComplaint(float(-6.30), float(106.65))
+
+		mockRegencyRepo := new(MockRegencyRepo)
+		mockUsecase := NewComplaintUseCase(new(MockComplaintRepo), new(MockComplaintFileRepo), mockRegencyRepo, new(MockSearchEngine))
+
+		mockRegencyRepo.On("GetBoundary", "3673").Return(serangBoundary, nil)
+
+		_, err := mockUsecase.Create(&complaint)
+		assert.Equal(t, constants.ErrLocationOutsideRegency, err)
+	})
+
+	t.Run("failed only latitude", func(t *testing.T) {
+		complaint := newComplaint(float(-6.12), nil)
+
+		mockUsecase := NewComplaintUseCase(new(MockComplaintRepo), new(MockComplaintFileRepo), new(MockRegencyRepo), new(MockSearchEngine))
+
+		_, err := mockUsecase.Create(&complaint)
+		assert.Equal(t, constants.ErrInvalidCoordinates, err)
+	})
+
+	t.Run("failed coordinates out of range", func(t *testing.T) {
+		complaint := newComplaint(float(-96.12), float(106.15))
+
+		mockUsecase := NewComplaintUseCase(new(MockComplaintRepo), new(MockComplaintFileRepo), new(MockRegencyRepo), new(MockSearchEngine))
+
+		_, err := mockUsecase.Create(&complaint)
+		assert.Equal(t, constants.ErrInvalidCoordinates, err)
+	})
+
+	t.Run("failed to get boundary", func(t *testing.T) {
+		complaint := newComplaint(float(-6.12), float(106.15))
+
+		mockRegencyRepo := new(MockRegencyRepo)
+		mockUsecase := NewComplaintUseCase(new(MockComplaintRepo), new(MockComplaintFileRepo), mockRegencyRepo, new(MockSearchEngine))
+
+		mockRegencyRepo.On("GetBoundary", "3673").Return(entities.RegencyBoundary{}, errors.New("database error"))
+
+		_, err := mockUsecase.Create(&complaint)
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+
+	t.Run("failed invalid boundary", func(t *testing.T) {
+		complaint := newComplaint(float(-6.12), float(106.15))
+
+		mockRegencyRepo := new(MockRegencyRepo)
+		mockUsecase := NewComplaintUseCase(new(MockComplaintRepo), new(MockComplaintFileRepo), mockRegencyRepo, new(MockSearchEngine))
+
+		mockRegencyRepo.On("GetBoundary", "3673").Return(entities.RegencyBoundary{Geometry: "{}"}, nil)
+
+		_, err := mockUsecase.Create(&complaint)
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+
+	t.Run("failed update outside regency", func(t *testing.T) {
+		complaint := newComplaint(float(-6.30), float(106.65))
+		complaint.ID = "C-1"
+
+		mockRegencyRepo := new(MockRegencyRepo)
+		mockUsecase := NewComplaintUseCase(new(MockComplaintRepo), new(MockComplaintFileRepo), mockRegencyRepo, new(MockSearchEngine))
+
+		mockRegencyRepo.On("GetBoundary", "3673").Return(serangBoundary, nil)
+
+		_, err := mockUsecase.Update(complaint)
+		assert.Equal(t, constants.ErrLocationOutsideRegency, err)
+	})
 }
 
 func TestGetByID(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		mockComplaintRepo := new(MockComplaintRepo)
 		mockComplaintFileRepo := new(MockComplaintFileRepo)
-		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo)
+		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo, new(MockRegencyRepo), new(MockSearchEngine))
 
 		mockComplaintRepo.On("GetByID", "1").Return(entities.Complaint{}, nil)
 
@@ -356,7 +863,7 @@ func TestGetByID(t *testing.T) {
 	t.Run("failed internal server error", func(t *testing.T) {
 		mockComplaintRepo := new(MockComplaintRepo)
 		mockComplaintFileRepo := new(MockComplaintFileRepo)
-		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo)
+		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo, new(MockRegencyRepo), new(MockSearchEngine))
 
 		mockComplaintRepo.On("GetByID", "1").Return(entities.Complaint{}, constants.ErrInternalServerError)
 
@@ -372,7 +879,7 @@ func TestGetByID(t *testing.T) {
 	t.Run("failed complaint not found", func(t *testing.T) {
 		mockComplaintRepo := new(MockComplaintRepo)
 		mockComplaintFileRepo := new(MockComplaintFileRepo)
-		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo)
+		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo, new(MockRegencyRepo), new(MockSearchEngine))
 
 		mockComplaintRepo.On("GetByID", "1").Return(entities.Complaint{}, constants.ErrComplaintNotFound)
 
@@ -390,7 +897,7 @@ func TestGetByUserID(t *testing.T) {
 	t.Run("success empty", func(t *testing.T) {
 		mockComplaintRepo := new(MockComplaintRepo)
 		mockComplaintFileRepo := new(MockComplaintFileRepo)
-		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo)
+		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo, new(MockRegencyRepo), new(MockSearchEngine))
 
 		mockComplaintRepo.On("GetByUserID", 1).Return([]entities.Complaint{}, nil)
 
@@ -405,7 +912,7 @@ func TestGetByUserID(t *testing.T) {
 	t.Run("success not empty", func(t *testing.T) {
 		mockComplaintRepo := new(MockComplaintRepo)
 		mockComplaintFileRepo := new(MockComplaintFileRepo)
-		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo)
+		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo, new(MockRegencyRepo), new(MockSearchEngine))
 
 		mockComplaintRepo.On("GetByUserID", 1).Return([]entities.Complaint{{ID: "1"}}, nil)
 
@@ -420,7 +927,7 @@ func TestGetByUserID(t *testing.T) {
 	t.Run("failed internal server error", func(t *testing.T) {
 		mockComplaintRepo := new(MockComplaintRepo)
 		mockComplaintFileRepo := new(MockComplaintFileRepo)
-		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo)
+		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo, new(MockRegencyRepo), new(MockSearchEngine))
 
 		mockComplaintRepo.On("GetByUserID", 1).Return([]entities.Complaint(nil), constants.ErrInternalServerError)
 
@@ -449,7 +956,7 @@ func TestCreate(t *testing.T) {
 
 		mockComplaintRepo := new(MockComplaintRepo)
 		mockComplaintFileRepo := new(MockComplaintFileRepo)
-		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo)
+		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo, new(MockRegencyRepo), new(MockSearchEngine))
 
 		mockComplaintRepo.On("Create", &complaint).Return(nil)
 
@@ -475,7 +982,7 @@ func TestCreate(t *testing.T) {
 		mockComplaintRepo := new(MockComplaintRepo)
 		mockComplaintFileRepo := new(MockComplaintFileRepo)
 
-		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo)
+		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo, new(MockRegencyRepo), new(MockSearchEngine))
 
 		result, err := mockUsecase.Create(&complaint)
 		assert.Error(t, constants.ErrAllFieldsMustBeFilled, err)
@@ -502,7 +1009,7 @@ func TestCreate(t *testing.T) {
 
 		mockComplaintRepo.On("Create", &complaint).Return(errors.New("REFERENCES `regencies` (`id`))"))
 
-		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo)
+		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo, new(MockRegencyRepo), new(MockSearchEngine))
 
 		result, err := mockUsecase.Create(&complaint)
 		assert.Error(t, constants.ErrRegencyNotFound, err)
@@ -529,7 +1036,7 @@ func TestCreate(t *testing.T) {
 
 		mockComplaintRepo.On("Create", &complaint).Return(errors.New("REFERENCES `categories` (`id`))"))
 
-		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo)
+		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo, new(MockRegencyRepo), new(MockSearchEngine))
 
 		result, err := mockUsecase.Create(&complaint)
 		assert.Error(t, constants.ErrCategoryNotFound, err)
@@ -556,7 +1063,7 @@ func TestCreate(t *testing.T) {
 
 		mockComplaintRepo.On("Create", &complaint).Return(constants.ErrInternalServerError)
 
-		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo)
+		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo, new(MockRegencyRepo), new(MockSearchEngine))
 
 		result, err := mockUsecase.Create(&complaint)
 		assert.Error(t, constants.ErrInternalServerError, err)
@@ -574,7 +1081,7 @@ func TestDelete(t *testing.T) {
 
 		mockComplaintRepo.On("AdminDelete", "1").Return(nil)
 
-		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo)
+		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo, new(MockRegencyRepo), new(MockSearchEngine))
 
 		err := mockUsecase.Delete("1", 1, "admin")
 		assert.NoError(t, err)
@@ -589,7 +1096,7 @@ func TestDelete(t *testing.T) {
 
 		mockComplaintRepo.On("Delete", "1", 1).Return(nil)
 
-		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo)
+		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo, new(MockRegencyRepo), new(MockSearchEngine))
 
 		err := mockUsecase.Delete("1", 1, "user")
 		assert.NoError(t, err)
@@ -604,7 +1111,7 @@ func TestDelete(t *testing.T) {
 
 		mockComplaintRepo.On("AdminDelete", "1").Return(constants.ErrInternalServerError)
 
-		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo)
+		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo, new(MockRegencyRepo), new(MockSearchEngine))
 
 		err := mockUsecase.Delete("1", 1, "admin")
 		assert.Error(t, constants.ErrInternalServerError, err)
@@ -619,7 +1126,7 @@ func TestDelete(t *testing.T) {
 
 		mockComplaintRepo.On("Delete", "1", 1).Return(constants.ErrInternalServerError)
 
-		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo)
+		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo, new(MockRegencyRepo), new(MockSearchEngine))
 
 		err := mockUsecase.Delete("1", 1, "user")
 		assert.Error(t, constants.ErrInternalServerError, err)
@@ -648,7 +1155,7 @@ func TestUpdate(t *testing.T) {
 
 		mockComplaintRepo.On("Update", complaint).Return(complaint, nil)
 
-		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo)
+		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo, new(MockRegencyRepo), new(MockSearchEngine))
 
 		result, err := mockUsecase.Update(complaint)
 		assert.NoError(t, err)
@@ -673,7 +1180,7 @@ func TestUpdate(t *testing.T) {
 		mockComplaintRepo := new(MockComplaintRepo)
 		mockComplaintFileRepo := new(MockComplaintFileRepo)
 
-		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo)
+		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo, new(MockRegencyRepo), new(MockSearchEngine))
 
 		result, err := mockUsecase.Update(complaint)
 		assert.Error(t, constants.ErrAllFieldsMustBeFilled, err)
@@ -701,7 +1208,7 @@ func TestUpdate(t *testing.T) {
 
 		mockComplaintRepo.On("Update", complaint).Return(entities.Complaint{}, errors.New("REFERENCES `regencies` (`id`))"))
 
-		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo)
+		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo, new(MockRegencyRepo), new(MockSearchEngine))
 
 		result, err := mockUsecase.Update(complaint)
 		assert.Error(t, constants.ErrRegencyNotFound, err)
@@ -729,7 +1236,7 @@ func TestUpdate(t *testing.T) {
 
 		mockComplaintRepo.On("Update", complaint).Return(entities.Complaint{}, errors.New("REFERENCES `categories` (`id`))"))
 
-		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo)
+		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo, new(MockRegencyRepo), new(MockSearchEngine))
 
 		result, err := mockUsecase.Update(complaint)
 		assert.Error(t, constants.ErrCategoryNotFound, err)
@@ -757,7 +1264,7 @@ func TestUpdate(t *testing.T) {
 
 		mockComplaintRepo.On("Update", complaint).Return(entities.Complaint{}, constants.ErrInternalServerError)
 
-		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo)
+		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo, new(MockRegencyRepo), new(MockSearchEngine))
 
 		result, err := mockUsecase.Update(complaint)
 		assert.Error(t, constants.ErrInternalServerError, err)
@@ -775,7 +1282,7 @@ func TestUpdateStatus(t *testing.T) {
 
 		mockComplaintRepo.On("UpdateStatus", "1", "Selesai").Return(nil)
 
-		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo)
+		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo, new(MockRegencyRepo), new(MockSearchEngine))
 
 		err := mockUsecase.UpdateStatus("1", "Selesai")
 		assert.NoError(t, err)
@@ -790,7 +1297,7 @@ func TestUpdateStatus(t *testing.T) {
 
 		mockComplaintRepo.On("UpdateStatus", "1", "Selesai").Return(constants.ErrInternalServerError)
 
-		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo)
+		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo, new(MockRegencyRepo), new(MockSearchEngine))
 
 		err := mockUsecase.UpdateStatus("1", "Selesai")
 		assert.Error(t, constants.ErrInternalServerError, err)
@@ -803,7 +1310,7 @@ func TestUpdateStatus(t *testing.T) {
 		mockComplaintRepo := new(MockComplaintRepo)
 		mockComplaintFileRepo := new(MockComplaintFileRepo)
 
-		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo)
+		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo, new(MockRegencyRepo), new(MockSearchEngine))
 
 		err := mockUsecase.UpdateStatus("1", "Invalid")
 		assert.Error(t, constants.ErrInvalidStatus, err)
@@ -816,7 +1323,7 @@ func TestUpdateStatus(t *testing.T) {
 		mockComplaintRepo := new(MockComplaintRepo)
 		mockComplaintFileRepo := new(MockComplaintFileRepo)
 
-		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo)
+		mockUsecase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo, new(MockRegencyRepo), new(MockSearchEngine))
 
 		err := mockUsecase.UpdateStatus("", "Selesai")
 		assert.Error(t, constants.ErrIDMustBeFilled, err)
@@ -826,161 +1333,12 @@ func TestUpdateStatus(t *testing.T) {
 	})
 }
 
-// Mock utility function
-func GetRowsFromExcel(file *multipart.FileHeader) ([][]string, error) {
-	// This is just a placeholder. You should mock this function as needed.
-	return nil, nil
-}
-
-func TestImport(t *testing.T) {
-	t.Run("success", func(t *testing.T) {
-		mockComplaintRepo := new(MockComplaintRepo)
-		mockComplaintFileRepo := new(MockComplaintFileRepo)
-
-		complaintUseCase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo)
-
-		fileHeader := &multipart.FileHeader{}
-
-		rows := [][]string{
-			{"UserID", "CategoryID", "RegencyID", "Address", "Description", "Status", "Type", "Date", "Files"},
-			{"1", "2", "Regency1", "Address1", "Description1", "Verifikasi", "Type1", "05-05-2024", "file1.jpg,file2.jpg"},
-			{"1", "2", "Regency1", "Address1", "Description1", "On Progress", "Type1", "05-05-2024", "file1.jpg"},
-			{"1", "2", "Regency1", "Address1", "Description1", "Selesai", "Type1", "05-05-2024", "file1.jpg,file2.jpg"},
-			{"1", "2", "Regency1", "Address1", "Description1", "Ditolak", "Type1", "05-05-2024", "file1.jpg"},
-			{"1", "2", "Regency1", "Address1", "Description1", "Pending", "Type1", "05-05-2024", "file1.jpg"},
-		}
-
-		complaintUseCase.getRowsFromExcel = func(file *multipart.FileHeader) ([][]string, error) {
-			return rows, nil
-		}
-
-		mockComplaintRepo.On("Import", mock.Anything).Return(nil)
-
-		err := complaintUseCase.Import(fileHeader)
-		assert.NoError(t, err)
-
-		mockComplaintRepo.AssertExpectations(t)
-	})
-
-	t.Run("failed getting rows from excel", func(t *testing.T) {
-		mockComplaintRepo := new(MockComplaintRepo)
-		mockComplaintFileRepo := new(MockComplaintFileRepo)
-
-		complaintUseCase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo)
-
-		fileHeader := &multipart.FileHeader{}
-		complaintUseCase.getRowsFromExcel = func(file *multipart.FileHeader) ([][]string, error) {
-			return nil, errors.New("failed to read excel")
-		}
-
-		err := complaintUseCase.Import(fileHeader)
-		assert.Error(t, err)
-		assert.Equal(t, "failed to read excel", err.Error())
-	})
-
-	t.Run("failed importing complaints", func(t *testing.T) {
-		mockComplaintRepo := new(MockComplaintRepo)
-		mockComplaintFileRepo := new(MockComplaintFileRepo)
-
-		complaintUseCase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo)
-
-		fileHeader := &multipart.FileHeader{}
-
-		rows := [][]string{
-			{"UserID", "CategoryID", "RegencyID", "Address", "Description", "Status", "Type", "Date", "Files"},
-			{"1", "2", "Regency1", "Address1", "Description1", "Verifikasi", "Type1", "05-05-2024", "file1.jpg"},
-		}
-
-		complaintUseCase.getRowsFromExcel = func(file *multipart.FileHeader) ([][]string, error) {
-			return rows, nil
-		}
-
-		mockComplaintRepo.On("Import", mock.Anything).Return(errors.New("import failed"))
-
-		err := complaintUseCase.Import(fileHeader)
-		assert.Error(t, err)
-		assert.Equal(t, "import failed", err.Error())
-
-		mockComplaintRepo.AssertExpectations(t)
-	})
-
-	t.Run("failed not enough columns", func(t *testing.T) {
-		mockComplaintRepo := new(MockComplaintRepo)
-		mockComplaintFileRepo := new(MockComplaintFileRepo)
-
-		complaintUseCase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo)
-
-		fileHeader := &multipart.FileHeader{}
-
-		rows := [][]string{
-			{"UserID", "CategoryID", "RegencyID", "Address", "Description"},
-			{"1", "2", "Regency1", "Address1"},
-		}
-
-		complaintUseCase.getRowsFromExcel = func(file *multipart.FileHeader) ([][]string, error) {
-			return rows, nil
-		}
-
-		err := complaintUseCase.Import(fileHeader)
-		assert.Error(t, err)
-		assert.Equal(t, constants.ErrColumnsDoesntMatch, err)
-	})
-
-	t.Run("failed invalid user id", func(t *testing.T) {
-		mockComplaintRepo := new(MockComplaintRepo)
-		mockComplaintFileRepo := new(MockComplaintFileRepo)
-
-		complaintUseCase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo)
-
-		fileHeader := &multipart.FileHeader{}
-
-		rows := [][]string{
-			{"UserID", "CategoryID", "RegencyID", "Address", "Description", "Status", "Type", "Date", "Files"},
-			{"a", "2", "Regency1", "Address1", "Description1", "Verifikasi", "Type1", "05-05-2024", "file1.jpg"},
-		}
-
-		complaintUseCase.getRowsFromExcel = func(file *multipart.FileHeader) ([][]string, error) {
-			return rows, nil
-		}
-
-		err := complaintUseCase.Import(fileHeader)
-		assert.Error(t, err)
-		assert.Equal(t, constants.ErrInvalidIDFormat, err)
-
-		mockComplaintRepo.AssertExpectations(t)
-	})
-
-	t.Run("failed invalid category id", func(t *testing.T) {
-		mockComplaintRepo := new(MockComplaintRepo)
-		mockComplaintFileRepo := new(MockComplaintFileRepo)
-
-		complaintUseCase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo)
-
-		fileHeader := &multipart.FileHeader{}
-
-		rows := [][]string{
-			{"UserID", "CategoryID", "RegencyID", "Address", "Description", "Status", "Type", "Date", "Files"},
-			{"1", "a", "Regency1", "Address1", "Description1", "Verifikasi", "Type1", "05-05-2024", "file1.jpg"},
-		}
-
-		complaintUseCase.getRowsFromExcel = func(file *multipart.FileHeader) ([][]string, error) {
-			return rows, nil
-		}
-
-		err := complaintUseCase.Import(fileHeader)
-		assert.Error(t, err)
-		assert.Equal(t, constants.ErrInvalidCategoryIDFormat, err)
-
-		mockComplaintRepo.AssertExpectations(t)
-	})
-}
-
 func TestIncreaseTotalLikes(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		mockComplaintRepo := new(MockComplaintRepo)
 		mockComplaintFileRepo := new(MockComplaintFileRepo)
 
-		complaintUseCase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo)
+		complaintUseCase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo, new(MockRegencyRepo), new(MockSearchEngine))
 
 		mockComplaintRepo.On("IncreaseTotalLikes", "1").Return(nil)
 
@@ -994,7 +1352,7 @@ func TestIncreaseTotalLikes(t *testing.T) {
 		mockComplaintRepo := new(MockComplaintRepo)
 		mockComplaintFileRepo := new(MockComplaintFileRepo)
 
-		complaintUseCase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo)
+		complaintUseCase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo, new(MockRegencyRepo), new(MockSearchEngine))
 
 		mockComplaintRepo.On("IncreaseTotalLikes", "1").Return(constants.ErrInternalServerError)
 
@@ -1010,7 +1368,7 @@ func TestDecreaseTotalLikes(t *testing.T) {
 		mockComplaintRepo := new(MockComplaintRepo)
 		mockComplaintFileRepo := new(MockComplaintFileRepo)
 
-		complaintUseCase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo)
+		complaintUseCase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo, new(MockRegencyRepo), new(MockSearchEngine))
 
 		mockComplaintRepo.On("DecreaseTotalLikes", "1").Return(nil)
 
@@ -1024,7 +1382,7 @@ func TestDecreaseTotalLikes(t *testing.T) {
 		mockComplaintRepo := new(MockComplaintRepo)
 		mockComplaintFileRepo := new(MockComplaintFileRepo)
 
-		complaintUseCase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo)
+		complaintUseCase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo, new(MockRegencyRepo), new(MockSearchEngine))
 
 		mockComplaintRepo.On("DecreaseTotalLikes", "1").Return(constants.ErrInternalServerError)
 
@@ -1040,7 +1398,7 @@ func TestGetComplaintIDsByUserID(t *testing.T) {
 		mockComplaintRepo := new(MockComplaintRepo)
 		mockComplaintFileRepo := new(MockComplaintFileRepo)
 
-		complaintUseCase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo)
+		complaintUseCase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo, new(MockRegencyRepo), new(MockSearchEngine))
 
 		mockComplaintRepo.On("GetComplaintIDsByUserID", 1).Return([]string{}, nil)
 
@@ -1055,7 +1413,7 @@ func TestGetComplaintIDsByUserID(t *testing.T) {
 		mockComplaintRepo := new(MockComplaintRepo)
 		mockComplaintFileRepo := new(MockComplaintFileRepo)
 
-		complaintUseCase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo)
+		complaintUseCase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo, new(MockRegencyRepo), new(MockSearchEngine))
 
 		mockComplaintRepo.On("GetComplaintIDsByUserID", 1).Return([]string{"1", "2"}, nil)
 
@@ -1070,7 +1428,7 @@ func TestGetComplaintIDsByUserID(t *testing.T) {
 		mockComplaintRepo := new(MockComplaintRepo)
 		mockComplaintFileRepo := new(MockComplaintFileRepo)
 
-		complaintUseCase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo)
+		complaintUseCase := NewComplaintUseCase(mockComplaintRepo, mockComplaintFileRepo, new(MockRegencyRepo), new(MockSearchEngine))
 
 		mockComplaintRepo.On("GetComplaintIDsByUserID", 1).Return([]string(nil), constants.ErrInternalServerError)
 
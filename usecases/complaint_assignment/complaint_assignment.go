@@ -0,0 +1,234 @@
+package complaint_assignment
+
+import (
+	"e-complaint-api/constants"
+	"e-complaint-api/entities"
+	"e-complaint-api/workflow"
+	"errors"
+	"strings"
+	"time"
+)
+
+type ComplaintAssignmentUseCase struct {
+	repository  entities.ComplaintAssignmentRepositoryInterface
+	unitOfWork  entities.UnitOfWorkInterface
+	roleUseCase entities.RoleUseCaseInterface
+}
+
+func NewComplaintAssignmentUseCase(repository entities.ComplaintAssignmentRepositoryInterface, unitOfWork entities.UnitOfWorkInterface, roleUseCase entities.RoleUseCaseInterface) *ComplaintAssignmentUseCase {
+	return &ComplaintAssignmentUseCase{
+		repository:  repository,
+		unitOfWork:  unitOfWork,
+		roleUseCase: roleUseCase,
+	}
+}
+
+// ensureCanProcess returns an error when the admin may not process complaints or the
+// complaint is outside of their scope, so they could not handle it once assigned.
+func (u *ComplaintAssignmentUseCase) ensureCanProcess(adminID int, complaint entities.Complaint) error {
+	if err := u.roleUseCase.HasPermission(adminID, "admin", constants.PermissionComplaintProcess); err != nil {
+		if errors.Is(err, constants.ErrUnauthorized) {
+			return constants.ErrAdminNotFound
+		} else if errors.Is(err, constants.ErrForbidden) {
+			return constants.ErrAdminCannotProcessComplaints
+		}
+		return err
+	}
+
+	if err := u.roleUseCase.EnsureInScope(adminID, "admin", complaint); err != nil {
+		if errors.Is(err, constants.ErrUnauthorized) {
+			return constants.ErrAdminNotFound
+		} else if errors.Is(err, constants.ErrComplaintOutOfScope) {
+			return constants.ErrOutsideAdminScope
+		}
+		return err
+	}
+
+	return nil
+}
+
+// Assign hands a complaint over to an admin. A super admin may assign any complaint,
+// an admin only a complaint that is unassigned or assigned to themselves.
+func (u *ComplaintAssignmentUseCase) Assign(complaintID string, adminID int, assignedByID int, role string, note string) (entities.ComplaintAssignment, error) {
+	if complaintID == "" || adminID == 0 {
+		return entities.ComplaintAssignment{}, constants.ErrAllFieldsMustBeFilled
+	}
+
+	complaintAssignment := entities.ComplaintAssignment{
+		ComplaintID:  complaintID,
+		AdminID:      adminID,
+		AssignedByID: &assignedByID,
+		Note:         note,
+	}
+
+	err := u.unitOfWork.Do(func(repositories entities.UnitOfWorkRepositories) error {
+		assigneeID, err := repositories.Assignment.GetAssigneeID(complaintID)
+		if err != nil {
+			return err
+		}
+
+		if role != "super_admin" && assigneeID != 0 && assigneeID != assignedByID {
+			return constants.ErrComplaintNotAssignedToYou
+		}
+
+		if assigneeID == adminID {
+			return constants.ErrComplaintAlreadyAssigned
+		}
+
+		complaint, err := repositories.Complaint.GetByID(complaintID)
+		if err != nil {
+			return err
+		}
+
+		if err := u.ensureCanProcess(adminID, complaint); err != nil {
+			return err
+		}
+
+		return repositories.Assignment.Assign(&complaintAssignment)
+	})
+	if err != nil {
+		if errors.Is(err, constants.ErrComplaintNotFound) || errors.Is(err, constants.ErrComplaintNotAssignedToYou) || errors.Is(err, constants.ErrComplaintAlreadyAssigned) ||
+			errors.Is(err, constants.ErrAdminNotFound) || errors.Is(err, constants.ErrAdminCannotProcessComplaints) || errors.Is(err, constants.ErrOutsideAdminScope) {
+			return entities.ComplaintAssignment{}, err
+		} else if strings.HasSuffix(err.Error(), "REFERENCES `admins` (`id`))") {
+			return entities.ComplaintAssignment{}, constants.ErrAdminNotFound
+		}
+		return entities.ComplaintAssignment{}, constants.ErrInternalServerError
+	}
+
+	return complaintAssignment, nil
+}
+
+// AutoAssign assigns a new complaint to the pool member of its category and regency
+// that has gone the longest without an assignment. The complaint stays unassigned
+// when no pool member matches.
+func (u *ComplaintAssignmentUseCase) AutoAssign(complaint entities.Complaint) (entities.ComplaintAssignment, error) {
+	var complaintAssignment entities.ComplaintAssignment
+
+	err := u.unitOfWork.Do(func(repositories entities.UnitOfWorkRepositories) error {
+		assignmentPool, err := repositories.Assignment.GetNextPoolMember(complaint.CategoryID, complaint.RegencyID)
+		if err != nil {
+			return err
+		}
+
+		complaintAssignment = entities.ComplaintAssignment{
+			ComplaintID: complaint.ID,
+			AdminID:     assignmentPool.AdminID,
+			Note:        "Aduan ditugaskan secara otomatis",
+		}
+
+		if err := repositories.Assignment.Assign(&complaintAssignment); err != nil {
+			return err
+		}
+
+		return repositories.Assignment.UpdatePoolLastAssignedAt(assignmentPool.ID, time.Now())
+	})
+	if err != nil {
+		if errors.Is(err, constants.ErrAssignmentPoolNotFound) {
+			return entities.ComplaintAssignment{}, nil
+		}
+		return entities.ComplaintAssignment{}, constants.ErrInternalServerError
+	}
+
+	return complaintAssignment, nil
+}
+
+// EnsureCanProcess returns an error when the admin may not add processes to the
+// complaint. Only its assignee and super admins may, so an unassigned complaint has to
+// be assigned first.
+func (u *ComplaintAssignmentUseCase) EnsureCanProcess(complaintID string, adminID int, role string) error {
+	if role == "super_admin" {
+		return nil
+	}
+
+	assigneeID, err := u.repository.GetAssigneeID(complaintID)
+	if err != nil {
+		if errors.Is(err, constants.ErrComplaintNotFound) {
+			return err
+		}
+		return constants.ErrInternalServerError
+	}
+
+	if assigneeID != adminID {
+		return constants.ErrComplaintNotAssignedToYou
+	}
+
+	return nil
+}
+
+func (u *ComplaintAssignmentUseCase) GetHistory(complaintID string) ([]entities.ComplaintAssignment, error) {
+	complaintAssignments, err := u.repository.GetHistory(complaintID)
+	if err != nil {
+		return nil, constants.ErrInternalServerError
+	}
+
+	return complaintAssignments, nil
+}
+
+// GetQueue returns the complaints assigned to the admin that still need handling.
+func (u *ComplaintAssignmentUseCase) GetQueue(adminID int) ([]entities.Complaint, error) {
+	complaints, err := u.repository.GetQueue(adminID, workflow.Complaint.FinalStatuses())
+	if err != nil {
+		return nil, constants.ErrInternalServerError
+	}
+
+	return complaints, nil
+}
+
+func (u *ComplaintAssignmentUseCase) GetPools() ([]entities.AssignmentPool, error) {
+	assignmentPools, err := u.repository.GetPools()
+	if err != nil {
+		return nil, constants.ErrInternalServerError
+	}
+
+	return assignmentPools, nil
+}
+
+func (u *ComplaintAssignmentUseCase) CreatePool(assignmentPool *entities.AssignmentPool) (entities.AssignmentPool, error) {
+	if assignmentPool.AdminID == 0 {
+		return entities.AssignmentPool{}, constants.ErrAllFieldsMustBeFilled
+	}
+
+	// A pool without a category or regency matches every category or regency, which
+	// only fits an admin that is not limited to some of them
+	var complaint entities.Complaint
+	if assignmentPool.CategoryID != nil {
+		complaint.CategoryID = *assignmentPool.CategoryID
+	}
+	if assignmentPool.RegencyID != nil {
+		complaint.RegencyID = *assignmentPool.RegencyID
+	}
+
+	if err := u.ensureCanProcess(assignmentPool.AdminID, complaint); err != nil {
+		if errors.Is(err, constants.ErrAdminNotFound) || errors.Is(err, constants.ErrAdminCannotProcessComplaints) || errors.Is(err, constants.ErrOutsideAdminScope) {
+			return entities.AssignmentPool{}, err
+		}
+		return entities.AssignmentPool{}, constants.ErrInternalServerError
+	}
+
+	err := u.repository.CreatePool(assignmentPool)
+	if err != nil {
+		if strings.HasSuffix(err.Error(), "REFERENCES `admins` (`id`))") {
+			return entities.AssignmentPool{}, constants.ErrAdminNotFound
+		} else if strings.HasSuffix(err.Error(), "REFERENCES `categories` (`id`))") {
+			return entities.AssignmentPool{}, constants.ErrCategoryNotFound
+		} else if strings.HasSuffix(err.Error(), "REFERENCES `regencies` (`id`))") {
+			return entities.AssignmentPool{}, constants.ErrRegencyNotFound
+		}
+		return entities.AssignmentPool{}, constants.ErrInternalServerError
+	}
+
+	return *assignmentPool, nil
+}
+
+func (u *ComplaintAssignmentUseCase) DeletePool(id int) error {
+	err := u.repository.DeletePool(id)
+	if err != nil {
+		if errors.Is(err, constants.ErrAssignmentPoolNotFound) {
+			return err
+		}
+		return constants.ErrInternalServerError
+	}
+
+	return nil
+}
@@ -0,0 +1,729 @@
+package complaint_assignment
+
+import (
+	"e-complaint-api/constants"
+	"e-complaint-api/entities"
+	"e-complaint-api/workflow"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockComplaintAssignmentRepo struct {
+	mock.Mock
+}
+
+func (m *MockComplaintAssignmentRepo) Assign(complaintAssignment *entities.ComplaintAssignment) error {
+	args := m.Called(complaintAssignment)
+	return args.Error(0)
+}
+
+func (m *MockComplaintAssignmentRepo) GetAssigneeID(complaintID string) (int, error) {
+	args := m.Called(complaintID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockComplaintAssignmentRepo) GetHistory(complaintID string) ([]entities.ComplaintAssignment, error) {
+	args := m.Called(complaintID)
+	return args.Get(0).([]entities.ComplaintAssignment), args.Error(1)
+}
+
+func (m *MockComplaintAssignmentRepo) GetQueue(adminID int, excludedStatuses []string) ([]entities.Complaint, error) {
+	args := m.Called(adminID, excludedStatuses)
+	return args.Get(0).([]entities.Complaint), args.Error(1)
+}
+
+func (m *MockComplaintAssignmentRepo) GetNextPoolMember(categoryID int, regencyID string) (entities.AssignmentPool, error) {
+	args := m.Called(categoryID, regencyID)
+	return args.Get(0).(entities.AssignmentPool), args.Error(1)
+}
+
+func (m *MockComplaintAssignmentRepo) UpdatePoolLastAssignedAt(id int, lastAssignedAt time.Time) error {
+	args := m.Called(id, lastAssignedAt)
+	return args.Error(0)
+}
+
+func (m *MockComplaintAssignmentRepo) GetPools() ([]entities.AssignmentPool, error) {
+	args := m.Called()
+	return args.Get(0).([]entities.AssignmentPool), args.Error(1)
+}
+
+func (m *MockComplaintAssignmentRepo) CreatePool(assignmentPool *entities.AssignmentPool) error {
+	args := m.Called(assignmentPool)
+	return args.Error(0)
+}
+
+func (m *MockComplaintAssignmentRepo) DeletePool(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+type MockComplaintRepo struct {
+	mock.Mock
+}
+
+func (m *MockComplaintRepo) GetPaginated(limit int, page int, search string, filter map[string]interface{}, sortBy string, sortType string) ([]entities.Complaint, error) {
+	args := m.Called(limit, page, search, filter, sortBy, sortType)
+	return args.Get(0).([]entities.Complaint), args.Error(1)
+}
+
+func (m *MockComplaintRepo) GetMetaData(limit int, page int, search string, filter map[string]interface{}) (entities.Metadata, error) {
+	args := m.Called(limit, page, search, filter)
+	return args.Get(0).(entities.Metadata), args.Error(1)
+}
+
+func (m *MockComplaintRepo) GetAfter(limit int, afterID string, afterValue string, search string, filter map[string]interface{}, sortBy string, sortType string) ([]entities.Complaint, error) {
+	args := m.Called(limit, afterID, afterValue, search, filter, sortBy, sortType)
+	return args.Get(0).([]entities.Complaint), args.Error(1)
+}
+
+func (m *MockComplaintRepo) GetAfterWithProcesses(limit int, afterID string, afterValue string, search string, filter map[string]interface{}, sortBy string, sortType string) ([]entities.Complaint, error) {
+	args := m.Called(limit, afterID, afterValue, search, filter, sortBy, sortType)
+	return args.Get(0).([]entities.Complaint), args.Error(1)
+}
+
+func (m *MockComplaintRepo) GetWithLocation(limit int, search string, filter map[string]interface{}) ([]entities.Complaint, error) {
+	args := m.Called(limit, search, filter)
+	return args.Get(0).([]entities.Complaint), args.Error(1)
+}
+
+func (m *MockComplaintRepo) GetByID(id string) (entities.Complaint, error) {
+	args := m.Called(id)
+	return args.Get(0).(entities.Complaint), args.Error(1)
+}
+
+func (m *MockComplaintRepo) GetByUserID(userId int) ([]entities.Complaint, error) {
+	args := m.Called(userId)
+	return args.Get(0).([]entities.Complaint), args.Error(1)
+}
+
+func (m *MockComplaintRepo) Create(complaint *entities.Complaint) error {
+	args := m.Called(complaint)
+	return args.Error(0)
+}
+
+func (m *MockComplaintRepo) Delete(id string, userId int) error {
+	args := m.Called(id, userId)
+	return args.Error(0)
+}
+
+func (m *MockComplaintRepo) AdminDelete(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockComplaintRepo) Update(complaint entities.Complaint) (entities.Complaint, error) {
+	args := m.Called(complaint)
+	return args.Get(0).(entities.Complaint), args.Error(1)
+}
+
+func (m *MockComplaintRepo) UpdateStatus(id string, status string) error {
+	args := m.Called(id, status)
+	return args.Error(0)
+}
+
+func (m *MockComplaintRepo) GetStatus(id string) (string, error) {
+	args := m.Called(id)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockComplaintRepo) IncreaseTotalLikes(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockComplaintRepo) DecreaseTotalLikes(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockComplaintRepo) GetComplaintIDsByUserID(userID int) ([]string, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+type MockRoleUseCase struct {
+	mock.Mock
+}
+
+func (m *MockRoleUseCase) GetAll() ([]entities.Role, error) {
+	args := m.Called()
+	return args.Get(0).([]entities.Role), args.Error(1)
+}
+
+func (m *MockRoleUseCase) GetByID(id int) (entities.Role, error) {
+	args := m.Called(id)
+	return args.Get(0).(entities.Role), args.Error(1)
+}
+
+func (m *MockRoleUseCase) Create(role *entities.Role, permissions []string) (entities.Role, error) {
+	args := m.Called(role, permissions)
+	return args.Get(0).(entities.Role), args.Error(1)
+}
+
+func (m *MockRoleUseCase) Update(role *entities.Role, permissions []string) (entities.Role, error) {
+	args := m.Called(role, permissions)
+	return args.Get(0).(entities.Role), args.Error(1)
+}
+
+func (m *MockRoleUseCase) Delete(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockRoleUseCase) GetPermissions() ([]entities.Permission, error) {
+	args := m.Called()
+	return args.Get(0).([]entities.Permission), args.Error(1)
+}
+
+func (m *MockRoleUseCase) GetAdminAccess(adminID int) (entities.Admin, error) {
+	args := m.Called(adminID)
+	return args.Get(0).(entities.Admin), args.Error(1)
+}
+
+func (m *MockRoleUseCase) UpdateAdminAccess(adminID int, roleID *int, regencyIDs []string, categoryIDs []int) (entities.Admin, error) {
+	args := m.Called(adminID, roleID, regencyIDs, categoryIDs)
+	return args.Get(0).(entities.Admin), args.Error(1)
+}
+
+func (m *MockRoleUseCase) HasPermission(adminID int, role string, permission string) error {
+	args := m.Called(adminID, role, permission)
+	return args.Error(0)
+}
+
+func (m *MockRoleUseCase) GetScope(adminID int, role string) (entities.AdminScope, error) {
+	args := m.Called(adminID, role)
+	return args.Get(0).(entities.AdminScope), args.Error(1)
+}
+
+func (m *MockRoleUseCase) EnsureInScope(adminID int, role string, complaint entities.Complaint) error {
+	args := m.Called(adminID, role, complaint)
+	return args.Error(0)
+}
+
+type MockUnitOfWork struct {
+	complaintAssignmentRepo *MockComplaintAssignmentRepo
+	complaintRepo           *MockComplaintRepo
+}
+
+func (m *MockUnitOfWork) Do(fn func(repositories entities.UnitOfWorkRepositories) error) error {
+	return fn(entities.UnitOfWorkRepositories{
+		Assignment: m.complaintAssignmentRepo,
+		Complaint:  m.complaintRepo,
+	})
+}
+
+func TestAssign(t *testing.T) {
+	complaint := entities.Complaint{ID: "C-123", CategoryID: 1, RegencyID: "3601"}
+
+	t.Run("success", func(t *testing.T) {
+		mockRepo := new(MockComplaintAssignmentRepo)
+		mockComplaintRepo := new(MockComplaintRepo)
+		mockRoleUseCase := new(MockRoleUseCase)
+		usecase := NewComplaintAssignmentUseCase(mockRepo, &MockUnitOfWork{mockRepo, mockComplaintRepo}, mockRoleUseCase)
+
+		mockRepo.On("GetAssigneeID", "C-123").Return(2, nil)
+		mockComplaintRepo.On("GetByID", "C-123").Return(complaint, nil)
+		mockRoleUseCase.On("HasPermission", 3, "admin", constants.PermissionComplaintProcess).Return(nil)
+		mockRoleUseCase.On("EnsureInScope", 3, "admin", complaint).Return(nil)
+		mockRepo.On("Assign", mock.Anything).Return(nil)
+
+		result, err := usecase.Assign("C-123", 3, 2, "admin", "Dialihkan ke bidang terkait")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "C-123", result.ComplaintID)
+		assert.Equal(t, 3, result.AdminID)
+		assert.Equal(t, 2, *result.AssignedByID)
+		assert.Equal(t, "Dialihkan ke bidang terkait", result.Note)
+	})
+
+	t.Run("success super admin reassigns", func(t *testing.T) {
+		mockRepo := new(MockComplaintAssignmentRepo)
+		mockComplaintRepo := new(MockComplaintRepo)
+		mockRoleUseCase := new(MockRoleUseCase)
+		usecase := NewComplaintAssignmentUseCase(mockRepo, &MockUnitOfWork{mockRepo, mockComplaintRepo}, mockRoleUseCase)
+
+		mockRepo.On("GetAssigneeID", "C-123").Return(2, nil)
+		mockComplaintRepo.On("GetByID", "C-123").Return(complaint, nil)
+		mockRoleUseCase.On("HasPermission", 3, "admin", constants.PermissionComplaintProcess).Return(nil)
+		mockRoleUseCase.On("EnsureInScope", 3, "admin", complaint).Return(nil)
+		mockRepo.On("Assign", mock.Anything).Return(nil)
+
+		_, err := usecase.Assign("C-123", 3, 1, "super_admin", "")
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("failed fields are empty", func(t *testing.T) {
+		mockRepo := new(MockComplaintAssignmentRepo)
+		mockComplaintRepo := new(MockComplaintRepo)
+		mockRoleUseCase := new(MockRoleUseCase)
+		usecase := NewComplaintAssignmentUseCase(mockRepo, &MockUnitOfWork{mockRepo, mockComplaintRepo}, mockRoleUseCase)
+
+		_, err := usecase.Assign("", 3, 1, "super_admin", "")
+
+		assert.Equal(t, constants.ErrAllFieldsMustBeFilled, err)
+	})
+
+	t.Run("failed complaint not found", func(t *testing.T) {
+		mockRepo := new(MockComplaintAssignmentRepo)
+		mockComplaintRepo := new(MockComplaintRepo)
+		mockRoleUseCase := new(MockRoleUseCase)
+		usecase := NewComplaintAssignmentUseCase(mockRepo, &MockUnitOfWork{mockRepo, mockComplaintRepo}, mockRoleUseCase)
+
+		mockRepo.On("GetAssigneeID", "C-123").Return(0, constants.ErrComplaintNotFound)
+
+		_, err := usecase.Assign("C-123", 3, 1, "super_admin", "")
+
+		assert.Equal(t, constants.ErrComplaintNotFound, err)
+	})
+
+	t.Run("failed complaint not assigned to admin", func(t *testing.T) {
+		mockRepo := new(MockComplaintAssignmentRepo)
+		mockComplaintRepo := new(MockComplaintRepo)
+		mockRoleUseCase := new(MockRoleUseCase)
+		usecase := NewComplaintAssignmentUseCase(mockRepo, &MockUnitOfWork{mockRepo, mockComplaintRepo}, mockRoleUseCase)
+
+		mockRepo.On("GetAssigneeID", "C-123").Return(4, nil)
+
+		_, err := usecase.Assign("C-123", 3, 2, "admin", "")
+
+		assert.Equal(t, constants.ErrComplaintNotAssignedToYou, err)
+	})
+
+	t.Run("failed complaint already assigned", func(t *testing.T) {
+		mockRepo := new(MockComplaintAssignmentRepo)
+		mockComplaintRepo := new(MockComplaintRepo)
+		mockRoleUseCase := new(MockRoleUseCase)
+		usecase := NewComplaintAssignmentUseCase(mockRepo, &MockUnitOfWork{mockRepo, mockComplaintRepo}, mockRoleUseCase)
+
+		mockRepo.On("GetAssigneeID", "C-123").Return(3, nil)
+
+		_, err := usecase.Assign("C-123", 3, 1, "super_admin", "")
+
+		assert.Equal(t, constants.ErrComplaintAlreadyAssigned, err)
+	})
+
+	t.Run("failed admin not found", func(t *testing.T) {
+		mockRepo := new(MockComplaintAssignmentRepo)
+		mockComplaintRepo := new(MockComplaintRepo)
+		mockRoleUseCase := new(MockRoleUseCase)
+		usecase := NewComplaintAssignmentUseCase(mockRepo, &MockUnitOfWork{mockRepo, mockComplaintRepo}, mockRoleUseCase)
+
+		mockRepo.On("GetAssigneeID", "C-123").Return(0, nil)
+		mockComplaintRepo.On("GetByID", "C-123").Return(complaint, nil)
+		mockRoleUseCase.On("HasPermission", 99, "admin", constants.PermissionComplaintProcess).Return(nil)
+		mockRoleUseCase.On("EnsureInScope", 99, "admin", complaint).Return(nil)
+		mockRepo.On("Assign", mock.Anything).Return(errors.New("FOREIGN KEY (`admin_id`) REFERENCES `admins` (`id`))"))
+
+		_, err := usecase.Assign("C-123", 99, 1, "super_admin", "")
+
+		assert.Equal(t, constants.ErrAdminNotFound, err)
+	})
+
+	t.Run("failed admin cannot process complaints", func(t *testing.T) {
+		mockRepo := new(MockComplaintAssignmentRepo)
+		mockComplaintRepo := new(MockComplaintRepo)
+		mockRoleUseCase := new(MockRoleUseCase)
+		usecase := NewComplaintAssignmentUseCase(mockRepo, &MockUnitOfWork{mockRepo, mockComplaintRepo}, mockRoleUseCase)
+
+		mockRepo.On("GetAssigneeID", "C-123").Return(0, nil)
+		mockComplaintRepo.On("GetByID", "C-123").Return(complaint, nil)
+		mockRoleUseCase.On("HasPermission", 3, "admin", constants.PermissionComplaintProcess).Return(constants.ErrForbidden)
+
+		_, err := usecase.Assign("C-123", 3, 1, "super_admin", "")
+
+		assert.Equal(t, constants.ErrAdminCannotProcessComplaints, err)
+		mockRepo.AssertNotCalled(t, "Assign", mock.Anything)
+	})
+
+	t.Run("failed complaint outside of the admin's scope", func(t *testing.T) {
+		mockRepo := new(MockComplaintAssignmentRepo)
+		mockComplaintRepo := new(MockComplaintRepo)
+		mockRoleUseCase := new(MockRoleUseCase)
+		usecase := NewComplaintAssignmentUseCase(mockRepo, &MockUnitOfWork{mockRepo, mockComplaintRepo}, mockRoleUseCase)
+
+		mockRepo.On("GetAssigneeID", "C-123").Return(0, nil)
+		mockComplaintRepo.On("GetByID", "C-123").Return(complaint, nil)
+		mockRoleUseCase.On("HasPermission", 3, "admin", constants.PermissionComplaintProcess).Return(nil)
+		mockRoleUseCase.On("EnsureInScope", 3, "admin", complaint).Return(constants.ErrComplaintOutOfScope)
+
+		_, err := usecase.Assign("C-123", 3, 1, "super_admin", "")
+
+		assert.Equal(t, constants.ErrOutsideAdminScope, err)
+		mockRepo.AssertNotCalled(t, "Assign", mock.Anything)
+	})
+
+	t.Run("internal server error", func(t *testing.T) {
+		mockRepo := new(MockComplaintAssignmentRepo)
+		mockComplaintRepo := new(MockComplaintRepo)
+		mockRoleUseCase := new(MockRoleUseCase)
+		usecase := NewComplaintAssignmentUseCase(mockRepo, &MockUnitOfWork{mockRepo, mockComplaintRepo}, mockRoleUseCase)
+
+		mockRepo.On("GetAssigneeID", "C-123").Return(0, nil)
+		mockComplaintRepo.On("GetByID", "C-123").Return(complaint, nil)
+		mockRoleUseCase.On("HasPermission", 3, "admin", constants.PermissionComplaintProcess).Return(nil)
+		mockRoleUseCase.On("EnsureInScope", 3, "admin", complaint).Return(nil)
+		mockRepo.On("Assign", mock.Anything).Return(errors.New("database error"))
+
+		_, err := usecase.Assign("C-123", 3, 1, "super_admin", "")
+
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+}
+
+func TestAutoAssign(t *testing.T) {
+	complaint := entities.Complaint{ID: "C-123", CategoryID: 1, RegencyID: "3601"}
+
+	t.Run("success", func(t *testing.T) {
+		mockRepo := new(MockComplaintAssignmentRepo)
+		usecase := NewComplaintAssignmentUseCase(mockRepo, &MockUnitOfWork{complaintAssignmentRepo: mockRepo}, nil)
+
+		mockRepo.On("GetNextPoolMember", 1, "3601").Return(entities.AssignmentPool{ID: 5, AdminID: 3}, nil)
+		mockRepo.On("Assign", mock.Anything).Return(nil)
+		mockRepo.On("UpdatePoolLastAssignedAt", 5, mock.Anything).Return(nil)
+
+		result, err := usecase.AutoAssign(complaint)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "C-123", result.ComplaintID)
+		assert.Equal(t, 3, result.AdminID)
+		assert.Nil(t, result.AssignedByID)
+	})
+
+	t.Run("success no pool member", func(t *testing.T) {
+		mockRepo := new(MockComplaintAssignmentRepo)
+		usecase := NewComplaintAssignmentUseCase(mockRepo, &MockUnitOfWork{complaintAssignmentRepo: mockRepo}, nil)
+
+		mockRepo.On("GetNextPoolMember", 1, "3601").Return(entities.AssignmentPool{}, constants.ErrAssignmentPoolNotFound)
+
+		result, err := usecase.AutoAssign(complaint)
+
+		assert.NoError(t, err)
+		assert.Equal(t, entities.ComplaintAssignment{}, result)
+	})
+
+	t.Run("failed assign", func(t *testing.T) {
+		mockRepo := new(MockComplaintAssignmentRepo)
+		usecase := NewComplaintAssignmentUseCase(mockRepo, &MockUnitOfWork{complaintAssignmentRepo: mockRepo}, nil)
+
+		mockRepo.On("GetNextPoolMember", 1, "3601").Return(entities.AssignmentPool{ID: 5, AdminID: 3}, nil)
+		mockRepo.On("Assign", mock.Anything).Return(errors.New("database error"))
+
+		_, err := usecase.AutoAssign(complaint)
+
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+
+	t.Run("failed update pool", func(t *testing.T) {
+		mockRepo := new(MockComplaintAssignmentRepo)
+		usecase := NewComplaintAssignmentUseCase(mockRepo, &MockUnitOfWork{complaintAssignmentRepo: mockRepo}, nil)
+
+		mockRepo.On("GetNextPoolMember", 1, "3601").Return(entities.AssignmentPool{ID: 5, AdminID: 3}, nil)
+		mockRepo.On("Assign", mock.Anything).Return(nil)
+		mockRepo.On("UpdatePoolLastAssignedAt", 5, mock.Anything).Return(errors.New("database error"))
+
+		_, err := usecase.AutoAssign(complaint)
+
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+}
+
+func TestEnsureCanProcess(t *testing.T) {
+	t.Run("success super admin", func(t *testing.T) {
+		mockRepo := new(MockComplaintAssignmentRepo)
+		usecase := NewComplaintAssignmentUseCase(mockRepo, nil, nil)
+
+		err := usecase.EnsureCanProcess("C-123", 1, "super_admin")
+
+		assert.NoError(t, err)
+		mockRepo.AssertNotCalled(t, "GetAssigneeID", mock.Anything)
+	})
+
+	t.Run("success assignee", func(t *testing.T) {
+		mockRepo := new(MockComplaintAssignmentRepo)
+		usecase := NewComplaintAssignmentUseCase(mockRepo, nil, nil)
+
+		mockRepo.On("GetAssigneeID", "C-123").Return(2, nil)
+
+		err := usecase.EnsureCanProcess("C-123", 2, "admin")
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("failed unassigned", func(t *testing.T) {
+		mockRepo := new(MockComplaintAssignmentRepo)
+		usecase := NewComplaintAssignmentUseCase(mockRepo, nil, nil)
+
+		mockRepo.On("GetAssigneeID", "C-123").Return(0, nil)
+
+		err := usecase.EnsureCanProcess("C-123", 2, "admin")
+
+		assert.Equal(t, constants.ErrComplaintNotAssignedToYou, err)
+	})
+
+	t.Run("failed not assigned to admin", func(t *testing.T) {
+		mockRepo := new(MockComplaintAssignmentRepo)
+		usecase := NewComplaintAssignmentUseCase(mockRepo, nil, nil)
+
+		mockRepo.On("GetAssigneeID", "C-123").Return(3, nil)
+
+		err := usecase.EnsureCanProcess("C-123", 2, "admin")
+
+		assert.Equal(t, constants.ErrComplaintNotAssignedToYou, err)
+	})
+
+	t.Run("failed complaint not found", func(t *testing.T) {
+		mockRepo := new(MockComplaintAssignmentRepo)
+		usecase := NewComplaintAssignmentUseCase(mockRepo, nil, nil)
+
+		mockRepo.On("GetAssigneeID", "C-123").Return(0, constants.ErrComplaintNotFound)
+
+		err := usecase.EnsureCanProcess("C-123", 2, "admin")
+
+		assert.Equal(t, constants.ErrComplaintNotFound, err)
+	})
+
+	t.Run("internal server error", func(t *testing.T) {
+		mockRepo := new(MockComplaintAssignmentRepo)
+		usecase := NewComplaintAssignmentUseCase(mockRepo, nil, nil)
+
+		mockRepo.On("GetAssigneeID", "C-123").Return(0, errors.New("database error"))
+
+		err := usecase.EnsureCanProcess("C-123", 2, "admin")
+
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+}
+
+func TestGetHistory(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockRepo := new(MockComplaintAssignmentRepo)
+		usecase := NewComplaintAssignmentUseCase(mockRepo, nil, nil)
+
+		complaintAssignments := []entities.ComplaintAssignment{{ID: 1, ComplaintID: "C-123", AdminID: 2}}
+		mockRepo.On("GetHistory", "C-123").Return(complaintAssignments, nil)
+
+		result, err := usecase.GetHistory("C-123")
+
+		assert.NoError(t, err)
+		assert.Equal(t, complaintAssignments, result)
+	})
+
+	t.Run("internal server error", func(t *testing.T) {
+		mockRepo := new(MockComplaintAssignmentRepo)
+		usecase := NewComplaintAssignmentUseCase(mockRepo, nil, nil)
+
+		mockRepo.On("GetHistory", "C-123").Return([]entities.ComplaintAssignment{}, errors.New("database error"))
+
+		result, err := usecase.GetHistory("C-123")
+
+		assert.Nil(t, result)
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+}
+
+func TestGetQueue(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockRepo := new(MockComplaintAssignmentRepo)
+		usecase := NewComplaintAssignmentUseCase(mockRepo, nil, nil)
+
+		complaints := []entities.Complaint{{ID: "C-123", Status: workflow.StatusVerifikasi}}
+		mockRepo.On("GetQueue", 2, workflow.Complaint.FinalStatuses()).Return(complaints, nil)
+
+		result, err := usecase.GetQueue(2)
+
+		assert.NoError(t, err)
+		assert.Equal(t, complaints, result)
+	})
+
+	t.Run("internal server error", func(t *testing.T) {
+		mockRepo := new(MockComplaintAssignmentRepo)
+		usecase := NewComplaintAssignmentUseCase(mockRepo, nil, nil)
+
+		mockRepo.On("GetQueue", 2, mock.Anything).Return([]entities.Complaint{}, errors.New("database error"))
+
+		result, err := usecase.GetQueue(2)
+
+		assert.Nil(t, result)
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+}
+
+func TestGetPools(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockRepo := new(MockComplaintAssignmentRepo)
+		usecase := NewComplaintAssignmentUseCase(mockRepo, nil, nil)
+
+		assignmentPools := []entities.AssignmentPool{{ID: 1, AdminID: 2}}
+		mockRepo.On("GetPools").Return(assignmentPools, nil)
+
+		result, err := usecase.GetPools()
+
+		assert.NoError(t, err)
+		assert.Equal(t, assignmentPools, result)
+	})
+
+	t.Run("internal server error", func(t *testing.T) {
+		mockRepo := new(MockComplaintAssignmentRepo)
+		usecase := NewComplaintAssignmentUseCase(mockRepo, nil, nil)
+
+		mockRepo.On("GetPools").Return([]entities.AssignmentPool{}, errors.New("database error"))
+
+		result, err := usecase.GetPools()
+
+		assert.Nil(t, result)
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+}
+
+func TestCreatePool(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockRepo := new(MockComplaintAssignmentRepo)
+		mockRoleUseCase := new(MockRoleUseCase)
+		usecase := NewComplaintAssignmentUseCase(mockRepo, nil, mockRoleUseCase)
+
+		assignmentPool := &entities.AssignmentPool{AdminID: 2}
+		mockRoleUseCase.On("HasPermission", 2, "admin", constants.PermissionComplaintProcess).Return(nil)
+		mockRoleUseCase.On("EnsureInScope", 2, "admin", entities.Complaint{}).Return(nil)
+		mockRepo.On("CreatePool", assignmentPool).Return(nil)
+
+		result, err := usecase.CreatePool(assignmentPool)
+
+		assert.NoError(t, err)
+		assert.Equal(t, *assignmentPool, result)
+	})
+
+	t.Run("failed fields are empty", func(t *testing.T) {
+		mockRepo := new(MockComplaintAssignmentRepo)
+		mockRoleUseCase := new(MockRoleUseCase)
+		usecase := NewComplaintAssignmentUseCase(mockRepo, nil, mockRoleUseCase)
+
+		_, err := usecase.CreatePool(&entities.AssignmentPool{})
+
+		assert.Equal(t, constants.ErrAllFieldsMustBeFilled, err)
+	})
+
+	t.Run("failed admin cannot process complaints", func(t *testing.T) {
+		mockRepo := new(MockComplaintAssignmentRepo)
+		mockRoleUseCase := new(MockRoleUseCase)
+		usecase := NewComplaintAssignmentUseCase(mockRepo, nil, mockRoleUseCase)
+
+		mockRoleUseCase.On("HasPermission", 2, "admin", constants.PermissionComplaintProcess).Return(constants.ErrForbidden)
+
+		_, err := usecase.CreatePool(&entities.AssignmentPool{AdminID: 2})
+
+		assert.Equal(t, constants.ErrAdminCannotProcessComplaints, err)
+		mockRepo.AssertNotCalled(t, "CreatePool", mock.Anything)
+	})
+
+	t.Run("failed pool outside of the admin's scope", func(t *testing.T) {
+		mockRepo := new(MockComplaintAssignmentRepo)
+		mockRoleUseCase := new(MockRoleUseCase)
+		usecase := NewComplaintAssignmentUseCase(mockRepo, nil, mockRoleUseCase)
+
+		categoryID := 1
+		regencyID := "3601"
+		mockRoleUseCase.On("HasPermission", 2, "admin", constants.PermissionComplaintProcess).Return(nil)
+		mockRoleUseCase.On("EnsureInScope", 2, "admin", entities.Complaint{CategoryID: 1, RegencyID: "3601"}).Return(constants.ErrComplaintOutOfScope)
+
+		_, err := usecase.CreatePool(&entities.AssignmentPool{AdminID: 2, CategoryID: &categoryID, RegencyID: &regencyID})
+
+		assert.Equal(t, constants.ErrOutsideAdminScope, err)
+		mockRepo.AssertNotCalled(t, "CreatePool", mock.Anything)
+	})
+
+	t.Run("failed admin not found", func(t *testing.T) {
+		mockRepo := new(MockComplaintAssignmentRepo)
+		mockRoleUseCase := new(MockRoleUseCase)
+		usecase := NewComplaintAssignmentUseCase(mockRepo, nil, mockRoleUseCase)
+
+		mockRoleUseCase.On("HasPermission", mock.Anything, "admin", constants.PermissionComplaintProcess).Return(nil)
+		mockRoleUseCase.On("EnsureInScope", mock.Anything, "admin", entities.Complaint{}).Return(nil)
+		mockRepo.On("CreatePool", mock.Anything).Return(errors.New("FOREIGN KEY (`admin_id`) REFERENCES `admins` (`id`))"))
+
+		_, err := usecase.CreatePool(&entities.AssignmentPool{AdminID: 99})
+
+		assert.Equal(t, constants.ErrAdminNotFound, err)
+	})
+
+	t.Run("failed category not found", func(t *testing.T) {
+		mockRepo := new(MockComplaintAssignmentRepo)
+		mockRoleUseCase := new(MockRoleUseCase)
+		usecase := NewComplaintAssignmentUseCase(mockRepo, nil, mockRoleUseCase)
+
+		mockRoleUseCase.On("HasPermission", mock.Anything, "admin", constants.PermissionComplaintProcess).Return(nil)
+		mockRoleUseCase.On("EnsureInScope", mock.Anything, "admin", entities.Complaint{}).Return(nil)
+		mockRepo.On("CreatePool", mock.Anything).Return(errors.New("FOREIGN KEY (`category_id`) REFERENCES `categories` (`id`))"))
+
+		_, err := usecase.CreatePool(&entities.AssignmentPool{AdminID: 2})
+
+		assert.Equal(t, constants.ErrCategoryNotFound, err)
+	})
+
+	t.Run("failed regency not found", func(t *testing.T) {
+		mockRepo := new(MockComplaintAssignmentRepo)
+		mockRoleUseCase := new(MockRoleUseCase)
+		usecase := NewComplaintAssignmentUseCase(mockRepo, nil, mockRoleUseCase)
+
+		mockRoleUseCase.On("HasPermission", mock.Anything, "admin", constants.PermissionComplaintProcess).Return(nil)
+		mockRoleUseCase.On("EnsureInScope", mock.Anything, "admin", entities.Complaint{}).Return(nil)
+		mockRepo.On("CreatePool", mock.Anything).Return(errors.New("FOREIGN KEY (`regency_id`) REFERENCES `regencies` (`id`))"))
+
+		_, err := usecase.CreatePool(&entities.AssignmentPool{AdminID: 2})
+
+		assert.Equal(t, constants.ErrRegencyNotFound, err)
+	})
+
+	t.Run("internal server error", func(t *testing.T) {
+		mockRepo := new(MockComplaintAssignmentRepo)
+		mockRoleUseCase := new(MockRoleUseCase)
+		usecase := NewComplaintAssignmentUseCase(mockRepo, nil, mockRoleUseCase)
+
+		mockRoleUseCase.On("HasPermission", mock.Anything, "admin", constants.PermissionComplaintProcess).Return(nil)
+		mockRoleUseCase.On("EnsureInScope", mock.Anything, "admin", entities.Complaint{}).Return(nil)
+		mockRepo.On("CreatePool", mock.Anything).Return(errors.New("database error"))
+
+		_, err := usecase.CreatePool(&entities.AssignmentPool{AdminID: 2})
+
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+}
+
+func TestDeletePool(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockRepo := new(MockComplaintAssignmentRepo)
+		usecase := NewComplaintAssignmentUseCase(mockRepo, nil, nil)
+
+		mockRepo.On("DeletePool", 1).Return(nil)
+
+		err := usecase.DeletePool(1)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("failed pool not found", func(t *testing.T) {
+		mockRepo := new(MockComplaintAssignmentRepo)
+		usecase := NewComplaintAssignmentUseCase(mockRepo, nil, nil)
+
+		mockRepo.On("DeletePool", 1).Return(constants.ErrAssignmentPoolNotFound)
+
+		err := usecase.DeletePool(1)
+
+		assert.Equal(t, constants.ErrAssignmentPoolNotFound, err)
+	})
+
+	t.Run("internal server error", func(t *testing.T) {
+		mockRepo := new(MockComplaintAssignmentRepo)
+		usecase := NewComplaintAssignmentUseCase(mockRepo, nil, nil)
+
+		mockRepo.On("DeletePool", 1).Return(errors.New("database error"))
+
+		err := usecase.DeletePool(1)
+
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+}
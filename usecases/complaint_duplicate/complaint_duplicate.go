@@ -0,0 +1,195 @@
+package complaint_duplicate
+
+import (
+	"e-complaint-api/constants"
+	"e-complaint-api/entities"
+	"e-complaint-api/geo"
+	"e-complaint-api/similarity"
+	"e-complaint-api/workflow"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+const (
+	// Only complaints filed within this many days are compared
+	candidateDays = 90
+	// Complaints further apart than this many kilometers are never duplicates
+	maxDistance = 1.0
+	// Complaints scoring below this are not suggested
+	minScore = 0.45
+	// At most this many similar complaints are suggested
+	maxSimilar = 5
+)
+
+type ComplaintDuplicateUseCase struct {
+	repository    entities.ComplaintDuplicateRepositoryInterface
+	complaintRepo entities.ComplaintRepositoryInterface
+	unitOfWork    entities.UnitOfWorkInterface
+}
+
+func NewComplaintDuplicateUseCase(repository entities.ComplaintDuplicateRepositoryInterface, complaintRepo entities.ComplaintRepositoryInterface, unitOfWork entities.UnitOfWorkInterface) *ComplaintDuplicateUseCase {
+	return &ComplaintDuplicateUseCase{
+		repository:    repository,
+		complaintRepo: complaintRepo,
+		unitOfWork:    unitOfWork,
+	}
+}
+
+// GetSimilar returns the open complaints in the same category and regency that likely
+// report the same problem, the most similar first. Users only get to see public
+// complaints and their own private ones.
+func (u *ComplaintDuplicateUseCase) GetSimilar(complaint entities.Complaint, viewerID int, role string) ([]entities.SimilarComplaint, error) {
+	candidates, err := u.repository.GetCandidates(complaint, workflow.Complaint.FinalStatuses(), time.Now().AddDate(0, 0, -candidateDays))
+	if err != nil {
+		return nil, constants.ErrInternalServerError
+	}
+
+	similarComplaints := []entities.SimilarComplaint{}
+	for _, candidate := range candidates {
+		if role == "user" && candidate.Type == "private" && candidate.UserID != viewerID {
+			continue
+		}
+
+		similarComplaint, ok := compare(complaint, candidate)
+		if ok {
+			similarComplaints = append(similarComplaints, similarComplaint)
+		}
+	}
+
+	sort.SliceStable(similarComplaints, func(i, j int) bool {
+		return similarComplaints[i].Score > similarComplaints[j].Score
+	})
+
+	if len(similarComplaints) > maxSimilar {
+		similarComplaints = similarComplaints[:maxSimilar]
+	}
+
+	return similarComplaints, nil
+}
+
+func (u *ComplaintDuplicateUseCase) GetSimilarByID(complaintID string) ([]entities.SimilarComplaint, error) {
+	complaint, err := u.complaintRepo.GetByID(complaintID)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.GetSimilar(complaint, 0, "super_admin")
+}
+
+// compare scores how likely candidate reports the same problem as complaint. The
+// description weighs more than the address, nearby locations raise the score.
+func compare(complaint entities.Complaint, candidate entities.Complaint) (entities.SimilarComplaint, bool) {
+	score := 0.7*similarity.Text(complaint.Description, candidate.Description) + 0.3*similarity.Text(complaint.Address, candidate.Address)
+
+	var distance *float64
+	if complaint.Latitude != nil && complaint.Longitude != nil && candidate.Latitude != nil && candidate.Longitude != nil {
+		d := geo.Distance(geo.Point{Lat: *complaint.Latitude, Lng: *complaint.Longitude}, geo.Point{Lat: *candidate.Latitude, Lng: *candidate.Longitude})
+		if d > maxDistance {
+			return entities.SimilarComplaint{}, false
+		}
+
+		distance = &d
+		score = min(1, score+0.3*(1-d/maxDistance))
+	}
+
+	if score < minScore {
+		return entities.SimilarComplaint{}, false
+	}
+
+	return entities.SimilarComplaint{Complaint: candidate, Score: score, Distance: distance}, true
+}
+
+// Merge closes the duplicates and moves their likes, discussions and files to the
+// master complaint, so private duplicates can only be merged into a private master. It
+// returns the updated master and the merged duplicates.
+func (u *ComplaintDuplicateUseCase) Merge(masterID string, duplicateIDs []string, adminID int) (entities.Complaint, []entities.Complaint, error) {
+	if masterID == "" || len(duplicateIDs) == 0 {
+		return entities.Complaint{}, nil, constants.ErrAllFieldsMustBeFilled
+	}
+
+	var duplicates []entities.Complaint
+	err := u.unitOfWork.Do(func(repositories entities.UnitOfWorkRepositories) error {
+		master, err := repositories.Complaint.GetByID(masterID)
+		if err != nil {
+			return err
+		}
+
+		if master.MergedIntoID != nil {
+			return constants.ErrComplaintAlreadyMerged
+		}
+
+		// a finished or rejected master would close its duplicates unhandled
+		if err := workflow.Complaint.EnsureNotFinal(master.Status); err != nil {
+			return err
+		}
+
+		merged := map[string]bool{}
+		var ids []string
+		for _, id := range duplicateIDs {
+			if id == masterID {
+				return constants.ErrCannotMergeComplaintIntoItself
+			}
+			if merged[id] {
+				continue
+			}
+			merged[id] = true
+
+			duplicate, err := repositories.Complaint.GetByID(id)
+			if err != nil {
+				return err
+			}
+
+			if err := workflow.Complaint.Validate(duplicate.Status, workflow.StatusDigabung); err != nil {
+				return err
+			}
+
+			// The discussions and files of a private complaint must not become visible
+			// to everyone
+			if duplicate.Type == "private" && master.Type == "public" {
+				return constants.ErrCannotMergePrivateIntoPublic
+			}
+
+			complaintProcess := entities.ComplaintProcess{
+				ComplaintID: duplicate.ID,
+				AdminID:     adminID,
+				Status:      workflow.StatusDigabung,
+				Message:     fmt.Sprintf("Aduan anda digabungkan dengan aduan %s yang melaporkan masalah yang sama", masterID),
+			}
+			if err := repositories.ComplaintProcess.Create(&complaintProcess); err != nil {
+				return err
+			}
+
+			if err := repositories.Complaint.UpdateStatus(duplicate.ID, workflow.StatusDigabung); err != nil {
+				return err
+			}
+
+			// Merged complaints need no further handling
+			if err := repositories.ComplaintSLA.UpdateDueDate(duplicate.ID, nil); err != nil {
+				return err
+			}
+
+			duplicate.Status = workflow.StatusDigabung
+			duplicate.MergedIntoID = &masterID
+			duplicates = append(duplicates, duplicate)
+			ids = append(ids, id)
+		}
+
+		return repositories.Duplicate.Merge(masterID, ids)
+	})
+	if err != nil {
+		if errors.Is(err, constants.ErrComplaintNotFound) || errors.Is(err, constants.ErrComplaintAlreadyMerged) || errors.Is(err, constants.ErrCannotMergeComplaintIntoItself) ||
+			errors.Is(err, constants.ErrCannotMergePrivateIntoPublic) || errors.Is(err, constants.ErrComplaintAlreadyFinished) || errors.Is(err, constants.ErrComplaintAlreadyRejected) {
+			return entities.Complaint{}, nil, err
+		}
+		return entities.Complaint{}, nil, constants.ErrInternalServerError
+	}
+
+	master, err := u.complaintRepo.GetByID(masterID)
+	if err != nil {
+		return entities.Complaint{}, nil, constants.ErrInternalServerError
+	}
+
+	return master, duplicates, nil
+}
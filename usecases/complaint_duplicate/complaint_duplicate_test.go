@@ -0,0 +1,501 @@
+package complaint_duplicate
+
+import (
+	"e-complaint-api/constants"
+	"e-complaint-api/entities"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockComplaintDuplicateRepo struct {
+	mock.Mock
+}
+
+func (m *MockComplaintDuplicateRepo) GetCandidates(complaint entities.Complaint, excludedStatuses []string, since time.Time) ([]entities.Complaint, error) {
+	args := m.Called(complaint, excludedStatuses, since)
+	return args.Get(0).([]entities.Complaint), args.Error(1)
+}
+
+func (m *MockComplaintDuplicateRepo) Merge(masterID string, duplicateIDs []string) error {
+	args := m.Called(masterID, duplicateIDs)
+	return args.Error(0)
+}
+
+type MockComplaintRepo struct {
+	mock.Mock
+}
+
+func (m *MockComplaintRepo) GetPaginated(limit int, page int, search string, filter map[string]interface{}, sortBy string, sortType string) ([]entities.Complaint, error) {
+	args := m.Called(limit, page, search, filter, sortBy, sortType)
+	return args.Get(0).([]entities.Complaint), args.Error(1)
+}
+
+func (m *MockComplaintRepo) GetMetaData(limit int, page int, search string, filter map[string]interface{}) (entities.Metadata, error) {
+	args := m.Called(limit, page, search, filter)
+	return args.Get(0).(entities.Metadata), args.Error(1)
+}
+
+func (m *MockComplaintRepo) GetAfter(limit int, afterID string, afterValue string, search string, filter map[string]interface{}, sortBy string, sortType string) ([]entities.Complaint, error) {
+	args := m.Called(limit, afterID, afterValue, search, filter, sortBy, sortType)
+	return args.Get(0).([]entities.Complaint), args.Error(1)
+}
+
+func (m *MockComplaintRepo) GetAfterWithProcesses(limit int, afterID string, afterValue string, search string, filter map[string]interface{}, sortBy string, sortType string) ([]entities.Complaint, error) {
+	args := m.Called(limit, afterID, afterValue, search, filter, sortBy, sortType)
+	return args.Get(0).([]entities.Complaint), args.Error(1)
+}
+
+func (m *MockComplaintRepo) GetWithLocation(limit int, search string, filter map[string]interface{}) ([]entities.Complaint, error) {
+	args := m.Called(limit, search, filter)
+	return args.Get(0).([]entities.Complaint), args.Error(1)
+}
+
+func (m *MockComplaintRepo) GetByID(id string) (entities.Complaint, error) {
+	args := m.Called(id)
+	return args.Get(0).(entities.Complaint), args.Error(1)
+}
+
+func (m *MockComplaintRepo) GetByUserID(userId int) ([]entities.Complaint, error) {
+	args := m.Called(userId)
+	return args.Get(0).([]entities.Complaint), args.Error(1)
+}
+
+func (m *MockComplaintRepo) Create(complaint *entities.Complaint) error {
+	args := m.Called(complaint)
+	return args.Error(0)
+}
+
+func (m *MockComplaintRepo) Delete(id string, userId int) error {
+	args := m.Called(id, userId)
+	return args.Error(0)
+}
+
+func (m *MockComplaintRepo) AdminDelete(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockComplaintRepo) Update(complaint entities.Complaint) (entities.Complaint, error) {
+	args := m.Called(complaint)
+	return args.Get(0).(entities.Complaint), args.Error(1)
+}
+
+func (m *MockComplaintRepo) UpdateStatus(id string, status string) error {
+	args := m.Called(id, status)
+	return args.Error(0)
+}
+
+func (m *MockComplaintRepo) GetStatus(id string) (string, error) {
+	args := m.Called(id)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockComplaintRepo) IncreaseTotalLikes(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockComplaintRepo) DecreaseTotalLikes(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockComplaintRepo) GetComplaintIDsByUserID(userID int) ([]string, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+type MockComplaintProcessRepo struct {
+	mock.Mock
+}
+
+func (m *MockComplaintProcessRepo) Create(complaintProcess *entities.ComplaintProcess) error {
+	args := m.Called(complaintProcess)
+	return args.Error(0)
+}
+
+func (m *MockComplaintProcessRepo) GetByComplaintID(complaintID string) ([]entities.ComplaintProcess, error) {
+	args := m.Called(complaintID)
+	return args.Get(0).([]entities.ComplaintProcess), args.Error(1)
+}
+
+func (m *MockComplaintProcessRepo) GetLatestByComplaintID(complaintID string) (entities.ComplaintProcess, error) {
+	args := m.Called(complaintID)
+	return args.Get(0).(entities.ComplaintProcess), args.Error(1)
+}
+
+func (m *MockComplaintProcessRepo) Update(complaintProcess *entities.ComplaintProcess) error {
+	args := m.Called(complaintProcess)
+	return args.Error(0)
+}
+
+func (m *MockComplaintProcessRepo) Delete(complaintID string, complaintProcessID int) error {
+	args := m.Called(complaintID, complaintProcessID)
+	return args.Error(0)
+}
+
+type MockComplaintSLARepo struct {
+	mock.Mock
+}
+
+func (m *MockComplaintSLARepo) GetAll() ([]entities.ComplaintSLA, error) {
+	args := m.Called()
+	return args.Get(0).([]entities.ComplaintSLA), args.Error(1)
+}
+
+func (m *MockComplaintSLARepo) Create(complaintSLA *entities.ComplaintSLA) error {
+	args := m.Called(complaintSLA)
+	return args.Error(0)
+}
+
+func (m *MockComplaintSLARepo) Update(complaintSLA *entities.ComplaintSLA) error {
+	args := m.Called(complaintSLA)
+	return args.Error(0)
+}
+
+func (m *MockComplaintSLARepo) Delete(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockComplaintSLARepo) GetTargetDays(complaintID string, status string) (int, error) {
+	args := m.Called(complaintID, status)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockComplaintSLARepo) UpdateDueDate(complaintID string, dueAt *time.Time) error {
+	args := m.Called(complaintID, dueAt)
+	return args.Error(0)
+}
+
+func (m *MockComplaintSLARepo) GetOverdue(now time.Time) ([]entities.Complaint, error) {
+	args := m.Called(now)
+	return args.Get(0).([]entities.Complaint), args.Error(1)
+}
+
+func (m *MockComplaintSLARepo) MarkEscalated(complaintID string, escalatedAt time.Time) error {
+	args := m.Called(complaintID, escalatedAt)
+	return args.Error(0)
+}
+
+type MockUnitOfWork struct {
+	duplicateRepo        *MockComplaintDuplicateRepo
+	complaintRepo        *MockComplaintRepo
+	complaintProcessRepo *MockComplaintProcessRepo
+	complaintSLARepo     *MockComplaintSLARepo
+}
+
+func (m *MockUnitOfWork) Do(fn func(repositories entities.UnitOfWorkRepositories) error) error {
+	return fn(entities.UnitOfWorkRepositories{
+		Complaint:        m.complaintRepo,
+		ComplaintProcess: m.complaintProcessRepo,
+		ComplaintSLA:     m.complaintSLARepo,
+		Duplicate:        m.duplicateRepo,
+	})
+}
+
+func newUseCase() (*ComplaintDuplicateUseCase, *MockUnitOfWork) {
+	unitOfWork := &MockUnitOfWork{
+		duplicateRepo:        new(MockComplaintDuplicateRepo),
+		complaintRepo:        new(MockComplaintRepo),
+		complaintProcessRepo: new(MockComplaintProcessRepo),
+		complaintSLARepo:     new(MockComplaintSLARepo),
+	}
+
+	return NewComplaintDuplicateUseCase(unitOfWork.duplicateRepo, unitOfWork.complaintRepo, unitOfWork), unitOfWork
+}
+
+func float(value float64) *float64 {
+	return &value
+}
+
+func TestGetSimilar(t *testing.T) {
+	complaint := entities.Complaint{
+		ID:          "C-1",
+		UserID:      1,
+		CategoryID:  1,
+		RegencyID:   "3673",
+		Description: "Jalan berlubang besar di depan pasar Rau",
+		Address:     "Jl. Ahmad Yani, Serang",
+	}
+	candidates := []entities.Complaint{
+		{ID: "C-2", UserID: 2, Type: "public", Description: "Ada lubang besar di jalan depan pasar rau", Address: "Jl. Ahmad Yani Serang"},
+		{ID: "C-3", UserID: 3, Type: "public", Description: "Sampah menumpuk di sungai", Address: "Kampung Kaloran"},
+		{ID: "C-4", UserID: 4, Type: "private", Description: "Jalan berlubang besar di depan pasar Rau", Address: "Jl. Ahmad Yani, Serang"},
+		{ID: "C-5", UserID: 1, Type: "private", Description: "Jalan berlubang di depan pasar Rau", Address: "Jl. Ahmad Yani"},
+	}
+
+	t.Run("success for user", func(t *testing.T) {
+		usecase, unitOfWork := newUseCase()
+		unitOfWork.duplicateRepo.On("GetCandidates", complaint, []string{"Selesai", "Ditolak", "Digabung"}, mock.Anything).Return(candidates, nil)
+
+		result, err := usecase.GetSimilar(complaint, 1, "user")
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 2)
+		assert.Equal(t, "C-2", result[0].Complaint.ID)
+		assert.Equal(t, "C-5", result[1].Complaint.ID)
+		assert.Greater(t, result[0].Score, result[1].Score)
+		assert.Nil(t, result[0].Distance)
+	})
+
+	t.Run("success for admin", func(t *testing.T) {
+		usecase, unitOfWork := newUseCase()
+		unitOfWork.duplicateRepo.On("GetCandidates", complaint, mock.Anything, mock.Anything).Return(candidates, nil)
+
+		result, err := usecase.GetSimilar(complaint, 9, "admin")
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 3)
+		assert.Equal(t, "C-4", result[0].Complaint.ID)
+		assert.Equal(t, 1.0, result[0].Score)
+	})
+
+	t.Run("success with location", func(t *testing.T) {
+		located := complaint
+		located.Latitude, located.Longitude = float(-6.1200), float(106.1500)
+
+		usecase, unitOfWork := newUseCase()
+		unitOfWork.duplicateRepo.On("GetCandidates", located, mock.Anything, mock.Anything).Return([]entities.Complaint{
+			// Close by, so a weaker text match is still suggested
+			{ID: "C-6", Description: "Jalan rusak parah", Address: "Pasar Rau", Latitude: float(-6.1201), Longitude: float(106.1502)},
+			// Same text but too far away
+			{ID: "C-7", Description: complaint.Description, Address: complaint.Address, Latitude: float(-6.2000), Longitude: float(106.1500)},
+		}, nil)
+
+		result, err := usecase.GetSimilar(located, 1, "admin")
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 1)
+		assert.Equal(t, "C-6", result[0].Complaint.ID)
+		assert.InDelta(t, 0.025, *result[0].Distance, 0.01)
+	})
+
+	t.Run("success limited to the most similar", func(t *testing.T) {
+		var many []entities.Complaint
+		for i := 0; i < 7; i++ {
+			many = append(many, entities.Complaint{ID: "C-" + string(rune('a'+i)), Description: complaint.Description, Address: complaint.Address})
+		}
+
+		usecase, unitOfWork := newUseCase()
+		unitOfWork.duplicateRepo.On("GetCandidates", complaint, mock.Anything, mock.Anything).Return(many, nil)
+
+		result, err := usecase.GetSimilar(complaint, 1, "admin")
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 5)
+		assert.Equal(t, "C-a", result[0].Complaint.ID)
+	})
+
+	t.Run("failed internal server error", func(t *testing.T) {
+		usecase, unitOfWork := newUseCase()
+		unitOfWork.duplicateRepo.On("GetCandidates", complaint, mock.Anything, mock.Anything).Return([]entities.Complaint(nil), errors.New("database error"))
+
+		_, err := usecase.GetSimilar(complaint, 1, "user")
+
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+}
+
+func TestGetSimilarByID(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		complaint := entities.Complaint{ID: "C-1", Description: "Lampu jalan mati", Address: "Alun-alun"}
+
+		usecase, unitOfWork := newUseCase()
+		unitOfWork.complaintRepo.On("GetByID", "C-1").Return(complaint, nil)
+		unitOfWork.duplicateRepo.On("GetCandidates", complaint, mock.Anything, mock.Anything).Return([]entities.Complaint{
+			{ID: "C-2", Type: "private", UserID: 2, Description: "Lampu jalan mati", Address: "Alun-alun"},
+		}, nil)
+
+		result, err := usecase.GetSimilarByID("C-1")
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 1)
+	})
+
+	t.Run("failed complaint not found", func(t *testing.T) {
+		usecase, unitOfWork := newUseCase()
+		unitOfWork.complaintRepo.On("GetByID", "C-1").Return(entities.Complaint{}, constants.ErrComplaintNotFound)
+
+		_, err := usecase.GetSimilarByID("C-1")
+
+		assert.Equal(t, constants.ErrComplaintNotFound, err)
+	})
+}
+
+func TestMerge(t *testing.T) {
+	master := entities.Complaint{ID: "C-1", Status: "Verifikasi"}
+
+	t.Run("success", func(t *testing.T) {
+		usecase, unitOfWork := newUseCase()
+		unitOfWork.complaintRepo.On("GetByID", "C-1").Return(master, nil).Once()
+		unitOfWork.complaintRepo.On("GetByID", "C-2").Return(entities.Complaint{ID: "C-2", UserID: 2, Status: "Pending"}, nil)
+		unitOfWork.complaintRepo.On("GetByID", "C-3").Return(entities.Complaint{ID: "C-3", UserID: 3, Status: "On Progress"}, nil)
+		unitOfWork.complaintProcessRepo.On("Create", mock.MatchedBy(func(complaintProcess *entities.ComplaintProcess) bool {
+			return complaintProcess.Status == "Digabung" && complaintProcess.AdminID == 9 && complaintProcess.Message == "Aduan anda digabungkan dengan aduan C-1 yang melaporkan masalah yang sama"
+		})).Return(nil).Twice()
+		unitOfWork.complaintRepo.On("UpdateStatus", mock.Anything, "Digabung").Return(nil).Twice()
+		unitOfWork.complaintSLARepo.On("UpdateDueDate", mock.Anything, (*time.Time)(nil)).Return(nil).Twice()
+		unitOfWork.duplicateRepo.On("Merge", "C-1", []string{"C-2", "C-3"}).Return(nil)
+		unitOfWork.complaintRepo.On("GetByID", "C-1").Return(entities.Complaint{ID: "C-1", Status: "Verifikasi", TotalLikes: 4}, nil).Once()
+
+		result, duplicates, err := usecase.Merge("C-1", []string{"C-2", "C-3", "C-2"}, 9)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 4, result.TotalLikes)
+		assert.Len(t, duplicates, 2)
+		assert.Equal(t, "Digabung", duplicates[0].Status)
+		assert.Equal(t, "C-1", *duplicates[1].MergedIntoID)
+		unitOfWork.complaintRepo.AssertExpectations(t)
+		unitOfWork.complaintProcessRepo.AssertExpectations(t)
+		unitOfWork.complaintSLARepo.AssertExpectations(t)
+		unitOfWork.duplicateRepo.AssertExpectations(t)
+	})
+
+	t.Run("failed all fields must be filled", func(t *testing.T) {
+		usecase, _ := newUseCase()
+
+		_, _, err := usecase.Merge("C-1", nil, 9)
+
+		assert.Equal(t, constants.ErrAllFieldsMustBeFilled, err)
+	})
+
+	t.Run("failed master not found", func(t *testing.T) {
+		usecase, unitOfWork := newUseCase()
+		unitOfWork.complaintRepo.On("GetByID", "C-1").Return(entities.Complaint{}, constants.ErrComplaintNotFound)
+
+		_, _, err := usecase.Merge("C-1", []string{"C-2"}, 9)
+
+		assert.Equal(t, constants.ErrComplaintNotFound, err)
+	})
+
+	t.Run("failed master already merged", func(t *testing.T) {
+		mergedInto := "C-0"
+
+		usecase, unitOfWork := newUseCase()
+		unitOfWork.complaintRepo.On("GetByID", "C-1").Return(entities.Complaint{ID: "C-1", Status: "Digabung", MergedIntoID: &mergedInto}, nil)
+
+		_, _, err := usecase.Merge("C-1", []string{"C-2"}, 9)
+
+		assert.Equal(t, constants.ErrComplaintAlreadyMerged, err)
+	})
+
+	t.Run("failed master already finished", func(t *testing.T) {
+		usecase, unitOfWork := newUseCase()
+		unitOfWork.complaintRepo.On("GetByID", "C-1").Return(entities.Complaint{ID: "C-1", Status: "Selesai"}, nil)
+
+		_, _, err := usecase.Merge("C-1", []string{"C-2"}, 9)
+
+		assert.Equal(t, constants.ErrComplaintAlreadyFinished, err)
+		unitOfWork.complaintRepo.AssertNotCalled(t, "GetByID", "C-2")
+	})
+
+	t.Run("failed master already rejected", func(t *testing.T) {
+		usecase, unitOfWork := newUseCase()
+		unitOfWork.complaintRepo.On("GetByID", "C-1").Return(entities.Complaint{ID: "C-1", Status: "Ditolak"}, nil)
+
+		_, _, err := usecase.Merge("C-1", []string{"C-2"}, 9)
+
+		assert.Equal(t, constants.ErrComplaintAlreadyRejected, err)
+	})
+
+	t.Run("failed merge into itself", func(t *testing.T) {
+		usecase, unitOfWork := newUseCase()
+		unitOfWork.complaintRepo.On("GetByID", "C-1").Return(master, nil)
+
+		_, _, err := usecase.Merge("C-1", []string{"C-1"}, 9)
+
+		assert.Equal(t, constants.ErrCannotMergeComplaintIntoItself, err)
+	})
+
+	t.Run("failed duplicate already finished", func(t *testing.T) {
+		usecase, unitOfWork := newUseCase()
+		unitOfWork.complaintRepo.On("GetByID", "C-1").Return(master, nil)
+		unitOfWork.complaintRepo.On("GetByID", "C-2").Return(entities.Complaint{ID: "C-2", Status: "Selesai"}, nil)
+
+		_, _, err := usecase.Merge("C-1", []string{"C-2"}, 9)
+
+		assert.Equal(t, constants.ErrComplaintAlreadyFinished, err)
+	})
+
+	t.Run("failed private duplicate into public master", func(t *testing.T) {
+		usecase, unitOfWork := newUseCase()
+		unitOfWork.complaintRepo.On("GetByID", "C-1").Return(entities.Complaint{ID: "C-1", Status: "Verifikasi", Type: "public"}, nil)
+		unitOfWork.complaintRepo.On("GetByID", "C-2").Return(entities.Complaint{ID: "C-2", Status: "Pending", Type: "private"}, nil)
+
+		_, _, err := usecase.Merge("C-1", []string{"C-2"}, 9)
+
+		assert.Equal(t, constants.ErrCannotMergePrivateIntoPublic, err)
+		unitOfWork.complaintProcessRepo.AssertNotCalled(t, "Create", mock.Anything)
+		unitOfWork.duplicateRepo.AssertNotCalled(t, "Merge", mock.Anything, mock.Anything)
+	})
+
+	t.Run("failed to create complaint process", func(t *testing.T) {
+		usecase, unitOfWork := newUseCase()
+		unitOfWork.complaintRepo.On("GetByID", "C-1").Return(master, nil)
+		unitOfWork.complaintRepo.On("GetByID", "C-2").Return(entities.Complaint{ID: "C-2", Status: "Pending"}, nil)
+		unitOfWork.complaintProcessRepo.On("Create", mock.Anything).Return(errors.New("database error"))
+
+		_, _, err := usecase.Merge("C-1", []string{"C-2"}, 9)
+
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+
+	t.Run("failed to update status", func(t *testing.T) {
+		usecase, unitOfWork := newUseCase()
+		unitOfWork.complaintRepo.On("GetByID", "C-1").Return(master, nil)
+		unitOfWork.complaintRepo.On("GetByID", "C-2").Return(entities.Complaint{ID: "C-2", Status: "Pending"}, nil)
+		unitOfWork.complaintProcessRepo.On("Create", mock.Anything).Return(nil)
+		unitOfWork.complaintRepo.On("UpdateStatus", "C-2", "Digabung").Return(errors.New("database error"))
+
+		_, _, err := usecase.Merge("C-1", []string{"C-2"}, 9)
+
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+
+	t.Run("failed to update due date", func(t *testing.T) {
+		usecase, unitOfWork := newUseCase()
+		unitOfWork.complaintRepo.On("GetByID", "C-1").Return(master, nil)
+		unitOfWork.complaintRepo.On("GetByID", "C-2").Return(entities.Complaint{ID: "C-2", Status: "Pending"}, nil)
+		unitOfWork.complaintProcessRepo.On("Create", mock.Anything).Return(nil)
+		unitOfWork.complaintRepo.On("UpdateStatus", "C-2", "Digabung").Return(nil)
+		unitOfWork.complaintSLARepo.On("UpdateDueDate", "C-2", (*time.Time)(nil)).Return(errors.New("database error"))
+
+		_, _, err := usecase.Merge("C-1", []string{"C-2"}, 9)
+
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+
+	t.Run("failed to move likes, discussions and files", func(t *testing.T) {
+		usecase, unitOfWork := newUseCase()
+		unitOfWork.complaintRepo.On("GetByID", "C-1").Return(master, nil)
+		unitOfWork.complaintRepo.On("GetByID", "C-2").Return(entities.Complaint{ID: "C-2", Status: "Pending"}, nil)
+		unitOfWork.complaintProcessRepo.On("Create", mock.Anything).Return(nil)
+		unitOfWork.complaintRepo.On("UpdateStatus", "C-2", "Digabung").Return(nil)
+		unitOfWork.complaintSLARepo.On("UpdateDueDate", "C-2", (*time.Time)(nil)).Return(nil)
+		unitOfWork.duplicateRepo.On("Merge", "C-1", []string{"C-2"}).Return(errors.New("database error"))
+
+		_, _, err := usecase.Merge("C-1", []string{"C-2"}, 9)
+
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+
+	t.Run("failed to get merged master", func(t *testing.T) {
+		usecase, unitOfWork := newUseCase()
+		unitOfWork.complaintRepo.On("GetByID", "C-1").Return(master, nil).Once()
+		unitOfWork.complaintRepo.On("GetByID", "C-2").Return(entities.Complaint{ID: "C-2", Status: "Pending"}, nil)
+		unitOfWork.complaintProcessRepo.On("Create", mock.Anything).Return(nil)
+		unitOfWork.complaintRepo.On("UpdateStatus", "C-2", "Digabung").Return(nil)
+		unitOfWork.complaintSLARepo.On("UpdateDueDate", "C-2", (*time.Time)(nil)).Return(nil)
+		unitOfWork.duplicateRepo.On("Merge", "C-1", []string{"C-2"}).Return(nil)
+		unitOfWork.complaintRepo.On("GetByID", "C-1").Return(entities.Complaint{}, errors.New("database error")).Once()
+
+		_, _, err := usecase.Merge("C-1", []string{"C-2"}, 9)
+
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+}
@@ -0,0 +1,143 @@
+package complaint_event
+
+import (
+	"e-complaint-api/constants"
+	"e-complaint-api/entities"
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+type ComplaintEventUseCase struct {
+	repository entities.ComplaintEventRepositoryInterface
+	hub        entities.PubSubInterface
+}
+
+func NewComplaintEventUseCase(repository entities.ComplaintEventRepositoryInterface, hub entities.PubSubInterface) *ComplaintEventUseCase {
+	return &ComplaintEventUseCase{
+		repository: repository,
+		hub:        hub,
+	}
+}
+
+func topic(complaintID string) string {
+	return fmt.Sprintf("complaint.%s", complaintID)
+}
+
+// Publish stores an event of a complaint with data as its JSON and pushes it to the
+// streams of the complaint.
+func (u *ComplaintEventUseCase) Publish(complaintID string, eventType string, data interface{}) error {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return constants.ErrInternalServerError
+	}
+
+	event := entities.ComplaintEvent{
+		ComplaintID: complaintID,
+		Type:        eventType,
+		Data:        string(encoded),
+	}
+	if err := u.repository.Create(&event); err != nil {
+		return constants.ErrInternalServerError
+	}
+
+	u.hub.Publish(topic(complaintID), entities.Event{Type: eventType, Data: event})
+
+	return nil
+}
+
+func (u *ComplaintEventUseCase) Subscribe(complaintID string, lastEventID int) (<-chan entities.ComplaintEvent, func(), error) {
+	// subscribing before loading the missed events leaves no gap between the two, the
+	// events in both are skipped the second time
+	published, unsubscribe := u.hub.Subscribe(topic(complaintID))
+
+	backlog, err := u.backlog(complaintID, lastEventID)
+	if err != nil {
+		unsubscribe()
+		return nil, nil, constants.ErrInternalServerError
+	}
+
+	lastID := lastEventID
+	if len(backlog) > 0 {
+		lastID = backlog[len(backlog)-1].ID
+	}
+
+	events := make(chan entities.ComplaintEvent)
+	done := make(chan struct{})
+	var once sync.Once
+	stop := func() {
+		once.Do(func() {
+			close(done)
+			unsubscribe()
+		})
+	}
+
+	go func() {
+		defer close(events)
+
+		send := func(event entities.ComplaintEvent) bool {
+			select {
+			case events <- event:
+				return true
+			case <-done:
+				return false
+			}
+		}
+
+		for _, event := range backlog {
+			if !send(event) {
+				return
+			}
+		}
+
+		for notification := range published {
+			event, ok := notification.Data.(entities.ComplaintEvent)
+			if !ok || event.ID <= lastID {
+				continue
+			}
+
+			// the hub drops events for a subscriber whose buffer is full, so once it has
+			// been full the stored events fill the gap. A stream that cannot be refilled
+			// is closed, the client resumes it with its last event ID.
+			missed := []entities.ComplaintEvent{event}
+			if lastID > 0 && len(published) >= cap(published)-1 {
+				var err error
+				missed, err = u.backlog(complaintID, lastID)
+				if err != nil {
+					return
+				}
+			}
+
+			for _, event := range missed {
+				if !send(event) {
+					return
+				}
+				lastID = event.ID
+			}
+		}
+	}()
+
+	return events, stop, nil
+}
+
+// backlog returns the events of a complaint after lastEventID, none when it is 0.
+func (u *ComplaintEventUseCase) backlog(complaintID string, lastEventID int) ([]entities.ComplaintEvent, error) {
+	backlog := []entities.ComplaintEvent{}
+	if lastEventID <= 0 {
+		return backlog, nil
+	}
+
+	afterID := lastEventID
+	for {
+		events, err := u.repository.GetAfter(complaintID, afterID, constants.ComplaintEventBacklogLimit)
+		if err != nil {
+			return nil, err
+		}
+
+		backlog = append(backlog, events...)
+		if len(events) < constants.ComplaintEventBacklogLimit {
+			return backlog, nil
+		}
+		afterID = events[len(events)-1].ID
+	}
+}
@@ -0,0 +1,241 @@
+package complaint_event
+
+import (
+	"e-complaint-api/constants"
+	"e-complaint-api/entities"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockComplaintEventRepo struct {
+	mock.Mock
+}
+
+func (m *MockComplaintEventRepo) Create(event *entities.ComplaintEvent) error {
+	args := m.Called(event)
+	return args.Error(0)
+}
+
+func (m *MockComplaintEventRepo) GetAfter(complaintID string, afterID int, limit int) ([]entities.ComplaintEvent, error) {
+	args := m.Called(complaintID, afterID, limit)
+	return args.Get(0).([]entities.ComplaintEvent), args.Error(1)
+}
+
+type MockPubSub struct {
+	mock.Mock
+}
+
+func (m *MockPubSub) Publish(topic string, event entities.Event) {
+	m.Called(topic, event)
+}
+
+func (m *MockPubSub) Subscribe(topic string) (<-chan entities.Event, func()) {
+	args := m.Called(topic)
+	return args.Get(0).(<-chan entities.Event), args.Get(1).(func())
+}
+
+var errDatabase = errors.New("database error")
+
+// subscription returns a mocked hub subscription whose unsubscribe closes the
+// channel, like the real hub does.
+func subscription(hub *MockPubSub, topic string) (chan entities.Event, *bool) {
+	published := make(chan entities.Event, 10)
+	unsubscribed := false
+	hub.On("Subscribe", topic).Return((<-chan entities.Event)(published), func() {
+		unsubscribed = true
+		close(published)
+	})
+
+	return published, &unsubscribed
+}
+
+func receive(t *testing.T, events <-chan entities.ComplaintEvent) entities.ComplaintEvent {
+	select {
+	case event, ok := <-events:
+		if !ok {
+			t.Fatal("events closed")
+		}
+		return event
+	case <-time.After(time.Second):
+		t.Fatal("no event received")
+		return entities.ComplaintEvent{}
+	}
+}
+
+// assertClosed drains events, an event being sent as the stream stops may still
+// arrive.
+func assertClosed(t *testing.T, events <-chan entities.ComplaintEvent) {
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case _, ok := <-events:
+			if !ok {
+				return
+			}
+		case <-timeout:
+			t.Fatal("events not closed")
+		}
+	}
+}
+
+func TestPublish(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		repo := new(MockComplaintEventRepo)
+		repo.On("Create", &entities.ComplaintEvent{ComplaintID: "C-123", Type: constants.ComplaintEventLikes, Data: `{"total_likes":3}`}).
+			Run(func(args mock.Arguments) { args.Get(0).(*entities.ComplaintEvent).ID = 7 }).Return(nil)
+		hub := new(MockPubSub)
+		hub.On("Publish", "complaint.C-123", entities.Event{
+			Type: constants.ComplaintEventLikes,
+			Data: entities.ComplaintEvent{ID: 7, ComplaintID: "C-123", Type: constants.ComplaintEventLikes, Data: `{"total_likes":3}`},
+		}).Return()
+
+		err := NewComplaintEventUseCase(repo, hub).Publish("C-123", constants.ComplaintEventLikes, map[string]int{"total_likes": 3})
+		assert.NoError(t, err)
+		hub.AssertExpectations(t)
+	})
+
+	t.Run("failed invalid data", func(t *testing.T) {
+		repo := new(MockComplaintEventRepo)
+
+		err := NewComplaintEventUseCase(repo, new(MockPubSub)).Publish("C-123", constants.ComplaintEventLikes, make(chan int))
+		assert.Equal(t, constants.ErrInternalServerError, err)
+		repo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("failed create", func(t *testing.T) {
+		repo := new(MockComplaintEventRepo)
+		repo.On("Create", mock.Anything).Return(errDatabase)
+		hub := new(MockPubSub)
+
+		err := NewComplaintEventUseCase(repo, hub).Publish("C-123", constants.ComplaintEventLikes, nil)
+		assert.Equal(t, constants.ErrInternalServerError, err)
+		hub.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+	})
+}
+
+func TestSubscribe(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		repo := new(MockComplaintEventRepo)
+		hub := new(MockPubSub)
+		published, unsubscribed := subscription(hub, "complaint.C-123")
+
+		events, stop, err := NewComplaintEventUseCase(repo, hub).Subscribe("C-123", 0)
+		assert.NoError(t, err)
+
+		published <- entities.Event{Type: constants.ComplaintEventProcess, Data: entities.ComplaintEvent{ID: 4, Type: constants.ComplaintEventProcess}}
+		assert.Equal(t, 4, receive(t, events).ID)
+
+		stop()
+		stop()
+		assertClosed(t, events)
+		assert.True(t, *unsubscribed)
+		repo.AssertNotCalled(t, "GetAfter", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("success resume", func(t *testing.T) {
+		firstPage := []entities.ComplaintEvent{}
+		for id := 11; id < 11+constants.ComplaintEventBacklogLimit; id++ {
+			firstPage = append(firstPage, entities.ComplaintEvent{ID: id})
+		}
+		lastID := firstPage[len(firstPage)-1].ID
+
+		repo := new(MockComplaintEventRepo)
+		repo.On("GetAfter", "C-123", 10, constants.ComplaintEventBacklogLimit).Return(firstPage, nil)
+		repo.On("GetAfter", "C-123", lastID, constants.ComplaintEventBacklogLimit).Return([]entities.ComplaintEvent{{ID: lastID + 1}}, nil)
+		hub := new(MockPubSub)
+		published, _ := subscription(hub, "complaint.C-123")
+
+		events, stop, err := NewComplaintEventUseCase(repo, hub).Subscribe("C-123", 10)
+		assert.NoError(t, err)
+		defer stop()
+
+		// published while the backlog was loaded
+		published <- entities.Event{Data: entities.ComplaintEvent{ID: lastID + 1}}
+		published <- entities.Event{Data: "not a complaint event"}
+		published <- entities.Event{Data: entities.ComplaintEvent{ID: lastID + 2}}
+
+		for id := 11; id <= lastID+2; id++ {
+			assert.Equal(t, id, receive(t, events).ID)
+		}
+	})
+
+	t.Run("success refill dropped events", func(t *testing.T) {
+		repo := new(MockComplaintEventRepo)
+		repo.On("GetAfter", "C-123", 3, constants.ComplaintEventBacklogLimit).Return([]entities.ComplaintEvent{}, nil).Once()
+		repo.On("GetAfter", "C-123", 3, constants.ComplaintEventBacklogLimit).Return([]entities.ComplaintEvent{{ID: 4}, {ID: 5}, {ID: 6}}, nil).Once()
+		hub := new(MockPubSub)
+		// 5 was dropped by the hub, the buffer was full
+		published := make(chan entities.Event, 2)
+		published <- entities.Event{Data: entities.ComplaintEvent{ID: 4}}
+		published <- entities.Event{Data: entities.ComplaintEvent{ID: 6}}
+		hub.On("Subscribe", "complaint.C-123").Return((<-chan entities.Event)(published), func() {})
+
+		events, stop, err := NewComplaintEventUseCase(repo, hub).Subscribe("C-123", 3)
+		assert.NoError(t, err)
+		defer stop()
+
+		for id := 4; id <= 6; id++ {
+			assert.Equal(t, id, receive(t, events).ID)
+		}
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("failed refill dropped events", func(t *testing.T) {
+		repo := new(MockComplaintEventRepo)
+		repo.On("GetAfter", "C-123", 3, constants.ComplaintEventBacklogLimit).Return([]entities.ComplaintEvent{}, nil).Once()
+		repo.On("GetAfter", "C-123", 3, constants.ComplaintEventBacklogLimit).Return([]entities.ComplaintEvent{}, errDatabase).Once()
+		hub := new(MockPubSub)
+		published := make(chan entities.Event, 1)
+		published <- entities.Event{Data: entities.ComplaintEvent{ID: 5}}
+		hub.On("Subscribe", "complaint.C-123").Return((<-chan entities.Event)(published), func() {})
+
+		events, stop, err := NewComplaintEventUseCase(repo, hub).Subscribe("C-123", 3)
+		assert.NoError(t, err)
+		defer stop()
+
+		assertClosed(t, events)
+	})
+
+	t.Run("success stop while sending", func(t *testing.T) {
+		repo := new(MockComplaintEventRepo)
+		repo.On("GetAfter", "C-123", 10, constants.ComplaintEventBacklogLimit).Return([]entities.ComplaintEvent{{ID: 11}}, nil)
+		hub := new(MockPubSub)
+		subscription(hub, "complaint.C-123")
+
+		events, stop, err := NewComplaintEventUseCase(repo, hub).Subscribe("C-123", 10)
+		assert.NoError(t, err)
+
+		stop()
+		assertClosed(t, events)
+	})
+
+	t.Run("success stop while forwarding", func(t *testing.T) {
+		repo := new(MockComplaintEventRepo)
+		hub := new(MockPubSub)
+		published := make(chan entities.Event, 1)
+		hub.On("Subscribe", "complaint.C-123").Return((<-chan entities.Event)(published), func() {})
+
+		events, stop, err := NewComplaintEventUseCase(repo, hub).Subscribe("C-123", 0)
+		assert.NoError(t, err)
+
+		published <- entities.Event{Data: entities.ComplaintEvent{ID: 4}}
+		time.Sleep(10 * time.Millisecond)
+		stop()
+		assertClosed(t, events)
+	})
+
+	t.Run("failed get backlog", func(t *testing.T) {
+		repo := new(MockComplaintEventRepo)
+		repo.On("GetAfter", "C-123", 10, constants.ComplaintEventBacklogLimit).Return([]entities.ComplaintEvent{}, errDatabase)
+		hub := new(MockPubSub)
+		_, unsubscribed := subscription(hub, "complaint.C-123")
+
+		_, _, err := NewComplaintEventUseCase(repo, hub).Subscribe("C-123", 10)
+		assert.Equal(t, constants.ErrInternalServerError, err)
+		assert.True(t, *unsubscribed)
+	})
+}
@@ -1,8 +1,13 @@
 package complaint_file
 
 import (
+	"bytes"
+	"e-complaint-api/constants"
 	"e-complaint-api/entities"
 	"errors"
+	"image"
+	"image/png"
+	"io"
 	"mime/multipart"
 	"testing"
 
@@ -24,11 +29,16 @@ func (m *MockComplaintFileRepository) DeleteByComplaintID(complaintID string) er
 	return args.Error(0)
 }
 
+func (m *MockComplaintFileRepository) FindByComplaintID(complaintID string) ([]entities.ComplaintFile, error) {
+	args := m.Called(complaintID)
+	return args.Get(0).([]entities.ComplaintFile), args.Error(1)
+}
+
 type MockComplaintFileGCSAPI struct {
 	mock.Mock
 }
 
-func (m *MockComplaintFileGCSAPI) Upload(files []*multipart.FileHeader) ([]string, error) {
+func (m *MockComplaintFileGCSAPI) Upload(files []entities.UploadFile) ([]string, error) {
 	args := m.Called(files)
 	return args.Get(0).([]string), args.Error(1)
 }
@@ -38,22 +48,63 @@ func (m *MockComplaintFileGCSAPI) Delete(paths []string) error {
 	return args.Error(0)
 }
 
+func (m *MockComplaintFileGCSAPI) Open(filePath string) (io.ReadCloser, error) {
+	args := m.Called(filePath)
+	return args.Get(0).(io.ReadCloser), args.Error(1)
+}
+
+func newFileHeader(t *testing.T, filename string, content []byte) *multipart.FileHeader {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, _ := writer.CreateFormFile("files", filename)
+	part.Write(content)
+	writer.Close()
+
+	form, err := multipart.NewReader(&body, writer.Boundary()).ReadForm(1024 * 1024)
+	assert.NoError(t, err)
+
+	return form.File["files"][0]
+}
+
+func newImageFileHeader(t *testing.T) *multipart.FileHeader {
+	var content bytes.Buffer
+	png.Encode(&content, image.NewRGBA(image.Rect(0, 0, 4, 4)))
+
+	return newFileHeader(t, "photo.png", content.Bytes())
+}
+
 func TestCreate(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		repo := new(MockComplaintFileRepository)
 		gcs_api := new(MockComplaintFileGCSAPI)
 		usecase := NewComplaintFileUseCase(repo, gcs_api)
 
-		files := []*multipart.FileHeader{}
+		files := []*multipart.FileHeader{newImageFileHeader(t)}
 		complaintID := "complaint_id"
 
 		repo.On("Create", mock.Anything).Return(nil)
-		gcs_api.On("Upload", files).Return([]string{"path"}, nil)
+		gcs_api.On("Upload", mock.Anything).Return([]string{"path"}, nil).Once()
+		gcs_api.On("Upload", mock.Anything).Return([]string{"thumbnail"}, nil).Once()
 
 		result, err := usecase.Create(files, complaintID)
 
 		assert.NoError(t, err)
-		assert.NotEmpty(t, result)
+		assert.Equal(t, "path", result[0].Path)
+		assert.Equal(t, "thumbnail", result[0].ThumbnailPath)
+	})
+
+	t.Run("failed invalid file", func(t *testing.T) {
+		repo := new(MockComplaintFileRepository)
+		gcs_api := new(MockComplaintFileGCSAPI)
+		usecase := NewComplaintFileUseCase(repo, gcs_api)
+
+		files := []*multipart.FileHeader{newFileHeader(t, "photo.jpg", []byte("<html></html>"))}
+		complaintID := "complaint_id"
+
+		result, err := usecase.Create(files, complaintID)
+
+		assert.Equal(t, constants.ErrInvalidFileFormat, err)
+		assert.Empty(t, result)
 	})
 
 	t.Run("failed to upload", func(t *testing.T) {
@@ -61,10 +112,10 @@ func TestCreate(t *testing.T) {
 		gcs_api := new(MockComplaintFileGCSAPI)
 		usecase := NewComplaintFileUseCase(repo, gcs_api)
 
-		files := []*multipart.FileHeader{}
+		files := []*multipart.FileHeader{newImageFileHeader(t)}
 		complaintID := "complaint_id"
 
-		gcs_api.On("Upload", files).Return([]string{}, errors.New("failed to upload"))
+		gcs_api.On("Upload", mock.Anything).Return([]string{}, errors.New("failed to upload"))
 
 		result, err := usecase.Create(files, complaintID)
 
@@ -77,16 +128,19 @@ func TestCreate(t *testing.T) {
 		gcs_api := new(MockComplaintFileGCSAPI)
 		usecase := NewComplaintFileUseCase(repo, gcs_api)
 
-		files := []*multipart.FileHeader{}
+		files := []*multipart.FileHeader{newImageFileHeader(t)}
 		complaintID := "complaint_id"
 
 		repo.On("Create", mock.Anything).Return(errors.New("failed to create"))
-		gcs_api.On("Upload", files).Return([]string{"path"}, nil)
+		gcs_api.On("Upload", mock.Anything).Return([]string{"path"}, nil).Once()
+		gcs_api.On("Upload", mock.Anything).Return([]string{"thumbnail"}, nil).Once()
+		gcs_api.On("Delete", []string{"path", "thumbnail"}).Return(nil)
 
 		result, err := usecase.Create(files, complaintID)
 
 		assert.Error(t, err)
 		assert.Empty(t, result)
+		gcs_api.AssertExpectations(t)
 	})
 }
 
@@ -98,13 +152,29 @@ func TestDeleteByComplaintID(t *testing.T) {
 
 		complaintID := "complaint_id"
 
+		repo.On("FindByComplaintID", complaintID).Return([]entities.ComplaintFile{{ComplaintID: complaintID, Path: "path", ThumbnailPath: "thumbnail"}}, nil)
 		repo.On("DeleteByComplaintID", complaintID).Return(nil)
+		gcs_api.On("Delete", []string{"path", "thumbnail"}).Return(nil)
 
 		err := usecase.DeleteByComplaintID(complaintID)
 
 		assert.NoError(t, err)
 	})
 
+	t.Run("failed to find", func(t *testing.T) {
+		repo := new(MockComplaintFileRepository)
+		gcs_api := new(MockComplaintFileGCSAPI)
+		usecase := NewComplaintFileUseCase(repo, gcs_api)
+
+		complaintID := "complaint_id"
+
+		repo.On("FindByComplaintID", complaintID).Return([]entities.ComplaintFile{}, errors.New("failed to find"))
+
+		err := usecase.DeleteByComplaintID(complaintID)
+
+		assert.Error(t, err)
+	})
+
 	t.Run("failed to delete", func(t *testing.T) {
 		repo := new(MockComplaintFileRepository)
 		gcs_api := new(MockComplaintFileGCSAPI)
@@ -112,10 +182,27 @@ func TestDeleteByComplaintID(t *testing.T) {
 
 		complaintID := "complaint_id"
 
+		repo.On("FindByComplaintID", complaintID).Return([]entities.ComplaintFile{{ComplaintID: complaintID, Path: "path"}}, nil)
 		repo.On("DeleteByComplaintID", complaintID).Return(errors.New("failed to delete"))
 
 		err := usecase.DeleteByComplaintID(complaintID)
 
 		assert.Error(t, err)
 	})
+
+	t.Run("failed to delete file", func(t *testing.T) {
+		repo := new(MockComplaintFileRepository)
+		gcs_api := new(MockComplaintFileGCSAPI)
+		usecase := NewComplaintFileUseCase(repo, gcs_api)
+
+		complaintID := "complaint_id"
+
+		repo.On("FindByComplaintID", complaintID).Return([]entities.ComplaintFile{{ComplaintID: complaintID, Path: "path"}}, nil)
+		repo.On("DeleteByComplaintID", complaintID).Return(nil)
+		gcs_api.On("Delete", []string{"path"}).Return(errors.New("failed to delete file"))
+
+		err := usecase.DeleteByComplaintID(complaintID)
+
+		assert.Error(t, err)
+	})
 }
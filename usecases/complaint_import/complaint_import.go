@@ -0,0 +1,484 @@
+package complaint_import
+
+import (
+	"crypto/sha256"
+	"e-complaint-api/constants"
+	"e-complaint-api/entities"
+	"e-complaint-api/utils"
+	"e-complaint-api/workflow"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Columns of an import file, after a header row. Files and the idempotency key may be
+// left out.
+const (
+	columnUserID = iota
+	columnCategoryID
+	columnRegencyID
+	columnAddress
+	columnDescription
+	columnStatus
+	columnType
+	columnDate
+	columnFiles
+	columnImportKey
+	requiredColumns = columnFiles
+)
+
+// dateLayout is the layout of the date column.
+const dateLayout = "02-01-2006"
+
+type ComplaintImportUseCase struct {
+	repository       entities.ComplaintImportRepositoryInterface
+	storage          entities.FileStorageInterface
+	jobUseCase       entities.JobUseCaseInterface
+	getRowsFromExcel func(file io.Reader) ([][]string, error)
+	getRowsFromCSV   func(file io.Reader) ([][]string, error)
+}
+
+// importPayload is the payload of an import job. The file waits in storage until the
+// job imported it.
+type importPayload struct {
+	Path     string
+	Filename string
+	Mode     string
+	AdminID  int
+	Scope    entities.AdminScope
+}
+
+// NewComplaintImportUseCase registers the handler of import jobs, which reads the
+// files to import from storage.
+func NewComplaintImportUseCase(repository entities.ComplaintImportRepositoryInterface, storage entities.FileStorageInterface, jobUseCase entities.JobUseCaseInterface) *ComplaintImportUseCase {
+	useCase := &ComplaintImportUseCase{
+		repository:       repository,
+		storage:          storage,
+		jobUseCase:       jobUseCase,
+		getRowsFromExcel: utils.GetRowsFromExcelReader,
+		getRowsFromCSV:   utils.GetRowsFromCSVReader,
+	}
+	jobUseCase.Register(constants.JobTypeComplaintImport, useCase.runJob)
+
+	return useCase
+}
+
+// row is a data row of an import file with the complaint it turns into.
+type row struct {
+	number    int
+	complaint entities.Complaint
+	key       string
+	errors    []string
+}
+
+// Import validates every row of the file and reports the invalid ones. Depending on mode
+// it then imports nothing, the valid rows or, when every row is valid, the whole file.
+// Rows whose idempotency key is already imported are skipped, so uploading a file again
+// does not duplicate its complaints. Rows outside of a non-empty scope are invalid.
+func (u *ComplaintImportUseCase) Import(file *multipart.FileHeader, mode string, adminID int, scope entities.AdminScope) (entities.ComplaintImportReport, error) {
+	mode, err := checkMode(mode)
+	if err != nil {
+		return entities.ComplaintImportReport{}, err
+	}
+
+	if file == nil {
+		return entities.ComplaintImportReport{}, constants.ErrImportFileMustBeFilled
+	}
+
+	content, err := file.Open()
+	if err != nil {
+		return entities.ComplaintImportReport{}, constants.ErrInternalServerError
+	}
+	defer content.Close()
+
+	return u.importFile(file.Filename, content, mode, adminID, scope)
+}
+
+// Enqueue stores the file and leaves its import to a background job, whose result is
+// the report of the import.
+func (u *ComplaintImportUseCase) Enqueue(file *multipart.FileHeader, mode string, adminID int, scope entities.AdminScope) (entities.Job, error) {
+	mode, err := checkMode(mode)
+	if err != nil {
+		return entities.Job{}, err
+	}
+
+	if file == nil {
+		return entities.Job{}, constants.ErrImportFileMustBeFilled
+	}
+
+	content, err := file.Open()
+	if err != nil {
+		return entities.Job{}, constants.ErrInternalServerError
+	}
+	defer content.Close()
+
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return entities.Job{}, constants.ErrInternalServerError
+	}
+
+	paths, err := u.storage.Upload([]entities.UploadFile{{
+		Name:        file.Filename,
+		ContentType: file.Header.Get("Content-Type"),
+		Content:     data,
+	}})
+	if err != nil {
+		return entities.Job{}, constants.ErrInternalServerError
+	}
+
+	job, err := u.jobUseCase.Enqueue(constants.JobTypeComplaintImport, &adminID, importPayload{
+		Path:     paths[0],
+		Filename: file.Filename,
+		Mode:     mode,
+		AdminID:  adminID,
+		Scope:    scope,
+	})
+	if err != nil {
+		u.storage.Delete(paths)
+		return entities.Job{}, err
+	}
+
+	return job, nil
+}
+
+// runJob imports a file stored by Enqueue. The file is deleted once the import is
+// final, and kept for the next attempt when the import failed on the way.
+func (u *ComplaintImportUseCase) runJob(data []byte) (string, error) {
+	var payload importPayload
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return "", err
+	}
+
+	content, err := u.storage.Open(payload.Path)
+	if err != nil {
+		return "", err
+	}
+	defer content.Close()
+
+	report, err := u.importFile(payload.Filename, content, payload.Mode, payload.AdminID, payload.Scope)
+	if err != nil && err != constants.ErrImportHasInvalidRows {
+		return "", err
+	}
+
+	u.storage.Delete([]string{payload.Path})
+
+	result, _ := json.Marshal(report)
+	return string(result), err
+}
+
+func checkMode(mode string) (string, error) {
+	if mode == "" {
+		mode = constants.ImportModeAllOrNothing
+	}
+	if mode != constants.ImportModeDryRun && mode != constants.ImportModePartial && mode != constants.ImportModeAllOrNothing {
+		return "", constants.ErrInvalidImportMode
+	}
+
+	return mode, nil
+}
+
+func (u *ComplaintImportUseCase) importFile(filename string, content io.Reader, mode string, adminID int, scope entities.AdminScope) (entities.ComplaintImportReport, error) {
+	getRows := u.getRowsFromExcel
+	if strings.EqualFold(filepath.Ext(filename), ".csv") {
+		getRows = u.getRowsFromCSV
+	}
+
+	cells, err := getRows(content)
+	if err != nil {
+		return entities.ComplaintImportReport{}, err
+	}
+
+	rows := []*row{}
+	for i, cells := range cells {
+		// the first row is the header
+		if i == 0 || isBlank(cells) {
+			continue
+		}
+		row := parseRow(i+1, cells, adminID)
+		if len(row.errors) == 0 && !scope.Contains(row.complaint.RegencyID, row.complaint.CategoryID) {
+			row.errors = append(row.errors, constants.ErrComplaintOutOfScope.Error())
+		}
+		rows = append(rows, row)
+	}
+
+	if err := u.checkReferences(rows); err != nil {
+		return entities.ComplaintImportReport{}, err
+	}
+
+	importedKeys, err := u.importedKeys(rows)
+	if err != nil {
+		return entities.ComplaintImportReport{}, err
+	}
+
+	report := entities.ComplaintImportReport{
+		Mode:        mode,
+		TotalRows:   len(rows),
+		SkippedRows: []int{},
+		Errors:      []entities.ComplaintImportRowError{},
+	}
+
+	complaints := []entities.Complaint{}
+	for _, row := range rows {
+		if len(row.errors) > 0 {
+			report.Errors = append(report.Errors, entities.ComplaintImportRowError{Row: row.number, Errors: row.errors})
+			continue
+		}
+
+		if importedKeys[row.key] {
+			report.SkippedRows = append(report.SkippedRows, row.number)
+			continue
+		}
+		importedKeys[row.key] = true
+
+		key := row.key
+		row.complaint.ImportKey = &key
+		complaints = append(complaints, row.complaint)
+	}
+	report.ValidRows = len(complaints)
+
+	if mode == constants.ImportModeDryRun {
+		return report, nil
+	}
+
+	if mode == constants.ImportModeAllOrNothing && len(report.Errors) > 0 {
+		return report, constants.ErrImportHasInvalidRows
+	}
+
+	if err := u.setDueDates(complaints, time.Now()); err != nil {
+		return entities.ComplaintImportReport{}, err
+	}
+
+	if err := u.repository.Import(complaints); err != nil {
+		return entities.ComplaintImportReport{}, constants.ErrInternalServerError
+	}
+	report.ImportedRows = len(complaints)
+
+	return report, nil
+}
+
+func isBlank(cells []string) bool {
+	for _, cell := range cells {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+
+	return true
+}
+
+// parseRow checks everything about a row that does not need the database.
+func parseRow(number int, cells []string, adminID int) *row {
+	r := &row{number: number}
+	if len(cells) < requiredColumns {
+		r.errors = append(r.errors, constants.ErrColumnsDoesntMatch.Error())
+		return r
+	}
+
+	for len(cells) <= columnImportKey {
+		cells = append(cells, "")
+	}
+	for i := range cells {
+		cells[i] = strings.TrimSpace(cells[i])
+	}
+
+	complaint := entities.Complaint{
+		ID:          utils.GenerateID("C-", 10),
+		RegencyID:   cells[columnRegencyID],
+		Address:     cells[columnAddress],
+		Description: cells[columnDescription],
+		Status:      cells[columnStatus],
+		Type:        cells[columnType],
+	}
+
+	var err error
+	if complaint.UserID, err = strconv.Atoi(cells[columnUserID]); err != nil {
+		r.errors = append(r.errors, constants.ErrInvalidIDFormat.Error())
+	}
+
+	if complaint.CategoryID, err = strconv.Atoi(cells[columnCategoryID]); err != nil {
+		r.errors = append(r.errors, constants.ErrInvalidCategoryIDFormat.Error())
+	}
+
+	if complaint.Address == "" || complaint.Description == "" || complaint.RegencyID == "" {
+		r.errors = append(r.errors, constants.ErrAllFieldsMustBeFilled.Error())
+	}
+
+	if complaint.Type != "public" && complaint.Type != "private" {
+		r.errors = append(r.errors, constants.ErrInvalidComplaintType.Error())
+	}
+
+	if complaint.Date, err = time.Parse(dateLayout, cells[columnDate]); err != nil {
+		r.errors = append(r.errors, constants.ErrInvalidDateFormat.Error())
+	}
+
+	transitions, err := workflow.Complaint.Path(complaint.Status)
+	if err != nil {
+		r.errors = append(r.errors, err.Error())
+	}
+	for _, transition := range transitions {
+		complaint.Process = append(complaint.Process, entities.ComplaintProcess{
+			AdminID: adminID,
+			Status:  transition.To,
+			Message: transition.DefaultMessage,
+		})
+	}
+
+	for _, path := range strings.Split(cells[columnFiles], ",") {
+		if path = strings.TrimSpace(path); path != "" {
+			complaint.Files = append(complaint.Files, entities.ComplaintFile{Path: path})
+		}
+	}
+
+	r.key = cells[columnImportKey]
+	if r.key == "" {
+		r.key = fingerprint(cells)
+	} else if len(r.key) > constants.MaxImportKeyLength {
+		r.errors = append(r.errors, constants.ErrImportKeyTooLong.Error())
+	}
+
+	r.complaint = complaint
+	return r
+}
+
+// fingerprint is the idempotency key of a row without one. It covers what the complaint
+// is about and leaves out its status and files, which may be updated between uploads.
+func fingerprint(cells []string) string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{
+		cells[columnUserID],
+		cells[columnCategoryID],
+		cells[columnRegencyID],
+		cells[columnAddress],
+		cells[columnDescription],
+		cells[columnType],
+		cells[columnDate],
+	}, "\x1f")))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// checkReferences reports the rows that refer to a user, category or regency that does
+// not exist.
+func (u *ComplaintImportUseCase) checkReferences(rows []*row) error {
+	userIDs, categoryIDs, regencyIDs := []int{}, []int{}, []string{}
+	for _, row := range rows {
+		if len(row.errors) == 0 {
+			userIDs = append(userIDs, row.complaint.UserID)
+			categoryIDs = append(categoryIDs, row.complaint.CategoryID)
+			regencyIDs = append(regencyIDs, row.complaint.RegencyID)
+		}
+	}
+
+	existingUserIDs, err := u.repository.GetExistingUserIDs(unique(userIDs))
+	if err != nil {
+		return constants.ErrInternalServerError
+	}
+
+	existingCategoryIDs, err := u.repository.GetExistingCategoryIDs(unique(categoryIDs))
+	if err != nil {
+		return constants.ErrInternalServerError
+	}
+
+	existingRegencyIDs, err := u.repository.GetExistingRegencyIDs(unique(regencyIDs))
+	if err != nil {
+		return constants.ErrInternalServerError
+	}
+
+	users, categories, regencies := set(existingUserIDs), set(existingCategoryIDs), set(existingRegencyIDs)
+	for _, row := range rows {
+		if len(row.errors) > 0 {
+			continue
+		}
+		if !users[row.complaint.UserID] {
+			row.errors = append(row.errors, constants.ErrUserNotFound.Error())
+		}
+		if !categories[row.complaint.CategoryID] {
+			row.errors = append(row.errors, constants.ErrCategoryNotFound.Error())
+		}
+		if !regencies[row.complaint.RegencyID] {
+			row.errors = append(row.errors, constants.ErrRegencyNotFound.Error())
+		}
+	}
+
+	return nil
+}
+
+// setDueDates sets the SLA deadline of complaints that are imported into a status that
+// is not final, counted from now since their processes are created by the import.
+func (u *ComplaintImportUseCase) setDueDates(complaints []entities.Complaint, now time.Time) error {
+	if len(complaints) == 0 {
+		return nil
+	}
+
+	categoryIDs := []int{}
+	for _, complaint := range complaints {
+		categoryIDs = append(categoryIDs, complaint.CategoryID)
+	}
+
+	slas, err := u.repository.GetComplaintSLAs(unique(categoryIDs))
+	if err != nil {
+		return constants.ErrInternalServerError
+	}
+
+	targetDays := map[int]map[string]int{}
+	for _, sla := range slas {
+		if targetDays[sla.CategoryID] == nil {
+			targetDays[sla.CategoryID] = map[string]int{}
+		}
+		targetDays[sla.CategoryID][sla.Status] = sla.TargetDays
+	}
+
+	for i := range complaints {
+		if workflow.Complaint.EnsureNotFinal(complaints[i].Status) != nil {
+			continue
+		}
+		if days := targetDays[complaints[i].CategoryID][complaints[i].Status]; days > 0 {
+			dueAt := now.AddDate(0, 0, days)
+			complaints[i].DueAt = &dueAt
+		}
+	}
+
+	return nil
+}
+
+func (u *ComplaintImportUseCase) importedKeys(rows []*row) (map[string]bool, error) {
+	keys := []string{}
+	for _, row := range rows {
+		if len(row.errors) == 0 {
+			keys = append(keys, row.key)
+		}
+	}
+
+	imported, err := u.repository.GetImportedKeys(unique(keys))
+	if err != nil {
+		return nil, constants.ErrInternalServerError
+	}
+
+	return set(imported), nil
+}
+
+func unique[T comparable](values []T) []T {
+	seen := set(values)
+	result := make([]T, 0, len(seen))
+	for _, value := range values {
+		if seen[value] {
+			result = append(result, value)
+			delete(seen, value)
+		}
+	}
+
+	return result
+}
+
+func set[T comparable](values []T) map[T]bool {
+	result := make(map[T]bool, len(values))
+	for _, value := range values {
+		result[value] = true
+	}
+
+	return result
+}
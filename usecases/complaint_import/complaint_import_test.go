@@ -0,0 +1,522 @@
+package complaint_import
+
+import (
+	"bytes"
+	"e-complaint-api/constants"
+	"e-complaint-api/entities"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime/multipart"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockComplaintImportRepo struct {
+	mock.Mock
+}
+
+func (m *MockComplaintImportRepo) GetExistingUserIDs(ids []int) ([]int, error) {
+	args := m.Called(ids)
+	return args.Get(0).([]int), args.Error(1)
+}
+
+func (m *MockComplaintImportRepo) GetExistingCategoryIDs(ids []int) ([]int, error) {
+	args := m.Called(ids)
+	return args.Get(0).([]int), args.Error(1)
+}
+
+func (m *MockComplaintImportRepo) GetExistingRegencyIDs(ids []string) ([]string, error) {
+	args := m.Called(ids)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockComplaintImportRepo) GetImportedKeys(keys []string) ([]string, error) {
+	args := m.Called(keys)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockComplaintImportRepo) GetComplaintSLAs(categoryIDs []int) ([]entities.ComplaintSLA, error) {
+	args := m.Called(categoryIDs)
+	return args.Get(0).([]entities.ComplaintSLA), args.Error(1)
+}
+
+func (m *MockComplaintImportRepo) Import(complaints []entities.Complaint) error {
+	args := m.Called(complaints)
+	return args.Error(0)
+}
+
+type MockFileStorage struct {
+	mock.Mock
+}
+
+func (m *MockFileStorage) Upload(files []entities.UploadFile) ([]string, error) {
+	args := m.Called(files)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockFileStorage) Delete(paths []string) error {
+	args := m.Called(paths)
+	return args.Error(0)
+}
+
+func (m *MockFileStorage) Open(filePath string) (io.ReadCloser, error) {
+	args := m.Called(filePath)
+	return args.Get(0).(io.ReadCloser), args.Error(1)
+}
+
+type MockJobUseCase struct {
+	mock.Mock
+}
+
+func (m *MockJobUseCase) Register(jobType string, handler entities.JobHandler) {
+	m.Called(jobType, handler)
+}
+
+func (m *MockJobUseCase) Enqueue(jobType string, createdBy *int, payload interface{}) (entities.Job, error) {
+	args := m.Called(jobType, createdBy, payload)
+	return args.Get(0).(entities.Job), args.Error(1)
+}
+
+func (m *MockJobUseCase) RunOnce() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+func (m *MockJobUseCase) GetByCursor(filter map[string]interface{}, limit int, cursor string) ([]entities.Job, string, error) {
+	args := m.Called(filter, limit, cursor)
+	return args.Get(0).([]entities.Job), args.String(1), args.Error(2)
+}
+
+func (m *MockJobUseCase) GetByID(id int) (entities.Job, error) {
+	args := m.Called(id)
+	return args.Get(0).(entities.Job), args.Error(1)
+}
+
+func (m *MockJobUseCase) GetOwn(id int, jobType string, adminID int) (entities.Job, error) {
+	args := m.Called(id, jobType, adminID)
+	return args.Get(0).(entities.Job), args.Error(1)
+}
+
+func (m *MockJobUseCase) Retry(id int) (entities.Job, error) {
+	args := m.Called(id)
+	return args.Get(0).(entities.Job), args.Error(1)
+}
+
+func newFileHeader(t *testing.T, filename string, content []byte) *multipart.FileHeader {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, _ := writer.CreateFormFile("file", filename)
+	part.Write(content)
+	writer.Close()
+
+	form, err := multipart.NewReader(&body, writer.Boundary()).ReadForm(1024 * 1024)
+	assert.NoError(t, err)
+
+	return form.File["file"][0]
+}
+
+var header = []string{"UserID", "CategoryID", "RegencyID", "Address", "Description", "Status", "Type", "Date", "Files", "ImportKey"}
+
+func newUseCase(repo *MockComplaintImportRepo, rows [][]string) *ComplaintImportUseCase {
+	useCase, _, _ := newUseCaseWithJobs(repo, rows)
+	return useCase
+}
+
+func newUseCaseWithJobs(repo *MockComplaintImportRepo, rows [][]string) (*ComplaintImportUseCase, *MockFileStorage, *MockJobUseCase) {
+	storage := new(MockFileStorage)
+	jobUseCase := new(MockJobUseCase)
+	jobUseCase.On("Register", constants.JobTypeComplaintImport, mock.Anything).Return()
+
+	useCase := NewComplaintImportUseCase(repo, storage, jobUseCase)
+	useCase.getRowsFromExcel = func(file io.Reader) ([][]string, error) {
+		return rows, nil
+	}
+	useCase.getRowsFromCSV = func(file io.Reader) ([][]string, error) {
+		return nil, errors.New("not a csv file")
+	}
+
+	return useCase, storage, jobUseCase
+}
+
+// withReferences makes users 1 and 2, categories 1 and 2 and regency 3601 exist, with
+// an SLA of 3 days for pending complaints of category 1.
+func withReferences(repo *MockComplaintImportRepo) {
+	repo.On("GetExistingUserIDs", mock.Anything).Return([]int{1, 2}, nil)
+	repo.On("GetExistingCategoryIDs", mock.Anything).Return([]int{1, 2}, nil)
+	repo.On("GetExistingRegencyIDs", mock.Anything).Return([]string{"3601"}, nil)
+	repo.On("GetComplaintSLAs", mock.Anything).Return([]entities.ComplaintSLA{
+		{CategoryID: 1, Status: "Pending", TargetDays: 3},
+		{CategoryID: 2, Status: "Selesai", TargetDays: 3},
+	}, nil).Maybe()
+}
+
+func TestImport(t *testing.T) {
+	xlsx := newFileHeader(t, "aduan.xlsx", []byte("xlsx"))
+
+	t.Run("success all or nothing", func(t *testing.T) {
+		repo := new(MockComplaintImportRepo)
+		useCase := newUseCase(repo, [][]string{
+			header,
+			{"1", "2", "3601", "Jl. Merdeka", "Jalan berlubang", "Selesai", "public", "05-05-2024", "a.jpg, b.jpg"},
+			{"", "", "", ""},
+			{"2", "1", "3601", "Jl. Sudirman", "Sampah menumpuk", "Pending", "private", "06-05-2024"},
+		})
+		withReferences(repo)
+		repo.On("GetImportedKeys", mock.Anything).Return([]string{}, nil)
+
+		var imported []entities.Complaint
+		repo.On("Import", mock.Anything).Run(func(args mock.Arguments) {
+			imported = args.Get(0).([]entities.Complaint)
+		}).Return(nil)
+
+		report, err := useCase.Import(xlsx, "", 7, entities.AdminScope{})
+		assert.NoError(t, err)
+		assert.Equal(t, entities.ComplaintImportReport{
+			Mode:         constants.ImportModeAllOrNothing,
+			TotalRows:    2,
+			ValidRows:    2,
+			ImportedRows: 2,
+			SkippedRows:  []int{},
+			Errors:       []entities.ComplaintImportRowError{},
+		}, report)
+
+		assert.Len(t, imported, 2)
+		assert.Equal(t, []entities.ComplaintFile{{Path: "a.jpg"}, {Path: "b.jpg"}}, imported[0].Files)
+		assert.Len(t, imported[0].Process, 4)
+		for _, process := range imported[0].Process {
+			assert.Equal(t, 7, process.AdminID)
+		}
+		assert.Equal(t, "Selesai", imported[0].Process[3].Status)
+		assert.Len(t, *imported[0].ImportKey, 64)
+		assert.Nil(t, imported[1].Files)
+		assert.NotEqual(t, *imported[0].ImportKey, *imported[1].ImportKey)
+		assert.Nil(t, imported[0].DueAt)
+		assert.WithinDuration(t, time.Now().AddDate(0, 0, 3), *imported[1].DueAt, time.Minute)
+	})
+
+	t.Run("success dry run reports every invalid row", func(t *testing.T) {
+		repo := new(MockComplaintImportRepo)
+		useCase := newUseCase(repo, [][]string{
+			header,
+			{"1", "2", "3601", "Jl. Merdeka", "Jalan berlubang", "Pending", "public", "05-05-2024"},
+			{"a", "b", "3601", "", "Jalan berlubang", "Hilang", "rahasia", "2024-05-05"},
+			{"9", "8", "9999", "Jl. Merdeka", "Jalan berlubang", "Pending", "public", "05-05-2024"},
+			{"1", "2", "3601"},
+			{"1", "2", "3601", "Jl. Merdeka", "Jalan berlubang", "Pending", "public", "05-05-2024", "", strings.Repeat("k", 65)},
+		})
+		withReferences(repo)
+		repo.On("GetImportedKeys", mock.Anything).Return([]string{}, nil)
+
+		report, err := useCase.Import(xlsx, constants.ImportModeDryRun, 7, entities.AdminScope{})
+		assert.NoError(t, err)
+		assert.Equal(t, 5, report.TotalRows)
+		assert.Equal(t, 1, report.ValidRows)
+		assert.Equal(t, 0, report.ImportedRows)
+		assert.Equal(t, []entities.ComplaintImportRowError{
+			{Row: 3, Errors: []string{
+				constants.ErrInvalidIDFormat.Error(),
+				constants.ErrInvalidCategoryIDFormat.Error(),
+				constants.ErrAllFieldsMustBeFilled.Error(),
+				constants.ErrInvalidComplaintType.Error(),
+				constants.ErrInvalidDateFormat.Error(),
+				constants.ErrInvalidStatus.Error(),
+			}},
+			{Row: 4, Errors: []string{
+				constants.ErrUserNotFound.Error(),
+				constants.ErrCategoryNotFound.Error(),
+				constants.ErrRegencyNotFound.Error(),
+			}},
+			{Row: 5, Errors: []string{constants.ErrColumnsDoesntMatch.Error()}},
+			{Row: 6, Errors: []string{constants.ErrImportKeyTooLong.Error()}},
+		}, report.Errors)
+		repo.AssertNotCalled(t, "Import", mock.Anything)
+	})
+
+	t.Run("success partial skips imported rows", func(t *testing.T) {
+		repo := new(MockComplaintImportRepo)
+		useCase := newUseCase(repo, [][]string{
+			header,
+			{"1", "2", "3601", "Jl. Merdeka", "Jalan berlubang", "Pending", "public", "05-05-2024", "", "SIAP-1"},
+			{"1", "2", "3601", "Jl. Merdeka", "Jalan berlubang", "Pending", "public", "05-05-2024", "", "SIAP-2"},
+			{"1", "2", "3601", "Jl. Merdeka", "Jalan rusak", "Pending", "public", "05-05-2024", "", "SIAP-2"},
+			{"1", "2", "3601", "Jl. Merdeka", "Jalan berlubang", "Pending", "public", "31-02-2024"},
+		})
+		withReferences(repo)
+		repo.On("GetImportedKeys", []string{"SIAP-1", "SIAP-2"}).Return([]string{"SIAP-1"}, nil)
+		repo.On("Import", mock.MatchedBy(func(complaints []entities.Complaint) bool {
+			return len(complaints) == 1 && *complaints[0].ImportKey == "SIAP-2"
+		})).Return(nil)
+
+		report, err := useCase.Import(xlsx, constants.ImportModePartial, 7, entities.AdminScope{})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, report.ImportedRows)
+		assert.Equal(t, []int{2, 4}, report.SkippedRows)
+		assert.Equal(t, []entities.ComplaintImportRowError{{Row: 5, Errors: []string{constants.ErrInvalidDateFormat.Error()}}}, report.Errors)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("success partial rejects rows out of scope", func(t *testing.T) {
+		repo := new(MockComplaintImportRepo)
+		useCase := newUseCase(repo, [][]string{
+			header,
+			{"1", "2", "3601", "Jl. Merdeka", "Jalan berlubang", "Pending", "public", "05-05-2024"},
+			{"1", "1", "3601", "Jl. Merdeka", "Sampah menumpuk", "Pending", "public", "05-05-2024"},
+		})
+		withReferences(repo)
+		repo.On("GetImportedKeys", mock.Anything).Return([]string{}, nil)
+		repo.On("Import", mock.MatchedBy(func(complaints []entities.Complaint) bool {
+			return len(complaints) == 1 && complaints[0].CategoryID == 1
+		})).Return(nil)
+
+		report, err := useCase.Import(xlsx, constants.ImportModePartial, 7, entities.AdminScope{RegencyIDs: []string{"3601"}, CategoryIDs: []int{1}})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, report.ImportedRows)
+		assert.Equal(t, []entities.ComplaintImportRowError{{Row: 2, Errors: []string{constants.ErrComplaintOutOfScope.Error()}}}, report.Errors)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("success csv file", func(t *testing.T) {
+		repo := new(MockComplaintImportRepo)
+		useCase := newUseCase(repo, nil)
+		useCase.getRowsFromCSV = func(file io.Reader) ([][]string, error) {
+			content, _ := io.ReadAll(file)
+			assert.Equal(t, "UserID", string(content))
+			return [][]string{header}, nil
+		}
+		repo.On("GetExistingUserIDs", []int{}).Return([]int{}, nil)
+		repo.On("GetExistingCategoryIDs", []int{}).Return([]int{}, nil)
+		repo.On("GetExistingRegencyIDs", []string{}).Return([]string{}, nil)
+		repo.On("GetImportedKeys", []string{}).Return([]string{}, nil)
+		repo.On("Import", []entities.Complaint{}).Return(nil)
+
+		report, err := useCase.Import(newFileHeader(t, "aduan.CSV", []byte("UserID")), constants.ImportModePartial, 7, entities.AdminScope{})
+		assert.NoError(t, err)
+		assert.Equal(t, 0, report.TotalRows)
+	})
+
+	t.Run("failed all or nothing with invalid rows", func(t *testing.T) {
+		repo := new(MockComplaintImportRepo)
+		useCase := newUseCase(repo, [][]string{
+			header,
+			{"1", "2", "3601", "Jl. Merdeka", "Jalan berlubang", "Pending", "public", "05-05-2024"},
+			{"1", "2", "3601", "Jl. Merdeka", "Jalan berlubang", "Digabung", "public", "05-05-2024"},
+		})
+		withReferences(repo)
+		repo.On("GetImportedKeys", mock.Anything).Return([]string{}, nil)
+
+		report, err := useCase.Import(xlsx, constants.ImportModeAllOrNothing, 7, entities.AdminScope{})
+		assert.Equal(t, constants.ErrImportHasInvalidRows, err)
+		assert.Equal(t, 1, report.ValidRows)
+		assert.Equal(t, 0, report.ImportedRows)
+		assert.Len(t, report.Errors, 1)
+		repo.AssertNotCalled(t, "Import", mock.Anything)
+	})
+
+	t.Run("failed invalid mode", func(t *testing.T) {
+		_, err := newUseCase(new(MockComplaintImportRepo), nil).Import(xlsx, "force", 7, entities.AdminScope{})
+		assert.Equal(t, constants.ErrInvalidImportMode, err)
+	})
+
+	t.Run("failed file must be filled", func(t *testing.T) {
+		_, err := newUseCase(new(MockComplaintImportRepo), nil).Import(nil, "", 7, entities.AdminScope{})
+		assert.Equal(t, constants.ErrImportFileMustBeFilled, err)
+	})
+
+	t.Run("failed reading file", func(t *testing.T) {
+		_, err := newUseCase(new(MockComplaintImportRepo), nil).Import(newFileHeader(t, "aduan.csv", nil), "", 7, entities.AdminScope{})
+		assert.EqualError(t, err, "not a csv file")
+	})
+
+	t.Run("failed opening file", func(t *testing.T) {
+		_, err := newUseCase(new(MockComplaintImportRepo), nil).Import(&multipart.FileHeader{Filename: "aduan.xlsx"}, "", 7, entities.AdminScope{})
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+
+	rows := [][]string{header, {"1", "2", "3601", "Jl. Merdeka", "Jalan berlubang", "Pending", "public", "05-05-2024"}}
+
+	t.Run("failed getting users", func(t *testing.T) {
+		repo := new(MockComplaintImportRepo)
+		repo.On("GetExistingUserIDs", mock.Anything).Return([]int(nil), errors.New("database error"))
+
+		_, err := newUseCase(repo, rows).Import(xlsx, "", 7, entities.AdminScope{})
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+
+	t.Run("failed getting categories", func(t *testing.T) {
+		repo := new(MockComplaintImportRepo)
+		repo.On("GetExistingUserIDs", mock.Anything).Return([]int{1}, nil)
+		repo.On("GetExistingCategoryIDs", mock.Anything).Return([]int(nil), errors.New("database error"))
+
+		_, err := newUseCase(repo, rows).Import(xlsx, "", 7, entities.AdminScope{})
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+
+	t.Run("failed getting regencies", func(t *testing.T) {
+		repo := new(MockComplaintImportRepo)
+		repo.On("GetExistingUserIDs", mock.Anything).Return([]int{1}, nil)
+		repo.On("GetExistingCategoryIDs", mock.Anything).Return([]int{2}, nil)
+		repo.On("GetExistingRegencyIDs", mock.Anything).Return([]string(nil), errors.New("database error"))
+
+		_, err := newUseCase(repo, rows).Import(xlsx, "", 7, entities.AdminScope{})
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+
+	t.Run("failed getting imported keys", func(t *testing.T) {
+		repo := new(MockComplaintImportRepo)
+		withReferences(repo)
+		repo.On("GetImportedKeys", mock.Anything).Return([]string(nil), errors.New("database error"))
+
+		_, err := newUseCase(repo, rows).Import(xlsx, "", 7, entities.AdminScope{})
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+
+	t.Run("failed getting complaint slas", func(t *testing.T) {
+		repo := new(MockComplaintImportRepo)
+		repo.On("GetExistingUserIDs", mock.Anything).Return([]int{1}, nil)
+		repo.On("GetExistingCategoryIDs", mock.Anything).Return([]int{2}, nil)
+		repo.On("GetExistingRegencyIDs", mock.Anything).Return([]string{"3601"}, nil)
+		repo.On("GetImportedKeys", mock.Anything).Return([]string{}, nil)
+		repo.On("GetComplaintSLAs", []int{2}).Return([]entities.ComplaintSLA(nil), errors.New("database error"))
+
+		_, err := newUseCase(repo, rows).Import(xlsx, "", 7, entities.AdminScope{})
+		assert.Equal(t, constants.ErrInternalServerError, err)
+		repo.AssertNotCalled(t, "Import", mock.Anything)
+	})
+
+	t.Run("failed importing", func(t *testing.T) {
+		repo := new(MockComplaintImportRepo)
+		withReferences(repo)
+		repo.On("GetImportedKeys", mock.Anything).Return([]string{}, nil)
+		repo.On("Import", mock.Anything).Return(errors.New("database error"))
+
+		_, err := newUseCase(repo, rows).Import(xlsx, "", 7, entities.AdminScope{})
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+}
+
+func TestEnqueue(t *testing.T) {
+	adminID := 7
+
+	t.Run("success", func(t *testing.T) {
+		useCase, storage, jobUseCase := newUseCaseWithJobs(new(MockComplaintImportRepo), nil)
+		storage.On("Upload", []entities.UploadFile{{Name: "aduan.xlsx", ContentType: "application/octet-stream", Content: []byte("xlsx")}}).Return([]string{"imports/abc.xlsx"}, nil)
+		jobUseCase.On("Enqueue", constants.JobTypeComplaintImport, &adminID, importPayload{
+			Path:     "imports/abc.xlsx",
+			Filename: "aduan.xlsx",
+			Mode:     constants.ImportModeAllOrNothing,
+			AdminID:  adminID,
+		}).Return(entities.Job{ID: 1, Status: constants.JobStatusPending}, nil)
+
+		job, err := useCase.Enqueue(newFileHeader(t, "aduan.xlsx", []byte("xlsx")), "", adminID, entities.AdminScope{})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, job.ID)
+	})
+
+	t.Run("failed invalid mode", func(t *testing.T) {
+		_, err := newUseCase(new(MockComplaintImportRepo), nil).Enqueue(newFileHeader(t, "aduan.xlsx", nil), "force", adminID, entities.AdminScope{})
+		assert.Equal(t, constants.ErrInvalidImportMode, err)
+	})
+
+	t.Run("failed file must be filled", func(t *testing.T) {
+		_, err := newUseCase(new(MockComplaintImportRepo), nil).Enqueue(nil, "", adminID, entities.AdminScope{})
+		assert.Equal(t, constants.ErrImportFileMustBeFilled, err)
+	})
+
+	t.Run("failed opening file", func(t *testing.T) {
+		_, err := newUseCase(new(MockComplaintImportRepo), nil).Enqueue(&multipart.FileHeader{Filename: "aduan.xlsx"}, "", adminID, entities.AdminScope{})
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+
+	t.Run("failed storing file", func(t *testing.T) {
+		useCase, storage, _ := newUseCaseWithJobs(new(MockComplaintImportRepo), nil)
+		storage.On("Upload", mock.Anything).Return([]string(nil), constants.ErrFailedToUploadObject)
+
+		_, err := useCase.Enqueue(newFileHeader(t, "aduan.xlsx", []byte("xlsx")), "", adminID, entities.AdminScope{})
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+
+	t.Run("failed enqueueing removes file", func(t *testing.T) {
+		useCase, storage, jobUseCase := newUseCaseWithJobs(new(MockComplaintImportRepo), nil)
+		storage.On("Upload", mock.Anything).Return([]string{"imports/abc.xlsx"}, nil)
+		storage.On("Delete", []string{"imports/abc.xlsx"}).Return(nil)
+		jobUseCase.On("Enqueue", mock.Anything, mock.Anything, mock.Anything).Return(entities.Job{}, constants.ErrInternalServerError)
+
+		_, err := useCase.Enqueue(newFileHeader(t, "aduan.xlsx", []byte("xlsx")), "", adminID, entities.AdminScope{})
+		assert.Equal(t, constants.ErrInternalServerError, err)
+		storage.AssertExpectations(t)
+	})
+}
+
+func TestRunJob(t *testing.T) {
+	payload, _ := json.Marshal(importPayload{Path: "imports/abc.xlsx", Filename: "aduan.xlsx", Mode: constants.ImportModeAllOrNothing, AdminID: 7})
+	rows := [][]string{header, {"1", "2", "3601", "Jl. Merdeka", "Jalan berlubang", "Pending", "public", "05-05-2024"}}
+
+	t.Run("success removes file", func(t *testing.T) {
+		repo := new(MockComplaintImportRepo)
+		withReferences(repo)
+		repo.On("GetImportedKeys", mock.Anything).Return([]string{}, nil)
+		repo.On("Import", mock.Anything).Return(nil)
+
+		useCase, storage, _ := newUseCaseWithJobs(repo, rows)
+		storage.On("Open", "imports/abc.xlsx").Return(io.NopCloser(strings.NewReader("xlsx")), nil)
+		storage.On("Delete", []string{"imports/abc.xlsx"}).Return(nil)
+
+		result, err := useCase.runJob(payload)
+		assert.NoError(t, err)
+
+		var report entities.ComplaintImportReport
+		assert.NoError(t, json.Unmarshal([]byte(result), &report))
+		assert.Equal(t, 1, report.ImportedRows)
+		storage.AssertExpectations(t)
+	})
+
+	t.Run("failed invalid rows keeps report", func(t *testing.T) {
+		repo := new(MockComplaintImportRepo)
+		repo.On("GetExistingUserIDs", mock.Anything).Return([]int{}, nil)
+		repo.On("GetExistingCategoryIDs", mock.Anything).Return([]int{2}, nil)
+		repo.On("GetExistingRegencyIDs", mock.Anything).Return([]string{"3601"}, nil)
+		repo.On("GetImportedKeys", mock.Anything).Return([]string{}, nil)
+
+		useCase, storage, _ := newUseCaseWithJobs(repo, rows)
+		storage.On("Open", "imports/abc.xlsx").Return(io.NopCloser(strings.NewReader("xlsx")), nil)
+		storage.On("Delete", []string{"imports/abc.xlsx"}).Return(nil)
+
+		result, err := useCase.runJob(payload)
+		assert.Equal(t, constants.ErrImportHasInvalidRows, err)
+		assert.Contains(t, result, constants.ErrUserNotFound.Error())
+	})
+
+	t.Run("failed import keeps file for next attempt", func(t *testing.T) {
+		repo := new(MockComplaintImportRepo)
+		repo.On("GetExistingUserIDs", mock.Anything).Return([]int(nil), errors.New("database error"))
+
+		useCase, storage, _ := newUseCaseWithJobs(repo, rows)
+		storage.On("Open", "imports/abc.xlsx").Return(io.NopCloser(strings.NewReader("xlsx")), nil)
+
+		_, err := useCase.runJob(payload)
+		assert.Equal(t, constants.ErrInternalServerError, err)
+		storage.AssertNotCalled(t, "Delete", mock.Anything)
+	})
+
+	t.Run("failed file not found", func(t *testing.T) {
+		useCase, storage, _ := newUseCaseWithJobs(new(MockComplaintImportRepo), rows)
+		storage.On("Open", "imports/abc.xlsx").Return(io.NopCloser(nil), constants.ErrFileNotFound)
+
+		_, err := useCase.runJob(payload)
+		assert.Equal(t, constants.ErrFileNotFound, err)
+	})
+
+	t.Run("failed invalid payload", func(t *testing.T) {
+		_, err := newUseCase(new(MockComplaintImportRepo), rows).runJob([]byte("{"))
+		assert.Error(t, err)
+	})
+}
+
+func TestUnique(t *testing.T) {
+	assert.Equal(t, []int{3, 1, 2}, unique([]int{3, 1, 3, 2, 1}))
+}
@@ -12,19 +12,19 @@ type MockComplaintLike struct {
 	mock.Mock
 }
 
-func (m MockComplaintLike) Unlike(complaintLike *entities.ComplaintLike) error {
+func (m *MockComplaintLike) Unlike(complaintLike *entities.ComplaintLike) error {
 	args := m.Called(complaintLike)
 	return args.Error(0)
 
 }
 
-func (m MockComplaintLike) Likes(complaintLike *entities.ComplaintLike) error {
+func (m *MockComplaintLike) Likes(complaintLike *entities.ComplaintLike) error {
 	args := m.Called(complaintLike)
 	return args.Error(0)
 
 }
 
-func (m MockComplaintLike) FindByUserAndComplaint(userID int, complaintID string) (*entities.ComplaintLike, error) {
+func (m *MockComplaintLike) FindByUserAndComplaint(userID int, complaintID string) (*entities.ComplaintLike, error) {
 	args := m.Called(userID, complaintID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
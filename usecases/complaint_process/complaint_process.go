@@ -3,18 +3,20 @@ package complaint_process
 import (
 	"e-complaint-api/constants"
 	"e-complaint-api/entities"
+	"e-complaint-api/workflow"
 	"strings"
+	"time"
 )
 
 type ComplaintProcessUseCase struct {
-	repository          entities.ComplaintProcessRepositoryInterface
-	complaintRepository entities.ComplaintRepositoryInterface
+	repository entities.ComplaintProcessRepositoryInterface
+	unitOfWork entities.UnitOfWorkInterface
 }
 
-func NewComplaintProcessUseCase(repository entities.ComplaintProcessRepositoryInterface, complaintRepository entities.ComplaintRepositoryInterface) *ComplaintProcessUseCase {
+func NewComplaintProcessUseCase(repository entities.ComplaintProcessRepositoryInterface, unitOfWork entities.UnitOfWorkInterface) *ComplaintProcessUseCase {
 	return &ComplaintProcessUseCase{
-		repository:          repository,
-		complaintRepository: complaintRepository,
+		repository: repository,
+		unitOfWork: unitOfWork,
 	}
 }
 
@@ -23,70 +25,42 @@ func (u *ComplaintProcessUseCase) Create(complaintProcess *entities.ComplaintPro
 		return entities.ComplaintProcess{}, constants.ErrAllFieldsMustBeFilled
 	}
 
-	if complaintProcess.Status != "Pending" && complaintProcess.Status != "Verifikasi" && complaintProcess.Status != "On Progress" && complaintProcess.Status != "Selesai" && complaintProcess.Status != "Ditolak" {
+	if !workflow.Complaint.IsValid(complaintProcess.Status) {
 		return entities.ComplaintProcess{}, constants.ErrInvalidStatus
 	}
 
-	status, err := u.complaintRepository.GetStatus(complaintProcess.ComplaintID)
-	if err != nil {
-		return entities.ComplaintProcess{}, constants.ErrInternalServerError
+	if complaintProcess.Status == workflow.StatusDigabung {
+		return entities.ComplaintProcess{}, constants.ErrMergedStatusNotAllowed
 	}
 
-	if complaintProcess.Status == "Pending" {
-		if status == "On Progress" {
-			return entities.ComplaintProcess{}, constants.ErrComplaintNotVerified
-		} else if status == "Selesai" {
-			return entities.ComplaintProcess{}, constants.ErrComplaintNotVerified
-		}
-	} else if complaintProcess.Status == "Verifikasi" {
-		if status == "Verifikasi" {
-			return entities.ComplaintProcess{}, constants.ErrComplaintAlreadyVerified
-		} else if status == "Ditolak" {
-			return entities.ComplaintProcess{}, constants.ErrComplaintAlreadyRejected
-		} else if status == "Selesai" {
-			return entities.ComplaintProcess{}, constants.ErrComplaintAlreadyFinished
-		} else if status == "On Progress" {
-			return entities.ComplaintProcess{}, constants.ErrComplaintAlreadyOnProgress
+	err := u.unitOfWork.Do(func(repositories entities.UnitOfWorkRepositories) error {
+		status, err := repositories.Complaint.GetStatus(complaintProcess.ComplaintID)
+		if err != nil {
+			return constants.ErrInternalServerError
 		}
-	} else if complaintProcess.Status == "On Progress" {
-		if status == "On Progress" {
-			return entities.ComplaintProcess{}, constants.ErrComplaintAlreadyOnProgress
-		} else if status == "Ditolak" {
-			return entities.ComplaintProcess{}, constants.ErrComplaintAlreadyRejected
-		} else if status == "Selesai" {
-			return entities.ComplaintProcess{}, constants.ErrComplaintAlreadyFinished
-		} else if status == "Pending" {
-			return entities.ComplaintProcess{}, constants.ErrComplaintNotVerified
+
+		if err := workflow.Complaint.Validate(status, complaintProcess.Status); err != nil {
+			return err
 		}
-	} else if complaintProcess.Status == "Selesai" {
-		if status == "Selesai" {
-			return entities.ComplaintProcess{}, constants.ErrComplaintAlreadyFinished
-		} else if status == "Ditolak" {
-			return entities.ComplaintProcess{}, constants.ErrComplaintAlreadyRejected
-		} else if status == "Pending" {
-			return entities.ComplaintProcess{}, constants.ErrComplaintNotVerified
-		} else if status == "Verifikasi" {
-			return entities.ComplaintProcess{}, constants.ErrComplaintNotOnProgress
+
+		err = repositories.ComplaintProcess.Create(complaintProcess)
+		if err != nil {
+			if strings.Contains(err.Error(), "REFERENCES `complaints` (`id`)") {
+				return constants.ErrComplaintNotFound
+			} else {
+				return constants.ErrInternalServerError
+			}
 		}
-	} else if complaintProcess.Status == "Ditolak" {
-		if status == "Ditolak" {
-			return entities.ComplaintProcess{}, constants.ErrComplaintAlreadyRejected
-		} else if status == "Selesai" {
-			return entities.ComplaintProcess{}, constants.ErrComplaintAlreadyFinished
-		} else if status == "Verifikasi" {
-			return entities.ComplaintProcess{}, constants.ErrComplaintAlreadyVerified
-		} else if status == "On Progress" {
-			return entities.ComplaintProcess{}, constants.ErrComplaintAlreadyOnProgress
+
+		err = repositories.Complaint.UpdateStatus(complaintProcess.ComplaintID, complaintProcess.Status)
+		if err != nil {
+			return err
 		}
-	}
 
-	err = u.repository.Create(complaintProcess)
+		return refreshDueDate(repositories, complaintProcess.ComplaintID, complaintProcess.Status, time.Now())
+	})
 	if err != nil {
-		if strings.Contains(err.Error(), "REFERENCES `complaints` (`id`)") {
-			return entities.ComplaintProcess{}, constants.ErrComplaintNotFound
-		} else {
-			return entities.ComplaintProcess{}, constants.ErrInternalServerError
-		}
+		return entities.ComplaintProcess{}, err
 	}
 
 	return *complaintProcess, nil
@@ -119,20 +93,56 @@ func (u *ComplaintProcessUseCase) Delete(complaintID string, complaintProcessID
 		return "", constants.ErrInvalidIDFormat
 	}
 
-	status, err := u.repository.Delete(complaintID, complaintProcessID)
+	var status string
+	err := u.unitOfWork.Do(func(repositories entities.UnitOfWorkRepositories) error {
+		err := repositories.ComplaintProcess.Delete(complaintID, complaintProcessID)
+		if err != nil {
+			return err
+		}
+
+		// Revert the complaint to the status of the process that is now the latest one
+		since := time.Now()
+		latest, err := repositories.ComplaintProcess.GetLatestByComplaintID(complaintID)
+		if err == nil {
+			status = latest.Status
+			since = latest.CreatedAt
+		} else if err == constants.ErrComplaintProcessNotFound {
+			status = workflow.Complaint.Initial()
+		} else {
+			return err
+		}
+
+		err = repositories.Complaint.UpdateStatus(complaintID, status)
+		if err != nil {
+			return err
+		}
+
+		return refreshDueDate(repositories, complaintID, status, since)
+	})
 	if err != nil {
 		return "", err
 	}
 
-	if status == "Verifikasi" {
-		status = "Pending"
-	} else if status == "On Progress" {
-		status = "Verifikasi"
-	} else if status == "Selesai" {
-		status = "On Progress"
-	} else if status == "Ditolak" {
-		status = "Pending"
+	return status, nil
+}
+
+// refreshDueDate sets the SLA deadline of a complaint that entered status at since.
+func refreshDueDate(repositories entities.UnitOfWorkRepositories, complaintID string, status string, since time.Time) error {
+	targetDays, err := repositories.ComplaintSLA.GetTargetDays(complaintID, status)
+	if err != nil {
+		return constants.ErrInternalServerError
 	}
 
-	return status, nil
+	var dueAt *time.Time
+	if targetDays > 0 {
+		deadline := since.AddDate(0, 0, targetDays)
+		dueAt = &deadline
+	}
+
+	err = repositories.ComplaintSLA.UpdateDueDate(complaintID, dueAt)
+	if err != nil {
+		return constants.ErrInternalServerError
+	}
+
+	return nil
 }
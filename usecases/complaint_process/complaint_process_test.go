@@ -5,6 +5,7 @@ import (
 	"e-complaint-api/entities"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -30,9 +31,14 @@ func (m *MockComplaintProcess) Update(complaintProcesses *entities.ComplaintProc
 	return args.Error(0)
 }
 
-func (m *MockComplaintProcess) Delete(complaintID string, complaintProcessID int) (string, error) {
+func (m *MockComplaintProcess) GetLatestByComplaintID(complaintID string) (entities.ComplaintProcess, error) {
+	args := m.Called(complaintID)
+	return args.Get(0).(entities.ComplaintProcess), args.Error(1)
+}
+
+func (m *MockComplaintProcess) Delete(complaintID string, complaintProcessID int) error {
 	args := m.Called(complaintID, complaintProcessID)
-	return args.String(0), args.Error(1)
+	return args.Error(0)
 }
 
 type MockComplaint struct {
@@ -83,12 +89,6 @@ func (m *MockComplaint) GetStatus(id string) (string, error) {
 
 }
 
-func (m *MockComplaint) Import(complaints []entities.Complaint) error {
-	args := m.Called(complaints)
-	return args.Error(0)
-
-}
-
 func (m *MockComplaint) IncreaseTotalLikes(id string) error {
 	args := m.Called(id)
 	return args.Error(0)
@@ -119,17 +119,91 @@ func (m *MockComplaint) GetMetaData(limit int, page int, search string, filter m
 	return result.(entities.Metadata), args.Error(1)
 }
 
+func (m *MockComplaint) GetAfter(limit int, afterID string, afterValue string, search string, filter map[string]interface{}, sortBy string, sortType string) ([]entities.Complaint, error) {
+	args := m.Called(limit, afterID, afterValue, search, filter, sortBy, sortType)
+	return args.Get(0).([]entities.Complaint), args.Error(1)
+}
+
+func (m *MockComplaint) GetAfterWithProcesses(limit int, afterID string, afterValue string, search string, filter map[string]interface{}, sortBy string, sortType string) ([]entities.Complaint, error) {
+	args := m.Called(limit, afterID, afterValue, search, filter, sortBy, sortType)
+	return args.Get(0).([]entities.Complaint), args.Error(1)
+}
+
+func (m *MockComplaint) GetWithLocation(limit int, search string, filter map[string]interface{}) ([]entities.Complaint, error) {
+	args := m.Called(limit, search, filter)
+	return args.Get(0).([]entities.Complaint), args.Error(1)
+}
+
 func (m *MockComplaint) GetByID(id string) (entities.Complaint, error) {
 	args := m.Called(id)
 	result := args.Get(0)
 	return result.(entities.Complaint), args.Error(1)
 }
 
+type MockComplaintSLA struct {
+	mock.Mock
+}
+
+func (m *MockComplaintSLA) GetAll() ([]entities.ComplaintSLA, error) {
+	args := m.Called()
+	return args.Get(0).([]entities.ComplaintSLA), args.Error(1)
+}
+
+func (m *MockComplaintSLA) Create(complaintSLA *entities.ComplaintSLA) error {
+	args := m.Called(complaintSLA)
+	return args.Error(0)
+}
+
+func (m *MockComplaintSLA) Update(complaintSLA *entities.ComplaintSLA) error {
+	args := m.Called(complaintSLA)
+	return args.Error(0)
+}
+
+func (m *MockComplaintSLA) Delete(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockComplaintSLA) GetTargetDays(complaintID string, status string) (int, error) {
+	args := m.Called(complaintID, status)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockComplaintSLA) UpdateDueDate(complaintID string, dueAt *time.Time) error {
+	args := m.Called(complaintID, dueAt)
+	return args.Error(0)
+}
+
+func (m *MockComplaintSLA) GetOverdue(now time.Time) ([]entities.Complaint, error) {
+	args := m.Called(now)
+	return args.Get(0).([]entities.Complaint), args.Error(1)
+}
+
+func (m *MockComplaintSLA) MarkEscalated(complaintID string, escalatedAt time.Time) error {
+	args := m.Called(complaintID, escalatedAt)
+	return args.Error(0)
+}
+
+type MockUnitOfWork struct {
+	complaintRepo        *MockComplaint
+	complaintProcessRepo *MockComplaintProcess
+	complaintSLARepo     *MockComplaintSLA
+}
+
+func (m *MockUnitOfWork) Do(fn func(repositories entities.UnitOfWorkRepositories) error) error {
+	return fn(entities.UnitOfWorkRepositories{
+		Complaint:        m.complaintRepo,
+		ComplaintProcess: m.complaintProcessRepo,
+		ComplaintSLA:     m.complaintSLARepo,
+	})
+}
+
 func TestCreate(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		mockComplaintProcessRepo := new(MockComplaintProcess)
 		mockComplaintRepo := new(MockComplaint)
-		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, mockComplaintRepo)
+		mockComplaintSLARepo := new(MockComplaintSLA)
+		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, &MockUnitOfWork{mockComplaintRepo, mockComplaintProcessRepo, mockComplaintSLARepo})
 		dummyComplaintProcess := &entities.ComplaintProcess{
 			Message:     "Test Message",
 			Status:      "Pending",
@@ -138,17 +212,112 @@ func TestCreate(t *testing.T) {
 
 		mockComplaintRepo.On("GetStatus", mock.Anything).Return("Pending", nil)
 		mockComplaintProcessRepo.On("Create", mock.Anything).Return(nil)
+		mockComplaintRepo.On("UpdateStatus", "123", "Pending").Return(nil)
+		mockComplaintSLARepo.On("GetTargetDays", "123", "Pending").Return(0, nil)
+		mockComplaintSLARepo.On("UpdateDueDate", "123", (*time.Time)(nil)).Return(nil)
 
 		result, err := usecase.Create(dummyComplaintProcess)
 
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
+		mockComplaintRepo.AssertExpectations(t)
+		mockComplaintSLARepo.AssertExpectations(t)
+	})
+
+	t.Run("success setting sla due date", func(t *testing.T) {
+		mockComplaintProcessRepo := new(MockComplaintProcess)
+		mockComplaintRepo := new(MockComplaint)
+		mockComplaintSLARepo := new(MockComplaintSLA)
+		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, &MockUnitOfWork{mockComplaintRepo, mockComplaintProcessRepo, mockComplaintSLARepo})
+		dummyComplaintProcess := &entities.ComplaintProcess{
+			Message:     "Test Message",
+			Status:      "Verifikasi",
+			ComplaintID: "123",
+		}
+
+		mockComplaintRepo.On("GetStatus", "123").Return("Pending", nil)
+		mockComplaintProcessRepo.On("Create", mock.Anything).Return(nil)
+		mockComplaintRepo.On("UpdateStatus", "123", "Verifikasi").Return(nil)
+		mockComplaintSLARepo.On("GetTargetDays", "123", "Verifikasi").Return(14, nil)
+		mockComplaintSLARepo.On("UpdateDueDate", "123", mock.MatchedBy(func(dueAt *time.Time) bool {
+			return dueAt != nil && dueAt.After(time.Now().AddDate(0, 0, 13))
+		})).Return(nil)
+
+		_, err := usecase.Create(dummyComplaintProcess)
+
+		assert.NoError(t, err)
+		mockComplaintSLARepo.AssertExpectations(t)
+	})
+
+	t.Run("error when getting sla target fails", func(t *testing.T) {
+		mockComplaintProcessRepo := new(MockComplaintProcess)
+		mockComplaintRepo := new(MockComplaint)
+		mockComplaintSLARepo := new(MockComplaintSLA)
+		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, &MockUnitOfWork{mockComplaintRepo, mockComplaintProcessRepo, mockComplaintSLARepo})
+		dummyComplaintProcess := &entities.ComplaintProcess{
+			Message:     "Test Message",
+			Status:      "Verifikasi",
+			ComplaintID: "123",
+		}
+
+		mockComplaintRepo.On("GetStatus", "123").Return("Pending", nil)
+		mockComplaintProcessRepo.On("Create", mock.Anything).Return(nil)
+		mockComplaintRepo.On("UpdateStatus", "123", "Verifikasi").Return(nil)
+		mockComplaintSLARepo.On("GetTargetDays", "123", "Verifikasi").Return(0, errors.New("database error"))
+
+		_, err := usecase.Create(dummyComplaintProcess)
+
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+
+	t.Run("error when updating sla due date fails", func(t *testing.T) {
+		mockComplaintProcessRepo := new(MockComplaintProcess)
+		mockComplaintRepo := new(MockComplaint)
+		mockComplaintSLARepo := new(MockComplaintSLA)
+		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, &MockUnitOfWork{mockComplaintRepo, mockComplaintProcessRepo, mockComplaintSLARepo})
+		dummyComplaintProcess := &entities.ComplaintProcess{
+			Message:     "Test Message",
+			Status:      "Verifikasi",
+			ComplaintID: "123",
+		}
+
+		mockComplaintRepo.On("GetStatus", "123").Return("Pending", nil)
+		mockComplaintProcessRepo.On("Create", mock.Anything).Return(nil)
+		mockComplaintRepo.On("UpdateStatus", "123", "Verifikasi").Return(nil)
+		mockComplaintSLARepo.On("GetTargetDays", "123", "Verifikasi").Return(2, nil)
+		mockComplaintSLARepo.On("UpdateDueDate", "123", mock.Anything).Return(errors.New("database error"))
+
+		_, err := usecase.Create(dummyComplaintProcess)
+
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+
+	t.Run("error when updating complaint status fails", func(t *testing.T) {
+		mockComplaintProcessRepo := new(MockComplaintProcess)
+		mockComplaintRepo := new(MockComplaint)
+		mockComplaintSLARepo := new(MockComplaintSLA)
+		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, &MockUnitOfWork{mockComplaintRepo, mockComplaintProcessRepo, mockComplaintSLARepo})
+		dummyComplaintProcess := &entities.ComplaintProcess{
+			Message:     "Test Message",
+			Status:      "Verifikasi",
+			ComplaintID: "123",
+		}
+
+		mockComplaintRepo.On("GetStatus", mock.Anything).Return("Pending", nil)
+		mockComplaintProcessRepo.On("Create", mock.Anything).Return(nil)
+		mockComplaintRepo.On("UpdateStatus", "123", "Verifikasi").Return(constants.ErrComplaintNotFound)
+
+		result, err := usecase.Create(dummyComplaintProcess)
+
+		assert.Equal(t, entities.ComplaintProcess{}, result)
+		assert.Equal(t, constants.ErrComplaintNotFound, err)
 	})
 
 	t.Run("internal server error", func(t *testing.T) {
 		mockComplaintProcessRepo := new(MockComplaintProcess)
 		mockComplaintRepo := new(MockComplaint)
-		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, mockComplaintRepo)
+		mockComplaintSLARepo := new(MockComplaintSLA)
+		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, &MockUnitOfWork{mockComplaintRepo, mockComplaintProcessRepo, mockComplaintSLARepo})
 		dummyComplaintProcess := &entities.ComplaintProcess{
 			Message:     "Test Message",
 			Status:      "Pending",
@@ -166,7 +335,8 @@ func TestCreate(t *testing.T) {
 	t.Run("error when message is empty", func(t *testing.T) {
 		mockComplaintProcessRepo := new(MockComplaintProcess)
 		mockComplaintRepo := new(MockComplaint)
-		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, mockComplaintRepo)
+		mockComplaintSLARepo := new(MockComplaintSLA)
+		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, &MockUnitOfWork{mockComplaintRepo, mockComplaintProcessRepo, mockComplaintSLARepo})
 		dummyComplaintProcess := &entities.ComplaintProcess{
 			Message:     "",
 			Status:      "Pending",
@@ -183,7 +353,8 @@ func TestCreate(t *testing.T) {
 	t.Run("error when status is invalid", func(t *testing.T) {
 		mockComplaintProcessRepo := new(MockComplaintProcess)
 		mockComplaintRepo := new(MockComplaint)
-		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, mockComplaintRepo)
+		mockComplaintSLARepo := new(MockComplaintSLA)
+		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, &MockUnitOfWork{mockComplaintRepo, mockComplaintProcessRepo, mockComplaintSLARepo})
 		dummyComplaintProcess := &entities.ComplaintProcess{
 			Message:     "Test Message",
 			Status:      "Invalid",
@@ -197,10 +368,28 @@ func TestCreate(t *testing.T) {
 		assert.Equal(t, constants.ErrInvalidStatus, err)
 	})
 
+	t.Run("error when status is Digabung", func(t *testing.T) {
+		mockComplaintProcessRepo := new(MockComplaintProcess)
+		mockComplaintRepo := new(MockComplaint)
+		mockComplaintSLARepo := new(MockComplaintSLA)
+		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, &MockUnitOfWork{mockComplaintRepo, mockComplaintProcessRepo, mockComplaintSLARepo})
+		dummyComplaintProcess := &entities.ComplaintProcess{
+			Message:     "Test Message",
+			Status:      "Digabung",
+			ComplaintID: "123",
+		}
+
+		result, err := usecase.Create(dummyComplaintProcess)
+
+		assert.Equal(t, entities.ComplaintProcess{}, result)
+		assert.Equal(t, constants.ErrMergedStatusNotAllowed, err)
+	})
+
 	t.Run("error when status is Pending and complaint status is On Progress", func(t *testing.T) {
 		mockComplaintProcessRepo := new(MockComplaintProcess)
 		mockComplaintRepo := new(MockComplaint)
-		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, mockComplaintRepo)
+		mockComplaintSLARepo := new(MockComplaintSLA)
+		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, &MockUnitOfWork{mockComplaintRepo, mockComplaintProcessRepo, mockComplaintSLARepo})
 		dummyComplaintProcess := &entities.ComplaintProcess{
 			Message:     "Test Message",
 			Status:      "Pending",
@@ -219,7 +408,8 @@ func TestCreate(t *testing.T) {
 	t.Run("error when status is Pending and complaint status is Selesai", func(t *testing.T) {
 		mockComplaintProcessRepo := new(MockComplaintProcess)
 		mockComplaintRepo := new(MockComplaint)
-		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, mockComplaintRepo)
+		mockComplaintSLARepo := new(MockComplaintSLA)
+		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, &MockUnitOfWork{mockComplaintRepo, mockComplaintProcessRepo, mockComplaintSLARepo})
 		dummyComplaintProcess := &entities.ComplaintProcess{
 			Message:     "Test Message",
 			Status:      "Pending",
@@ -238,7 +428,8 @@ func TestCreate(t *testing.T) {
 	t.Run("error when complaint not found in repository", func(t *testing.T) {
 		mockComplaintProcessRepo := new(MockComplaintProcess)
 		mockComplaintRepo := new(MockComplaint)
-		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, mockComplaintRepo)
+		mockComplaintSLARepo := new(MockComplaintSLA)
+		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, &MockUnitOfWork{mockComplaintRepo, mockComplaintProcessRepo, mockComplaintSLARepo})
 		dummyComplaintProcess := &entities.ComplaintProcess{
 			Message:     "Test Message",
 			Status:      "Pending",
@@ -258,7 +449,8 @@ func TestCreate(t *testing.T) {
 	t.Run("error when internal server error occurs in repository", func(t *testing.T) {
 		mockComplaintProcessRepo := new(MockComplaintProcess)
 		mockComplaintRepo := new(MockComplaint)
-		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, mockComplaintRepo)
+		mockComplaintSLARepo := new(MockComplaintSLA)
+		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, &MockUnitOfWork{mockComplaintRepo, mockComplaintProcessRepo, mockComplaintSLARepo})
 		dummyComplaintProcess := &entities.ComplaintProcess{
 			Message:     "Test Message",
 			Status:      "Pending",
@@ -278,7 +470,8 @@ func TestCreate(t *testing.T) {
 	t.Run("error when status is Verifikasi and complaint status is Verifikasi", func(t *testing.T) {
 		mockComplaintProcessRepo := new(MockComplaintProcess)
 		mockComplaintRepo := new(MockComplaint)
-		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, mockComplaintRepo)
+		mockComplaintSLARepo := new(MockComplaintSLA)
+		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, &MockUnitOfWork{mockComplaintRepo, mockComplaintProcessRepo, mockComplaintSLARepo})
 		dummyComplaintProcess := &entities.ComplaintProcess{
 			Message:     "Test Message",
 			Status:      "Verifikasi",
@@ -297,7 +490,8 @@ func TestCreate(t *testing.T) {
 	t.Run("error when status is Verifikasi and complaint status is Ditolak", func(t *testing.T) {
 		mockComplaintProcessRepo := new(MockComplaintProcess)
 		mockComplaintRepo := new(MockComplaint)
-		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, mockComplaintRepo)
+		mockComplaintSLARepo := new(MockComplaintSLA)
+		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, &MockUnitOfWork{mockComplaintRepo, mockComplaintProcessRepo, mockComplaintSLARepo})
 		dummyComplaintProcess := &entities.ComplaintProcess{
 			Message:     "Test Message",
 			Status:      "Verifikasi",
@@ -316,7 +510,8 @@ func TestCreate(t *testing.T) {
 	t.Run("error when status is Verifikasi and complaint status is Selesai", func(t *testing.T) {
 		mockComplaintProcessRepo := new(MockComplaintProcess)
 		mockComplaintRepo := new(MockComplaint)
-		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, mockComplaintRepo)
+		mockComplaintSLARepo := new(MockComplaintSLA)
+		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, &MockUnitOfWork{mockComplaintRepo, mockComplaintProcessRepo, mockComplaintSLARepo})
 		dummyComplaintProcess := &entities.ComplaintProcess{
 			Message:     "Test Message",
 			Status:      "Verifikasi",
@@ -335,7 +530,8 @@ func TestCreate(t *testing.T) {
 	t.Run("error when status is Verifikasi and complaint status is On Progress", func(t *testing.T) {
 		mockComplaintProcessRepo := new(MockComplaintProcess)
 		mockComplaintRepo := new(MockComplaint)
-		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, mockComplaintRepo)
+		mockComplaintSLARepo := new(MockComplaintSLA)
+		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, &MockUnitOfWork{mockComplaintRepo, mockComplaintProcessRepo, mockComplaintSLARepo})
 		dummyComplaintProcess := &entities.ComplaintProcess{
 			Message:     "Test Message",
 			Status:      "Verifikasi",
@@ -354,7 +550,8 @@ func TestCreate(t *testing.T) {
 	t.Run("error when status is On Progress and complaint status is On Progress", func(t *testing.T) {
 		mockComplaintProcessRepo := new(MockComplaintProcess)
 		mockComplaintRepo := new(MockComplaint)
-		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, mockComplaintRepo)
+		mockComplaintSLARepo := new(MockComplaintSLA)
+		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, &MockUnitOfWork{mockComplaintRepo, mockComplaintProcessRepo, mockComplaintSLARepo})
 		dummyComplaintProcess := &entities.ComplaintProcess{
 			Message:     "Test Message",
 			Status:      "On Progress",
@@ -373,7 +570,8 @@ func TestCreate(t *testing.T) {
 	t.Run("error when status is On Progress and complaint status is Ditolak", func(t *testing.T) {
 		mockComplaintProcessRepo := new(MockComplaintProcess)
 		mockComplaintRepo := new(MockComplaint)
-		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, mockComplaintRepo)
+		mockComplaintSLARepo := new(MockComplaintSLA)
+		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, &MockUnitOfWork{mockComplaintRepo, mockComplaintProcessRepo, mockComplaintSLARepo})
 		dummyComplaintProcess := &entities.ComplaintProcess{
 			Message:     "Test Message",
 			Status:      "On Progress",
@@ -393,7 +591,8 @@ func TestCreate(t *testing.T) {
 	t.Run("error when status is On Progress and complaint status is Selesai", func(t *testing.T) {
 		mockComplaintProcessRepo := new(MockComplaintProcess)
 		mockComplaintRepo := new(MockComplaint)
-		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, mockComplaintRepo)
+		mockComplaintSLARepo := new(MockComplaintSLA)
+		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, &MockUnitOfWork{mockComplaintRepo, mockComplaintProcessRepo, mockComplaintSLARepo})
 		dummyComplaintProcess := &entities.ComplaintProcess{
 			Message:     "Test Message",
 			Status:      "On Progress",
@@ -412,7 +611,8 @@ func TestCreate(t *testing.T) {
 	t.Run("error when status is On Progress and complaint status is Pending", func(t *testing.T) {
 		mockComplaintProcessRepo := new(MockComplaintProcess)
 		mockComplaintRepo := new(MockComplaint)
-		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, mockComplaintRepo)
+		mockComplaintSLARepo := new(MockComplaintSLA)
+		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, &MockUnitOfWork{mockComplaintRepo, mockComplaintProcessRepo, mockComplaintSLARepo})
 		dummyComplaintProcess := &entities.ComplaintProcess{
 			Message:     "Test Message",
 			Status:      "On Progress",
@@ -431,7 +631,8 @@ func TestCreate(t *testing.T) {
 	t.Run("error when status is Selesai and complaint status is Selesai", func(t *testing.T) {
 		mockComplaintProcessRepo := new(MockComplaintProcess)
 		mockComplaintRepo := new(MockComplaint)
-		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, mockComplaintRepo)
+		mockComplaintSLARepo := new(MockComplaintSLA)
+		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, &MockUnitOfWork{mockComplaintRepo, mockComplaintProcessRepo, mockComplaintSLARepo})
 		dummyComplaintProcess := &entities.ComplaintProcess{
 			Message:     "Test Message",
 			Status:      "Selesai",
@@ -450,7 +651,8 @@ func TestCreate(t *testing.T) {
 	t.Run("error when status is Selesai and complaint status is Ditolak", func(t *testing.T) {
 		mockComplaintProcessRepo := new(MockComplaintProcess)
 		mockComplaintRepo := new(MockComplaint)
-		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, mockComplaintRepo)
+		mockComplaintSLARepo := new(MockComplaintSLA)
+		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, &MockUnitOfWork{mockComplaintRepo, mockComplaintProcessRepo, mockComplaintSLARepo})
 		dummyComplaintProcess := &entities.ComplaintProcess{
 			Message:     "Test Message",
 			Status:      "Selesai",
@@ -469,7 +671,8 @@ func TestCreate(t *testing.T) {
 	t.Run("error when status is Selesai and complaint status is Pending", func(t *testing.T) {
 		mockComplaintProcessRepo := new(MockComplaintProcess)
 		mockComplaintRepo := new(MockComplaint)
-		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, mockComplaintRepo)
+		mockComplaintSLARepo := new(MockComplaintSLA)
+		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, &MockUnitOfWork{mockComplaintRepo, mockComplaintProcessRepo, mockComplaintSLARepo})
 		dummyComplaintProcess := &entities.ComplaintProcess{
 			Message:     "Test Message",
 			Status:      "Selesai",
@@ -488,7 +691,8 @@ func TestCreate(t *testing.T) {
 	t.Run("error when status is Selesai and complaint status is Verifikasi", func(t *testing.T) {
 		mockComplaintProcessRepo := new(MockComplaintProcess)
 		mockComplaintRepo := new(MockComplaint)
-		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, mockComplaintRepo)
+		mockComplaintSLARepo := new(MockComplaintSLA)
+		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, &MockUnitOfWork{mockComplaintRepo, mockComplaintProcessRepo, mockComplaintSLARepo})
 		dummyComplaintProcess := &entities.ComplaintProcess{
 			Message:     "Test Message",
 			Status:      "Selesai",
@@ -507,7 +711,8 @@ func TestCreate(t *testing.T) {
 	t.Run("error when status is Ditolak and complaint status is Ditolak", func(t *testing.T) {
 		mockComplaintProcessRepo := new(MockComplaintProcess)
 		mockComplaintRepo := new(MockComplaint)
-		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, mockComplaintRepo)
+		mockComplaintSLARepo := new(MockComplaintSLA)
+		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, &MockUnitOfWork{mockComplaintRepo, mockComplaintProcessRepo, mockComplaintSLARepo})
 		dummyComplaintProcess := &entities.ComplaintProcess{
 			Message:     "Test Message",
 			Status:      "Ditolak",
@@ -526,7 +731,8 @@ func TestCreate(t *testing.T) {
 	t.Run("error when status is Ditolak and complaint status is Selesai", func(t *testing.T) {
 		mockComplaintProcessRepo := new(MockComplaintProcess)
 		mockComplaintRepo := new(MockComplaint)
-		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, mockComplaintRepo)
+		mockComplaintSLARepo := new(MockComplaintSLA)
+		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, &MockUnitOfWork{mockComplaintRepo, mockComplaintProcessRepo, mockComplaintSLARepo})
 		dummyComplaintProcess := &entities.ComplaintProcess{
 			Message:     "Test Message",
 			Status:      "Ditolak",
@@ -545,7 +751,8 @@ func TestCreate(t *testing.T) {
 	t.Run("error when status is Ditolak and complaint status is On Progress", func(t *testing.T) {
 		mockComplaintProcessRepo := new(MockComplaintProcess)
 		mockComplaintRepo := new(MockComplaint)
-		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, mockComplaintRepo)
+		mockComplaintSLARepo := new(MockComplaintSLA)
+		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, &MockUnitOfWork{mockComplaintRepo, mockComplaintProcessRepo, mockComplaintSLARepo})
 		dummyComplaintProcess := &entities.ComplaintProcess{
 			Message:     "Test Message",
 			Status:      "Ditolak",
@@ -564,7 +771,8 @@ func TestCreate(t *testing.T) {
 	t.Run("error when status is Ditolak and complaint status is Verifikasi", func(t *testing.T) {
 		mockComplaintProcessRepo := new(MockComplaintProcess)
 		mockComplaintRepo := new(MockComplaint)
-		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, mockComplaintRepo)
+		mockComplaintSLARepo := new(MockComplaintSLA)
+		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, &MockUnitOfWork{mockComplaintRepo, mockComplaintProcessRepo, mockComplaintSLARepo})
 		dummyComplaintProcess := &entities.ComplaintProcess{
 			Message:     "Test Message",
 			Status:      "Ditolak",
@@ -585,7 +793,8 @@ func TestComplaintProcessUseCase_GetByComplaintID(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		mockComplaintProcessRepo := new(MockComplaintProcess)
 		mockComplaintRepo := new(MockComplaint)
-		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, mockComplaintRepo)
+		mockComplaintSLARepo := new(MockComplaintSLA)
+		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, &MockUnitOfWork{mockComplaintRepo, mockComplaintProcessRepo, mockComplaintSLARepo})
 
 		mockComplaintProcessRepo.On("GetByComplaintID", mock.Anything).Return([]entities.ComplaintProcess{}, nil)
 
@@ -598,7 +807,8 @@ func TestComplaintProcessUseCase_GetByComplaintID(t *testing.T) {
 	t.Run("internal server error", func(t *testing.T) {
 		mockComplaintProcessRepo := new(MockComplaintProcess)
 		mockComplaintRepo := new(MockComplaint)
-		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, mockComplaintRepo)
+		mockComplaintSLARepo := new(MockComplaintSLA)
+		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, &MockUnitOfWork{mockComplaintRepo, mockComplaintProcessRepo, mockComplaintSLARepo})
 
 		mockComplaintProcessRepo.On("GetByComplaintID", mock.Anything).Return([]entities.ComplaintProcess{}, constants.ErrInternalServerError)
 
@@ -612,7 +822,8 @@ func TestComplaintProcessUseCase_GetByComplaintID(t *testing.T) {
 	t.Run("complaint process not found", func(t *testing.T) {
 		mockComplaintProcessRepo := new(MockComplaintProcess)
 		mockComplaintRepo := new(MockComplaint)
-		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, mockComplaintRepo)
+		mockComplaintSLARepo := new(MockComplaintSLA)
+		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, &MockUnitOfWork{mockComplaintRepo, mockComplaintProcessRepo, mockComplaintSLARepo})
 
 		mockComplaintProcessRepo.On("GetByComplaintID", mock.Anything).Return([]entities.ComplaintProcess{}, constants.ErrComplaintProcessNotFound)
 
@@ -629,7 +840,8 @@ func TestComplaintProcessUseCase_Update(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		mockComplaintProcessRepo := new(MockComplaintProcess)
 		mockComplaintRepo := new(MockComplaint)
-		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, mockComplaintRepo)
+		mockComplaintSLARepo := new(MockComplaintSLA)
+		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, &MockUnitOfWork{mockComplaintRepo, mockComplaintProcessRepo, mockComplaintSLARepo})
 
 		mockComplaintProcessRepo.On("Update", mock.Anything).Return(nil)
 
@@ -647,7 +859,8 @@ func TestComplaintProcessUseCase_Update(t *testing.T) {
 	t.Run("error when message is empty", func(t *testing.T) {
 		mockComplaintProcessRepo := new(MockComplaintProcess)
 		mockComplaintRepo := new(MockComplaint)
-		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, mockComplaintRepo)
+		mockComplaintSLARepo := new(MockComplaintSLA)
+		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, &MockUnitOfWork{mockComplaintRepo, mockComplaintProcessRepo, mockComplaintSLARepo})
 
 		result, err := usecase.Update(&entities.ComplaintProcess{
 			ID:          1,
@@ -664,7 +877,8 @@ func TestComplaintProcessUseCase_Update(t *testing.T) {
 	t.Run("error when repository update fails", func(t *testing.T) {
 		mockComplaintProcessRepo := new(MockComplaintProcess)
 		mockComplaintRepo := new(MockComplaint)
-		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, mockComplaintRepo)
+		mockComplaintSLARepo := new(MockComplaintSLA)
+		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, &MockUnitOfWork{mockComplaintRepo, mockComplaintProcessRepo, mockComplaintSLARepo})
 
 		mockComplaintProcessRepo.On("Update", mock.Anything).Return(errors.New("update error"))
 
@@ -707,9 +921,11 @@ func TestComplaintProcessUseCase_Delete(t *testing.T) {
 
 	t.Run("error when repository delete fails", func(t *testing.T) {
 		mockComplaintProcessRepo := new(MockComplaintProcess)
-		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, nil)
+		mockComplaintRepo := new(MockComplaint)
+		mockComplaintSLARepo := new(MockComplaintSLA)
+		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, &MockUnitOfWork{mockComplaintRepo, mockComplaintProcessRepo, mockComplaintSLARepo})
 
-		mockComplaintProcessRepo.On("Delete", mock.Anything, mock.Anything).Return("", errors.New("delete error"))
+		mockComplaintProcessRepo.On("Delete", mock.Anything, mock.Anything).Return(errors.New("delete error"))
 
 		status, err := usecase.Delete("123", 1)
 
@@ -718,51 +934,72 @@ func TestComplaintProcessUseCase_Delete(t *testing.T) {
 		assert.Equal(t, "delete error", err.Error())
 	})
 
-	t.Run("success with status Verifikasi", func(t *testing.T) {
+	t.Run("error when getting latest process fails", func(t *testing.T) {
 		mockComplaintProcessRepo := new(MockComplaintProcess)
-		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, nil)
+		mockComplaintRepo := new(MockComplaint)
+		mockComplaintSLARepo := new(MockComplaintSLA)
+		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, &MockUnitOfWork{mockComplaintRepo, mockComplaintProcessRepo, mockComplaintSLARepo})
 
-		mockComplaintProcessRepo.On("Delete", mock.Anything, mock.Anything).Return("Verifikasi", nil)
+		mockComplaintProcessRepo.On("Delete", "123", 1).Return(nil)
+		mockComplaintProcessRepo.On("GetLatestByComplaintID", "123").Return(entities.ComplaintProcess{}, constants.ErrInternalServerError)
 
 		status, err := usecase.Delete("123", 1)
 
-		assert.NoError(t, err)
-		assert.Equal(t, "Pending", status)
+		assert.Equal(t, "", status)
+		assert.Equal(t, constants.ErrInternalServerError, err)
 	})
 
-	t.Run("success with status On Progress", func(t *testing.T) {
+	t.Run("error when updating complaint status fails", func(t *testing.T) {
 		mockComplaintProcessRepo := new(MockComplaintProcess)
-		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, nil)
+		mockComplaintRepo := new(MockComplaint)
+		mockComplaintSLARepo := new(MockComplaintSLA)
+		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, &MockUnitOfWork{mockComplaintRepo, mockComplaintProcessRepo, mockComplaintSLARepo})
 
-		mockComplaintProcessRepo.On("Delete", mock.Anything, mock.Anything).Return("On Progress", nil)
+		mockComplaintProcessRepo.On("Delete", "123", 1).Return(nil)
+		mockComplaintProcessRepo.On("GetLatestByComplaintID", "123").Return(entities.ComplaintProcess{Status: "Verifikasi"}, nil)
+		mockComplaintRepo.On("UpdateStatus", "123", "Verifikasi").Return(constants.ErrInternalServerError)
 
 		status, err := usecase.Delete("123", 1)
 
-		assert.NoError(t, err)
-		assert.Equal(t, "Verifikasi", status)
+		assert.Equal(t, "", status)
+		assert.Equal(t, constants.ErrInternalServerError, err)
 	})
 
-	t.Run("success with status Selesai", func(t *testing.T) {
+	t.Run("success reverting to previous process status", func(t *testing.T) {
 		mockComplaintProcessRepo := new(MockComplaintProcess)
-		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, nil)
+		mockComplaintRepo := new(MockComplaint)
+		mockComplaintSLARepo := new(MockComplaintSLA)
+		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, &MockUnitOfWork{mockComplaintRepo, mockComplaintProcessRepo, mockComplaintSLARepo})
 
-		mockComplaintProcessRepo.On("Delete", mock.Anything, mock.Anything).Return("Selesai", nil)
+		mockComplaintProcessRepo.On("Delete", "123", 1).Return(nil)
+		mockComplaintProcessRepo.On("GetLatestByComplaintID", "123").Return(entities.ComplaintProcess{Status: "On Progress"}, nil)
+		mockComplaintRepo.On("UpdateStatus", "123", "On Progress").Return(nil)
+		mockComplaintSLARepo.On("GetTargetDays", "123", "On Progress").Return(14, nil)
+		mockComplaintSLARepo.On("UpdateDueDate", "123", mock.Anything).Return(nil)
 
 		status, err := usecase.Delete("123", 1)
 
 		assert.NoError(t, err)
 		assert.Equal(t, "On Progress", status)
+		mockComplaintRepo.AssertExpectations(t)
 	})
 
-	t.Run("success with status Ditolak", func(t *testing.T) {
+	t.Run("success reverting to initial status when no process left", func(t *testing.T) {
 		mockComplaintProcessRepo := new(MockComplaintProcess)
-		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, nil)
+		mockComplaintRepo := new(MockComplaint)
+		mockComplaintSLARepo := new(MockComplaintSLA)
+		usecase := NewComplaintProcessUseCase(mockComplaintProcessRepo, &MockUnitOfWork{mockComplaintRepo, mockComplaintProcessRepo, mockComplaintSLARepo})
 
-		mockComplaintProcessRepo.On("Delete", mock.Anything, mock.Anything).Return("Ditolak", nil)
+		mockComplaintProcessRepo.On("Delete", "123", 1).Return(nil)
+		mockComplaintProcessRepo.On("GetLatestByComplaintID", "123").Return(entities.ComplaintProcess{}, constants.ErrComplaintProcessNotFound)
+		mockComplaintRepo.On("UpdateStatus", "123", "Pending").Return(nil)
+		mockComplaintSLARepo.On("GetTargetDays", "123", "Pending").Return(0, nil)
+		mockComplaintSLARepo.On("UpdateDueDate", "123", (*time.Time)(nil)).Return(nil)
 
 		status, err := usecase.Delete("123", 1)
 
 		assert.NoError(t, err)
 		assert.Equal(t, "Pending", status)
+		mockComplaintRepo.AssertExpectations(t)
 	})
 }
@@ -0,0 +1,120 @@
+package complaint_sla
+
+import (
+	"e-complaint-api/constants"
+	"e-complaint-api/entities"
+	"e-complaint-api/workflow"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+type ComplaintSLAUseCase struct {
+	repository entities.ComplaintSLARepositoryInterface
+	unitOfWork entities.UnitOfWorkInterface
+}
+
+func NewComplaintSLAUseCase(repository entities.ComplaintSLARepositoryInterface, unitOfWork entities.UnitOfWorkInterface) *ComplaintSLAUseCase {
+	return &ComplaintSLAUseCase{
+		repository: repository,
+		unitOfWork: unitOfWork,
+	}
+}
+
+func (u *ComplaintSLAUseCase) GetAll() ([]entities.ComplaintSLA, error) {
+	complaintSLAs, err := u.repository.GetAll()
+	if err != nil {
+		return nil, constants.ErrInternalServerError
+	}
+
+	return complaintSLAs, nil
+}
+
+func (u *ComplaintSLAUseCase) Create(complaintSLA *entities.ComplaintSLA) (entities.ComplaintSLA, error) {
+	if complaintSLA.CategoryID == 0 || complaintSLA.Status == "" {
+		return entities.ComplaintSLA{}, constants.ErrAllFieldsMustBeFilled
+	}
+
+	if !workflow.Complaint.IsValid(complaintSLA.Status) {
+		return entities.ComplaintSLA{}, constants.ErrInvalidStatus
+	}
+
+	if complaintSLA.TargetDays <= 0 {
+		return entities.ComplaintSLA{}, constants.ErrTargetDaysMustBePositive
+	}
+
+	err := u.repository.Create(complaintSLA)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "Error 1062") {
+			return entities.ComplaintSLA{}, constants.ErrComplaintSLAAlreadyExists
+		} else if strings.HasSuffix(err.Error(), "REFERENCES `categories` (`id`))") {
+			return entities.ComplaintSLA{}, constants.ErrCategoryNotFound
+		}
+		return entities.ComplaintSLA{}, constants.ErrInternalServerError
+	}
+
+	return *complaintSLA, nil
+}
+
+func (u *ComplaintSLAUseCase) Update(complaintSLA *entities.ComplaintSLA) (entities.ComplaintSLA, error) {
+	if complaintSLA.TargetDays <= 0 {
+		return entities.ComplaintSLA{}, constants.ErrTargetDaysMustBePositive
+	}
+
+	err := u.repository.Update(complaintSLA)
+	if err != nil {
+		if errors.Is(err, constants.ErrComplaintSLANotFound) {
+			return entities.ComplaintSLA{}, err
+		}
+		return entities.ComplaintSLA{}, constants.ErrInternalServerError
+	}
+
+	return *complaintSLA, nil
+}
+
+func (u *ComplaintSLAUseCase) Delete(id int) error {
+	err := u.repository.Delete(id)
+	if err != nil {
+		if errors.Is(err, constants.ErrComplaintSLANotFound) {
+			return err
+		}
+		return constants.ErrInternalServerError
+	}
+
+	return nil
+}
+
+// EscalateOverdue records an escalation process for every complaint that passed the
+// deadline of its current status and has not been escalated yet. A complaint that
+// fails to be escalated is logged and retried on the next run, the others are still
+// escalated.
+func (u *ComplaintSLAUseCase) EscalateOverdue() error {
+	now := time.Now()
+	complaints, err := u.repository.GetOverdue(now)
+	if err != nil {
+		return constants.ErrInternalServerError
+	}
+
+	for _, complaint := range complaints {
+		err := u.unitOfWork.Do(func(repositories entities.UnitOfWorkRepositories) error {
+			err := repositories.ComplaintProcess.Create(&entities.ComplaintProcess{
+				ComplaintID: complaint.ID,
+				Status:      complaint.Status,
+				Message:     fmt.Sprintf("Aduan telah melewati batas waktu penanganan untuk status %s dan dieskalasi ke admin kami", complaint.Status),
+				Escalation:  true,
+			})
+			if err != nil {
+				return err
+			}
+
+			return repositories.ComplaintSLA.MarkEscalated(complaint.ID, now)
+		})
+		if err != nil {
+			log.Printf("complaint %s: escalate failed: %v", complaint.ID, err)
+		}
+	}
+
+	return nil
+}
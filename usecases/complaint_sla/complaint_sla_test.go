@@ -0,0 +1,332 @@
+package complaint_sla
+
+import (
+	"e-complaint-api/constants"
+	"e-complaint-api/entities"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockComplaintSLARepo struct {
+	mock.Mock
+}
+
+func (m *MockComplaintSLARepo) GetAll() ([]entities.ComplaintSLA, error) {
+	args := m.Called()
+	return args.Get(0).([]entities.ComplaintSLA), args.Error(1)
+}
+
+func (m *MockComplaintSLARepo) Create(complaintSLA *entities.ComplaintSLA) error {
+	args := m.Called(complaintSLA)
+	return args.Error(0)
+}
+
+func (m *MockComplaintSLARepo) Update(complaintSLA *entities.ComplaintSLA) error {
+	args := m.Called(complaintSLA)
+	return args.Error(0)
+}
+
+func (m *MockComplaintSLARepo) Delete(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockComplaintSLARepo) GetTargetDays(complaintID string, status string) (int, error) {
+	args := m.Called(complaintID, status)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockComplaintSLARepo) UpdateDueDate(complaintID string, dueAt *time.Time) error {
+	args := m.Called(complaintID, dueAt)
+	return args.Error(0)
+}
+
+func (m *MockComplaintSLARepo) GetOverdue(now time.Time) ([]entities.Complaint, error) {
+	args := m.Called(now)
+	return args.Get(0).([]entities.Complaint), args.Error(1)
+}
+
+func (m *MockComplaintSLARepo) MarkEscalated(complaintID string, escalatedAt time.Time) error {
+	args := m.Called(complaintID, escalatedAt)
+	return args.Error(0)
+}
+
+type MockComplaintProcessRepo struct {
+	mock.Mock
+}
+
+func (m *MockComplaintProcessRepo) Create(complaintProcess *entities.ComplaintProcess) error {
+	args := m.Called(complaintProcess)
+	return args.Error(0)
+}
+
+func (m *MockComplaintProcessRepo) GetByComplaintID(complaintID string) ([]entities.ComplaintProcess, error) {
+	args := m.Called(complaintID)
+	return args.Get(0).([]entities.ComplaintProcess), args.Error(1)
+}
+
+func (m *MockComplaintProcessRepo) GetLatestByComplaintID(complaintID string) (entities.ComplaintProcess, error) {
+	args := m.Called(complaintID)
+	return args.Get(0).(entities.ComplaintProcess), args.Error(1)
+}
+
+func (m *MockComplaintProcessRepo) Update(complaintProcess *entities.ComplaintProcess) error {
+	args := m.Called(complaintProcess)
+	return args.Error(0)
+}
+
+func (m *MockComplaintProcessRepo) Delete(complaintID string, complaintProcessID int) error {
+	args := m.Called(complaintID, complaintProcessID)
+	return args.Error(0)
+}
+
+type MockUnitOfWork struct {
+	complaintProcessRepo *MockComplaintProcessRepo
+	complaintSLARepo     *MockComplaintSLARepo
+}
+
+func (m *MockUnitOfWork) Do(fn func(repositories entities.UnitOfWorkRepositories) error) error {
+	return fn(entities.UnitOfWorkRepositories{
+		ComplaintProcess: m.complaintProcessRepo,
+		ComplaintSLA:     m.complaintSLARepo,
+	})
+}
+
+func TestGetAll(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockRepo := new(MockComplaintSLARepo)
+		usecase := NewComplaintSLAUseCase(mockRepo, nil)
+
+		complaintSLAs := []entities.ComplaintSLA{{ID: 1, CategoryID: 1, Status: "Pending", TargetDays: 2}}
+		mockRepo.On("GetAll").Return(complaintSLAs, nil)
+
+		result, err := usecase.GetAll()
+
+		assert.NoError(t, err)
+		assert.Equal(t, complaintSLAs, result)
+	})
+
+	t.Run("internal server error", func(t *testing.T) {
+		mockRepo := new(MockComplaintSLARepo)
+		usecase := NewComplaintSLAUseCase(mockRepo, nil)
+
+		mockRepo.On("GetAll").Return([]entities.ComplaintSLA{}, errors.New("database error"))
+
+		result, err := usecase.GetAll()
+
+		assert.Nil(t, result)
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+}
+
+func TestCreate(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockRepo := new(MockComplaintSLARepo)
+		usecase := NewComplaintSLAUseCase(mockRepo, nil)
+
+		complaintSLA := &entities.ComplaintSLA{CategoryID: 1, Status: "Pending", TargetDays: 2}
+		mockRepo.On("Create", complaintSLA).Return(nil)
+
+		result, err := usecase.Create(complaintSLA)
+
+		assert.NoError(t, err)
+		assert.Equal(t, *complaintSLA, result)
+	})
+
+	t.Run("error when fields are empty", func(t *testing.T) {
+		mockRepo := new(MockComplaintSLARepo)
+		usecase := NewComplaintSLAUseCase(mockRepo, nil)
+
+		_, err := usecase.Create(&entities.ComplaintSLA{Status: "Pending", TargetDays: 2})
+
+		assert.Equal(t, constants.ErrAllFieldsMustBeFilled, err)
+	})
+
+	t.Run("error when status is invalid", func(t *testing.T) {
+		mockRepo := new(MockComplaintSLARepo)
+		usecase := NewComplaintSLAUseCase(mockRepo, nil)
+
+		_, err := usecase.Create(&entities.ComplaintSLA{CategoryID: 1, Status: "Invalid", TargetDays: 2})
+
+		assert.Equal(t, constants.ErrInvalidStatus, err)
+	})
+
+	t.Run("error when target days is not positive", func(t *testing.T) {
+		mockRepo := new(MockComplaintSLARepo)
+		usecase := NewComplaintSLAUseCase(mockRepo, nil)
+
+		_, err := usecase.Create(&entities.ComplaintSLA{CategoryID: 1, Status: "Pending", TargetDays: 0})
+
+		assert.Equal(t, constants.ErrTargetDaysMustBePositive, err)
+	})
+
+	t.Run("error when sla already exists", func(t *testing.T) {
+		mockRepo := new(MockComplaintSLARepo)
+		usecase := NewComplaintSLAUseCase(mockRepo, nil)
+
+		mockRepo.On("Create", mock.Anything).Return(errors.New("Error 1062 (23000): Duplicate entry '1-Pending'"))
+
+		_, err := usecase.Create(&entities.ComplaintSLA{CategoryID: 1, Status: "Pending", TargetDays: 2})
+
+		assert.Equal(t, constants.ErrComplaintSLAAlreadyExists, err)
+	})
+
+	t.Run("error when category not found", func(t *testing.T) {
+		mockRepo := new(MockComplaintSLARepo)
+		usecase := NewComplaintSLAUseCase(mockRepo, nil)
+
+		mockRepo.On("Create", mock.Anything).Return(errors.New("FOREIGN KEY (`category_id`) REFERENCES `categories` (`id`))"))
+
+		_, err := usecase.Create(&entities.ComplaintSLA{CategoryID: 99, Status: "Pending", TargetDays: 2})
+
+		assert.Equal(t, constants.ErrCategoryNotFound, err)
+	})
+
+	t.Run("internal server error", func(t *testing.T) {
+		mockRepo := new(MockComplaintSLARepo)
+		usecase := NewComplaintSLAUseCase(mockRepo, nil)
+
+		mockRepo.On("Create", mock.Anything).Return(errors.New("database error"))
+
+		_, err := usecase.Create(&entities.ComplaintSLA{CategoryID: 1, Status: "Pending", TargetDays: 2})
+
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockRepo := new(MockComplaintSLARepo)
+		usecase := NewComplaintSLAUseCase(mockRepo, nil)
+
+		complaintSLA := &entities.ComplaintSLA{ID: 1, TargetDays: 3}
+		mockRepo.On("Update", complaintSLA).Return(nil)
+
+		result, err := usecase.Update(complaintSLA)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, result.TargetDays)
+	})
+
+	t.Run("error when target days is not positive", func(t *testing.T) {
+		mockRepo := new(MockComplaintSLARepo)
+		usecase := NewComplaintSLAUseCase(mockRepo, nil)
+
+		_, err := usecase.Update(&entities.ComplaintSLA{ID: 1, TargetDays: -1})
+
+		assert.Equal(t, constants.ErrTargetDaysMustBePositive, err)
+	})
+
+	t.Run("error when sla not found", func(t *testing.T) {
+		mockRepo := new(MockComplaintSLARepo)
+		usecase := NewComplaintSLAUseCase(mockRepo, nil)
+
+		mockRepo.On("Update", mock.Anything).Return(constants.ErrComplaintSLANotFound)
+
+		_, err := usecase.Update(&entities.ComplaintSLA{ID: 1, TargetDays: 3})
+
+		assert.Equal(t, constants.ErrComplaintSLANotFound, err)
+	})
+
+	t.Run("internal server error", func(t *testing.T) {
+		mockRepo := new(MockComplaintSLARepo)
+		usecase := NewComplaintSLAUseCase(mockRepo, nil)
+
+		mockRepo.On("Update", mock.Anything).Return(errors.New("database error"))
+
+		_, err := usecase.Update(&entities.ComplaintSLA{ID: 1, TargetDays: 3})
+
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+}
+
+func TestDelete(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockRepo := new(MockComplaintSLARepo)
+		usecase := NewComplaintSLAUseCase(mockRepo, nil)
+
+		mockRepo.On("Delete", 1).Return(nil)
+
+		err := usecase.Delete(1)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("error when sla not found", func(t *testing.T) {
+		mockRepo := new(MockComplaintSLARepo)
+		usecase := NewComplaintSLAUseCase(mockRepo, nil)
+
+		mockRepo.On("Delete", 1).Return(constants.ErrComplaintSLANotFound)
+
+		err := usecase.Delete(1)
+
+		assert.Equal(t, constants.ErrComplaintSLANotFound, err)
+	})
+
+	t.Run("internal server error", func(t *testing.T) {
+		mockRepo := new(MockComplaintSLARepo)
+		usecase := NewComplaintSLAUseCase(mockRepo, nil)
+
+		mockRepo.On("Delete", 1).Return(errors.New("database error"))
+
+		err := usecase.Delete(1)
+
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+}
+
+func TestEscalateOverdue(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockRepo := new(MockComplaintSLARepo)
+		mockProcessRepo := new(MockComplaintProcessRepo)
+		usecase := NewComplaintSLAUseCase(mockRepo, &MockUnitOfWork{mockProcessRepo, mockRepo})
+
+		mockRepo.On("GetOverdue", mock.Anything).Return([]entities.Complaint{{ID: "C-1", Status: "Verifikasi"}}, nil)
+		mockProcessRepo.On("Create", mock.MatchedBy(func(complaintProcess *entities.ComplaintProcess) bool {
+			return complaintProcess.ComplaintID == "C-1" && complaintProcess.Escalation && complaintProcess.AdminID == 0 && complaintProcess.Status == "Verifikasi"
+		})).Return(nil)
+		mockRepo.On("MarkEscalated", "C-1", mock.Anything).Return(nil)
+
+		err := usecase.EscalateOverdue()
+
+		assert.NoError(t, err)
+		mockProcessRepo.AssertExpectations(t)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("error when getting overdue complaints fails", func(t *testing.T) {
+		mockRepo := new(MockComplaintSLARepo)
+		usecase := NewComplaintSLAUseCase(mockRepo, nil)
+
+		mockRepo.On("GetOverdue", mock.Anything).Return([]entities.Complaint{}, errors.New("database error"))
+
+		err := usecase.EscalateOverdue()
+
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+
+	t.Run("failed escalation does not stop the others", func(t *testing.T) {
+		mockRepo := new(MockComplaintSLARepo)
+		mockProcessRepo := new(MockComplaintProcessRepo)
+		usecase := NewComplaintSLAUseCase(mockRepo, &MockUnitOfWork{mockProcessRepo, mockRepo})
+
+		mockRepo.On("GetOverdue", mock.Anything).Return([]entities.Complaint{{ID: "C-1", Status: "Verifikasi"}, {ID: "C-2", Status: "On Progress"}}, nil)
+		mockProcessRepo.On("Create", mock.MatchedBy(func(complaintProcess *entities.ComplaintProcess) bool {
+			return complaintProcess.ComplaintID == "C-1"
+		})).Return(errors.New("database error"))
+		mockProcessRepo.On("Create", mock.MatchedBy(func(complaintProcess *entities.ComplaintProcess) bool {
+			return complaintProcess.ComplaintID == "C-2"
+		})).Return(nil)
+		mockRepo.On("MarkEscalated", "C-2", mock.Anything).Return(nil)
+
+		err := usecase.EscalateOverdue()
+
+		assert.NoError(t, err)
+		mockRepo.AssertNotCalled(t, "MarkEscalated", "C-1", mock.Anything)
+		mockRepo.AssertExpectations(t)
+	})
+}
@@ -10,6 +10,7 @@ type DashboardRepoInterface interface {
 	GetComplaintsByStatus() (map[string]int64, error)
 	GetUsersByYearAndMonth() (map[string][]response.MonthData, error)
 	GetLatestComplaints(limit int) ([]entities.Complaint, error)
+	GetSLAByRegency() ([]response.SLAByRegency, error)
 }
 
 type DashboardUsecase struct {
@@ -35,3 +36,7 @@ func (uc *DashboardUsecase) GetUsersByYearAndMonth() (map[string][]response.Mont
 func (uc *DashboardUsecase) GetLatestComplaints(limit int) ([]entities.Complaint, error) {
 	return uc.DashboardRepo.GetLatestComplaints(limit)
 }
+
+func (uc *DashboardUsecase) GetSLAByRegency() ([]response.SLAByRegency, error) {
+	return uc.DashboardRepo.GetSLAByRegency()
+}
@@ -34,6 +34,11 @@ func (m *MockDashboardRepo) GetLatestComplaints(limit int) ([]entities.Complaint
 	return args.Get(0).([]entities.Complaint), args.Error(1)
 }
 
+func (m *MockDashboardRepo) GetSLAByRegency() ([]response.SLAByRegency, error) {
+	args := m.Called()
+	return args.Get(0).([]response.SLAByRegency), args.Error(1)
+}
+
 func TestDashboardUsecase_GetTotalComplaints(t *testing.T) {
 	mockRepo := new(MockDashboardRepo)
 	mockRepo.On("GetTotalComplaints").Return(int64(10), nil)
@@ -77,3 +82,14 @@ func TestDashboardUsecase_GetLatestComplaints(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, []entities.Complaint{{ID: "1"}, {ID: "2"}, {ID: "3"}, {ID: "4"}, {ID: "5"}}, complaints)
 }
+
+func TestDashboardUsecase_GetSLAByRegency(t *testing.T) {
+	mockRepo := new(MockDashboardRepo)
+	mockRepo.On("GetSLAByRegency").Return([]response.SLAByRegency{{Regency: "KOTA SERANG", OnTime: 8, Breached: 2}}, nil)
+
+	uc := dashboard.NewDashboardUseCase(mockRepo)
+	slaByRegency, err := uc.GetSLAByRegency()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []response.SLAByRegency{{Regency: "KOTA SERANG", OnTime: 8, Breached: 2}}, slaByRegency)
+}
@@ -1,22 +1,25 @@
 package discussion
 
 import (
+	"context"
 	"e-complaint-api/constants"
+	"e-complaint-api/cursor"
 	"e-complaint-api/entities"
+	"e-complaint-api/llm"
 	"strconv"
 )
 
 type DiscussionUseCase struct {
 	discussionRepo entities.DiscussionRepositoryInterface
 	faqRepo        entities.FaqRepositoryInterface
-	openAIAPI      entities.DiscussionOpenAIAPIInterface
+	llmProvider    entities.LLMProviderInterface
 }
 
-func NewDiscussionUseCase(discussionRepo entities.DiscussionRepositoryInterface, faqRepo entities.FaqRepositoryInterface, openAIAPI entities.DiscussionOpenAIAPIInterface) *DiscussionUseCase {
+func NewDiscussionUseCase(discussionRepo entities.DiscussionRepositoryInterface, faqRepo entities.FaqRepositoryInterface, llmProvider entities.LLMProviderInterface) *DiscussionUseCase {
 	return &DiscussionUseCase{
 		discussionRepo: discussionRepo,
 		faqRepo:        faqRepo,
-		openAIAPI:      openAIAPI,
+		llmProvider:    llmProvider,
 	}
 }
 
@@ -51,6 +54,27 @@ func (u *DiscussionUseCase) GetByComplaintID(complaintID string) (*[]entities.Di
 	return discussions, nil
 }
 
+// GetByComplaintIDCursor returns the page of discussions of a complaint after the one the
+// cursor points at and the cursor of the next page, which is empty on the last page.
+func (u *DiscussionUseCase) GetByComplaintIDCursor(complaintID string, limit int, token string) ([]entities.Discussion, string, error) {
+	afterID, err := cursor.DecodeInt(token, "id ASC")
+	if err != nil {
+		return nil, "", err
+	}
+
+	limit = cursor.Limit(limit)
+	discussions, err := u.discussionRepo.GetByComplaintIDAfter(complaintID, limit+1, afterID)
+	if err != nil {
+		return nil, "", constants.ErrInternalServerError
+	}
+
+	discussions, next := cursor.Page(discussions, limit, "id ASC", func(discussion entities.Discussion) string {
+		return strconv.Itoa(discussion.ID)
+	})
+
+	return discussions, next, nil
+}
+
 func (u *DiscussionUseCase) Update(discussion *entities.Discussion) error {
 	if discussion.Comment == "" {
 		return constants.ErrCommentCannotBeEmpty
@@ -72,7 +96,7 @@ func (u *DiscussionUseCase) Delete(id int) error {
 	return nil
 }
 
-func (u *DiscussionUseCase) GetAnswerRecommendation(complaintID string) (string, error) {
+func (u *DiscussionUseCase) GetAnswerRecommendation(ctx context.Context, complaintID string) (string, error) {
 	discussions, err := u.GetByComplaintID(complaintID)
 	if err != nil {
 		return "", err
@@ -110,7 +134,7 @@ func (u *DiscussionUseCase) GetAnswerRecommendation(complaintID string) (string,
 
 	prompt = append(prompt, "Anda sebagai admin, berikan respon jawaban terhadap diskusi oleh user di atas yang belum terjawab oleh Admin. Jika ada pertanyaan yang sama atau mirip, jawaban yang diberikan cukup satu kali saja. Jawaban yang anda berikan disesuaikan dengan FAQ yang telah disediakan(Menyocokkan pertanyaan pada Q lalu jawab dengan A yang sesuai).")
 
-	botResponse, err := u.openAIAPI.GetChatCompletion(prompt, "")
+	botResponse, err := u.llmProvider.ChatCompletion(ctx, llm.Prompt(prompt, ""))
 	if err != nil {
 		return "", err
 	}
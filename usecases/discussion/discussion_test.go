@@ -1,7 +1,9 @@
 package discussion
 
 import (
+	"context"
 	"e-complaint-api/constants"
+	"e-complaint-api/cursor"
 	"e-complaint-api/entities"
 	"errors"
 	"github.com/stretchr/testify/assert"
@@ -28,6 +30,11 @@ func (m *MockDiscussion) GetByComplaintID(complaintID string) (*[]entities.Discu
 	return args.Get(0).(*[]entities.Discussion), args.Error(1)
 }
 
+func (m *MockDiscussion) GetByComplaintIDAfter(complaintID string, limit int, afterID int) ([]entities.Discussion, error) {
+	args := m.Called(complaintID, limit, afterID)
+	return args.Get(0).([]entities.Discussion), args.Error(1)
+}
+
 func (m *MockDiscussion) Update(discussion *entities.Discussion) error {
 	args := m.Called(discussion)
 	return args.Error(0)
@@ -48,26 +55,31 @@ func (m *MockFaq) GetAll() ([]entities.Faq, error) {
 
 }
 
-type OpenAIAPI struct {
+type LLMProvider struct {
 	mock.Mock
 }
 
-func (m *OpenAIAPI) GetResponse(text string) (string, error) {
-	args := m.Called(text)
+func (m *LLMProvider) ChatCompletion(ctx context.Context, messages []entities.LLMMessage) (string, error) {
+	args := m.Called(ctx, messages)
 	return args.String(0), args.Error(1)
 }
 
-func (m *OpenAIAPI) GetChatCompletion(prompt []string, userPrompt string) (string, error) {
-	args := m.Called(prompt, userPrompt)
-	return args.String(0), args.Error(1)
-}
+// withoutUser matches conversations that only have system messages.
+var withoutUser = mock.MatchedBy(func(messages []entities.LLMMessage) bool {
+	for _, message := range messages {
+		if message.Role != constants.LLMRoleSystem {
+			return false
+		}
+	}
+	return true
+})
 
 func TestDiscussionUseCase_GetById(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		mockDiscussion := new(MockDiscussion)
 		mockFaq := new(MockFaq)
-		mockOpenAIAPI := new(OpenAIAPI)
-		useCase := NewDiscussionUseCase(mockDiscussion, mockFaq, mockOpenAIAPI)
+		mockLLMProvider := new(LLMProvider)
+		useCase := NewDiscussionUseCase(mockDiscussion, mockFaq, mockLLMProvider)
 
 		discussion := entities.Discussion{
 			ID:      1,
@@ -84,8 +96,8 @@ func TestDiscussionUseCase_GetById(t *testing.T) {
 	t.Run("discussion not found", func(t *testing.T) {
 		mockDiscussion := new(MockDiscussion)
 		mockFaq := new(MockFaq)
-		mockOpenAIAPI := new(OpenAIAPI)
-		useCase := NewDiscussionUseCase(mockDiscussion, mockFaq, mockOpenAIAPI)
+		mockLLMProvider := new(LLMProvider)
+		useCase := NewDiscussionUseCase(mockDiscussion, mockFaq, mockLLMProvider)
 
 		mockDiscussion.On("GetById", 1).Return((*entities.Discussion)(nil), constants.ErrDiscussionNotFound)
 		result, err := useCase.GetById(1)
@@ -96,8 +108,8 @@ func TestDiscussionUseCase_GetById(t *testing.T) {
 	t.Run("error", func(t *testing.T) {
 		mockDiscussion := new(MockDiscussion)
 		mockFaq := new(MockFaq)
-		mockOpenAIAPI := new(OpenAIAPI)
-		useCase := NewDiscussionUseCase(mockDiscussion, mockFaq, mockOpenAIAPI)
+		mockLLMProvider := new(LLMProvider)
+		useCase := NewDiscussionUseCase(mockDiscussion, mockFaq, mockLLMProvider)
 
 		mockDiscussion.On("GetById", 1).Return((*entities.Discussion)(nil), constants.ErrInternalServerError)
 		result, err := useCase.GetById(1)
@@ -111,8 +123,8 @@ func TestDiscussionUseCase_Create(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		mockDiscussion := new(MockDiscussion)
 		mockFaq := new(MockFaq)
-		mockOpenAIAPI := new(OpenAIAPI)
-		useCase := NewDiscussionUseCase(mockDiscussion, mockFaq, mockOpenAIAPI)
+		mockLLMProvider := new(LLMProvider)
+		useCase := NewDiscussionUseCase(mockDiscussion, mockFaq, mockLLMProvider)
 
 		discussion := entities.Discussion{
 			Comment: "Hello",
@@ -126,8 +138,8 @@ func TestDiscussionUseCase_Create(t *testing.T) {
 	t.Run("comment cannot be empty", func(t *testing.T) {
 		mockDiscussion := new(MockDiscussion)
 		mockFaq := new(MockFaq)
-		mockOpenAIAPI := new(OpenAIAPI)
-		useCase := NewDiscussionUseCase(mockDiscussion, mockFaq, mockOpenAIAPI)
+		mockLLMProvider := new(LLMProvider)
+		useCase := NewDiscussionUseCase(mockDiscussion, mockFaq, mockLLMProvider)
 
 		discussion := entities.Discussion{
 			Comment: "",
@@ -141,8 +153,8 @@ func TestDiscussionUseCase_Create(t *testing.T) {
 	t.Run("error", func(t *testing.T) {
 		mockDiscussion := new(MockDiscussion)
 		mockFaq := new(MockFaq)
-		mockOpenAIAPI := new(OpenAIAPI)
-		useCase := NewDiscussionUseCase(mockDiscussion, mockFaq, mockOpenAIAPI)
+		mockLLMProvider := new(LLMProvider)
+		useCase := NewDiscussionUseCase(mockDiscussion, mockFaq, mockLLMProvider)
 
 		discussion := entities.Discussion{
 			Comment: "Hello",
@@ -155,12 +167,43 @@ func TestDiscussionUseCase_Create(t *testing.T) {
 	})
 }
 
+func TestDiscussionUseCase_GetByComplaintIDCursor(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockDiscussion := new(MockDiscussion)
+		useCase := NewDiscussionUseCase(mockDiscussion, new(MockFaq), new(LLMProvider))
+
+		discussions := []entities.Discussion{{ID: 4, ComplaintID: "1"}, {ID: 6, ComplaintID: "1"}, {ID: 9, ComplaintID: "1"}}
+		mockDiscussion.On("GetByComplaintIDAfter", "1", 3, 2).Return(discussions, nil)
+		result, nextCursor, err := useCase.GetByComplaintIDCursor("1", 2, cursor.Encode("2", "id ASC"))
+		assert.Nil(t, err)
+		assert.Equal(t, discussions[:2], result)
+		assert.Equal(t, cursor.Encode("6", "id ASC"), nextCursor)
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		useCase := NewDiscussionUseCase(new(MockDiscussion), new(MockFaq), new(LLMProvider))
+
+		_, _, err := useCase.GetByComplaintIDCursor("1", 2, "invalid")
+		assert.Equal(t, constants.ErrInvalidCursor, err)
+	})
+
+	t.Run("internal server error", func(t *testing.T) {
+		mockDiscussion := new(MockDiscussion)
+		useCase := NewDiscussionUseCase(mockDiscussion, new(MockFaq), new(LLMProvider))
+
+		mockDiscussion.On("GetByComplaintIDAfter", "1", constants.DefaultCursorLimit+1, 0).Return([]entities.Discussion(nil), errors.New("database error"))
+		result, _, err := useCase.GetByComplaintIDCursor("1", 0, "")
+		assert.Equal(t, constants.ErrInternalServerError, err)
+		assert.Nil(t, result)
+	})
+}
+
 func TestDiscussionUseCase_Update(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		mockDiscussion := new(MockDiscussion)
 		mockFaq := new(MockFaq)
-		mockOpenAIAPI := new(OpenAIAPI)
-		useCase := NewDiscussionUseCase(mockDiscussion, mockFaq, mockOpenAIAPI)
+		mockLLMProvider := new(LLMProvider)
+		useCase := NewDiscussionUseCase(mockDiscussion, mockFaq, mockLLMProvider)
 
 		discussion := entities.Discussion{
 			Comment: "Hello",
@@ -174,8 +217,8 @@ func TestDiscussionUseCase_Update(t *testing.T) {
 	t.Run("comment cannot be empty", func(t *testing.T) {
 		mockDiscussion := new(MockDiscussion)
 		mockFaq := new(MockFaq)
-		mockOpenAIAPI := new(OpenAIAPI)
-		useCase := NewDiscussionUseCase(mockDiscussion, mockFaq, mockOpenAIAPI)
+		mockLLMProvider := new(LLMProvider)
+		useCase := NewDiscussionUseCase(mockDiscussion, mockFaq, mockLLMProvider)
 
 		discussion := entities.Discussion{
 			Comment: "",
@@ -189,8 +232,8 @@ func TestDiscussionUseCase_Update(t *testing.T) {
 	t.Run("error", func(t *testing.T) {
 		mockDiscussion := new(MockDiscussion)
 		mockFaq := new(MockFaq)
-		mockOpenAIAPI := new(OpenAIAPI)
-		useCase := NewDiscussionUseCase(mockDiscussion, mockFaq, mockOpenAIAPI)
+		mockLLMProvider := new(LLMProvider)
+		useCase := NewDiscussionUseCase(mockDiscussion, mockFaq, mockLLMProvider)
 
 		discussion := entities.Discussion{
 			Comment: "Hello",
@@ -207,8 +250,8 @@ func TestDiscussionUseCase_Delete(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		mockDiscussion := new(MockDiscussion)
 		mockFaq := new(MockFaq)
-		mockOpenAIAPI := new(OpenAIAPI)
-		useCase := NewDiscussionUseCase(mockDiscussion, mockFaq, mockOpenAIAPI)
+		mockLLMProvider := new(LLMProvider)
+		useCase := NewDiscussionUseCase(mockDiscussion, mockFaq, mockLLMProvider)
 
 		mockDiscussion.On("Delete", 1).Return(nil)
 		err := useCase.Delete(1)
@@ -218,8 +261,8 @@ func TestDiscussionUseCase_Delete(t *testing.T) {
 	t.Run("error", func(t *testing.T) {
 		mockDiscussion := new(MockDiscussion)
 		mockFaq := new(MockFaq)
-		mockOpenAIAPI := new(OpenAIAPI)
-		useCase := NewDiscussionUseCase(mockDiscussion, mockFaq, mockOpenAIAPI)
+		mockLLMProvider := new(LLMProvider)
+		useCase := NewDiscussionUseCase(mockDiscussion, mockFaq, mockLLMProvider)
 
 		mockDiscussion.On("Delete", 1).Return(constants.ErrInternalServerError)
 		err := useCase.Delete(1)
@@ -233,8 +276,8 @@ func TestDiscussionUseCase_GetByComplaintID(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		mockDiscussion := new(MockDiscussion)
 		mockFaq := new(MockFaq)
-		mockOpenAIAPI := new(OpenAIAPI)
-		useCase := NewDiscussionUseCase(mockDiscussion, mockFaq, mockOpenAIAPI)
+		mockLLMProvider := new(LLMProvider)
+		useCase := NewDiscussionUseCase(mockDiscussion, mockFaq, mockLLMProvider)
 
 		discussions := []entities.Discussion{
 			{
@@ -253,8 +296,8 @@ func TestDiscussionUseCase_GetByComplaintID(t *testing.T) {
 	t.Run("discussion not found", func(t *testing.T) {
 		mockDiscussion := new(MockDiscussion)
 		mockFaq := new(MockFaq)
-		mockOpenAIAPI := new(OpenAIAPI)
-		useCase := NewDiscussionUseCase(mockDiscussion, mockFaq, mockOpenAIAPI)
+		mockLLMProvider := new(LLMProvider)
+		useCase := NewDiscussionUseCase(mockDiscussion, mockFaq, mockLLMProvider)
 
 		mockDiscussion.On("GetByComplaintID", "1").Return((*[]entities.Discussion)(nil), constants.ErrDiscussionNotFound)
 		result, err := useCase.GetByComplaintID("1")
@@ -267,8 +310,8 @@ func TestDiscussionUseCase_GetAnswerRecommendation(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		mockDiscussionRepo := new(MockDiscussion)
 		mockFaqRepo := new(MockFaq)
-		mockOpenAIAPI := new(OpenAIAPI)
-		useCase := NewDiscussionUseCase(mockDiscussionRepo, mockFaqRepo, mockOpenAIAPI)
+		mockLLMProvider := new(LLMProvider)
+		useCase := NewDiscussionUseCase(mockDiscussionRepo, mockFaqRepo, mockLLMProvider)
 
 		discussions := []entities.Discussion{
 			{
@@ -287,9 +330,9 @@ func TestDiscussionUseCase_GetAnswerRecommendation(t *testing.T) {
 
 		mockDiscussionRepo.On("GetByComplaintID", "1").Return(&discussions, nil)
 		mockFaqRepo.On("GetAll").Return(faqs, nil)
-		mockOpenAIAPI.On("GetChatCompletion", mock.Anything, "").Return("Test response", nil)
+		mockLLMProvider.On("ChatCompletion", mock.Anything, withoutUser).Return("Test response", nil)
 
-		result, err := useCase.GetAnswerRecommendation("1")
+		result, err := useCase.GetAnswerRecommendation(context.Background(), "1")
 		assert.Nil(t, err)
 		assert.Equal(t, "Test response", result)
 	})
@@ -297,12 +340,12 @@ func TestDiscussionUseCase_GetAnswerRecommendation(t *testing.T) {
 	t.Run("error", func(t *testing.T) {
 		mockDiscussionRepo := new(MockDiscussion)
 		mockFaqRepo := new(MockFaq)
-		mockOpenAIAPI := new(OpenAIAPI)
-		useCase := NewDiscussionUseCase(mockDiscussionRepo, mockFaqRepo, mockOpenAIAPI)
+		mockLLMProvider := new(LLMProvider)
+		useCase := NewDiscussionUseCase(mockDiscussionRepo, mockFaqRepo, mockLLMProvider)
 
 		mockDiscussionRepo.On("GetByComplaintID", "1").Return((*[]entities.Discussion)(nil), constants.ErrDiscussionNotFound)
 
-		result, err := useCase.GetAnswerRecommendation("1")
+		result, err := useCase.GetAnswerRecommendation(context.Background(), "1")
 		assert.NotNil(t, err)
 		assert.Equal(t, "", result)
 	})
@@ -310,31 +353,31 @@ func TestDiscussionUseCase_GetAnswerRecommendation(t *testing.T) {
 	t.Run("GetAll returns error", func(t *testing.T) {
 		mockDiscussionRepo := new(MockDiscussion)
 		mockFaqRepo := new(MockFaq)
-		mockOpenAIAPI := new(OpenAIAPI)
-		useCase := NewDiscussionUseCase(mockDiscussionRepo, mockFaqRepo, mockOpenAIAPI)
+		mockLLMProvider := new(LLMProvider)
+		useCase := NewDiscussionUseCase(mockDiscussionRepo, mockFaqRepo, mockLLMProvider)
 		mockDiscussionRepo.On("GetByComplaintID", "1").Return((*[]entities.Discussion)(nil), nil)
 		mockFaqRepo.On("GetAll").Return([]entities.Faq(nil), errors.New("some error"))
-		_, err := useCase.GetAnswerRecommendation("1")
+		_, err := useCase.GetAnswerRecommendation(context.Background(), "1")
 		assert.Error(t, err)
 	})
 
-	t.Run("GetChatCompletion returns error", func(t *testing.T) {
+	t.Run("ChatCompletion returns error", func(t *testing.T) {
 		mockDiscussionRepo := new(MockDiscussion)
 		mockFaqRepo := new(MockFaq)
-		mockOpenAIAPI := new(OpenAIAPI)
-		useCase := NewDiscussionUseCase(mockDiscussionRepo, mockFaqRepo, mockOpenAIAPI)
+		mockLLMProvider := new(LLMProvider)
+		useCase := NewDiscussionUseCase(mockDiscussionRepo, mockFaqRepo, mockLLMProvider)
 		mockDiscussionRepo.On("GetByComplaintID", "1").Return((*[]entities.Discussion)(nil), nil)
 		mockFaqRepo.On("GetAll").Return([]entities.Faq{{Question: "What is the meaning of life?", Answer: "42"}}, nil)
-		mockOpenAIAPI.On("GetChatCompletion", mock.Anything, "").Return("", errors.New("some error"))
-		_, err := useCase.GetAnswerRecommendation("1")
+		mockLLMProvider.On("ChatCompletion", mock.Anything, withoutUser).Return("", errors.New("some error"))
+		_, err := useCase.GetAnswerRecommendation(context.Background(), "1")
 		assert.Error(t, err)
 	})
 
 	t.Run("UserID is not nil", func(t *testing.T) {
 		mockDiscussionRepo := new(MockDiscussion)
 		mockFaqRepo := new(MockFaq)
-		mockOpenAIAPI := new(OpenAIAPI)
-		useCase := NewDiscussionUseCase(mockDiscussionRepo, mockFaqRepo, mockOpenAIAPI)
+		mockLLMProvider := new(LLMProvider)
+		useCase := NewDiscussionUseCase(mockDiscussionRepo, mockFaqRepo, mockLLMProvider)
 
 		userID := 1
 		discussions := []entities.Discussion{
@@ -347,9 +390,9 @@ func TestDiscussionUseCase_GetAnswerRecommendation(t *testing.T) {
 
 		mockDiscussionRepo.On("GetByComplaintID", "1").Return(&discussions, nil)
 		mockFaqRepo.On("GetAll").Return([]entities.Faq{}, nil)
-		mockOpenAIAPI.On("GetChatCompletion", mock.Anything, "").Return("1.)User: Hello\nTest response", nil)
+		mockLLMProvider.On("ChatCompletion", mock.Anything, withoutUser).Return("1.)User: Hello\nTest response", nil)
 
-		result, err := useCase.GetAnswerRecommendation("1")
+		result, err := useCase.GetAnswerRecommendation(context.Background(), "1")
 		assert.Nil(t, err)
 		assert.Contains(t, result, "1.)User: Hello")
 	})
@@ -357,8 +400,8 @@ func TestDiscussionUseCase_GetAnswerRecommendation(t *testing.T) {
 	t.Run("UserID is nil", func(t *testing.T) {
 		mockDiscussionRepo := new(MockDiscussion)
 		mockFaqRepo := new(MockFaq)
-		mockOpenAIAPI := new(OpenAIAPI)
-		useCase := NewDiscussionUseCase(mockDiscussionRepo, mockFaqRepo, mockOpenAIAPI)
+		mockLLMProvider := new(LLMProvider)
+		useCase := NewDiscussionUseCase(mockDiscussionRepo, mockFaqRepo, mockLLMProvider)
 
 		discussions := []entities.Discussion{
 			{
@@ -370,9 +413,9 @@ func TestDiscussionUseCase_GetAnswerRecommendation(t *testing.T) {
 
 		mockDiscussionRepo.On("GetByComplaintID", "1").Return(&discussions, nil)
 		mockFaqRepo.On("GetAll").Return([]entities.Faq{}, nil)
-		mockOpenAIAPI.On("GetChatCompletion", mock.Anything, "").Return("Test response", nil)
+		mockLLMProvider.On("ChatCompletion", mock.Anything, withoutUser).Return("Test response", nil)
 
-		result, err := useCase.GetAnswerRecommendation("1")
+		result, err := useCase.GetAnswerRecommendation(context.Background(), "1")
 		assert.Nil(t, err)
 		assert.NotContains(t, result, "1.)User: Hello")
 	})
@@ -0,0 +1,240 @@
+package email
+
+import (
+	"e-complaint-api/constants"
+	"e-complaint-api/entities"
+	"e-complaint-api/workflow"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+)
+
+// statusEvents are the email events of the statuses a complaint can be moved to.
+// Merged complaints have no email, their reporters get a notification instead.
+var statusEvents = map[string]string{
+	workflow.StatusPending:    constants.EmailEventComplaintReceived,
+	workflow.StatusVerifikasi: constants.EmailEventComplaintVerified,
+	workflow.StatusOnProgress: constants.EmailEventComplaintOnProgress,
+	workflow.StatusSelesai:    constants.EmailEventComplaintFinished,
+	workflow.StatusDitolak:    constants.EmailEventComplaintRejected,
+}
+
+var subjects = map[string]string{
+	constants.EmailEventComplaintReceived:   "Aduan %s Telah Diterima",
+	constants.EmailEventComplaintVerified:   "Aduan %s Telah Diverifikasi",
+	constants.EmailEventComplaintOnProgress: "Aduan %s Sedang Ditangani",
+	constants.EmailEventComplaintFinished:   "Aduan %s Telah Selesai",
+	constants.EmailEventComplaintRejected:   "Aduan %s Ditolak",
+	constants.EmailEventDiscussionReply:     "Tanggapan Baru pada Aduan %s",
+}
+
+type EmailUseCase struct {
+	repository      entities.EmailRepositoryInterface
+	mailer          entities.MailerInterface
+	evidenceStorage entities.FileStorageInterface
+	jobUseCase      entities.JobUseCaseInterface
+}
+
+// emailPayload is the payload of the email jobs. The complaint is loaded when the job
+// runs, so the email shows it as it is by then.
+type emailPayload struct {
+	ComplaintID string
+	Event       string
+	Status      string
+	Message     string
+}
+
+// templateData is what the email templates are rendered with.
+type templateData struct {
+	Name        string
+	ComplaintID string
+	Description string
+	Status      string
+	Message     string
+	Evidence    []evidenceData
+}
+
+type evidenceData struct {
+	PenanggungJawab string
+	FinishedOn      string
+}
+
+// NewEmailUseCase registers the handlers of the email jobs, which send the emails
+// with mailer. The evidence of finished complaints is attached from evidenceStorage.
+func NewEmailUseCase(repository entities.EmailRepositoryInterface, mailer entities.MailerInterface, evidenceStorage entities.FileStorageInterface, jobUseCase entities.JobUseCaseInterface) *EmailUseCase {
+	useCase := &EmailUseCase{
+		repository:      repository,
+		mailer:          mailer,
+		evidenceStorage: evidenceStorage,
+		jobUseCase:      jobUseCase,
+	}
+	jobUseCase.Register(constants.JobTypeEmailComplaintProcess, useCase.send)
+	jobUseCase.Register(constants.JobTypeEmailDiscussionReply, useCase.send)
+
+	return useCase
+}
+
+// NotifyComplaintProcess emails the reporter about the new status of their complaint.
+func (u *EmailUseCase) NotifyComplaintProcess(complaint entities.Complaint, complaintProcess entities.ComplaintProcess) error {
+	event, ok := statusEvents[complaintProcess.Status]
+	if !ok {
+		return nil
+	}
+
+	_, err := u.jobUseCase.Enqueue(constants.JobTypeEmailComplaintProcess, nil, emailPayload{
+		ComplaintID: complaint.ID,
+		Event:       event,
+		Status:      complaintProcess.Status,
+		Message:     complaintProcess.Message,
+	})
+
+	return err
+}
+
+// NotifyDiscussion emails the reporter when an admin replies in the discussion of
+// their complaint.
+func (u *EmailUseCase) NotifyDiscussion(complaint entities.Complaint, discussion entities.Discussion) error {
+	if discussion.AdminID == nil {
+		return nil
+	}
+
+	_, err := u.jobUseCase.Enqueue(constants.JobTypeEmailDiscussionReply, nil, emailPayload{
+		ComplaintID: complaint.ID,
+		Event:       constants.EmailEventDiscussionReply,
+		Status:      complaint.Status,
+		Message:     discussion.Comment,
+	})
+
+	return err
+}
+
+// send is the handler of the email jobs. It sends nothing to users who opted out of
+// the event.
+func (u *EmailUseCase) send(data []byte) (string, error) {
+	var payload emailPayload
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return "", err
+	}
+
+	complaint, err := u.repository.GetComplaint(payload.ComplaintID)
+	if err != nil {
+		return "", err
+	}
+
+	optOuts, err := u.repository.GetOptOuts(complaint.UserID)
+	if err != nil {
+		return "", err
+	}
+	for _, optOut := range optOuts {
+		if optOut == payload.Event {
+			return "user opted out", nil
+		}
+	}
+
+	templateData := templateData{
+		Name:        complaint.User.Name,
+		ComplaintID: complaint.ID,
+		Description: complaint.Description,
+		Status:      payload.Status,
+		Message:     payload.Message,
+		Evidence:    []evidenceData{},
+	}
+
+	attachments := []entities.EmailAttachment{}
+	if payload.Event == constants.EmailEventComplaintFinished {
+		evidence, err := u.repository.GetEvidence(complaint.ID)
+		if err != nil {
+			return "", err
+		}
+
+		for _, evidence := range evidence {
+			attachment, err := u.attachment(evidence.Path)
+			if err != nil {
+				return "", err
+			}
+
+			attachments = append(attachments, attachment)
+			templateData.Evidence = append(templateData.Evidence, evidenceData{
+				PenanggungJawab: evidence.PenanggungJawab,
+				FinishedOn:      evidence.FinishedOn.Format("2 January 2006"),
+			})
+		}
+	}
+
+	subject := fmt.Sprintf(subjects[payload.Event], complaint.ID)
+	if err := u.mailer.Send(complaint.User.Email, subject, payload.Event, templateData, attachments); err != nil {
+		return "", err
+	}
+
+	return "", nil
+}
+
+func (u *EmailUseCase) attachment(filePath string) (entities.EmailAttachment, error) {
+	file, err := u.evidenceStorage.Open(filePath)
+	if err != nil {
+		return entities.EmailAttachment{}, err
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return entities.EmailAttachment{}, err
+	}
+
+	return entities.EmailAttachment{Name: path.Base(filePath), Content: content}, nil
+}
+
+// GetPreferences returns whether the user gets the emails of every event.
+func (u *EmailUseCase) GetPreferences(userID int) ([]entities.EmailPreference, error) {
+	optOuts, err := u.repository.GetOptOuts(userID)
+	if err != nil {
+		return nil, constants.ErrInternalServerError
+	}
+
+	optedOut := map[string]bool{}
+	for _, event := range optOuts {
+		optedOut[event] = true
+	}
+
+	preferences := []entities.EmailPreference{}
+	for _, event := range constants.EmailEvents {
+		preferences = append(preferences, entities.EmailPreference{Event: event, Enabled: !optedOut[event]})
+	}
+
+	return preferences, nil
+}
+
+// UpdatePreferences changes the preferences of the events given, the others stay as
+// they are.
+func (u *EmailUseCase) UpdatePreferences(userID int, preferences []entities.EmailPreference) ([]entities.EmailPreference, error) {
+	current, err := u.GetPreferences(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	enabled := map[string]bool{}
+	for _, preference := range current {
+		enabled[preference.Event] = preference.Enabled
+	}
+
+	for _, preference := range preferences {
+		if _, ok := enabled[preference.Event]; !ok {
+			return nil, constants.ErrInvalidEmailEvent
+		}
+		enabled[preference.Event] = preference.Enabled
+	}
+
+	optOuts := []string{}
+	for _, event := range constants.EmailEvents {
+		if !enabled[event] {
+			optOuts = append(optOuts, event)
+		}
+	}
+
+	if err := u.repository.UpdateOptOuts(userID, optOuts); err != nil {
+		return nil, constants.ErrInternalServerError
+	}
+
+	return u.GetPreferences(userID)
+}
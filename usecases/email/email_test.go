@@ -0,0 +1,398 @@
+package email
+
+import (
+	"e-complaint-api/constants"
+	"e-complaint-api/entities"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockEmailRepo struct {
+	mock.Mock
+}
+
+func (m *MockEmailRepo) GetOptOuts(userID int) ([]string, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockEmailRepo) UpdateOptOuts(userID int, events []string) error {
+	args := m.Called(userID, events)
+	return args.Error(0)
+}
+
+func (m *MockEmailRepo) GetComplaint(complaintID string) (entities.Complaint, error) {
+	args := m.Called(complaintID)
+	return args.Get(0).(entities.Complaint), args.Error(1)
+}
+
+func (m *MockEmailRepo) GetEvidence(complaintID string) ([]entities.UnggahBukti, error) {
+	args := m.Called(complaintID)
+	return args.Get(0).([]entities.UnggahBukti), args.Error(1)
+}
+
+type MockMailer struct {
+	mock.Mock
+}
+
+func (m *MockMailer) Send(to string, subject string, templateName string, data interface{}, attachments []entities.EmailAttachment) error {
+	args := m.Called(to, subject, templateName, data, attachments)
+	return args.Error(0)
+}
+
+type MockFileStorage struct {
+	mock.Mock
+}
+
+func (m *MockFileStorage) Upload(files []entities.UploadFile) ([]string, error) {
+	args := m.Called(files)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockFileStorage) Delete(paths []string) error {
+	args := m.Called(paths)
+	return args.Error(0)
+}
+
+func (m *MockFileStorage) Open(filePath string) (io.ReadCloser, error) {
+	args := m.Called(filePath)
+	return args.Get(0).(io.ReadCloser), args.Error(1)
+}
+
+type MockJobUseCase struct {
+	mock.Mock
+}
+
+func (m *MockJobUseCase) Register(jobType string, handler entities.JobHandler) {
+	m.Called(jobType, handler)
+}
+
+func (m *MockJobUseCase) Enqueue(jobType string, createdBy *int, payload interface{}) (entities.Job, error) {
+	args := m.Called(jobType, createdBy, payload)
+	return args.Get(0).(entities.Job), args.Error(1)
+}
+
+func (m *MockJobUseCase) RunOnce() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+func (m *MockJobUseCase) GetByCursor(filter map[string]interface{}, limit int, cursor string) ([]entities.Job, string, error) {
+	args := m.Called(filter, limit, cursor)
+	return args.Get(0).([]entities.Job), args.String(1), args.Error(2)
+}
+
+func (m *MockJobUseCase) GetByID(id int) (entities.Job, error) {
+	args := m.Called(id)
+	return args.Get(0).(entities.Job), args.Error(1)
+}
+
+func (m *MockJobUseCase) GetOwn(id int, jobType string, adminID int) (entities.Job, error) {
+	args := m.Called(id, jobType, adminID)
+	return args.Get(0).(entities.Job), args.Error(1)
+}
+
+func (m *MockJobUseCase) Retry(id int) (entities.Job, error) {
+	args := m.Called(id)
+	return args.Get(0).(entities.Job), args.Error(1)
+}
+
+type errReader struct{}
+
+func (errReader) Read(p []byte) (int, error) {
+	return 0, errors.New("read error")
+}
+
+func newUseCase(repo *MockEmailRepo, mailer *MockMailer, storage *MockFileStorage) (*EmailUseCase, *MockJobUseCase) {
+	jobUseCase := new(MockJobUseCase)
+	jobUseCase.On("Register", constants.JobTypeEmailComplaintProcess, mock.Anything).Return()
+	jobUseCase.On("Register", constants.JobTypeEmailDiscussionReply, mock.Anything).Return()
+
+	return NewEmailUseCase(repo, mailer, storage, jobUseCase), jobUseCase
+}
+
+func payload(p emailPayload) []byte {
+	data, _ := json.Marshal(p)
+	return data
+}
+
+var complaint = entities.Complaint{
+	ID:          "C-123",
+	UserID:      5,
+	Description: "Jalan rusak",
+	Status:      "On Progress",
+	User:        entities.User{Name: "Budi", Email: "budi@example.com"},
+}
+
+func TestNotifyComplaintProcess(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		useCase, jobUseCase := newUseCase(nil, nil, nil)
+		jobUseCase.On("Enqueue", constants.JobTypeEmailComplaintProcess, (*int)(nil), emailPayload{
+			ComplaintID: "C-123",
+			Event:       constants.EmailEventComplaintReceived,
+			Status:      "Pending",
+			Message:     "Aduan diterima",
+		}).Return(entities.Job{}, nil)
+
+		err := useCase.NotifyComplaintProcess(complaint, entities.ComplaintProcess{Status: "Pending", Message: "Aduan diterima"})
+		assert.NoError(t, err)
+		jobUseCase.AssertExpectations(t)
+	})
+
+	t.Run("success no email for merged complaints", func(t *testing.T) {
+		useCase, jobUseCase := newUseCase(nil, nil, nil)
+
+		err := useCase.NotifyComplaintProcess(complaint, entities.ComplaintProcess{Status: "Digabung"})
+		assert.NoError(t, err)
+		jobUseCase.AssertNotCalled(t, "Enqueue", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("failed enqueueing", func(t *testing.T) {
+		useCase, jobUseCase := newUseCase(nil, nil, nil)
+		jobUseCase.On("Enqueue", mock.Anything, mock.Anything, mock.Anything).Return(entities.Job{}, constants.ErrInternalServerError)
+
+		err := useCase.NotifyComplaintProcess(complaint, entities.ComplaintProcess{Status: "Selesai"})
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+}
+
+func TestNotifyDiscussion(t *testing.T) {
+	t.Run("success admin reply", func(t *testing.T) {
+		adminID := 2
+		useCase, jobUseCase := newUseCase(nil, nil, nil)
+		jobUseCase.On("Enqueue", constants.JobTypeEmailDiscussionReply, (*int)(nil), emailPayload{
+			ComplaintID: "C-123",
+			Event:       constants.EmailEventDiscussionReply,
+			Status:      "On Progress",
+			Message:     "Sedang kami tangani",
+		}).Return(entities.Job{}, nil)
+
+		err := useCase.NotifyDiscussion(complaint, entities.Discussion{AdminID: &adminID, Comment: "Sedang kami tangani"})
+		assert.NoError(t, err)
+		jobUseCase.AssertExpectations(t)
+	})
+
+	t.Run("success no email for user comments", func(t *testing.T) {
+		userID := 5
+		useCase, jobUseCase := newUseCase(nil, nil, nil)
+
+		err := useCase.NotifyDiscussion(complaint, entities.Discussion{UserID: &userID})
+		assert.NoError(t, err)
+		jobUseCase.AssertNotCalled(t, "Enqueue", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestSend(t *testing.T) {
+	finishedOn := time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC)
+	evidence := []entities.UnggahBukti{{ComplaintID: "C-123", Path: "evidence-files/bukti.jpg", PenanggungJawab: "Dinas PU", FinishedOn: finishedOn}}
+
+	t.Run("success", func(t *testing.T) {
+		repo := new(MockEmailRepo)
+		repo.On("GetComplaint", "C-123").Return(complaint, nil)
+		repo.On("GetOptOuts", 5).Return([]string{constants.EmailEventComplaintRejected}, nil)
+		mailer := new(MockMailer)
+		mailer.On("Send", "budi@example.com", "Aduan C-123 Sedang Ditangani", constants.EmailEventComplaintOnProgress, templateData{
+			Name:        "Budi",
+			ComplaintID: "C-123",
+			Description: "Jalan rusak",
+			Status:      "On Progress",
+			Message:     "Petugas menuju lokasi",
+			Evidence:    []evidenceData{},
+		}, []entities.EmailAttachment{}).Return(nil)
+
+		useCase, _ := newUseCase(repo, mailer, nil)
+		result, err := useCase.send(payload(emailPayload{ComplaintID: "C-123", Event: constants.EmailEventComplaintOnProgress, Status: "On Progress", Message: "Petugas menuju lokasi"}))
+		assert.NoError(t, err)
+		assert.Equal(t, "", result)
+		mailer.AssertExpectations(t)
+	})
+
+	t.Run("success finished with evidence", func(t *testing.T) {
+		repo := new(MockEmailRepo)
+		repo.On("GetComplaint", "C-123").Return(complaint, nil)
+		repo.On("GetOptOuts", 5).Return([]string{}, nil)
+		repo.On("GetEvidence", "C-123").Return(evidence, nil)
+		storage := new(MockFileStorage)
+		storage.On("Open", "evidence-files/bukti.jpg").Return(io.NopCloser(strings.NewReader("image")), nil)
+		mailer := new(MockMailer)
+		mailer.On("Send", "budi@example.com", "Aduan C-123 Telah Selesai", constants.EmailEventComplaintFinished, templateData{
+			Name:        "Budi",
+			ComplaintID: "C-123",
+			Description: "Jalan rusak",
+			Status:      "Selesai",
+			Evidence:    []evidenceData{{PenanggungJawab: "Dinas PU", FinishedOn: "3 June 2024"}},
+		}, []entities.EmailAttachment{{Name: "bukti.jpg", Content: []byte("image")}}).Return(nil)
+
+		useCase, _ := newUseCase(repo, mailer, storage)
+		_, err := useCase.send(payload(emailPayload{ComplaintID: "C-123", Event: constants.EmailEventComplaintFinished, Status: "Selesai"}))
+		assert.NoError(t, err)
+		mailer.AssertExpectations(t)
+	})
+
+	t.Run("success opted out", func(t *testing.T) {
+		repo := new(MockEmailRepo)
+		repo.On("GetComplaint", "C-123").Return(complaint, nil)
+		repo.On("GetOptOuts", 5).Return([]string{constants.EmailEventDiscussionReply}, nil)
+		mailer := new(MockMailer)
+
+		useCase, _ := newUseCase(repo, mailer, nil)
+		result, err := useCase.send(payload(emailPayload{ComplaintID: "C-123", Event: constants.EmailEventDiscussionReply}))
+		assert.NoError(t, err)
+		assert.Equal(t, "user opted out", result)
+		mailer.AssertNotCalled(t, "Send", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("failed invalid payload", func(t *testing.T) {
+		useCase, _ := newUseCase(nil, nil, nil)
+		_, err := useCase.send([]byte("{"))
+		assert.Error(t, err)
+	})
+
+	t.Run("failed complaint not found", func(t *testing.T) {
+		repo := new(MockEmailRepo)
+		repo.On("GetComplaint", "C-123").Return(entities.Complaint{}, constants.ErrComplaintNotFound)
+
+		useCase, _ := newUseCase(repo, nil, nil)
+		_, err := useCase.send(payload(emailPayload{ComplaintID: "C-123"}))
+		assert.Equal(t, constants.ErrComplaintNotFound, err)
+	})
+
+	t.Run("failed getting opt outs", func(t *testing.T) {
+		repo := new(MockEmailRepo)
+		repo.On("GetComplaint", "C-123").Return(complaint, nil)
+		repo.On("GetOptOuts", 5).Return([]string{}, constants.ErrInternalServerError)
+
+		useCase, _ := newUseCase(repo, nil, nil)
+		_, err := useCase.send(payload(emailPayload{ComplaintID: "C-123"}))
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+
+	t.Run("failed getting evidence", func(t *testing.T) {
+		repo := new(MockEmailRepo)
+		repo.On("GetComplaint", "C-123").Return(complaint, nil)
+		repo.On("GetOptOuts", 5).Return([]string{}, nil)
+		repo.On("GetEvidence", "C-123").Return([]entities.UnggahBukti{}, constants.ErrInternalServerError)
+
+		useCase, _ := newUseCase(repo, nil, nil)
+		_, err := useCase.send(payload(emailPayload{ComplaintID: "C-123", Event: constants.EmailEventComplaintFinished}))
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+
+	t.Run("failed opening evidence", func(t *testing.T) {
+		repo := new(MockEmailRepo)
+		repo.On("GetComplaint", "C-123").Return(complaint, nil)
+		repo.On("GetOptOuts", 5).Return([]string{}, nil)
+		repo.On("GetEvidence", "C-123").Return(evidence, nil)
+		storage := new(MockFileStorage)
+		storage.On("Open", "evidence-files/bukti.jpg").Return(io.NopCloser(nil), constants.ErrInternalServerError)
+
+		useCase, _ := newUseCase(repo, nil, storage)
+		_, err := useCase.send(payload(emailPayload{ComplaintID: "C-123", Event: constants.EmailEventComplaintFinished}))
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+
+	t.Run("failed reading evidence", func(t *testing.T) {
+		repo := new(MockEmailRepo)
+		repo.On("GetComplaint", "C-123").Return(complaint, nil)
+		repo.On("GetOptOuts", 5).Return([]string{}, nil)
+		repo.On("GetEvidence", "C-123").Return(evidence, nil)
+		storage := new(MockFileStorage)
+		storage.On("Open", "evidence-files/bukti.jpg").Return(io.NopCloser(errReader{}), nil)
+
+		useCase, _ := newUseCase(repo, nil, storage)
+		_, err := useCase.send(payload(emailPayload{ComplaintID: "C-123", Event: constants.EmailEventComplaintFinished}))
+		assert.EqualError(t, err, "read error")
+	})
+
+	t.Run("failed sending", func(t *testing.T) {
+		repo := new(MockEmailRepo)
+		repo.On("GetComplaint", "C-123").Return(complaint, nil)
+		repo.On("GetOptOuts", 5).Return([]string{}, nil)
+		mailer := new(MockMailer)
+		mailer.On("Send", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(constants.ErrInternalServerError)
+
+		useCase, _ := newUseCase(repo, mailer, nil)
+		_, err := useCase.send(payload(emailPayload{ComplaintID: "C-123", Event: constants.EmailEventComplaintVerified}))
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+}
+
+func TestGetPreferences(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		repo := new(MockEmailRepo)
+		repo.On("GetOptOuts", 5).Return([]string{constants.EmailEventDiscussionReply}, nil)
+
+		useCase, _ := newUseCase(repo, nil, nil)
+		preferences, err := useCase.GetPreferences(5)
+		assert.NoError(t, err)
+		assert.Len(t, preferences, len(constants.EmailEvents))
+		for _, preference := range preferences {
+			assert.Equal(t, preference.Event != constants.EmailEventDiscussionReply, preference.Enabled)
+		}
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		repo := new(MockEmailRepo)
+		repo.On("GetOptOuts", 5).Return([]string{}, errors.New("database error"))
+
+		useCase, _ := newUseCase(repo, nil, nil)
+		_, err := useCase.GetPreferences(5)
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+}
+
+func TestUpdatePreferences(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		repo := new(MockEmailRepo)
+		repo.On("GetOptOuts", 5).Return([]string{constants.EmailEventDiscussionReply}, nil).Once()
+		repo.On("UpdateOptOuts", 5, []string{constants.EmailEventComplaintVerified}).Return(nil)
+		repo.On("GetOptOuts", 5).Return([]string{constants.EmailEventComplaintVerified}, nil).Once()
+
+		useCase, _ := newUseCase(repo, nil, nil)
+		preferences, err := useCase.UpdatePreferences(5, []entities.EmailPreference{
+			{Event: constants.EmailEventComplaintVerified, Enabled: false},
+			{Event: constants.EmailEventDiscussionReply, Enabled: true},
+		})
+		assert.NoError(t, err)
+		for _, preference := range preferences {
+			assert.Equal(t, preference.Event != constants.EmailEventComplaintVerified, preference.Enabled)
+		}
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("failed invalid event", func(t *testing.T) {
+		repo := new(MockEmailRepo)
+		repo.On("GetOptOuts", 5).Return([]string{}, nil)
+
+		useCase, _ := newUseCase(repo, nil, nil)
+		_, err := useCase.UpdatePreferences(5, []entities.EmailPreference{{Event: "newsletter"}})
+		assert.Equal(t, constants.ErrInvalidEmailEvent, err)
+		repo.AssertNotCalled(t, "UpdateOptOuts", mock.Anything, mock.Anything)
+	})
+
+	t.Run("failed getting opt outs", func(t *testing.T) {
+		repo := new(MockEmailRepo)
+		repo.On("GetOptOuts", 5).Return([]string{}, errors.New("database error"))
+
+		useCase, _ := newUseCase(repo, nil, nil)
+		_, err := useCase.UpdatePreferences(5, []entities.EmailPreference{})
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+
+	t.Run("failed updating opt outs", func(t *testing.T) {
+		repo := new(MockEmailRepo)
+		repo.On("GetOptOuts", 5).Return([]string{}, nil)
+		repo.On("UpdateOptOuts", 5, []string{}).Return(errors.New("database error"))
+
+		useCase, _ := newUseCase(repo, nil, nil)
+		_, err := useCase.UpdatePreferences(5, []entities.EmailPreference{})
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+}
@@ -0,0 +1,235 @@
+package job
+
+import (
+	"e-complaint-api/constants"
+	"e-complaint-api/cursor"
+	"e-complaint-api/entities"
+	"e-complaint-api/utils"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+type JobUseCase struct {
+	repository  entities.JobRepositoryInterface
+	lockTimeout time.Duration
+	mutex       sync.RWMutex
+	handlers    map[string]entities.JobHandler
+}
+
+// NewJobUseCase returns a job queue whose jobs are run by calling RunOnce. A job that
+// has been running for longer than lockTimeout is taken to belong to a worker that
+// stopped and is run again.
+func NewJobUseCase(repository entities.JobRepositoryInterface, lockTimeout time.Duration) *JobUseCase {
+	return &JobUseCase{
+		repository:  repository,
+		lockTimeout: lockTimeout,
+		handlers:    map[string]entities.JobHandler{},
+	}
+}
+
+// Register sets the handler that runs the jobs of a type.
+func (u *JobUseCase) Register(jobType string, handler entities.JobHandler) {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	u.handlers[jobType] = handler
+}
+
+// Enqueue stores a job that runs the handler of jobType with payload encoded as JSON
+// as soon as a worker is free.
+func (u *JobUseCase) Enqueue(jobType string, createdBy *int, payload interface{}) (entities.Job, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return entities.Job{}, constants.ErrInternalServerError
+	}
+
+	job := entities.Job{
+		Type:        jobType,
+		Payload:     string(data),
+		Status:      constants.JobStatusPending,
+		MaxAttempts: constants.JobMaxAttempts,
+		RunAt:       time.Now(),
+		CreatedBy:   createdBy,
+	}
+
+	if err := u.repository.Create(&job); err != nil {
+		return entities.Job{}, constants.ErrInternalServerError
+	}
+
+	return job, nil
+}
+
+// RunOnce runs the jobs that are due one after another until none is left. Several
+// workers may call it at the same time, each job is claimed by only one of them.
+func (u *JobUseCase) RunOnce() error {
+	for {
+		now := time.Now()
+		job, err := u.repository.Claim(now, now.Add(-u.lockTimeout))
+		if err != nil {
+			return constants.ErrInternalServerError
+		}
+		if job == nil {
+			return nil
+		}
+
+		if err := u.run(job); err != nil {
+			return err
+		}
+	}
+}
+
+// run runs a claimed job and stores its outcome. A failed job is retried with an
+// exponential backoff until it runs out of attempts, except when the error is one the
+// job itself caused, which running it again would not fix.
+func (u *JobUseCase) run(job *entities.Job) error {
+	var result string
+	var err error
+
+	u.mutex.RLock()
+	handler, ok := u.handlers[job.Type]
+	u.mutex.RUnlock()
+
+	retryable := true
+	if job.Attempts > job.MaxAttempts {
+		// the job was claimed again after its last attempt because its worker stopped
+		err = errors.New("worker stopped while running the job")
+		retryable = false
+	} else if !ok {
+		err = constants.ErrJobTypeNotRegistered
+		retryable = false
+	} else {
+		result, err = call(handler, []byte(job.Payload))
+		retryable = err != nil && utils.ConvertResponseCode(err) == http.StatusInternalServerError
+	}
+
+	job.Result = result
+	job.LockedAt = nil
+	if err == nil {
+		job.Status = constants.JobStatusDone
+		job.LastError = ""
+	} else if retryable && job.Attempts < job.MaxAttempts {
+		job.Status = constants.JobStatusPending
+		job.RunAt = time.Now().Add(backoff(job.Attempts))
+		job.LastError = err.Error()
+	} else {
+		job.Status = constants.JobStatusDead
+		job.LastError = err.Error()
+	}
+
+	if err := u.repository.Update(job); err != nil {
+		return constants.ErrInternalServerError
+	}
+
+	return nil
+}
+
+// call runs handler, turning a panic into an error so one broken job does not stop
+// the worker.
+func call(handler entities.JobHandler, payload []byte) (result string, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("job panicked: %v", recovered)
+		}
+	}()
+
+	return handler(payload)
+}
+
+// backoff is the delay before the next attempt of a job that failed attempts times.
+func backoff(attempts int) time.Duration {
+	delay := constants.JobRetryBaseDelay
+	for i := 1; i < attempts && delay < constants.JobRetryMaxDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, constants.JobRetryMaxDelay)
+}
+
+// GetByCursor returns the page of jobs after the one the cursor points at, newest
+// first, and the cursor of the next page. The filter may hold a status and a type.
+func (u *JobUseCase) GetByCursor(filter map[string]interface{}, limit int, token string) ([]entities.Job, string, error) {
+	if status, ok := filter["status"]; ok && !isStatus(status) {
+		return nil, "", constants.ErrInvalidJobStatus
+	}
+
+	afterID, err := cursor.DecodeInt(token, "id DESC")
+	if err != nil {
+		return nil, "", err
+	}
+
+	limit = cursor.Limit(limit)
+	jobs, err := u.repository.GetAfter(filter, limit+1, afterID)
+	if err != nil {
+		return nil, "", constants.ErrInternalServerError
+	}
+
+	jobs, next := cursor.Page(jobs, limit, "id DESC", func(job entities.Job) string {
+		return strconv.Itoa(job.ID)
+	})
+
+	return jobs, next, nil
+}
+
+func isStatus(status interface{}) bool {
+	switch status {
+	case constants.JobStatusPending, constants.JobStatusRunning, constants.JobStatusDone, constants.JobStatusDead:
+		return true
+	}
+
+	return false
+}
+
+func (u *JobUseCase) GetByID(id int) (entities.Job, error) {
+	job, err := u.repository.GetByID(id)
+	if err != nil {
+		if errors.Is(err, constants.ErrJobNotFound) {
+			return entities.Job{}, err
+		}
+		return entities.Job{}, constants.ErrInternalServerError
+	}
+
+	return job, nil
+}
+
+// GetOwn returns a job of jobType that adminID asked for. Jobs of other types or other
+// admins are reported as not found.
+func (u *JobUseCase) GetOwn(id int, jobType string, adminID int) (entities.Job, error) {
+	job, err := u.GetByID(id)
+	if err != nil {
+		return entities.Job{}, err
+	}
+
+	if job.Type != jobType || job.CreatedBy == nil || *job.CreatedBy != adminID {
+		return entities.Job{}, constants.ErrJobNotFound
+	}
+
+	return job, nil
+}
+
+// Retry gives a dead job a fresh set of attempts, starting right away.
+func (u *JobUseCase) Retry(id int) (entities.Job, error) {
+	job, err := u.GetByID(id)
+	if err != nil {
+		return entities.Job{}, err
+	}
+
+	if job.Status != constants.JobStatusDead {
+		return entities.Job{}, constants.ErrJobNotRetryable
+	}
+
+	job.Status = constants.JobStatusPending
+	job.Attempts = 0
+	job.RunAt = time.Now()
+	job.Result = ""
+
+	if err := u.repository.Update(&job); err != nil {
+		return entities.Job{}, constants.ErrInternalServerError
+	}
+
+	return job, nil
+}
@@ -0,0 +1,324 @@
+package job
+
+import (
+	"e-complaint-api/constants"
+	"e-complaint-api/entities"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockJobRepo struct {
+	mock.Mock
+}
+
+func (m *MockJobRepo) Create(job *entities.Job) error {
+	args := m.Called(job)
+	return args.Error(0)
+}
+
+func (m *MockJobRepo) Claim(now time.Time, staleBefore time.Time) (*entities.Job, error) {
+	args := m.Called(now, staleBefore)
+	return args.Get(0).(*entities.Job), args.Error(1)
+}
+
+func (m *MockJobRepo) Update(job *entities.Job) error {
+	args := m.Called(job)
+	return args.Error(0)
+}
+
+func (m *MockJobRepo) GetAfter(filter map[string]interface{}, limit int, afterID int) ([]entities.Job, error) {
+	args := m.Called(filter, limit, afterID)
+	return args.Get(0).([]entities.Job), args.Error(1)
+}
+
+func (m *MockJobRepo) GetByID(id int) (entities.Job, error) {
+	args := m.Called(id)
+	return args.Get(0).(entities.Job), args.Error(1)
+}
+
+func TestEnqueue(t *testing.T) {
+	adminID := 7
+
+	t.Run("success", func(t *testing.T) {
+		repo := new(MockJobRepo)
+		repo.On("Create", mock.MatchedBy(func(job *entities.Job) bool {
+			return job.Type == constants.JobTypeEmailOTP &&
+				job.Payload == `{"Email":"a@b.c"}` &&
+				job.Status == constants.JobStatusPending &&
+				job.MaxAttempts == constants.JobMaxAttempts &&
+				*job.CreatedBy == adminID
+		})).Return(nil)
+
+		job, err := NewJobUseCase(repo, time.Minute).Enqueue(constants.JobTypeEmailOTP, &adminID, map[string]string{"Email": "a@b.c"})
+		assert.NoError(t, err)
+		assert.Equal(t, constants.JobStatusPending, job.Status)
+	})
+
+	t.Run("failed payload is not json", func(t *testing.T) {
+		_, err := NewJobUseCase(new(MockJobRepo), time.Minute).Enqueue(constants.JobTypeEmailOTP, nil, make(chan int))
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+
+	t.Run("failed creating job", func(t *testing.T) {
+		repo := new(MockJobRepo)
+		repo.On("Create", mock.Anything).Return(errors.New("database error"))
+
+		_, err := NewJobUseCase(repo, time.Minute).Enqueue(constants.JobTypeEmailOTP, nil, nil)
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+}
+
+// runJob runs job through RunOnce with handler registered for its type, and returns
+// the job as it was stored afterwards.
+func runJob(t *testing.T, job entities.Job, handler entities.JobHandler) entities.Job {
+	repo := new(MockJobRepo)
+	repo.On("Claim", mock.Anything, mock.Anything).Return(&job, nil).Once()
+	repo.On("Claim", mock.Anything, mock.Anything).Return((*entities.Job)(nil), nil).Once()
+
+	var stored entities.Job
+	repo.On("Update", mock.Anything).Run(func(args mock.Arguments) {
+		stored = *args.Get(0).(*entities.Job)
+	}).Return(nil)
+
+	useCase := NewJobUseCase(repo, time.Minute)
+	if handler != nil {
+		useCase.Register(job.Type, handler)
+	}
+
+	assert.NoError(t, useCase.RunOnce())
+	return stored
+}
+
+func TestRunOnce(t *testing.T) {
+	now := time.Now()
+	claimed := entities.Job{ID: 1, Type: constants.JobTypeEmailOTP, Payload: `{}`, Status: constants.JobStatusRunning, Attempts: 1, MaxAttempts: 3, LockedAt: &now, LastError: "smtp timeout"}
+
+	t.Run("success", func(t *testing.T) {
+		job := runJob(t, claimed, func(payload []byte) (string, error) {
+			assert.Equal(t, `{}`, string(payload))
+			return "sent", nil
+		})
+
+		assert.Equal(t, constants.JobStatusDone, job.Status)
+		assert.Equal(t, "sent", job.Result)
+		assert.Empty(t, job.LastError)
+		assert.Nil(t, job.LockedAt)
+	})
+
+	t.Run("failed attempt is retried with backoff", func(t *testing.T) {
+		job := runJob(t, claimed, func(payload []byte) (string, error) {
+			return "", errors.New("smtp timeout")
+		})
+
+		assert.Equal(t, constants.JobStatusPending, job.Status)
+		assert.Equal(t, "smtp timeout", job.LastError)
+		assert.WithinDuration(t, time.Now().Add(constants.JobRetryBaseDelay), job.RunAt, time.Second)
+	})
+
+	t.Run("failed last attempt is dead", func(t *testing.T) {
+		lastAttempt := claimed
+		lastAttempt.Attempts = 3
+
+		job := runJob(t, lastAttempt, func(payload []byte) (string, error) {
+			return "", errors.New("smtp timeout")
+		})
+
+		assert.Equal(t, constants.JobStatusDead, job.Status)
+	})
+
+	t.Run("failed by the job itself is dead", func(t *testing.T) {
+		job := runJob(t, claimed, func(payload []byte) (string, error) {
+			return "report", constants.ErrImportHasInvalidRows
+		})
+
+		assert.Equal(t, constants.JobStatusDead, job.Status)
+		assert.Equal(t, "report", job.Result)
+		assert.Equal(t, constants.ErrImportHasInvalidRows.Error(), job.LastError)
+	})
+
+	t.Run("failed panic is retried", func(t *testing.T) {
+		job := runJob(t, claimed, func(payload []byte) (string, error) {
+			panic("nil map")
+		})
+
+		assert.Equal(t, constants.JobStatusPending, job.Status)
+		assert.Equal(t, "job panicked: nil map", job.LastError)
+	})
+
+	t.Run("failed type without handler is dead", func(t *testing.T) {
+		job := runJob(t, claimed, nil)
+
+		assert.Equal(t, constants.JobStatusDead, job.Status)
+		assert.Equal(t, constants.ErrJobTypeNotRegistered.Error(), job.LastError)
+	})
+
+	t.Run("failed worker stopped during last attempt", func(t *testing.T) {
+		reclaimed := claimed
+		reclaimed.Attempts = 4
+
+		job := runJob(t, reclaimed, func(payload []byte) (string, error) {
+			t.Fatal("job ran after its last attempt")
+			return "", nil
+		})
+
+		assert.Equal(t, constants.JobStatusDead, job.Status)
+	})
+
+	t.Run("failed claiming job", func(t *testing.T) {
+		repo := new(MockJobRepo)
+		repo.On("Claim", mock.Anything, mock.Anything).Return((*entities.Job)(nil), errors.New("database error"))
+
+		assert.Equal(t, constants.ErrInternalServerError, NewJobUseCase(repo, time.Minute).RunOnce())
+	})
+
+	t.Run("failed updating job", func(t *testing.T) {
+		job := claimed
+		repo := new(MockJobRepo)
+		repo.On("Claim", mock.Anything, mock.Anything).Return(&job, nil)
+		repo.On("Update", mock.Anything).Return(errors.New("database error"))
+
+		useCase := NewJobUseCase(repo, time.Minute)
+		useCase.Register(job.Type, func(payload []byte) (string, error) {
+			return "", nil
+		})
+
+		assert.Equal(t, constants.ErrInternalServerError, useCase.RunOnce())
+	})
+}
+
+func TestBackoff(t *testing.T) {
+	assert.Equal(t, constants.JobRetryBaseDelay, backoff(1))
+	assert.Equal(t, 4*constants.JobRetryBaseDelay, backoff(3))
+	assert.Equal(t, constants.JobRetryMaxDelay, backoff(50))
+}
+
+func TestGetByCursor(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		filter := map[string]interface{}{"status": constants.JobStatusDead}
+		repo := new(MockJobRepo)
+		repo.On("GetAfter", filter, 3, 0).Return([]entities.Job{{ID: 9}, {ID: 8}, {ID: 7}}, nil)
+
+		jobs, next, err := NewJobUseCase(repo, time.Minute).GetByCursor(filter, 2, "")
+		assert.NoError(t, err)
+		assert.Len(t, jobs, 2)
+		assert.NotEmpty(t, next)
+	})
+
+	t.Run("failed invalid status", func(t *testing.T) {
+		_, _, err := NewJobUseCase(new(MockJobRepo), time.Minute).GetByCursor(map[string]interface{}{"status": "failed"}, 2, "")
+		assert.Equal(t, constants.ErrInvalidJobStatus, err)
+	})
+
+	t.Run("failed invalid cursor", func(t *testing.T) {
+		_, _, err := NewJobUseCase(new(MockJobRepo), time.Minute).GetByCursor(nil, 2, "!")
+		assert.Equal(t, constants.ErrInvalidCursor, err)
+	})
+
+	t.Run("failed getting jobs", func(t *testing.T) {
+		repo := new(MockJobRepo)
+		repo.On("GetAfter", mock.Anything, mock.Anything, mock.Anything).Return([]entities.Job(nil), errors.New("database error"))
+
+		_, _, err := NewJobUseCase(repo, time.Minute).GetByCursor(nil, 2, "")
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+}
+
+func TestGetByID(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		repo := new(MockJobRepo)
+		repo.On("GetByID", 1).Return(entities.Job{ID: 1}, nil)
+
+		job, err := NewJobUseCase(repo, time.Minute).GetByID(1)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, job.ID)
+	})
+
+	t.Run("failed not found", func(t *testing.T) {
+		repo := new(MockJobRepo)
+		repo.On("GetByID", 1).Return(entities.Job{}, constants.ErrJobNotFound)
+
+		_, err := NewJobUseCase(repo, time.Minute).GetByID(1)
+		assert.Equal(t, constants.ErrJobNotFound, err)
+	})
+
+	t.Run("failed getting job", func(t *testing.T) {
+		repo := new(MockJobRepo)
+		repo.On("GetByID", 1).Return(entities.Job{}, errors.New("database error"))
+
+		_, err := NewJobUseCase(repo, time.Minute).GetByID(1)
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+}
+
+func TestGetOwn(t *testing.T) {
+	adminID := 7
+	repo := new(MockJobRepo)
+	repo.On("GetByID", 1).Return(entities.Job{ID: 1, Type: constants.JobTypeComplaintExport, CreatedBy: &adminID}, nil)
+	repo.On("GetByID", 2).Return(entities.Job{ID: 2, Type: constants.JobTypeEmailOTP}, nil)
+	repo.On("GetByID", 3).Return(entities.Job{}, constants.ErrJobNotFound)
+	useCase := NewJobUseCase(repo, time.Minute)
+
+	t.Run("success", func(t *testing.T) {
+		job, err := useCase.GetOwn(1, constants.JobTypeComplaintExport, adminID)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, job.ID)
+	})
+
+	t.Run("failed job of another admin", func(t *testing.T) {
+		_, err := useCase.GetOwn(1, constants.JobTypeComplaintExport, 8)
+		assert.Equal(t, constants.ErrJobNotFound, err)
+	})
+
+	t.Run("failed job of another type", func(t *testing.T) {
+		_, err := useCase.GetOwn(2, constants.JobTypeComplaintExport, adminID)
+		assert.Equal(t, constants.ErrJobNotFound, err)
+	})
+
+	t.Run("failed not found", func(t *testing.T) {
+		_, err := useCase.GetOwn(3, constants.JobTypeComplaintExport, adminID)
+		assert.Equal(t, constants.ErrJobNotFound, err)
+	})
+}
+
+func TestRetry(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		repo := new(MockJobRepo)
+		repo.On("GetByID", 1).Return(entities.Job{ID: 1, Status: constants.JobStatusDead, Attempts: 5, Result: "report"}, nil)
+		repo.On("Update", mock.Anything).Return(nil)
+
+		job, err := NewJobUseCase(repo, time.Minute).Retry(1)
+		assert.NoError(t, err)
+		assert.Equal(t, constants.JobStatusPending, job.Status)
+		assert.Zero(t, job.Attempts)
+		assert.Empty(t, job.Result)
+	})
+
+	t.Run("failed job is not dead", func(t *testing.T) {
+		repo := new(MockJobRepo)
+		repo.On("GetByID", 1).Return(entities.Job{ID: 1, Status: constants.JobStatusPending}, nil)
+
+		_, err := NewJobUseCase(repo, time.Minute).Retry(1)
+		assert.Equal(t, constants.ErrJobNotRetryable, err)
+	})
+
+	t.Run("failed not found", func(t *testing.T) {
+		repo := new(MockJobRepo)
+		repo.On("GetByID", 1).Return(entities.Job{}, constants.ErrJobNotFound)
+
+		_, err := NewJobUseCase(repo, time.Minute).Retry(1)
+		assert.Equal(t, constants.ErrJobNotFound, err)
+	})
+
+	t.Run("failed updating job", func(t *testing.T) {
+		repo := new(MockJobRepo)
+		repo.On("GetByID", 1).Return(entities.Job{ID: 1, Status: constants.JobStatusDead}, nil)
+		repo.On("Update", mock.Anything).Return(errors.New("database error"))
+
+		_, err := NewJobUseCase(repo, time.Minute).Retry(1)
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+}
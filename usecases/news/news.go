@@ -2,17 +2,22 @@ package news
 
 import (
 	"e-complaint-api/constants"
+	"e-complaint-api/cursor"
 	"e-complaint-api/entities"
+	"e-complaint-api/queryspec"
+	"strconv"
 	"strings"
 )
 
 type NewsUseCase struct {
-	repository entities.NewsRepositoryInterface
+	repository   entities.NewsRepositoryInterface
+	searchEngine entities.SearchEngineInterface
 }
 
-func NewNewsUseCase(repository entities.NewsRepositoryInterface) *NewsUseCase {
+func NewNewsUseCase(repository entities.NewsRepositoryInterface, searchEngine entities.SearchEngineInterface) *NewsUseCase {
 	return &NewsUseCase{
-		repository: repository,
+		repository:   repository,
+		searchEngine: searchEngine,
 	}
 }
 
@@ -23,12 +28,14 @@ func (u *NewsUseCase) GetPaginated(limit int, page int, search string, filter ma
 		return nil, constants.ErrLimitMustBeFilled
 	}
 
-	if sortBy == "" {
-		sortBy = "created_at"
+	search, filter, err := u.fullTextSearch(search, filter)
+	if err != nil {
+		return nil, err
 	}
 
-	if sortType == "" {
-		sortType = "DESC"
+	sortBy, sortType, err = queryspec.News.Sort(sortBy, sortType, filter["ids"] != nil)
+	if err != nil {
+		return nil, err
 	}
 
 	news, err := u.repository.GetPaginated(limit, page, search, filter, sortBy, sortType)
@@ -41,6 +48,11 @@ func (u *NewsUseCase) GetPaginated(limit int, page int, search string, filter ma
 
 func (u *NewsUseCase) GetMetaData(limit int, page int, search string, filter map[string]interface{}) (entities.Metadata, error) {
 	var pagination entities.Pagination
+	search, filter, err := u.fullTextSearch(search, filter)
+	if err != nil {
+		return entities.Metadata{}, err
+	}
+
 	metaData, err := u.repository.GetMetaData(limit, page, search, filter)
 
 	if err != nil {
@@ -82,6 +94,107 @@ func (u *NewsUseCase) GetMetaData(limit int, page int, search string, filter map
 	return metaData, nil
 }
 
+// GetByCursor returns the page of news after the one the cursor points at and the cursor
+// of the next page, which is empty on the last page.
+func (u *NewsUseCase) GetByCursor(limit int, token string, search string, filter map[string]interface{}, sortBy string, sortType string) ([]entities.News, string, error) {
+	search, filter, err := u.fullTextSearch(search, filter)
+	if err != nil {
+		return nil, "", err
+	}
+
+	sortBy, sortType, err = queryspec.News.Sort(sortBy, sortType, filter["ids"] != nil)
+	if err != nil {
+		return nil, "", err
+	}
+
+	sort := sortBy + " " + sortType
+	id, afterValue, err := cursor.DecodeAfter(token, sort)
+	if err != nil {
+		return nil, "", err
+	}
+
+	afterID := 0
+	if id != "" {
+		if afterID, err = strconv.Atoi(id); err != nil {
+			return nil, "", constants.ErrInvalidCursor
+		}
+	}
+
+	// the hits of a full-text search are already in order, the page starts after the
+	// hit the cursor points at
+	if sortBy == queryspec.Relevance && afterID != 0 {
+		filter["ids"] = idsAfter(filter["ids"].([]int), afterID)
+		afterID = 0
+	}
+
+	limit = cursor.Limit(limit)
+	news, err := u.repository.GetAfter(limit+1, afterID, afterValue, search, filter, sortBy, sortType)
+	if err != nil {
+		return nil, "", constants.ErrInternalServerError
+	}
+
+	news, next := cursor.PageAfter(news, limit, sort, func(news entities.News) (string, string) {
+		return strconv.Itoa(news.ID), sortValue(news, sortBy)
+	})
+
+	return news, next, nil
+}
+
+// sortValue returns the value of a news in the column sortBy, the relevance of a hit is
+// its place in the ids filter and has no value.
+func sortValue(news entities.News, sortBy string) string {
+	switch sortBy {
+	case "created_at":
+		return cursor.Time(news.CreatedAt)
+	case "updated_at":
+		return cursor.Time(news.UpdatedAt)
+	case "title":
+		return news.Title
+	case "total_likes":
+		return strconv.Itoa(news.TotalLikes)
+	default:
+		return ""
+	}
+}
+
+func idsAfter(ids []int, id int) []int {
+	for i := range ids {
+		if ids[i] == id {
+			return ids[i+1:]
+		}
+	}
+
+	return []int{}
+}
+
+// fullTextSearch narrows the filter down to the IDs of the news that match search, best
+// match first, and clears search.
+func (u *NewsUseCase) fullTextSearch(search string, filter map[string]interface{}) (string, map[string]interface{}, error) {
+	search = strings.TrimSpace(search)
+	if search == "" {
+		return search, filter, nil
+	}
+
+	hits, err := u.searchEngine.Search(constants.SearchNews, search, constants.MaxSearchHits)
+	if err != nil {
+		return "", nil, constants.ErrInternalServerError
+	}
+
+	ids := make([]int, 0, len(hits))
+	for _, hit := range hits {
+		if id, err := strconv.Atoi(hit.ID); err == nil {
+			ids = append(ids, id)
+		}
+	}
+
+	narrowed := map[string]interface{}{"ids": ids}
+	for key, value := range filter {
+		narrowed[key] = value
+	}
+
+	return "", narrowed, nil
+}
+
 func (u *NewsUseCase) GetByID(id int) (entities.News, error) {
 	news, err := u.repository.GetByID(id)
 	if err != nil {
@@ -2,6 +2,7 @@ package news
 
 import (
 	"e-complaint-api/constants"
+	"e-complaint-api/cursor"
 	"e-complaint-api/entities"
 	"errors"
 	"github.com/stretchr/testify/assert"
@@ -23,6 +24,11 @@ func (m *MockNews) GetMetaData(limit int, page int, search string, filter map[st
 	return args.Get(0).(entities.Metadata), args.Error(1)
 }
 
+func (m *MockNews) GetAfter(limit int, afterID int, afterValue string, search string, filter map[string]interface{}, sortBy string, sortType string) ([]entities.News, error) {
+	args := m.Called(limit, afterID, afterValue, search, filter, sortBy, sortType)
+	return args.Get(0).([]entities.News), args.Error(1)
+}
+
 func (m *MockNews) GetByID(id int) (entities.News, error) {
 	args := m.Called(id)
 	return args.Get(0).(entities.News), args.Error(1)
@@ -43,10 +49,19 @@ func (m *MockNews) Delete(id int) error {
 	return args.Error(0)
 }
 
+type MockSearchEngine struct {
+	mock.Mock
+}
+
+func (m *MockSearchEngine) Search(collection string, query string, limit int) ([]entities.SearchHit, error) {
+	args := m.Called(collection, query, limit)
+	return args.Get(0).([]entities.SearchHit), args.Error(1)
+}
+
 func TestNewsUseCase_GetPaginated(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		mockNews := new(MockNews)
-		useCase := NewNewsUseCase(mockNews)
+		useCase := NewNewsUseCase(mockNews, new(MockSearchEngine))
 		news := []entities.News{
 			{
 				ID:         1,
@@ -72,7 +87,7 @@ func TestNewsUseCase_GetPaginated(t *testing.T) {
 
 	t.Run("success - sort by is empty", func(t *testing.T) {
 		mockNews := new(MockNews)
-		useCase := NewNewsUseCase(mockNews)
+		useCase := NewNewsUseCase(mockNews, new(MockSearchEngine))
 		mockNews.On("GetPaginated", 10, 1, "", map[string]interface{}{}, "created_at", "DESC").Return([]entities.News{}, nil)
 		_, err := useCase.GetPaginated(10, 1, "", map[string]interface{}{}, "", "DESC")
 		assert.NoError(t, err)
@@ -80,7 +95,7 @@ func TestNewsUseCase_GetPaginated(t *testing.T) {
 
 	t.Run("success - sort type is empty", func(t *testing.T) {
 		mockNews := new(MockNews)
-		useCase := NewNewsUseCase(mockNews)
+		useCase := NewNewsUseCase(mockNews, new(MockSearchEngine))
 		mockNews.On("GetPaginated", 10, 1, "", map[string]interface{}{}, "created_at", "DESC").Return([]entities.News{}, nil)
 		_, err := useCase.GetPaginated(10, 1, "", map[string]interface{}{}, "created_at", "")
 		assert.NoError(t, err)
@@ -88,7 +103,7 @@ func TestNewsUseCase_GetPaginated(t *testing.T) {
 
 	t.Run("error - page must be filled", func(t *testing.T) {
 		mockNews := new(MockNews)
-		useCase := NewNewsUseCase(mockNews)
+		useCase := NewNewsUseCase(mockNews, new(MockSearchEngine))
 		_, err := useCase.GetPaginated(10, 0, "", map[string]interface{}{}, "created_at", "DESC")
 		assert.Error(t, err)
 		assert.Equal(t, constants.ErrPageMustBeFilled, err)
@@ -96,15 +111,34 @@ func TestNewsUseCase_GetPaginated(t *testing.T) {
 
 	t.Run("error - limit must be filled", func(t *testing.T) {
 		mockNews := new(MockNews)
-		useCase := NewNewsUseCase(mockNews)
+		useCase := NewNewsUseCase(mockNews, new(MockSearchEngine))
 		_, err := useCase.GetPaginated(0, 1, "", map[string]interface{}{}, "created_at", "DESC")
 		assert.Error(t, err)
 		assert.Equal(t, constants.ErrLimitMustBeFilled, err)
 	})
 
+	t.Run("success - full-text search sorted by relevance", func(t *testing.T) {
+		mockNews := new(MockNews)
+		mockSearchEngine := new(MockSearchEngine)
+		useCase := NewNewsUseCase(mockNews, mockSearchEngine)
+		mockSearchEngine.On("Search", constants.SearchNews, "perbaikan jalan", constants.MaxSearchHits).Return([]entities.SearchHit{{ID: "3"}, {ID: "1"}}, nil)
+		mockNews.On("GetPaginated", 10, 1, "", map[string]interface{}{"ids": []int{3, 1}, "category_id": 2}, "relevance", "DESC").Return([]entities.News{{ID: 3}, {ID: 1}}, nil)
+		news, err := useCase.GetPaginated(10, 1, "perbaikan jalan", map[string]interface{}{"category_id": 2}, "", "")
+		assert.NoError(t, err)
+		assert.Equal(t, []entities.News{{ID: 3}, {ID: 1}}, news)
+	})
+
+	t.Run("error - search engine error", func(t *testing.T) {
+		mockSearchEngine := new(MockSearchEngine)
+		useCase := NewNewsUseCase(new(MockNews), mockSearchEngine)
+		mockSearchEngine.On("Search", constants.SearchNews, "banjir", constants.MaxSearchHits).Return([]entities.SearchHit(nil), errors.New("database error"))
+		_, err := useCase.GetPaginated(10, 1, "banjir", nil, "", "")
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+
 	t.Run("error", func(t *testing.T) {
 		mockNews := new(MockNews)
-		useCase := NewNewsUseCase(mockNews)
+		useCase := NewNewsUseCase(mockNews, new(MockSearchEngine))
 		mockNews.On("GetPaginated", 10, 1, "", map[string]interface{}{}, "created_at", "DESC").Return([]entities.News{}, constants.ErrInternalServerError)
 		_, err := useCase.GetPaginated(10, 1, "", map[string]interface{}{}, "created_at", "DESC")
 		assert.Error(t, err)
@@ -113,15 +147,23 @@ func TestNewsUseCase_GetPaginated(t *testing.T) {
 
 	t.Run("invalid sort type", func(t *testing.T) {
 		mockNews := new(MockNews)
-		useCase := NewNewsUseCase(mockNews)
-		mockNews.On("GetPaginated", 10, 1, "", map[string]interface{}{}, "created_at", "INVALID").Return([]entities.News{}, errors.New("invalid sort type"))
+		useCase := NewNewsUseCase(mockNews, new(MockSearchEngine))
 		_, err := useCase.GetPaginated(10, 1, "", map[string]interface{}{}, "created_at", "INVALID")
-		assert.Error(t, err)
+		assert.Equal(t, constants.ErrInvalidSortType, err)
+		mockNews.AssertNotCalled(t, "GetPaginated")
+	})
+
+	t.Run("invalid sort by", func(t *testing.T) {
+		mockNews := new(MockNews)
+		useCase := NewNewsUseCase(mockNews, new(MockSearchEngine))
+		_, err := useCase.GetPaginated(10, 1, "", map[string]interface{}{}, "title; DROP TABLE news", "DESC")
+		assert.Equal(t, constants.ErrInvalidSortBy, err)
+		mockNews.AssertNotCalled(t, "GetPaginated")
 	})
 
 	t.Run("error - page must be filled", func(t *testing.T) {
 		mockNews := new(MockNews)
-		useCase := NewNewsUseCase(mockNews)
+		useCase := NewNewsUseCase(mockNews, new(MockSearchEngine))
 		_, err := useCase.GetPaginated(10, 0, "", map[string]interface{}{}, "created_at", "DESC")
 		assert.Error(t, err)
 		assert.Equal(t, constants.ErrPageMustBeFilled, err)
@@ -129,7 +171,7 @@ func TestNewsUseCase_GetPaginated(t *testing.T) {
 
 	t.Run("error - limit must be filled", func(t *testing.T) {
 		mockNews := new(MockNews)
-		useCase := NewNewsUseCase(mockNews)
+		useCase := NewNewsUseCase(mockNews, new(MockSearchEngine))
 		_, err := useCase.GetPaginated(0, 1, "", map[string]interface{}{}, "created_at", "DESC")
 		assert.Error(t, err)
 		assert.Equal(t, constants.ErrLimitMustBeFilled, err)
@@ -137,10 +179,90 @@ func TestNewsUseCase_GetPaginated(t *testing.T) {
 
 }
 
+func TestNewsUseCase_GetByCursor(t *testing.T) {
+	t.Run("success - first page", func(t *testing.T) {
+		mockNews := new(MockNews)
+		useCase := NewNewsUseCase(mockNews, new(MockSearchEngine))
+		news := []entities.News{{ID: 9, TotalLikes: 4}, {ID: 8, TotalLikes: 4}, {ID: 5, TotalLikes: 1}}
+		mockNews.On("GetAfter", 3, 0, "", "", map[string]interface{}(nil), "total_likes", "DESC").Return(news, nil)
+		result, nextCursor, err := useCase.GetByCursor(2, "", "", nil, "total_likes", "")
+		assert.NoError(t, err)
+		assert.Equal(t, news[:2], result)
+		assert.Equal(t, cursor.EncodeAfter("8", "4", "total_likes DESC"), nextCursor)
+	})
+
+	t.Run("success - last page", func(t *testing.T) {
+		mockNews := new(MockNews)
+		useCase := NewNewsUseCase(mockNews, new(MockSearchEngine))
+		mockNews.On("GetAfter", 3, 8, "4", "", map[string]interface{}(nil), "total_likes", "ASC").Return([]entities.News{{ID: 5}}, nil)
+		result, nextCursor, err := useCase.GetByCursor(2, cursor.EncodeAfter("8", "4", "total_likes ASC"), "", nil, "total_likes", "asc")
+		assert.NoError(t, err)
+		assert.Equal(t, []entities.News{{ID: 5}}, result)
+		assert.Equal(t, "", nextCursor)
+	})
+
+	t.Run("success - full-text search continues after the cursor", func(t *testing.T) {
+		mockNews := new(MockNews)
+		mockSearchEngine := new(MockSearchEngine)
+		useCase := NewNewsUseCase(mockNews, mockSearchEngine)
+		mockSearchEngine.On("Search", constants.SearchNews, "banjir", constants.MaxSearchHits).Return([]entities.SearchHit{{ID: "3"}, {ID: "1"}, {ID: "7"}}, nil)
+		mockNews.On("GetAfter", 3, 0, "", "", map[string]interface{}{"ids": []int{7}}, "relevance", "DESC").Return([]entities.News{{ID: 7}}, nil)
+		result, nextCursor, err := useCase.GetByCursor(2, cursor.Encode("1", "relevance DESC"), "banjir", nil, "", "")
+		assert.NoError(t, err)
+		assert.Equal(t, []entities.News{{ID: 7}}, result)
+		assert.Equal(t, "", nextCursor)
+	})
+
+	t.Run("success - full-text search with unknown cursor", func(t *testing.T) {
+		mockNews := new(MockNews)
+		mockSearchEngine := new(MockSearchEngine)
+		useCase := NewNewsUseCase(mockNews, mockSearchEngine)
+		mockSearchEngine.On("Search", constants.SearchNews, "banjir", constants.MaxSearchHits).Return([]entities.SearchHit{{ID: "3"}}, nil)
+		mockNews.On("GetAfter", 3, 0, "", "", map[string]interface{}{"ids": []int{}}, "relevance", "DESC").Return([]entities.News{}, nil)
+		result, _, err := useCase.GetByCursor(2, cursor.Encode("1", "relevance DESC"), "banjir", nil, "", "")
+		assert.NoError(t, err)
+		assert.Empty(t, result)
+	})
+
+	t.Run("error - invalid cursor", func(t *testing.T) {
+		useCase := NewNewsUseCase(new(MockNews), new(MockSearchEngine))
+		_, _, err := useCase.GetByCursor(2, cursor.Encode("8", "created_at DESC"), "", nil, "title", "asc")
+		assert.Equal(t, constants.ErrInvalidCursor, err)
+	})
+
+	t.Run("error - cursor id not a number", func(t *testing.T) {
+		useCase := NewNewsUseCase(new(MockNews), new(MockSearchEngine))
+		_, _, err := useCase.GetByCursor(2, cursor.EncodeAfter("C-1", "4", "total_likes DESC"), "", nil, "total_likes", "")
+		assert.Equal(t, constants.ErrInvalidCursor, err)
+	})
+
+	t.Run("error - invalid sort by", func(t *testing.T) {
+		useCase := NewNewsUseCase(new(MockNews), new(MockSearchEngine))
+		_, _, err := useCase.GetByCursor(2, "", "", nil, "content", "")
+		assert.Equal(t, constants.ErrInvalidSortBy, err)
+	})
+
+	t.Run("error - search engine error", func(t *testing.T) {
+		mockSearchEngine := new(MockSearchEngine)
+		useCase := NewNewsUseCase(new(MockNews), mockSearchEngine)
+		mockSearchEngine.On("Search", constants.SearchNews, "banjir", constants.MaxSearchHits).Return([]entities.SearchHit(nil), errors.New("database error"))
+		_, _, err := useCase.GetByCursor(2, "", "banjir", nil, "", "")
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+
+	t.Run("error - internal server error", func(t *testing.T) {
+		mockNews := new(MockNews)
+		useCase := NewNewsUseCase(mockNews, new(MockSearchEngine))
+		mockNews.On("GetAfter", constants.DefaultCursorLimit+1, 0, "", "", map[string]interface{}(nil), "created_at", "DESC").Return([]entities.News(nil), errors.New("database error"))
+		_, _, err := useCase.GetByCursor(0, "", "", nil, "", "")
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+}
+
 func TestNewsUseCase_Create(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		mockNews := new(MockNews)
-		useCase := NewNewsUseCase(mockNews)
+		useCase := NewNewsUseCase(mockNews, new(MockSearchEngine))
 		news := entities.News{
 			Title:      "title",
 			Content:    "content",
@@ -153,7 +275,7 @@ func TestNewsUseCase_Create(t *testing.T) {
 
 	t.Run("error", func(t *testing.T) {
 		mockNews := new(MockNews)
-		useCase := NewNewsUseCase(mockNews)
+		useCase := NewNewsUseCase(mockNews, new(MockSearchEngine))
 		news := entities.News{
 			Title:      "title",
 			Content:    "content",
@@ -166,7 +288,7 @@ func TestNewsUseCase_Create(t *testing.T) {
 
 	t.Run("empty title", func(t *testing.T) {
 		mockNews := new(MockNews)
-		useCase := NewNewsUseCase(mockNews)
+		useCase := NewNewsUseCase(mockNews, new(MockSearchEngine))
 		news := entities.News{
 			Title:      "",
 			Content:    "content",
@@ -179,7 +301,7 @@ func TestNewsUseCase_Create(t *testing.T) {
 
 	t.Run("empty content", func(t *testing.T) {
 		mockNews := new(MockNews)
-		useCase := NewNewsUseCase(mockNews)
+		useCase := NewNewsUseCase(mockNews, new(MockSearchEngine))
 		news := entities.News{
 			Title:      "title",
 			Content:    "",
@@ -192,7 +314,7 @@ func TestNewsUseCase_Create(t *testing.T) {
 
 	t.Run("empty category id", func(t *testing.T) {
 		mockNews := new(MockNews)
-		useCase := NewNewsUseCase(mockNews)
+		useCase := NewNewsUseCase(mockNews, new(MockSearchEngine))
 		news := entities.News{
 			Title:      "title",
 			Content:    "content",
@@ -205,7 +327,7 @@ func TestNewsUseCase_Create(t *testing.T) {
 
 	t.Run("error - category not found due to reference error", func(t *testing.T) {
 		mockNews := new(MockNews)
-		useCase := NewNewsUseCase(mockNews)
+		useCase := NewNewsUseCase(mockNews, new(MockSearchEngine))
 		news := entities.News{
 			Title:      "title",
 			Content:    "content",
@@ -221,7 +343,7 @@ func TestNewsUseCase_Create(t *testing.T) {
 func TestNewsUseCase_GetByID(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		mockNews := new(MockNews)
-		useCase := NewNewsUseCase(mockNews)
+		useCase := NewNewsUseCase(mockNews, new(MockSearchEngine))
 		news := entities.News{
 			ID:         1,
 			Title:      "title",
@@ -235,7 +357,7 @@ func TestNewsUseCase_GetByID(t *testing.T) {
 
 	t.Run("error", func(t *testing.T) {
 		mockNews := new(MockNews)
-		useCase := NewNewsUseCase(mockNews)
+		useCase := NewNewsUseCase(mockNews, new(MockSearchEngine))
 		mockNews.On("GetByID", 1).Return(entities.News{}, constants.ErrInternalServerError)
 		_, err := useCase.GetByID(1)
 		assert.Error(t, err)
@@ -245,7 +367,7 @@ func TestNewsUseCase_GetByID(t *testing.T) {
 func TestNewsUseCase_Delete(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		mockNews := new(MockNews)
-		useCase := NewNewsUseCase(mockNews)
+		useCase := NewNewsUseCase(mockNews, new(MockSearchEngine))
 		mockNews.On("Delete", 1).Return(nil)
 		err := useCase.Delete(1)
 		assert.NoError(t, err)
@@ -253,7 +375,7 @@ func TestNewsUseCase_Delete(t *testing.T) {
 
 	t.Run("error", func(t *testing.T) {
 		mockNews := new(MockNews)
-		useCase := NewNewsUseCase(mockNews)
+		useCase := NewNewsUseCase(mockNews, new(MockSearchEngine))
 		mockNews.On("Delete", 1).Return(constants.ErrInternalServerError)
 		err := useCase.Delete(1)
 		assert.Error(t, err)
@@ -263,7 +385,7 @@ func TestNewsUseCase_Delete(t *testing.T) {
 func TestNewsUseCase_Update(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		mockNews := new(MockNews)
-		useCase := NewNewsUseCase(mockNews)
+		useCase := NewNewsUseCase(mockNews, new(MockSearchEngine))
 		news := entities.News{
 			ID:         1,
 			Title:      "title",
@@ -277,7 +399,7 @@ func TestNewsUseCase_Update(t *testing.T) {
 
 	t.Run("error", func(t *testing.T) {
 		mockNews := new(MockNews)
-		useCase := NewNewsUseCase(mockNews)
+		useCase := NewNewsUseCase(mockNews, new(MockSearchEngine))
 		news := entities.News{
 			ID:         1,
 			Title:      "title",
@@ -291,7 +413,7 @@ func TestNewsUseCase_Update(t *testing.T) {
 
 	t.Run("empty title", func(t *testing.T) {
 		mockNews := new(MockNews)
-		useCase := NewNewsUseCase(mockNews)
+		useCase := NewNewsUseCase(mockNews, new(MockSearchEngine))
 		news := entities.News{
 			ID:         1,
 			Title:      "",
@@ -305,7 +427,7 @@ func TestNewsUseCase_Update(t *testing.T) {
 
 	t.Run("empty content", func(t *testing.T) {
 		mockNews := new(MockNews)
-		useCase := NewNewsUseCase(mockNews)
+		useCase := NewNewsUseCase(mockNews, new(MockSearchEngine))
 		news := entities.News{
 			ID:         1,
 			Title:      "title",
@@ -319,7 +441,7 @@ func TestNewsUseCase_Update(t *testing.T) {
 
 	t.Run("empty category id", func(t *testing.T) {
 		mockNews := new(MockNews)
-		useCase := NewNewsUseCase(mockNews)
+		useCase := NewNewsUseCase(mockNews, new(MockSearchEngine))
 		news := entities.News{
 			ID:         1,
 			Title:      "title",
@@ -333,7 +455,7 @@ func TestNewsUseCase_Update(t *testing.T) {
 
 	t.Run("error - category not found due to reference error", func(t *testing.T) {
 		mockNews := new(MockNews)
-		useCase := NewNewsUseCase(mockNews)
+		useCase := NewNewsUseCase(mockNews, new(MockSearchEngine))
 		news := entities.News{
 			ID:         1,
 			Title:      "title",
@@ -350,7 +472,7 @@ func TestNewsUseCase_Update(t *testing.T) {
 func TestNewsUseCase_GetMetaData(t *testing.T) {
 	t.Run("success - with limit and page", func(t *testing.T) {
 		mockNews := new(MockNews)
-		useCase := NewNewsUseCase(mockNews)
+		useCase := NewNewsUseCase(mockNews, new(MockSearchEngine))
 		mockNews.On("GetMetaData", 10, 1, "", map[string]interface{}{}).Return(entities.Metadata{TotalData: 100}, nil)
 		metaData, err := useCase.GetMetaData(10, 1, "", map[string]interface{}{})
 		assert.NoError(t, err)
@@ -364,7 +486,7 @@ func TestNewsUseCase_GetMetaData(t *testing.T) {
 
 	t.Run("success - without limit and page", func(t *testing.T) {
 		mockNews := new(MockNews)
-		useCase := NewNewsUseCase(mockNews)
+		useCase := NewNewsUseCase(mockNews, new(MockSearchEngine))
 		mockNews.On("GetMetaData", 0, 0, "", map[string]interface{}{}).Return(entities.Metadata{TotalData: 100}, nil)
 		metaData, err := useCase.GetMetaData(0, 0, "", map[string]interface{}{})
 		assert.NoError(t, err)
@@ -378,7 +500,7 @@ func TestNewsUseCase_GetMetaData(t *testing.T) {
 
 	t.Run("success - current page is last page", func(t *testing.T) {
 		mockNews := new(MockNews)
-		useCase := NewNewsUseCase(mockNews)
+		useCase := NewNewsUseCase(mockNews, new(MockSearchEngine))
 		mockNews.On("GetMetaData", 10, 10, "", map[string]interface{}{}).Return(entities.Metadata{TotalData: 100}, nil)
 		metaData, err := useCase.GetMetaData(10, 10, "", map[string]interface{}{})
 		assert.NoError(t, err)
@@ -387,7 +509,7 @@ func TestNewsUseCase_GetMetaData(t *testing.T) {
 
 	t.Run("success - page is greater than 1", func(t *testing.T) {
 		mockNews := new(MockNews)
-		useCase := NewNewsUseCase(mockNews)
+		useCase := NewNewsUseCase(mockNews, new(MockSearchEngine))
 		mockNews.On("GetMetaData", 10, 2, "", map[string]interface{}{}).Return(entities.Metadata{TotalData: 100}, nil)
 		metaData, err := useCase.GetMetaData(10, 2, "", map[string]interface{}{})
 		assert.NoError(t, err)
@@ -396,7 +518,7 @@ func TestNewsUseCase_GetMetaData(t *testing.T) {
 
 	t.Run("success - page is less than last page", func(t *testing.T) {
 		mockNews := new(MockNews)
-		useCase := NewNewsUseCase(mockNews)
+		useCase := NewNewsUseCase(mockNews, new(MockSearchEngine))
 		mockNews.On("GetMetaData", 10, 1, "", map[string]interface{}{}).Return(entities.Metadata{TotalData: 100}, nil)
 		metaData, err := useCase.GetMetaData(10, 1, "", map[string]interface{}{})
 		assert.NoError(t, err)
@@ -405,7 +527,7 @@ func TestNewsUseCase_GetMetaData(t *testing.T) {
 
 	t.Run("success - page is equal to last page", func(t *testing.T) {
 		mockNews := new(MockNews)
-		useCase := NewNewsUseCase(mockNews)
+		useCase := NewNewsUseCase(mockNews, new(MockSearchEngine))
 		mockNews.On("GetMetaData", 10, 10, "", map[string]interface{}{}).Return(entities.Metadata{TotalData: 100}, nil)
 		metaData, err := useCase.GetMetaData(10, 10, "", map[string]interface{}{})
 		assert.NoError(t, err)
@@ -414,11 +536,30 @@ func TestNewsUseCase_GetMetaData(t *testing.T) {
 
 	t.Run("error - internal server error", func(t *testing.T) {
 		mockNews := new(MockNews)
-		useCase := NewNewsUseCase(mockNews)
+		useCase := NewNewsUseCase(mockNews, new(MockSearchEngine))
 		mockNews.On("GetMetaData", 10, 1, "", map[string]interface{}{}).Return(entities.Metadata{}, errors.New("internal server error"))
 		_, err := useCase.GetMetaData(10, 1, "", map[string]interface{}{})
 		assert.Error(t, err)
 		assert.Equal(t, constants.ErrInternalServerError, err)
 	})
 
+	t.Run("success - full-text search", func(t *testing.T) {
+		mockNews := new(MockNews)
+		mockSearchEngine := new(MockSearchEngine)
+		useCase := NewNewsUseCase(mockNews, mockSearchEngine)
+		mockSearchEngine.On("Search", constants.SearchNews, "banjir", constants.MaxSearchHits).Return([]entities.SearchHit{{ID: "4"}}, nil)
+		mockNews.On("GetMetaData", 10, 1, "", map[string]interface{}{"ids": []int{4}}).Return(entities.Metadata{TotalData: 1}, nil)
+		metaData, err := useCase.GetMetaData(10, 1, "banjir", nil)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, metaData.TotalData)
+	})
+
+	t.Run("error - search engine error", func(t *testing.T) {
+		mockSearchEngine := new(MockSearchEngine)
+		useCase := NewNewsUseCase(new(MockNews), mockSearchEngine)
+		mockSearchEngine.On("Search", constants.SearchNews, "banjir", constants.MaxSearchHits).Return([]entities.SearchHit(nil), errors.New("database error"))
+		_, err := useCase.GetMetaData(10, 1, "banjir", nil)
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+
 }
@@ -1,10 +1,15 @@
 package news_file
 
 import (
+	"bytes"
+	"e-complaint-api/constants"
 	"e-complaint-api/entities"
 	"errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"image"
+	"image/jpeg"
+	"io"
 	"mime/multipart"
 	"testing"
 )
@@ -22,6 +27,11 @@ func (m *NewsFileMock) DeleteByNewsID(newsID int) error {
 	return args.Error(0)
 }
 
+func (m *NewsFileMock) FindByNewsID(newsID int) ([]entities.NewsFile, error) {
+	args := m.Called(newsID)
+	return args.Get(0).([]entities.NewsFile), args.Error(1)
+}
+
 type NewsFileGCSAPIMock struct {
 	mock.Mock
 }
@@ -31,11 +41,36 @@ func (m *NewsFileGCSAPIMock) Delete(filePaths []string) error {
 	return args.Error(0)
 }
 
-func (m *NewsFileGCSAPIMock) Upload(files []*multipart.FileHeader) ([]string, error) {
+func (m *NewsFileGCSAPIMock) Open(filePath string) (io.ReadCloser, error) {
+	args := m.Called(filePath)
+	return args.Get(0).(io.ReadCloser), args.Error(1)
+}
+
+func (m *NewsFileGCSAPIMock) Upload(files []entities.UploadFile) ([]string, error) {
 	args := m.Called(files)
 	return args.Get(0).([]string), args.Error(1)
 }
 
+func newFileHeader(t *testing.T, filename string, content []byte) *multipart.FileHeader {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, _ := writer.CreateFormFile("files", filename)
+	part.Write(content)
+	writer.Close()
+
+	form, err := multipart.NewReader(&body, writer.Boundary()).ReadForm(1024 * 1024)
+	assert.NoError(t, err)
+
+	return form.File["files"][0]
+}
+
+func newImageFileHeader(t *testing.T) *multipart.FileHeader {
+	var content bytes.Buffer
+	jpeg.Encode(&content, image.NewRGBA(image.Rect(0, 0, 4, 4)), nil)
+
+	return newFileHeader(t, "cover.jpg", content.Bytes())
+}
+
 func TestNewsFileUseCase_Create(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		newsFileMock := new(NewsFileMock)
@@ -43,16 +78,32 @@ func TestNewsFileUseCase_Create(t *testing.T) {
 		newsFileUseCase := NewNewsFileUseCase(newsFileMock, newsFileGCSAPIMock)
 
 		files := []*multipart.FileHeader{
-			&multipart.FileHeader{},
-			&multipart.FileHeader{},
+			newImageFileHeader(t),
+			newImageFileHeader(t),
 		}
 		newsID := 1
 
-		newsFileGCSAPIMock.On("Upload", files).Return([]string{"path1", "path2"}, nil)
+		newsFileGCSAPIMock.On("Upload", mock.Anything).Return([]string{"path1", "path2"}, nil).Once()
+		newsFileGCSAPIMock.On("Upload", mock.Anything).Return([]string{"thumb1", "thumb2"}, nil).Once()
 		newsFileMock.On("Create", mock.Anything).Return(nil)
 
-		_, err := newsFileUseCase.Create(files, newsID)
+		result, err := newsFileUseCase.Create(files, newsID)
 		assert.Nil(t, err)
+		assert.Equal(t, "thumb2", result[1].ThumbnailPath)
+	})
+
+	t.Run("invalid file", func(t *testing.T) {
+		newsFileMock := new(NewsFileMock)
+		newsFileGCSAPIMock := new(NewsFileGCSAPIMock)
+		newsFileUseCase := NewNewsFileUseCase(newsFileMock, newsFileGCSAPIMock)
+
+		files := []*multipart.FileHeader{
+			newFileHeader(t, "cover.png", []byte("%PDF-1.4")),
+		}
+		newsID := 1
+
+		_, err := newsFileUseCase.Create(files, newsID)
+		assert.Equal(t, constants.ErrInvalidFileFormat, err)
 	})
 
 	t.Run("upload error", func(t *testing.T) {
@@ -61,12 +112,12 @@ func TestNewsFileUseCase_Create(t *testing.T) {
 		newsFileUseCase := NewNewsFileUseCase(newsFileMock, newsFileGCSAPIMock)
 
 		files := []*multipart.FileHeader{
-			&multipart.FileHeader{},
-			&multipart.FileHeader{},
+			newImageFileHeader(t),
+			newImageFileHeader(t),
 		}
 		newsID := 1
 
-		newsFileGCSAPIMock.On("Upload", files).Return([]string{}, errors.New("upload error"))
+		newsFileGCSAPIMock.On("Upload", mock.Anything).Return([]string{}, errors.New("upload error"))
 
 		_, err := newsFileUseCase.Create(files, newsID)
 		assert.NotNil(t, err)
@@ -78,13 +129,15 @@ func TestNewsFileUseCase_Create(t *testing.T) {
 		newsFileUseCase := NewNewsFileUseCase(newsFileMock, newsFileGCSAPIMock)
 
 		files := []*multipart.FileHeader{
-			&multipart.FileHeader{},
-			&multipart.FileHeader{},
+			newImageFileHeader(t),
+			newImageFileHeader(t),
 		}
 		newsID := 1
 
-		newsFileGCSAPIMock.On("Upload", files).Return([]string{"path1", "path2"}, nil)
+		newsFileGCSAPIMock.On("Upload", mock.Anything).Return([]string{"path1", "path2"}, nil).Once()
+		newsFileGCSAPIMock.On("Upload", mock.Anything).Return([]string{"thumb1", "thumb2"}, nil).Once()
 		newsFileMock.On("Create", mock.Anything).Return(errors.New("create error"))
+		newsFileGCSAPIMock.On("Delete", []string{"path1", "path2", "thumb1", "thumb2"}).Return(nil)
 
 		_, err := newsFileUseCase.Create(files, newsID)
 		assert.NotNil(t, err)
@@ -99,13 +152,27 @@ func TestNewsFileUseCase_DeleteByNewsID(t *testing.T) {
 
 		newsID := 1
 
+		newsFileMock.On("FindByNewsID", newsID).Return([]entities.NewsFile{{NewsID: newsID, Path: "path1", ThumbnailPath: "thumb1"}}, nil)
 		newsFileMock.On("DeleteByNewsID", newsID).Return(nil)
-		newsFileGCSAPIMock.On("Delete", mock.Anything).Return(nil)
+		newsFileGCSAPIMock.On("Delete", []string{"path1", "thumb1"}).Return(nil)
 
 		err := newsFileUseCase.DeleteByNewsID(newsID)
 		assert.Nil(t, err)
 	})
 
+	t.Run("find error", func(t *testing.T) {
+		newsFileMock := new(NewsFileMock)
+		newsFileGCSAPIMock := new(NewsFileGCSAPIMock)
+		newsFileUseCase := NewNewsFileUseCase(newsFileMock, newsFileGCSAPIMock)
+
+		newsID := 1
+
+		newsFileMock.On("FindByNewsID", newsID).Return([]entities.NewsFile{}, errors.New("find error"))
+
+		err := newsFileUseCase.DeleteByNewsID(newsID)
+		assert.NotNil(t, err)
+	})
+
 	t.Run("delete error", func(t *testing.T) {
 		newsFileMock := new(NewsFileMock)
 		newsFileGCSAPIMock := new(NewsFileGCSAPIMock)
@@ -113,9 +180,25 @@ func TestNewsFileUseCase_DeleteByNewsID(t *testing.T) {
 
 		newsID := 1
 
+		newsFileMock.On("FindByNewsID", newsID).Return([]entities.NewsFile{{NewsID: newsID, Path: "path1"}}, nil)
 		newsFileMock.On("DeleteByNewsID", newsID).Return(errors.New("delete error"))
 
 		err := newsFileUseCase.DeleteByNewsID(newsID)
 		assert.NotNil(t, err)
 	})
+
+	t.Run("delete file error", func(t *testing.T) {
+		newsFileMock := new(NewsFileMock)
+		newsFileGCSAPIMock := new(NewsFileGCSAPIMock)
+		newsFileUseCase := NewNewsFileUseCase(newsFileMock, newsFileGCSAPIMock)
+
+		newsID := 1
+
+		newsFileMock.On("FindByNewsID", newsID).Return([]entities.NewsFile{{NewsID: newsID, Path: "path1"}}, nil)
+		newsFileMock.On("DeleteByNewsID", newsID).Return(nil)
+		newsFileGCSAPIMock.On("Delete", []string{"path1"}).Return(errors.New("delete file error"))
+
+		err := newsFileUseCase.DeleteByNewsID(newsID)
+		assert.NotNil(t, err)
+	})
 }
@@ -0,0 +1,238 @@
+package notification
+
+import (
+	"e-complaint-api/constants"
+	"e-complaint-api/cursor"
+	"e-complaint-api/entities"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+type NotificationUseCase struct {
+	repository                 entities.NotificationRepositoryInterface
+	complaintProcessRepository entities.ComplaintProcessRepositoryInterface
+}
+
+func NewNotificationUseCase(repository entities.NotificationRepositoryInterface, complaintProcessRepository entities.ComplaintProcessRepositoryInterface) *NotificationUseCase {
+	return &NotificationUseCase{
+		repository:                 repository,
+		complaintProcessRepository: complaintProcessRepository,
+	}
+}
+
+func getRecipientType(role string) (string, error) {
+	if role == "user" {
+		return "user", nil
+	} else if role == "admin" || role == "super_admin" {
+		return "admin", nil
+	}
+
+	return "", constants.ErrUnauthorized
+}
+
+func (u *NotificationUseCase) GetByRecipient(recipientID int, role string) ([]entities.Notification, error) {
+	recipientType, err := getRecipientType(role)
+	if err != nil {
+		return nil, err
+	}
+
+	notifications, err := u.repository.GetByRecipient(recipientID, recipientType)
+	if err != nil {
+		return nil, constants.ErrInternalServerError
+	}
+
+	return notifications, nil
+}
+
+// GetByRecipientCursor returns the page of notifications after the one the cursor points
+// at, newest first, and the cursor of the next page, which is empty on the last page.
+func (u *NotificationUseCase) GetByRecipientCursor(recipientID int, role string, limit int, token string) ([]entities.Notification, string, error) {
+	recipientType, err := getRecipientType(role)
+	if err != nil {
+		return nil, "", err
+	}
+
+	afterID, err := cursor.DecodeInt(token, "id DESC")
+	if err != nil {
+		return nil, "", err
+	}
+
+	limit = cursor.Limit(limit)
+	notifications, err := u.repository.GetByRecipientAfter(recipientID, recipientType, limit+1, afterID)
+	if err != nil {
+		return nil, "", constants.ErrInternalServerError
+	}
+
+	notifications, next := cursor.Page(notifications, limit, "id DESC", func(notification entities.Notification) string {
+		return strconv.Itoa(notification.ID)
+	})
+
+	return notifications, next, nil
+}
+
+func (u *NotificationUseCase) MarkAsRead(id int, recipientID int, role string) error {
+	if id == 0 {
+		return constants.ErrIDMustBeFilled
+	}
+
+	recipientType, err := getRecipientType(role)
+	if err != nil {
+		return err
+	}
+
+	err = u.repository.MarkAsRead(id, recipientID, recipientType)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (u *NotificationUseCase) MarkAllAsRead(recipientID int, role string) error {
+	recipientType, err := getRecipientType(role)
+	if err != nil {
+		return err
+	}
+
+	err = u.repository.MarkAllAsRead(recipientID, recipientType)
+	if err != nil {
+		return constants.ErrInternalServerError
+	}
+
+	return nil
+}
+
+func (u *NotificationUseCase) GetUnreadCount(recipientID int, role string) (int64, error) {
+	recipientType, err := getRecipientType(role)
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := u.repository.CountUnread(recipientID, recipientType)
+	if err != nil {
+		return 0, constants.ErrInternalServerError
+	}
+
+	return count, nil
+}
+
+func (u *NotificationUseCase) NotifyComplaintProcess(complaint entities.Complaint, complaintProcess entities.ComplaintProcess) error {
+	notification := &entities.Notification{
+		RecipientID:   complaint.UserID,
+		RecipientType: "user",
+		ComplaintID:   complaint.ID,
+		Type:          "process",
+		Message:       fmt.Sprintf("Aduan %s kini berstatus %s: %s", complaint.ID, complaintProcess.Status, complaintProcess.Message),
+	}
+
+	err := u.repository.Create([]*entities.Notification{notification})
+	if err != nil {
+		return constants.ErrInternalServerError
+	}
+
+	return nil
+}
+
+func (u *NotificationUseCase) NotifyDiscussion(complaint entities.Complaint, discussion entities.Discussion) error {
+	var notifications []*entities.Notification
+
+	if discussion.AdminID != nil {
+		notifications = append(notifications, &entities.Notification{
+			RecipientID:   complaint.UserID,
+			RecipientType: "user",
+			ComplaintID:   complaint.ID,
+			Type:          "discussion",
+			Message:       fmt.Sprintf("Admin menanggapi diskusi pada aduan %s", complaint.ID),
+		})
+	} else if discussion.UserID != nil && *discussion.UserID != complaint.UserID {
+		notifications = append(notifications, &entities.Notification{
+			RecipientID:   complaint.UserID,
+			RecipientType: "user",
+			ComplaintID:   complaint.ID,
+			Type:          "discussion",
+			Message:       fmt.Sprintf("Ada komentar baru pada aduan %s", complaint.ID),
+		})
+	} else if discussion.UserID != nil {
+		// The reporter commented on their own complaint, notify the admins handling it
+		complaintProcesses, err := u.complaintProcessRepository.GetByComplaintID(complaint.ID)
+		if err != nil && !errors.Is(err, constants.ErrComplaintProcessNotFound) {
+			return constants.ErrInternalServerError
+		}
+
+		notifiedAdmins := map[int]bool{}
+		for _, complaintProcess := range complaintProcesses {
+			// escalations have no admin
+			if complaintProcess.Escalation || notifiedAdmins[complaintProcess.AdminID] {
+				continue
+			}
+			notifiedAdmins[complaintProcess.AdminID] = true
+
+			notifications = append(notifications, &entities.Notification{
+				RecipientID:   complaintProcess.AdminID,
+				RecipientType: "admin",
+				ComplaintID:   complaint.ID,
+				Type:          "discussion",
+				Message:       fmt.Sprintf("Pelapor menambahkan komentar pada aduan %s", complaint.ID),
+			})
+		}
+	}
+
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	err := u.repository.Create(notifications)
+	if err != nil {
+		return constants.ErrInternalServerError
+	}
+
+	return nil
+}
+
+func (u *NotificationUseCase) NotifyLike(complaint entities.Complaint, complaintLike entities.ComplaintLike) error {
+	if complaintLike.UserID == complaint.UserID {
+		return nil
+	}
+
+	notification := &entities.Notification{
+		RecipientID:   complaint.UserID,
+		RecipientType: "user",
+		ComplaintID:   complaint.ID,
+		Type:          "like",
+		Message:       fmt.Sprintf("Seseorang menyukai aduan %s", complaint.ID),
+	}
+
+	err := u.repository.Create([]*entities.Notification{notification})
+	if err != nil {
+		return constants.ErrInternalServerError
+	}
+
+	return nil
+}
+
+// NotifyComplaintMerged tells the reporters of the duplicates that their complaint is
+// handled as part of the master complaint.
+func (u *NotificationUseCase) NotifyComplaintMerged(master entities.Complaint, duplicates []entities.Complaint) error {
+	var notifications []*entities.Notification
+	for _, duplicate := range duplicates {
+		notifications = append(notifications, &entities.Notification{
+			RecipientID:   duplicate.UserID,
+			RecipientType: "user",
+			ComplaintID:   duplicate.ID,
+			Type:          "merge",
+			Message:       fmt.Sprintf("Aduan %s digabungkan dengan aduan %s yang melaporkan masalah yang sama", duplicate.ID, master.ID),
+		})
+	}
+
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	err := u.repository.Create(notifications)
+	if err != nil {
+		return constants.ErrInternalServerError
+	}
+
+	return nil
+}
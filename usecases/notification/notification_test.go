@@ -0,0 +1,682 @@
+package notification
+
+import (
+	"e-complaint-api/constants"
+	"e-complaint-api/cursor"
+	"e-complaint-api/entities"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockNotificationRepo struct {
+	mock.Mock
+}
+
+func (m *MockNotificationRepo) Create(notifications []*entities.Notification) error {
+	args := m.Called(notifications)
+	return args.Error(0)
+}
+
+func (m *MockNotificationRepo) GetByRecipient(recipientID int, recipientType string) ([]entities.Notification, error) {
+	args := m.Called(recipientID, recipientType)
+	return args.Get(0).([]entities.Notification), args.Error(1)
+}
+
+func (m *MockNotificationRepo) GetByRecipientAfter(recipientID int, recipientType string, limit int, afterID int) ([]entities.Notification, error) {
+	args := m.Called(recipientID, recipientType, limit, afterID)
+	return args.Get(0).([]entities.Notification), args.Error(1)
+}
+
+func (m *MockNotificationRepo) MarkAsRead(id int, recipientID int, recipientType string) error {
+	args := m.Called(id, recipientID, recipientType)
+	return args.Error(0)
+}
+
+func (m *MockNotificationRepo) MarkAllAsRead(recipientID int, recipientType string) error {
+	args := m.Called(recipientID, recipientType)
+	return args.Error(0)
+}
+
+func (m *MockNotificationRepo) CountUnread(recipientID int, recipientType string) (int64, error) {
+	args := m.Called(recipientID, recipientType)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+type MockComplaintProcessRepo struct {
+	mock.Mock
+}
+
+func (m *MockComplaintProcessRepo) Create(complaintProcesses *entities.ComplaintProcess) error {
+	args := m.Called(complaintProcesses)
+	return args.Error(0)
+}
+
+func (m *MockComplaintProcessRepo) GetByComplaintID(complaintID string) ([]entities.ComplaintProcess, error) {
+	args := m.Called(complaintID)
+	return args.Get(0).([]entities.ComplaintProcess), args.Error(1)
+}
+
+func (m *MockComplaintProcessRepo) Update(complaintProcesses *entities.ComplaintProcess) error {
+	args := m.Called(complaintProcesses)
+	return args.Error(0)
+}
+
+func (m *MockComplaintProcessRepo) GetLatestByComplaintID(complaintID string) (entities.ComplaintProcess, error) {
+	args := m.Called(complaintID)
+	return args.Get(0).(entities.ComplaintProcess), args.Error(1)
+}
+
+func (m *MockComplaintProcessRepo) Delete(complaintID string, complaintProcessID int) error {
+	args := m.Called(complaintID, complaintProcessID)
+	return args.Error(0)
+}
+
+func TestGetByRecipient(t *testing.T) {
+	t.Run("success for user", func(t *testing.T) {
+		mockRepo := new(MockNotificationRepo)
+		usecase := NewNotificationUseCase(mockRepo, nil)
+
+		notifications := []entities.Notification{{ID: 1, RecipientID: 1, RecipientType: "user"}}
+		mockRepo.On("GetByRecipient", 1, "user").Return(notifications, nil)
+
+		result, err := usecase.GetByRecipient(1, "user")
+
+		assert.NoError(t, err)
+		assert.Equal(t, notifications, result)
+	})
+
+	t.Run("success for super admin", func(t *testing.T) {
+		mockRepo := new(MockNotificationRepo)
+		usecase := NewNotificationUseCase(mockRepo, nil)
+
+		notifications := []entities.Notification{{ID: 1, RecipientID: 1, RecipientType: "admin"}}
+		mockRepo.On("GetByRecipient", 1, "admin").Return(notifications, nil)
+
+		result, err := usecase.GetByRecipient(1, "super_admin")
+
+		assert.NoError(t, err)
+		assert.Equal(t, notifications, result)
+	})
+
+	t.Run("error when role is invalid", func(t *testing.T) {
+		mockRepo := new(MockNotificationRepo)
+		usecase := NewNotificationUseCase(mockRepo, nil)
+
+		result, err := usecase.GetByRecipient(1, "guest")
+
+		assert.Nil(t, result)
+		assert.Equal(t, constants.ErrUnauthorized, err)
+	})
+
+	t.Run("internal server error", func(t *testing.T) {
+		mockRepo := new(MockNotificationRepo)
+		usecase := NewNotificationUseCase(mockRepo, nil)
+
+		mockRepo.On("GetByRecipient", 1, "user").Return([]entities.Notification{}, errors.New("database error"))
+
+		result, err := usecase.GetByRecipient(1, "user")
+
+		assert.Nil(t, result)
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+}
+
+func TestGetByRecipientCursor(t *testing.T) {
+	t.Run("success first page", func(t *testing.T) {
+		mockRepo := new(MockNotificationRepo)
+		usecase := NewNotificationUseCase(mockRepo, nil)
+
+		notifications := []entities.Notification{{ID: 9}, {ID: 7}, {ID: 4}}
+		mockRepo.On("GetByRecipientAfter", 1, "user", 3, 0).Return(notifications, nil)
+
+		result, nextCursor, err := usecase.GetByRecipientCursor(1, "user", 2, "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, notifications[:2], result)
+		assert.Equal(t, cursor.Encode("7", "id DESC"), nextCursor)
+	})
+
+	t.Run("success last page", func(t *testing.T) {
+		mockRepo := new(MockNotificationRepo)
+		usecase := NewNotificationUseCase(mockRepo, nil)
+
+		notifications := []entities.Notification{{ID: 4}}
+		mockRepo.On("GetByRecipientAfter", 1, "admin", 3, 7).Return(notifications, nil)
+
+		result, nextCursor, err := usecase.GetByRecipientCursor(1, "admin", 2, cursor.Encode("7", "id DESC"))
+
+		assert.NoError(t, err)
+		assert.Equal(t, notifications, result)
+		assert.Equal(t, "", nextCursor)
+	})
+
+	t.Run("error when role is invalid", func(t *testing.T) {
+		usecase := NewNotificationUseCase(new(MockNotificationRepo), nil)
+
+		_, _, err := usecase.GetByRecipientCursor(1, "guest", 2, "")
+
+		assert.Equal(t, constants.ErrUnauthorized, err)
+	})
+
+	t.Run("error when cursor is invalid", func(t *testing.T) {
+		usecase := NewNotificationUseCase(new(MockNotificationRepo), nil)
+
+		_, _, err := usecase.GetByRecipientCursor(1, "user", 2, cursor.Encode("7", "id ASC"))
+
+		assert.Equal(t, constants.ErrInvalidCursor, err)
+	})
+
+	t.Run("internal server error", func(t *testing.T) {
+		mockRepo := new(MockNotificationRepo)
+		usecase := NewNotificationUseCase(mockRepo, nil)
+
+		mockRepo.On("GetByRecipientAfter", 1, "user", constants.DefaultCursorLimit+1, 0).Return([]entities.Notification{}, errors.New("database error"))
+
+		result, _, err := usecase.GetByRecipientCursor(1, "user", 0, "")
+
+		assert.Nil(t, result)
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+}
+
+func TestMarkAsRead(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockRepo := new(MockNotificationRepo)
+		usecase := NewNotificationUseCase(mockRepo, nil)
+
+		mockRepo.On("MarkAsRead", 1, 2, "admin").Return(nil)
+
+		err := usecase.MarkAsRead(1, 2, "admin")
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("error when id is empty", func(t *testing.T) {
+		mockRepo := new(MockNotificationRepo)
+		usecase := NewNotificationUseCase(mockRepo, nil)
+
+		err := usecase.MarkAsRead(0, 2, "admin")
+
+		assert.Equal(t, constants.ErrIDMustBeFilled, err)
+	})
+
+	t.Run("error when role is invalid", func(t *testing.T) {
+		mockRepo := new(MockNotificationRepo)
+		usecase := NewNotificationUseCase(mockRepo, nil)
+
+		err := usecase.MarkAsRead(1, 2, "")
+
+		assert.Equal(t, constants.ErrUnauthorized, err)
+	})
+
+	t.Run("error when notification not found", func(t *testing.T) {
+		mockRepo := new(MockNotificationRepo)
+		usecase := NewNotificationUseCase(mockRepo, nil)
+
+		mockRepo.On("MarkAsRead", 1, 2, "user").Return(constants.ErrNotificationNotFound)
+
+		err := usecase.MarkAsRead(1, 2, "user")
+
+		assert.Equal(t, constants.ErrNotificationNotFound, err)
+	})
+}
+
+func TestMarkAllAsRead(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockRepo := new(MockNotificationRepo)
+		usecase := NewNotificationUseCase(mockRepo, nil)
+
+		mockRepo.On("MarkAllAsRead", 1, "user").Return(nil)
+
+		err := usecase.MarkAllAsRead(1, "user")
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("error when role is invalid", func(t *testing.T) {
+		mockRepo := new(MockNotificationRepo)
+		usecase := NewNotificationUseCase(mockRepo, nil)
+
+		err := usecase.MarkAllAsRead(1, "guest")
+
+		assert.Equal(t, constants.ErrUnauthorized, err)
+	})
+
+	t.Run("internal server error", func(t *testing.T) {
+		mockRepo := new(MockNotificationRepo)
+		usecase := NewNotificationUseCase(mockRepo, nil)
+
+		mockRepo.On("MarkAllAsRead", 1, "user").Return(errors.New("database error"))
+
+		err := usecase.MarkAllAsRead(1, "user")
+
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+}
+
+func TestGetUnreadCount(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockRepo := new(MockNotificationRepo)
+		usecase := NewNotificationUseCase(mockRepo, nil)
+
+		mockRepo.On("CountUnread", 1, "user").Return(int64(3), nil)
+
+		count, err := usecase.GetUnreadCount(1, "user")
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), count)
+	})
+
+	t.Run("error when role is invalid", func(t *testing.T) {
+		mockRepo := new(MockNotificationRepo)
+		usecase := NewNotificationUseCase(mockRepo, nil)
+
+		count, err := usecase.GetUnreadCount(1, "guest")
+
+		assert.Equal(t, int64(0), count)
+		assert.Equal(t, constants.ErrUnauthorized, err)
+	})
+
+	t.Run("internal server error", func(t *testing.T) {
+		mockRepo := new(MockNotificationRepo)
+		usecase := NewNotificationUseCase(mockRepo, nil)
+
+		mockRepo.On("CountUnread", 1, "user").Return(int64(0), errors.New("database error"))
+
+		count, err := usecase.GetUnreadCount(1, "user")
+
+		assert.Equal(t, int64(0), count)
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+}
+
+func TestNotifyComplaintProcess(t *testing.T) {
+	complaint := entities.Complaint{ID: "C-123", UserID: 5}
+	complaintProcess := entities.ComplaintProcess{Status: "Verifikasi", Message: "Aduan anda telah diverifikasi"}
+
+	t.Run("success", func(t *testing.T) {
+		mockRepo := new(MockNotificationRepo)
+		usecase := NewNotificationUseCase(mockRepo, nil)
+
+		mockRepo.On("Create", mock.MatchedBy(func(notifications []*entities.Notification) bool {
+			return len(notifications) == 1 && notifications[0].RecipientID == 5 && notifications[0].RecipientType == "user" && notifications[0].Type == "process"
+		})).Return(nil)
+
+		err := usecase.NotifyComplaintProcess(complaint, complaintProcess)
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("internal server error", func(t *testing.T) {
+		mockRepo := new(MockNotificationRepo)
+		usecase := NewNotificationUseCase(mockRepo, nil)
+
+		mockRepo.On("Create", mock.Anything).Return(errors.New("database error"))
+
+		err := usecase.NotifyComplaintProcess(complaint, complaintProcess)
+
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+}
+
+func TestNotifyDiscussion(t *testing.T) {
+	complaint := entities.Complaint{ID: "C-123", UserID: 5}
+	ownerID := 5
+	otherUserID := 6
+	adminID := 2
+
+	t.Run("admin reply notifies the reporter", func(t *testing.T) {
+		mockRepo := new(MockNotificationRepo)
+		usecase := NewNotificationUseCase(mockRepo, nil)
+
+		mockRepo.On("Create", mock.MatchedBy(func(notifications []*entities.Notification) bool {
+			return len(notifications) == 1 && notifications[0].RecipientID == 5 && notifications[0].RecipientType == "user"
+		})).Return(nil)
+
+		err := usecase.NotifyDiscussion(complaint, entities.Discussion{AdminID: &adminID})
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("other user comment notifies the reporter", func(t *testing.T) {
+		mockRepo := new(MockNotificationRepo)
+		usecase := NewNotificationUseCase(mockRepo, nil)
+
+		mockRepo.On("Create", mock.MatchedBy(func(notifications []*entities.Notification) bool {
+			return len(notifications) == 1 && notifications[0].RecipientID == 5 && notifications[0].RecipientType == "user"
+		})).Return(nil)
+
+		err := usecase.NotifyDiscussion(complaint, entities.Discussion{UserID: &otherUserID})
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("reporter comment notifies handling admins once", func(t *testing.T) {
+		mockRepo := new(MockNotificationRepo)
+		mockProcessRepo := new(MockComplaintProcessRepo)
+		usecase := NewNotificationUseCase(mockRepo, mockProcessRepo)
+
+		mockProcessRepo.On("GetByComplaintID", "C-123").Return([]entities.ComplaintProcess{
+			{AdminID: 1, Status: "Pending"},
+			{AdminID: 2, Status: "Verifikasi"},
+			{AdminID: 2, Status: "On Progress"},
+		}, nil)
+		mockRepo.On("Create", mock.MatchedBy(func(notifications []*entities.Notification) bool {
+			return len(notifications) == 2 && notifications[0].RecipientType == "admin" && notifications[1].RecipientID == 2
+		})).Return(nil)
+
+		err := usecase.NotifyDiscussion(complaint, entities.Discussion{UserID: &ownerID})
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("reporter comment without processes creates nothing", func(t *testing.T) {
+		mockRepo := new(MockNotificationRepo)
+		mockProcessRepo := new(MockComplaintProcessRepo)
+		usecase := NewNotificationUseCase(mockRepo, mockProcessRepo)
+
+		mockProcessRepo.On("GetByComplaintID", "C-123").Return([]entities.ComplaintProcess{}, constants.ErrComplaintProcessNotFound)
+
+		err := usecase.NotifyDiscussion(complaint, entities.Discussion{UserID: &ownerID})
+
+		assert.NoError(t, err)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("error when getting complaint processes fails", func(t *testing.T) {
+		mockRepo := new(MockNotificationRepo)
+		mockProcessRepo := new(MockComplaintProcessRepo)
+		usecase := NewNotificationUseCase(mockRepo, mockProcessRepo)
+
+		mockProcessRepo.On("GetByComplaintID", "C-123").Return([]entities.ComplaintProcess{}, constants.ErrInternalServerError)
+
+		err := usecase.NotifyDiscussion(complaint, entities.Discussion{UserID: &ownerID})
+
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+
+	t.Run("internal server error", func(t *testing.T) {
+		mockRepo := new(MockNotificationRepo)
+		usecase := NewNotificationUseCase(mockRepo, nil)
+
+		mockRepo.On("Create", mock.Anything).Return(errors.New("database error"))
+
+		err := usecase.NotifyDiscussion(complaint, entities.Discussion{AdminID: &adminID})
+
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+}
+
+func TestNotifyLike(t *testing.T) {
+	complaint := entities.Complaint{ID: "C-123", UserID: 5}
+
+	t.Run("success", func(t *testing.T) {
+		mockRepo := new(MockNotificationRepo)
+		usecase := NewNotificationUseCase(mockRepo, nil)
+
+		mockRepo.On("Create", mock.MatchedBy(func(notifications []*entities.Notification) bool {
+			return len(notifications) == 1 && notifications[0].RecipientID == 5 && notifications[0].Type == "like"
+		})).Return(nil)
+
+		err := usecase.NotifyLike(complaint, entities.ComplaintLike{UserID: 6})
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("reporter liking own complaint creates nothing", func(t *testing.T) {
+		mockRepo := new(MockNotificationRepo)
+		usecase := NewNotificationUseCase(mockRepo, nil)
+
+		err := usecase.NotifyLike(complaint, entities.ComplaintLike{UserID: 5})
+
+		assert.NoError(t, err)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("internal server error", func(t *testing.T) {
+		mockRepo := new(MockNotificationRepo)
+		usecase := NewNotificationUseCase(mockRepo, nil)
+
+		mockRepo.On("Create", mock.Anything).Return(errors.New("database error"))
+
+		err := usecase.NotifyLike(complaint, entities.ComplaintLike{UserID: 6})
+
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+}
+
+func TestNotifyComplaintMerged(t *testing.T) {
+	master := entities.Complaint{ID: "C-123", UserID: 5}
+	duplicates := []entities.Complaint{{ID: "C-124", UserID: 6}, {ID: "C-125", UserID: 7}}
+
+	t.Run("success", func(t *testing.T) {
+		mockRepo := new(MockNotificationRepo)
+		usecase := NewNotificationUseCase(mockRepo, nil)
+
+		mockRepo.On("Create", mock.MatchedBy(func(notifications []*entities.Notification) bool {
+			return len(notifications) == 2 && notifications[0].RecipientID == 6 && notifications[0].ComplaintID == "C-124" &&
+				notifications[1].RecipientID == 7 && notifications[1].Type == "merge"
+		})).Return(nil)
+
+		err := usecase.NotifyComplaintMerged(master, duplicates)
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("no duplicates creates nothing", func(t *testing.T) {
+		mockRepo := new(MockNotificationRepo)
+		usecase := NewNotificationUseCase(mockRepo, nil)
+
+		err := usecase.NotifyComplaintMerged(master, nil)
+
+		assert.NoError(t, err)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("internal server error", func(t *testing.T) {
+		mockRepo := new(MockNotificationRepo)
+		usecase := NewNotificationUseCase(mockRepo, nil)
+
+		mockRepo.On("Create", mock.Anything).Return(errors.New("database error"))
+
+		err := usecase.NotifyComplaintMerged(master, duplicates)
+
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+}
+
+type MockJobUseCase struct {
+	mock.Mock
+}
+
+func (m *MockJobUseCase) Register(jobType string, handler entities.JobHandler) {
+	m.Called(jobType, handler)
+}
+
+func (m *MockJobUseCase) Enqueue(jobType string, createdBy *int, payload interface{}) (entities.Job, error) {
+	args := m.Called(jobType, createdBy, payload)
+	return args.Get(0).(entities.Job), args.Error(1)
+}
+
+func (m *MockJobUseCase) RunOnce() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+func (m *MockJobUseCase) GetByCursor(filter map[string]interface{}, limit int, cursor string) ([]entities.Job, string, error) {
+	args := m.Called(filter, limit, cursor)
+	return args.Get(0).([]entities.Job), args.String(1), args.Error(2)
+}
+
+func (m *MockJobUseCase) GetByID(id int) (entities.Job, error) {
+	args := m.Called(id)
+	return args.Get(0).(entities.Job), args.Error(1)
+}
+
+func (m *MockJobUseCase) GetOwn(id int, jobType string, adminID int) (entities.Job, error) {
+	args := m.Called(id, jobType, adminID)
+	return args.Get(0).(entities.Job), args.Error(1)
+}
+
+func (m *MockJobUseCase) Retry(id int) (entities.Job, error) {
+	args := m.Called(id)
+	return args.Get(0).(entities.Job), args.Error(1)
+}
+
+type MockEmailUseCase struct {
+	mock.Mock
+}
+
+func (m *MockEmailUseCase) NotifyComplaintProcess(complaint entities.Complaint, complaintProcess entities.ComplaintProcess) error {
+	args := m.Called(complaint, complaintProcess)
+	return args.Error(0)
+}
+
+func (m *MockEmailUseCase) NotifyDiscussion(complaint entities.Complaint, discussion entities.Discussion) error {
+	args := m.Called(complaint, discussion)
+	return args.Error(0)
+}
+
+func (m *MockEmailUseCase) GetPreferences(userID int) ([]entities.EmailPreference, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]entities.EmailPreference), args.Error(1)
+}
+
+func (m *MockEmailUseCase) UpdatePreferences(userID int, preferences []entities.EmailPreference) ([]entities.EmailPreference, error) {
+	args := m.Called(userID, preferences)
+	return args.Get(0).([]entities.EmailPreference), args.Error(1)
+}
+
+// newQueuedUseCase returns a queued use case whose jobs are run as soon as they are
+// enqueued, by the handlers it registered.
+func newQueuedUseCase(mockRepo *MockNotificationRepo, mockProcessRepo *MockComplaintProcessRepo, mockEmailUseCase *MockEmailUseCase) *QueuedNotificationUseCase {
+	handlers := map[string]entities.JobHandler{}
+	jobUseCase := new(MockJobUseCase)
+	jobUseCase.On("Register", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		handlers[args.String(0)] = args.Get(1).(entities.JobHandler)
+	}).Return()
+
+	jobUseCase.On("Enqueue", mock.Anything, (*int)(nil), mock.Anything).Run(func(args mock.Arguments) {
+		payload, _ := json.Marshal(args.Get(2))
+		handlers[args.String(0)](payload)
+	}).Return(entities.Job{}, nil)
+
+	return NewQueuedNotificationUseCase(NewNotificationUseCase(mockRepo, mockProcessRepo), jobUseCase, mockEmailUseCase)
+}
+
+func TestQueuedNotificationUseCase(t *testing.T) {
+	complaint := entities.Complaint{ID: "C-123", UserID: 5}
+
+	t.Run("success complaint process", func(t *testing.T) {
+		mockRepo := new(MockNotificationRepo)
+		mockRepo.On("Create", mock.MatchedBy(func(notifications []*entities.Notification) bool {
+			return len(notifications) == 1 && notifications[0].RecipientID == 5 &&
+				notifications[0].Message == "Aduan C-123 kini berstatus Verifikasi: Aduan diverifikasi"
+		})).Return(nil)
+
+		complaintProcess := entities.ComplaintProcess{Status: "Verifikasi", Message: "Aduan diverifikasi"}
+		mockEmailUseCase := new(MockEmailUseCase)
+		mockEmailUseCase.On("NotifyComplaintProcess", complaint, complaintProcess).Return(nil)
+
+		err := newQueuedUseCase(mockRepo, nil, mockEmailUseCase).NotifyComplaintProcess(complaint, complaintProcess)
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+		mockEmailUseCase.AssertExpectations(t)
+	})
+
+	t.Run("success discussion", func(t *testing.T) {
+		adminID := 2
+		mockRepo := new(MockNotificationRepo)
+		mockRepo.On("Create", mock.MatchedBy(func(notifications []*entities.Notification) bool {
+			return len(notifications) == 1 && notifications[0].Message == "Admin menanggapi diskusi pada aduan C-123"
+		})).Return(nil)
+
+		discussion := entities.Discussion{AdminID: &adminID}
+		mockEmailUseCase := new(MockEmailUseCase)
+		mockEmailUseCase.On("NotifyDiscussion", complaint, discussion).Return(nil)
+
+		err := newQueuedUseCase(mockRepo, nil, mockEmailUseCase).NotifyDiscussion(complaint, discussion)
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+		mockEmailUseCase.AssertExpectations(t)
+	})
+
+	t.Run("success like", func(t *testing.T) {
+		mockRepo := new(MockNotificationRepo)
+		mockRepo.On("Create", mock.MatchedBy(func(notifications []*entities.Notification) bool {
+			return len(notifications) == 1 && notifications[0].Type == "like"
+		})).Return(nil)
+
+		err := newQueuedUseCase(mockRepo, nil, nil).NotifyLike(complaint, entities.ComplaintLike{UserID: 6})
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("success complaint merged", func(t *testing.T) {
+		mockRepo := new(MockNotificationRepo)
+		mockRepo.On("Create", mock.MatchedBy(func(notifications []*entities.Notification) bool {
+			return len(notifications) == 1 && notifications[0].RecipientID == 6 &&
+				notifications[0].Message == "Aduan C-124 digabungkan dengan aduan C-123 yang melaporkan masalah yang sama"
+		})).Return(nil)
+
+		err := newQueuedUseCase(mockRepo, nil, nil).NotifyComplaintMerged(complaint, []entities.Complaint{{ID: "C-124", UserID: 6}})
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("failed enqueueing", func(t *testing.T) {
+		jobUseCase := new(MockJobUseCase)
+		jobUseCase.On("Register", mock.Anything, mock.Anything).Return()
+		jobUseCase.On("Enqueue", mock.Anything, mock.Anything, mock.Anything).Return(entities.Job{}, constants.ErrInternalServerError)
+
+		err := NewQueuedNotificationUseCase(NewNotificationUseCase(new(MockNotificationRepo), nil), jobUseCase, nil).NotifyLike(complaint, entities.ComplaintLike{UserID: 6})
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+
+	t.Run("failed enqueueing email", func(t *testing.T) {
+		mockRepo := new(MockNotificationRepo)
+		mockRepo.On("Create", mock.Anything).Return(nil)
+		mockEmailUseCase := new(MockEmailUseCase)
+		mockEmailUseCase.On("NotifyComplaintProcess", mock.Anything, mock.Anything).Return(constants.ErrInternalServerError)
+		mockEmailUseCase.On("NotifyDiscussion", mock.Anything, mock.Anything).Return(constants.ErrInternalServerError)
+
+		useCase := newQueuedUseCase(mockRepo, nil, mockEmailUseCase)
+		err := useCase.NotifyComplaintProcess(complaint, entities.ComplaintProcess{Status: "Verifikasi"})
+		assert.Equal(t, constants.ErrInternalServerError, err)
+
+		adminID := 2
+		err = useCase.NotifyDiscussion(complaint, entities.Discussion{AdminID: &adminID})
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+
+	t.Run("failed enqueueing notification", func(t *testing.T) {
+		jobUseCase := new(MockJobUseCase)
+		jobUseCase.On("Register", mock.Anything, mock.Anything).Return()
+		jobUseCase.On("Enqueue", mock.Anything, mock.Anything, mock.Anything).Return(entities.Job{}, constants.ErrInternalServerError)
+
+		useCase := NewQueuedNotificationUseCase(NewNotificationUseCase(new(MockNotificationRepo), nil), jobUseCase, new(MockEmailUseCase))
+		assert.Equal(t, constants.ErrInternalServerError, useCase.NotifyComplaintProcess(complaint, entities.ComplaintProcess{}))
+		assert.Equal(t, constants.ErrInternalServerError, useCase.NotifyDiscussion(complaint, entities.Discussion{}))
+	})
+
+	t.Run("failed invalid payload", func(t *testing.T) {
+		jobUseCase := new(MockJobUseCase)
+		jobUseCase.On("Register", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			_, err := args.Get(1).(entities.JobHandler)([]byte("{"))
+			assert.Error(t, err)
+		}).Return()
+
+		NewQueuedNotificationUseCase(NewNotificationUseCase(new(MockNotificationRepo), nil), jobUseCase, nil)
+		jobUseCase.AssertNumberOfCalls(t, "Register", 4)
+	})
+}
@@ -0,0 +1,157 @@
+package notification
+
+import (
+	"e-complaint-api/constants"
+	"e-complaint-api/entities"
+	"encoding/json"
+)
+
+// QueuedNotificationUseCase creates notifications on the job queue instead of during
+// the request that causes them. Everything else is left to the wrapped use case.
+// Complaint process and discussion notifications are emailed as well.
+type QueuedNotificationUseCase struct {
+	entities.NotificationUseCaseInterface
+	jobUseCase   entities.JobUseCaseInterface
+	emailUseCase entities.EmailUseCaseInterface
+}
+
+// The payloads of the notification jobs carry only the fields notifications are made
+// from.
+type complaintProcessPayload struct {
+	ComplaintID string
+	UserID      int
+	Status      string
+	Message     string
+}
+
+type discussionPayload struct {
+	ComplaintID       string
+	UserID            int
+	DiscussionAdminID *int
+	DiscussionUserID  *int
+}
+
+type likePayload struct {
+	ComplaintID string
+	UserID      int
+	LikeUserID  int
+}
+
+type complaintMergedPayload struct {
+	MasterID   string
+	Duplicates []mergedDuplicate
+}
+
+type mergedDuplicate struct {
+	ID     string
+	UserID int
+}
+
+// NewQueuedNotificationUseCase wraps notificationUseCase and registers the handlers of
+// the notification jobs, which call it. The emails are left to emailUseCase.
+func NewQueuedNotificationUseCase(notificationUseCase entities.NotificationUseCaseInterface, jobUseCase entities.JobUseCaseInterface, emailUseCase entities.EmailUseCaseInterface) *QueuedNotificationUseCase {
+	jobUseCase.Register(constants.JobTypeNotifyComplaintProcess, func(data []byte) (string, error) {
+		var payload complaintProcessPayload
+		if err := json.Unmarshal(data, &payload); err != nil {
+			return "", err
+		}
+
+		return "", notificationUseCase.NotifyComplaintProcess(
+			entities.Complaint{ID: payload.ComplaintID, UserID: payload.UserID},
+			entities.ComplaintProcess{Status: payload.Status, Message: payload.Message},
+		)
+	})
+
+	jobUseCase.Register(constants.JobTypeNotifyDiscussion, func(data []byte) (string, error) {
+		var payload discussionPayload
+		if err := json.Unmarshal(data, &payload); err != nil {
+			return "", err
+		}
+
+		return "", notificationUseCase.NotifyDiscussion(
+			entities.Complaint{ID: payload.ComplaintID, UserID: payload.UserID},
+			entities.Discussion{AdminID: payload.DiscussionAdminID, UserID: payload.DiscussionUserID},
+		)
+	})
+
+	jobUseCase.Register(constants.JobTypeNotifyLike, func(data []byte) (string, error) {
+		var payload likePayload
+		if err := json.Unmarshal(data, &payload); err != nil {
+			return "", err
+		}
+
+		return "", notificationUseCase.NotifyLike(
+			entities.Complaint{ID: payload.ComplaintID, UserID: payload.UserID},
+			entities.ComplaintLike{UserID: payload.LikeUserID},
+		)
+	})
+
+	jobUseCase.Register(constants.JobTypeNotifyComplaintMerged, func(data []byte) (string, error) {
+		var payload complaintMergedPayload
+		if err := json.Unmarshal(data, &payload); err != nil {
+			return "", err
+		}
+
+		duplicates := []entities.Complaint{}
+		for _, duplicate := range payload.Duplicates {
+			duplicates = append(duplicates, entities.Complaint{ID: duplicate.ID, UserID: duplicate.UserID})
+		}
+
+		return "", notificationUseCase.NotifyComplaintMerged(entities.Complaint{ID: payload.MasterID}, duplicates)
+	})
+
+	return &QueuedNotificationUseCase{
+		NotificationUseCaseInterface: notificationUseCase,
+		jobUseCase:                   jobUseCase,
+		emailUseCase:                 emailUseCase,
+	}
+}
+
+func (u *QueuedNotificationUseCase) NotifyComplaintProcess(complaint entities.Complaint, complaintProcess entities.ComplaintProcess) error {
+	_, err := u.jobUseCase.Enqueue(constants.JobTypeNotifyComplaintProcess, nil, complaintProcessPayload{
+		ComplaintID: complaint.ID,
+		UserID:      complaint.UserID,
+		Status:      complaintProcess.Status,
+		Message:     complaintProcess.Message,
+	})
+	if err != nil {
+		return err
+	}
+
+	return u.emailUseCase.NotifyComplaintProcess(complaint, complaintProcess)
+}
+
+func (u *QueuedNotificationUseCase) NotifyDiscussion(complaint entities.Complaint, discussion entities.Discussion) error {
+	_, err := u.jobUseCase.Enqueue(constants.JobTypeNotifyDiscussion, nil, discussionPayload{
+		ComplaintID:       complaint.ID,
+		UserID:            complaint.UserID,
+		DiscussionAdminID: discussion.AdminID,
+		DiscussionUserID:  discussion.UserID,
+	})
+	if err != nil {
+		return err
+	}
+
+	return u.emailUseCase.NotifyDiscussion(complaint, discussion)
+}
+
+func (u *QueuedNotificationUseCase) NotifyLike(complaint entities.Complaint, complaintLike entities.ComplaintLike) error {
+	_, err := u.jobUseCase.Enqueue(constants.JobTypeNotifyLike, nil, likePayload{
+		ComplaintID: complaint.ID,
+		UserID:      complaint.UserID,
+		LikeUserID:  complaintLike.UserID,
+	})
+
+	return err
+}
+
+func (u *QueuedNotificationUseCase) NotifyComplaintMerged(master entities.Complaint, duplicates []entities.Complaint) error {
+	payload := complaintMergedPayload{MasterID: master.ID, Duplicates: []mergedDuplicate{}}
+	for _, duplicate := range duplicates {
+		payload.Duplicates = append(payload.Duplicates, mergedDuplicate{ID: duplicate.ID, UserID: duplicate.UserID})
+	}
+
+	_, err := u.jobUseCase.Enqueue(constants.JobTypeNotifyComplaintMerged, nil, payload)
+
+	return err
+}
@@ -19,6 +19,11 @@ func (m *RegencyRepositoryMock) GetAll() ([]entities.Regency, error) {
 	return args.Get(0).([]entities.Regency), args.Error(1)
 }
 
+func (m *RegencyRepositoryMock) GetBoundary(regencyID string) (entities.RegencyBoundary, error) {
+	args := m.Called(regencyID)
+	return args.Get(0).(entities.RegencyBoundary), args.Error(1)
+}
+
 func TestRegencyUseCase_GetAll(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		repo := new(RegencyRepositoryMock)
@@ -0,0 +1,242 @@
+package role
+
+import (
+	"e-complaint-api/constants"
+	"e-complaint-api/entities"
+	"errors"
+	"strings"
+)
+
+type RoleUseCase struct {
+	repository entities.RoleRepositoryInterface
+}
+
+func NewRoleUseCase(repository entities.RoleRepositoryInterface) *RoleUseCase {
+	return &RoleUseCase{
+		repository: repository,
+	}
+}
+
+func (u *RoleUseCase) GetAll() ([]entities.Role, error) {
+	roles, err := u.repository.GetAll()
+	if err != nil {
+		return nil, constants.ErrInternalServerError
+	}
+
+	return roles, nil
+}
+
+func (u *RoleUseCase) GetByID(id int) (entities.Role, error) {
+	role, err := u.repository.GetByID(id)
+	if err != nil {
+		if errors.Is(err, constants.ErrRoleNotFound) {
+			return entities.Role{}, err
+		}
+		return entities.Role{}, constants.ErrInternalServerError
+	}
+
+	return role, nil
+}
+
+func (u *RoleUseCase) Create(role *entities.Role, permissions []string) (entities.Role, error) {
+	if err := validateRole(role, permissions); err != nil {
+		return entities.Role{}, err
+	}
+
+	err := u.repository.Create(role, permissions)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "Error 1062") {
+			return entities.Role{}, constants.ErrRoleAlreadyExists
+		}
+		return entities.Role{}, constants.ErrInternalServerError
+	}
+
+	return *role, nil
+}
+
+func (u *RoleUseCase) Update(role *entities.Role, permissions []string) (entities.Role, error) {
+	if err := validateRole(role, permissions); err != nil {
+		return entities.Role{}, err
+	}
+
+	err := u.repository.Update(role, permissions)
+	if err != nil {
+		if errors.Is(err, constants.ErrRoleNotFound) {
+			return entities.Role{}, err
+		} else if strings.HasPrefix(err.Error(), "Error 1062") {
+			return entities.Role{}, constants.ErrRoleAlreadyExists
+		}
+		return entities.Role{}, constants.ErrInternalServerError
+	}
+
+	return *role, nil
+}
+
+func (u *RoleUseCase) Delete(id int) error {
+	err := u.repository.Delete(id)
+	if err != nil {
+		if errors.Is(err, constants.ErrRoleNotFound) {
+			return err
+		}
+		return constants.ErrInternalServerError
+	}
+
+	return nil
+}
+
+func (u *RoleUseCase) GetPermissions() ([]entities.Permission, error) {
+	permissions, err := u.repository.GetPermissions()
+	if err != nil {
+		return nil, constants.ErrInternalServerError
+	}
+
+	return permissions, nil
+}
+
+func (u *RoleUseCase) GetAdminAccess(adminID int) (entities.Admin, error) {
+	admin, err := u.repository.GetAdminAccess(adminID)
+	if err != nil {
+		if errors.Is(err, constants.ErrAdminNotFound) {
+			return entities.Admin{}, err
+		}
+		return entities.Admin{}, constants.ErrInternalServerError
+	}
+
+	return admin, nil
+}
+
+// UpdateAdminAccess replaces the role and the regency and category scope of an admin.
+func (u *RoleUseCase) UpdateAdminAccess(adminID int, roleID *int, regencyIDs []string, categoryIDs []int) (entities.Admin, error) {
+	err := u.repository.UpdateAdminAccess(adminID, roleID, uniqueStrings(regencyIDs), uniqueInts(categoryIDs))
+	if err != nil {
+		if errors.Is(err, constants.ErrAdminNotFound) || errors.Is(err, constants.ErrRoleNotFound) || errors.Is(err, constants.ErrRegencyNotFound) || errors.Is(err, constants.ErrCategoryNotFound) {
+			return entities.Admin{}, err
+		}
+		return entities.Admin{}, constants.ErrInternalServerError
+	}
+
+	return u.GetAdminAccess(adminID)
+}
+
+// HasPermission checks whether the logged in account may use a permission. Super admins
+// have every permission and admins without a role have none.
+func (u *RoleUseCase) HasPermission(adminID int, role string, permission string) error {
+	if role == "super_admin" {
+		return nil
+	}
+
+	if role != "admin" {
+		return constants.ErrForbidden
+	}
+
+	admin, err := u.repository.GetAdminAccess(adminID)
+	if err != nil {
+		if errors.Is(err, constants.ErrAdminNotFound) {
+			return constants.ErrUnauthorized
+		}
+		return constants.ErrInternalServerError
+	}
+
+	if admin.IsSuperAdmin {
+		return nil
+	}
+
+	if admin.Role == nil {
+		return constants.ErrForbidden
+	}
+
+	for _, rolePermission := range admin.Role.Permissions {
+		if rolePermission.Name == permission {
+			return nil
+		}
+	}
+
+	return constants.ErrForbidden
+}
+
+// GetScope returns the regencies and categories the logged in account is limited to.
+// Only admins can be limited, every other role gets an empty scope.
+func (u *RoleUseCase) GetScope(adminID int, role string) (entities.AdminScope, error) {
+	if role != "admin" {
+		return entities.AdminScope{}, nil
+	}
+
+	admin, err := u.repository.GetAdminAccess(adminID)
+	if err != nil {
+		if errors.Is(err, constants.ErrAdminNotFound) {
+			return entities.AdminScope{}, constants.ErrUnauthorized
+		}
+		return entities.AdminScope{}, constants.ErrInternalServerError
+	}
+
+	var scope entities.AdminScope
+	for _, regency := range admin.Regencies {
+		scope.RegencyIDs = append(scope.RegencyIDs, regency.ID)
+	}
+	for _, category := range admin.Categories {
+		scope.CategoryIDs = append(scope.CategoryIDs, category.ID)
+	}
+
+	return scope, nil
+}
+
+func (u *RoleUseCase) EnsureInScope(adminID int, role string, complaint entities.Complaint) error {
+	scope, err := u.GetScope(adminID, role)
+	if err != nil {
+		return err
+	}
+
+	if !scope.Contains(complaint.RegencyID, complaint.CategoryID) {
+		return constants.ErrComplaintOutOfScope
+	}
+
+	return nil
+}
+
+func validateRole(role *entities.Role, permissions []string) error {
+	if role.Name == "" || len(permissions) == 0 {
+		return constants.ErrAllFieldsMustBeFilled
+	}
+
+	for _, permission := range permissions {
+		valid := false
+		for _, knownPermission := range constants.Permissions {
+			if permission == knownPermission {
+				valid = true
+				break
+			}
+		}
+
+		if !valid {
+			return constants.ErrInvalidPermission
+		}
+	}
+
+	return nil
+}
+
+func uniqueStrings(values []string) []string {
+	var unique []string
+	seen := map[string]bool{}
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			unique = append(unique, value)
+		}
+	}
+
+	return unique
+}
+
+func uniqueInts(values []int) []int {
+	var unique []int
+	seen := map[int]bool{}
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			unique = append(unique, value)
+		}
+	}
+
+	return unique
+}
@@ -0,0 +1,569 @@
+package role
+
+import (
+	"e-complaint-api/constants"
+	"e-complaint-api/entities"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockRoleRepo struct {
+	mock.Mock
+}
+
+func (m *MockRoleRepo) GetAll() ([]entities.Role, error) {
+	args := m.Called()
+	return args.Get(0).([]entities.Role), args.Error(1)
+}
+
+func (m *MockRoleRepo) GetByID(id int) (entities.Role, error) {
+	args := m.Called(id)
+	return args.Get(0).(entities.Role), args.Error(1)
+}
+
+func (m *MockRoleRepo) Create(role *entities.Role, permissions []string) error {
+	args := m.Called(role, permissions)
+	return args.Error(0)
+}
+
+func (m *MockRoleRepo) Update(role *entities.Role, permissions []string) error {
+	args := m.Called(role, permissions)
+	return args.Error(0)
+}
+
+func (m *MockRoleRepo) Delete(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockRoleRepo) GetPermissions() ([]entities.Permission, error) {
+	args := m.Called()
+	return args.Get(0).([]entities.Permission), args.Error(1)
+}
+
+func (m *MockRoleRepo) GetAdminAccess(adminID int) (entities.Admin, error) {
+	args := m.Called(adminID)
+	return args.Get(0).(entities.Admin), args.Error(1)
+}
+
+func (m *MockRoleRepo) UpdateAdminAccess(adminID int, roleID *int, regencyIDs []string, categoryIDs []int) error {
+	args := m.Called(adminID, roleID, regencyIDs, categoryIDs)
+	return args.Error(0)
+}
+
+func TestGetAll(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockRepo := new(MockRoleRepo)
+		usecase := NewRoleUseCase(mockRepo)
+
+		roles := []entities.Role{{ID: 1, Name: "Petugas"}}
+		mockRepo.On("GetAll").Return(roles, nil)
+
+		result, err := usecase.GetAll()
+
+		assert.NoError(t, err)
+		assert.Equal(t, roles, result)
+	})
+
+	t.Run("internal server error", func(t *testing.T) {
+		mockRepo := new(MockRoleRepo)
+		usecase := NewRoleUseCase(mockRepo)
+
+		mockRepo.On("GetAll").Return([]entities.Role{}, errors.New("database error"))
+
+		result, err := usecase.GetAll()
+
+		assert.Nil(t, result)
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+}
+
+func TestGetByID(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockRepo := new(MockRoleRepo)
+		usecase := NewRoleUseCase(mockRepo)
+
+		role := entities.Role{ID: 1, Name: "Petugas"}
+		mockRepo.On("GetByID", 1).Return(role, nil)
+
+		result, err := usecase.GetByID(1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, role, result)
+	})
+
+	t.Run("failed role not found", func(t *testing.T) {
+		mockRepo := new(MockRoleRepo)
+		usecase := NewRoleUseCase(mockRepo)
+
+		mockRepo.On("GetByID", 1).Return(entities.Role{}, constants.ErrRoleNotFound)
+
+		_, err := usecase.GetByID(1)
+
+		assert.Equal(t, constants.ErrRoleNotFound, err)
+	})
+
+	t.Run("internal server error", func(t *testing.T) {
+		mockRepo := new(MockRoleRepo)
+		usecase := NewRoleUseCase(mockRepo)
+
+		mockRepo.On("GetByID", 1).Return(entities.Role{}, errors.New("database error"))
+
+		_, err := usecase.GetByID(1)
+
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+}
+
+func TestCreate(t *testing.T) {
+	permissions := []string{constants.PermissionComplaintProcess}
+
+	t.Run("success", func(t *testing.T) {
+		mockRepo := new(MockRoleRepo)
+		usecase := NewRoleUseCase(mockRepo)
+
+		role := &entities.Role{Name: "Petugas"}
+		mockRepo.On("Create", role, permissions).Return(nil)
+
+		result, err := usecase.Create(role, permissions)
+
+		assert.NoError(t, err)
+		assert.Equal(t, *role, result)
+	})
+
+	t.Run("failed fields are empty", func(t *testing.T) {
+		mockRepo := new(MockRoleRepo)
+		usecase := NewRoleUseCase(mockRepo)
+
+		_, err := usecase.Create(&entities.Role{Name: "Petugas"}, nil)
+
+		assert.Equal(t, constants.ErrAllFieldsMustBeFilled, err)
+	})
+
+	t.Run("failed invalid permission", func(t *testing.T) {
+		mockRepo := new(MockRoleRepo)
+		usecase := NewRoleUseCase(mockRepo)
+
+		_, err := usecase.Create(&entities.Role{Name: "Petugas"}, []string{"invalid:permission"})
+
+		assert.Equal(t, constants.ErrInvalidPermission, err)
+	})
+
+	t.Run("failed role already exists", func(t *testing.T) {
+		mockRepo := new(MockRoleRepo)
+		usecase := NewRoleUseCase(mockRepo)
+
+		mockRepo.On("Create", mock.Anything, permissions).Return(errors.New("Error 1062 (23000): Duplicate entry 'Petugas'"))
+
+		_, err := usecase.Create(&entities.Role{Name: "Petugas"}, permissions)
+
+		assert.Equal(t, constants.ErrRoleAlreadyExists, err)
+	})
+
+	t.Run("internal server error", func(t *testing.T) {
+		mockRepo := new(MockRoleRepo)
+		usecase := NewRoleUseCase(mockRepo)
+
+		mockRepo.On("Create", mock.Anything, permissions).Return(errors.New("database error"))
+
+		_, err := usecase.Create(&entities.Role{Name: "Petugas"}, permissions)
+
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	permissions := []string{constants.PermissionComplaintProcess}
+
+	t.Run("success", func(t *testing.T) {
+		mockRepo := new(MockRoleRepo)
+		usecase := NewRoleUseCase(mockRepo)
+
+		role := &entities.Role{ID: 1, Name: "Petugas"}
+		mockRepo.On("Update", role, permissions).Return(nil)
+
+		result, err := usecase.Update(role, permissions)
+
+		assert.NoError(t, err)
+		assert.Equal(t, *role, result)
+	})
+
+	t.Run("failed fields are empty", func(t *testing.T) {
+		mockRepo := new(MockRoleRepo)
+		usecase := NewRoleUseCase(mockRepo)
+
+		_, err := usecase.Update(&entities.Role{ID: 1}, permissions)
+
+		assert.Equal(t, constants.ErrAllFieldsMustBeFilled, err)
+	})
+
+	t.Run("failed role not found", func(t *testing.T) {
+		mockRepo := new(MockRoleRepo)
+		usecase := NewRoleUseCase(mockRepo)
+
+		mockRepo.On("Update", mock.Anything, permissions).Return(constants.ErrRoleNotFound)
+
+		_, err := usecase.Update(&entities.Role{ID: 1, Name: "Petugas"}, permissions)
+
+		assert.Equal(t, constants.ErrRoleNotFound, err)
+	})
+
+	t.Run("failed role already exists", func(t *testing.T) {
+		mockRepo := new(MockRoleRepo)
+		usecase := NewRoleUseCase(mockRepo)
+
+		mockRepo.On("Update", mock.Anything, permissions).Return(errors.New("Error 1062 (23000): Duplicate entry 'Petugas'"))
+
+		_, err := usecase.Update(&entities.Role{ID: 1, Name: "Petugas"}, permissions)
+
+		assert.Equal(t, constants.ErrRoleAlreadyExists, err)
+	})
+
+	t.Run("internal server error", func(t *testing.T) {
+		mockRepo := new(MockRoleRepo)
+		usecase := NewRoleUseCase(mockRepo)
+
+		mockRepo.On("Update", mock.Anything, permissions).Return(errors.New("database error"))
+
+		_, err := usecase.Update(&entities.Role{ID: 1, Name: "Petugas"}, permissions)
+
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+}
+
+func TestDelete(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockRepo := new(MockRoleRepo)
+		usecase := NewRoleUseCase(mockRepo)
+
+		mockRepo.On("Delete", 1).Return(nil)
+
+		err := usecase.Delete(1)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("failed role not found", func(t *testing.T) {
+		mockRepo := new(MockRoleRepo)
+		usecase := NewRoleUseCase(mockRepo)
+
+		mockRepo.On("Delete", 1).Return(constants.ErrRoleNotFound)
+
+		err := usecase.Delete(1)
+
+		assert.Equal(t, constants.ErrRoleNotFound, err)
+	})
+
+	t.Run("internal server error", func(t *testing.T) {
+		mockRepo := new(MockRoleRepo)
+		usecase := NewRoleUseCase(mockRepo)
+
+		mockRepo.On("Delete", 1).Return(errors.New("database error"))
+
+		err := usecase.Delete(1)
+
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+}
+
+func TestGetPermissions(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockRepo := new(MockRoleRepo)
+		usecase := NewRoleUseCase(mockRepo)
+
+		permissions := []entities.Permission{{ID: 1, Name: constants.PermissionAdminRead}}
+		mockRepo.On("GetPermissions").Return(permissions, nil)
+
+		result, err := usecase.GetPermissions()
+
+		assert.NoError(t, err)
+		assert.Equal(t, permissions, result)
+	})
+
+	t.Run("internal server error", func(t *testing.T) {
+		mockRepo := new(MockRoleRepo)
+		usecase := NewRoleUseCase(mockRepo)
+
+		mockRepo.On("GetPermissions").Return([]entities.Permission{}, errors.New("database error"))
+
+		result, err := usecase.GetPermissions()
+
+		assert.Nil(t, result)
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+}
+
+func TestGetAdminAccess(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockRepo := new(MockRoleRepo)
+		usecase := NewRoleUseCase(mockRepo)
+
+		admin := entities.Admin{ID: 2}
+		mockRepo.On("GetAdminAccess", 2).Return(admin, nil)
+
+		result, err := usecase.GetAdminAccess(2)
+
+		assert.NoError(t, err)
+		assert.Equal(t, admin, result)
+	})
+
+	t.Run("failed admin not found", func(t *testing.T) {
+		mockRepo := new(MockRoleRepo)
+		usecase := NewRoleUseCase(mockRepo)
+
+		mockRepo.On("GetAdminAccess", 2).Return(entities.Admin{}, constants.ErrAdminNotFound)
+
+		_, err := usecase.GetAdminAccess(2)
+
+		assert.Equal(t, constants.ErrAdminNotFound, err)
+	})
+
+	t.Run("internal server error", func(t *testing.T) {
+		mockRepo := new(MockRoleRepo)
+		usecase := NewRoleUseCase(mockRepo)
+
+		mockRepo.On("GetAdminAccess", 2).Return(entities.Admin{}, errors.New("database error"))
+
+		_, err := usecase.GetAdminAccess(2)
+
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+}
+
+func TestUpdateAdminAccess(t *testing.T) {
+	roleID := 1
+
+	t.Run("success", func(t *testing.T) {
+		mockRepo := new(MockRoleRepo)
+		usecase := NewRoleUseCase(mockRepo)
+
+		admin := entities.Admin{ID: 2, RoleID: &roleID}
+		mockRepo.On("UpdateAdminAccess", 2, &roleID, []string{"3604"}, []int{1, 2}).Return(nil)
+		mockRepo.On("GetAdminAccess", 2).Return(admin, nil)
+
+		result, err := usecase.UpdateAdminAccess(2, &roleID, []string{"3604", "3604"}, []int{1, 2, 1})
+
+		assert.NoError(t, err)
+		assert.Equal(t, admin, result)
+	})
+
+	t.Run("failed regency not found", func(t *testing.T) {
+		mockRepo := new(MockRoleRepo)
+		usecase := NewRoleUseCase(mockRepo)
+
+		mockRepo.On("UpdateAdminAccess", 2, &roleID, []string{"9999"}, []int(nil)).Return(constants.ErrRegencyNotFound)
+
+		_, err := usecase.UpdateAdminAccess(2, &roleID, []string{"9999"}, nil)
+
+		assert.Equal(t, constants.ErrRegencyNotFound, err)
+	})
+
+	t.Run("internal server error", func(t *testing.T) {
+		mockRepo := new(MockRoleRepo)
+		usecase := NewRoleUseCase(mockRepo)
+
+		mockRepo.On("UpdateAdminAccess", 2, &roleID, []string(nil), []int(nil)).Return(errors.New("database error"))
+
+		_, err := usecase.UpdateAdminAccess(2, &roleID, nil, nil)
+
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+}
+
+func TestHasPermission(t *testing.T) {
+	role := &entities.Role{
+		ID:          1,
+		Name:        "Petugas",
+		Permissions: []entities.Permission{{Name: constants.PermissionComplaintProcess}},
+	}
+
+	t.Run("success super admin", func(t *testing.T) {
+		mockRepo := new(MockRoleRepo)
+		usecase := NewRoleUseCase(mockRepo)
+
+		err := usecase.HasPermission(1, "super_admin", constants.PermissionNewsManage)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("success role has permission", func(t *testing.T) {
+		mockRepo := new(MockRoleRepo)
+		usecase := NewRoleUseCase(mockRepo)
+
+		mockRepo.On("GetAdminAccess", 2).Return(entities.Admin{ID: 2, Role: role}, nil)
+
+		err := usecase.HasPermission(2, "admin", constants.PermissionComplaintProcess)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("success account is a super admin", func(t *testing.T) {
+		mockRepo := new(MockRoleRepo)
+		usecase := NewRoleUseCase(mockRepo)
+
+		mockRepo.On("GetAdminAccess", 1).Return(entities.Admin{ID: 1, IsSuperAdmin: true}, nil)
+
+		err := usecase.HasPermission(1, "admin", constants.PermissionComplaintProcess)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("failed admin without role", func(t *testing.T) {
+		mockRepo := new(MockRoleRepo)
+		usecase := NewRoleUseCase(mockRepo)
+
+		mockRepo.On("GetAdminAccess", 2).Return(entities.Admin{ID: 2}, nil)
+
+		err := usecase.HasPermission(2, "admin", constants.PermissionNewsManage)
+
+		assert.Equal(t, constants.ErrForbidden, err)
+	})
+
+	t.Run("failed role has no permission", func(t *testing.T) {
+		mockRepo := new(MockRoleRepo)
+		usecase := NewRoleUseCase(mockRepo)
+
+		mockRepo.On("GetAdminAccess", 2).Return(entities.Admin{ID: 2, Role: role}, nil)
+
+		err := usecase.HasPermission(2, "admin", constants.PermissionNewsManage)
+
+		assert.Equal(t, constants.ErrForbidden, err)
+	})
+
+	t.Run("failed user", func(t *testing.T) {
+		mockRepo := new(MockRoleRepo)
+		usecase := NewRoleUseCase(mockRepo)
+
+		err := usecase.HasPermission(2, "user", constants.PermissionNewsManage)
+
+		assert.Equal(t, constants.ErrForbidden, err)
+	})
+
+	t.Run("failed admin not found", func(t *testing.T) {
+		mockRepo := new(MockRoleRepo)
+		usecase := NewRoleUseCase(mockRepo)
+
+		mockRepo.On("GetAdminAccess", 2).Return(entities.Admin{}, constants.ErrAdminNotFound)
+
+		err := usecase.HasPermission(2, "admin", constants.PermissionNewsManage)
+
+		assert.Equal(t, constants.ErrUnauthorized, err)
+	})
+
+	t.Run("internal server error", func(t *testing.T) {
+		mockRepo := new(MockRoleRepo)
+		usecase := NewRoleUseCase(mockRepo)
+
+		mockRepo.On("GetAdminAccess", 2).Return(entities.Admin{}, errors.New("database error"))
+
+		err := usecase.HasPermission(2, "admin", constants.PermissionNewsManage)
+
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+}
+
+func TestGetScope(t *testing.T) {
+	t.Run("success admin", func(t *testing.T) {
+		mockRepo := new(MockRoleRepo)
+		usecase := NewRoleUseCase(mockRepo)
+
+		admin := entities.Admin{
+			ID:         4,
+			Regencies:  []entities.Regency{{ID: "3604"}},
+			Categories: []entities.Category{{ID: 1}},
+		}
+		mockRepo.On("GetAdminAccess", 4).Return(admin, nil)
+
+		result, err := usecase.GetScope(4, "admin")
+
+		assert.NoError(t, err)
+		assert.Equal(t, entities.AdminScope{RegencyIDs: []string{"3604"}, CategoryIDs: []int{1}}, result)
+	})
+
+	t.Run("success super admin", func(t *testing.T) {
+		mockRepo := new(MockRoleRepo)
+		usecase := NewRoleUseCase(mockRepo)
+
+		result, err := usecase.GetScope(1, "super_admin")
+
+		assert.NoError(t, err)
+		assert.True(t, result.IsEmpty())
+	})
+
+	t.Run("failed admin not found", func(t *testing.T) {
+		mockRepo := new(MockRoleRepo)
+		usecase := NewRoleUseCase(mockRepo)
+
+		mockRepo.On("GetAdminAccess", 4).Return(entities.Admin{}, constants.ErrAdminNotFound)
+
+		_, err := usecase.GetScope(4, "admin")
+
+		assert.Equal(t, constants.ErrUnauthorized, err)
+	})
+
+	t.Run("internal server error", func(t *testing.T) {
+		mockRepo := new(MockRoleRepo)
+		usecase := NewRoleUseCase(mockRepo)
+
+		mockRepo.On("GetAdminAccess", 4).Return(entities.Admin{}, errors.New("database error"))
+
+		_, err := usecase.GetScope(4, "admin")
+
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+}
+
+func TestEnsureInScope(t *testing.T) {
+	admin := entities.Admin{
+		ID:         4,
+		Regencies:  []entities.Regency{{ID: "3604"}},
+		Categories: []entities.Category{{ID: 1}},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockRepo := new(MockRoleRepo)
+		usecase := NewRoleUseCase(mockRepo)
+
+		mockRepo.On("GetAdminAccess", 4).Return(admin, nil)
+
+		err := usecase.EnsureInScope(4, "admin", entities.Complaint{RegencyID: "3604", CategoryID: 1})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("failed regency out of scope", func(t *testing.T) {
+		mockRepo := new(MockRoleRepo)
+		usecase := NewRoleUseCase(mockRepo)
+
+		mockRepo.On("GetAdminAccess", 4).Return(admin, nil)
+
+		err := usecase.EnsureInScope(4, "admin", entities.Complaint{RegencyID: "3601", CategoryID: 1})
+
+		assert.Equal(t, constants.ErrComplaintOutOfScope, err)
+	})
+
+	t.Run("failed category out of scope", func(t *testing.T) {
+		mockRepo := new(MockRoleRepo)
+		usecase := NewRoleUseCase(mockRepo)
+
+		mockRepo.On("GetAdminAccess", 4).Return(admin, nil)
+
+		err := usecase.EnsureInScope(4, "admin", entities.Complaint{RegencyID: "3604", CategoryID: 2})
+
+		assert.Equal(t, constants.ErrComplaintOutOfScope, err)
+	})
+
+	t.Run("failed get scope", func(t *testing.T) {
+		mockRepo := new(MockRoleRepo)
+		usecase := NewRoleUseCase(mockRepo)
+
+		mockRepo.On("GetAdminAccess", 4).Return(entities.Admin{}, errors.New("database error"))
+
+		err := usecase.EnsureInScope(4, "admin", entities.Complaint{RegencyID: "3604", CategoryID: 1})
+
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+}
@@ -0,0 +1,108 @@
+package search
+
+import (
+	"e-complaint-api/constants"
+	"e-complaint-api/entities"
+	"errors"
+	"strings"
+)
+
+type SearchUseCase struct {
+	searchEngine  entities.SearchEngineInterface
+	complaintRepo entities.ComplaintRepositoryInterface
+}
+
+func NewSearchUseCase(searchEngine entities.SearchEngineInterface, complaintRepo entities.ComplaintRepositoryInterface) *SearchUseCase {
+	return &SearchUseCase{
+		searchEngine:  searchEngine,
+		complaintRepo: complaintRepo,
+	}
+}
+
+// Search ranks the records of collection that match query. Complaints, and the
+// discussions of complaints, outside of a non-empty scope are left out.
+func (u *SearchUseCase) Search(collection string, query string, limit int, scope entities.AdminScope) ([]entities.SearchHit, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, constants.ErrSearchQueryMustBeFilled
+	}
+
+	switch collection {
+	case constants.SearchComplaints, constants.SearchNews, constants.SearchDiscussions:
+	default:
+		return nil, constants.ErrInvalidSearchCollection
+	}
+
+	if limit <= 0 {
+		limit = constants.DefaultSearchLimit
+	} else if limit > constants.MaxSearchLimit {
+		limit = constants.MaxSearchLimit
+	}
+
+	scoped := !scope.IsEmpty() && collection != constants.SearchNews
+
+	// the hits out of scope are only known after the search, so a scoped search ranks
+	// every hit and keeps the first limit in scope
+	engineLimit := limit
+	if scoped {
+		engineLimit = constants.MaxSearchHits
+	}
+
+	hits, err := u.searchEngine.Search(collection, query, engineLimit)
+	if err != nil {
+		if errors.Is(err, constants.ErrInvalidSearchCollection) {
+			return nil, err
+		}
+		return nil, constants.ErrInternalServerError
+	}
+
+	if scoped {
+		hits, err = u.inScope(collection, hits, scope)
+		if err != nil {
+			return nil, constants.ErrInternalServerError
+		}
+	}
+	if len(hits) > limit {
+		hits = hits[:limit]
+	}
+
+	return hits, nil
+}
+
+// inScope keeps the hits whose complaint is in scope, in their order.
+func (u *SearchUseCase) inScope(collection string, hits []entities.SearchHit, scope entities.AdminScope) ([]entities.SearchHit, error) {
+	if len(hits) == 0 {
+		return hits, nil
+	}
+
+	complaintID := func(hit entities.SearchHit) string {
+		if collection == constants.SearchDiscussions {
+			return hit.Reference
+		}
+		return hit.ID
+	}
+
+	ids := make([]string, 0, len(hits))
+	for _, hit := range hits {
+		ids = append(ids, complaintID(hit))
+	}
+
+	complaints, err := u.complaintRepo.GetPaginated(0, 0, "", map[string]interface{}{"ids": ids, "scope": scope}, "id", "asc")
+	if err != nil {
+		return nil, err
+	}
+
+	allowed := map[string]bool{}
+	for _, complaint := range complaints {
+		allowed[complaint.ID] = true
+	}
+
+	filtered := []entities.SearchHit{}
+	for _, hit := range hits {
+		if allowed[complaintID(hit)] {
+			filtered = append(filtered, hit)
+		}
+	}
+
+	return filtered, nil
+}
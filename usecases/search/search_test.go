@@ -0,0 +1,235 @@
+package search
+
+import (
+	"e-complaint-api/constants"
+	"e-complaint-api/entities"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockSearchEngine struct {
+	mock.Mock
+}
+
+func (m *MockSearchEngine) Search(collection string, query string, limit int) ([]entities.SearchHit, error) {
+	args := m.Called(collection, query, limit)
+	return args.Get(0).([]entities.SearchHit), args.Error(1)
+}
+
+type MockComplaintRepo struct {
+	mock.Mock
+}
+
+func (m *MockComplaintRepo) GetPaginated(limit int, page int, search string, filter map[string]interface{}, sortBy string, sortType string) ([]entities.Complaint, error) {
+	args := m.Called(limit, page, search, filter, sortBy, sortType)
+	return args.Get(0).([]entities.Complaint), args.Error(1)
+}
+
+func (m *MockComplaintRepo) GetMetaData(limit int, page int, search string, filter map[string]interface{}) (entities.Metadata, error) {
+	args := m.Called(limit, page, search, filter)
+	return args.Get(0).(entities.Metadata), args.Error(1)
+}
+
+func (m *MockComplaintRepo) GetAfter(limit int, afterID string, afterValue string, search string, filter map[string]interface{}, sortBy string, sortType string) ([]entities.Complaint, error) {
+	args := m.Called(limit, afterID, afterValue, search, filter, sortBy, sortType)
+	return args.Get(0).([]entities.Complaint), args.Error(1)
+}
+
+func (m *MockComplaintRepo) GetAfterWithProcesses(limit int, afterID string, afterValue string, search string, filter map[string]interface{}, sortBy string, sortType string) ([]entities.Complaint, error) {
+	args := m.Called(limit, afterID, afterValue, search, filter, sortBy, sortType)
+	return args.Get(0).([]entities.Complaint), args.Error(1)
+}
+
+func (m *MockComplaintRepo) GetWithLocation(limit int, search string, filter map[string]interface{}) ([]entities.Complaint, error) {
+	args := m.Called(limit, search, filter)
+	return args.Get(0).([]entities.Complaint), args.Error(1)
+}
+
+func (m *MockComplaintRepo) GetByID(id string) (entities.Complaint, error) {
+	args := m.Called(id)
+	return args.Get(0).(entities.Complaint), args.Error(1)
+}
+
+func (m *MockComplaintRepo) GetByUserID(userId int) ([]entities.Complaint, error) {
+	args := m.Called(userId)
+	return args.Get(0).([]entities.Complaint), args.Error(1)
+}
+
+func (m *MockComplaintRepo) Create(complaint *entities.Complaint) error {
+	args := m.Called(complaint)
+	return args.Error(0)
+}
+
+func (m *MockComplaintRepo) Delete(id string, userId int) error {
+	args := m.Called(id, userId)
+	return args.Error(0)
+}
+
+func (m *MockComplaintRepo) AdminDelete(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockComplaintRepo) Update(complaint entities.Complaint) (entities.Complaint, error) {
+	args := m.Called(complaint)
+	return args.Get(0).(entities.Complaint), args.Error(1)
+}
+
+func (m *MockComplaintRepo) UpdateStatus(id string, status string) error {
+	args := m.Called(id, status)
+	return args.Error(0)
+}
+
+func (m *MockComplaintRepo) GetStatus(id string) (string, error) {
+	args := m.Called(id)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockComplaintRepo) IncreaseTotalLikes(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockComplaintRepo) DecreaseTotalLikes(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockComplaintRepo) GetComplaintIDsByUserID(userID int) ([]string, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func TestSearch(t *testing.T) {
+	hits := []entities.SearchHit{
+		{ID: "C-1", Score: 3.2, Highlights: map[string]string{"description": "Jalan <mark>berlubang</mark>"}},
+		{ID: "C-2", Score: 1.1, Highlights: map[string]string{"address": "Jl. <mark>Lubang</mark> Buaya"}},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockSearchEngine := new(MockSearchEngine)
+		searchUseCase := NewSearchUseCase(mockSearchEngine, new(MockComplaintRepo))
+
+		mockSearchEngine.On("Search", constants.SearchComplaints, "jalan berlubang", 10).Return(hits, nil)
+
+		result, err := searchUseCase.Search(constants.SearchComplaints, " jalan berlubang ", 10, entities.AdminScope{})
+		assert.NoError(t, err)
+		assert.Equal(t, hits, result)
+	})
+
+	t.Run("success default limit", func(t *testing.T) {
+		mockSearchEngine := new(MockSearchEngine)
+		searchUseCase := NewSearchUseCase(mockSearchEngine, new(MockComplaintRepo))
+
+		mockSearchEngine.On("Search", constants.SearchNews, "banjir", constants.DefaultSearchLimit).Return([]entities.SearchHit{}, nil)
+
+		result, err := searchUseCase.Search(constants.SearchNews, "banjir", 0, entities.AdminScope{})
+		assert.NoError(t, err)
+		assert.Empty(t, result)
+	})
+
+	t.Run("success max limit", func(t *testing.T) {
+		mockSearchEngine := new(MockSearchEngine)
+		searchUseCase := NewSearchUseCase(mockSearchEngine, new(MockComplaintRepo))
+
+		mockSearchEngine.On("Search", constants.SearchDiscussions, "sampah", constants.MaxSearchLimit).Return([]entities.SearchHit{{ID: "1", Reference: "C-1"}}, nil)
+
+		result, err := searchUseCase.Search(constants.SearchDiscussions, "sampah", 1000, entities.AdminScope{})
+		assert.NoError(t, err)
+		assert.Equal(t, "C-1", result[0].Reference)
+	})
+
+	t.Run("failed query must be filled", func(t *testing.T) {
+		searchUseCase := NewSearchUseCase(new(MockSearchEngine), new(MockComplaintRepo))
+
+		result, err := searchUseCase.Search(constants.SearchComplaints, "  ", 10, entities.AdminScope{})
+		assert.Equal(t, constants.ErrSearchQueryMustBeFilled, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("failed invalid collection", func(t *testing.T) {
+		searchUseCase := NewSearchUseCase(new(MockSearchEngine), new(MockComplaintRepo))
+
+		result, err := searchUseCase.Search("users", "admin", 10, entities.AdminScope{})
+		assert.Equal(t, constants.ErrInvalidSearchCollection, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("failed collection unknown to the engine", func(t *testing.T) {
+		mockSearchEngine := new(MockSearchEngine)
+		searchUseCase := NewSearchUseCase(mockSearchEngine, new(MockComplaintRepo))
+
+		mockSearchEngine.On("Search", constants.SearchNews, "banjir", 10).Return([]entities.SearchHit(nil), constants.ErrInvalidSearchCollection)
+
+		_, err := searchUseCase.Search(constants.SearchNews, "banjir", 10, entities.AdminScope{})
+		assert.Equal(t, constants.ErrInvalidSearchCollection, err)
+	})
+
+	t.Run("failed internal server error", func(t *testing.T) {
+		mockSearchEngine := new(MockSearchEngine)
+		searchUseCase := NewSearchUseCase(mockSearchEngine, new(MockComplaintRepo))
+
+		mockSearchEngine.On("Search", constants.SearchComplaints, "banjir", 10).Return([]entities.SearchHit(nil), errors.New("database error"))
+
+		_, err := searchUseCase.Search(constants.SearchComplaints, "banjir", 10, entities.AdminScope{})
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+
+	t.Run("success scoped complaints", func(t *testing.T) {
+		mockSearchEngine := new(MockSearchEngine)
+		mockComplaintRepo := new(MockComplaintRepo)
+		searchUseCase := NewSearchUseCase(mockSearchEngine, mockComplaintRepo)
+		scope := entities.AdminScope{RegencyIDs: []string{"3604"}}
+
+		mockSearchEngine.On("Search", constants.SearchComplaints, "banjir", constants.MaxSearchHits).Return([]entities.SearchHit{{ID: "C-1"}, {ID: "C-2"}, {ID: "C-3"}}, nil)
+		mockComplaintRepo.On("GetPaginated", 0, 0, "", map[string]interface{}{"ids": []string{"C-1", "C-2", "C-3"}, "scope": scope}, "id", "asc").
+			Return([]entities.Complaint{{ID: "C-2"}, {ID: "C-3"}}, nil)
+
+		result, err := searchUseCase.Search(constants.SearchComplaints, "banjir", 1, scope)
+		assert.NoError(t, err)
+		assert.Equal(t, []entities.SearchHit{{ID: "C-2"}}, result)
+	})
+
+	t.Run("success scoped discussions", func(t *testing.T) {
+		mockSearchEngine := new(MockSearchEngine)
+		mockComplaintRepo := new(MockComplaintRepo)
+		searchUseCase := NewSearchUseCase(mockSearchEngine, mockComplaintRepo)
+		scope := entities.AdminScope{CategoryIDs: []int{1}}
+
+		mockSearchEngine.On("Search", constants.SearchDiscussions, "sampah", constants.MaxSearchHits).Return([]entities.SearchHit{{ID: "1", Reference: "C-1"}, {ID: "2", Reference: "C-2"}}, nil)
+		mockComplaintRepo.On("GetPaginated", 0, 0, "", map[string]interface{}{"ids": []string{"C-1", "C-2"}, "scope": scope}, "id", "asc").
+			Return([]entities.Complaint{{ID: "C-1"}}, nil)
+
+		result, err := searchUseCase.Search(constants.SearchDiscussions, "sampah", 10, scope)
+		assert.NoError(t, err)
+		assert.Equal(t, []entities.SearchHit{{ID: "1", Reference: "C-1"}}, result)
+	})
+
+	t.Run("success scoped news", func(t *testing.T) {
+		mockSearchEngine := new(MockSearchEngine)
+		mockComplaintRepo := new(MockComplaintRepo)
+		searchUseCase := NewSearchUseCase(mockSearchEngine, mockComplaintRepo)
+
+		mockSearchEngine.On("Search", constants.SearchNews, "banjir", 10).Return([]entities.SearchHit{{ID: "1"}}, nil)
+
+		result, err := searchUseCase.Search(constants.SearchNews, "banjir", 10, entities.AdminScope{RegencyIDs: []string{"3604"}})
+		assert.NoError(t, err)
+		assert.Len(t, result, 1)
+		mockComplaintRepo.AssertNotCalled(t, "GetPaginated", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("failed scope", func(t *testing.T) {
+		mockSearchEngine := new(MockSearchEngine)
+		mockComplaintRepo := new(MockComplaintRepo)
+		searchUseCase := NewSearchUseCase(mockSearchEngine, mockComplaintRepo)
+
+		mockSearchEngine.On("Search", constants.SearchComplaints, "banjir", constants.MaxSearchHits).Return([]entities.SearchHit{{ID: "C-1"}}, nil)
+		mockComplaintRepo.On("GetPaginated", 0, 0, "", mock.Anything, "id", "asc").Return([]entities.Complaint(nil), errors.New("database error"))
+
+		_, err := searchUseCase.Search(constants.SearchComplaints, "banjir", 10, entities.AdminScope{RegencyIDs: []string{"3604"}})
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+}
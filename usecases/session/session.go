@@ -0,0 +1,158 @@
+package session
+
+import (
+	"e-complaint-api/constants"
+	"e-complaint-api/entities"
+	"e-complaint-api/middlewares"
+	"e-complaint-api/utils"
+	"errors"
+	"time"
+)
+
+type SessionUseCase struct {
+	repository      entities.SessionRepositoryInterface
+	accessTokenTTL  time.Duration
+	refreshTokenTTL time.Duration
+}
+
+func NewSessionUseCase(repository entities.SessionRepositoryInterface, accessTokenTTL time.Duration, refreshTokenTTL time.Duration) *SessionUseCase {
+	return &SessionUseCase{
+		repository:      repository,
+		accessTokenTTL:  accessTokenTTL,
+		refreshTokenTTL: refreshTokenTTL,
+	}
+}
+
+func getSubjectType(role string) string {
+	if role == "admin" || role == "super_admin" {
+		return "admin"
+	}
+
+	return "user"
+}
+
+// newSession builds a session with a fresh refresh token together with the tokens
+// handed out to the client.
+func (u *SessionUseCase) newSession(subjectID int, role string, name string, email string) (entities.Session, entities.AuthToken) {
+	now := time.Now()
+	refreshToken := utils.GenerateToken(32)
+
+	session := entities.Session{
+		ID:          utils.GenerateID("S-", 20),
+		SubjectID:   subjectID,
+		SubjectType: getSubjectType(role),
+		Role:        role,
+		Name:        name,
+		Email:       email,
+		TokenHash:   utils.HashToken(refreshToken),
+		ExpiresAt:   now.Add(u.refreshTokenTTL),
+	}
+
+	expiresAt := now.Add(u.accessTokenTTL)
+	authToken := entities.AuthToken{
+		AccessToken:  middlewares.GenerateTokenJWT(subjectID, name, email, role, session.ID, expiresAt),
+		RefreshToken: refreshToken,
+		ExpiresAt:    expiresAt,
+	}
+
+	return session, authToken
+}
+
+func (u *SessionUseCase) Create(subjectID int, role string, name string, email string) (entities.AuthToken, error) {
+	session, authToken := u.newSession(subjectID, role, name, email)
+
+	err := u.repository.Create(&session)
+	if err != nil {
+		return entities.AuthToken{}, constants.ErrInternalServerError
+	}
+
+	return authToken, nil
+}
+
+// Refresh exchanges a refresh token for a new pair of tokens. Every refresh token can
+// only be used once; presenting one that was already rotated means it leaked, so all
+// sessions of the account are revoked. The new tokens carry the current role, name and
+// email of the account, and the sessions of deleted accounts are revoked.
+func (u *SessionUseCase) Refresh(refreshToken string) (entities.AuthToken, error) {
+	if refreshToken == "" {
+		return entities.AuthToken{}, constants.ErrAllFieldsMustBeFilled
+	}
+
+	session, err := u.repository.GetByTokenHash(utils.HashToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, constants.ErrInvalidRefreshToken) {
+			return entities.AuthToken{}, err
+		}
+		return entities.AuthToken{}, constants.ErrInternalServerError
+	}
+
+	if session.RevokedAt != nil {
+		if err := u.repository.RevokeAll(session.SubjectID, session.SubjectType); err != nil {
+			return entities.AuthToken{}, constants.ErrInternalServerError
+		}
+		return entities.AuthToken{}, constants.ErrInvalidRefreshToken
+	}
+
+	if session.ExpiresAt.Before(time.Now()) {
+		return entities.AuthToken{}, constants.ErrRefreshTokenExpired
+	}
+
+	subject, err := u.repository.GetSubject(session.SubjectID, session.SubjectType)
+	if err != nil {
+		if !errors.Is(err, constants.ErrInvalidRefreshToken) {
+			return entities.AuthToken{}, constants.ErrInternalServerError
+		}
+		if err := u.repository.RevokeAll(session.SubjectID, session.SubjectType); err != nil {
+			return entities.AuthToken{}, constants.ErrInternalServerError
+		}
+		return entities.AuthToken{}, err
+	}
+
+	newSession, authToken := u.newSession(session.SubjectID, subject.Role, subject.Name, subject.Email)
+
+	err = u.repository.Rotate(session.ID, &newSession)
+	if err != nil {
+		if errors.Is(err, constants.ErrInvalidRefreshToken) {
+			return entities.AuthToken{}, err
+		}
+		return entities.AuthToken{}, constants.ErrInternalServerError
+	}
+
+	return authToken, nil
+}
+
+func (u *SessionUseCase) Logout(sessionID string) error {
+	err := u.repository.Revoke(sessionID)
+	if err != nil {
+		return constants.ErrInternalServerError
+	}
+
+	return nil
+}
+
+func (u *SessionUseCase) RevokeAll(subjectID int, role string) error {
+	err := u.repository.RevokeAll(subjectID, getSubjectType(role))
+	if err != nil {
+		return constants.ErrInternalServerError
+	}
+
+	return nil
+}
+
+// EnsureActive returns an error when the session an access token was issued for has
+// been revoked or has expired.
+func (u *SessionUseCase) EnsureActive(sessionID string) error {
+	session, err := u.repository.GetByID(sessionID)
+	if err != nil {
+		if errors.Is(err, constants.ErrSessionRevoked) {
+			return err
+		}
+		return constants.ErrInternalServerError
+	}
+
+	if session.RevokedAt != nil || session.ExpiresAt.Before(time.Now()) {
+		return constants.ErrSessionRevoked
+	}
+
+	return nil
+}
@@ -0,0 +1,390 @@
+package session
+
+import (
+	"e-complaint-api/constants"
+	"e-complaint-api/entities"
+	"e-complaint-api/utils"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockSessionRepo struct {
+	mock.Mock
+}
+
+func (m *MockSessionRepo) Create(session *entities.Session) error {
+	args := m.Called(session)
+	return args.Error(0)
+}
+
+func (m *MockSessionRepo) GetByTokenHash(tokenHash string) (entities.Session, error) {
+	args := m.Called(tokenHash)
+	return args.Get(0).(entities.Session), args.Error(1)
+}
+
+func (m *MockSessionRepo) GetByID(id string) (entities.Session, error) {
+	args := m.Called(id)
+	return args.Get(0).(entities.Session), args.Error(1)
+}
+
+func (m *MockSessionRepo) Rotate(oldSessionID string, newSession *entities.Session) error {
+	args := m.Called(oldSessionID, newSession)
+	return args.Error(0)
+}
+
+func (m *MockSessionRepo) Revoke(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockSessionRepo) GetSubject(subjectID int, subjectType string) (entities.SessionSubject, error) {
+	args := m.Called(subjectID, subjectType)
+	return args.Get(0).(entities.SessionSubject), args.Error(1)
+}
+
+func (m *MockSessionRepo) RevokeAll(subjectID int, subjectType string) error {
+	args := m.Called(subjectID, subjectType)
+	return args.Error(0)
+}
+
+func TestCreate(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockRepo := new(MockSessionRepo)
+		usecase := NewSessionUseCase(mockRepo, 15*time.Minute, time.Hour)
+
+		mockRepo.On("Create", mock.MatchedBy(func(session *entities.Session) bool {
+			return session.SubjectID == 1 && session.SubjectType == "admin" && session.Role == "super_admin"
+		})).Return(nil)
+
+		result, err := usecase.Create(1, "super_admin", "admin", "admin@gmail.com")
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, result.AccessToken)
+		assert.NotEmpty(t, result.RefreshToken)
+		assert.WithinDuration(t, time.Now().Add(15*time.Minute), result.ExpiresAt, time.Minute)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("failed internal server error", func(t *testing.T) {
+		mockRepo := new(MockSessionRepo)
+		usecase := NewSessionUseCase(mockRepo, 15*time.Minute, time.Hour)
+
+		mockRepo.On("Create", mock.Anything).Return(errors.New("database error"))
+
+		result, err := usecase.Create(1, "user", "user", "user@gmail.com")
+
+		assert.Equal(t, constants.ErrInternalServerError, err)
+		assert.Equal(t, entities.AuthToken{}, result)
+	})
+}
+
+func TestRefresh(t *testing.T) {
+	refreshToken := "refresh-token"
+	tokenHash := utils.HashToken(refreshToken)
+
+	activeSession := entities.Session{
+		ID:          "S-1",
+		SubjectID:   1,
+		SubjectType: "user",
+		Role:        "user",
+		Name:        "user",
+		Email:       "user@gmail.com",
+		TokenHash:   tokenHash,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	}
+	subject := entities.SessionSubject{Role: "user", Name: "user", Email: "user@gmail.com"}
+
+	t.Run("success", func(t *testing.T) {
+		mockRepo := new(MockSessionRepo)
+		usecase := NewSessionUseCase(mockRepo, 15*time.Minute, time.Hour)
+
+		mockRepo.On("GetByTokenHash", tokenHash).Return(activeSession, nil)
+		mockRepo.On("GetSubject", 1, "user").Return(subject, nil)
+		mockRepo.On("Rotate", "S-1", mock.MatchedBy(func(session *entities.Session) bool {
+			return session.ID != "S-1" && session.SubjectID == 1 && session.TokenHash != tokenHash
+		})).Return(nil)
+
+		result, err := usecase.Refresh(refreshToken)
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, result.AccessToken)
+		assert.NotEqual(t, refreshToken, result.RefreshToken)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("failed empty refresh token", func(t *testing.T) {
+		mockRepo := new(MockSessionRepo)
+		usecase := NewSessionUseCase(mockRepo, 15*time.Minute, time.Hour)
+
+		result, err := usecase.Refresh("")
+
+		assert.Equal(t, constants.ErrAllFieldsMustBeFilled, err)
+		assert.Equal(t, entities.AuthToken{}, result)
+	})
+
+	t.Run("failed invalid refresh token", func(t *testing.T) {
+		mockRepo := new(MockSessionRepo)
+		usecase := NewSessionUseCase(mockRepo, 15*time.Minute, time.Hour)
+
+		mockRepo.On("GetByTokenHash", tokenHash).Return(entities.Session{}, constants.ErrInvalidRefreshToken)
+
+		_, err := usecase.Refresh(refreshToken)
+
+		assert.Equal(t, constants.ErrInvalidRefreshToken, err)
+	})
+
+	t.Run("failed get session", func(t *testing.T) {
+		mockRepo := new(MockSessionRepo)
+		usecase := NewSessionUseCase(mockRepo, 15*time.Minute, time.Hour)
+
+		mockRepo.On("GetByTokenHash", tokenHash).Return(entities.Session{}, errors.New("database error"))
+
+		_, err := usecase.Refresh(refreshToken)
+
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+
+	t.Run("failed reused refresh token revokes all sessions", func(t *testing.T) {
+		mockRepo := new(MockSessionRepo)
+		usecase := NewSessionUseCase(mockRepo, 15*time.Minute, time.Hour)
+
+		revokedAt := time.Now()
+		revokedSession := activeSession
+		revokedSession.RevokedAt = &revokedAt
+
+		mockRepo.On("GetByTokenHash", tokenHash).Return(revokedSession, nil)
+		mockRepo.On("RevokeAll", 1, "user").Return(nil)
+
+		_, err := usecase.Refresh(refreshToken)
+
+		assert.Equal(t, constants.ErrInvalidRefreshToken, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("failed revoke all sessions", func(t *testing.T) {
+		mockRepo := new(MockSessionRepo)
+		usecase := NewSessionUseCase(mockRepo, 15*time.Minute, time.Hour)
+
+		revokedAt := time.Now()
+		revokedSession := activeSession
+		revokedSession.RevokedAt = &revokedAt
+
+		mockRepo.On("GetByTokenHash", tokenHash).Return(revokedSession, nil)
+		mockRepo.On("RevokeAll", 1, "user").Return(errors.New("database error"))
+
+		_, err := usecase.Refresh(refreshToken)
+
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+
+	t.Run("failed refresh token expired", func(t *testing.T) {
+		mockRepo := new(MockSessionRepo)
+		usecase := NewSessionUseCase(mockRepo, 15*time.Minute, time.Hour)
+
+		expiredSession := activeSession
+		expiredSession.ExpiresAt = time.Now().Add(-time.Minute)
+
+		mockRepo.On("GetByTokenHash", tokenHash).Return(expiredSession, nil)
+
+		_, err := usecase.Refresh(refreshToken)
+
+		assert.Equal(t, constants.ErrRefreshTokenExpired, err)
+	})
+
+	t.Run("success current role", func(t *testing.T) {
+		mockRepo := new(MockSessionRepo)
+		usecase := NewSessionUseCase(mockRepo, 15*time.Minute, time.Hour)
+
+		adminSession := activeSession
+		adminSession.SubjectType = "admin"
+		adminSession.Role = "super_admin"
+
+		mockRepo.On("GetByTokenHash", tokenHash).Return(adminSession, nil)
+		mockRepo.On("GetSubject", 1, "admin").Return(entities.SessionSubject{Role: "admin", Name: "admin baru", Email: "admin@gmail.com"}, nil)
+		mockRepo.On("Rotate", "S-1", mock.MatchedBy(func(session *entities.Session) bool {
+			return session.Role == "admin" && session.Name == "admin baru" && session.SubjectType == "admin"
+		})).Return(nil)
+
+		_, err := usecase.Refresh(refreshToken)
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("failed deleted account revokes all sessions", func(t *testing.T) {
+		mockRepo := new(MockSessionRepo)
+		usecase := NewSessionUseCase(mockRepo, 15*time.Minute, time.Hour)
+
+		mockRepo.On("GetByTokenHash", tokenHash).Return(activeSession, nil)
+		mockRepo.On("GetSubject", 1, "user").Return(entities.SessionSubject{}, constants.ErrInvalidRefreshToken)
+		mockRepo.On("RevokeAll", 1, "user").Return(nil)
+
+		_, err := usecase.Refresh(refreshToken)
+
+		assert.Equal(t, constants.ErrInvalidRefreshToken, err)
+		mockRepo.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "Rotate", mock.Anything, mock.Anything)
+	})
+
+	t.Run("failed revoke sessions of deleted account", func(t *testing.T) {
+		mockRepo := new(MockSessionRepo)
+		usecase := NewSessionUseCase(mockRepo, 15*time.Minute, time.Hour)
+
+		mockRepo.On("GetByTokenHash", tokenHash).Return(activeSession, nil)
+		mockRepo.On("GetSubject", 1, "user").Return(entities.SessionSubject{}, constants.ErrInvalidRefreshToken)
+		mockRepo.On("RevokeAll", 1, "user").Return(errors.New("database error"))
+
+		_, err := usecase.Refresh(refreshToken)
+
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+
+	t.Run("failed get subject", func(t *testing.T) {
+		mockRepo := new(MockSessionRepo)
+		usecase := NewSessionUseCase(mockRepo, 15*time.Minute, time.Hour)
+
+		mockRepo.On("GetByTokenHash", tokenHash).Return(activeSession, nil)
+		mockRepo.On("GetSubject", 1, "user").Return(entities.SessionSubject{}, errors.New("database error"))
+
+		_, err := usecase.Refresh(refreshToken)
+
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+
+	t.Run("failed refresh token already rotated", func(t *testing.T) {
+		mockRepo := new(MockSessionRepo)
+		usecase := NewSessionUseCase(mockRepo, 15*time.Minute, time.Hour)
+
+		mockRepo.On("GetByTokenHash", tokenHash).Return(activeSession, nil)
+		mockRepo.On("GetSubject", 1, "user").Return(subject, nil)
+		mockRepo.On("Rotate", "S-1", mock.Anything).Return(constants.ErrInvalidRefreshToken)
+
+		_, err := usecase.Refresh(refreshToken)
+
+		assert.Equal(t, constants.ErrInvalidRefreshToken, err)
+	})
+
+	t.Run("failed rotate session", func(t *testing.T) {
+		mockRepo := new(MockSessionRepo)
+		usecase := NewSessionUseCase(mockRepo, 15*time.Minute, time.Hour)
+
+		mockRepo.On("GetByTokenHash", tokenHash).Return(activeSession, nil)
+		mockRepo.On("GetSubject", 1, "user").Return(subject, nil)
+		mockRepo.On("Rotate", "S-1", mock.Anything).Return(errors.New("database error"))
+
+		_, err := usecase.Refresh(refreshToken)
+
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+}
+
+func TestLogout(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockRepo := new(MockSessionRepo)
+		usecase := NewSessionUseCase(mockRepo, 15*time.Minute, time.Hour)
+
+		mockRepo.On("Revoke", "S-1").Return(nil)
+
+		err := usecase.Logout("S-1")
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("failed internal server error", func(t *testing.T) {
+		mockRepo := new(MockSessionRepo)
+		usecase := NewSessionUseCase(mockRepo, 15*time.Minute, time.Hour)
+
+		mockRepo.On("Revoke", "S-1").Return(errors.New("database error"))
+
+		err := usecase.Logout("S-1")
+
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+}
+
+func TestRevokeAll(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockRepo := new(MockSessionRepo)
+		usecase := NewSessionUseCase(mockRepo, 15*time.Minute, time.Hour)
+
+		mockRepo.On("RevokeAll", 1, "admin").Return(nil)
+
+		err := usecase.RevokeAll(1, "admin")
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("failed internal server error", func(t *testing.T) {
+		mockRepo := new(MockSessionRepo)
+		usecase := NewSessionUseCase(mockRepo, 15*time.Minute, time.Hour)
+
+		mockRepo.On("RevokeAll", 1, "user").Return(errors.New("database error"))
+
+		err := usecase.RevokeAll(1, "user")
+
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+}
+
+func TestEnsureActive(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockRepo := new(MockSessionRepo)
+		usecase := NewSessionUseCase(mockRepo, 15*time.Minute, time.Hour)
+
+		mockRepo.On("GetByID", "S-1").Return(entities.Session{ID: "S-1", ExpiresAt: time.Now().Add(time.Hour)}, nil)
+
+		err := usecase.EnsureActive("S-1")
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("failed session not found", func(t *testing.T) {
+		mockRepo := new(MockSessionRepo)
+		usecase := NewSessionUseCase(mockRepo, 15*time.Minute, time.Hour)
+
+		mockRepo.On("GetByID", "S-1").Return(entities.Session{}, constants.ErrSessionRevoked)
+
+		err := usecase.EnsureActive("S-1")
+
+		assert.Equal(t, constants.ErrSessionRevoked, err)
+	})
+
+	t.Run("failed internal server error", func(t *testing.T) {
+		mockRepo := new(MockSessionRepo)
+		usecase := NewSessionUseCase(mockRepo, 15*time.Minute, time.Hour)
+
+		mockRepo.On("GetByID", "S-1").Return(entities.Session{}, errors.New("database error"))
+
+		err := usecase.EnsureActive("S-1")
+
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+
+	t.Run("failed session revoked", func(t *testing.T) {
+		mockRepo := new(MockSessionRepo)
+		usecase := NewSessionUseCase(mockRepo, 15*time.Minute, time.Hour)
+
+		revokedAt := time.Now()
+		mockRepo.On("GetByID", "S-1").Return(entities.Session{ID: "S-1", ExpiresAt: time.Now().Add(time.Hour), RevokedAt: &revokedAt}, nil)
+
+		err := usecase.EnsureActive("S-1")
+
+		assert.Equal(t, constants.ErrSessionRevoked, err)
+	})
+
+	t.Run("failed session expired", func(t *testing.T) {
+		mockRepo := new(MockSessionRepo)
+		usecase := NewSessionUseCase(mockRepo, 15*time.Minute, time.Hour)
+
+		mockRepo.On("GetByID", "S-1").Return(entities.Session{ID: "S-1", ExpiresAt: time.Now().Add(-time.Minute)}, nil)
+
+		err := usecase.EnsureActive("S-1")
+
+		assert.Equal(t, constants.ErrSessionRevoked, err)
+	})
+}
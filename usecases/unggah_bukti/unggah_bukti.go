@@ -1,17 +1,38 @@
 package unggah_bukti
 
-import "e-complaint-api/entities"
+import (
+	"e-complaint-api/entities"
+	"e-complaint-api/upload"
+	"mime/multipart"
+)
 
 type unggahBuktiUseCase struct {
-	repo entities.UnggahBuktiRepositoryInterface
+	repo    entities.UnggahBuktiRepositoryInterface
+	storage entities.FileStorageInterface
 }
 
-func NewUnggahBuktiUseCase(repo entities.UnggahBuktiRepositoryInterface) entities.UnggahBuktiUseCaseInterface {
-	return &unggahBuktiUseCase{repo: repo}
+func NewUnggahBuktiUseCase(repo entities.UnggahBuktiRepositoryInterface, storage entities.FileStorageInterface) entities.UnggahBuktiUseCaseInterface {
+	return &unggahBuktiUseCase{repo: repo, storage: storage}
 }
 
-func (uc *unggahBuktiUseCase) Create(unggahBukti *entities.UnggahBukti) error {
-	return uc.repo.Create(unggahBukti)
+// Create menyimpan file bukti ke storage lalu mencatatnya di database
+func (uc *unggahBuktiUseCase) Create(file *multipart.FileHeader, unggahBukti *entities.UnggahBukti) error {
+	uploadFiles, err := upload.Evidence.Process([]*multipart.FileHeader{file})
+	if err != nil {
+		return err
+	}
+
+	paths, err := uc.storage.Upload(uploadFiles)
+	if err != nil {
+		return err
+	}
+	unggahBukti.Path = paths[0]
+
+	if err := uc.repo.Create(unggahBukti); err != nil {
+		uc.storage.Delete(paths)
+		return err
+	}
+	return nil
 }
 
 func (uc *unggahBuktiUseCase) GetAll() ([]entities.UnggahBukti, error) {
@@ -28,9 +49,20 @@ func (uc *unggahBuktiUseCase) GetByID(id int64) (*entities.UnggahBukti, error) {
 }
 
 func (uc *unggahBuktiUseCase) Update(id int64, unggahBukti *entities.UnggahBukti) error {
+	// Path hanya boleh diisi oleh storage, nilai kosong tidak ikut diperbarui
+	unggahBukti.Path = ""
 	return uc.repo.Update(id, unggahBukti)
 }
 
+// Delete menghapus file bukti dari storage beserta datanya
 func (uc *unggahBuktiUseCase) Delete(id int64) error {
+	unggahBukti, err := uc.repo.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.storage.Delete([]string{unggahBukti.Path}); err != nil {
+		return err
+	}
 	return uc.repo.Delete(id)
 }
@@ -3,7 +3,7 @@ package user
 import (
 	"e-complaint-api/constants"
 	"e-complaint-api/entities"
-	"e-complaint-api/middlewares"
+	"e-complaint-api/upload"
 	"e-complaint-api/utils"
 	"errors"
 	"mime/multipart"
@@ -11,16 +11,18 @@ import (
 )
 
 type UserUseCase struct {
-	repository   entities.UserRepositoryInterface
-	emailTrapApi entities.MailTrapAPIInterface
-	gcsAPI       entities.UserGCSAPIInterface
+	repository     entities.UserRepositoryInterface
+	emailTrapApi   entities.MailTrapAPIInterface
+	storage        entities.FileStorageInterface
+	sessionUseCase entities.SessionUseCaseInterface
 }
 
-func NewUserUseCase(repository entities.UserRepositoryInterface, emailTrapApi entities.MailTrapAPIInterface, gcsAPI entities.UserGCSAPIInterface) *UserUseCase {
+func NewUserUseCase(repository entities.UserRepositoryInterface, emailTrapApi entities.MailTrapAPIInterface, storage entities.FileStorageInterface, sessionUseCase entities.SessionUseCaseInterface) *UserUseCase {
 	return &UserUseCase{
-		repository:   repository,
-		emailTrapApi: emailTrapApi,
-		gcsAPI:       gcsAPI,
+		repository:     repository,
+		emailTrapApi:   emailTrapApi,
+		storage:        storage,
+		sessionUseCase: sessionUseCase,
 	}
 }
 
@@ -56,13 +58,18 @@ func (u *UserUseCase) Login(user *entities.User) (entities.User, error) {
 	}
 
 	err := u.repository.Login(user)
+	if err != nil {
+		return entities.User{}, err
+	}
 
-	(*user).Token = middlewares.GenerateTokenJWT(user.ID, user.Name, user.Email, "user")
-
+	authToken, err := u.sessionUseCase.Create(user.ID, "user", user.Name, user.Email)
 	if err != nil {
 		return entities.User{}, err
 	}
 
+	(*user).Token = authToken.AccessToken
+	(*user).RefreshToken = authToken.RefreshToken
+
 	return *user, nil
 }
 
@@ -117,7 +124,12 @@ func (u *UserUseCase) UpdateUser(id int, user *entities.User) (entities.User, er
 }
 
 func (u *UserUseCase) UpdateProfilePhoto(id int, profilePhoto *multipart.FileHeader) error {
-	filepaths, err := u.gcsAPI.Upload([]*multipart.FileHeader{profilePhoto})
+	uploadFiles, err := upload.ProfilePhoto.Process([]*multipart.FileHeader{profilePhoto})
+	if err != nil {
+		return err
+	}
+
+	filepaths, err := u.storage.Upload(uploadFiles)
 	if err != nil {
 		return err
 	}
@@ -144,7 +156,8 @@ func (u *UserUseCase) Delete(id int) error {
 		return constants.ErrInternalServerError
 	}
 
-	return nil
+	// The deleted user is signed out everywhere
+	return u.sessionUseCase.RevokeAll(id, "user")
 }
 
 func (u *UserUseCase) UpdatePassword(id int, newPassword string) error {
@@ -157,7 +170,13 @@ func (u *UserUseCase) UpdatePassword(id int, newPassword string) error {
 	}
 
 	hash, _ := utils.HashPassword(newPassword)
-	return u.repository.UpdatePassword(id, hash)
+	err := u.repository.UpdatePassword(id, hash)
+	if err != nil {
+		return err
+	}
+
+	// A new password signs the user out everywhere
+	return u.sessionUseCase.RevokeAll(id, "user")
 }
 
 func (u *UserUseCase) SendOTP(email, otp_type string) error {
@@ -215,5 +234,16 @@ func (u *UserUseCase) UpdatePasswordForgot(email, newPassword string) error {
 		return err
 	}
 
+	user, err := u.repository.GetUserByEmail(email)
+	if err != nil {
+		return err
+	}
+
+	// A new password signs the user out everywhere
+	err = u.sessionUseCase.RevokeAll(user.ID, "user")
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
@@ -1,9 +1,13 @@
 package user
 
 import (
+	"bytes"
 	"e-complaint-api/constants"
 	"e-complaint-api/entities"
 	"errors"
+	"image"
+	"image/jpeg"
+	"io"
 	"mime/multipart"
 	"testing"
 
@@ -75,6 +79,11 @@ func (m *MockUserRepository) UpdatePasswordForgot(email, newPassword string) err
 	return args.Error(0)
 }
 
+func (m *MockUserRepository) GetUserByEmail(email string) (*entities.User, error) {
+	args := m.Called(email)
+	return args.Get(0).(*entities.User), args.Error(1)
+}
+
 type MockMailTrapAPI struct {
 	mock.Mock
 }
@@ -88,7 +97,7 @@ type MockUserGCSAPI struct {
 	mock.Mock
 }
 
-func (m *MockUserGCSAPI) Upload(files []*multipart.FileHeader) ([]string, error) {
+func (m *MockUserGCSAPI) Upload(files []entities.UploadFile) ([]string, error) {
 	args := m.Called(files)
 	return args.Get(0).([]string), args.Error(1)
 }
@@ -98,12 +107,47 @@ func (m *MockUserGCSAPI) Delete(filePaths []string) error {
 	return args.Error(0)
 }
 
+func (m *MockUserGCSAPI) Open(filePath string) (io.ReadCloser, error) {
+	args := m.Called(filePath)
+	return args.Get(0).(io.ReadCloser), args.Error(1)
+}
+
+type MockSessionUseCase struct {
+	mock.Mock
+}
+
+func (m *MockSessionUseCase) Create(subjectID int, role string, name string, email string) (entities.AuthToken, error) {
+	args := m.Called(subjectID, role, name, email)
+	return args.Get(0).(entities.AuthToken), args.Error(1)
+}
+
+func (m *MockSessionUseCase) Refresh(refreshToken string) (entities.AuthToken, error) {
+	args := m.Called(refreshToken)
+	return args.Get(0).(entities.AuthToken), args.Error(1)
+}
+
+func (m *MockSessionUseCase) Logout(sessionID string) error {
+	args := m.Called(sessionID)
+	return args.Error(0)
+}
+
+func (m *MockSessionUseCase) RevokeAll(subjectID int, role string) error {
+	args := m.Called(subjectID, role)
+	return args.Error(0)
+}
+
+func (m *MockSessionUseCase) EnsureActive(sessionID string) error {
+	args := m.Called(sessionID)
+	return args.Error(0)
+}
+
 func TestRegister(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		mockUserRepository := new(MockUserRepository)
 		mockMailTrapAPI := new(MockMailTrapAPI)
 		mockUserGCSAPI := new(MockUserGCSAPI)
-		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI)
+		mockSessionUseCase := new(MockSessionUseCase)
+		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI, mockSessionUseCase)
 
 		user := entities.User{
 			Email:           "user@example.com",
@@ -125,7 +169,8 @@ func TestRegister(t *testing.T) {
 		mockUserRepository := new(MockUserRepository)
 		mockMailTrapAPI := new(MockMailTrapAPI)
 		mockUserGCSAPI := new(MockUserGCSAPI)
-		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI)
+		mockSessionUseCase := new(MockSessionUseCase)
+		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI, mockSessionUseCase)
 
 		user := entities.User{
 			Email:           "",
@@ -145,7 +190,8 @@ func TestRegister(t *testing.T) {
 		mockUserRepository := new(MockUserRepository)
 		mockMailTrapAPI := new(MockMailTrapAPI)
 		mockUserGCSAPI := new(MockUserGCSAPI)
-		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI)
+		mockSessionUseCase := new(MockSessionUseCase)
+		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI, mockSessionUseCase)
 
 		user := entities.User{
 			Email:           "user@gmail.com",
@@ -167,7 +213,8 @@ func TestRegister(t *testing.T) {
 		mockUserRepository := new(MockUserRepository)
 		mockMailTrapAPI := new(MockMailTrapAPI)
 		mockUserGCSAPI := new(MockUserGCSAPI)
-		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI)
+		mockSessionUseCase := new(MockSessionUseCase)
+		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI, mockSessionUseCase)
 
 		user := entities.User{
 			Email:           "user@gmail.com",
@@ -189,7 +236,8 @@ func TestRegister(t *testing.T) {
 		mockUserRepository := new(MockUserRepository)
 		mockMailTrapAPI := new(MockMailTrapAPI)
 		mockUserGCSAPI := new(MockUserGCSAPI)
-		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI)
+		mockSessionUseCase := new(MockSessionUseCase)
+		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI, mockSessionUseCase)
 
 		user := entities.User{
 			Email:           "user@gmail.com",
@@ -211,7 +259,8 @@ func TestRegister(t *testing.T) {
 		mockUserRepository := new(MockUserRepository)
 		mockMailTrapAPI := new(MockMailTrapAPI)
 		mockUserGCSAPI := new(MockUserGCSAPI)
-		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI)
+		mockSessionUseCase := new(MockSessionUseCase)
+		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI, mockSessionUseCase)
 
 		user := entities.User{
 			Email:           "user@gmail.com",
@@ -233,7 +282,8 @@ func TestLogin(t *testing.T) {
 		mockUserRepository := new(MockUserRepository)
 		mockMailTrapAPI := new(MockMailTrapAPI)
 		mockUserGCSAPI := new(MockUserGCSAPI)
-		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI)
+		mockSessionUseCase := new(MockSessionUseCase)
+		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI, mockSessionUseCase)
 
 		user := entities.User{
 			Email:    "user@gmail.com",
@@ -241,19 +291,46 @@ func TestLogin(t *testing.T) {
 		}
 
 		mockUserRepository.On("Login", &user).Return(nil)
+		mockSessionUseCase.On("Create", 0, "user", "", "user@gmail.com").Return(entities.AuthToken{AccessToken: "access", RefreshToken: "refresh"}, nil)
 
 		result, err := userUseCase.Login(&user)
 		assert.NoError(t, err)
 		assert.NotEmpty(t, result.Token)
+		assert.NotEmpty(t, result.RefreshToken)
 
 		mockUserRepository.AssertExpectations(t)
+		mockSessionUseCase.AssertExpectations(t)
+	})
+
+	t.Run("failed create session", func(t *testing.T) {
+		mockUserRepository := new(MockUserRepository)
+		mockMailTrapAPI := new(MockMailTrapAPI)
+		mockUserGCSAPI := new(MockUserGCSAPI)
+		mockSessionUseCase := new(MockSessionUseCase)
+		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI, mockSessionUseCase)
+
+		user := entities.User{
+			Email:    "user@gmail.com",
+			Password: "password",
+		}
+
+		mockUserRepository.On("Login", &user).Return(nil)
+		mockSessionUseCase.On("Create", 0, "user", "", "user@gmail.com").Return(entities.AuthToken{}, constants.ErrInternalServerError)
+
+		result, err := userUseCase.Login(&user)
+		assert.Equal(t, constants.ErrInternalServerError, err)
+		assert.Equal(t, entities.User{}, result)
+
+		mockUserRepository.AssertExpectations(t)
+		mockSessionUseCase.AssertExpectations(t)
 	})
 
 	t.Run("failed empty field", func(t *testing.T) {
 		mockUserRepository := new(MockUserRepository)
 		mockMailTrapAPI := new(MockMailTrapAPI)
 		mockUserGCSAPI := new(MockUserGCSAPI)
-		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI)
+		mockSessionUseCase := new(MockSessionUseCase)
+		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI, mockSessionUseCase)
 
 		user := entities.User{
 			Email:    "",
@@ -271,7 +348,8 @@ func TestLogin(t *testing.T) {
 		mockUserRepository := new(MockUserRepository)
 		mockMailTrapAPI := new(MockMailTrapAPI)
 		mockUserGCSAPI := new(MockUserGCSAPI)
-		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI)
+		mockSessionUseCase := new(MockSessionUseCase)
+		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI, mockSessionUseCase)
 
 		user := entities.User{
 			Email:    "user123@gmail.com",
@@ -291,7 +369,8 @@ func TestGetAllUsers(t *testing.T) {
 		mockUserRepository := new(MockUserRepository)
 		mockMailTrapAPI := new(MockMailTrapAPI)
 		mockUserGCSAPI := new(MockUserGCSAPI)
-		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI)
+		mockSessionUseCase := new(MockSessionUseCase)
+		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI, mockSessionUseCase)
 
 		users := []*entities.User{
 			{
@@ -323,7 +402,8 @@ func TestGetAllUsers(t *testing.T) {
 		mockUserRepository := new(MockUserRepository)
 		mockMailTrapAPI := new(MockMailTrapAPI)
 		mockUserGCSAPI := new(MockUserGCSAPI)
-		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI)
+		mockSessionUseCase := new(MockSessionUseCase)
+		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI, mockSessionUseCase)
 
 		mockUserRepository.On("GetAllUsers").Return(([]*entities.User)(nil), constants.ErrInternalServerError)
 
@@ -340,7 +420,8 @@ func TestGetUserByID(t *testing.T) {
 		mockUserRepository := new(MockUserRepository)
 		mockMailTrapAPI := new(MockMailTrapAPI)
 		mockUserGCSAPI := new(MockUserGCSAPI)
-		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI)
+		mockSessionUseCase := new(MockSessionUseCase)
+		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI, mockSessionUseCase)
 
 		user := entities.User{
 			ID:              1,
@@ -363,7 +444,8 @@ func TestGetUserByID(t *testing.T) {
 		mockUserRepository := new(MockUserRepository)
 		mockMailTrapAPI := new(MockMailTrapAPI)
 		mockUserGCSAPI := new(MockUserGCSAPI)
-		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI)
+		mockSessionUseCase := new(MockSessionUseCase)
+		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI, mockSessionUseCase)
 
 		mockUserRepository.On("GetUserByID", 1).Return((*entities.User)(nil), constants.ErrUserNotFound)
 
@@ -380,7 +462,8 @@ func TestUpdateUser(t *testing.T) {
 		mockUserRepository := new(MockUserRepository)
 		mockMailTrapAPI := new(MockMailTrapAPI)
 		mockUserGCSAPI := new(MockUserGCSAPI)
-		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI)
+		mockSessionUseCase := new(MockSessionUseCase)
+		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI, mockSessionUseCase)
 
 		user := entities.User{
 			ID:              1,
@@ -403,7 +486,8 @@ func TestUpdateUser(t *testing.T) {
 		mockUserRepository := new(MockUserRepository)
 		mockMailTrapAPI := new(MockMailTrapAPI)
 		mockUserGCSAPI := new(MockUserGCSAPI)
-		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI)
+		mockSessionUseCase := new(MockSessionUseCase)
+		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI, mockSessionUseCase)
 
 		user := entities.User{
 			ID:              1,
@@ -423,7 +507,8 @@ func TestUpdateUser(t *testing.T) {
 		mockUserRepository := new(MockUserRepository)
 		mockMailTrapAPI := new(MockMailTrapAPI)
 		mockUserGCSAPI := new(MockUserGCSAPI)
-		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI)
+		mockSessionUseCase := new(MockSessionUseCase)
+		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI, mockSessionUseCase)
 
 		user := entities.User{
 			ID:              1,
@@ -445,7 +530,8 @@ func TestUpdateUser(t *testing.T) {
 		mockUserRepository := new(MockUserRepository)
 		mockMailTrapAPI := new(MockMailTrapAPI)
 		mockUserGCSAPI := new(MockUserGCSAPI)
-		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI)
+		mockSessionUseCase := new(MockSessionUseCase)
+		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI, mockSessionUseCase)
 
 		user := entities.User{
 			ID:              1,
@@ -468,7 +554,8 @@ func TestUpdateUser(t *testing.T) {
 		mockUserRepository := new(MockUserRepository)
 		mockMailTrapAPI := new(MockMailTrapAPI)
 		mockUserGCSAPI := new(MockUserGCSAPI)
-		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI)
+		mockSessionUseCase := new(MockSessionUseCase)
+		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI, mockSessionUseCase)
 
 		user := entities.User{
 			ID:              1,
@@ -491,7 +578,8 @@ func TestUpdateUser(t *testing.T) {
 		mockUserRepository := new(MockUserRepository)
 		mockMailTrapAPI := new(MockMailTrapAPI)
 		mockUserGCSAPI := new(MockUserGCSAPI)
-		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI)
+		mockSessionUseCase := new(MockSessionUseCase)
+		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI, mockSessionUseCase)
 
 		user := entities.User{
 			ID:              1,
@@ -512,20 +600,37 @@ func TestUpdateUser(t *testing.T) {
 
 }
 
+func newFileHeader(t *testing.T, filename string, content []byte) *multipart.FileHeader {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, _ := writer.CreateFormFile("profile_photo", filename)
+	part.Write(content)
+	writer.Close()
+
+	form, err := multipart.NewReader(&body, writer.Boundary()).ReadForm(1024 * 1024)
+	assert.NoError(t, err)
+
+	return form.File["profile_photo"][0]
+}
+
+func newProfilePhotoFileHeader(t *testing.T) *multipart.FileHeader {
+	var content bytes.Buffer
+	jpeg.Encode(&content, image.NewRGBA(image.Rect(0, 0, 4, 4)), nil)
+
+	return newFileHeader(t, "profile_photo.jpg", content.Bytes())
+}
+
 func TestUpdateProfilePhoto(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		mockUserRepository := new(MockUserRepository)
 		mockMailTrapAPI := new(MockMailTrapAPI)
 		mockUserGCSAPI := new(MockUserGCSAPI)
-		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI)
+		mockSessionUseCase := new(MockSessionUseCase)
+		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI, mockSessionUseCase)
 
-		fileHeader := &multipart.FileHeader{
-			Filename: "profile_photo.jpg",
-		}
-
-		fileHeaders := []*multipart.FileHeader{fileHeader}
+		fileHeader := newProfilePhotoFileHeader(t)
 
-		mockUserGCSAPI.On("Upload", fileHeaders).Return([]string{"profile_photo.jpg"}, nil)
+		mockUserGCSAPI.On("Upload", mock.Anything).Return([]string{"profile_photo.jpg"}, nil)
 		mockUserRepository.On("UpdateProfilePhoto", 1, "profile_photo.jpg").Return(nil)
 
 		err := userUseCase.UpdateProfilePhoto(1, fileHeader)
@@ -538,15 +643,12 @@ func TestUpdateProfilePhoto(t *testing.T) {
 		mockUserRepository := new(MockUserRepository)
 		mockMailTrapAPI := new(MockMailTrapAPI)
 		mockUserGCSAPI := new(MockUserGCSAPI)
-		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI)
-
-		fileHeader := &multipart.FileHeader{
-			Filename: "profile_photo.jpg",
-		}
+		mockSessionUseCase := new(MockSessionUseCase)
+		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI, mockSessionUseCase)
 
-		fileHeaders := []*multipart.FileHeader{fileHeader}
+		fileHeader := newProfilePhotoFileHeader(t)
 
-		mockUserGCSAPI.On("Upload", fileHeaders).Return(([]string)(nil), constants.ErrInternalServerError)
+		mockUserGCSAPI.On("Upload", mock.Anything).Return(([]string)(nil), constants.ErrInternalServerError)
 
 		err := userUseCase.UpdateProfilePhoto(1, fileHeader)
 		assert.Error(t, constants.ErrInternalServerError, err)
@@ -558,15 +660,12 @@ func TestUpdateProfilePhoto(t *testing.T) {
 		mockUserRepository := new(MockUserRepository)
 		mockMailTrapAPI := new(MockMailTrapAPI)
 		mockUserGCSAPI := new(MockUserGCSAPI)
-		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI)
-
-		fileHeader := &multipart.FileHeader{
-			Filename: "profile_photo.jpg",
-		}
+		mockSessionUseCase := new(MockSessionUseCase)
+		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI, mockSessionUseCase)
 
-		fileHeaders := []*multipart.FileHeader{fileHeader}
+		fileHeader := newProfilePhotoFileHeader(t)
 
-		mockUserGCSAPI.On("Upload", fileHeaders).Return([]string{"profile_photo.jpg"}, nil)
+		mockUserGCSAPI.On("Upload", mock.Anything).Return([]string{"profile_photo.jpg"}, nil)
 		mockUserRepository.On("UpdateProfilePhoto", 1, "profile_photo.jpg").Return(constants.ErrInternalServerError)
 
 		err := userUseCase.UpdateProfilePhoto(1, fileHeader)
@@ -574,6 +673,21 @@ func TestUpdateProfilePhoto(t *testing.T) {
 
 		mockUserRepository.AssertExpectations(t)
 	})
+
+	t.Run("failed invalid file format", func(t *testing.T) {
+		mockUserRepository := new(MockUserRepository)
+		mockMailTrapAPI := new(MockMailTrapAPI)
+		mockUserGCSAPI := new(MockUserGCSAPI)
+		mockSessionUseCase := new(MockSessionUseCase)
+		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI, mockSessionUseCase)
+
+		fileHeader := newFileHeader(t, "profile_photo.jpg", []byte("GIF89a"))
+
+		err := userUseCase.UpdateProfilePhoto(1, fileHeader)
+		assert.Equal(t, constants.ErrInvalidFileFormat, err)
+
+		mockUserGCSAPI.AssertNotCalled(t, "Upload", mock.Anything)
+	})
 }
 
 func TestDelete(t *testing.T) {
@@ -581,22 +695,39 @@ func TestDelete(t *testing.T) {
 		mockUserRepository := new(MockUserRepository)
 		mockMailTrapAPI := new(MockMailTrapAPI)
 		mockUserGCSAPI := new(MockUserGCSAPI)
-		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI)
+		mockSessionUseCase := new(MockSessionUseCase)
+		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI, mockSessionUseCase)
 
 		mockUserRepository.On("GetUserByID", 1).Return(&entities.User{}, nil)
 		mockUserRepository.On("Delete", 1).Return(nil)
+		mockSessionUseCase.On("RevokeAll", 1, "user").Return(nil)
 
 		err := userUseCase.Delete(1)
 		assert.NoError(t, err)
 
 		mockUserRepository.AssertExpectations(t)
+		mockSessionUseCase.AssertExpectations(t)
+	})
+
+	t.Run("failed revoke sessions", func(t *testing.T) {
+		mockUserRepository := new(MockUserRepository)
+		mockSessionUseCase := new(MockSessionUseCase)
+		userUseCase := NewUserUseCase(mockUserRepository, new(MockMailTrapAPI), new(MockUserGCSAPI), mockSessionUseCase)
+
+		mockUserRepository.On("GetUserByID", 1).Return(&entities.User{}, nil)
+		mockUserRepository.On("Delete", 1).Return(nil)
+		mockSessionUseCase.On("RevokeAll", 1, "user").Return(constants.ErrInternalServerError)
+
+		err := userUseCase.Delete(1)
+		assert.Equal(t, constants.ErrInternalServerError, err)
 	})
 
 	t.Run("failed internal server error", func(t *testing.T) {
 		mockUserRepository := new(MockUserRepository)
 		mockMailTrapAPI := new(MockMailTrapAPI)
 		mockUserGCSAPI := new(MockUserGCSAPI)
-		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI)
+		mockSessionUseCase := new(MockSessionUseCase)
+		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI, mockSessionUseCase)
 
 		mockUserRepository.On("GetUserByID", 1).Return(&entities.User{}, nil)
 		mockUserRepository.On("Delete", 1).Return(constants.ErrInternalServerError)
@@ -611,7 +742,8 @@ func TestDelete(t *testing.T) {
 		mockUserRepository := new(MockUserRepository)
 		mockMailTrapAPI := new(MockMailTrapAPI)
 		mockUserGCSAPI := new(MockUserGCSAPI)
-		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI)
+		mockSessionUseCase := new(MockSessionUseCase)
+		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI, mockSessionUseCase)
 
 		mockUserRepository.On("GetUserByID", 1).Return((*entities.User)(nil), constants.ErrInternalServerError)
 
@@ -625,7 +757,8 @@ func TestDelete(t *testing.T) {
 		mockUserRepository := new(MockUserRepository)
 		mockMailTrapAPI := new(MockMailTrapAPI)
 		mockUserGCSAPI := new(MockUserGCSAPI)
-		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI)
+		mockSessionUseCase := new(MockSessionUseCase)
+		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI, mockSessionUseCase)
 
 		mockUserRepository.On("GetUserByID", 1).Return((*entities.User)(nil), constants.ErrUserNotFound)
 
@@ -642,21 +775,41 @@ func TestUpdatePassword(t *testing.T) {
 		mockUserRepository := new(MockUserRepository)
 		mockMailTrapAPI := new(MockMailTrapAPI)
 		mockUserGCSAPI := new(MockUserGCSAPI)
-		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI)
+		mockSessionUseCase := new(MockSessionUseCase)
+		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI, mockSessionUseCase)
 
 		mockUserRepository.On("UpdatePassword", 1, mock.Anything).Return(nil)
+		mockSessionUseCase.On("RevokeAll", 1, "user").Return(nil)
 
 		err := userUseCase.UpdatePassword(1, "password")
 		assert.NoError(t, err)
 
 		mockUserRepository.AssertExpectations(t)
+		mockSessionUseCase.AssertExpectations(t)
+	})
+
+	t.Run("failed internal server error", func(t *testing.T) {
+		mockUserRepository := new(MockUserRepository)
+		mockMailTrapAPI := new(MockMailTrapAPI)
+		mockUserGCSAPI := new(MockUserGCSAPI)
+		mockSessionUseCase := new(MockSessionUseCase)
+		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI, mockSessionUseCase)
+
+		mockUserRepository.On("UpdatePassword", 1, mock.Anything).Return(constants.ErrInternalServerError)
+
+		err := userUseCase.UpdatePassword(1, "password")
+		assert.Equal(t, constants.ErrInternalServerError, err)
+
+		mockUserRepository.AssertExpectations(t)
+		mockSessionUseCase.AssertExpectations(t)
 	})
 
 	t.Run("failed empty field", func(t *testing.T) {
 		mockUserRepository := new(MockUserRepository)
 		mockMailTrapAPI := new(MockMailTrapAPI)
 		mockUserGCSAPI := new(MockUserGCSAPI)
-		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI)
+		mockSessionUseCase := new(MockSessionUseCase)
+		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI, mockSessionUseCase)
 
 		err := userUseCase.UpdatePassword(1, "")
 		assert.Error(t, constants.ErrAllFieldsMustBeFilled, err)
@@ -668,7 +821,8 @@ func TestUpdatePassword(t *testing.T) {
 		mockUserRepository := new(MockUserRepository)
 		mockMailTrapAPI := new(MockMailTrapAPI)
 		mockUserGCSAPI := new(MockUserGCSAPI)
-		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI)
+		mockSessionUseCase := new(MockSessionUseCase)
+		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI, mockSessionUseCase)
 
 		err := userUseCase.UpdatePassword(1, "pass")
 		assert.Error(t, constants.ErrPasswordMustBeAtLeast8Characters, err)
@@ -682,7 +836,8 @@ func TestSendOTP(t *testing.T) {
 		mockUserRepository := new(MockUserRepository)
 		mockMailTrapAPI := new(MockMailTrapAPI)
 		mockUserGCSAPI := new(MockUserGCSAPI)
-		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI)
+		mockSessionUseCase := new(MockSessionUseCase)
+		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI, mockSessionUseCase)
 
 		mockUserRepository.On("SendOTP", "user@gmail.com", mock.Anything).Return(nil)
 		mockMailTrapAPI.On("SendOTP", "user@gmail.com", mock.Anything, "register").Return(nil)
@@ -697,7 +852,8 @@ func TestSendOTP(t *testing.T) {
 		mockUserRepository := new(MockUserRepository)
 		mockMailTrapAPI := new(MockMailTrapAPI)
 		mockUserGCSAPI := new(MockUserGCSAPI)
-		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI)
+		mockSessionUseCase := new(MockSessionUseCase)
+		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI, mockSessionUseCase)
 
 		mockUserRepository.On("SendOTP", "user@gmail.com", mock.Anything).Return(nil)
 		mockMailTrapAPI.On("SendOTP", "user@gmail.com", mock.Anything, "forgot_password").Return(nil)
@@ -712,7 +868,8 @@ func TestSendOTP(t *testing.T) {
 		mockUserRepository := new(MockUserRepository)
 		mockMailTrapAPI := new(MockMailTrapAPI)
 		mockUserGCSAPI := new(MockUserGCSAPI)
-		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI)
+		mockSessionUseCase := new(MockSessionUseCase)
+		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI, mockSessionUseCase)
 
 		err := userUseCase.SendOTP("", "register")
 		assert.Error(t, constants.ErrAllFieldsMustBeFilled, err)
@@ -724,7 +881,8 @@ func TestSendOTP(t *testing.T) {
 		mockUserRepository := new(MockUserRepository)
 		mockMailTrapAPI := new(MockMailTrapAPI)
 		mockUserGCSAPI := new(MockUserGCSAPI)
-		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI)
+		mockSessionUseCase := new(MockSessionUseCase)
+		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI, mockSessionUseCase)
 
 		mockUserRepository.On("SendOTP", "user@gmail.com", mock.Anything).Return(constants.ErrInternalServerError)
 
@@ -738,7 +896,8 @@ func TestSendOTP(t *testing.T) {
 		mockUserRepository := new(MockUserRepository)
 		mockMailTrapAPI := new(MockMailTrapAPI)
 		mockUserGCSAPI := new(MockUserGCSAPI)
-		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI)
+		mockSessionUseCase := new(MockSessionUseCase)
+		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI, mockSessionUseCase)
 
 		mockUserRepository.On("SendOTP", "user@gmail.com", mock.Anything).Return(constants.ErrEmailNotRegistered)
 
@@ -752,7 +911,8 @@ func TestSendOTP(t *testing.T) {
 		mockUserRepository := new(MockUserRepository)
 		mockMailTrapAPI := new(MockMailTrapAPI)
 		mockUserGCSAPI := new(MockUserGCSAPI)
-		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI)
+		mockSessionUseCase := new(MockSessionUseCase)
+		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI, mockSessionUseCase)
 
 		mockUserRepository.On("SendOTP", "user@gmail.com", mock.Anything).Return(nil)
 		mockMailTrapAPI.On("SendOTP", "user@gmail.com", mock.Anything, "register").Return(errors.New("Mailtrap API error"))
@@ -769,7 +929,8 @@ func TestVerifyOTP(t *testing.T) {
 		mockUserRepository := new(MockUserRepository)
 		mockMailTrapAPI := new(MockMailTrapAPI)
 		mockUserGCSAPI := new(MockUserGCSAPI)
-		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI)
+		mockSessionUseCase := new(MockSessionUseCase)
+		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI, mockSessionUseCase)
 
 		mockUserRepository.On("VerifyOTPRegister", "user@gmail.com", "12345").Return(nil)
 
@@ -783,7 +944,8 @@ func TestVerifyOTP(t *testing.T) {
 		mockUserRepository := new(MockUserRepository)
 		mockMailTrapAPI := new(MockMailTrapAPI)
 		mockUserGCSAPI := new(MockUserGCSAPI)
-		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI)
+		mockSessionUseCase := new(MockSessionUseCase)
+		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI, mockSessionUseCase)
 		mockUserRepository.On("VerifyOTPForgotPassword", "user@gmail.com", "12345").Return(nil)
 
 		err := userUseCase.VerifyOTP("user@gmail.com", "12345", "forgot_password")
@@ -796,7 +958,8 @@ func TestVerifyOTP(t *testing.T) {
 		mockUserRepository := new(MockUserRepository)
 		mockMailTrapAPI := new(MockMailTrapAPI)
 		mockUserGCSAPI := new(MockUserGCSAPI)
-		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI)
+		mockSessionUseCase := new(MockSessionUseCase)
+		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI, mockSessionUseCase)
 
 		err := userUseCase.VerifyOTP("", "12345", "register")
 		assert.Error(t, constants.ErrAllFieldsMustBeFilled, err)
@@ -808,7 +971,8 @@ func TestVerifyOTP(t *testing.T) {
 		mockUserRepository := new(MockUserRepository)
 		mockMailTrapAPI := new(MockMailTrapAPI)
 		mockUserGCSAPI := new(MockUserGCSAPI)
-		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI)
+		mockSessionUseCase := new(MockSessionUseCase)
+		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI, mockSessionUseCase)
 
 		mockUserRepository.On("VerifyOTPRegister", "user@gmail.com", "12345").Return(constants.ErrEmailNotRegistered)
 
@@ -822,7 +986,8 @@ func TestVerifyOTP(t *testing.T) {
 		mockUserRepository := new(MockUserRepository)
 		mockMailTrapAPI := new(MockMailTrapAPI)
 		mockUserGCSAPI := new(MockUserGCSAPI)
-		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI)
+		mockSessionUseCase := new(MockSessionUseCase)
+		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI, mockSessionUseCase)
 
 		mockUserRepository.On("VerifyOTPForgotPassword", "user@gmail.com", "12345").Return(constants.ErrEmailNotRegistered)
 
@@ -836,7 +1001,8 @@ func TestVerifyOTP(t *testing.T) {
 		mockUserRepository := new(MockUserRepository)
 		mockMailTrapAPI := new(MockMailTrapAPI)
 		mockUserGCSAPI := new(MockUserGCSAPI)
-		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI)
+		mockSessionUseCase := new(MockSessionUseCase)
+		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI, mockSessionUseCase)
 
 		mockUserRepository.On("VerifyOTPRegister", "user@gmail.com", "12345").Return(constants.ErrInvalidOTP)
 
@@ -850,7 +1016,8 @@ func TestVerifyOTP(t *testing.T) {
 		mockUserRepository := new(MockUserRepository)
 		mockMailTrapAPI := new(MockMailTrapAPI)
 		mockUserGCSAPI := new(MockUserGCSAPI)
-		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI)
+		mockSessionUseCase := new(MockSessionUseCase)
+		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI, mockSessionUseCase)
 
 		mockUserRepository.On("VerifyOTPForgotPassword", "user@gmail.com", "12345").Return(constants.ErrInvalidOTP)
 
@@ -864,7 +1031,8 @@ func TestVerifyOTP(t *testing.T) {
 		mockUserRepository := new(MockUserRepository)
 		mockMailTrapAPI := new(MockMailTrapAPI)
 		mockUserGCSAPI := new(MockUserGCSAPI)
-		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI)
+		mockSessionUseCase := new(MockSessionUseCase)
+		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI, mockSessionUseCase)
 
 		mockUserRepository.On("VerifyOTPRegister", "user@gmail.com", "12345").Return(constants.ErrInternalServerError)
 
@@ -878,7 +1046,8 @@ func TestVerifyOTP(t *testing.T) {
 		mockUserRepository := new(MockUserRepository)
 		mockMailTrapAPI := new(MockMailTrapAPI)
 		mockUserGCSAPI := new(MockUserGCSAPI)
-		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI)
+		mockSessionUseCase := new(MockSessionUseCase)
+		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI, mockSessionUseCase)
 		mockUserRepository.On("VerifyOTPForgotPassword", "user@gmail.com", "12345").Return(constants.ErrInternalServerError)
 
 		err := userUseCase.VerifyOTP("user@gmail.com", "12345", "forgot_password")
@@ -894,21 +1063,60 @@ func TestUpdatePasswordForgot(t *testing.T) {
 		mockUserRepository := new(MockUserRepository)
 		mockMailTrapAPI := new(MockMailTrapAPI)
 		mockUserGCSAPI := new(MockUserGCSAPI)
-		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI)
+		mockSessionUseCase := new(MockSessionUseCase)
+		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI, mockSessionUseCase)
 
 		mockUserRepository.On("UpdatePasswordForgot", "user@gmail.com", mock.Anything).Return(nil)
+		mockUserRepository.On("GetUserByEmail", "user@gmail.com").Return(&entities.User{ID: 1}, nil)
+		mockSessionUseCase.On("RevokeAll", 1, "user").Return(nil)
 
 		err := userUseCase.UpdatePasswordForgot("user@gmail.com", "password")
 		assert.NoError(t, err)
 
 		mockUserRepository.AssertExpectations(t)
+		mockSessionUseCase.AssertExpectations(t)
+	})
+
+	t.Run("failed get user by email", func(t *testing.T) {
+		mockUserRepository := new(MockUserRepository)
+		mockMailTrapAPI := new(MockMailTrapAPI)
+		mockUserGCSAPI := new(MockUserGCSAPI)
+		mockSessionUseCase := new(MockSessionUseCase)
+		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI, mockSessionUseCase)
+
+		mockUserRepository.On("UpdatePasswordForgot", "user@gmail.com", mock.Anything).Return(nil)
+		mockUserRepository.On("GetUserByEmail", "user@gmail.com").Return((*entities.User)(nil), constants.ErrInternalServerError)
+
+		err := userUseCase.UpdatePasswordForgot("user@gmail.com", "password")
+		assert.Equal(t, constants.ErrInternalServerError, err)
+
+		mockUserRepository.AssertExpectations(t)
+	})
+
+	t.Run("failed revoke sessions", func(t *testing.T) {
+		mockUserRepository := new(MockUserRepository)
+		mockMailTrapAPI := new(MockMailTrapAPI)
+		mockUserGCSAPI := new(MockUserGCSAPI)
+		mockSessionUseCase := new(MockSessionUseCase)
+		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI, mockSessionUseCase)
+
+		mockUserRepository.On("UpdatePasswordForgot", "user@gmail.com", mock.Anything).Return(nil)
+		mockUserRepository.On("GetUserByEmail", "user@gmail.com").Return(&entities.User{ID: 1}, nil)
+		mockSessionUseCase.On("RevokeAll", 1, "user").Return(constants.ErrInternalServerError)
+
+		err := userUseCase.UpdatePasswordForgot("user@gmail.com", "password")
+		assert.Equal(t, constants.ErrInternalServerError, err)
+
+		mockUserRepository.AssertExpectations(t)
+		mockSessionUseCase.AssertExpectations(t)
 	})
 
 	t.Run("failed empty field", func(t *testing.T) {
 		mockUserRepository := new(MockUserRepository)
 		mockMailTrapAPI := new(MockMailTrapAPI)
 		mockUserGCSAPI := new(MockUserGCSAPI)
-		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI)
+		mockSessionUseCase := new(MockSessionUseCase)
+		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI, mockSessionUseCase)
 
 		err := userUseCase.UpdatePasswordForgot("", "password")
 		assert.Error(t, constants.ErrAllFieldsMustBeFilled, err)
@@ -920,7 +1128,8 @@ func TestUpdatePasswordForgot(t *testing.T) {
 		mockUserRepository := new(MockUserRepository)
 		mockMailTrapAPI := new(MockMailTrapAPI)
 		mockUserGCSAPI := new(MockUserGCSAPI)
-		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI)
+		mockSessionUseCase := new(MockSessionUseCase)
+		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI, mockSessionUseCase)
 
 		mockUserRepository.On("UpdatePasswordForgot", "user@gmail.com", mock.Anything).Return(constants.ErrUserNotFound)
 
@@ -934,7 +1143,8 @@ func TestUpdatePasswordForgot(t *testing.T) {
 		mockUserRepository := new(MockUserRepository)
 		mockMailTrapAPI := new(MockMailTrapAPI)
 		mockUserGCSAPI := new(MockUserGCSAPI)
-		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI)
+		mockSessionUseCase := new(MockSessionUseCase)
+		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI, mockSessionUseCase)
 
 		mockUserRepository.On("UpdatePasswordForgot", "user@gmail.com", mock.Anything).Return(constants.ErrInternalServerError)
 
@@ -948,7 +1158,8 @@ func TestUpdatePasswordForgot(t *testing.T) {
 		mockUserRepository := new(MockUserRepository)
 		mockMailTrapAPI := new(MockMailTrapAPI)
 		mockUserGCSAPI := new(MockUserGCSAPI)
-		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI)
+		mockSessionUseCase := new(MockSessionUseCase)
+		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI, mockSessionUseCase)
 
 		mockUserRepository.On("UpdatePasswordForgot", "user@gmail.com", mock.Anything).Return(constants.ErrForgotPasswordOTPNotVerified)
 
@@ -962,7 +1173,8 @@ func TestUpdatePasswordForgot(t *testing.T) {
 		mockUserRepository := new(MockUserRepository)
 		mockMailTrapAPI := new(MockMailTrapAPI)
 		mockUserGCSAPI := new(MockUserGCSAPI)
-		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI)
+		mockSessionUseCase := new(MockSessionUseCase)
+		userUseCase := NewUserUseCase(mockUserRepository, mockMailTrapAPI, mockUserGCSAPI, mockSessionUseCase)
 
 		err := userUseCase.UpdatePasswordForgot("user@gmail.com", "pass")
 		assert.Error(t, constants.ErrPasswordMustBeAtLeast8Characters, err)
@@ -0,0 +1,340 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"e-complaint-api/constants"
+	"e-complaint-api/cursor"
+	"e-complaint-api/entities"
+	"e-complaint-api/utils"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type WebhookUseCase struct {
+	repository entities.WebhookRepositoryInterface
+	sender     entities.WebhookSenderInterface
+	jobUseCase entities.JobUseCaseInterface
+}
+
+// deliveryPayload is the payload of the delivery jobs.
+type deliveryPayload struct {
+	WebhookID  int
+	DeliveryID int
+}
+
+// envelope is the body posted to webhooks. The data of an event is stored as JSON
+// when the event happens, so every attempt and replay sends the same data.
+type envelope struct {
+	ID        int             `json:"id"`
+	Event     string          `json:"event"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// NewWebhookUseCase registers the handler of the delivery jobs, which post the
+// deliveries with sender. Failed deliveries are retried with the backoff of the job
+// queue.
+func NewWebhookUseCase(repository entities.WebhookRepositoryInterface, sender entities.WebhookSenderInterface, jobUseCase entities.JobUseCaseInterface) *WebhookUseCase {
+	useCase := &WebhookUseCase{
+		repository: repository,
+		sender:     sender,
+		jobUseCase: jobUseCase,
+	}
+	jobUseCase.Register(constants.JobTypeWebhookDelivery, useCase.deliver)
+
+	return useCase
+}
+
+// Create stores a webhook with a new secret, which is only shown to the admin in the
+// response of its creation.
+func (u *WebhookUseCase) Create(rawURL string, events []string, createdBy int) (entities.Webhook, error) {
+	joinedEvents, err := validate(rawURL, events)
+	if err != nil {
+		return entities.Webhook{}, err
+	}
+
+	webhook := entities.Webhook{
+		URL:       rawURL,
+		Secret:    utils.GenerateToken(32),
+		Events:    joinedEvents,
+		Active:    true,
+		CreatedBy: createdBy,
+	}
+	if err := u.repository.Create(&webhook); err != nil {
+		return entities.Webhook{}, constants.ErrInternalServerError
+	}
+
+	return webhook, nil
+}
+
+// validate checks the URL and events of a webhook and returns the events joined the
+// way they are stored.
+func validate(rawURL string, events []string) (string, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" || len(rawURL) > 255 {
+		return "", constants.ErrInvalidWebhookURL
+	}
+
+	// Host names are checked again by the sender once they are resolved
+	host := strings.ToLower(parsed.Hostname())
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return "", constants.ErrWebhookAddressNotAllowed
+	}
+	if ip := net.ParseIP(host); ip != nil && !utils.IsPublicIP(ip) {
+		return "", constants.ErrWebhookAddressNotAllowed
+	}
+
+	if len(events) == 0 {
+		return "", constants.ErrWebhookEventsRequired
+	}
+
+	subscribed := []string{}
+	for _, event := range events {
+		if !slices.Contains(constants.WebhookEvents, event) {
+			return "", constants.ErrInvalidWebhookEvent
+		}
+		if !slices.Contains(subscribed, event) {
+			subscribed = append(subscribed, event)
+		}
+	}
+
+	return strings.Join(subscribed, ","), nil
+}
+
+func (u *WebhookUseCase) GetAll() ([]entities.Webhook, error) {
+	webhooks, err := u.repository.GetAll()
+	if err != nil {
+		return nil, constants.ErrInternalServerError
+	}
+
+	return webhooks, nil
+}
+
+func (u *WebhookUseCase) GetByID(id int) (entities.Webhook, error) {
+	webhook, err := u.repository.GetByID(id)
+	if err != nil {
+		if errors.Is(err, constants.ErrWebhookNotFound) {
+			return entities.Webhook{}, err
+		}
+		return entities.Webhook{}, constants.ErrInternalServerError
+	}
+
+	return webhook, nil
+}
+
+// Update changes the URL and events of a webhook, and pauses or resumes it. The
+// deliveries of a paused webhook that are still queued fail.
+func (u *WebhookUseCase) Update(id int, rawURL string, events []string, active bool) (entities.Webhook, error) {
+	joinedEvents, err := validate(rawURL, events)
+	if err != nil {
+		return entities.Webhook{}, err
+	}
+
+	webhook, err := u.GetByID(id)
+	if err != nil {
+		return entities.Webhook{}, err
+	}
+
+	webhook.URL = rawURL
+	webhook.Events = joinedEvents
+	webhook.Active = active
+	if err := u.repository.Update(&webhook); err != nil {
+		return entities.Webhook{}, constants.ErrInternalServerError
+	}
+
+	return webhook, nil
+}
+
+// Delete removes a webhook along with its delivery log.
+func (u *WebhookUseCase) Delete(id int) error {
+	if err := u.repository.Delete(id); err != nil {
+		if errors.Is(err, constants.ErrWebhookNotFound) {
+			return err
+		}
+		return constants.ErrInternalServerError
+	}
+
+	return nil
+}
+
+func (u *WebhookUseCase) Dispatch(event string, data interface{}) error {
+	webhooks, err := u.repository.GetByEvent(event)
+	if err != nil {
+		return constants.ErrInternalServerError
+	}
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return constants.ErrInternalServerError
+	}
+
+	for _, webhook := range webhooks {
+		delivery := entities.WebhookDelivery{
+			WebhookID: webhook.ID,
+			Event:     event,
+			Payload:   string(payload),
+			Status:    constants.WebhookDeliveryPending,
+		}
+		if err := u.queue(&delivery); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// queue stores a delivery and enqueues the job that sends it.
+func (u *WebhookUseCase) queue(delivery *entities.WebhookDelivery) error {
+	if err := u.repository.CreateDelivery(delivery); err != nil {
+		return constants.ErrInternalServerError
+	}
+
+	_, err := u.jobUseCase.Enqueue(constants.JobTypeWebhookDelivery, nil, deliveryPayload{
+		WebhookID:  delivery.WebhookID,
+		DeliveryID: delivery.ID,
+	})
+
+	return err
+}
+
+// deliver is the handler of the delivery jobs. The body is signed with the secret of
+// the webhook, see sign. Responses other than 2xx fail the attempt, so the job is
+// retried, and the delivery fails along with its last attempt.
+func (u *WebhookUseCase) deliver(data []byte) (string, error) {
+	var payload deliveryPayload
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return "", err
+	}
+
+	delivery, err := u.repository.GetDeliveryByID(payload.WebhookID, payload.DeliveryID)
+	if err != nil {
+		return "", err
+	}
+
+	if !delivery.Webhook.Active {
+		delivery.Status = constants.WebhookDeliveryFailed
+		delivery.LastError = "webhook is inactive"
+		if err := u.repository.UpdateDelivery(&delivery); err != nil {
+			return "", err
+		}
+		return "webhook is inactive", nil
+	}
+
+	body, err := json.Marshal(envelope{
+		ID:        delivery.ID,
+		Event:     delivery.Event,
+		CreatedAt: delivery.CreatedAt,
+		Data:      json.RawMessage(delivery.Payload),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	response, sendErr := u.sender.Send(delivery.Webhook.URL, map[string]string{
+		"Content-Type":        "application/json",
+		"X-Webhook-Event":     delivery.Event,
+		"X-Webhook-Delivery":  strconv.Itoa(delivery.ID),
+		"X-Webhook-Timestamp": timestamp,
+		"X-Webhook-Signature": "sha256=" + sign(delivery.Webhook.Secret, timestamp, body),
+	}, body)
+	if sendErr == nil && (response.StatusCode < 200 || response.StatusCode > 299) {
+		sendErr = fmt.Errorf("webhook responded with status %d", response.StatusCode)
+	}
+
+	delivery.Attempts++
+	delivery.ResponseStatus = response.StatusCode
+	delivery.ResponseBody = response.Body
+	if sendErr == nil {
+		now := time.Now()
+		delivery.Status = constants.WebhookDeliverySuccess
+		delivery.LastError = ""
+		delivery.DeliveredAt = &now
+	} else {
+		delivery.LastError = sendErr.Error()
+		if delivery.Attempts >= constants.JobMaxAttempts {
+			delivery.Status = constants.WebhookDeliveryFailed
+		}
+	}
+
+	if err := u.repository.UpdateDelivery(&delivery); err != nil {
+		return "", err
+	}
+	if sendErr != nil {
+		return "", sendErr
+	}
+
+	return fmt.Sprintf("delivered with status %d", response.StatusCode), nil
+}
+
+// sign returns the hex HMAC-SHA256 of the timestamp and body of a delivery, joined by
+// a dot. Receivers compute the same with the secret of the webhook, and reject old
+// timestamps to stop deliveries from being replayed by others.
+func sign(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// GetDeliveries returns the page of the delivery log of a webhook after the delivery
+// the cursor points at, newest first, and the cursor of the next page.
+func (u *WebhookUseCase) GetDeliveries(webhookID int, limit int, token string) ([]entities.WebhookDelivery, string, error) {
+	if _, err := u.GetByID(webhookID); err != nil {
+		return nil, "", err
+	}
+
+	afterID, err := cursor.DecodeInt(token, "id DESC")
+	if err != nil {
+		return nil, "", err
+	}
+
+	limit = cursor.Limit(limit)
+	deliveries, err := u.repository.GetDeliveriesAfter(webhookID, limit+1, afterID)
+	if err != nil {
+		return nil, "", constants.ErrInternalServerError
+	}
+
+	deliveries, next := cursor.Page(deliveries, limit, "id DESC", func(delivery entities.WebhookDelivery) string {
+		return strconv.Itoa(delivery.ID)
+	})
+
+	return deliveries, next, nil
+}
+
+// Replay queues a new delivery with the event and data of an earlier one, whatever
+// became of it.
+func (u *WebhookUseCase) Replay(webhookID int, deliveryID int) (entities.WebhookDelivery, error) {
+	original, err := u.repository.GetDeliveryByID(webhookID, deliveryID)
+	if err != nil {
+		if errors.Is(err, constants.ErrWebhookDeliveryNotFound) {
+			return entities.WebhookDelivery{}, err
+		}
+		return entities.WebhookDelivery{}, constants.ErrInternalServerError
+	}
+
+	delivery := entities.WebhookDelivery{
+		WebhookID: original.WebhookID,
+		Event:     original.Event,
+		Payload:   original.Payload,
+		Status:    constants.WebhookDeliveryPending,
+		ReplayOf:  &original.ID,
+	}
+	if err := u.queue(&delivery); err != nil {
+		return entities.WebhookDelivery{}, err
+	}
+
+	return delivery, nil
+}
@@ -0,0 +1,623 @@
+package webhook
+
+import (
+	"e-complaint-api/constants"
+	"e-complaint-api/entities"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockWebhookRepo struct {
+	mock.Mock
+}
+
+func (m *MockWebhookRepo) Create(webhook *entities.Webhook) error {
+	args := m.Called(webhook)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepo) GetAll() ([]entities.Webhook, error) {
+	args := m.Called()
+	return args.Get(0).([]entities.Webhook), args.Error(1)
+}
+
+func (m *MockWebhookRepo) GetByID(id int) (entities.Webhook, error) {
+	args := m.Called(id)
+	return args.Get(0).(entities.Webhook), args.Error(1)
+}
+
+func (m *MockWebhookRepo) GetByEvent(event string) ([]entities.Webhook, error) {
+	args := m.Called(event)
+	return args.Get(0).([]entities.Webhook), args.Error(1)
+}
+
+func (m *MockWebhookRepo) Update(webhook *entities.Webhook) error {
+	args := m.Called(webhook)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepo) Delete(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepo) CreateDelivery(delivery *entities.WebhookDelivery) error {
+	args := m.Called(delivery)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepo) GetDeliveryByID(webhookID int, id int) (entities.WebhookDelivery, error) {
+	args := m.Called(webhookID, id)
+	return args.Get(0).(entities.WebhookDelivery), args.Error(1)
+}
+
+func (m *MockWebhookRepo) GetDeliveriesAfter(webhookID int, limit int, afterID int) ([]entities.WebhookDelivery, error) {
+	args := m.Called(webhookID, limit, afterID)
+	return args.Get(0).([]entities.WebhookDelivery), args.Error(1)
+}
+
+func (m *MockWebhookRepo) UpdateDelivery(delivery *entities.WebhookDelivery) error {
+	args := m.Called(delivery)
+	return args.Error(0)
+}
+
+type MockSender struct {
+	mock.Mock
+}
+
+func (m *MockSender) Send(url string, headers map[string]string, body []byte) (entities.WebhookResponse, error) {
+	args := m.Called(url, headers, body)
+	return args.Get(0).(entities.WebhookResponse), args.Error(1)
+}
+
+type MockJobUseCase struct {
+	mock.Mock
+}
+
+func (m *MockJobUseCase) Register(jobType string, handler entities.JobHandler) {
+	m.Called(jobType, handler)
+}
+
+func (m *MockJobUseCase) Enqueue(jobType string, createdBy *int, payload interface{}) (entities.Job, error) {
+	args := m.Called(jobType, createdBy, payload)
+	return args.Get(0).(entities.Job), args.Error(1)
+}
+
+func (m *MockJobUseCase) RunOnce() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+func (m *MockJobUseCase) GetByCursor(filter map[string]interface{}, limit int, cursor string) ([]entities.Job, string, error) {
+	args := m.Called(filter, limit, cursor)
+	return args.Get(0).([]entities.Job), args.String(1), args.Error(2)
+}
+
+func (m *MockJobUseCase) GetByID(id int) (entities.Job, error) {
+	args := m.Called(id)
+	return args.Get(0).(entities.Job), args.Error(1)
+}
+
+func (m *MockJobUseCase) GetOwn(id int, jobType string, adminID int) (entities.Job, error) {
+	args := m.Called(id, jobType, adminID)
+	return args.Get(0).(entities.Job), args.Error(1)
+}
+
+func (m *MockJobUseCase) Retry(id int) (entities.Job, error) {
+	args := m.Called(id)
+	return args.Get(0).(entities.Job), args.Error(1)
+}
+
+var (
+	errDatabase = errors.New("database error")
+	webhook     = entities.Webhook{ID: 3, URL: "https://opd.example.com/hooks", Secret: "rahasia", Events: "complaint.created", Active: true}
+	createdAt   = time.Date(2024, 6, 3, 8, 0, 0, 0, time.UTC)
+)
+
+func newUseCase(repo *MockWebhookRepo, sender *MockSender) (*WebhookUseCase, *MockJobUseCase) {
+	jobUseCase := new(MockJobUseCase)
+	jobUseCase.On("Register", constants.JobTypeWebhookDelivery, mock.Anything).Return()
+
+	return NewWebhookUseCase(repo, sender, jobUseCase), jobUseCase
+}
+
+func payload(webhookID int, deliveryID int) []byte {
+	data, _ := json.Marshal(deliveryPayload{WebhookID: webhookID, DeliveryID: deliveryID})
+	return data
+}
+
+func TestCreate(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		repo := new(MockWebhookRepo)
+		repo.On("Create", mock.MatchedBy(func(webhook *entities.Webhook) bool {
+			return webhook.URL == "https://opd.example.com/hooks" &&
+				webhook.Events == "complaint.created,evidence.uploaded" &&
+				len(webhook.Secret) == 64 &&
+				webhook.Active &&
+				webhook.CreatedBy == 2
+		})).Return(nil)
+		useCase, _ := newUseCase(repo, nil)
+
+		webhook, err := useCase.Create("https://opd.example.com/hooks", []string{constants.WebhookEventComplaintCreated, constants.WebhookEventEvidenceUploaded, constants.WebhookEventComplaintCreated}, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, "complaint.created,evidence.uploaded", webhook.Events)
+	})
+
+	t.Run("failed invalid url", func(t *testing.T) {
+		useCase, _ := newUseCase(new(MockWebhookRepo), nil)
+
+		for _, url := range []string{"opd.example.com/hooks", "ftp://opd.example.com", "https://", "://", "https://opd.example.com/" + strings.Repeat("a", 255)} {
+			_, err := useCase.Create(url, []string{constants.WebhookEventComplaintCreated}, 2)
+			assert.Equal(t, constants.ErrInvalidWebhookURL, err, url)
+		}
+	})
+
+	t.Run("failed internal address", func(t *testing.T) {
+		useCase, _ := newUseCase(new(MockWebhookRepo), nil)
+
+		for _, url := range []string{"http://localhost:8080", "http://api.localhost", "http://127.0.0.1/hooks", "http://10.0.0.5", "http://192.168.1.1", "http://169.254.169.254/latest/meta-data", "http://[::1]:9000", "http://0.0.0.0"} {
+			_, err := useCase.Create(url, []string{constants.WebhookEventComplaintCreated}, 2)
+			assert.Equal(t, constants.ErrWebhookAddressNotAllowed, err, url)
+		}
+	})
+
+	t.Run("failed no events", func(t *testing.T) {
+		useCase, _ := newUseCase(new(MockWebhookRepo), nil)
+
+		_, err := useCase.Create("https://opd.example.com/hooks", nil, 2)
+		assert.Equal(t, constants.ErrWebhookEventsRequired, err)
+	})
+
+	t.Run("failed invalid event", func(t *testing.T) {
+		useCase, _ := newUseCase(new(MockWebhookRepo), nil)
+
+		_, err := useCase.Create("https://opd.example.com/hooks", []string{"complaint.deleted"}, 2)
+		assert.Equal(t, constants.ErrInvalidWebhookEvent, err)
+	})
+
+	t.Run("failed create", func(t *testing.T) {
+		repo := new(MockWebhookRepo)
+		repo.On("Create", mock.Anything).Return(errDatabase)
+		useCase, _ := newUseCase(repo, nil)
+
+		_, err := useCase.Create("https://opd.example.com/hooks", []string{constants.WebhookEventComplaintCreated}, 2)
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+}
+
+func TestGetAll(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		repo := new(MockWebhookRepo)
+		repo.On("GetAll").Return([]entities.Webhook{webhook}, nil)
+		useCase, _ := newUseCase(repo, nil)
+
+		webhooks, err := useCase.GetAll()
+		assert.NoError(t, err)
+		assert.Equal(t, []entities.Webhook{webhook}, webhooks)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		repo := new(MockWebhookRepo)
+		repo.On("GetAll").Return([]entities.Webhook{}, errDatabase)
+		useCase, _ := newUseCase(repo, nil)
+
+		_, err := useCase.GetAll()
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+}
+
+func TestGetByID(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		repo := new(MockWebhookRepo)
+		repo.On("GetByID", 3).Return(webhook, nil)
+		useCase, _ := newUseCase(repo, nil)
+
+		result, err := useCase.GetByID(3)
+		assert.NoError(t, err)
+		assert.Equal(t, webhook, result)
+	})
+
+	t.Run("failed not found", func(t *testing.T) {
+		repo := new(MockWebhookRepo)
+		repo.On("GetByID", 3).Return(entities.Webhook{}, constants.ErrWebhookNotFound)
+		useCase, _ := newUseCase(repo, nil)
+
+		_, err := useCase.GetByID(3)
+		assert.Equal(t, constants.ErrWebhookNotFound, err)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		repo := new(MockWebhookRepo)
+		repo.On("GetByID", 3).Return(entities.Webhook{}, errDatabase)
+		useCase, _ := newUseCase(repo, nil)
+
+		_, err := useCase.GetByID(3)
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		repo := new(MockWebhookRepo)
+		repo.On("GetByID", 3).Return(webhook, nil)
+		repo.On("Update", mock.MatchedBy(func(webhook *entities.Webhook) bool {
+			return webhook.URL == "http://lapor.example.com" && webhook.Events == "discussion.created" && !webhook.Active && webhook.Secret == "rahasia"
+		})).Return(nil)
+		useCase, _ := newUseCase(repo, nil)
+
+		result, err := useCase.Update(3, "http://lapor.example.com", []string{constants.WebhookEventDiscussionCreated}, false)
+		assert.NoError(t, err)
+		assert.False(t, result.Active)
+	})
+
+	t.Run("failed invalid", func(t *testing.T) {
+		repo := new(MockWebhookRepo)
+		useCase, _ := newUseCase(repo, nil)
+
+		_, err := useCase.Update(3, "lapor", []string{constants.WebhookEventDiscussionCreated}, true)
+		assert.Equal(t, constants.ErrInvalidWebhookURL, err)
+		repo.AssertNotCalled(t, "GetByID", mock.Anything)
+	})
+
+	t.Run("failed not found", func(t *testing.T) {
+		repo := new(MockWebhookRepo)
+		repo.On("GetByID", 3).Return(entities.Webhook{}, constants.ErrWebhookNotFound)
+		useCase, _ := newUseCase(repo, nil)
+
+		_, err := useCase.Update(3, "http://lapor.example.com", []string{constants.WebhookEventDiscussionCreated}, true)
+		assert.Equal(t, constants.ErrWebhookNotFound, err)
+	})
+
+	t.Run("failed update", func(t *testing.T) {
+		repo := new(MockWebhookRepo)
+		repo.On("GetByID", 3).Return(webhook, nil)
+		repo.On("Update", mock.Anything).Return(errDatabase)
+		useCase, _ := newUseCase(repo, nil)
+
+		_, err := useCase.Update(3, "http://lapor.example.com", []string{constants.WebhookEventDiscussionCreated}, true)
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+}
+
+func TestDelete(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		repo := new(MockWebhookRepo)
+		repo.On("Delete", 3).Return(nil)
+		useCase, _ := newUseCase(repo, nil)
+
+		assert.NoError(t, useCase.Delete(3))
+	})
+
+	t.Run("failed not found", func(t *testing.T) {
+		repo := new(MockWebhookRepo)
+		repo.On("Delete", 3).Return(constants.ErrWebhookNotFound)
+		useCase, _ := newUseCase(repo, nil)
+
+		assert.Equal(t, constants.ErrWebhookNotFound, useCase.Delete(3))
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		repo := new(MockWebhookRepo)
+		repo.On("Delete", 3).Return(errDatabase)
+		useCase, _ := newUseCase(repo, nil)
+
+		assert.Equal(t, constants.ErrInternalServerError, useCase.Delete(3))
+	})
+}
+
+func TestDispatch(t *testing.T) {
+	data := map[string]string{"id": "C-123"}
+
+	t.Run("success", func(t *testing.T) {
+		other := entities.Webhook{ID: 4}
+		repo := new(MockWebhookRepo)
+		repo.On("GetByEvent", constants.WebhookEventComplaintCreated).Return([]entities.Webhook{webhook, other}, nil)
+		repo.On("CreateDelivery", mock.MatchedBy(func(delivery *entities.WebhookDelivery) bool {
+			return delivery.Event == constants.WebhookEventComplaintCreated && delivery.Payload == `{"id":"C-123"}` && delivery.Status == constants.WebhookDeliveryPending
+		})).Run(func(args mock.Arguments) {
+			delivery := args.Get(0).(*entities.WebhookDelivery)
+			delivery.ID = delivery.WebhookID * 10
+		}).Return(nil)
+		useCase, jobUseCase := newUseCase(repo, nil)
+		jobUseCase.On("Enqueue", constants.JobTypeWebhookDelivery, (*int)(nil), deliveryPayload{WebhookID: 3, DeliveryID: 30}).Return(entities.Job{}, nil)
+		jobUseCase.On("Enqueue", constants.JobTypeWebhookDelivery, (*int)(nil), deliveryPayload{WebhookID: 4, DeliveryID: 40}).Return(entities.Job{}, nil)
+
+		err := useCase.Dispatch(constants.WebhookEventComplaintCreated, data)
+		assert.NoError(t, err)
+		jobUseCase.AssertExpectations(t)
+	})
+
+	t.Run("success no webhooks", func(t *testing.T) {
+		repo := new(MockWebhookRepo)
+		repo.On("GetByEvent", constants.WebhookEventComplaintCreated).Return([]entities.Webhook{}, nil)
+		useCase, _ := newUseCase(repo, nil)
+
+		err := useCase.Dispatch(constants.WebhookEventComplaintCreated, data)
+		assert.NoError(t, err)
+		repo.AssertNotCalled(t, "CreateDelivery", mock.Anything)
+	})
+
+	t.Run("failed get webhooks", func(t *testing.T) {
+		repo := new(MockWebhookRepo)
+		repo.On("GetByEvent", constants.WebhookEventComplaintCreated).Return([]entities.Webhook{}, errDatabase)
+		useCase, _ := newUseCase(repo, nil)
+
+		err := useCase.Dispatch(constants.WebhookEventComplaintCreated, data)
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+
+	t.Run("failed invalid data", func(t *testing.T) {
+		repo := new(MockWebhookRepo)
+		repo.On("GetByEvent", constants.WebhookEventComplaintCreated).Return([]entities.Webhook{webhook}, nil)
+		useCase, _ := newUseCase(repo, nil)
+
+		err := useCase.Dispatch(constants.WebhookEventComplaintCreated, make(chan int))
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+
+	t.Run("failed create delivery", func(t *testing.T) {
+		repo := new(MockWebhookRepo)
+		repo.On("GetByEvent", constants.WebhookEventComplaintCreated).Return([]entities.Webhook{webhook}, nil)
+		repo.On("CreateDelivery", mock.Anything).Return(errDatabase)
+		useCase, _ := newUseCase(repo, nil)
+
+		err := useCase.Dispatch(constants.WebhookEventComplaintCreated, data)
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+
+	t.Run("failed enqueue", func(t *testing.T) {
+		repo := new(MockWebhookRepo)
+		repo.On("GetByEvent", constants.WebhookEventComplaintCreated).Return([]entities.Webhook{webhook}, nil)
+		repo.On("CreateDelivery", mock.Anything).Return(nil)
+		useCase, jobUseCase := newUseCase(repo, nil)
+		jobUseCase.On("Enqueue", constants.JobTypeWebhookDelivery, (*int)(nil), mock.Anything).Return(entities.Job{}, constants.ErrInternalServerError)
+
+		err := useCase.Dispatch(constants.WebhookEventComplaintCreated, data)
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+}
+
+func TestDeliver(t *testing.T) {
+	delivery := entities.WebhookDelivery{ID: 30, WebhookID: 3, Event: constants.WebhookEventComplaintCreated, Payload: `{"id":"C-123"}`, Status: constants.WebhookDeliveryPending, CreatedAt: createdAt, Webhook: webhook}
+
+	// sent matches the headers and body of the delivery, the signature is checked
+	// against the headers that were sent
+	sent := func() (interface{}, interface{}) {
+		headers := mock.MatchedBy(func(headers map[string]string) bool {
+			_, err := strconv.ParseInt(headers["X-Webhook-Timestamp"], 10, 64)
+			return err == nil &&
+				headers["Content-Type"] == "application/json" &&
+				headers["X-Webhook-Event"] == constants.WebhookEventComplaintCreated &&
+				headers["X-Webhook-Delivery"] == "30" &&
+				strings.HasPrefix(headers["X-Webhook-Signature"], "sha256=")
+		})
+		body := mock.MatchedBy(func(body []byte) bool {
+			return string(body) == `{"id":30,"event":"complaint.created","created_at":"2024-06-03T08:00:00Z","data":{"id":"C-123"}}`
+		})
+		return headers, body
+	}
+
+	t.Run("success", func(t *testing.T) {
+		repo := new(MockWebhookRepo)
+		repo.On("GetDeliveryByID", 3, 30).Return(delivery, nil)
+		repo.On("UpdateDelivery", mock.MatchedBy(func(delivery *entities.WebhookDelivery) bool {
+			return delivery.Status == constants.WebhookDeliverySuccess && delivery.Attempts == 1 && delivery.ResponseStatus == 204 && delivery.DeliveredAt != nil
+		})).Return(nil)
+		sender := new(MockSender)
+		headers, body := sent()
+		sender.On("Send", "https://opd.example.com/hooks", headers, body).Return(entities.WebhookResponse{StatusCode: 204}, nil)
+		useCase, _ := newUseCase(repo, sender)
+
+		result, err := useCase.deliver(payload(3, 30))
+		assert.NoError(t, err)
+		assert.Equal(t, "delivered with status 204", result)
+
+		arguments := sender.Calls[0].Arguments
+		sentHeaders := arguments.Get(1).(map[string]string)
+		assert.Equal(t, "sha256="+sign("rahasia", sentHeaders["X-Webhook-Timestamp"], arguments.Get(2).([]byte)), sentHeaders["X-Webhook-Signature"])
+	})
+
+	t.Run("failed response is retried", func(t *testing.T) {
+		repo := new(MockWebhookRepo)
+		repo.On("GetDeliveryByID", 3, 30).Return(delivery, nil)
+		repo.On("UpdateDelivery", mock.MatchedBy(func(delivery *entities.WebhookDelivery) bool {
+			return delivery.Status == constants.WebhookDeliveryPending && delivery.Attempts == 1 && delivery.ResponseStatus == 503 &&
+				delivery.ResponseBody == "maintenance" && delivery.LastError == "webhook responded with status 503"
+		})).Return(nil)
+		sender := new(MockSender)
+		sender.On("Send", mock.Anything, mock.Anything, mock.Anything).Return(entities.WebhookResponse{StatusCode: 503, Body: "maintenance"}, nil)
+		useCase, _ := newUseCase(repo, sender)
+
+		_, err := useCase.deliver(payload(3, 30))
+		assert.EqualError(t, err, "webhook responded with status 503")
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("failed last attempt", func(t *testing.T) {
+		retried := delivery
+		retried.Attempts = constants.JobMaxAttempts - 1
+		repo := new(MockWebhookRepo)
+		repo.On("GetDeliveryByID", 3, 30).Return(retried, nil)
+		repo.On("UpdateDelivery", mock.MatchedBy(func(delivery *entities.WebhookDelivery) bool {
+			return delivery.Status == constants.WebhookDeliveryFailed && delivery.LastError == "connection refused"
+		})).Return(nil)
+		sender := new(MockSender)
+		sender.On("Send", mock.Anything, mock.Anything, mock.Anything).Return(entities.WebhookResponse{}, errors.New("connection refused"))
+		useCase, _ := newUseCase(repo, sender)
+
+		_, err := useCase.deliver(payload(3, 30))
+		assert.EqualError(t, err, "connection refused")
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("success inactive webhook", func(t *testing.T) {
+		inactive := delivery
+		inactive.Webhook.Active = false
+		repo := new(MockWebhookRepo)
+		repo.On("GetDeliveryByID", 3, 30).Return(inactive, nil)
+		repo.On("UpdateDelivery", mock.MatchedBy(func(delivery *entities.WebhookDelivery) bool {
+			return delivery.Status == constants.WebhookDeliveryFailed && delivery.LastError == "webhook is inactive"
+		})).Return(nil)
+		sender := new(MockSender)
+		useCase, _ := newUseCase(repo, sender)
+
+		result, err := useCase.deliver(payload(3, 30))
+		assert.NoError(t, err)
+		assert.Equal(t, "webhook is inactive", result)
+		sender.AssertNotCalled(t, "Send", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("failed update inactive webhook", func(t *testing.T) {
+		inactive := delivery
+		inactive.Webhook.Active = false
+		repo := new(MockWebhookRepo)
+		repo.On("GetDeliveryByID", 3, 30).Return(inactive, nil)
+		repo.On("UpdateDelivery", mock.Anything).Return(errDatabase)
+		useCase, _ := newUseCase(repo, nil)
+
+		_, err := useCase.deliver(payload(3, 30))
+		assert.Equal(t, errDatabase, err)
+	})
+
+	t.Run("failed invalid payload", func(t *testing.T) {
+		useCase, _ := newUseCase(new(MockWebhookRepo), nil)
+
+		_, err := useCase.deliver([]byte("{"))
+		assert.Error(t, err)
+	})
+
+	t.Run("failed delivery not found", func(t *testing.T) {
+		repo := new(MockWebhookRepo)
+		repo.On("GetDeliveryByID", 3, 30).Return(entities.WebhookDelivery{}, constants.ErrWebhookDeliveryNotFound)
+		useCase, _ := newUseCase(repo, nil)
+
+		_, err := useCase.deliver(payload(3, 30))
+		assert.Equal(t, constants.ErrWebhookDeliveryNotFound, err)
+	})
+
+	t.Run("failed invalid stored payload", func(t *testing.T) {
+		broken := delivery
+		broken.Payload = "{"
+		repo := new(MockWebhookRepo)
+		repo.On("GetDeliveryByID", 3, 30).Return(broken, nil)
+		useCase, _ := newUseCase(repo, nil)
+
+		_, err := useCase.deliver(payload(3, 30))
+		assert.Error(t, err)
+	})
+
+	t.Run("failed update delivery", func(t *testing.T) {
+		repo := new(MockWebhookRepo)
+		repo.On("GetDeliveryByID", 3, 30).Return(delivery, nil)
+		repo.On("UpdateDelivery", mock.Anything).Return(errDatabase)
+		sender := new(MockSender)
+		sender.On("Send", mock.Anything, mock.Anything, mock.Anything).Return(entities.WebhookResponse{StatusCode: 200}, nil)
+		useCase, _ := newUseCase(repo, sender)
+
+		_, err := useCase.deliver(payload(3, 30))
+		assert.Equal(t, errDatabase, err)
+	})
+}
+
+func TestSign(t *testing.T) {
+	// printf '1717401600.{}' | openssl dgst -sha256 -hmac rahasia
+	assert.Equal(t, "d38e904f5a0dbe6215ae4eb31c16ed4400f0b3593c3e52ddbf3ee61d3395a2b3", sign("rahasia", "1717401600", []byte("{}")))
+	assert.NotEqual(t, sign("rahasia", "1717401600", []byte("{}")), sign("lain", "1717401600", []byte("{}")))
+	assert.NotEqual(t, sign("rahasia", "1717401600", []byte("{}")), sign("rahasia", "1717401601", []byte("{}")))
+}
+
+func TestGetDeliveries(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		repo := new(MockWebhookRepo)
+		repo.On("GetByID", 3).Return(webhook, nil)
+		repo.On("GetDeliveriesAfter", 3, 3, 0).Return([]entities.WebhookDelivery{{ID: 9}, {ID: 8}, {ID: 7}}, nil)
+		useCase, _ := newUseCase(repo, nil)
+
+		deliveries, next, err := useCase.GetDeliveries(3, 2, "")
+		assert.NoError(t, err)
+		assert.Equal(t, []entities.WebhookDelivery{{ID: 9}, {ID: 8}}, deliveries)
+		assert.NotEmpty(t, next)
+	})
+
+	t.Run("failed webhook not found", func(t *testing.T) {
+		repo := new(MockWebhookRepo)
+		repo.On("GetByID", 3).Return(entities.Webhook{}, constants.ErrWebhookNotFound)
+		useCase, _ := newUseCase(repo, nil)
+
+		_, _, err := useCase.GetDeliveries(3, 2, "")
+		assert.Equal(t, constants.ErrWebhookNotFound, err)
+	})
+
+	t.Run("failed invalid cursor", func(t *testing.T) {
+		repo := new(MockWebhookRepo)
+		repo.On("GetByID", 3).Return(webhook, nil)
+		useCase, _ := newUseCase(repo, nil)
+
+		_, _, err := useCase.GetDeliveries(3, 2, "invalid")
+		assert.Equal(t, constants.ErrInvalidCursor, err)
+	})
+
+	t.Run("failed get deliveries", func(t *testing.T) {
+		repo := new(MockWebhookRepo)
+		repo.On("GetByID", 3).Return(webhook, nil)
+		repo.On("GetDeliveriesAfter", 3, 3, 0).Return([]entities.WebhookDelivery{}, errDatabase)
+		useCase, _ := newUseCase(repo, nil)
+
+		_, _, err := useCase.GetDeliveries(3, 2, "")
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+}
+
+func TestReplay(t *testing.T) {
+	original := entities.WebhookDelivery{ID: 30, WebhookID: 3, Event: constants.WebhookEventComplaintCreated, Payload: `{"id":"C-123"}`, Status: constants.WebhookDeliveryFailed, Attempts: 5, Webhook: webhook}
+
+	t.Run("success", func(t *testing.T) {
+		repo := new(MockWebhookRepo)
+		repo.On("GetDeliveryByID", 3, 30).Return(original, nil)
+		repo.On("CreateDelivery", mock.MatchedBy(func(delivery *entities.WebhookDelivery) bool {
+			return delivery.WebhookID == 3 && delivery.Event == constants.WebhookEventComplaintCreated && delivery.Payload == `{"id":"C-123"}` &&
+				delivery.Status == constants.WebhookDeliveryPending && delivery.Attempts == 0 && *delivery.ReplayOf == 30
+		})).Run(func(args mock.Arguments) { args.Get(0).(*entities.WebhookDelivery).ID = 31 }).Return(nil)
+		useCase, jobUseCase := newUseCase(repo, nil)
+		jobUseCase.On("Enqueue", constants.JobTypeWebhookDelivery, (*int)(nil), deliveryPayload{WebhookID: 3, DeliveryID: 31}).Return(entities.Job{}, nil)
+
+		delivery, err := useCase.Replay(3, 30)
+		assert.NoError(t, err)
+		assert.Equal(t, 31, delivery.ID)
+	})
+
+	t.Run("failed not found", func(t *testing.T) {
+		repo := new(MockWebhookRepo)
+		repo.On("GetDeliveryByID", 3, 30).Return(entities.WebhookDelivery{}, constants.ErrWebhookDeliveryNotFound)
+		useCase, _ := newUseCase(repo, nil)
+
+		_, err := useCase.Replay(3, 30)
+		assert.Equal(t, constants.ErrWebhookDeliveryNotFound, err)
+	})
+
+	t.Run("failed get delivery", func(t *testing.T) {
+		repo := new(MockWebhookRepo)
+		repo.On("GetDeliveryByID", 3, 30).Return(entities.WebhookDelivery{}, errDatabase)
+		useCase, _ := newUseCase(repo, nil)
+
+		_, err := useCase.Replay(3, 30)
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+
+	t.Run("failed queue", func(t *testing.T) {
+		repo := new(MockWebhookRepo)
+		repo.On("GetDeliveryByID", 3, 30).Return(original, nil)
+		repo.On("CreateDelivery", mock.Anything).Return(errDatabase)
+		useCase, _ := newUseCase(repo, nil)
+
+		_, err := useCase.Replay(3, 30)
+		assert.Equal(t, constants.ErrInternalServerError, err)
+	})
+}
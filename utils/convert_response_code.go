@@ -26,6 +26,7 @@ func ConvertResponseCode(err error) int {
 		constants.ErrInvalidStatus,
 		constants.ErrIDMustBeFilled,
 		constants.ErrComplaintProcessCannotBeDeleted,
+		constants.ErrComplaintProcessCannotBeUpdated,
 		constants.ErrEmailOrUsernameAlreadyExists,
 		constants.ErrNoChangesDetected,
 		constants.ErrCommentCannotBeEmpty,
@@ -42,6 +43,54 @@ func ConvertResponseCode(err error) int {
 		constants.ErrEmailNotRegistered,
 		constants.ErrPasswordMustBeAtLeast8Characters,
 		constants.ErrCategoryHasBeenUsed,
+		constants.ErrInvalidStatusTransition,
+		constants.ErrComplaintSLAAlreadyExists,
+		constants.ErrTargetDaysMustBePositive,
+		constants.ErrComplaintAlreadyAssigned,
+		constants.ErrAdminCannotProcessComplaints,
+		constants.ErrOutsideAdminScope,
+		constants.ErrRoleAlreadyExists,
+		constants.ErrInvalidPermission,
+		constants.ErrImageDimensionsTooLarge,
+		constants.ErrInvalidCoordinates,
+		constants.ErrInvalidGeometry,
+		constants.ErrInvalidRadius,
+		constants.ErrLocationOutsideRegency,
+		constants.ErrRegencyHasNoBoundary,
+		constants.ErrComplaintAlreadyMerged,
+		constants.ErrCannotMergeComplaintIntoItself,
+		constants.ErrCannotMergePrivateIntoPublic,
+		constants.ErrMergedStatusNotAllowed,
+		constants.ErrInvalidSearchCollection,
+		constants.ErrSearchQueryMustBeFilled,
+		constants.ErrInvalidCursor,
+		constants.ErrInvalidFilterValue,
+		constants.ErrInvalidRange,
+		constants.ErrInvalidSortBy,
+		constants.ErrInvalidSortType,
+		constants.ErrInvalidExportFormat,
+		constants.ErrImportFileMustBeFilled,
+		constants.ErrInvalidImportMode,
+		constants.ErrImportHasInvalidRows,
+		constants.ErrInvalidComplaintType,
+		constants.ErrInvalidDateFormat,
+		constants.ErrImportKeyTooLong,
+		constants.ErrJobNotRetryable,
+		constants.ErrInvalidJobStatus,
+		constants.ErrExportNotReady,
+		constants.ErrInvalidEmailEvent,
+		constants.ErrRoomNameRequired,
+		constants.ErrRoomMemberRequired,
+		constants.ErrRoomNotJoinable,
+		constants.ErrMessageRequired,
+		constants.ErrInvalidChatEvent,
+		constants.ErrRoomClosed,
+		constants.ErrInvalidRoomStatus,
+		constants.ErrInvalidLastEventID,
+		constants.ErrInvalidWebhookURL,
+		constants.ErrWebhookAddressNotAllowed,
+		constants.ErrInvalidWebhookEvent,
+		constants.ErrWebhookEventsRequired,
 	}
 
 	var notFoundErrors = []error{
@@ -53,13 +102,42 @@ func ConvertResponseCode(err error) int {
 		constants.ErrNewsNotFound,
 		constants.ErrUserNotFound,
 		constants.ErrNotFound,
+		constants.ErrNotificationNotFound,
+		constants.ErrComplaintSLANotFound,
+		constants.ErrAssignmentPoolNotFound,
+		constants.ErrRoleNotFound,
+		constants.ErrFileNotFound,
+		constants.ErrJobNotFound,
+		constants.ErrRoomNotFound,
+		constants.ErrWebhookNotFound,
+		constants.ErrWebhookDeliveryNotFound,
+		constants.ErrMessageNotFound,
+	}
+
+	var forbiddenErrors = []error{
+		constants.ErrComplaintNotAssignedToYou,
+		constants.ErrForbidden,
+		constants.ErrComplaintOutOfScope,
+		constants.ErrInvalidDownloadURL,
+		constants.ErrDownloadURLExpired,
+		constants.ErrNotRoomMember,
+		constants.ErrFilterNotAllowed,
+	}
+
+	var unauthorizedErrors = []error{
+		constants.ErrUnauthorized,
+		constants.ErrInvalidRefreshToken,
+		constants.ErrRefreshTokenExpired,
+		constants.ErrSessionRevoked,
 	}
 
 	if contains(badRequestErrors, err) {
 		return http.StatusBadRequest
 	} else if contains(notFoundErrors, err) {
 		return http.StatusNotFound
-	} else if err == constants.ErrUnauthorized {
+	} else if contains(forbiddenErrors, err) {
+		return http.StatusForbidden
+	} else if contains(unauthorizedErrors, err) {
 		return http.StatusUnauthorized
 	} else {
 		return http.StatusInternalServerError
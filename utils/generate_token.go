@@ -0,0 +1,18 @@
+package utils
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// GenerateToken returns a random hex string made of length random bytes, used for
+// opaque tokens such as refresh tokens.
+func GenerateToken(length int) string {
+	randomBytes := make([]byte, length)
+	_, err := rand.Read(randomBytes)
+	if err != nil {
+		panic(err)
+	}
+
+	return hex.EncodeToString(randomBytes)
+}
@@ -0,0 +1,33 @@
+package utils
+
+import (
+	"e-complaint-api/constants"
+	"encoding/csv"
+	"io"
+	"mime/multipart"
+)
+
+func GetRowsFromCSV(file *multipart.FileHeader) ([][]string, error) {
+	f, err := file.Open()
+	if err != nil {
+		return nil, constants.ErrInternalServerError
+	}
+	defer f.Close()
+
+	return GetRowsFromCSVReader(f)
+}
+
+// GetRowsFromCSVReader is GetRowsFromCSV for a file that is not an upload, like one
+// read back from storage.
+func GetRowsFromCSVReader(r io.Reader) ([][]string, error) {
+	reader := csv.NewReader(r)
+	// rows may leave out trailing empty columns, as spreadsheet exports often do
+	reader.FieldsPerRecord = -1
+
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, constants.ErrInvalidFileFormat
+	}
+
+	return rows, nil
+}
@@ -4,7 +4,6 @@ import (
 	"e-complaint-api/constants"
 	"io"
 	"mime/multipart"
-	"os"
 
 	"github.com/xuri/excelize/v2"
 )
@@ -16,18 +15,13 @@ func GetRowsFromExcel(file *multipart.FileHeader) ([][]string, error) {
 	}
 	defer f.Close()
 
-	tempFile, err := os.CreateTemp("", "uploaded-*.xlsx")
-	if err != nil {
-		return nil, constants.ErrInternalServerError
-	}
-	defer os.Remove(tempFile.Name())
-	defer tempFile.Close()
-
-	if _, err := io.Copy(tempFile, f); err != nil {
-		return nil, constants.ErrInternalServerError
-	}
+	return GetRowsFromExcelReader(f)
+}
 
-	excelFile, err := excelize.OpenFile(tempFile.Name())
+// GetRowsFromExcelReader is GetRowsFromExcel for a file that is not an upload, like
+// one read back from storage.
+func GetRowsFromExcelReader(r io.Reader) ([][]string, error) {
+	excelFile, err := excelize.OpenReader(r)
 	if err != nil {
 		return nil, constants.ErrInternalServerError
 	}
@@ -1,8 +0,0 @@
-package utils
-
-import "strings"
-
-func GetToken(auth string) string {
-	splittedAuth := strings.Split(auth, "Bearer ")
-	return splittedAuth[1]
-}
@@ -0,0 +1,25 @@
+package utils
+
+import (
+	"e-complaint-api/constants"
+	"e-complaint-api/entities"
+
+	"github.com/labstack/echo/v4"
+)
+
+const principalContextKey = "principal"
+
+func SetPrincipal(c echo.Context, principal entities.Principal) {
+	c.Set(principalContextKey, principal)
+}
+
+// GetPrincipal returns the account stored by the JWT middleware. Routes that are not
+// behind the middleware have no principal and are treated as unauthorized.
+func GetPrincipal(c echo.Context) (entities.Principal, error) {
+	principal, ok := c.Get(principalContextKey).(entities.Principal)
+	if !ok {
+		return entities.Principal{}, constants.ErrUnauthorized
+	}
+
+	return principal, nil
+}
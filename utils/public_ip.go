@@ -0,0 +1,22 @@
+package utils
+
+import "net"
+
+// sharedAddressSpace is the carrier-grade NAT range of RFC 6598, which net.IP does not
+// count as private.
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// IsPublicIP tells whether ip is reachable on the internet, as opposed to loopback,
+// private, link-local (like the cloud metadata address 169.254.169.254), multicast
+// and unspecified addresses.
+func IsPublicIP(ip net.IP) bool {
+	return ip != nil &&
+		!ip.IsLoopback() &&
+		!ip.IsPrivate() &&
+		!ip.IsLinkLocalUnicast() &&
+		!ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() &&
+		!ip.IsMulticast() &&
+		!ip.IsUnspecified() &&
+		!sharedAddressSpace.Contains(ip)
+}
@@ -0,0 +1,49 @@
+package workflow
+
+import "e-complaint-api/constants"
+
+const (
+	StatusPending    = "Pending"
+	StatusVerifikasi = "Verifikasi"
+	StatusOnProgress = "On Progress"
+	StatusSelesai    = "Selesai"
+	StatusDitolak    = "Ditolak"
+	StatusDigabung   = "Digabung"
+)
+
+// Complaint is the workflow every complaint goes through. New statuses (e.g.
+// "Dialihkan") only need to be registered here together with their
+// transitions.
+var Complaint = New(
+	StatusPending,
+	[]Status{
+		{Name: StatusPending},
+		{Name: StatusVerifikasi, Previous: StatusPending, ErrReached: constants.ErrComplaintAlreadyVerified},
+		{Name: StatusOnProgress, Previous: StatusVerifikasi, ErrReached: constants.ErrComplaintAlreadyOnProgress},
+		{Name: StatusSelesai, Previous: StatusOnProgress, ErrReached: constants.ErrComplaintAlreadyFinished, Final: true},
+		{Name: StatusDitolak, Previous: StatusPending, ErrReached: constants.ErrComplaintAlreadyRejected, Final: true},
+		// Digabung has no previous status, a complaint only ends up in it when it is
+		// merged into another complaint and never through an import.
+		{Name: StatusDigabung, ErrReached: constants.ErrComplaintAlreadyMerged, Final: true},
+	},
+	[]Transition{
+		{From: "", To: StatusPending, DefaultMessage: "Aduan anda sedang dalam proses verifikasi oleh admin kami"},
+		{From: StatusPending, To: StatusPending, DefaultMessage: "Aduan anda sedang dalam proses verifikasi oleh admin kami"},
+		{From: StatusVerifikasi, To: StatusPending, DefaultMessage: "Aduan anda sedang dalam proses verifikasi oleh admin kami"},
+		{From: StatusDitolak, To: StatusPending, DefaultMessage: "Aduan anda sedang dalam proses verifikasi oleh admin kami"},
+		{From: StatusPending, To: StatusVerifikasi, DefaultMessage: "Aduan anda telah diverifikasi oleh admin kami"},
+		{From: StatusVerifikasi, To: StatusOnProgress, DefaultMessage: "Aduan anda sedang dalam proses penanganan"},
+		{From: StatusOnProgress, To: StatusSelesai, DefaultMessage: "Aduan anda telah selesai ditangani"},
+		{From: StatusPending, To: StatusDitolak, DefaultMessage: "Aduan anda ditolak karena tidak sesuai dengan ketentuan yang berlaku"},
+		{From: StatusPending, To: StatusDigabung, DefaultMessage: "Aduan anda digabungkan dengan aduan lain yang melaporkan masalah yang sama"},
+		{From: StatusVerifikasi, To: StatusDigabung, DefaultMessage: "Aduan anda digabungkan dengan aduan lain yang melaporkan masalah yang sama"},
+		{From: StatusOnProgress, To: StatusDigabung, DefaultMessage: "Aduan anda digabungkan dengan aduan lain yang melaporkan masalah yang sama"},
+	},
+	[]Guard{
+		{From: StatusOnProgress, To: StatusPending, Err: constants.ErrComplaintNotVerified},
+		{From: StatusSelesai, To: StatusPending, Err: constants.ErrComplaintNotVerified},
+		{From: StatusPending, To: StatusOnProgress, Err: constants.ErrComplaintNotVerified},
+		{From: StatusPending, To: StatusSelesai, Err: constants.ErrComplaintNotVerified},
+		{From: StatusVerifikasi, To: StatusSelesai, Err: constants.ErrComplaintNotOnProgress},
+	},
+)
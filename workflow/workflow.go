@@ -0,0 +1,165 @@
+package workflow
+
+import "e-complaint-api/constants"
+
+// Status describes a single state a record can be in.
+type Status struct {
+	Name string
+	// Previous is the status a record usually comes from. It defines the path
+	// used when a record is created directly in this status (e.g. on import).
+	Previous string
+	// ErrReached is returned when a record already in this status is moved to
+	// a status that has no allowed transition or guard for it.
+	ErrReached error
+	// Final marks a status in which the record needs no further handling.
+	Final bool
+}
+
+// Transition is an allowed move between two statuses. An empty From marks the
+// transition that creates the record in the initial status.
+type Transition struct {
+	From           string
+	To             string
+	DefaultMessage string
+}
+
+// Guard rejects a move between two statuses with a specific error.
+type Guard struct {
+	From string
+	To   string
+	Err  error
+}
+
+type Workflow struct {
+	initial     string
+	names       []string
+	statuses    map[string]Status
+	transitions map[string]map[string]Transition
+	guards      map[string]map[string]error
+}
+
+func New(initial string, statuses []Status, transitions []Transition, guards []Guard) *Workflow {
+	w := &Workflow{
+		initial:     initial,
+		statuses:    make(map[string]Status),
+		transitions: make(map[string]map[string]Transition),
+		guards:      make(map[string]map[string]error),
+	}
+
+	for _, status := range statuses {
+		w.names = append(w.names, status.Name)
+		w.statuses[status.Name] = status
+	}
+
+	for _, transition := range transitions {
+		if w.transitions[transition.From] == nil {
+			w.transitions[transition.From] = make(map[string]Transition)
+		}
+		w.transitions[transition.From][transition.To] = transition
+	}
+
+	for _, guard := range guards {
+		if w.guards[guard.From] == nil {
+			w.guards[guard.From] = make(map[string]error)
+		}
+		w.guards[guard.From][guard.To] = guard.Err
+	}
+
+	return w
+}
+
+func (w *Workflow) Initial() string {
+	return w.initial
+}
+
+func (w *Workflow) Statuses() []string {
+	return w.names
+}
+
+// FinalStatuses returns the statuses that need no further handling.
+func (w *Workflow) FinalStatuses() []string {
+	var final []string
+	for _, name := range w.names {
+		if w.statuses[name].Final {
+			final = append(final, name)
+		}
+	}
+
+	return final
+}
+
+// EnsureNotFinal returns the error of status when it is final, a record in it needs no
+// further handling.
+func (w *Workflow) EnsureNotFinal(status string) error {
+	if s, ok := w.statuses[status]; ok && s.Final {
+		return s.ErrReached
+	}
+
+	return nil
+}
+
+func (w *Workflow) IsValid(status string) bool {
+	_, ok := w.statuses[status]
+	return ok
+}
+
+// Validate checks whether a record in status from may be moved to status to.
+func (w *Workflow) Validate(from string, to string) error {
+	if !w.IsValid(to) {
+		return constants.ErrInvalidStatus
+	}
+
+	if _, ok := w.transitions[from][to]; ok {
+		return nil
+	}
+
+	if err, ok := w.guards[from][to]; ok {
+		return err
+	}
+
+	if status, ok := w.statuses[from]; ok && status.ErrReached != nil {
+		return status.ErrReached
+	}
+
+	return constants.ErrInvalidStatusTransition
+}
+
+func (w *Workflow) DefaultMessage(from string, to string) string {
+	return w.transitions[from][to].DefaultMessage
+}
+
+// Previous returns the status a record falls back to when it leaves status,
+// or status itself when it has nowhere to fall back to.
+func (w *Workflow) Previous(status string) string {
+	if previous := w.statuses[status].Previous; previous != "" {
+		return previous
+	}
+
+	return status
+}
+
+// Path returns the transitions a new record goes through to end up in status,
+// starting with the creation of the record in the initial status.
+func (w *Workflow) Path(status string) ([]Transition, error) {
+	if !w.IsValid(status) {
+		return nil, constants.ErrInvalidStatus
+	}
+
+	var path []Transition
+	for current := status; current != w.initial; current = w.statuses[current].Previous {
+		previous := w.statuses[current].Previous
+		transition, ok := w.transitions[previous][current]
+		if !ok || len(path) == len(w.names) {
+			return nil, constants.ErrInvalidStatusTransition
+		}
+
+		path = append([]Transition{transition}, path...)
+	}
+
+	initial, ok := w.transitions[""][w.initial]
+	if !ok {
+		initial = Transition{To: w.initial}
+	}
+
+	return append([]Transition{initial}, path...), nil
+}
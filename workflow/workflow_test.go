@@ -0,0 +1,106 @@
+package workflow
+
+import (
+	"e-complaint-api/constants"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		assert.NoError(t, Complaint.Validate(StatusPending, StatusVerifikasi))
+		assert.NoError(t, Complaint.Validate(StatusVerifikasi, StatusOnProgress))
+		assert.NoError(t, Complaint.Validate(StatusOnProgress, StatusSelesai))
+		assert.NoError(t, Complaint.Validate(StatusPending, StatusDitolak))
+		assert.NoError(t, Complaint.Validate(StatusOnProgress, StatusDigabung))
+	})
+
+	t.Run("failed invalid status", func(t *testing.T) {
+		assert.Equal(t, constants.ErrInvalidStatus, Complaint.Validate(StatusPending, "Invalid"))
+	})
+
+	t.Run("failed guarded transition", func(t *testing.T) {
+		assert.Equal(t, constants.ErrComplaintNotOnProgress, Complaint.Validate(StatusVerifikasi, StatusSelesai))
+	})
+
+	t.Run("failed status already reached", func(t *testing.T) {
+		assert.Equal(t, constants.ErrComplaintAlreadyRejected, Complaint.Validate(StatusDitolak, StatusOnProgress))
+		assert.Equal(t, constants.ErrComplaintAlreadyMerged, Complaint.Validate(StatusDigabung, StatusPending))
+	})
+
+	t.Run("failed unknown transition", func(t *testing.T) {
+		workflow := New("A", []Status{{Name: "A"}, {Name: "B"}}, nil, nil)
+
+		assert.Equal(t, constants.ErrInvalidStatusTransition, workflow.Validate("A", "B"))
+	})
+}
+
+func TestDefaultMessage(t *testing.T) {
+	assert.Equal(t, "Aduan anda telah diverifikasi oleh admin kami", Complaint.DefaultMessage(StatusPending, StatusVerifikasi))
+	assert.Empty(t, Complaint.DefaultMessage(StatusSelesai, StatusPending))
+}
+
+func TestPrevious(t *testing.T) {
+	assert.Equal(t, StatusOnProgress, Complaint.Previous(StatusSelesai))
+	assert.Equal(t, StatusPending, Complaint.Previous(StatusDitolak))
+	assert.Equal(t, StatusPending, Complaint.Previous(StatusPending))
+}
+
+func TestFinalStatuses(t *testing.T) {
+	assert.Equal(t, []string{StatusSelesai, StatusDitolak, StatusDigabung}, Complaint.FinalStatuses())
+}
+
+func TestEnsureNotFinal(t *testing.T) {
+	assert.NoError(t, Complaint.EnsureNotFinal(StatusOnProgress))
+	assert.Equal(t, constants.ErrComplaintAlreadyFinished, Complaint.EnsureNotFinal(StatusSelesai))
+	assert.Equal(t, constants.ErrComplaintAlreadyRejected, Complaint.EnsureNotFinal(StatusDitolak))
+	assert.Equal(t, constants.ErrComplaintAlreadyMerged, Complaint.EnsureNotFinal(StatusDigabung))
+}
+
+func TestPath(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		path, err := Complaint.Path(StatusSelesai)
+
+		assert.NoError(t, err)
+		assert.Len(t, path, 4)
+		assert.Equal(t, StatusPending, path[0].To)
+		assert.Equal(t, StatusSelesai, path[3].To)
+	})
+
+	t.Run("success with custom status", func(t *testing.T) {
+		workflow := New(
+			StatusPending,
+			[]Status{{Name: StatusPending}, {Name: "Dialihkan", Previous: StatusPending}},
+			[]Transition{{From: StatusPending, To: "Dialihkan", DefaultMessage: "Aduan anda dialihkan"}},
+			nil,
+		)
+
+		path, err := workflow.Path("Dialihkan")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []Transition{{To: StatusPending}, {From: StatusPending, To: "Dialihkan", DefaultMessage: "Aduan anda dialihkan"}}, path)
+		assert.Equal(t, []string{StatusPending, "Dialihkan"}, workflow.Statuses())
+		assert.Equal(t, StatusPending, workflow.Initial())
+	})
+
+	t.Run("failed invalid status", func(t *testing.T) {
+		_, err := Complaint.Path("Invalid")
+
+		assert.Equal(t, constants.ErrInvalidStatus, err)
+	})
+
+	t.Run("failed merged status", func(t *testing.T) {
+		_, err := Complaint.Path(StatusDigabung)
+
+		assert.Equal(t, constants.ErrInvalidStatusTransition, err)
+	})
+
+	t.Run("failed missing transition", func(t *testing.T) {
+		workflow := New(StatusPending, []Status{{Name: StatusPending}, {Name: "Dialihkan", Previous: StatusPending}}, nil, nil)
+
+		_, err := workflow.Path("Dialihkan")
+
+		assert.Equal(t, constants.ErrInvalidStatusTransition, err)
+	})
+}